@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	_ "time/tzdata"
 
 	"github.com/lzimin05/course-todo/config"
 	_ "github.com/lzimin05/course-todo/docs"
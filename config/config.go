@@ -6,17 +6,27 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DBConfig         *DBConfig
-	ServerConfig     *ServerConfig
-	JWTConfig        *JWTConfig
-	MigrationsConfig *MigrationsConfig
-	RedisConfig      *RedisConfig
+	DBConfig           *DBConfig
+	ServerConfig       *ServerConfig
+	JWTConfig          *JWTConfig
+	MigrationsConfig   *MigrationsConfig
+	RedisConfig        *RedisConfig
+	StorageConfig      *StorageConfig
+	WebhookConfig      *WebhookConfig
+	OutboxConfig       *OutboxConfig
+	LiveConfig         *LiveConfig
+	NotificationConfig *NotificationConfig
+	ReminderConfig     *ReminderConfig
+	MailConfig         *MailConfig
+	MentionConfig      *MentionConfig
+	ImportConfig       *ImportConfig
 }
 
 type DBConfig struct {
@@ -32,6 +42,8 @@ type DBConfig struct {
 
 type ServerConfig struct {
 	Port string
+	// PublicURL - внешний адрес сервиса, из него строятся ссылки на календарные фиды
+	PublicURL string
 }
 
 type JWTConfig struct {
@@ -43,6 +55,135 @@ type MigrationsConfig struct {
 	Path string
 }
 
+const (
+	StorageBackendLocal = "local"
+	StorageBackendS3    = "s3"
+)
+
+// StorageConfig описывает хранилище вложений и лимиты на их размер
+type StorageConfig struct {
+	Backend      string
+	LocalPath    string
+	PublicURL    string
+	SigningKey   string
+	MaxFileSize  int64
+	ProjectQuota int64
+	URLLifeSpan  time.Duration
+	S3           *S3Config
+}
+
+// S3Config - параметры S3-совместимого хранилища (AWS S3, MinIO)
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool
+}
+
+// WebhookConfig - параметры очереди доставок вебхуков. Пауза перед n-й
+// повторной попыткой - RetryBase * 2^(n-1), но не больше RetryMax.
+// AllowPrivateNetworks снимает запрет на адреса во внутренних сетях,
+// включается только для локальной отладки
+type WebhookConfig struct {
+	MaxAttempts          int
+	RetryBase            time.Duration
+	RetryMax             time.Duration
+	Timeout              time.Duration
+	PollInterval         time.Duration
+	BatchSize            int
+	AllowPrivateNetworks bool
+}
+
+const (
+	OutboxSinkBus   = "bus"
+	OutboxSinkRedis = "redis"
+)
+
+// OutboxConfig - параметры ретранслятора исходящих событий. Sinks - куда
+// публикуются события: bus - шина внутри процесса (на ней подписаны вебхуки),
+// redis - поток Redis Streams для внешних потребителей
+type OutboxConfig struct {
+	Sinks          []string
+	PollInterval   time.Duration
+	BatchSize      int
+	Lease          time.Duration
+	PublishTimeout time.Duration
+	RetryBase      time.Duration
+	RetryMax       time.Duration
+	Retention      time.Duration
+	RedisStream    string
+	StreamMaxLen   int64
+}
+
+// LiveConfig - параметры подписки на изменения (SSE и WebSocket). События
+// рассылаются между экземплярами через канал Redis pub/sub Channel
+type LiveConfig struct {
+	Channel     string
+	Heartbeat   time.Duration
+	BufferSize  int
+	ReplayLimit int
+}
+
+// NotificationConfig - прочитанные уведомления старше Retention удаляются
+// фоновой задачей раз в CleanupInterval
+type NotificationConfig struct {
+	Retention       time.Duration
+	CleanupInterval time.Duration
+}
+
+// ReminderConfig - параметры планировщика напоминаний. Напоминание о задаче
+// приходит за каждый из Offsets до срока, ежедневная сводка - в DigestHour
+// по часовому поясу пользователя. Каждое задание в один момент выполняет
+// только один экземпляр сервиса: он держит блокировку в Redis не дольше LockTTL
+type ReminderConfig struct {
+	Offsets      []time.Duration
+	PollInterval time.Duration
+	LockTTL      time.Duration
+	LockPrefix   string
+	DigestHour   int
+}
+
+const (
+	MailBackendLog  = "log"
+	MailBackendSMTP = "smtp"
+)
+
+// MailConfig - параметры отправки писем. Backend log только пишет письма
+// в лог и подходит для локального запуска
+type MailConfig struct {
+	Backend  string
+	From     string
+	Host     string
+	Port     string
+	User     string
+	Password string
+}
+
+const (
+	MentionNonMembersIgnore = "ignore"
+	MentionNonMembersReject = "reject"
+)
+
+// MentionConfig - NonMembers задает, что делать с упоминанием пользователя не
+// из проекта: ignore - сохранить текст без упоминания, reject - отклонить изменение
+type MentionConfig struct {
+	NonMembers string
+}
+
+// ImportConfig - параметры импорта задач. Файл не больше MaxFileSize и не больше
+// MaxItems записей. Фоновый обработчик проверяет очередь раз в PollInterval;
+// задание, которое не продвигалось дольше StaleAfter, считается брошенным
+// и запускается заново. Завершенные задания удаляются через Retention
+type ImportConfig struct {
+	MaxFileSize  int64
+	MaxItems     int
+	PollInterval time.Duration
+	StaleAfter   time.Duration
+	Retention    time.Duration
+}
+
 type RedisConfig struct {
 	Host     string
 	Port     string
@@ -80,12 +221,66 @@ func NewConfig() (*Config, error) {
 		return nil, err
 	}
 
+	storageConfig, err := newStorageConfig(serverConfig, JWTConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookConfig, err := newWebhookConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	outboxConfig, err := newOutboxConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	liveConfig, err := newLiveConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	notificationConfig, err := newNotificationConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	reminderConfig, err := newReminderConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	mailConfig, err := newMailConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	mentionConfig, err := newMentionConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	importConfig, err := newImportConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
-		DBConfig:         dbConfig,
-		ServerConfig:     serverConfig,
-		JWTConfig:        JWTConfig,
-		MigrationsConfig: migrationsConfig,
-		RedisConfig:      redisConfig,
+		DBConfig:           dbConfig,
+		ServerConfig:       serverConfig,
+		JWTConfig:          JWTConfig,
+		MigrationsConfig:   migrationsConfig,
+		RedisConfig:        redisConfig,
+		StorageConfig:      storageConfig,
+		WebhookConfig:      webhookConfig,
+		OutboxConfig:       outboxConfig,
+		LiveConfig:         liveConfig,
+		NotificationConfig: notificationConfig,
+		ReminderConfig:     reminderConfig,
+		MailConfig:         mailConfig,
+		MentionConfig:      mentionConfig,
+		ImportConfig:       importConfig,
 	}, nil
 }
 
@@ -124,7 +319,8 @@ func newServerConfig() (*ServerConfig, error) {
 	}
 
 	return &ServerConfig{
-		Port: port,
+		Port:      port,
+		PublicURL: strings.TrimRight(getEnvDefault("SERVER_PUBLIC_URL", "http://localhost:"+port), "/"),
 	}, nil
 }
 
@@ -196,6 +392,419 @@ func newRedisConfig() (*RedisConfig, error) {
 	}, nil
 }
 
+// newStorageConfig читает настройки вложений. Все параметры необязательны:
+// по умолчанию файлы хранятся на локальном диске
+func newStorageConfig(server *ServerConfig, jwt *JWTConfig) (*StorageConfig, error) {
+	cfg := &StorageConfig{
+		Backend:      getEnvDefault("STORAGE_BACKEND", StorageBackendLocal),
+		LocalPath:    getEnvDefault("STORAGE_LOCAL_PATH", "./data/attachments"),
+		PublicURL:    strings.TrimRight(getEnvDefault("STORAGE_PUBLIC_URL", "http://localhost:"+server.Port), "/"),
+		SigningKey:   getEnvDefault("STORAGE_SIGNING_KEY", jwt.Signature),
+		MaxFileSize:  25 << 20,
+		ProjectQuota: 1 << 30,
+		URLLifeSpan:  15 * time.Minute,
+	}
+
+	if v, ok := os.LookupEnv("ATTACHMENT_MAX_SIZE"); ok {
+		size, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || size <= 0 {
+			return nil, errors.New("invalid ATTACHMENT_MAX_SIZE value")
+		}
+		cfg.MaxFileSize = size
+	}
+
+	if v, ok := os.LookupEnv("ATTACHMENT_PROJECT_QUOTA"); ok {
+		quota, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || quota <= 0 {
+			return nil, errors.New("invalid ATTACHMENT_PROJECT_QUOTA value")
+		}
+		cfg.ProjectQuota = quota
+	}
+
+	if v, ok := os.LookupEnv("ATTACHMENT_URL_LIFESPAN"); ok {
+		lifespan, err := time.ParseDuration(v)
+		if err != nil || lifespan <= 0 {
+			return nil, errors.New("invalid ATTACHMENT_URL_LIFESPAN value")
+		}
+		cfg.URLLifeSpan = lifespan
+	}
+
+	switch cfg.Backend {
+	case StorageBackendLocal:
+	case StorageBackendS3:
+		s3, err := newS3Config()
+		if err != nil {
+			return nil, err
+		}
+		cfg.S3 = s3
+	default:
+		return nil, errors.New("STORAGE_BACKEND must be local or s3")
+	}
+
+	return cfg, nil
+}
+
+// newWebhookConfig читает настройки доставки вебхуков, все они необязательны
+func newWebhookConfig() (*WebhookConfig, error) {
+	cfg := &WebhookConfig{
+		MaxAttempts:  8,
+		RetryBase:    30 * time.Second,
+		RetryMax:     6 * time.Hour,
+		Timeout:      10 * time.Second,
+		PollInterval: 5 * time.Second,
+		BatchSize:    20,
+	}
+
+	if v, ok := os.LookupEnv("WEBHOOK_MAX_ATTEMPTS"); ok {
+		attempts, err := strconv.Atoi(v)
+		if err != nil || attempts <= 0 {
+			return nil, errors.New("invalid WEBHOOK_MAX_ATTEMPTS value")
+		}
+		cfg.MaxAttempts = attempts
+	}
+
+	if v, ok := os.LookupEnv("WEBHOOK_ALLOW_PRIVATE_NETWORKS"); ok {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.New("invalid WEBHOOK_ALLOW_PRIVATE_NETWORKS value")
+		}
+		cfg.AllowPrivateNetworks = allow
+	}
+
+	durations := []struct {
+		key   string
+		value *time.Duration
+	}{
+		{"WEBHOOK_RETRY_BASE", &cfg.RetryBase},
+		{"WEBHOOK_RETRY_MAX", &cfg.RetryMax},
+		{"WEBHOOK_TIMEOUT", &cfg.Timeout},
+		{"WEBHOOK_POLL_INTERVAL", &cfg.PollInterval},
+	}
+	for _, d := range durations {
+		v, ok := os.LookupEnv(d.key)
+		if !ok {
+			continue
+		}
+		duration, err := time.ParseDuration(v)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid %s value", d.key)
+		}
+		*d.value = duration
+	}
+
+	return cfg, nil
+}
+
+// newOutboxConfig читает настройки ретранслятора событий, все они необязательны
+func newOutboxConfig() (*OutboxConfig, error) {
+	cfg := &OutboxConfig{
+		Sinks:          []string{OutboxSinkBus},
+		PollInterval:   time.Second,
+		BatchSize:      100,
+		Lease:          time.Minute,
+		PublishTimeout: 5 * time.Second,
+		RetryBase:      time.Second,
+		RetryMax:       5 * time.Minute,
+		Retention:      7 * 24 * time.Hour,
+		RedisStream:    getEnvDefault("OUTBOX_REDIS_STREAM", "todo:events"),
+		StreamMaxLen:   100000,
+	}
+
+	if v, ok := os.LookupEnv("OUTBOX_SINKS"); ok {
+		cfg.Sinks = nil
+		for _, sink := range strings.Split(v, ",") {
+			sink = strings.TrimSpace(sink)
+			if sink == "" {
+				continue
+			}
+			if sink != OutboxSinkBus && sink != OutboxSinkRedis {
+				return nil, fmt.Errorf("unknown outbox sink %q, expected bus or redis", sink)
+			}
+			cfg.Sinks = append(cfg.Sinks, sink)
+		}
+		if len(cfg.Sinks) == 0 {
+			return nil, errors.New("OUTBOX_SINKS must contain at least one sink")
+		}
+	}
+
+	if v, ok := os.LookupEnv("OUTBOX_BATCH_SIZE"); ok {
+		size, err := strconv.Atoi(v)
+		if err != nil || size <= 0 {
+			return nil, errors.New("invalid OUTBOX_BATCH_SIZE value")
+		}
+		cfg.BatchSize = size
+	}
+
+	if v, ok := os.LookupEnv("OUTBOX_STREAM_MAXLEN"); ok {
+		maxLen, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || maxLen < 0 {
+			return nil, errors.New("invalid OUTBOX_STREAM_MAXLEN value")
+		}
+		cfg.StreamMaxLen = maxLen
+	}
+
+	durations := []struct {
+		key   string
+		value *time.Duration
+	}{
+		{"OUTBOX_POLL_INTERVAL", &cfg.PollInterval},
+		{"OUTBOX_LEASE", &cfg.Lease},
+		{"OUTBOX_PUBLISH_TIMEOUT", &cfg.PublishTimeout},
+		{"OUTBOX_RETRY_BASE", &cfg.RetryBase},
+		{"OUTBOX_RETRY_MAX", &cfg.RetryMax},
+		{"OUTBOX_RETENTION", &cfg.Retention},
+	}
+	for _, d := range durations {
+		v, ok := os.LookupEnv(d.key)
+		if !ok {
+			continue
+		}
+		duration, err := parseDurationWithDays(v)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid %s value", d.key)
+		}
+		*d.value = duration
+	}
+
+	return cfg, nil
+}
+
+// newLiveConfig читает настройки подписки на изменения, все они необязательны
+func newLiveConfig() (*LiveConfig, error) {
+	cfg := &LiveConfig{
+		Channel:     getEnvDefault("LIVE_CHANNEL", "todo:live"),
+		Heartbeat:   25 * time.Second,
+		BufferSize:  64,
+		ReplayLimit: 500,
+	}
+
+	if v, ok := os.LookupEnv("LIVE_HEARTBEAT"); ok {
+		heartbeat, err := parseDurationWithDays(v)
+		if err != nil || heartbeat <= 0 {
+			return nil, errors.New("invalid LIVE_HEARTBEAT value")
+		}
+		cfg.Heartbeat = heartbeat
+	}
+
+	if v, ok := os.LookupEnv("LIVE_BUFFER_SIZE"); ok {
+		size, err := strconv.Atoi(v)
+		if err != nil || size <= 0 {
+			return nil, errors.New("invalid LIVE_BUFFER_SIZE value")
+		}
+		cfg.BufferSize = size
+	}
+
+	if v, ok := os.LookupEnv("LIVE_REPLAY_LIMIT"); ok {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return nil, errors.New("invalid LIVE_REPLAY_LIMIT value")
+		}
+		cfg.ReplayLimit = limit
+	}
+
+	return cfg, nil
+}
+
+// newNotificationConfig читает настройки уведомлений, все они необязательны
+func newNotificationConfig() (*NotificationConfig, error) {
+	cfg := &NotificationConfig{
+		Retention:       30 * 24 * time.Hour,
+		CleanupInterval: time.Hour,
+	}
+
+	durations := []struct {
+		key   string
+		value *time.Duration
+	}{
+		{"NOTIFICATION_RETENTION", &cfg.Retention},
+		{"NOTIFICATION_CLEANUP_INTERVAL", &cfg.CleanupInterval},
+	}
+	for _, d := range durations {
+		v, ok := os.LookupEnv(d.key)
+		if !ok {
+			continue
+		}
+		duration, err := parseDurationWithDays(v)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid %s value", d.key)
+		}
+		*d.value = duration
+	}
+
+	return cfg, nil
+}
+
+// newReminderConfig читает настройки напоминаний, все они необязательны
+func newReminderConfig() (*ReminderConfig, error) {
+	cfg := &ReminderConfig{
+		Offsets:      []time.Duration{24 * time.Hour, time.Hour},
+		PollInterval: time.Minute,
+		LockTTL:      5 * time.Minute,
+		LockPrefix:   getEnvDefault("REMINDER_LOCK_PREFIX", "todo:lock:"),
+		DigestHour:   8,
+	}
+
+	if v, ok := os.LookupEnv("REMINDER_OFFSETS"); ok {
+		cfg.Offsets = nil
+		for _, item := range strings.Split(v, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			offset, err := parseDurationWithDays(item)
+			if err != nil || offset <= 0 {
+				return nil, fmt.Errorf("invalid reminder offset %q", item)
+			}
+			cfg.Offsets = append(cfg.Offsets, offset)
+		}
+	}
+
+	if v, ok := os.LookupEnv("REMINDER_DIGEST_HOUR"); ok {
+		hour, err := strconv.Atoi(v)
+		if err != nil || hour < 0 || hour > 23 {
+			return nil, errors.New("REMINDER_DIGEST_HOUR must be between 0 and 23")
+		}
+		cfg.DigestHour = hour
+	}
+
+	durations := []struct {
+		key   string
+		value *time.Duration
+	}{
+		{"REMINDER_POLL_INTERVAL", &cfg.PollInterval},
+		{"REMINDER_LOCK_TTL", &cfg.LockTTL},
+	}
+	for _, d := range durations {
+		v, ok := os.LookupEnv(d.key)
+		if !ok {
+			continue
+		}
+		duration, err := parseDurationWithDays(v)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid %s value", d.key)
+		}
+		*d.value = duration
+	}
+
+	return cfg, nil
+}
+
+// newMailConfig читает настройки почты. По умолчанию письма только пишутся в лог
+func newMailConfig() (*MailConfig, error) {
+	cfg := &MailConfig{
+		Backend:  getEnvDefault("MAIL_BACKEND", MailBackendLog),
+		From:     getEnvDefault("MAIL_FROM", "todo@localhost"),
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     getEnvDefault("SMTP_PORT", "587"),
+		User:     os.Getenv("SMTP_USER"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+	}
+
+	switch cfg.Backend {
+	case MailBackendLog:
+	case MailBackendSMTP:
+		if cfg.Host == "" {
+			return nil, errors.New("SMTP_HOST is required for MAIL_BACKEND=smtp")
+		}
+	default:
+		return nil, errors.New("MAIL_BACKEND must be log or smtp")
+	}
+
+	return cfg, nil
+}
+
+func newMentionConfig() (*MentionConfig, error) {
+	cfg := &MentionConfig{
+		NonMembers: getEnvDefault("MENTION_NON_MEMBERS", MentionNonMembersIgnore),
+	}
+
+	if cfg.NonMembers != MentionNonMembersIgnore && cfg.NonMembers != MentionNonMembersReject {
+		return nil, errors.New("MENTION_NON_MEMBERS must be ignore or reject")
+	}
+
+	return cfg, nil
+}
+
+func newImportConfig() (*ImportConfig, error) {
+	cfg := &ImportConfig{
+		MaxFileSize:  10 << 20,
+		MaxItems:     5000,
+		PollInterval: 2 * time.Second,
+		StaleAfter:   10 * time.Minute,
+		Retention:    7 * 24 * time.Hour,
+	}
+
+	if v, ok := os.LookupEnv("IMPORT_MAX_FILE_SIZE"); ok {
+		size, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || size <= 0 {
+			return nil, errors.New("invalid IMPORT_MAX_FILE_SIZE value")
+		}
+		cfg.MaxFileSize = size
+	}
+
+	if v, ok := os.LookupEnv("IMPORT_MAX_ITEMS"); ok {
+		items, err := strconv.Atoi(v)
+		if err != nil || items <= 0 {
+			return nil, errors.New("invalid IMPORT_MAX_ITEMS value")
+		}
+		cfg.MaxItems = items
+	}
+
+	durations := []struct {
+		key   string
+		value *time.Duration
+	}{
+		{"IMPORT_POLL_INTERVAL", &cfg.PollInterval},
+		{"IMPORT_STALE_AFTER", &cfg.StaleAfter},
+		{"IMPORT_RETENTION", &cfg.Retention},
+	}
+	for _, d := range durations {
+		v, ok := os.LookupEnv(d.key)
+		if !ok {
+			continue
+		}
+		duration, err := parseDurationWithDays(v)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid %s value", d.key)
+		}
+		*d.value = duration
+	}
+
+	return cfg, nil
+}
+
+func newS3Config() (*S3Config, error) {
+	endpoint, endpointExists := os.LookupEnv("S3_ENDPOINT")
+	bucket, bucketExists := os.LookupEnv("S3_BUCKET")
+	accessKey, accessKeyExists := os.LookupEnv("S3_ACCESS_KEY")
+	secretKey, secretKeyExists := os.LookupEnv("S3_SECRET_KEY")
+
+	if !endpointExists || !bucketExists || !accessKeyExists || !secretKeyExists {
+		return nil, errors.New("incomplete S3 configuration")
+	}
+
+	pathStyle, err := strconv.ParseBool(getEnvDefault("S3_PATH_STYLE", "true"))
+	if err != nil {
+		return nil, errors.New("invalid S3_PATH_STYLE value")
+	}
+
+	return &S3Config{
+		Endpoint:  strings.TrimRight(endpoint, "/"),
+		Region:    getEnvDefault("S3_REGION", "us-east-1"),
+		Bucket:    bucket,
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		PathStyle: pathStyle,
+	}, nil
+}
+
+func getEnvDefault(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return fallback
+}
+
 func ConfigureDB(db *sql.DB, cfg *DBConfig) {
 	db.SetMaxOpenConns(cfg.MaxOpenConns)
 	db.SetMaxIdleConns(cfg.MaxIdleConns)
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS todo.idx_task_completed_at;
+
+ALTER TABLE todo."task" DROP COLUMN IF EXISTS completed_at;
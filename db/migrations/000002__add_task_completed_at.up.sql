@@ -0,0 +1,8 @@
+-- Время завершения задачи, нужно для статистики проекта
+ALTER TABLE todo."task" ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+
+-- Для уже завершенных задач настоящее время завершения неизвестно, поэтому
+-- completed_at остается пустым: такие задачи не попадают в среднее время
+-- выполнения и графики завершения, вместо того чтобы искажать их
+
+CREATE INDEX IF NOT EXISTS idx_task_completed_at ON todo."task"(project_id, completed_at);
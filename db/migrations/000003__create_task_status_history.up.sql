@@ -0,0 +1,21 @@
+-- История переходов задач между статусами
+CREATE TABLE IF NOT EXISTS todo.task_status_history (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  task_id UUID NOT NULL,
+  project_id UUID NOT NULL,
+  from_status VARCHAR CHECK (from_status IN ('waiting', 'in_progress', 'completed')),
+  to_status VARCHAR NOT NULL CHECK (to_status IN ('waiting', 'in_progress', 'completed')),
+  actor_id UUID,
+  changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (task_id) REFERENCES todo."task"(id) ON DELETE CASCADE,
+  FOREIGN KEY (project_id) REFERENCES todo.project(id) ON DELETE CASCADE,
+  FOREIGN KEY (actor_id) REFERENCES todo."user"(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_status_history_task ON todo.task_status_history(task_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_task_status_history_project ON todo.task_status_history(project_id, changed_at);
+
+-- Заполняем историю для существующих задач: один переход в текущий статус на момент создания
+INSERT INTO todo.task_status_history (task_id, project_id, from_status, to_status, actor_id, changed_at)
+SELECT t.id, t.project_id, NULL, t.status, t.user_id, COALESCE(t.created_at, CURRENT_TIMESTAMP)
+FROM todo."task" t;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS todo.idx_project_search;
+DROP INDEX IF EXISTS todo.idx_note_search;
+DROP INDEX IF EXISTS todo.idx_task_search;
+
+ALTER TABLE todo.project DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE todo.note DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE todo."task" DROP COLUMN IF EXISTS search_vector;
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS todo.ru_en;
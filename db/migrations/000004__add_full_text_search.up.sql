@@ -0,0 +1,31 @@
+-- Конфигурация полнотекстового поиска для смешанного русского и английского текста:
+-- слова латиницей обрабатываются английским стеммером, кириллицей — русским
+CREATE TEXT SEARCH CONFIGURATION todo.ru_en (COPY = pg_catalog.russian);
+
+ALTER TEXT SEARCH CONFIGURATION todo.ru_en
+  ALTER MAPPING FOR asciiword, asciihword, hword_asciipart WITH english_stem;
+
+ALTER TEXT SEARCH CONFIGURATION todo.ru_en
+  ALTER MAPPING FOR word, hword, hword_part WITH russian_stem;
+
+ALTER TABLE todo."task" ADD COLUMN IF NOT EXISTS search_vector tsvector
+  GENERATED ALWAYS AS (
+    setweight(to_tsvector('todo.ru_en', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('todo.ru_en', coalesce(description, '')), 'B')
+  ) STORED;
+
+ALTER TABLE todo.note ADD COLUMN IF NOT EXISTS search_vector tsvector
+  GENERATED ALWAYS AS (
+    setweight(to_tsvector('todo.ru_en', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('todo.ru_en', coalesce(description, '')), 'B')
+  ) STORED;
+
+ALTER TABLE todo.project ADD COLUMN IF NOT EXISTS search_vector tsvector
+  GENERATED ALWAYS AS (
+    setweight(to_tsvector('todo.ru_en', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('todo.ru_en', coalesce(description, '')), 'B')
+  ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_task_search ON todo."task" USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_note_search ON todo.note USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_project_search ON todo.project USING GIN (search_vector);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS todo.saved_filter;
+
+ALTER TABLE todo."user" DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,16 @@
+-- Часовой пояс пользователя (IANA), используется для относительных дат в фильтрах
+ALTER TABLE todo."user" ADD COLUMN IF NOT EXISTS timezone VARCHAR NOT NULL DEFAULT 'UTC';
+
+-- Сохраненные фильтры задач (умные списки)
+CREATE TABLE IF NOT EXISTS todo.saved_filter (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL,
+  name VARCHAR NOT NULL,
+  definition JSONB NOT NULL DEFAULT '{}'::jsonb,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE CASCADE,
+  UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_filter_user ON todo.saved_filter(user_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS todo.note_revision;
+
+DROP FUNCTION IF EXISTS todo.note_revision_immutable();
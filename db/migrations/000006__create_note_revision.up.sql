@@ -0,0 +1,38 @@
+-- Неизменяемые ревизии заметок: каждая правка сохраняет новую ревизию
+CREATE TABLE IF NOT EXISTS todo.note_revision (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  note_id UUID NOT NULL,
+  revision INTEGER NOT NULL,
+  name VARCHAR NOT NULL,
+  description VARCHAR,
+  author_id UUID,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  restored_from INTEGER,
+  FOREIGN KEY (note_id) REFERENCES todo.note(id) ON DELETE CASCADE,
+  FOREIGN KEY (author_id) REFERENCES todo."user"(id) ON DELETE SET NULL,
+  UNIQUE (note_id, revision)
+);
+
+-- Содержимое ревизии менять нельзя. Автор может обнулиться при удалении пользователя
+CREATE OR REPLACE FUNCTION todo.note_revision_immutable() RETURNS trigger AS $$
+BEGIN
+  IF NEW.note_id IS DISTINCT FROM OLD.note_id
+    OR NEW.revision IS DISTINCT FROM OLD.revision
+    OR NEW.name IS DISTINCT FROM OLD.name
+    OR NEW.description IS DISTINCT FROM OLD.description
+    OR NEW.created_at IS DISTINCT FROM OLD.created_at
+    OR NEW.restored_from IS DISTINCT FROM OLD.restored_from THEN
+    RAISE EXCEPTION 'note revisions are immutable';
+  END IF;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER note_revision_immutable
+  BEFORE UPDATE ON todo.note_revision
+  FOR EACH ROW EXECUTE FUNCTION todo.note_revision_immutable();
+
+-- Текущее состояние существующих заметок становится первой ревизией
+INSERT INTO todo.note_revision (note_id, revision, name, description, author_id, created_at)
+SELECT n.id, 1, n.name, COALESCE(n.description, ''), n.user_id, COALESCE(n.created_at, CURRENT_TIMESTAMP)
+FROM todo.note n;
@@ -0,0 +1,3 @@
+ALTER TABLE todo.project DROP COLUMN IF EXISTS version;
+ALTER TABLE todo.note DROP COLUMN IF EXISTS version;
+ALTER TABLE todo.task DROP COLUMN IF EXISTS version;
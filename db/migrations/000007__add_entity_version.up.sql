@@ -0,0 +1,4 @@
+-- Версия строки для оптимистичной блокировки: растет на единицу при каждом изменении
+ALTER TABLE todo.task ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE todo.note ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE todo.project ADD COLUMN version INT NOT NULL DEFAULT 1;
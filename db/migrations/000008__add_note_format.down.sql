@@ -0,0 +1 @@
+ALTER TABLE todo.note DROP COLUMN IF EXISTS format;
@@ -0,0 +1,3 @@
+-- Формат текста заметки: обычный текст или Markdown
+ALTER TABLE todo.note ADD COLUMN format VARCHAR(16) NOT NULL DEFAULT 'plain'
+  CHECK (format IN ('plain', 'markdown'));
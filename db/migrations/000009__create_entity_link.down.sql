@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS note_entity_link_cleanup ON todo.note;
+DROP TRIGGER IF EXISTS task_entity_link_cleanup ON todo.task;
+DROP FUNCTION IF EXISTS todo.entity_link_cleanup();
+DROP TABLE IF EXISTS todo.entity_link;
@@ -0,0 +1,30 @@
+-- Ссылки из текста заметок и задач: [[Название]] и #uuid
+CREATE TABLE IF NOT EXISTS todo.entity_link (
+  source_type VARCHAR NOT NULL CHECK (source_type IN ('task', 'note')),
+  source_id UUID NOT NULL,
+  target_type VARCHAR NOT NULL CHECK (target_type IN ('task', 'note')),
+  target_id UUID NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (source_type, source_id, target_type, target_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_entity_link_target ON todo.entity_link(target_type, target_id);
+
+-- Ссылки указывают и на задачи, и на заметки, поэтому внешних ключей нет:
+-- при удалении сущности ее ссылки в обе стороны удаляет триггер
+CREATE OR REPLACE FUNCTION todo.entity_link_cleanup() RETURNS trigger AS $$
+BEGIN
+  DELETE FROM todo.entity_link
+  WHERE (source_type = TG_ARGV[0] AND source_id = OLD.id)
+    OR (target_type = TG_ARGV[0] AND target_id = OLD.id);
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_entity_link_cleanup
+  AFTER DELETE ON todo.task
+  FOR EACH ROW EXECUTE FUNCTION todo.entity_link_cleanup('task');
+
+CREATE TRIGGER note_entity_link_cleanup
+  AFTER DELETE ON todo.note
+  FOR EACH ROW EXECUTE FUNCTION todo.entity_link_cleanup('note');
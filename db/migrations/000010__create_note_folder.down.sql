@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS todo.note_user_flag;
+
+DROP INDEX IF EXISTS todo.idx_note_folder_position;
+ALTER TABLE todo.note DROP COLUMN IF EXISTS position;
+ALTER TABLE todo.note DROP COLUMN IF EXISTS folder_id;
+
+DROP TABLE IF EXISTS todo.note_folder;
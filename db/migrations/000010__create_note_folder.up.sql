@@ -0,0 +1,40 @@
+-- Иерархические папки заметок внутри проекта
+CREATE TABLE IF NOT EXISTS todo.note_folder (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL,
+  parent_id UUID,
+  name VARCHAR NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (project_id) REFERENCES todo.project(id) ON DELETE CASCADE,
+  FOREIGN KEY (parent_id) REFERENCES todo.note_folder(id) ON DELETE CASCADE,
+  CHECK (parent_id IS NULL OR parent_id <> id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_note_folder_project ON todo.note_folder(project_id, parent_id);
+
+-- Заметка лежит в папке или в корне проекта. При удалении папки заметки переносятся в корень
+ALTER TABLE todo.note ADD COLUMN folder_id UUID REFERENCES todo.note_folder(id) ON DELETE SET NULL;
+-- Ручной порядок заметок внутри папки
+ALTER TABLE todo.note ADD COLUMN position INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_note_folder_position ON todo.note(project_id, folder_id, position);
+
+-- Существующие заметки упорядочиваются по дате создания
+UPDATE todo.note n
+SET position = ordered.rn - 1
+FROM (
+  SELECT id, ROW_NUMBER() OVER (PARTITION BY project_id ORDER BY created_at, id) AS rn
+  FROM todo.note
+) ordered
+WHERE n.id = ordered.id;
+
+-- Личные отметки пользователя: закрепленные и избранные заметки
+CREATE TABLE IF NOT EXISTS todo.note_user_flag (
+  user_id UUID NOT NULL,
+  note_id UUID NOT NULL,
+  pinned BOOLEAN NOT NULL DEFAULT FALSE,
+  favourite BOOLEAN NOT NULL DEFAULT FALSE,
+  PRIMARY KEY (user_id, note_id),
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE CASCADE,
+  FOREIGN KEY (note_id) REFERENCES todo.note(id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS note_attachment_cleanup ON todo.note;
+DROP TRIGGER IF EXISTS task_attachment_cleanup ON todo.task;
+DROP FUNCTION IF EXISTS todo.attachment_parent_cleanup();
+
+DROP TABLE IF EXISTS todo.attachment;
+DROP FUNCTION IF EXISTS todo.attachment_blob_gc_enqueue();
+DROP TABLE IF EXISTS todo.attachment_blob_gc;
@@ -0,0 +1,55 @@
+-- Вложения задач и заметок. Сами файлы лежат в хранилище по ключу storage_key
+CREATE TABLE IF NOT EXISTS todo.attachment (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL,
+  parent_type VARCHAR NOT NULL CHECK (parent_type IN ('task', 'note')),
+  parent_id UUID NOT NULL,
+  user_id UUID,
+  file_name VARCHAR NOT NULL,
+  content_type VARCHAR NOT NULL,
+  size BIGINT NOT NULL CHECK (size >= 0),
+  storage_key VARCHAR NOT NULL UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (project_id) REFERENCES todo.project(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachment_parent ON todo.attachment(parent_type, parent_id);
+CREATE INDEX IF NOT EXISTS idx_attachment_project ON todo.attachment(project_id);
+
+-- Ключи файлов удаленных вложений. Приложение удаляет файлы из хранилища
+-- и затем убирает ключи отсюда, поэтому сбой хранилища не теряет мусор
+CREATE TABLE IF NOT EXISTS todo.attachment_blob_gc (
+  storage_key VARCHAR PRIMARY KEY,
+  deleted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE OR REPLACE FUNCTION todo.attachment_blob_gc_enqueue() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO todo.attachment_blob_gc (storage_key) VALUES (OLD.storage_key)
+  ON CONFLICT DO NOTHING;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER attachment_blob_gc_enqueue
+  AFTER DELETE ON todo.attachment
+  FOR EACH ROW EXECUTE FUNCTION todo.attachment_blob_gc_enqueue();
+
+-- У вложения нет внешнего ключа на задачу или заметку, поэтому при их удалении
+-- вложения удаляет триггер
+CREATE OR REPLACE FUNCTION todo.attachment_parent_cleanup() RETURNS trigger AS $$
+BEGIN
+  DELETE FROM todo.attachment
+  WHERE parent_type = TG_ARGV[0] AND parent_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_attachment_cleanup
+  AFTER DELETE ON todo.task
+  FOR EACH ROW EXECUTE FUNCTION todo.attachment_parent_cleanup('task');
+
+CREATE TRIGGER note_attachment_cleanup
+  AFTER DELETE ON todo.note
+  FOR EACH ROW EXECUTE FUNCTION todo.attachment_parent_cleanup('note');
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS todo.task_checklist_item;
@@ -0,0 +1,14 @@
+-- Пункты чек-листа задачи в ручном порядке
+CREATE TABLE IF NOT EXISTS todo.task_checklist_item (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  task_id UUID NOT NULL,
+  text VARCHAR(500) NOT NULL,
+  done BOOLEAN NOT NULL DEFAULT FALSE,
+  assignee_id UUID,
+  position INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (task_id) REFERENCES todo.task(id) ON DELETE CASCADE,
+  FOREIGN KEY (assignee_id) REFERENCES todo."user"(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_checklist_item_task ON todo.task_checklist_item(task_id, position);
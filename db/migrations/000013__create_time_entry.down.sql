@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS todo.time_entry;
+ALTER TABLE todo.task DROP COLUMN IF EXISTS estimate_minutes;
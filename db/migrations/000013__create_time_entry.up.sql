@@ -0,0 +1,21 @@
+-- Оценка трудозатрат задачи в минутах
+ALTER TABLE todo.task ADD COLUMN estimate_minutes INT CHECK (estimate_minutes >= 0);
+
+-- Учет времени. Запущенный таймер - запись без ended_at
+CREATE TABLE IF NOT EXISTS todo.time_entry (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  task_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  started_at TIMESTAMP NOT NULL,
+  ended_at TIMESTAMP,
+  note VARCHAR(500) NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CHECK (ended_at IS NULL OR ended_at >= started_at),
+  FOREIGN KEY (task_id) REFERENCES todo.task(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE CASCADE
+);
+
+-- У пользователя не больше одного запущенного таймера
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entry_running ON todo.time_entry(user_id) WHERE ended_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_time_entry_task ON todo.time_entry(task_id, started_at);
+CREATE INDEX IF NOT EXISTS idx_time_entry_started ON todo.time_entry(started_at);
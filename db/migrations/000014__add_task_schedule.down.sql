@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS todo.idx_task_deadline;
+DROP INDEX IF EXISTS todo.idx_task_start_at;
+ALTER TABLE todo.task DROP COLUMN IF EXISTS all_day;
+ALTER TABLE todo.task DROP COLUMN IF EXISTS start_at;
@@ -0,0 +1,7 @@
+-- Начало работы над задачей и признак задачи на весь день.
+-- У задач на весь день start_at и deadline хранят полночь UTC нужной даты
+ALTER TABLE todo.task ADD COLUMN start_at TIMESTAMP;
+ALTER TABLE todo.task ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_task_start_at ON todo.task(start_at);
+CREATE INDEX IF NOT EXISTS idx_task_deadline ON todo.task(deadline);
@@ -0,0 +1,14 @@
+-- Секретные ссылки на ICS-фиды. Хранится только SHA-256 токена.
+-- У пользователя один общий фид (project_id IS NULL) и по одному фиду на проект
+CREATE TABLE IF NOT EXISTS todo.calendar_feed (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL,
+  project_id UUID,
+  token_hash VARCHAR(64) NOT NULL UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE CASCADE,
+  FOREIGN KEY (project_id) REFERENCES todo.project(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feed_user ON todo.calendar_feed(user_id) WHERE project_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feed_user_project ON todo.calendar_feed(user_id, project_id) WHERE project_id IS NOT NULL;
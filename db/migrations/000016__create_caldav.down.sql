@@ -0,0 +1,14 @@
+DROP TRIGGER IF EXISTS task_tombstone_project_cleanup ON todo.project;
+DROP FUNCTION IF EXISTS todo.task_tombstone_project_cleanup();
+DROP TRIGGER IF EXISTS task_tombstone_record ON todo.task;
+DROP FUNCTION IF EXISTS todo.task_tombstone_record();
+DROP TABLE IF EXISTS todo.task_tombstone;
+DROP TRIGGER IF EXISTS task_sync_touch ON todo.task;
+DROP FUNCTION IF EXISTS todo.task_sync_touch();
+DROP INDEX IF EXISTS todo.idx_task_sync_seq;
+ALTER TABLE todo.task DROP COLUMN IF EXISTS sync_seq;
+DROP SEQUENCE IF EXISTS todo.task_sync_seq;
+DROP INDEX IF EXISTS todo.idx_task_caldav_name;
+ALTER TABLE todo.task DROP COLUMN IF EXISTS caldav_uid;
+ALTER TABLE todo.task DROP COLUMN IF EXISTS caldav_name;
+DROP TABLE IF EXISTS todo.app_password;
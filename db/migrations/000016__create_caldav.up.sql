@@ -0,0 +1,78 @@
+-- Пароли приложений для CalDAV-клиентов, которые не умеют работать с JWT.
+-- Пароль генерируется сервером, хранится только его SHA-256
+CREATE TABLE IF NOT EXISTS todo.app_password (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  password_hash VARCHAR(64) NOT NULL UNIQUE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_used_at TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_app_password_user ON todo.app_password(user_id);
+
+-- Имя ресурса и UID, которые выбрал CalDAV-клиент при создании задачи.
+-- У задач, созданных через API, ресурс называется <id>.ics
+ALTER TABLE todo.task ADD COLUMN caldav_name VARCHAR(255);
+ALTER TABLE todo.task ADD COLUMN caldav_uid VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_caldav_name ON todo.task(project_id, caldav_name) WHERE caldav_name IS NOT NULL;
+
+-- Номер последнего изменения задачи для sync-collection (RFC 6578).
+-- Триггеры обновляют его при любой записи, поэтому синхронизация не зависит
+-- от того, каким путем изменилась задача
+CREATE SEQUENCE IF NOT EXISTS todo.task_sync_seq;
+
+ALTER TABLE todo.task ADD COLUMN sync_seq BIGINT NOT NULL DEFAULT nextval('todo.task_sync_seq');
+
+CREATE INDEX IF NOT EXISTS idx_task_sync_seq ON todo.task(project_id, sync_seq);
+
+CREATE OR REPLACE FUNCTION todo.task_sync_touch() RETURNS trigger AS $$
+BEGIN
+  NEW.sync_seq := nextval('todo.task_sync_seq');
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_sync_touch
+  BEFORE UPDATE ON todo.task
+  FOR EACH ROW EXECUTE FUNCTION todo.task_sync_touch();
+
+-- Удаленные задачи, о которых клиенты узнают при синхронизации.
+-- Задачи удаленного проекта в журнал не попадают: проект исчезает целиком
+CREATE TABLE IF NOT EXISTS todo.task_tombstone (
+  task_id UUID PRIMARY KEY,
+  project_id UUID NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  sync_seq BIGINT NOT NULL,
+  deleted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tombstone_project ON todo.task_tombstone(project_id, sync_seq);
+
+CREATE OR REPLACE FUNCTION todo.task_tombstone_record() RETURNS trigger AS $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM todo.project WHERE id = OLD.project_id) THEN
+    INSERT INTO todo.task_tombstone (task_id, project_id, name, sync_seq)
+    VALUES (OLD.id, OLD.project_id, COALESCE(OLD.caldav_name, OLD.id::text || '.ics'), nextval('todo.task_sync_seq'))
+    ON CONFLICT (task_id) DO NOTHING;
+  END IF;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_tombstone_record
+  AFTER DELETE ON todo.task
+  FOR EACH ROW EXECUTE FUNCTION todo.task_tombstone_record();
+
+CREATE OR REPLACE FUNCTION todo.task_tombstone_project_cleanup() RETURNS trigger AS $$
+BEGIN
+  DELETE FROM todo.task_tombstone WHERE project_id = OLD.id;
+  RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_tombstone_project_cleanup
+  AFTER DELETE ON todo.project
+  FOR EACH ROW EXECUTE FUNCTION todo.task_tombstone_project_cleanup();
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS todo.webhook_delivery;
+DROP TABLE IF EXISTS todo.webhook;
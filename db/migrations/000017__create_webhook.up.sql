@@ -0,0 +1,39 @@
+-- Вебхуки проектов. Секрет хранится открыто: им подписывается каждая доставка
+CREATE TABLE IF NOT EXISTS todo.webhook (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL,
+  created_by UUID NOT NULL,
+  url VARCHAR(2048) NOT NULL,
+  secret VARCHAR(64) NOT NULL,
+  events TEXT[] NOT NULL,
+  is_active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (project_id) REFERENCES todo.project(id) ON DELETE CASCADE,
+  FOREIGN KEY (created_by) REFERENCES todo."user"(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_project ON todo.webhook(project_id);
+
+-- Очередь и журнал доставок. Строка со статусом pending ждет отправки
+-- в next_attempt_at; воркер, взявший строку, сдвигает next_attempt_at на время
+-- аренды, поэтому после падения процесса доставка будет повторена
+CREATE TABLE IF NOT EXISTS todo.webhook_delivery (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  webhook_id UUID NOT NULL,
+  event_id UUID NOT NULL,
+  event_type VARCHAR(50) NOT NULL,
+  payload JSONB NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_attempt_at TIMESTAMP,
+  response_code INT,
+  last_error TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  delivered_at TIMESTAMP,
+  FOREIGN KEY (webhook_id) REFERENCES todo.webhook(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_due ON todo.webhook_delivery(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_webhook ON todo.webhook_delivery(webhook_id, created_at DESC);
@@ -0,0 +1,22 @@
+-- Исходящие события. Строка пишется в той же транзакции, что и изменение
+-- задачи, заметки или проекта, поэтому событие не теряется при падении процесса.
+-- Ретранслятор публикует строки по порядку seq и отмечает published_at;
+-- взятая строка сдвигает next_attempt_at на время аренды
+CREATE TABLE IF NOT EXISTS todo.outbox (
+  id UUID PRIMARY KEY,
+  seq BIGSERIAL NOT NULL,
+  event_type VARCHAR(50) NOT NULL,
+  project_id UUID NOT NULL,
+  payload JSONB NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_error TEXT,
+  published_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON todo.outbox(next_attempt_at, seq) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_published ON todo.outbox(published_at) WHERE published_at IS NOT NULL;
+
+-- Повторная публикация события не должна ставить в очередь вторую доставку
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_event ON todo.webhook_delivery(webhook_id, event_id);
@@ -0,0 +1,30 @@
+-- Уведомления пользователей. event_id - событие, из которого получено уведомление:
+-- повторная обработка того же события не создает дубль
+CREATE TABLE IF NOT EXISTS todo.notification (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL,
+  type VARCHAR(50) NOT NULL,
+  project_id UUID NOT NULL,
+  actor_id UUID,
+  event_id UUID NOT NULL,
+  data JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  read_at TIMESTAMP,
+  UNIQUE (user_id, event_id, type),
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE CASCADE,
+  FOREIGN KEY (project_id) REFERENCES todo.project(id) ON DELETE CASCADE,
+  FOREIGN KEY (actor_id) REFERENCES todo."user"(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_user ON todo.notification(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notification_unread ON todo.notification(user_id) WHERE read_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_notification_read ON todo.notification(read_at) WHERE read_at IS NOT NULL;
+
+-- Настройки уведомлений. Нет строки - уведомления этого типа включены
+CREATE TABLE IF NOT EXISTS todo.notification_preference (
+  user_id UUID NOT NULL,
+  type VARCHAR(50) NOT NULL,
+  enabled BOOLEAN NOT NULL,
+  PRIMARY KEY (user_id, type),
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS todo.user_digest;
+DROP INDEX IF EXISTS todo.idx_task_overdue_pending;
+ALTER TABLE todo.task DROP COLUMN IF EXISTS overdue_at;
@@ -0,0 +1,18 @@
+-- Момент, когда задача отмечена просроченной. Сбрасывается при переносе срока,
+-- чтобы о новом сроке снова пришли напоминание и отметка о просрочке
+ALTER TABLE todo.task ADD COLUMN overdue_at TIMESTAMP;
+
+-- Задачи, просроченные до появления колонки, считаются уже отмеченными:
+-- иначе первый запуск планировщика разослал бы уведомления по всей истории
+UPDATE todo.task SET overdue_at = now()
+WHERE status <> 'completed' AND deadline > '0001-01-01' AND deadline < now();
+
+CREATE INDEX IF NOT EXISTS idx_task_overdue_pending ON todo.task(deadline)
+  WHERE overdue_at IS NULL AND status <> 'completed';
+
+-- Дата последней ежедневной сводки пользователя в его часовом поясе
+CREATE TABLE IF NOT EXISTS todo.user_digest (
+  user_id UUID PRIMARY KEY,
+  last_sent_on DATE NOT NULL,
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE CASCADE
+);
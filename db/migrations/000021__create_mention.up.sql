@@ -0,0 +1,16 @@
+-- Упоминания @login в описаниях задач и текстах заметок. Хранятся только
+-- упоминания участников проекта, которому принадлежит источник
+CREATE TABLE IF NOT EXISTS todo.mention (
+  source_type VARCHAR(10) NOT NULL CHECK (source_type IN ('task', 'note')),
+  source_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  project_id UUID NOT NULL,
+  author_id UUID,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (source_type, source_id, user_id),
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE CASCADE,
+  FOREIGN KEY (project_id) REFERENCES todo.project(id) ON DELETE CASCADE,
+  FOREIGN KEY (author_id) REFERENCES todo."user"(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mention_user ON todo.mention(user_id, created_at DESC);
@@ -0,0 +1,28 @@
+-- Задания импорта задач и заметок. Загруженный файл хранится в задании:
+-- проверку и запись выполняет фоновый обработчик любого экземпляра сервиса.
+-- Обработчик, взявший задание, обновляет updated_at по мере продвижения,
+-- поэтому задание, брошенное упавшим процессом, можно найти и запустить заново
+CREATE TABLE IF NOT EXISTS todo.import_job (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  project_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  source VARCHAR(16) NOT NULL CHECK (source IN ('csv', 'todoist', 'trello', 'course-todo')),
+  status VARCHAR(16) NOT NULL DEFAULT 'queued'
+    CHECK (status IN ('queued', 'validating', 'validated', 'pending_commit', 'importing', 'completed', 'failed')),
+  file_name VARCHAR(255) NOT NULL,
+  file BYTEA NOT NULL,
+  mapping JSONB NOT NULL DEFAULT '{}',
+  total INT NOT NULL DEFAULT 0,
+  processed INT NOT NULL DEFAULT 0,
+  report JSONB,
+  error TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  finished_at TIMESTAMP,
+  FOREIGN KEY (project_id) REFERENCES todo.project(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES todo."user"(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_job_queue ON todo.import_job(updated_at)
+  WHERE status IN ('queued', 'validating', 'pending_commit', 'importing');
+CREATE INDEX IF NOT EXISTS idx_import_job_project ON todo.import_job(project_id, created_at DESC);
@@ -0,0 +1,2 @@
+-- Вместе со служебным пользователем каскадно удаляется все, что было переназначено на него
+DELETE FROM todo."user" WHERE id = '00000000-0000-0000-0000-000000000001';
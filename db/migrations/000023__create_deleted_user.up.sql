@@ -0,0 +1,6 @@
+-- Служебный пользователь, которому переходит авторство задач, заметок, записей
+-- времени и вебхуков удаленных аккаунтов. Войти под ним нельзя: пустой хеш пароля
+-- не проходит проверку bcrypt, а логин с дефисом не пропускает регистрация
+INSERT INTO todo."user" (id, login, username, email, password_hash, timezone)
+VALUES ('00000000-0000-0000-0000-000000000001', 'deleted-user', 'Deleted user', 'deleted-user@invalid', '', 'UTC')
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS todo.idx_outbox_published_seq;
+ALTER TABLE todo.outbox DROP COLUMN IF EXISTS published_seq;
+DROP SEQUENCE IF EXISTS todo.outbox_published_seq;
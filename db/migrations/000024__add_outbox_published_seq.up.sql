@@ -0,0 +1,14 @@
+-- Номер публикации - курсор потока событий. seq выдается при записи, и событие
+-- с меньшим seq может быть опубликовано позже большего, поэтому переподключение
+-- по seq пропускало бы его. published_seq выдается при отметке о публикации.
+-- Уже опубликованные события сохраняют прежние номера, чтобы не сбить курсоры
+-- подключенных клиентов
+CREATE SEQUENCE IF NOT EXISTS todo.outbox_published_seq;
+
+ALTER TABLE todo.outbox ADD COLUMN IF NOT EXISTS published_seq BIGINT;
+
+UPDATE todo.outbox SET published_seq = seq WHERE published_at IS NOT NULL AND published_seq IS NULL;
+
+SELECT setval('todo.outbox_published_seq', GREATEST((SELECT MAX(seq) FROM todo.outbox), 1));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_published_seq ON todo.outbox(published_seq) WHERE published_seq IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE todo.import_job DROP COLUMN IF EXISTS claim_token;
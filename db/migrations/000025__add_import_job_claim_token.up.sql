@@ -0,0 +1,5 @@
+-- Метка обработчика, взявшего задание. Брошенное задание забирает другой обработчик
+-- с новой меткой, и запись прежнего обработчика, если он все же дойдет до конца,
+-- не проходит проверку метки и откатывается. updated_at для этого не годится:
+-- его сдвигает сохранение прогресса
+ALTER TABLE todo.import_job ADD COLUMN IF NOT EXISTS claim_token UUID;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS todo.idx_webhook_delivery_event;
+ALTER TABLE todo.webhook_delivery DROP COLUMN IF EXISTS redelivery;
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_event ON todo.webhook_delivery(webhook_id, event_id);
@@ -0,0 +1,16 @@
+-- Повторная публикация события не должна ставить в очередь вторую доставку.
+-- Ручная повторная отправка из журнала - отдельная строка с тем же событием,
+-- на нее ограничение не распространяется
+ALTER TABLE todo.webhook_delivery ADD COLUMN IF NOT EXISTS redelivery BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Уже накопившиеся дубли, кроме самой ранней доставки, считаются повторными отправками
+UPDATE todo.webhook_delivery d
+SET redelivery = TRUE
+FROM (
+  SELECT id, ROW_NUMBER() OVER (PARTITION BY webhook_id, event_id ORDER BY created_at, id) AS rn
+  FROM todo.webhook_delivery
+) ranked
+WHERE d.id = ranked.id AND ranked.rn > 1;
+
+DROP INDEX IF EXISTS todo.idx_webhook_delivery_event;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_delivery_event ON todo.webhook_delivery(webhook_id, event_id) WHERE NOT redelivery;
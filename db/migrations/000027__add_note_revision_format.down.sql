@@ -0,0 +1 @@
+ALTER TABLE todo.note_revision DROP COLUMN IF EXISTS format;
@@ -0,0 +1,5 @@
+-- Формат хранится в каждой ревизии: восстановление возвращает заметке формат
+-- ревизии, а смена одного формата отличается от предыдущей ревизии.
+-- Ревизии, сохраненные до появления форматов, были обычным текстом
+ALTER TABLE todo.note_revision ADD COLUMN IF NOT EXISTS format VARCHAR(16) NOT NULL DEFAULT 'plain'
+  CHECK (format IN ('plain', 'markdown'));
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -13,8 +14,10 @@ import (
 
 	"github.com/lzimin05/course-todo/internal/infrastructure/redis"
 	"github.com/lzimin05/course-todo/internal/infrastructure/repository"
+	"github.com/lzimin05/course-todo/internal/infrastructure/storage"
 	"github.com/lzimin05/course-todo/internal/transport/jwt"
 	"github.com/lzimin05/course-todo/internal/transport/middleware"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
 	"github.com/sirupsen/logrus"
 
 	authrepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/auth"
@@ -33,9 +36,79 @@ import (
 	notet "github.com/lzimin05/course-todo/internal/transport/note"
 	noteuc "github.com/lzimin05/course-todo/internal/usecase/note"
 
+	linkRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/link"
+
+	mentionRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/mention"
+	mentiont "github.com/lzimin05/course-todo/internal/transport/mention"
+	mentionuc "github.com/lzimin05/course-todo/internal/usecase/mention"
+
 	projectRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/project"
 	projectt "github.com/lzimin05/course-todo/internal/transport/project"
 	projectuc "github.com/lzimin05/course-todo/internal/usecase/project"
+
+	exportRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/export"
+	exportt "github.com/lzimin05/course-todo/internal/transport/export"
+	exportuc "github.com/lzimin05/course-todo/internal/usecase/export"
+
+	accountRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/account"
+	accountt "github.com/lzimin05/course-todo/internal/transport/account"
+	accountuc "github.com/lzimin05/course-todo/internal/usecase/account"
+
+	importRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/importjob"
+	importt "github.com/lzimin05/course-todo/internal/transport/importjob"
+	importuc "github.com/lzimin05/course-todo/internal/usecase/importjob"
+
+	reportRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/report"
+	reportt "github.com/lzimin05/course-todo/internal/transport/report"
+	reportuc "github.com/lzimin05/course-todo/internal/usecase/report"
+
+	filterRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/filter"
+	filtert "github.com/lzimin05/course-todo/internal/transport/filter"
+	filteruc "github.com/lzimin05/course-todo/internal/usecase/filter"
+
+	taskQueryRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/taskquery"
+	taskqueryt "github.com/lzimin05/course-todo/internal/transport/taskquery"
+	taskqueryuc "github.com/lzimin05/course-todo/internal/usecase/taskquery"
+
+	searchRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/search"
+	searcht "github.com/lzimin05/course-todo/internal/transport/search"
+	searchuc "github.com/lzimin05/course-todo/internal/usecase/search"
+
+	timeEntryRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/timeentry"
+	timeentryt "github.com/lzimin05/course-todo/internal/transport/timeentry"
+	timeentryuc "github.com/lzimin05/course-todo/internal/usecase/timeentry"
+
+	calendarRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/calendar"
+	calendart "github.com/lzimin05/course-todo/internal/transport/calendar"
+	calendaruc "github.com/lzimin05/course-todo/internal/usecase/calendar"
+
+	attachmentRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/attachment"
+	attachmentt "github.com/lzimin05/course-todo/internal/transport/attachment"
+	attachmentuc "github.com/lzimin05/course-todo/internal/usecase/attachment"
+
+	caldavRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/caldav"
+	caldavt "github.com/lzimin05/course-todo/internal/transport/caldav"
+	caldavuc "github.com/lzimin05/course-todo/internal/usecase/caldav"
+
+	"github.com/lzimin05/course-todo/internal/infrastructure/eventbus"
+	outboxRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/outbox"
+	outboxuc "github.com/lzimin05/course-todo/internal/usecase/outbox"
+
+	livet "github.com/lzimin05/course-todo/internal/transport/live"
+	liveuc "github.com/lzimin05/course-todo/internal/usecase/live"
+
+	notificationRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/notification"
+	notificationt "github.com/lzimin05/course-todo/internal/transport/notification"
+	notificationuc "github.com/lzimin05/course-todo/internal/usecase/notification"
+
+	"github.com/lzimin05/course-todo/internal/infrastructure/mailer"
+	reminderRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/reminder"
+	reminderuc "github.com/lzimin05/course-todo/internal/usecase/reminder"
+
+	webhookRepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/webhook"
+	"github.com/lzimin05/course-todo/internal/infrastructure/webhook"
+	webhookt "github.com/lzimin05/course-todo/internal/transport/webhook"
+	webhookuc "github.com/lzimin05/course-todo/internal/usecase/webhook"
 )
 
 // App объединяет все компоненты приложения
@@ -44,6 +117,14 @@ type App struct {
 	logger *logrus.Logger
 	db     *sql.DB
 	router *mux.Router
+
+	webhooks *webhookuc.WebhookUsecase
+	outbox   *outboxuc.OutboxRelay
+	live     *liveuc.LiveHub
+
+	notifications *notificationuc.NotificationUsecase
+	reminders     *reminderuc.ReminderScheduler
+	imports       *importuc.ImportUsecase
 }
 
 func NewApp(conf *config.Config) (*App, error) {
@@ -72,9 +153,23 @@ func NewApp(conf *config.Config) (*App, error) {
 	tokenator := jwt.NewTokenator(conf.JWTConfig)
 
 	projectRepository := projectRepo.New(db)
-	projectUseCase := projectuc.New(projectRepository)
+
+	blobStorage, signedFiles, err := newBlobStorage(conf.StorageConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init attachment storage: %v", err)
+	}
+	attachmentRepository := attachmentRepo.New(db)
+	attachmentUC := attachmentuc.New(attachmentRepository, projectRepository, blobStorage, conf.StorageConfig)
+	attachmentHandler := attachmentt.New(attachmentUC, signedFiles, conf)
+
+	projectUseCase := projectuc.New(projectRepository, attachmentUC)
 	projectHandler := projectt.New(projectUseCase, conf)
 
+	linkRepository := linkRepo.New(db)
+
+	mentionUC := mentionuc.New(mentionRepo.New(db), conf.MentionConfig)
+	mentionHandler := mentiont.New(mentionUC, conf)
+
 	authRepo := authrepo.New(db)
 	authUC := authuc.New(authRepo, tokenator, redisAuthRepo, projectRepository)
 	authHandler := autht.New(authUC, conf)
@@ -84,9 +179,63 @@ func NewApp(conf *config.Config) (*App, error) {
 	userHandler := usert.New(userUC, conf)
 
 	noteRepo := noteRepo.NewNoteRepository(db)
-	noteUC := noteuc.NewNoteUsecase(noteRepo, projectRepository)
+	noteHTMLCache := redis.NewNoteHTMLCache(redisAuthClient)
+	noteUC := noteuc.NewNoteUsecase(noteRepo, projectRepository, noteHTMLCache, linkRepository, attachmentUC, mentionUC)
 	noteHandler := notet.NewNoteHandler(noteUC, conf)
 
+	exportUC := exportuc.New(exportRepo.New(db), projectRepository)
+	exportHandler := exportt.New(exportUC, conf)
+
+	accountUC := accountuc.New(accountRepo.New(db), userRepo, projectRepository, exportUC, redisAuthRepo, attachmentUC)
+	accountHandler := accountt.New(accountUC, conf)
+
+	importUC := importuc.New(importRepo.New(db), projectRepository, conf.ImportConfig)
+	importHandler := importt.New(importUC, conf)
+
+	reportRepository := reportRepo.New(db)
+	reportUC := reportuc.New(reportRepository, projectRepository)
+	reportHandler := reportt.New(reportUC, conf)
+
+	filterRepository := filterRepo.New(db)
+	filterUC := filteruc.New(filterRepository, projectRepository, userRepo)
+	filterHandler := filtert.New(filterUC, conf)
+
+	webhookRepository := webhookRepo.New(db)
+	webhookUC := webhookuc.New(webhookRepository, projectRepository, webhook.NewHTTPSender(conf.WebhookConfig), conf.WebhookConfig)
+	webhookHandler := webhookt.New(webhookUC, conf)
+
+	// Репозитории пишут события в outbox в транзакции изменения, ретранслятор
+	// передает их в шину внутри процесса и в Redis Streams
+	outboxRepository := outboxRepo.New(db)
+	liveHub := liveuc.New(redis.NewLiveChannel(redisAuthClient, conf.LiveConfig.Channel), outboxRepository, projectRepository, conf.LiveConfig)
+	liveHandler := livet.New(liveHub, conf)
+
+	notificationRepository := notificationRepo.New(db)
+	notificationUC := notificationuc.New(notificationRepository, conf.NotificationConfig)
+	notificationHandler := notificationt.New(notificationUC, conf)
+
+	// Напоминания о сроках и ежедневная сводка: задания планировщика выполняет
+	// тот экземпляр, который захватил блокировку в Redis
+	reminderScheduler := reminderuc.New(
+		reminderRepo.New(db),
+		notificationRepository,
+		redis.NewLocker(redisAuthClient, conf.ReminderConfig.LockPrefix),
+		newMailer(conf.MailConfig),
+		conf.ReminderConfig,
+	)
+
+	bus := eventbus.New()
+	bus.Subscribe("webhooks", webhookUC.HandleMessage)
+	bus.Subscribe("notifications", notificationUC.HandleMessage)
+	// Поток событий получает событие после отметки о публикации: номер
+	// публикации служит курсором переподключения
+	outboxRelay := outboxuc.New(outboxRepository, newOutboxSinks(conf.OutboxConfig, bus, redisAuthClient),
+		[]outboxuc.OutboxObserver{liveHub}, conf.OutboxConfig)
+
+	searchRepository := searchRepo.New(db)
+	searchUC := searchuc.New(searchRepository)
+	searchHandler := searcht.New(searchUC, conf)
+
 	// Настройка маршрутизатора
 	router := mux.NewRouter()
 	router.Use(func(next http.Handler) http.Handler {
@@ -102,6 +251,15 @@ func NewApp(conf *config.Config) (*App, error) {
 		authRouter.Handle("/logout",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(authHandler.Logout)),
 		).Methods(http.MethodPost)
+		authRouter.Handle("/app-passwords",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(authHandler.CreateAppPassword)),
+		).Methods(http.MethodPost)
+		authRouter.Handle("/app-passwords",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(authHandler.GetAppPasswords)),
+		).Methods(http.MethodGet)
+		authRouter.Handle("/app-passwords/{passwordId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(authHandler.DeleteAppPassword)),
+		).Methods(http.MethodDelete)
 	}
 
 	userRouter := apiRouter.PathPrefix("/users").Subrouter()
@@ -109,6 +267,12 @@ func NewApp(conf *config.Config) (*App, error) {
 		userRouter.Handle("/me",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(userHandler.GetMe)),
 		).Methods(http.MethodGet)
+		userRouter.Handle("/me",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(accountHandler.DeleteAccount)),
+		).Methods(http.MethodDelete)
+		userRouter.Handle("/me/export",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(accountHandler.ExportAccount)),
+		).Methods(http.MethodGet)
 		userRouter.Handle("/by-email",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(userHandler.GetUserByEmail)),
 		).Methods(http.MethodGet)
@@ -118,12 +282,34 @@ func NewApp(conf *config.Config) (*App, error) {
 		userRouter.Handle("/username",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(userHandler.UpdateUsername)),
 		).Methods(http.MethodPatch)
+		userRouter.Handle("/timezone",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(userHandler.UpdateTimezone)),
+		).Methods(http.MethodPatch)
+		userRouter.Handle("/me/mentions",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(mentionHandler.GetMyMentions)),
+		).Methods(http.MethodGet)
 	}
 
 	taskRepository := taskRepo.New(db)
-	taskUseCase := taskuc.New(taskRepository, projectRepository)
+	taskUseCase := taskuc.New(taskRepository, projectRepository, linkRepository, attachmentUC, mentionUC)
 	taskHandler := taskt.New(taskUseCase, conf)
 
+	timeEntryRepository := timeEntryRepo.New(db)
+	timeEntryUC := timeentryuc.New(timeEntryRepository, projectRepository, userRepo)
+	timeEntryHandler := timeentryt.New(timeEntryUC, conf)
+
+	calendarRepository := calendarRepo.New(db)
+	calendarUC := calendaruc.New(calendarRepository, projectRepository, userRepo)
+	calendarHandler := calendart.New(calendarUC, conf)
+
+	caldavRepository := caldavRepo.New(db)
+	caldavUC := caldavuc.New(caldavRepository, taskUseCase, userRepo)
+	caldavHandler := caldavt.New(caldavUC, conf)
+
+	taskQueryRepository := taskQueryRepo.New(db)
+	taskQueryUC := taskqueryuc.New(taskQueryRepository, userRepo)
+	taskQueryHandler := taskqueryt.New(taskQueryUC, conf)
+
 	taskRouter := apiRouter.PathPrefix("/todo").Subrouter()
 	{
 		taskRouter.Handle("/create",
@@ -132,12 +318,45 @@ func NewApp(conf *config.Config) (*App, error) {
 		taskRouter.Handle("/all",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.GetTasksByUserID)),
 		).Methods(http.MethodGet)
+		taskRouter.Handle("/search",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskQueryHandler.SearchTasks)),
+		).Methods(http.MethodGet)
 		taskRouter.Handle("/{taskId}/edit",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.UpdateTask)),
 		).Methods(http.MethodPut)
 		taskRouter.Handle("/{taskId}/edit",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.UpdateTaskStatus)),
 		).Methods(http.MethodPatch)
+		taskRouter.Handle("/{taskId}/backlinks",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.GetBacklinks)),
+		).Methods(http.MethodGet)
+		taskRouter.Handle("/{taskId}/checklist",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.GetChecklist)),
+		).Methods(http.MethodGet)
+		taskRouter.Handle("/{taskId}/checklist",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.AddChecklistItem)),
+		).Methods(http.MethodPost)
+		taskRouter.Handle("/{taskId}/checklist/{itemId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.UpdateChecklistItem)),
+		).Methods(http.MethodPatch)
+		taskRouter.Handle("/{taskId}/checklist/{itemId}/move",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.MoveChecklistItem)),
+		).Methods(http.MethodPut)
+		taskRouter.Handle("/{taskId}/checklist/{itemId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.DeleteChecklistItem)),
+		).Methods(http.MethodDelete)
+		taskRouter.Handle("/{taskId}/timer/start",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(timeEntryHandler.StartTimer)),
+		).Methods(http.MethodPost)
+		taskRouter.Handle("/{taskId}/time",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(timeEntryHandler.GetTaskTimeEntries)),
+		).Methods(http.MethodGet)
+		taskRouter.Handle("/{taskId}/time",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(timeEntryHandler.AddTimeEntry)),
+		).Methods(http.MethodPost)
+		taskRouter.Handle("/{taskId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.GetTaskByID)),
+		).Methods(http.MethodGet)
 		taskRouter.Handle("/{taskId}",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(taskHandler.DeleteTask)),
 		).Methods(http.MethodDelete)
@@ -151,12 +370,48 @@ func NewApp(conf *config.Config) (*App, error) {
 		noteRouter.Handle("/create",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.CreateNote)),
 		).Methods(http.MethodPost)
+		noteRouter.Handle("/folders",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.CreateFolder)),
+		).Methods(http.MethodPost)
+		noteRouter.Handle("/folders/{folderId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.UpdateFolder)),
+		).Methods(http.MethodPut)
+		noteRouter.Handle("/folders/{folderId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.DeleteFolder)),
+		).Methods(http.MethodDelete)
 		noteRouter.Handle("/{noteId}/edit",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.UpdateNote)),
 		).Methods(http.MethodPut)
+		noteRouter.Handle("/{noteId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.GetNoteByID)),
+		).Methods(http.MethodGet)
 		noteRouter.Handle("/{noteId}",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.DeleteNote)),
 		).Methods(http.MethodDelete)
+		noteRouter.Handle("/{noteId}/move",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.MoveNote)),
+		).Methods(http.MethodPut)
+		noteRouter.Handle("/{noteId}/flags",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.SetNoteFlags)),
+		).Methods(http.MethodPatch)
+		noteRouter.Handle("/{noteId}/html",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.RenderNote)),
+		).Methods(http.MethodGet)
+		noteRouter.Handle("/{noteId}/backlinks",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.GetBacklinks)),
+		).Methods(http.MethodGet)
+		noteRouter.Handle("/{noteId}/revisions",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.GetNoteRevisions)),
+		).Methods(http.MethodGet)
+		noteRouter.Handle("/{noteId}/revisions/diff",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.DiffNoteRevisions)),
+		).Methods(http.MethodGet)
+		noteRouter.Handle("/{noteId}/revisions/{revision:[0-9]+}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.GetNoteRevision)),
+		).Methods(http.MethodGet)
+		noteRouter.Handle("/{noteId}/revisions/{revision:[0-9]+}/restore",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.RestoreNoteRevision)),
+		).Methods(http.MethodPost)
 	}
 
 	projectRouter := apiRouter.PathPrefix("/projects").Subrouter()
@@ -190,6 +445,34 @@ func NewApp(conf *config.Config) (*App, error) {
 		projectRouter.Handle("/{projectId}/leave",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(projectHandler.LeaveProject)),
 		).Methods(http.MethodPost)
+		projectRouter.Handle("/{projectId}/stats",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(projectHandler.GetProjectStats)),
+		).Methods(http.MethodGet)
+		projectRouter.Handle("/{projectId}/export",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(exportHandler.ExportProject)),
+		).Methods(http.MethodGet)
+
+		// Импорт
+		projectRouter.Handle("/{projectId}/imports",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(importHandler.CreateImport)),
+		).Methods(http.MethodPost)
+		projectRouter.Handle("/{projectId}/imports",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(importHandler.GetImports)),
+		).Methods(http.MethodGet)
+		projectRouter.Handle("/{projectId}/imports/{jobId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(importHandler.GetImport)),
+		).Methods(http.MethodGet)
+		projectRouter.Handle("/{projectId}/imports/{jobId}/commit",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(importHandler.CommitImport)),
+		).Methods(http.MethodPost)
+
+		// Отчеты
+		projectRouter.Handle("/{projectId}/reports/cfd",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(reportHandler.GetCumulativeFlow)),
+		).Methods(http.MethodGet)
+		projectRouter.Handle("/{projectId}/reports/burndown",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(reportHandler.GetBurndown)),
+		).Methods(http.MethodGet)
 
 		// Задачи и заметки проекта
 		projectRouter.Handle("/{projectId}/tasks",
@@ -198,21 +481,190 @@ func NewApp(conf *config.Config) (*App, error) {
 		projectRouter.Handle("/{projectId}/notes",
 			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.GetNotesByProject)),
 		).Methods(http.MethodGet)
+		projectRouter.Handle("/{projectId}/notes/tree",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(noteHandler.GetNoteTree)),
+		).Methods(http.MethodGet)
+		projectRouter.Handle("/{projectId}/attachments/usage",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(attachmentHandler.GetProjectUsage)),
+		).Methods(http.MethodGet)
+
+		// Вебхуки
+		projectRouter.Handle("/{projectId}/webhooks",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(webhookHandler.CreateWebhook)),
+		).Methods(http.MethodPost)
+		projectRouter.Handle("/{projectId}/webhooks",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(webhookHandler.GetWebhooks)),
+		).Methods(http.MethodGet)
+		projectRouter.Handle("/{projectId}/webhooks/{webhookId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(webhookHandler.UpdateWebhook)),
+		).Methods(http.MethodPatch)
+		projectRouter.Handle("/{projectId}/webhooks/{webhookId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(webhookHandler.DeleteWebhook)),
+		).Methods(http.MethodDelete)
+		projectRouter.Handle("/{projectId}/webhooks/{webhookId}/deliveries",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(webhookHandler.GetDeliveries)),
+		).Methods(http.MethodGet)
+		projectRouter.Handle("/{projectId}/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(webhookHandler.Redeliver)),
+		).Methods(http.MethodPost)
+	}
+
+	apiRouter.Handle("/calendar",
+		middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(calendarHandler.GetCalendar)),
+	).Methods(http.MethodGet)
+
+	calendarRouter := apiRouter.PathPrefix("/calendar").Subrouter()
+	{
+		calendarRouter.Handle("/feeds",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(calendarHandler.GetFeeds)),
+		).Methods(http.MethodGet)
+		calendarRouter.Handle("/feeds",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(calendarHandler.CreateFeed)),
+		).Methods(http.MethodPost)
+		calendarRouter.Handle("/feeds/{feedId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(calendarHandler.DeleteFeed)),
+		).Methods(http.MethodDelete)
+		// Календарные клиенты не умеют передавать токен авторизации: доступ дает секрет в ссылке
+		calendarRouter.HandleFunc("/feed/{token:[A-Za-z0-9_-]+}.ics", calendarHandler.GetFeedICS).Methods(http.MethodGet)
+	}
+
+	timeRouter := apiRouter.PathPrefix("/time").Subrouter()
+	{
+		timeRouter.Handle("/timer",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(timeEntryHandler.GetRunningTimer)),
+		).Methods(http.MethodGet)
+		timeRouter.Handle("/timer/stop",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(timeEntryHandler.StopTimer)),
+		).Methods(http.MethodPost)
+		timeRouter.Handle("/entries/{entryId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(timeEntryHandler.DeleteTimeEntry)),
+		).Methods(http.MethodDelete)
+		timeRouter.Handle("/report",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(timeEntryHandler.GetTimeReport)),
+		).Methods(http.MethodGet)
+	}
+
+	attachmentRouter := apiRouter.PathPrefix("/attachments").Subrouter()
+	{
+		attachmentRouter.Handle("",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(attachmentHandler.Upload)),
+		).Methods(http.MethodPost)
+		attachmentRouter.Handle("",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(attachmentHandler.GetAttachments)),
+		).Methods(http.MethodGet)
+		attachmentRouter.Handle("/{attachmentId}/url",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(attachmentHandler.GetDownloadURL)),
+		).Methods(http.MethodGet)
+		attachmentRouter.Handle("/{attachmentId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(attachmentHandler.DeleteAttachment)),
+		).Methods(http.MethodDelete)
+
+		// Файлы локального хранилища отдаются по подписанным ссылкам без авторизации
+		if signedFiles != nil {
+			attachmentRouter.HandleFunc("/files/{key:.+}", attachmentHandler.ServeFile).Methods(http.MethodGet)
+		}
+	}
+
+	filterRouter := apiRouter.PathPrefix("/filters").Subrouter()
+	{
+		filterRouter.Handle("",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(filterHandler.CreateFilter)),
+		).Methods(http.MethodPost)
+		filterRouter.Handle("",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(filterHandler.GetFilters)),
+		).Methods(http.MethodGet)
+		filterRouter.Handle("/{filterId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(filterHandler.GetFilterByID)),
+		).Methods(http.MethodGet)
+		filterRouter.Handle("/{filterId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(filterHandler.UpdateFilter)),
+		).Methods(http.MethodPut)
+		filterRouter.Handle("/{filterId}",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(filterHandler.DeleteFilter)),
+		).Methods(http.MethodDelete)
+		filterRouter.Handle("/{filterId}/tasks",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(filterHandler.GetFilterTasks)),
+		).Methods(http.MethodGet)
+	}
+
+	apiRouter.Handle("/search",
+		middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(searchHandler.Search)),
+	).Methods(http.MethodGet)
+
+	notificationRouter := apiRouter.PathPrefix("/notifications").Subrouter()
+	{
+		notificationRouter.Handle("",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(notificationHandler.GetNotifications)),
+		).Methods(http.MethodGet)
+		notificationRouter.Handle("/unread-count",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(notificationHandler.GetUnreadCount)),
+		).Methods(http.MethodGet)
+		notificationRouter.Handle("/read-all",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(notificationHandler.MarkAllRead)),
+		).Methods(http.MethodPost)
+		notificationRouter.Handle("/preferences",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(notificationHandler.GetPreferences)),
+		).Methods(http.MethodGet)
+		notificationRouter.Handle("/preferences",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(notificationHandler.UpdatePreferences)),
+		).Methods(http.MethodPatch)
+		notificationRouter.Handle("/{notificationId}/read",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(notificationHandler.MarkRead)),
+		).Methods(http.MethodPost)
+	}
+
+	// Подписка на изменения: SSE и WebSocket
+	eventsRouter := apiRouter.PathPrefix("/events").Subrouter()
+	{
+		eventsRouter.Handle("",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(liveHandler.Events)),
+		).Methods(http.MethodGet)
+		eventsRouter.Handle("/ws",
+			middleware.AuthMiddleware(tokenator, redisAuthRepo)(http.HandlerFunc(liveHandler.WebSocket)),
+		).Methods(http.MethodGet)
+	}
+
+	// CalDAV: клиенты авторизуются паролями приложений, методы разбирает обработчик
+	router.HandleFunc("/.well-known/caldav", caldavHandler.WellKnown)
+	caldavRouter := router.PathPrefix("/caldav").Subrouter()
+	caldavRouter.Use(middleware.BasicAuthMiddleware(authUC, "course-todo"))
+	{
+		caldavRouter.HandleFunc("/", caldavHandler.Principal)
+		caldavRouter.HandleFunc("/projects/", caldavHandler.Home)
+		caldavRouter.HandleFunc("/projects/{projectId}/", caldavHandler.Collection)
+		caldavRouter.HandleFunc("/projects/{projectId}", caldavHandler.Collection)
+		caldavRouter.HandleFunc("/projects/{projectId}/{name}", caldavHandler.Object)
 	}
 
 	// Swagger
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
 	return &App{
-		conf:   conf,
-		logger: logger,
-		db:     db,
-		router: router,
+		conf:     conf,
+		logger:   logger,
+		db:       db,
+		router:   router,
+		webhooks: webhookUC,
+		outbox:   outboxRelay,
+		live:     liveHub,
+
+		notifications: notificationUC,
+		reminders:     reminderScheduler,
+		imports:       importUC,
 	}, nil
 }
 
-// Run запускает HTTP-сервер
+// Run запускает ретранслятор событий, доставку вебхуков, рассылку подписчикам,
+// очистку уведомлений, планировщик напоминаний, обработку импорта и HTTP-сервер
 func (a *App) Run() {
+	workerCtx := logctx.WithLogger(context.Background(), logrus.NewEntry(a.logger))
+	go a.outbox.Run(workerCtx)
+	go a.webhooks.Run(workerCtx)
+	go a.live.Run(workerCtx)
+	go a.notifications.Run(workerCtx)
+	go a.reminders.Run(workerCtx)
+	go a.imports.Run(workerCtx)
+
 	server := &http.Server{
 		Addr:    ":" + a.conf.ServerConfig.Port,
 		Handler: a.router,
@@ -224,3 +676,44 @@ func (a *App) Run() {
 		a.logger.Fatalf("Server failed: %v", err)
 	}
 }
+
+// newBlobStorage создает хранилище вложений. Для локального хранилища второе значение -
+// обработчик подписанных ссылок, S3 отдает файлы по своим presigned-ссылкам
+func newBlobStorage(cfg *config.StorageConfig) (attachmentuc.BlobStorage, attachmentt.SignedFileStorage, error) {
+	switch cfg.Backend {
+	case config.StorageBackendS3:
+		s3, err := storage.NewS3Storage(cfg.S3)
+		if err != nil {
+			return nil, nil, err
+		}
+		return s3, nil, nil
+	default:
+		local, err := storage.NewLocalStorage(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return local, local, nil
+	}
+}
+
+// newOutboxSinks собирает получателей событий в порядке, заданном в OUTBOX_SINKS
+func newOutboxSinks(cfg *config.OutboxConfig, bus *eventbus.Bus, client *redis.Client) []outboxuc.OutboxSink {
+	sinks := make([]outboxuc.OutboxSink, 0, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		switch name {
+		case config.OutboxSinkRedis:
+			sinks = append(sinks, redis.NewEventStream(client, cfg.RedisStream, cfg.StreamMaxLen))
+		default:
+			sinks = append(sinks, bus)
+		}
+	}
+	return sinks
+}
+
+// newMailer выбирает способ отправки писем по MAIL_BACKEND
+func newMailer(cfg *config.MailConfig) reminderuc.Mailer {
+	if cfg.Backend == config.MailBackendSMTP {
+		return mailer.NewSMTPMailer(cfg)
+	}
+	return mailer.NewLogMailer()
+}
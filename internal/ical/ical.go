@@ -0,0 +1,96 @@
+// Package ical формирует календари в формате iCalendar (RFC 5545)
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// Строки длиннее 75 октетов переносятся на следующую строку с пробелом в начале
+	maxLineOctets = 75
+
+	utcLayout  = "20060102T150405Z"
+	dateLayout = "20060102"
+)
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// Writer записывает компоненты и свойства календаря.
+// Строки разделяются CRLF и сворачиваются по 75 октетов
+type Writer struct {
+	buf bytes.Buffer
+}
+
+func NewWriter() *Writer {
+	return &Writer{}
+}
+
+// Begin открывает компонент (VCALENDAR, VEVENT, VTODO)
+func (w *Writer) Begin(component string) {
+	w.line("BEGIN:" + component)
+}
+
+// End закрывает компонент
+func (w *Writer) End(component string) {
+	w.line("END:" + component)
+}
+
+// Raw записывает свойство без экранирования. Подходит для значений
+// из фиксированного набора (STATUS, PRIORITY, VERSION)
+func (w *Writer) Raw(name, value string) {
+	w.line(name + ":" + value)
+}
+
+// Text записывает текстовое свойство, экранируя спецсимволы и переводы строк
+func (w *Writer) Text(name, value string) {
+	w.line(name + ":" + EscapeText(value))
+}
+
+// Time записывает момент времени в UTC
+func (w *Writer) Time(name string, t time.Time) {
+	w.line(name + ":" + t.UTC().Format(utcLayout))
+}
+
+// Date записывает дату без времени (VALUE=DATE)
+func (w *Writer) Date(name string, t time.Time) {
+	w.line(name + ";VALUE=DATE:" + t.Format(dateLayout))
+}
+
+// Bytes возвращает записанный календарь
+func (w *Writer) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// EscapeText экранирует значение типа TEXT
+func EscapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// line сворачивает строку так, чтобы ни одна ее часть не превышала 75 октетов,
+// не разрывая многобайтовые символы UTF-8
+func (w *Writer) line(s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.buf.WriteString(s[:cut])
+		w.buf.WriteString("\r\n ")
+		s = s[cut:]
+		// Пробел в начале продолжения тоже занимает октет
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(s)
+	w.buf.WriteString("\r\n")
+}
@@ -0,0 +1,47 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriter_Escaping(t *testing.T) {
+	w := NewWriter()
+	w.Text("SUMMARY", "a,b;c\\d\nновая строка")
+
+	assert.Equal(t, "SUMMARY:a\\,b\\;c\\\\d\\nновая строка\r\n", string(w.Bytes()))
+}
+
+func TestWriter_Folding(t *testing.T) {
+	w := NewWriter()
+	w.Text("DESCRIPTION", strings.Repeat("я", 100))
+
+	lines := strings.Split(strings.TrimSuffix(string(w.Bytes()), "\r\n"), "\r\n")
+	assert.Greater(t, len(lines), 1)
+
+	var unfolded strings.Builder
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	assert.Equal(t, "DESCRIPTION:"+strings.Repeat("я", 100), unfolded.String())
+}
+
+func TestWriter_Dates(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	w := NewWriter()
+	w.Begin("VEVENT")
+	w.Time("DTSTART", time.Date(2025, 3, 4, 9, 30, 0, 0, moscow))
+	w.Date("DTEND", time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC))
+	w.End("VEVENT")
+
+	assert.Equal(t, "BEGIN:VEVENT\r\nDTSTART:20250304T063000Z\r\nDTEND;VALUE=DATE:20250305\r\nEND:VEVENT\r\n", string(w.Bytes()))
+}
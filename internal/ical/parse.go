@@ -0,0 +1,185 @@
+package ical
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformed = errors.New("malformed iCalendar data")
+
+	textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n")
+)
+
+// Component - компонент календаря (VCALENDAR, VTODO, VTIMEZONE) со свойствами
+// и вложенными компонентами в порядке появления
+type Component struct {
+	Name       string
+	Properties []Property
+	Children   []*Component
+}
+
+// Property - свойство компонента. Имена свойств и параметров приводятся к верхнему регистру
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Prop возвращает первое свойство с именем name или nil
+func (c *Component) Prop(name string) *Property {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// Text возвращает значение свойства типа TEXT без экранирования
+func (p *Property) Text() string {
+	return textUnescaper.Replace(p.Value)
+}
+
+// Time разбирает значение DATE или DATE-TIME. Время без зоны ("плавающее")
+// и зоны, неизвестные серверу, считаются в локации floating.
+// allDay - значение было датой без времени, она возвращается как полночь UTC
+func (p *Property) Time(floating *time.Location) (t time.Time, allDay bool, err error) {
+	value := p.Value
+	if p.Params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		t, err = time.Parse(dateLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w: invalid date %q", ErrMalformed, value)
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse(utcLayout, value)
+	} else {
+		loc := floating
+		if tzid := p.Params["TZID"]; tzid != "" {
+			if l, lerr := time.LoadLocation(strings.TrimPrefix(tzid, "/")); lerr == nil {
+				loc = l
+			}
+		}
+		t, err = time.ParseInLocation(strings.TrimSuffix(utcLayout, "Z"), value, loc)
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: invalid date-time %q", ErrMalformed, value)
+	}
+	return t.UTC(), false, nil
+}
+
+// Parse разбирает календарь: разворачивает свернутые строки и строит дерево
+// компонентов. Возвращает корневой компонент (обычно VCALENDAR)
+func Parse(data []byte) (*Component, error) {
+	var (
+		root  *Component
+		stack []*Component
+	)
+
+	for _, line := range unfold(string(data)) {
+		if line == "" {
+			continue
+		}
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch prop.Name {
+		case "BEGIN":
+			c := &Component{Name: strings.ToUpper(prop.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, c)
+			} else if root != nil {
+				return nil, fmt.Errorf("%w: more than one root component", ErrMalformed)
+			} else {
+				root = c
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("%w: unexpected END:%s", ErrMalformed, prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: property %s outside of component", ErrMalformed, prop.Name)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, prop)
+		}
+	}
+
+	if root == nil || len(stack) > 0 {
+		return nil, fmt.Errorf("%w: unterminated component", ErrMalformed)
+	}
+	return root, nil
+}
+
+// unfold склеивает строки-продолжения (начинаются с пробела или табуляции).
+// Принимаются и CRLF, и LF: не все клиенты соблюдают RFC 5545
+func unfold(s string) []string {
+	raw := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	lines := make([]string, 0, len(raw))
+	for _, l := range raw {
+		l = strings.TrimSuffix(l, "\r")
+		if len(l) > 0 && (l[0] == ' ' || l[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// parseLine разбирает строку вида NAME;PARAM=value;PARAM="quoted":value
+func parseLine(line string) (Property, error) {
+	prop := Property{Params: map[string]string{}}
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return prop, fmt.Errorf("%w: invalid content line %q", ErrMalformed, line)
+	}
+	prop.Name = strings.ToUpper(line[:i])
+
+	rest := line[i:]
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return prop, fmt.Errorf("%w: invalid parameter in %q", ErrMalformed, line)
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return prop, fmt.Errorf("%w: unterminated quoted parameter in %q", ErrMalformed, line)
+			}
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return prop, fmt.Errorf("%w: invalid content line %q", ErrMalformed, line)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+		prop.Params[name] = value
+	}
+
+	if !strings.HasPrefix(rest, ":") {
+		return prop, fmt.Errorf("%w: invalid content line %q", ErrMalformed, line)
+	}
+	prop.Value = rest[1:]
+	return prop, nil
+}
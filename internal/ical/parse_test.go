@@ -0,0 +1,77 @@
+package ical
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"UID:ABC-123\r\n" +
+		"SUMMARY:Купить молоко\\, хлеб\r\n" +
+		"DESCRIPTION:Первая строка\\nвторая \r\n" +
+		" строка\r\n" +
+		"DUE;TZID=Europe/Moscow:20250304T120000\r\n" +
+		"DTSTART;VALUE=DATE:20250303\r\n" +
+		"X-APPLE-SORT-ORDER;X-PARAM=\"a:b;c\":42\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, err := Parse([]byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, "VCALENDAR", cal.Name)
+	assert.Len(t, cal.Children, 1)
+
+	todo := cal.Children[0]
+	assert.Equal(t, "VTODO", todo.Name)
+	assert.Equal(t, "Купить молоко, хлеб", todo.Prop("SUMMARY").Text())
+	assert.Equal(t, "Первая строка\nвторая строка", todo.Prop("DESCRIPTION").Text())
+	assert.Equal(t, "a:b;c", todo.Prop("X-APPLE-SORT-ORDER").Params["X-PARAM"])
+	assert.Equal(t, "42", todo.Prop("X-APPLE-SORT-ORDER").Value)
+	assert.Nil(t, todo.Prop("PRIORITY"))
+
+	due, allDay, err := todo.Prop("DUE").Time(time.UTC)
+	assert.NoError(t, err)
+	assert.False(t, allDay)
+	assert.Equal(t, time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC), due)
+
+	start, allDay, err := todo.Prop("DTSTART").Time(time.UTC)
+	assert.NoError(t, err)
+	assert.True(t, allDay)
+	assert.Equal(t, time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), start)
+}
+
+func TestProperty_TimeFloating(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	floating := &Property{Name: "DUE", Value: "20250304T120000"}
+	due, _, err := floating.Time(moscow)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC), due)
+
+	utc := &Property{Name: "DUE", Value: "20250304T120000Z"}
+	due, _, err = utc.Time(moscow)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC), due)
+
+	_, _, err = (&Property{Name: "DUE", Value: "tomorrow"}).Time(moscow)
+	assert.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestParse_Malformed(t *testing.T) {
+	tests := []string{
+		"",
+		"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VCALENDAR\r\n",
+		"SUMMARY:outside\r\n",
+		"BEGIN:VCALENDAR\r\nno colon here\r\nEND:VCALENDAR\r\n",
+	}
+
+	for _, data := range tests {
+		_, err := Parse([]byte(data))
+		assert.ErrorIs(t, err, ErrMalformed, data)
+	}
+}
@@ -0,0 +1,58 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	models "github.com/lzimin05/course-todo/internal/models/outbox"
+)
+
+// Handler обрабатывает событие. Ошибка возвращает событие в outbox на повторную
+// публикацию, поэтому обработчик должен спокойно переносить повторы с тем же ID
+type Handler func(ctx context.Context, msg models.Message) error
+
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+// Bus - шина событий внутри процесса. Подписчики вызываются по очереди
+// в порядке подписки
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []subscriber
+}
+
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe добавляет подписчика, name попадает в текст ошибки
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribers = append(b.subscribers, subscriber{name: name, handler: handler})
+}
+
+func (b *Bus) Name() string {
+	return "bus"
+}
+
+// Publish передает событие всем подписчикам. Ошибка одного подписчика
+// не мешает остальным, все ошибки возвращаются вместе
+func (b *Bus) Publish(ctx context.Context, msg models.Message) error {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subscribers {
+		if err := s.handler(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
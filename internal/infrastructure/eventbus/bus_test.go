@@ -0,0 +1,37 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/outbox"
+)
+
+func TestBus_Publish(t *testing.T) {
+	bus := New()
+	msg := models.Message{ID: uuid.New(), Type: "task.created"}
+
+	var calls []string
+	bus.Subscribe("webhooks", func(_ context.Context, m models.Message) error {
+		assert.Equal(t, msg.ID, m.ID)
+		calls = append(calls, "webhooks")
+		return errors.New("db error")
+	})
+	bus.Subscribe("live", func(_ context.Context, m models.Message) error {
+		calls = append(calls, "live")
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), msg)
+
+	assert.EqualError(t, err, "webhooks: db error")
+	assert.Equal(t, []string{"webhooks", "live"}, calls)
+}
+
+func TestBus_PublishWithoutSubscribers(t *testing.T) {
+	assert.NoError(t, New().Publish(context.Background(), models.Message{ID: uuid.New()}))
+}
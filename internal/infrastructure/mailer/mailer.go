@@ -0,0 +1,91 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/mail"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+// LogMailer не отправляет письма, а пишет их в лог. Используется локально и в тестовых стендах
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, mail models.Mail) error {
+	logctx.GetLogger(ctx).
+		WithField("op", "LogMailer.Send").
+		WithField("to", mail.To).
+		WithField("subject", mail.Subject).
+		Warn("mail is not sent, MAIL_BACKEND=log:\n" + mail.Body)
+	return nil
+}
+
+// SMTPMailer отправляет письма через SMTP-сервер. Если сервер поддерживает
+// STARTTLS, соединение шифруется; авторизация PLAIN - только при заданном пользователе
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(cfg *config.MailConfig) *SMTPMailer {
+	m := &SMTPMailer{
+		addr: net.JoinHostPort(cfg.Host, cfg.Port),
+		from: cfg.From,
+	}
+	if cfg.User != "" {
+		m.auth = smtp.PlainAuth("", cfg.User, cfg.Password, cfg.Host)
+	}
+	return m
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, mail models.Mail) error {
+	msg, err := buildMessage(m.from, mail, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// net/smtp не принимает контекст, поэтому отмена проверяется только до отправки
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{mail.To}, msg); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+	return nil
+}
+
+// buildMessage собирает письмо в формате RFC 5322 с телом text/plain в UTF-8
+func buildMessage(from string, mail models.Mail, date time.Time) ([]byte, error) {
+	for _, v := range []string{from, mail.To, mail.Subject} {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, errors.New("mail header contains line break")
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", mail.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", mail.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+
+	body := strings.ReplaceAll(mail.Body, "\r\n", "\n")
+	buf.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,40 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/mail"
+)
+
+func TestBuildMessage(t *testing.T) {
+	date := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+	t.Run("headers and body", func(t *testing.T) {
+		msg, err := buildMessage("todo@example.com", models.Mail{
+			To:      "user@example.com",
+			Subject: "Сводка задач",
+			Body:    "line 1\nline 2",
+		}, date)
+
+		assert.NoError(t, err)
+		text := string(msg)
+		assert.Contains(t, text, "From: todo@example.com\r\n")
+		assert.Contains(t, text, "To: user@example.com\r\n")
+		assert.Contains(t, text, "Subject: =?utf-8?q?")
+		assert.Contains(t, text, "Date: Mon, 02 Mar 2026 08:00:00 +0000\r\n")
+		assert.True(t, strings.HasSuffix(text, "\r\n\r\nline 1\r\nline 2"))
+	})
+
+	t.Run("header injection", func(t *testing.T) {
+		_, err := buildMessage("todo@example.com", models.Mail{
+			To:      "user@example.com\r\nBcc: other@example.com",
+			Subject: "Сводка",
+		}, date)
+
+		assert.Error(t, err)
+	})
+}
@@ -2,14 +2,18 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/lzimin05/course-todo/config"
+	"github.com/redis/go-redis/v9"
 )
 
 const (
-	userTokensPrefix = "user_id:"
+	userTokensPrefix    = "user_id:"
+	revokedBeforePrefix = "revoked_before:"
 )
 
 type AuthRepository struct {
@@ -53,3 +57,31 @@ func (r *AuthRepository) IsBlacklisted(ctx context.Context, userID, token string
 
 	return isMember, nil
 }
+
+// RevokeSessions делает недействительными все токены пользователя, выпущенные не позже before.
+// Отметка живет столько же, сколько токен, поэтому к ее удалению старых токенов уже не остается
+func (r *AuthRepository) RevokeSessions(ctx context.Context, userID string, before time.Time) error {
+	key := revokedBeforePrefix + userID
+	if err := r.client.Set(ctx, key, before.Unix(), r.cfg.TokenLifeSpan).Err(); err != nil {
+		return fmt.Errorf("failed to revoke user's sessions: %w", err)
+	}
+	return nil
+}
+
+// IsRevoked проверяет, выпущен ли токен до отзыва всех сессий пользователя
+func (r *AuthRepository) IsRevoked(ctx context.Context, userID string, issuedAt time.Time) (bool, error) {
+	value, err := r.client.Get(ctx, revokedBeforePrefix+userID).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check user's sessions revocation: %w", err)
+	}
+
+	revokedBefore, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid sessions revocation value: %w", err)
+	}
+	// iat хранится с точностью до секунды: токен той же секунды тоже отозван
+	return issuedAt.Unix() <= revokedBefore, nil
+}
@@ -0,0 +1,50 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	models "github.com/lzimin05/course-todo/internal/models/outbox"
+	"github.com/redis/go-redis/v9"
+)
+
+// EventStream публикует события outbox в поток Redis Streams. Событие может
+// попасть в поток повторно, потребители отбрасывают дубли по полю id
+type EventStream struct {
+	client *Client
+	stream string
+	maxLen int64
+}
+
+// NewEventStream создает публикатор. maxLen ограничивает длину потока
+// приблизительно (MAXLEN ~), 0 - без ограничения
+func NewEventStream(client *Client, stream string, maxLen int64) *EventStream {
+	return &EventStream{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (s *EventStream) Name() string {
+	return "redis"
+}
+
+func (s *EventStream) Publish(ctx context.Context, msg models.Message) error {
+	err := s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		MaxLen: s.maxLen,
+		Approx: s.maxLen > 0,
+		Values: map[string]interface{}{
+			"id":         msg.ID.String(),
+			"type":       msg.Type,
+			"project_id": msg.ProjectID.String(),
+			"payload":    string(msg.Payload),
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to add event to stream: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/outbox"
+)
+
+// LiveChannel рассылает события всем экземплярам сервиса через Redis pub/sub.
+// Сообщения не хранятся: пропущенное подписчик догоняет по таблице outbox
+type LiveChannel struct {
+	client  *Client
+	channel string
+}
+
+func NewLiveChannel(client *Client, channel string) *LiveChannel {
+	return &LiveChannel{
+		client:  client,
+		channel: channel,
+	}
+}
+
+type liveMessage struct {
+	ID           uuid.UUID       `json:"id"`
+	PublishedSeq int64           `json:"published_seq"`
+	Type         string          `json:"type"`
+	ProjectID    uuid.UUID       `json:"project_id"`
+	CreatedAt    time.Time       `json:"created_at"`
+	Payload      json.RawMessage `json:"payload"`
+}
+
+func (c *LiveChannel) Publish(ctx context.Context, msg models.Message) error {
+	data, err := json.Marshal(liveMessage{
+		ID:           msg.ID,
+		PublishedSeq: msg.PublishedSeq,
+		Type:         msg.Type,
+		ProjectID:    msg.ProjectID,
+		CreatedAt:    msg.CreatedAt,
+		Payload:      msg.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal live message: %w", err)
+	}
+
+	if err := c.client.Publish(ctx, c.channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish live message: %w", err)
+	}
+
+	return nil
+}
+
+// Listen подписывается на канал и передает сообщения в handler, пока не отменен
+// контекст. При обрыве соединения клиент Redis переподписывается сам
+func (c *LiveChannel) Listen(ctx context.Context, handler func(models.Message)) error {
+	pubsub := c.client.Subscribe(ctx, c.channel)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("failed to subscribe to live channel: %w", err)
+	}
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case m, ok := <-ch:
+			if !ok {
+				return fmt.Errorf("live channel closed")
+			}
+
+			var lm liveMessage
+			if err := json.Unmarshal([]byte(m.Payload), &lm); err != nil {
+				continue
+			}
+			handler(models.Message{
+				ID:           lm.ID,
+				PublishedSeq: lm.PublishedSeq,
+				Type:         lm.Type,
+				ProjectID:    lm.ProjectID,
+				CreatedAt:    lm.CreatedAt,
+				Payload:      lm.Payload,
+			})
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Ключ удаляется, только если его значение - токен владельца: блокировку,
+// истекшую и захваченную другим экземпляром, снять нельзя
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Locker - распределенная блокировка на одном ключе Redis (SET NX PX)
+type Locker struct {
+	client *Client
+	prefix string
+}
+
+func NewLocker(client *Client, prefix string) *Locker {
+	return &Locker{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// TryLock захватывает блокировку name на ttl. Если ее держит другой экземпляр,
+// возвращает false. release снимает блокировку, если она еще принадлежит вызывающему
+func (l *Locker) TryLock(ctx context.Context, name string, ttl time.Duration) (release func(context.Context) error, ok bool, err error) {
+	key := l.prefix + name
+	token := uuid.NewString()
+
+	ok, err = l.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	release = func(ctx context.Context) error {
+		if err := releaseLockScript.Run(ctx, l.client, []string{key}, token).Err(); err != nil {
+			return fmt.Errorf("failed to release lock %s: %w", name, err)
+		}
+		return nil
+	}
+	return release, true, nil
+}
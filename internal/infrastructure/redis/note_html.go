@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	noteHTMLPrefix = "note_html:"
+	noteHTMLTTL    = 24 * time.Hour
+)
+
+// NoteHTMLCache хранит отрисованный HTML заметок вместе с отметкой данных, из которых он получен
+type NoteHTMLCache struct {
+	client *Client
+}
+
+func NewNoteHTMLCache(client *Client) *NoteHTMLCache {
+	return &NoteHTMLCache{
+		client: client,
+	}
+}
+
+// Get возвращает HTML заметки, если он отрисован из тех же данных
+func (c *NoteHTMLCache) Get(ctx context.Context, noteID uuid.UUID, stamp string) (string, bool, error) {
+	values, err := c.client.HMGet(ctx, noteHTMLPrefix+noteID.String(), "stamp", "html").Result()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get note html from cache: %w", err)
+	}
+
+	cachedStamp, ok := values[0].(string)
+	if !ok || cachedStamp != stamp {
+		return "", false, nil
+	}
+	html, ok := values[1].(string)
+	if !ok {
+		return "", false, nil
+	}
+
+	return html, true, nil
+}
+
+// Set сохраняет HTML заметки для указанной отметки
+func (c *NoteHTMLCache) Set(ctx context.Context, noteID uuid.UUID, stamp string, html string) error {
+	key := noteHTMLPrefix + noteID.String()
+
+	pipe := c.client.TxPipeline()
+	pipe.HSet(ctx, key, "stamp", stamp, "html", html)
+	pipe.Expire(ctx, key, noteHTMLTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save note html to cache: %w", err)
+	}
+
+	return nil
+}
+
+// Invalidate удаляет отрисованный HTML заметки после ее изменения
+func (c *NoteHTMLCache) Invalidate(ctx context.Context, noteID uuid.UUID) error {
+	if err := c.client.Del(ctx, noteHTMLPrefix+noteID.String()).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate note html cache: %w", err)
+	}
+
+	return nil
+}
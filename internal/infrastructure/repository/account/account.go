@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	models "github.com/lzimin05/course-todo/internal/models/account"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	userModels "github.com/lzimin05/course-todo/internal/models/user"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	queryGetProfile = `
+		SELECT id, login, username, email, timezone, created_at
+		FROM todo."user"
+		WHERE id = $1`
+
+	queryGetProjects = `
+		SELECT p.id, p.name, pm.role, p.owner_id
+		FROM todo.project_member pm
+		JOIN todo.project p ON p.id = pm.project_id
+		WHERE pm.user_id = $1
+		ORDER BY p.created_at, p.id`
+
+	queryGetTimeEntries = `
+		SELECT te.id, te.task_id, t.title, t.project_id, te.started_at, te.ended_at, te.note
+		FROM todo.time_entry te
+		JOIN todo.task t ON t.id = te.task_id
+		WHERE te.user_id = $1
+		ORDER BY te.started_at, te.id`
+
+	// Блокировка не дает передать или удалить проекты параллельным запросом
+	// и создать новый проект, пока выполняется удаление
+	queryLockOwnedProjects = `
+		SELECT id FROM todo.project WHERE owner_id = $1 ORDER BY id FOR UPDATE`
+
+	queryPromoteNewOwner = `
+		UPDATE todo.project_member SET role = 'owner'
+		WHERE project_id = $1 AND user_id = $2`
+
+	queryTransferProject = `
+		UPDATE todo.project SET owner_id = $3, version = version + 1
+		WHERE id = $1 AND owner_id = $2`
+
+	queryDeleteProjects = `
+		DELETE FROM todo.project WHERE id = ANY($1) AND owner_id = $2`
+
+	// Запущенный таймер нельзя передать служебному пользователю:
+	// у него, как и у всех, может быть только один запущенный таймер
+	queryStopTimers = `
+		UPDATE todo.time_entry SET ended_at = GREATEST($2, started_at)
+		WHERE user_id = $1 AND ended_at IS NULL`
+
+	queryAnonymizeTasks       = `UPDATE todo.task SET user_id = $2 WHERE user_id = $1`
+	queryAnonymizeNotes       = `UPDATE todo.note SET user_id = $2 WHERE user_id = $1`
+	queryAnonymizeTimeEntries = `UPDATE todo.time_entry SET user_id = $2 WHERE user_id = $1`
+	queryAnonymizeWebhooks    = `UPDATE todo.webhook SET created_by = $2 WHERE created_by = $1`
+
+	queryDeleteUser = `DELETE FROM todo."user" WHERE id = $1`
+)
+
+type AccountRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+func (r *AccountRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*models.Profile, error) {
+	const op = "AccountRepository.GetProfile"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	var p models.Profile
+	err := r.db.QueryRowContext(ctx, queryGetProfile, userID).Scan(
+		&p.ID, &p.Login, &p.Username, &p.Email, &p.Timezone, &p.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NewNotFoundError("user not found")
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to get profile")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &p, nil
+}
+
+func (r *AccountRepository) GetProjects(ctx context.Context, userID uuid.UUID) ([]models.Project, error) {
+	const op = "AccountRepository.GetProjects"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	rows, err := r.db.QueryContext(ctx, queryGetProjects, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get projects")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	projects := []models.Project{}
+	for rows.Next() {
+		var p models.Project
+		if err := rows.Scan(&p.ID, &p.Name, &p.Role, &p.OwnerID); err != nil {
+			logger.WithError(err).Error("failed to scan project")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		projects = append(projects, p)
+	}
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return projects, nil
+}
+
+func (r *AccountRepository) GetTimeEntries(ctx context.Context, userID uuid.UUID) ([]models.TimeEntry, error) {
+	const op = "AccountRepository.GetTimeEntries"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	rows, err := r.db.QueryContext(ctx, queryGetTimeEntries, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get time entries")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	entries := []models.TimeEntry{}
+	for rows.Next() {
+		var e models.TimeEntry
+		if err := rows.Scan(&e.ID, &e.TaskID, &e.TaskTitle, &e.ProjectID, &e.StartedAt, &e.EndedAt, &e.Note); err != nil {
+			logger.WithError(err).Error("failed to scan time entry")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+// DeleteAccount одной транзакцией передает и удаляет проекты пользователя,
+// переназначает его задачи, заметки, записи времени и вебхуки на служебного
+// пользователя и удаляет аккаунт. Личные данные (участие в проектах, фильтры,
+// уведомления, календари) удаляются каскадом вместе с пользователем.
+// События участников не пишутся: обработчики уведомлений не найдут
+// удаленного пользователя
+func (r *AccountRepository) DeleteAccount(ctx context.Context, deletion models.Deletion, now time.Time) error {
+	const op = "AccountRepository.DeleteAccount"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", deletion.UserID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := checkOwnedProjects(ctx, tx, deletion); err != nil {
+		logger.WithError(err).Warn("owned projects changed")
+		return err
+	}
+
+	for _, t := range deletion.Transfers {
+		if err := transferProject(ctx, tx, t, deletion.UserID); err != nil {
+			logger.WithError(err).WithField("projectID", t.ProjectID).Warn("failed to transfer project")
+			return err
+		}
+	}
+
+	if len(deletion.Deletes) > 0 {
+		result, err := tx.ExecContext(ctx, queryDeleteProjects, pq.Array(deletion.Deletes), deletion.UserID)
+		if err != nil {
+			logger.WithError(err).Error("failed to delete projects")
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			logger.WithError(err).Error("failed to get rows affected")
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if rowsAffected != int64(len(deletion.Deletes)) {
+			return errs.ErrNotOwner
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, queryStopTimers, deletion.UserID, now); err != nil {
+		logger.WithError(err).Error("failed to stop timers")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, query := range []string{queryAnonymizeTasks, queryAnonymizeNotes, queryAnonymizeTimeEntries, queryAnonymizeWebhooks} {
+		if _, err := tx.ExecContext(ctx, query, deletion.UserID, userModels.DeletedUserID); err != nil {
+			logger.WithError(err).Error("failed to anonymize authorship")
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, queryDeleteUser, deletion.UserID)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete user")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		return errs.NewNotFoundError("user not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// checkOwnedProjects блокирует проекты пользователя и проверяет, что по каждому
+// из них принято решение: проект мог быть создан после проверки в usecase
+func checkOwnedProjects(ctx context.Context, tx *sql.Tx, deletion models.Deletion) error {
+	rows, err := tx.QueryContext(ctx, queryLockOwnedProjects, deletion.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to lock owned projects: %w", err)
+	}
+	defer rows.Close()
+
+	var unresolved []string
+	for rows.Next() {
+		var projectID uuid.UUID
+		if err := rows.Scan(&projectID); err != nil {
+			return fmt.Errorf("failed to scan owned project: %w", err)
+		}
+		if !deletion.Covers(projectID) {
+			unresolved = append(unresolved, projectID.String())
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read owned projects: %w", err)
+	}
+
+	if len(unresolved) > 0 {
+		return fmt.Errorf("%w: %s", errs.ErrProjectsUnresolved, strings.Join(unresolved, ", "))
+	}
+	return nil
+}
+
+func transferProject(ctx context.Context, tx *sql.Tx, t models.Transfer, ownerID uuid.UUID) error {
+	result, err := tx.ExecContext(ctx, queryPromoteNewOwner, t.ProjectID, t.NewOwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to promote new owner: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNewOwnerNotMember
+	}
+
+	result, err = tx.ExecContext(ctx, queryTransferProject, t.ProjectID, ownerID, t.NewOwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to transfer project: %w", err)
+	}
+	rowsAffected, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return errs.ErrNotOwner
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/account"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	userModels "github.com/lzimin05/course-todo/internal/models/user"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestAccountRepository_GetProjects(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	projectID := uuid.New()
+
+	mock.ExpectQuery(`SELECT p.id, p.name, pm.role, p.owner_id`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role", "owner_id"}).
+			AddRow(projectID, "Работа", "owner", userID))
+
+	projects, err := repo.GetProjects(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Project{{ID: projectID, Name: "Работа", Role: "owner", OwnerID: userID}}, projects)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAccountRepository_DeleteAccount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+	now := time.Now().UTC()
+
+	userID := uuid.New()
+	sharedID := uuid.New()
+	personalID := uuid.New()
+	newOwnerID := uuid.New()
+
+	deletion := models.Deletion{
+		UserID:    userID,
+		Transfers: []models.Transfer{{ProjectID: sharedID, NewOwnerID: newOwnerID}},
+		Deletes:   []uuid.UUID{personalID},
+	}
+
+	lockOwned := func(ids ...uuid.UUID) {
+		rows := sqlmock.NewRows([]string{"id"})
+		for _, id := range ids {
+			rows.AddRow(id)
+		}
+		mock.ExpectQuery(`SELECT id FROM todo.project WHERE owner_id = \$1 ORDER BY id FOR UPDATE`).
+			WithArgs(userID).
+			WillReturnRows(rows)
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMocks: func() {
+				mock.ExpectBegin()
+				lockOwned(sharedID, personalID)
+				mock.ExpectExec(`UPDATE todo.project_member SET role = 'owner'`).
+					WithArgs(sharedID, newOwnerID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE todo.project SET owner_id = \$3`).
+					WithArgs(sharedID, userID, newOwnerID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM todo.project`).
+					WithArgs(pq.Array([]uuid.UUID{personalID}), userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE todo.time_entry SET ended_at`).
+					WithArgs(userID, now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`UPDATE todo.task SET user_id`).
+					WithArgs(userID, userModels.DeletedUserID).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(`UPDATE todo.note SET user_id`).
+					WithArgs(userID, userModels.DeletedUserID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE todo.time_entry SET user_id`).
+					WithArgs(userID, userModels.DeletedUserID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`UPDATE todo.webhook SET created_by`).
+					WithArgs(userID, userModels.DeletedUserID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`DELETE FROM todo."user"`).
+					WithArgs(userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "project created after check",
+			setupMocks: func() {
+				mock.ExpectBegin()
+				lockOwned(sharedID, personalID, uuid.New())
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrProjectsUnresolved,
+		},
+		{
+			name: "new owner left project",
+			setupMocks: func() {
+				mock.ExpectBegin()
+				lockOwned(sharedID, personalID)
+				mock.ExpectExec(`UPDATE todo.project_member SET role = 'owner'`).
+					WithArgs(sharedID, newOwnerID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrNewOwnerNotMember,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := repo.DeleteAccount(ctx, deletion, now)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	models "github.com/lzimin05/course-todo/internal/models/attachment"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	// Проект родительской сущности, если пользователь в нем участвует
+	queryGetParentProject = `
+		SELECT x.project_id
+		FROM (
+			SELECT t.project_id FROM todo.task t WHERE $1::varchar = 'task' AND t.id = $2
+			UNION ALL
+			SELECT n.project_id FROM todo.note n WHERE $1::varchar = 'note' AND n.id = $2
+		) x
+		JOIN todo.project_member pm ON pm.project_id = x.project_id
+		WHERE pm.user_id = $3`
+
+	queryGetProjectUsage = `
+		SELECT COALESCE(SUM(size), 0) FROM todo.attachment WHERE project_id = $1`
+
+	// Блокировка строки проекта упорядочивает параллельные загрузки,
+	// чтобы они вместе не превысили квоту
+	queryLockProject = `
+		SELECT id FROM todo.project WHERE id = $1 FOR UPDATE`
+
+	queryCreateAttachment = `
+		INSERT INTO todo.attachment
+			(id, project_id, parent_type, parent_id, user_id, file_name, content_type, size, storage_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	queryGetAttachmentsByParent = `
+		SELECT a.id, a.project_id, a.parent_type, a.parent_id, a.user_id,
+			a.file_name, a.content_type, a.size, a.storage_key, a.created_at
+		FROM todo.attachment a
+		JOIN todo.project_member pm ON pm.project_id = a.project_id
+		WHERE a.parent_type = $1 AND a.parent_id = $2 AND pm.user_id = $3
+		ORDER BY a.created_at, a.id`
+
+	queryGetAttachmentByID = `
+		SELECT a.id, a.project_id, a.parent_type, a.parent_id, a.user_id,
+			a.file_name, a.content_type, a.size, a.storage_key, a.created_at
+		FROM todo.attachment a
+		JOIN todo.project_member pm ON pm.project_id = a.project_id
+		WHERE a.id = $1 AND pm.user_id = $2`
+
+	queryDeleteAttachment = `
+		DELETE FROM todo.attachment
+		WHERE id = $1 AND project_id IN (
+			SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $2
+		)`
+
+	queryGetDeletedBlobs = `
+		SELECT storage_key FROM todo.attachment_blob_gc
+		ORDER BY deleted_at
+		LIMIT $1`
+
+	queryRemoveDeletedBlobs = `
+		DELETE FROM todo.attachment_blob_gc WHERE storage_key = ANY($1::varchar[])`
+)
+
+type AttachmentRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+// GetParentProject возвращает проект задачи или заметки, доступной пользователю
+func (r *AttachmentRepository) GetParentProject(ctx context.Context, parentType string, parentID, userID uuid.UUID) (uuid.UUID, error) {
+	const op = "AttachmentRepository.GetParentProject"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("parentType", parentType).
+		WithField("parentID", parentID)
+
+	var projectID uuid.UUID
+	err := r.db.QueryRowContext(ctx, queryGetParentProject, parentType, parentID, userID).Scan(&projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("parent not found")
+			return uuid.Nil, errs.ErrNotFound
+		}
+		logger.WithError(err).Error("failed to get parent project")
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return projectID, nil
+}
+
+// GetProjectUsage возвращает суммарный размер вложений проекта в байтах
+func (r *AttachmentRepository) GetProjectUsage(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	const op = "AttachmentRepository.GetProjectUsage"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	var used int64
+	if err := r.db.QueryRowContext(ctx, queryGetProjectUsage, projectID).Scan(&used); err != nil {
+		logger.WithError(err).Error("failed to get project usage")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return used, nil
+}
+
+// CreateAttachment сохраняет вложение, если с ним проект укладывается в квоту
+func (r *AttachmentRepository) CreateAttachment(ctx context.Context, attachment *models.Attachment, quota int64) error {
+	const op = "AttachmentRepository.CreateAttachment"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", attachment.ProjectID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var projectID uuid.UUID
+	if err := tx.QueryRowContext(ctx, queryLockProject, attachment.ProjectID).Scan(&projectID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("project not found")
+			return errs.ErrNotFound
+		}
+		logger.WithError(err).Error("failed to lock project")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var used int64
+	if err := tx.QueryRowContext(ctx, queryGetProjectUsage, attachment.ProjectID).Scan(&used); err != nil {
+		logger.WithError(err).Error("failed to get project usage")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if used+attachment.Size > quota {
+		logger.Warn("project storage quota exceeded")
+		return errs.ErrQuotaExceeded
+	}
+
+	_, err = tx.ExecContext(ctx, queryCreateAttachment,
+		attachment.ID, attachment.ProjectID, attachment.ParentType, attachment.ParentID, attachment.UserID,
+		attachment.FileName, attachment.ContentType, attachment.Size, attachment.StorageKey, attachment.CreatedAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to create attachment")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *AttachmentRepository) GetAttachmentsByParent(ctx context.Context, parentType string, parentID, userID uuid.UUID) ([]models.Attachment, error) {
+	const op = "AttachmentRepository.GetAttachmentsByParent"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("parentType", parentType).
+		WithField("parentID", parentID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetAttachmentsByParent, parentType, parentID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get attachments")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	attachments := make([]models.Attachment, 0)
+	for rows.Next() {
+		var a models.Attachment
+		if err := rows.Scan(&a.ID, &a.ProjectID, &a.ParentType, &a.ParentID, &a.UserID,
+			&a.FileName, &a.ContentType, &a.Size, &a.StorageKey, &a.CreatedAt); err != nil {
+			logger.WithError(err).Error("failed to scan attachment")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		attachments = append(attachments, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) GetAttachmentByID(ctx context.Context, attachmentID, userID uuid.UUID) (*models.Attachment, error) {
+	const op = "AttachmentRepository.GetAttachmentByID"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("attachmentID", attachmentID)
+
+	var a models.Attachment
+	err := r.db.QueryRowContext(ctx, queryGetAttachmentByID, attachmentID, userID).Scan(
+		&a.ID, &a.ProjectID, &a.ParentType, &a.ParentID, &a.UserID,
+		&a.FileName, &a.ContentType, &a.Size, &a.StorageKey, &a.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("attachment not found")
+			return nil, errs.ErrNotFound
+		}
+		logger.WithError(err).Error("failed to get attachment")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &a, nil
+}
+
+// DeleteAttachment удаляет вложение. Ключ файла попадает в очередь на удаление триггером
+func (r *AttachmentRepository) DeleteAttachment(ctx context.Context, attachmentID, userID uuid.UUID) error {
+	const op = "AttachmentRepository.DeleteAttachment"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("attachmentID", attachmentID)
+
+	result, err := r.db.ExecContext(ctx, queryDeleteAttachment, attachmentID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete attachment")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Warn("attachment not found")
+		return errs.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetDeletedBlobs возвращает ключи файлов удаленных вложений, начиная с самых старых
+func (r *AttachmentRepository) GetDeletedBlobs(ctx context.Context, limit int) ([]string, error) {
+	const op = "AttachmentRepository.GetDeletedBlobs"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	rows, err := r.db.QueryContext(ctx, queryGetDeletedBlobs, limit)
+	if err != nil {
+		logger.WithError(err).Error("failed to get deleted blobs")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			logger.WithError(err).Error("failed to scan blob key")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// RemoveDeletedBlobs убирает из очереди ключи файлов, удаленных из хранилища
+func (r *AttachmentRepository) RemoveDeletedBlobs(ctx context.Context, keys []string) error {
+	const op = "AttachmentRepository.RemoveDeletedBlobs"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	if _, err := r.db.ExecContext(ctx, queryRemoveDeletedBlobs, pq.StringArray(keys)); err != nil {
+		logger.WithError(err).Error("failed to remove deleted blobs")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/attachment"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+var attachmentColumns = []string{"id", "project_id", "parent_type", "parent_id", "user_id",
+	"file_name", "content_type", "size", "storage_key", "created_at"}
+
+func TestAttachmentRepository_GetParentProject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	projectID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "accessible task",
+			setupMocks: func() {
+				mock.ExpectQuery(`SELECT x.project_id`).
+					WithArgs(models.ParentTask, taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+			},
+		},
+		{
+			name: "task not accessible",
+			setupMocks: func() {
+				mock.ExpectQuery(`SELECT x.project_id`).
+					WithArgs(models.ParentTask, taskID, userID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			result, err := repo.GetParentProject(ctx, models.ParentTask, taskID, userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, projectID, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAttachmentRepository_CreateAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	attachment := &models.Attachment{
+		ID:          uuid.New(),
+		ProjectID:   uuid.New(),
+		ParentType:  models.ParentNote,
+		ParentID:    uuid.New(),
+		UserID:      &userID,
+		FileName:    "screen.png",
+		ContentType: "image/png",
+		Size:        300,
+		StorageKey:  "projects/p/a",
+		CreatedAt:   time.Now(),
+	}
+
+	tests := []struct {
+		name        string
+		quota       int64
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name:  "fits into quota",
+			quota: 1000,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT id FROM todo.project WHERE id = \$1 FOR UPDATE`).
+					WithArgs(attachment.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(attachment.ProjectID))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(size\), 0\)`).
+					WithArgs(attachment.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(700))
+				mock.ExpectExec(`INSERT INTO todo.attachment`).
+					WithArgs(attachment.ID, attachment.ProjectID, attachment.ParentType, attachment.ParentID, attachment.UserID,
+						attachment.FileName, attachment.ContentType, attachment.Size, attachment.StorageKey, attachment.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "quota exceeded",
+			quota: 999,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT id FROM todo.project WHERE id = \$1 FOR UPDATE`).
+					WithArgs(attachment.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(attachment.ProjectID))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(size\), 0\)`).
+					WithArgs(attachment.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(700))
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrQuotaExceeded,
+		},
+		{
+			name:  "insert error",
+			quota: 1000,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT id FROM todo.project WHERE id = \$1 FOR UPDATE`).
+					WithArgs(attachment.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(attachment.ProjectID))
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(size\), 0\)`).
+					WithArgs(attachment.ProjectID).
+					WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(0))
+				mock.ExpectExec(`INSERT INTO todo.attachment`).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := repo.CreateAttachment(ctx, attachment, tt.quota)
+
+			if tt.expectedErr == errs.ErrQuotaExceeded {
+				assert.ErrorIs(t, err, errs.ErrQuotaExceeded)
+			} else if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "AttachmentRepository.CreateAttachment")
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAttachmentRepository_GetAttachmentsByParent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	taskID := uuid.New()
+	userID := uuid.New()
+
+	rows := sqlmock.NewRows(attachmentColumns).
+		AddRow(uuid.New(), uuid.New(), models.ParentTask, taskID, userID, "a.pdf", "application/pdf", 10, "k1", time.Now()).
+		AddRow(uuid.New(), uuid.New(), models.ParentTask, taskID, nil, "b.png", "image/png", 20, "k2", time.Now())
+	mock.ExpectQuery(`FROM todo.attachment a`).
+		WithArgs(models.ParentTask, taskID, userID).
+		WillReturnRows(rows)
+
+	attachments, err := repo.GetAttachmentsByParent(ctx, models.ParentTask, taskID, userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 2)
+	assert.Nil(t, attachments[1].UserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachmentRepository_DeleteAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	attachmentID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectExec(`DELETE FROM todo.attachment`).
+		WithArgs(attachmentID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteAttachment(ctx, attachmentID, userID)
+
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachmentRepository_DeletedBlobs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	mock.ExpectQuery(`SELECT storage_key FROM todo.attachment_blob_gc`).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"storage_key"}).AddRow("k1").AddRow("k2"))
+	mock.ExpectExec(`DELETE FROM todo.attachment_blob_gc`).
+		WithArgs(pq.StringArray{"k1", "k2"}).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	keys, err := repo.GetDeletedBlobs(ctx, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"k1", "k2"}, keys)
+
+	assert.NoError(t, repo.RemoveDeletedBlobs(ctx, keys))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
@@ -28,6 +29,27 @@ const (
 		SELECT id, login, username, email, password_hash 
 		FROM todo."user" 
 		WHERE email = $1 or login = $1`
+
+	createAppPasswordQuery = `
+		INSERT INTO todo.app_password (id, user_id, name, password_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	getAppPasswordsQuery = `
+		SELECT id, user_id, name, created_at, last_used_at
+		FROM todo.app_password
+		WHERE user_id = $1
+		ORDER BY created_at`
+
+	deleteAppPasswordQuery = `
+		DELETE FROM todo.app_password
+		WHERE id = $1 AND user_id = $2`
+
+	// Пароль подходит, только если логин или email в Basic-авторизации принадлежат его владельцу
+	useAppPasswordQuery = `
+		UPDATE todo.app_password ap SET last_used_at = $3
+		FROM todo."user" u
+		WHERE u.id = ap.user_id AND ap.password_hash = $1 AND (u.login = $2 OR u.email = $2)
+		RETURNING ap.user_id`
 )
 
 type AuthRepository struct {
@@ -106,3 +128,88 @@ func (r *AuthRepository) GetUserByEmailOrLogin(ctx context.Context, email string
 
 	return &user, nil
 }
+
+func (r *AuthRepository) CreateAppPassword(ctx context.Context, password *models.AppPassword, passwordHash string) error {
+	const op = "AuthRepository.CreateAppPassword"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", password.UserID)
+
+	_, err := r.db.ExecContext(ctx, createAppPasswordQuery,
+		password.ID, password.UserID, password.Name, passwordHash, password.CreatedAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to create app password")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *AuthRepository) GetAppPasswords(ctx context.Context, userID uuid.UUID) ([]models.AppPassword, error) {
+	const op = "AuthRepository.GetAppPasswords"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, getAppPasswordsQuery, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get app passwords")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var passwords []models.AppPassword
+	for rows.Next() {
+		var p models.AppPassword
+		if err := rows.Scan(&p.ID, &p.UserID, &p.Name, &p.CreatedAt, &p.LastUsedAt); err != nil {
+			logger.WithError(err).Error("failed to scan app password")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		passwords = append(passwords, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return passwords, nil
+}
+
+func (r *AuthRepository) DeleteAppPassword(ctx context.Context, id, userID uuid.UUID) error {
+	const op = "AuthRepository.DeleteAppPassword"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("id", id)
+
+	result, err := r.db.ExecContext(ctx, deleteAppPasswordQuery, id, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete app password")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rows == 0 {
+		logger.Warn("app password not found")
+		return errs.NewNotFoundError("app password not found")
+	}
+
+	return nil
+}
+
+// UseAppPassword находит владельца пароля приложения и отмечает время использования
+func (r *AuthRepository) UseAppPassword(ctx context.Context, loginOrEmail, passwordHash string, usedAt time.Time) (uuid.UUID, error) {
+	const op = "AuthRepository.UseAppPassword"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("login", loginOrEmail)
+
+	var userID uuid.UUID
+	err := r.db.QueryRowContext(ctx, useAppPasswordQuery, passwordHash, loginOrEmail, usedAt).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Warn("app password not found")
+		return uuid.Nil, errs.ErrInvalidCredentials
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to use app password")
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userID, nil
+}
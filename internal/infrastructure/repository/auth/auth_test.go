@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
@@ -297,3 +298,49 @@ func TestNew_AuthRepository(t *testing.T) {
 	assert.NotNil(t, repo)
 	assert.Equal(t, db, repo.db)
 }
+
+func TestAuthRepository_UseAppPassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	usedAt := time.Now().UTC()
+
+	mock.ExpectQuery(`UPDATE todo.app_password ap SET last_used_at = \$3`).
+		WithArgs("hash", "testuser", usedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userID))
+	mock.ExpectQuery(`UPDATE todo.app_password ap SET last_used_at = \$3`).
+		WithArgs("wrong", "testuser", usedAt).
+		WillReturnError(sql.ErrNoRows)
+
+	id, err := repo.UseAppPassword(ctx, "testuser", "hash", usedAt)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, id)
+
+	_, err = repo.UseAppPassword(ctx, "testuser", "wrong", usedAt)
+	assert.ErrorIs(t, err, errs.ErrInvalidCredentials)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthRepository_DeleteAppPassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	id, userID := uuid.New(), uuid.New()
+
+	mock.ExpectExec(`DELETE FROM todo.app_password`).
+		WithArgs(id, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteAppPassword(ctx, id, userID)
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
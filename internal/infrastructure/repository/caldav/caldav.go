@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	models "github.com/lzimin05/course-todo/internal/models/caldav"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	// Номер изменения коллекции - максимум по задачам и журналу удалений проекта
+	collectionColumns = `
+		SELECT p.id, p.name, p.description,
+			GREATEST(
+				COALESCE((SELECT MAX(t.sync_seq) FROM todo.task t WHERE t.project_id = p.id), 0),
+				COALESCE((SELECT MAX(ts.sync_seq) FROM todo.task_tombstone ts WHERE ts.project_id = p.id), 0)
+			)
+		FROM todo.project p
+		JOIN todo.project_member pm ON pm.project_id = p.id
+		WHERE pm.user_id = $1`
+
+	queryGetCollections = collectionColumns + `
+		ORDER BY p.name, p.id`
+
+	queryGetCollection = collectionColumns + `
+		AND p.id = $2`
+
+	// Задачи без сохраненного имени ресурса и UID получают их из ID
+	objectColumns = `
+		SELECT t.id, t.project_id,
+			COALESCE(t.caldav_name, t.id::text || '.ics'),
+			COALESCE(t.caldav_uid, t.id::text || '@course-todo'),
+			t.title, t.description, t.status, t.importance, t.start_at, t.deadline, t.all_day,
+			t.created_at, t.completed_at, t.version, t.sync_seq, t.estimate_minutes
+		FROM todo.task t
+		JOIN todo.project_member pm ON pm.project_id = t.project_id AND pm.user_id = $2
+		WHERE t.project_id = $1`
+
+	queryGetObjects = objectColumns + `
+		ORDER BY t.created_at, t.id`
+
+	queryGetObjectByName = objectColumns + `
+		AND COALESCE(t.caldav_name, t.id::text || '.ics') = $3`
+
+	queryGetObjectsByNames = objectColumns + `
+		AND COALESCE(t.caldav_name, t.id::text || '.ics') = ANY($3)
+		ORDER BY t.created_at, t.id`
+
+	queryGetChangedObjects = objectColumns + `
+		AND t.sync_seq > $3
+		ORDER BY t.sync_seq`
+
+	// Имя удаленной задачи могла занять новая задача, тогда ресурс не удален
+	queryGetDeletedNames = `
+		SELECT ts.name
+		FROM todo.task_tombstone ts
+		JOIN todo.project_member pm ON pm.project_id = ts.project_id AND pm.user_id = $2
+		WHERE ts.project_id = $1 AND ts.sync_seq > $3
+			AND NOT EXISTS (
+				SELECT 1 FROM todo.task t
+				WHERE t.project_id = ts.project_id
+					AND COALESCE(t.caldav_name, t.id::text || '.ics') = ts.name
+			)
+		ORDER BY ts.sync_seq`
+)
+
+type CalDAVRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *CalDAVRepository {
+	return &CalDAVRepository{db: db}
+}
+
+// GetCollections возвращает проекты пользователя
+func (r *CalDAVRepository) GetCollections(ctx context.Context, userID uuid.UUID) ([]models.Collection, error) {
+	const op = "CalDAVRepository.GetCollections"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetCollections, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get collections")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var collections []models.Collection
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan collection")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		collections = append(collections, *collection)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collections, nil
+}
+
+// GetCollection возвращает проект, если пользователь в нем участвует
+func (r *CalDAVRepository) GetCollection(ctx context.Context, projectID, userID uuid.UUID) (*models.Collection, error) {
+	const op = "CalDAVRepository.GetCollection"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	collection, err := scanCollection(r.db.QueryRowContext(ctx, queryGetCollection, userID, projectID))
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Warn("collection not found or not accessible")
+		return nil, errs.NewNotFoundError("collection not found")
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to get collection")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collection, nil
+}
+
+func (r *CalDAVRepository) GetObjects(ctx context.Context, projectID, userID uuid.UUID) ([]models.Object, error) {
+	const op = "CalDAVRepository.GetObjects"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	objects, err := r.queryObjects(ctx, queryGetObjects, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get objects")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return objects, nil
+}
+
+func (r *CalDAVRepository) GetObjectByName(ctx context.Context, projectID, userID uuid.UUID, name string) (*models.Object, error) {
+	const op = "CalDAVRepository.GetObjectByName"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", projectID).
+		WithField("name", name)
+
+	object, err := scanObject(r.db.QueryRowContext(ctx, queryGetObjectByName, projectID, userID, name))
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Warn("object not found")
+		return nil, errs.NewNotFoundError("calendar object not found")
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to get object")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return object, nil
+}
+
+// GetObjectsByNames возвращает найденные ресурсы для calendar-multiget.
+// Отсутствующие имена просто не попадают в результат
+func (r *CalDAVRepository) GetObjectsByNames(ctx context.Context, projectID, userID uuid.UUID, names []string) ([]models.Object, error) {
+	const op = "CalDAVRepository.GetObjectsByNames"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	objects, err := r.queryObjects(ctx, queryGetObjectsByNames, projectID, userID, pq.Array(names))
+	if err != nil {
+		logger.WithError(err).Error("failed to get objects by names")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return objects, nil
+}
+
+// GetChanges возвращает задачи, измененные после номера since, и имена удаленных
+func (r *CalDAVRepository) GetChanges(ctx context.Context, projectID, userID uuid.UUID, since int64) ([]models.Object, []string, error) {
+	const op = "CalDAVRepository.GetChanges"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", projectID).
+		WithField("since", since)
+
+	objects, err := r.queryObjects(ctx, queryGetChangedObjects, projectID, userID, since)
+	if err != nil {
+		logger.WithError(err).Error("failed to get changed objects")
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryGetDeletedNames, projectID, userID, since)
+	if err != nil {
+		logger.WithError(err).Error("failed to get deleted objects")
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var deleted []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			logger.WithError(err).Error("failed to scan deleted object")
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		deleted = append(deleted, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return objects, deleted, nil
+}
+
+func (r *CalDAVRepository) queryObjects(ctx context.Context, query string, args ...interface{}) ([]models.Object, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var objects []models.Object
+	for rows.Next() {
+		object, err := scanObject(rows)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, *object)
+	}
+
+	return objects, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanCollection(row rowScanner) (*models.Collection, error) {
+	var (
+		c           models.Collection
+		description sql.NullString
+	)
+	if err := row.Scan(&c.ProjectID, &c.Name, &description, &c.SyncSeq); err != nil {
+		return nil, err
+	}
+	c.Description = description.String
+	return &c, nil
+}
+
+func scanObject(row rowScanner) (*models.Object, error) {
+	var o models.Object
+	err := row.Scan(&o.TaskID, &o.ProjectID, &o.Name, &o.UID,
+		&o.Title, &o.Description, &o.Status, &o.Importance, &o.StartAt, &o.Deadline, &o.AllDay,
+		&o.CreatedAt, &o.CompletedAt, &o.Version, &o.SyncSeq, &o.EstimateMinutes)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
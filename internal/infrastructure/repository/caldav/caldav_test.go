@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+var objectRowColumns = []string{"id", "project_id", "name", "uid", "title", "description", "status", "importance",
+	"start_at", "deadline", "all_day", "created_at", "completed_at", "version", "sync_seq", "estimate_minutes"}
+
+func TestCalDAVRepository_GetCollection(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID, userID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`GREATEST`).
+		WithArgs(userID, projectID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "sync_seq"}).
+			AddRow(projectID, "Backend", nil, 42))
+	mock.ExpectQuery(`GREATEST`).
+		WithArgs(userID, projectID).
+		WillReturnError(sql.ErrNoRows)
+
+	collection, err := repo.GetCollection(ctx, projectID, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Backend", collection.Name)
+	assert.Equal(t, "", collection.Description)
+	assert.Equal(t, int64(42), collection.SyncSeq)
+
+	_, err = repo.GetCollection(ctx, projectID, userID)
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCalDAVRepository_GetObjectByName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID, userID, taskID := uuid.New(), uuid.New(), uuid.New()
+	deadline := time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`COALESCE\(t.caldav_name, t.id::text \|\| '.ics'\) = \$3`).
+		WithArgs(projectID, userID, "ABC.ics").
+		WillReturnRows(sqlmock.NewRows(objectRowColumns).
+			AddRow(taskID, projectID, "ABC.ics", "ABC", "Молоко", "", "waiting", 2,
+				nil, deadline, false, deadline, nil, 3, 17, nil))
+
+	object, err := repo.GetObjectByName(ctx, projectID, userID, "ABC.ics")
+
+	assert.NoError(t, err)
+	assert.Equal(t, taskID, object.TaskID)
+	assert.Equal(t, "ABC", object.UID)
+	assert.Equal(t, 3, object.Version)
+	assert.Equal(t, int64(17), object.SyncSeq)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCalDAVRepository_GetChanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID, userID := uuid.New(), uuid.New()
+	deadline := time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`t.sync_seq > \$3`).
+		WithArgs(projectID, userID, int64(10)).
+		WillReturnRows(sqlmock.NewRows(objectRowColumns).
+			AddRow(uuid.New(), projectID, "a.ics", "a", "Задача", "", "completed", 3,
+				nil, deadline, false, deadline, deadline, 2, 11, nil))
+	mock.ExpectQuery(`FROM todo.task_tombstone ts`).
+		WithArgs(projectID, userID, int64(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("b.ics"))
+
+	objects, deleted, err := repo.GetChanges(ctx, projectID, userID, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, []string{"b.ics"}, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
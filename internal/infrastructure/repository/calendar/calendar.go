@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/calendar"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	// Задача занимает отрезок от start_at (или дедлайна) до дедлайна (или start_at).
+	// Задачи на весь день сравниваются с окном по датам ($4, $5), остальные - по
+	// моментам времени ($2, $3). Задачи без обеих дат в календарь не попадают
+	queryGetCalendarEntries = `
+		SELECT t.id, t.project_id, p.name, t.title, t.status, t.importance, t.start_at, t.deadline, t.all_day
+		FROM todo.task t
+		JOIN todo.project_member pm ON pm.project_id = t.project_id
+		JOIN todo.project p ON p.id = t.project_id
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(t.start_at, t.deadline) AS starts,
+				CASE WHEN t.deadline > '0001-01-01'::timestamp THEN t.deadline ELSE t.start_at END AS ends
+		) s
+		WHERE pm.user_id = $1
+			AND (t.start_at IS NOT NULL OR t.deadline > '0001-01-01'::timestamp)
+			AND CASE WHEN t.all_day
+				THEN s.starts < $5 AND s.ends >= $4
+				ELSE s.starts < $3 AND s.ends >= $2
+			END
+		ORDER BY s.starts, t.id`
+)
+
+type CalendarRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *CalendarRepository {
+	return &CalendarRepository{db: db}
+}
+
+// GetCalendarEntries возвращает задачи из проектов пользователя, которые пересекают
+// окно [from, to) в UTC. Для задач на весь день окно задают даты [fromDate, toDate)
+func (r *CalendarRepository) GetCalendarEntries(ctx context.Context, userID uuid.UUID, from, to, fromDate, toDate time.Time) ([]models.Entry, error) {
+	const op = "CalendarRepository.GetCalendarEntries"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetCalendarEntries, userID, from, to, fromDate, toDate)
+	if err != nil {
+		logger.WithError(err).Error("failed to get calendar entries")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var entries []models.Entry
+	for rows.Next() {
+		var e models.Entry
+		err := rows.Scan(&e.TaskID, &e.ProjectID, &e.ProjectName, &e.Title, &e.Status, &e.Importance, &e.StartAt, &e.Deadline, &e.AllDay)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan calendar entry")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
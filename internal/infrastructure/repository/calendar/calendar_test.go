@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestCalendarRepository_GetCalendarEntries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	from := time.Date(2025, 2, 28, 21, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 7, 21, 0, 0, 0, time.UTC)
+	fromDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)
+	startAt := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`CASE WHEN t.all_day`).
+		WithArgs(userID, from, to, fromDate, toDate).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "name", "title", "status", "importance", "start_at", "deadline", "all_day"}).
+			AddRow(uuid.New(), uuid.New(), "Backend", "Релиз", "waiting", 2, startAt, startAt.AddDate(0, 0, 2), true).
+			AddRow(uuid.New(), uuid.New(), "Backend", "Созвон", "waiting", 1, nil, time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC), false))
+
+	entries, err := repo.GetCalendarEntries(ctx, userID, from, to, fromDate, toDate)
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.True(t, entries[0].AllDay)
+	assert.Equal(t, startAt, *entries[0].StartAt)
+	assert.Nil(t, entries[1].StartAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/calendar"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	queryDeleteFeed = `
+		DELETE FROM todo.calendar_feed
+		WHERE user_id = $1 AND project_id IS NOT DISTINCT FROM $2`
+
+	queryDeleteFeedByID = `
+		DELETE FROM todo.calendar_feed
+		WHERE id = $1 AND user_id = $2`
+
+	queryCreateFeed = `
+		INSERT INTO todo.calendar_feed (id, user_id, project_id, token_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	queryGetFeeds = `
+		SELECT id, user_id, project_id, created_at
+		FROM todo.calendar_feed
+		WHERE user_id = $1
+		ORDER BY project_id NULLS FIRST, created_at`
+
+	queryGetFeedByTokenHash = `
+		SELECT id, user_id, project_id, created_at
+		FROM todo.calendar_feed
+		WHERE token_hash = $1`
+
+	// Участие в проекте проверяется при каждом запросе фида:
+	// после выхода из проекта его задачи пропадают и из календаря
+	queryGetFeedTasks = `
+		SELECT t.id, p.name, t.title, t.description, t.status, t.importance,
+			t.start_at, t.deadline, t.all_day, t.created_at, t.completed_at, t.version
+		FROM todo.task t
+		JOIN todo.project_member pm ON pm.project_id = t.project_id
+		JOIN todo.project p ON p.id = t.project_id
+		WHERE pm.user_id = $1
+			AND ($2::uuid IS NULL OR t.project_id = $2)
+			AND t.deadline > '0001-01-01'::timestamp
+		ORDER BY t.deadline, t.id`
+)
+
+// ReplaceFeed создает фид, удаляя прежний фид пользователя с тем же проектом.
+// Старая ссылка после этого перестает работать
+func (r *CalendarRepository) ReplaceFeed(ctx context.Context, feed *models.Feed, tokenHash string) error {
+	const op = "CalendarRepository.ReplaceFeed"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", feed.UserID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, queryDeleteFeed, feed.UserID, feed.ProjectID); err != nil {
+		logger.WithError(err).Error("failed to delete previous feed")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, queryCreateFeed, feed.ID, feed.UserID, feed.ProjectID, tokenHash, feed.CreatedAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to create feed")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *CalendarRepository) DeleteFeed(ctx context.Context, feedID, userID uuid.UUID) error {
+	const op = "CalendarRepository.DeleteFeed"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("feedID", feedID)
+
+	result, err := r.db.ExecContext(ctx, queryDeleteFeedByID, feedID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete feed")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rows == 0 {
+		logger.Warn("feed not found")
+		return errs.NewNotFoundError("calendar feed not found")
+	}
+
+	return nil
+}
+
+func (r *CalendarRepository) GetFeeds(ctx context.Context, userID uuid.UUID) ([]models.Feed, error) {
+	const op = "CalendarRepository.GetFeeds"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetFeeds, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get feeds")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var feeds []models.Feed
+	for rows.Next() {
+		feed, err := scanFeed(rows)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan feed")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		feeds = append(feeds, *feed)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return feeds, nil
+}
+
+// GetFeedByTokenHash находит фид по хешу токена из ссылки
+func (r *CalendarRepository) GetFeedByTokenHash(ctx context.Context, tokenHash string) (*models.Feed, error) {
+	const op = "CalendarRepository.GetFeedByTokenHash"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	feed, err := scanFeed(r.db.QueryRowContext(ctx, queryGetFeedByTokenHash, tokenHash))
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Warn("feed not found")
+		return nil, errs.NewNotFoundError("calendar feed not found")
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to get feed")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return feed, nil
+}
+
+// GetFeedTasks возвращает задачи с дедлайном из проектов пользователя.
+// projectID ограничивает выборку одним проектом
+func (r *CalendarRepository) GetFeedTasks(ctx context.Context, userID uuid.UUID, projectID *uuid.UUID) ([]models.FeedTask, error) {
+	const op = "CalendarRepository.GetFeedTasks"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetFeedTasks, userID, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get feed tasks")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []models.FeedTask
+	for rows.Next() {
+		var t models.FeedTask
+		err := rows.Scan(&t.TaskID, &t.ProjectName, &t.Title, &t.Description, &t.Status, &t.Importance,
+			&t.StartAt, &t.Deadline, &t.AllDay, &t.CreatedAt, &t.CompletedAt, &t.Version)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan feed task")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tasks = append(tasks, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanFeed(row rowScanner) (*models.Feed, error) {
+	var feed models.Feed
+	if err := row.Scan(&feed.ID, &feed.UserID, &feed.ProjectID, &feed.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
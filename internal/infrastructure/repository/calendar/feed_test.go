@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/calendar"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestCalendarRepository_ReplaceFeed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	feed := &models.Feed{ID: uuid.New(), UserID: uuid.New(), ProjectID: &projectID, CreatedAt: time.Now().UTC()}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM todo.calendar_feed\s+WHERE user_id = \$1 AND project_id IS NOT DISTINCT FROM \$2`).
+		WithArgs(feed.UserID, &projectID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO todo.calendar_feed`).
+		WithArgs(feed.ID, feed.UserID, &projectID, "hash", feed.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.ReplaceFeed(ctx, feed, "hash")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCalendarRepository_DeleteFeed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	feedID, userID := uuid.New(), uuid.New()
+
+	mock.ExpectExec(`DELETE FROM todo.calendar_feed\s+WHERE id = \$1 AND user_id = \$2`).
+		WithArgs(feedID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteFeed(ctx, feedID, userID)
+
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCalendarRepository_GetFeedByTokenHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	feedID, userID := uuid.New(), uuid.New()
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`WHERE token_hash = \$1`).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "project_id", "created_at"}).
+			AddRow(feedID, userID, nil, createdAt))
+	mock.ExpectQuery(`WHERE token_hash = \$1`).
+		WithArgs("revoked").
+		WillReturnError(sql.ErrNoRows)
+
+	feed, err := repo.GetFeedByTokenHash(ctx, "hash")
+	assert.NoError(t, err)
+	assert.Equal(t, userID, feed.UserID)
+	assert.Nil(t, feed.ProjectID)
+
+	_, err = repo.GetFeedByTokenHash(ctx, "revoked")
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCalendarRepository_GetFeedTasks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	deadline := time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC)
+	completedAt := deadline.Add(-time.Hour)
+
+	mock.ExpectQuery(`t.deadline > '0001-01-01'::timestamp`).
+		WithArgs(userID, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "title", "description", "status", "importance",
+			"start_at", "deadline", "all_day", "created_at", "completed_at", "version"}).
+			AddRow(uuid.New(), "Backend", "Релиз", "", "completed", 3, nil, deadline, false, deadline.AddDate(0, 0, -7), completedAt, 4))
+
+	tasks, err := repo.GetFeedTasks(ctx, userID, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, "Backend", tasks[0].ProjectName)
+	assert.Equal(t, completedAt, *tasks[0].CompletedAt)
+	assert.Equal(t, 4, tasks[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
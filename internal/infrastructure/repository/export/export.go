@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/export"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	queryExportProject = `
+	SELECT id, name, COALESCE(description, ''), owner_id, created_at
+	FROM todo.project
+	WHERE id = $1`
+
+	queryExportMembers = `
+	SELECT u.id, u.login, u.username, pm.role, pm.joined_at
+	FROM todo.project_member pm
+	JOIN todo."user" u ON u.id = pm.user_id
+	WHERE pm.project_id = $1
+	ORDER BY pm.joined_at, u.login`
+
+	// Задача без срока хранит нулевую дату, в выгрузке срок отсутствует
+	queryExportTasks = `
+	SELECT t.id, t.user_id, u.login, t.title, COALESCE(t.description, ''), t.status, COALESCE(t.importance, 1),
+		NULLIF(t.deadline, '0001-01-01'::timestamp), t.start_at, t.all_day, t.estimate_minutes, t.created_at, t.completed_at
+	FROM todo.task t
+	JOIN todo."user" u ON u.id = t.user_id
+	WHERE t.project_id = $1
+	ORDER BY t.created_at, t.id`
+
+	queryExportNotes = `
+	SELECT n.id, n.user_id, u.login, n.name, COALESCE(n.description, ''), n.format, n.created_at
+	FROM todo.note n
+	JOIN todo."user" u ON u.id = n.user_id
+	WHERE n.project_id = $1
+	ORDER BY n.created_at, n.id`
+)
+
+type ExportRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *ExportRepository {
+	return &ExportRepository{db: db}
+}
+
+// ExportProject читает проект, участников, задачи и заметки построчно и сразу
+// передает их в w, не собирая выгрузку в памяти. Все запросы выполняются в одной
+// транзакции только для чтения, поэтому выгрузка - согласованный снимок проекта
+func (r *ExportRepository) ExportProject(ctx context.Context, projectID uuid.UUID, w models.Writer) error {
+	const op = "ExportRepository.ExportProject"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var project models.Project
+	err = tx.QueryRowContext(ctx, queryExportProject, projectID).
+		Scan(&project.ID, &project.Name, &project.Description, &project.OwnerID, &project.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.ErrNotFound
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to get project")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := w.WriteProject(project); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = queryEach(ctx, tx, queryExportMembers, projectID, func(rows *sql.Rows) error {
+		var m models.Member
+		if err := rows.Scan(&m.UserID, &m.Login, &m.Username, &m.Role, &m.JoinedAt); err != nil {
+			return err
+		}
+		return w.WriteMember(m)
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to export members")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = queryEach(ctx, tx, queryExportTasks, projectID, func(rows *sql.Rows) error {
+		var t models.Task
+		var estimate sql.NullInt64
+		if err := rows.Scan(&t.ID, &t.AuthorID, &t.AuthorLogin, &t.Title, &t.Description, &t.Status, &t.Importance,
+			&t.Deadline, &t.StartAt, &t.AllDay, &estimate, &t.CreatedAt, &t.CompletedAt); err != nil {
+			return err
+		}
+		if estimate.Valid {
+			minutes := int(estimate.Int64)
+			t.EstimateMinutes = &minutes
+		}
+		return w.WriteTask(t)
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to export tasks")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = queryEach(ctx, tx, queryExportNotes, projectID, func(rows *sql.Rows) error {
+		var n models.Note
+		if err := rows.Scan(&n.ID, &n.AuthorID, &n.AuthorLogin, &n.Name, &n.Description, &n.Format, &n.CreatedAt); err != nil {
+			return err
+		}
+		return w.WriteNote(n)
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to export notes")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// queryEach вызывает fn для каждой строки результата запроса
+func queryEach(ctx context.Context, tx *sql.Tx, query string, projectID uuid.UUID, fn func(rows *sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query, projectID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
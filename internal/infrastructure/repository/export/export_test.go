@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/export"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+// recordingWriter запоминает типы полученных записей
+type recordingWriter struct {
+	records []string
+	tasks   []models.Task
+}
+
+func (w *recordingWriter) WriteProject(models.Project) error {
+	w.records = append(w.records, models.RecordProject)
+	return nil
+}
+
+func (w *recordingWriter) WriteMember(models.Member) error {
+	w.records = append(w.records, models.RecordMember)
+	return nil
+}
+
+func (w *recordingWriter) WriteTask(t models.Task) error {
+	w.records = append(w.records, models.RecordTask)
+	w.tasks = append(w.tasks, t)
+	return nil
+}
+
+func (w *recordingWriter) WriteNote(models.Note) error {
+	w.records = append(w.records, models.RecordNote)
+	return nil
+}
+
+func TestExportRepository_ExportProject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	t.Run("streams records in order", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM todo.project`).
+			WithArgs(projectID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "owner_id", "created_at"}).
+				AddRow(projectID, "Launch", "", userID, now))
+		mock.ExpectQuery(`FROM todo.project_member pm`).
+			WithArgs(projectID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "login", "username", "role", "joined_at"}).
+				AddRow(userID, "anna", "Anna", "owner", now))
+		mock.ExpectQuery(`FROM todo.task t`).
+			WithArgs(projectID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "login", "title", "description", "status", "importance",
+				"deadline", "start_at", "all_day", "estimate_minutes", "created_at", "completed_at"}).
+				AddRow(uuid.New(), userID, "anna", "First", "", "waiting", 1, now, nil, false, 30, now, nil).
+				AddRow(uuid.New(), userID, "anna", "Second", "", "completed", 2, nil, nil, true, nil, now, now))
+		mock.ExpectQuery(`FROM todo.note n`).
+			WithArgs(projectID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "login", "name", "description", "format", "created_at"}).
+				AddRow(uuid.New(), userID, "anna", "Plan", "text", "plain", now))
+		mock.ExpectRollback()
+
+		w := &recordingWriter{}
+		assert.NoError(t, repo.ExportProject(ctx, projectID, w))
+		assert.Equal(t, []string{"project", "member", "task", "task", "note"}, w.records)
+		assert.Equal(t, 30, *w.tasks[0].EstimateMinutes)
+		assert.Nil(t, w.tasks[1].EstimateMinutes)
+		assert.NotNil(t, w.tasks[1].CompletedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("project not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM todo.project`).
+			WithArgs(projectID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "owner_id", "created_at"}))
+		mock.ExpectRollback()
+
+		w := &recordingWriter{}
+		assert.ErrorIs(t, repo.ExportProject(ctx, projectID, w), errs.ErrNotFound)
+		assert.Empty(t, w.records)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM todo.project`).
+			WithArgs(projectID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "owner_id", "created_at"}).
+				AddRow(projectID, "Launch", "", userID, now))
+		mock.ExpectQuery(`FROM todo.project_member pm`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.ExportProject(ctx, projectID, &recordingWriter{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "ExportRepository.ExportProject")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
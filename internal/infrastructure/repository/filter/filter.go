@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/filter"
+	taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	queryCreateFilter = `
+	INSERT INTO todo.saved_filter (id, user_id, name, definition, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6)`
+
+	queryGetFiltersByUserID = `
+	SELECT id, user_id, name, definition, created_at, updated_at
+	FROM todo.saved_filter
+	WHERE user_id = $1
+	ORDER BY name`
+
+	queryGetFilterByID = `
+	SELECT id, user_id, name, definition, created_at, updated_at
+	FROM todo.saved_filter
+	WHERE id = $1 AND user_id = $2`
+
+	queryUpdateFilter = `
+	UPDATE todo.saved_filter
+	SET name = $3, definition = $4, updated_at = $5
+	WHERE id = $1 AND user_id = $2`
+
+	queryDeleteFilter = `
+	DELETE FROM todo.saved_filter
+	WHERE id = $1 AND user_id = $2`
+
+	// Базовый запрос задач фильтра, условия добавляются в buildTaskQuery
+	queryFilterTasksBase = `
+	SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline, t.completed_at, t.version, t.estimate_minutes, t.start_at, t.all_day,
+		cl.done, cl.total
+	FROM todo.task t
+	JOIN todo.project_member pm ON t.project_id = pm.project_id
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) FILTER (WHERE ci.done) AS done, COUNT(*) AS total
+		FROM todo.task_checklist_item ci WHERE ci.task_id = t.id
+	) cl
+	WHERE pm.user_id = $1`
+
+	queryFilterTasksOrder = `
+	ORDER BY (t.deadline <= '0001-01-01'::timestamp), t.deadline, t.importance DESC, t.created_at`
+
+	uniqueViolationCode = "23505"
+)
+
+type FilterRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *FilterRepository {
+	return &FilterRepository{db: db}
+}
+
+func (r *FilterRepository) CreateFilter(ctx context.Context, filter *models.SavedFilter) error {
+	const op = "FilterRepository.CreateFilter"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("name", filter.Name)
+
+	definition, err := json.Marshal(filter.Definition)
+	if err != nil {
+		logger.WithError(err).Error("failed to marshal filter definition")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = r.db.ExecContext(ctx, queryCreateFilter,
+		filter.ID, filter.UserID, filter.Name, definition, filter.CreatedAt, filter.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolationCode {
+			logger.WithError(err).Warn("filter with this name already exists")
+			return fmt.Errorf("%s: %w", op, errs.ErrFilterNameTaken)
+		}
+		logger.WithError(err).Error("failed to create filter")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *FilterRepository) GetFiltersByUserID(ctx context.Context, userID uuid.UUID) ([]*models.SavedFilter, error) {
+	const op = "FilterRepository.GetFiltersByUserID"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetFiltersByUserID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get filters")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var filters []*models.SavedFilter
+	for rows.Next() {
+		filter, err := scanFilter(rows)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan filter")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		filters = append(filters, filter)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return filters, nil
+}
+
+func (r *FilterRepository) GetFilterByID(ctx context.Context, filterID, userID uuid.UUID) (*models.SavedFilter, error) {
+	const op = "FilterRepository.GetFilterByID"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("filterID", filterID)
+
+	filter, err := scanFilter(r.db.QueryRowContext(ctx, queryGetFilterByID, filterID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("filter not found")
+			return nil, fmt.Errorf("%s: %w", op, errs.ErrNotFound)
+		}
+		logger.WithError(err).Error("failed to get filter")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return filter, nil
+}
+
+func (r *FilterRepository) UpdateFilter(ctx context.Context, filter *models.SavedFilter) error {
+	const op = "FilterRepository.UpdateFilter"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("filterID", filter.ID)
+
+	definition, err := json.Marshal(filter.Definition)
+	if err != nil {
+		logger.WithError(err).Error("failed to marshal filter definition")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	result, err := r.db.ExecContext(ctx, queryUpdateFilter,
+		filter.ID, filter.UserID, filter.Name, definition, filter.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolationCode {
+			logger.WithError(err).Warn("filter with this name already exists")
+			return fmt.Errorf("%s: %w", op, errs.ErrFilterNameTaken)
+		}
+		logger.WithError(err).Error("failed to update filter")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Warn("filter not found")
+		return fmt.Errorf("%s: %w", op, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *FilterRepository) DeleteFilter(ctx context.Context, filterID, userID uuid.UUID) error {
+	const op = "FilterRepository.DeleteFilter"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("filterID", filterID)
+
+	result, err := r.db.ExecContext(ctx, queryDeleteFilter, filterID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete filter")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Warn("filter not found")
+		return fmt.Errorf("%s: %w", op, errs.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (r *FilterRepository) GetTasksByQuery(ctx context.Context, query models.TaskQuery) ([]*taskmodels.Task, error) {
+	const op = "FilterRepository.GetTasksByQuery"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", query.UserID)
+
+	sqlQuery, args := buildTaskQuery(query)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		logger.WithError(err).Error("failed to get filtered tasks")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []*taskmodels.Task
+	for rows.Next() {
+		var t taskmodels.Task
+		err := rows.Scan(&t.ID, &t.ProjectID, &t.UserID, &t.Title, &t.Description, &t.Importance, &t.Status, &t.CreatedAt, &t.Deadline, &t.CompletedAt, &t.Version, &t.EstimateMinutes, &t.StartAt, &t.AllDay, &t.ChecklistDone, &t.ChecklistTotal)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan task")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tasks = append(tasks, &t)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// buildTaskQuery собирает параметризованный запрос по условиям фильтра
+func buildTaskQuery(query models.TaskQuery) (string, []interface{}) {
+	var sb strings.Builder
+	sb.WriteString(queryFilterTasksBase)
+	args := []interface{}{query.UserID}
+
+	addArg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(query.ProjectIDs) > 0 {
+		ids := make([]string, len(query.ProjectIDs))
+		for i, id := range query.ProjectIDs {
+			ids[i] = id.String()
+		}
+		sb.WriteString("\n\tAND t.project_id = ANY(" + addArg(pq.StringArray(ids)) + "::uuid[])")
+	}
+	if len(query.Statuses) > 0 {
+		sb.WriteString("\n\tAND t.status = ANY(" + addArg(pq.StringArray(query.Statuses)) + ")")
+	}
+	if len(query.Importance) > 0 {
+		importance := make([]int64, len(query.Importance))
+		for i, v := range query.Importance {
+			importance[i] = int64(v)
+		}
+		sb.WriteString("\n\tAND t.importance = ANY(" + addArg(pq.Int64Array(importance)) + ")")
+	}
+	if query.OnlyMine {
+		sb.WriteString("\n\tAND t.user_id = $1")
+	}
+	// Задачи без дедлайна хранятся с нулевой датой и не попадают в диапазоны по дедлайну
+	if query.OverdueAt != nil || query.DeadlineFrom != nil || query.DeadlineBefore != nil {
+		sb.WriteString("\n\tAND t.deadline > '0001-01-01'::timestamp")
+	}
+	if query.OverdueAt != nil {
+		sb.WriteString("\n\tAND t.status <> 'completed' AND t.deadline < " + addArg(query.OverdueAt.UTC()))
+	}
+	if query.DeadlineFrom != nil {
+		sb.WriteString("\n\tAND t.deadline >= " + addArg(query.DeadlineFrom.UTC()))
+	}
+	if query.DeadlineBefore != nil {
+		sb.WriteString("\n\tAND t.deadline < " + addArg(query.DeadlineBefore.UTC()))
+	}
+	if query.Text != "" {
+		pattern := addArg("%" + escapeLike(query.Text) + "%")
+		sb.WriteString("\n\tAND (t.title ILIKE " + pattern + " OR t.description ILIKE " + pattern + ")")
+	}
+
+	sb.WriteString(queryFilterTasksOrder)
+	return sb.String(), args
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanFilter(row rowScanner) (*models.SavedFilter, error) {
+	var filter models.SavedFilter
+	var definition []byte
+
+	err := row.Scan(&filter.ID, &filter.UserID, &filter.Name, &definition, &filter.CreatedAt, &filter.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(definition, &filter.Definition); err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/filter"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestFilterRepository_CreateFilter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Now()
+	filter := &models.SavedFilter{
+		ID:         uuid.New(),
+		UserID:     uuid.New(),
+		Name:       "Горящие",
+		Definition: models.Definition{Importance: []int{3}},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "successful creation",
+			setupMocks: func() {
+				mock.ExpectExec(`INSERT INTO todo.saved_filter`).
+					WithArgs(filter.ID, filter.UserID, filter.Name, []byte(`{"importance":[3]}`), now, now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "duplicate name",
+			setupMocks: func() {
+				mock.ExpectExec(`INSERT INTO todo.saved_filter`).
+					WillReturnError(&pq.Error{Code: "23505"})
+			},
+			expectedErr: errs.ErrFilterNameTaken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := repo.CreateFilter(ctx, filter)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilterRepository_GetFilterByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	filterID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "user_id", "name", "definition", "created_at", "updated_at"}).
+			AddRow(filterID, userID, "Просроченные", []byte(`{"overdue":true,"deadline_to":{"days":7}}`), now, now)
+		mock.ExpectQuery(`SELECT (.+) FROM todo.saved_filter`).
+			WithArgs(filterID, userID).
+			WillReturnRows(rows)
+
+		filter, err := repo.GetFilterByID(ctx, filterID, userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Просроченные", filter.Name)
+		assert.True(t, filter.Definition.Overdue)
+		assert.Equal(t, 7, *filter.Definition.DeadlineTo.Days)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("filter not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM todo.saved_filter`).
+			WithArgs(filterID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "definition", "created_at", "updated_at"}))
+
+		filter, err := repo.GetFilterByID(ctx, filterID, userID)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.Nil(t, filter)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestFilterRepository_DeleteFilter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	filterID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "successful deletion",
+			setupMocks: func() {
+				mock.ExpectExec(`DELETE FROM todo.saved_filter`).
+					WithArgs(filterID, userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "filter not found",
+			setupMocks: func() {
+				mock.ExpectExec(`DELETE FROM todo.saved_filter`).
+					WithArgs(filterID, userID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+		{
+			name: "database error",
+			setupMocks: func() {
+				mock.ExpectExec(`DELETE FROM todo.saved_filter`).
+					WithArgs(filterID, userID).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedErr: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := repo.DeleteFilter(ctx, filterID, userID)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFilterRepository_GetTasksByQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := from.AddDate(0, 0, 7)
+
+	query := models.TaskQuery{
+		UserID:         userID,
+		ProjectIDs:     []uuid.UUID{projectID},
+		Statuses:       []string{"waiting"},
+		Importance:     []int{3},
+		OnlyMine:       true,
+		DeadlineFrom:   &from,
+		DeadlineBefore: &before,
+		Text:           "50%",
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline", "completed_at", "version", "estimate_minutes", "start_at", "all_day", "done", "total"}).
+		AddRow(uuid.New(), projectID, userID, "Отчет на 50%", "", 3, "waiting", time.Now(), from.AddDate(0, 0, 2), nil, 1, nil, nil, false, 0, 0)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`t.project_id = ANY($2::uuid[])`)+`(.|\n)*`+
+		regexp.QuoteMeta(`t.user_id = $1`)+`(.|\n)*`+
+		regexp.QuoteMeta(`t.deadline >= $5`)+`(.|\n)*`+
+		regexp.QuoteMeta(`t.title ILIKE $7`)).
+		WithArgs(userID, pq.StringArray{projectID.String()}, pq.StringArray{"waiting"}, pq.Int64Array{3}, from, before, `%50\%%`).
+		WillReturnRows(rows)
+
+	tasks, err := repo.GetTasksByQuery(ctx, query)
+
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, 3, tasks[0].Importance)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBuildTaskQuery_Empty(t *testing.T) {
+	userID := uuid.New()
+
+	query, args := buildTaskQuery(models.TaskQuery{UserID: userID})
+
+	assert.Equal(t, []interface{}{userID}, args)
+	assert.NotContains(t, query, "ANY")
+	assert.NotContains(t, query, "t.deadline >")
+}
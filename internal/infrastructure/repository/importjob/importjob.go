@@ -0,0 +1,435 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	linkrepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/link"
+	mentionrepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/mention"
+	noterepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/note"
+	taskrepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/task"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/importjob"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
+	notemodels "github.com/lzimin05/course-todo/internal/models/note"
+	taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+// Прогресс записи сохраняется каждые progressBatch записей
+const progressBatch = 100
+
+const (
+	queryCreateJob = `
+	INSERT INTO todo.import_job (id, project_id, user_id, source, status, file_name, file, mapping, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)`
+
+	queryGetJob = `
+	SELECT id, project_id, user_id, source, status, file_name, mapping, total, processed, report,
+		COALESCE(error, ''), created_at, updated_at, finished_at
+	FROM todo.import_job
+	WHERE id = $1 AND project_id = $2`
+
+	queryGetJobs = `
+	SELECT id, project_id, user_id, source, status, file_name, mapping, total, processed, report,
+		COALESCE(error, ''), created_at, updated_at, finished_at
+	FROM todo.import_job
+	WHERE project_id = $1
+	ORDER BY created_at DESC
+	LIMIT $2`
+
+	// Берется самое давнее задание, ждущее обработки, или задание, брошенное
+	// обработчиком: оно не продвигалось с staleBefore. Прерванная запись
+	// откатывается вместе с транзакцией, поэтому ее можно просто повторить.
+	// Новая метка claim_token отнимает задание у прежнего обработчика
+	queryClaimJob = `
+	UPDATE todo.import_job j
+	SET status = CASE WHEN j.status IN ('queued', 'validating') THEN 'validating' ELSE 'importing' END,
+		updated_at = $1, claim_token = $3
+	WHERE j.id = (
+		SELECT id FROM todo.import_job
+		WHERE status IN ('queued', 'pending_commit')
+			OR (status IN ('validating', 'importing') AND updated_at < $2)
+		ORDER BY updated_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING j.id, j.project_id, j.user_id, j.source, j.status, j.file_name, j.file, j.mapping,
+		j.total, j.processed, j.created_at, j.updated_at`
+
+	querySaveValidation = `
+	UPDATE todo.import_job
+	SET status = 'validated', total = $2, processed = $2, report = $3, updated_at = $4
+	WHERE id = $1 AND status = 'validating'`
+
+	queryRequestCommit = `
+	UPDATE todo.import_job
+	SET status = 'pending_commit', processed = 0, updated_at = $3
+	WHERE id = $1 AND project_id = $2 AND status = 'validated'
+		AND COALESCE((report->>'error_count')::int, 0) = 0`
+
+	// Прогресс сохраняет только обработчик, которому принадлежит задание
+	queryUpdateProgress = `
+	UPDATE todo.import_job SET processed = $2, updated_at = $3
+	WHERE id = $1 AND status = 'importing' AND claim_token = $4`
+
+	// Строка не блокируется: прогресс пишется отдельным соединением и ждал бы
+	// эту транзакцию. Окончательная проверка - в queryCompleteJob
+	queryCheckClaim = `
+	SELECT id FROM todo.import_job
+	WHERE id = $1 AND status = 'importing' AND claim_token = $2`
+
+	queryCompleteJob = `
+	UPDATE todo.import_job
+	SET status = 'completed', processed = $2, file = '', finished_at = $3, updated_at = $3
+	WHERE id = $1 AND status = 'importing' AND claim_token = $4`
+
+	queryFailJob = `
+	UPDATE todo.import_job
+	SET status = 'failed', error = $2, file = '', finished_at = $3, updated_at = $3
+	WHERE id = $1`
+
+	// Проверенные, но так и не подтвержденные задания удаляются вместе с завершенными
+	queryDeleteFinished = `
+	DELETE FROM todo.import_job
+	WHERE status IN ('validated', 'completed', 'failed') AND updated_at < $1`
+)
+
+type ImportRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+// CreateJob ставит задание в очередь
+func (r *ImportRepository) CreateJob(ctx context.Context, job *models.Job) error {
+	const op = "ImportRepository.CreateJob"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", job.ProjectID)
+
+	mapping, err := json.Marshal(job.Mapping)
+	if err != nil {
+		logger.WithError(err).Error("failed to marshal mapping")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = r.db.ExecContext(ctx, queryCreateJob,
+		job.ID, job.ProjectID, job.UserID, job.Source, job.Status, job.FileName, job.File, mapping, job.CreatedAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to create import job")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// GetJob возвращает задание проекта без содержимого файла
+func (r *ImportRepository) GetJob(ctx context.Context, projectID, jobID uuid.UUID) (*models.Job, error) {
+	const op = "ImportRepository.GetJob"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("jobID", jobID)
+
+	job, err := scanJob(r.db.QueryRowContext(ctx, queryGetJob, jobID, projectID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NewNotFoundError("import job not found")
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to get import job")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return job, nil
+}
+
+// GetJobs возвращает последние задания проекта
+func (r *ImportRepository) GetJobs(ctx context.Context, projectID uuid.UUID, limit int) ([]*models.Job, error) {
+	const op = "ImportRepository.GetJobs"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetJobs, projectID, limit)
+	if err != nil {
+		logger.WithError(err).Error("failed to get import jobs")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	jobs := make([]*models.Job, 0)
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan import job")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return jobs, nil
+}
+
+// ClaimJob берет следующее задание в работу и возвращает его вместе с файлом.
+// nil без ошибки - очередь пуста
+func (r *ImportRepository) ClaimJob(ctx context.Context, now, staleBefore time.Time) (*models.Job, error) {
+	const op = "ImportRepository.ClaimJob"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	var (
+		job     models.Job
+		mapping []byte
+	)
+	job.ClaimToken = uuid.New()
+	err := r.db.QueryRowContext(ctx, queryClaimJob, now, staleBefore, job.ClaimToken).Scan(
+		&job.ID, &job.ProjectID, &job.UserID, &job.Source, &job.Status, &job.FileName, &job.File, &mapping,
+		&job.Total, &job.Processed, &job.CreatedAt, &job.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to claim import job")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := json.Unmarshal(mapping, &job.Mapping); err != nil {
+		logger.WithError(err).Error("failed to unmarshal mapping")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &job, nil
+}
+
+// SaveValidation сохраняет отчет проверки файла
+func (r *ImportRepository) SaveValidation(ctx context.Context, jobID uuid.UUID, report *models.Report, now time.Time) error {
+	const op = "ImportRepository.SaveValidation"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("jobID", jobID)
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		logger.WithError(err).Error("failed to marshal report")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, querySaveValidation, jobID, report.Tasks+report.Notes, data, now); err != nil {
+		logger.WithError(err).Error("failed to save validation report")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// RequestCommit ставит проверенное задание без ошибок в очередь на запись
+func (r *ImportRepository) RequestCommit(ctx context.Context, projectID, jobID uuid.UUID, now time.Time) error {
+	const op = "ImportRepository.RequestCommit"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("jobID", jobID)
+
+	result, err := r.db.ExecContext(ctx, queryRequestCommit, jobID, projectID, now)
+	if err != nil {
+		logger.WithError(err).Error("failed to request import commit")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get affected rows")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return errs.ErrImportNotReady
+	}
+	return nil
+}
+
+// UpdateProgress сохраняет число обработанных записей и продлевает задание.
+// errs.ErrImportClaimLost - задание забрал другой обработчик
+func (r *ImportRepository) UpdateProgress(ctx context.Context, job *models.Job, processed int, now time.Time) error {
+	const op = "ImportRepository.UpdateProgress"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("jobID", job.ID)
+
+	result, err := r.db.ExecContext(ctx, queryUpdateProgress, job.ID, processed, now, job.ClaimToken)
+	if err != nil {
+		logger.WithError(err).Error("failed to update import progress")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get affected rows")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return errs.ErrImportClaimLost
+	}
+	return nil
+}
+
+// CommitImport записывает задачи и заметки одной транзакцией и в ней же завершает задание:
+// при ошибке в проекте не остается ничего из файла. Прогресс сохраняется отдельно.
+// ID записанных задач и заметок сохраняются в items. Ссылки и упоминания пишутся в той же
+// транзакции, когда у всех записей уже есть ID.
+// Задание завершается, только если его метка не сменилась, иначе запись откатывается
+// с errs.ErrImportClaimLost: задание уже записывает другой обработчик
+func (r *ImportRepository) CommitImport(ctx context.Context, job *models.Job, items []models.Item, now time.Time) error {
+	const op = "ImportRepository.CommitImport"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("jobID", job.ID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var id uuid.UUID
+	err = tx.QueryRowContext(ctx, queryCheckClaim, job.ID, job.ClaimToken).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Warn("import job was claimed by another worker")
+		return errs.ErrImportClaimLost
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to check import job claim")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i := range items {
+		item := &items[i]
+		if err := insertItem(ctx, tx, job, item, now); err != nil {
+			logger.WithError(err).WithField("row", item.Row).Error("failed to import item")
+			return fmt.Errorf("%s: row %d: %w", op, item.Row, err)
+		}
+		if (i+1)%progressBatch == 0 && i+1 < len(items) {
+			if err := r.UpdateProgress(ctx, job, i+1, time.Now()); err != nil {
+				if errors.Is(err, errs.ErrImportClaimLost) {
+					logger.Warn("import job was claimed by another worker")
+					return err
+				}
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	for i := range items {
+		item := &items[i]
+		if err := saveItemReferences(ctx, tx, job, item); err != nil {
+			logger.WithError(err).WithField("row", item.Row).Error("failed to save imported references")
+			return fmt.Errorf("%s: row %d: %w", op, item.Row, err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, queryCompleteJob, job.ID, len(items), now, job.ClaimToken)
+	if err != nil {
+		logger.WithError(err).Error("failed to complete import job")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get affected rows")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		logger.Warn("import job was claimed by another worker")
+		return errs.ErrImportClaimLost
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// FailJob завершает задание с ошибкой
+func (r *ImportRepository) FailJob(ctx context.Context, jobID uuid.UUID, message string, now time.Time) error {
+	const op = "ImportRepository.FailJob"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("jobID", jobID)
+
+	if _, err := r.db.ExecContext(ctx, queryFailJob, jobID, message, now); err != nil {
+		logger.WithError(err).Error("failed to mark import job failed")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// DeleteFinished удаляет завершенные задания, не менявшиеся с before, и возвращает их число
+func (r *ImportRepository) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	const op = "ImportRepository.DeleteFinished"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	result, err := r.db.ExecContext(ctx, queryDeleteFinished, before)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete finished import jobs")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get affected rows")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return count, nil
+}
+
+func insertItem(ctx context.Context, tx *sql.Tx, job *models.Job, item *models.Item, now time.Time) error {
+	item.ID = uuid.New()
+	if item.Kind == models.KindNote {
+		return noterepo.InsertNote(ctx, tx, &notemodels.Note{
+			ID:          item.ID,
+			ProjectID:   job.ProjectID,
+			UserID:      job.UserID,
+			Name:        item.Title,
+			Description: item.Description,
+			Format:      item.Format,
+			CreatedAt:   now,
+		})
+	}
+
+	return taskrepo.InsertTask(ctx, tx, &taskmodels.Task{
+		ID:              item.ID,
+		ProjectID:       job.ProjectID,
+		UserID:          job.UserID,
+		Title:           item.Title,
+		Description:     item.Description,
+		Importance:      item.Importance,
+		Status:          item.Status,
+		CreatedAt:       now,
+		Deadline:        item.Deadline,
+		EstimateMinutes: item.EstimateMinutes,
+		StartAt:         item.StartAt,
+		AllDay:          item.AllDay,
+	})
+}
+
+func saveItemReferences(ctx context.Context, tx *sql.Tx, job *models.Job, item *models.Item) error {
+	sourceType := linkmodels.TypeTask
+	if item.Kind == models.KindNote {
+		sourceType = linkmodels.TypeNote
+	}
+	if err := linkrepo.ReplaceLinks(ctx, tx, sourceType, item.ID, job.UserID, item.Links); err != nil {
+		return err
+	}
+	return mentionrepo.ReplaceMentions(ctx, tx, sourceType, item.ID, job.UserID, item.Mentions)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row rowScanner) (*models.Job, error) {
+	var (
+		job     models.Job
+		mapping []byte
+		report  []byte
+	)
+	err := row.Scan(&job.ID, &job.ProjectID, &job.UserID, &job.Source, &job.Status, &job.FileName, &mapping,
+		&job.Total, &job.Processed, &report, &job.Error, &job.CreatedAt, &job.UpdatedAt, &job.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(mapping, &job.Mapping); err != nil {
+		return nil, err
+	}
+	if report != nil {
+		job.Report = &models.Report{}
+		if err := json.Unmarshal(report, job.Report); err != nil {
+			return nil, err
+		}
+	}
+	return &job, nil
+}
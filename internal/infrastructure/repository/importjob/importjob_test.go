@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/importjob"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+var jobColumns = []string{"id", "project_id", "user_id", "source", "status", "file_name", "mapping",
+	"total", "processed", "report", "error", "created_at", "updated_at", "finished_at"}
+
+func TestImportRepository_GetJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	jobID, projectID, userID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now().UTC()
+
+	t.Run("found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .+ FROM todo.import_job\s+WHERE id = \$1 AND project_id = \$2`).
+			WithArgs(jobID, projectID).
+			WillReturnRows(sqlmock.NewRows(jobColumns).AddRow(
+				jobID, projectID, userID, "csv", "validated", "tasks.csv",
+				[]byte(`{"labels":{"urgent":3}}`), 2, 2,
+				[]byte(`{"total":3,"tasks":2,"notes":0,"skipped":1,"error_count":0,"errors":[]}`),
+				"", now, now, nil))
+
+		job, err := repo.GetJob(ctx, projectID, jobID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusValidated, job.Status)
+		assert.Equal(t, 3, job.Mapping.Labels["urgent"])
+		require.NotNil(t, job.Report)
+		assert.Equal(t, 1, job.Report.Skipped)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .+ FROM todo.import_job`).
+			WithArgs(jobID, projectID).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetJob(ctx, projectID, jobID)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportRepository_ClaimJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Now().UTC()
+	staleBefore := now.Add(-10 * time.Minute)
+
+	t.Run("claimed", func(t *testing.T) {
+		jobID := uuid.New()
+		mock.ExpectQuery(`UPDATE todo.import_job j .+ FOR UPDATE SKIP LOCKED`).
+			WithArgs(now, staleBefore, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "user_id", "source", "status", "file_name", "file", "mapping",
+				"total", "processed", "created_at", "updated_at"}).
+				AddRow(jobID, uuid.New(), uuid.New(), "todoist", "validating", "todoist.json", []byte("[]"), []byte("{}"), 0, 0, now, now))
+
+		job, err := repo.ClaimJob(ctx, now, staleBefore)
+
+		require.NoError(t, err)
+		assert.Equal(t, jobID, job.ID)
+		assert.Equal(t, []byte("[]"), job.File)
+		assert.NotEqual(t, uuid.Nil, job.ClaimToken)
+	})
+
+	t.Run("empty queue", func(t *testing.T) {
+		mock.ExpectQuery(`UPDATE todo.import_job j`).
+			WithArgs(now, staleBefore, sqlmock.AnyArg()).
+			WillReturnError(sql.ErrNoRows)
+
+		job, err := repo.ClaimJob(ctx, now, staleBefore)
+
+		assert.NoError(t, err)
+		assert.Nil(t, job)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportRepository_RequestCommit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	jobID, projectID := uuid.New(), uuid.New()
+	now := time.Now().UTC()
+
+	mock.ExpectExec(`UPDATE todo.import_job\s+SET status = 'pending_commit'`).
+		WithArgs(jobID, projectID, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.RequestCommit(ctx, projectID, jobID, now))
+
+	mock.ExpectExec(`UPDATE todo.import_job\s+SET status = 'pending_commit'`).
+		WithArgs(jobID, projectID, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, repo.RequestCommit(ctx, projectID, jobID, now), errs.ErrImportNotReady)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportRepository_CommitImport(t *testing.T) {
+	job := &models.Job{ID: uuid.New(), ProjectID: uuid.New(), UserID: uuid.New(), ClaimToken: uuid.New()}
+	now := time.Now().UTC()
+	items := []models.Item{
+		{Row: 2, Kind: models.KindTask, Title: "Купить молоко", Status: "completed", Importance: 2,
+			Links: linkmodels.References{Titles: []string{"Идеи"}}},
+		{Row: 3, Kind: models.KindNote, Title: "Идеи", Description: "# Идеи @anna", Format: "markdown",
+			Mentions: []string{"anna"}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+		repo := New(db)
+		ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+		mock.ExpectBegin()
+		expectClaim(mock)
+		mock.ExpectQuery(`INSERT INTO todo.task`).
+			WithArgs(sqlmock.AnyArg(), job.ProjectID, job.UserID, "Купить молоко", "", 2, "completed", now,
+				time.Time{}, nil, nil, false, "", "").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline", "estimate_minutes", "start_at", "all_day", "version"}).
+				AddRow(uuid.New(), job.ProjectID, job.UserID, "Купить молоко", "", 2, "completed", now, time.Time{}, nil, nil, false, 1))
+		mock.ExpectExec(`INSERT INTO todo.task_status_history`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO todo.outbox`).
+			WithArgs(sqlmock.AnyArg(), "task.created", job.ProjectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO todo.note `).
+			WithArgs(sqlmock.AnyArg(), job.ProjectID, job.UserID, "Идеи", "# Идеи @anna", "markdown", now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(uuid.New(), 1))
+		mock.ExpectQuery(`INSERT INTO todo.note_revision`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(1))
+		mock.ExpectExec(`INSERT INTO todo.outbox`).
+			WithArgs(sqlmock.AnyArg(), "note.created", job.ProjectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		// Ссылки и упоминания пишутся, когда записаны все записи файла
+		mock.ExpectExec(`DELETE FROM todo.entity_link`).
+			WithArgs("task", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO todo.entity_link`).
+			WithArgs("task", sqlmock.AnyArg(), pq.StringArray{"идеи"}, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+			WithArgs("task", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(job.ProjectID, "Купить молоко"))
+		mock.ExpectExec(`DELETE FROM todo.mention`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM todo.entity_link`).
+			WithArgs("note", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+			WithArgs("note", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(job.ProjectID, "Идеи"))
+		mock.ExpectExec(`DELETE FROM todo.mention`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`INSERT INTO todo.mention`).
+			WithArgs("note", sqlmock.AnyArg(), job.ProjectID, pq.StringArray{"anna"}, job.UserID, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(uuid.New()))
+		mock.ExpectExec(`INSERT INTO todo.outbox`).
+			WithArgs(sqlmock.AnyArg(), "mention.created", job.ProjectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE todo.import_job\s+SET status = 'completed'`).
+			WithArgs(job.ID, 2, now, job.ClaimToken).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.CommitImport(ctx, job, items, now))
+		assert.NotEqual(t, uuid.Nil, items[0].ID)
+		assert.NotEqual(t, uuid.Nil, items[1].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback on failure", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+		repo := New(db)
+		ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+		mock.ExpectBegin()
+		expectClaim(mock)
+		mock.ExpectQuery(`INSERT INTO todo.task`).WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err = repo.CommitImport(ctx, job, items, now)
+
+		assert.ErrorContains(t, err, "row 2")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("reclaimed before commit", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+		repo := New(db)
+		ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT id FROM todo.import_job`).
+			WithArgs(job.ID, job.ClaimToken).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		err = repo.CommitImport(ctx, job, items, now)
+
+		assert.ErrorIs(t, err, errs.ErrImportClaimLost)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("reclaimed while writing", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+		repo := New(db)
+		ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+		notes := []models.Item{{Row: 2, Kind: models.KindNote, Title: "Идеи", Format: "markdown"}}
+
+		mock.ExpectBegin()
+		expectClaim(mock)
+		mock.ExpectQuery(`INSERT INTO todo.note `).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "version"}).AddRow(uuid.New(), 1))
+		mock.ExpectQuery(`INSERT INTO todo.note_revision`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(1))
+		mock.ExpectExec(`INSERT INTO todo.outbox`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM todo.entity_link`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+			WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(job.ProjectID, "Идеи"))
+		mock.ExpectExec(`DELETE FROM todo.mention`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`UPDATE todo.import_job\s+SET status = 'completed'`).
+			WithArgs(job.ID, 1, now, job.ClaimToken).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err = repo.CommitImport(ctx, job, notes, now)
+
+		assert.ErrorIs(t, err, errs.ErrImportClaimLost)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func expectClaim(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT id FROM todo.import_job`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+}
+
+func TestImportRepository_DeleteFinished(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	before := time.Now().UTC()
+	mock.ExpectExec(`DELETE FROM todo.import_job\s+WHERE status IN \('validated', 'completed', 'failed'\) AND updated_at < \$1`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	count, err := repo.DeleteFinished(ctx, before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	models "github.com/lzimin05/course-todo/internal/models/link"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	queryDeleteSourceLinks = `
+	DELETE FROM todo.entity_link
+	WHERE source_type = $1 AND source_id = $2`
+
+	// Ссылка по ID сохраняется, только если автор видит целевую сущность
+	queryInsertLinksByID = `
+	INSERT INTO todo.entity_link (source_type, source_id, target_type, target_id, created_at)
+	SELECT $1::varchar, $2::uuid, x.type, x.id, $5
+	FROM (
+		SELECT 'task' AS type, t.id, t.project_id FROM todo.task t WHERE t.id = ANY($3::uuid[])
+		UNION ALL
+		SELECT 'note', n.id, n.project_id FROM todo.note n WHERE n.id = ANY($3::uuid[])
+	) x
+	JOIN todo.project_member pm ON pm.project_id = x.project_id AND pm.user_id = $4
+	WHERE x.id <> $2::uuid
+	ON CONFLICT DO NOTHING`
+
+	// Ссылка по названию ищется в проекте, которому принадлежит источник
+	queryInsertLinksByTitle = `
+	WITH source AS (
+		SELECT t.project_id FROM todo.task t WHERE $1::varchar = 'task' AND t.id = $2::uuid
+		UNION ALL
+		SELECT n.project_id FROM todo.note n WHERE $1::varchar = 'note' AND n.id = $2::uuid
+	)
+	INSERT INTO todo.entity_link (source_type, source_id, target_type, target_id, created_at)
+	SELECT $1::varchar, $2::uuid, x.type, x.id, $4
+	FROM (
+		SELECT 'task' AS type, t.id FROM todo.task t
+		JOIN source s ON t.project_id = s.project_id
+		WHERE lower(t.title) = ANY($3::text[])
+		UNION ALL
+		SELECT 'note', n.id FROM todo.note n
+		JOIN source s ON n.project_id = s.project_id
+		WHERE lower(n.name) = ANY($3::text[])
+	) x
+	WHERE x.id <> $2::uuid
+	ON CONFLICT DO NOTHING`
+
+	// Удаленные источники и источники из чужих проектов отсекаются соединениями
+	queryGetBacklinks = `
+	SELECT l.source_type, l.source_id, COALESCE(t.project_id, n.project_id), COALESCE(t.title, n.name)
+	FROM todo.entity_link l
+	LEFT JOIN todo.task t ON l.source_type = 'task' AND t.id = l.source_id
+	LEFT JOIN todo.note n ON l.source_type = 'note' AND n.id = l.source_id
+	JOIN todo.project_member pm ON pm.project_id = COALESCE(t.project_id, n.project_id)
+	WHERE l.target_type = $1 AND l.target_id = $2 AND pm.user_id = $3
+	ORDER BY l.created_at DESC, l.source_id`
+)
+
+type LinkRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *LinkRepository {
+	return &LinkRepository{db: db}
+}
+
+// ReplaceLinks заменяет исходящие ссылки сущности ссылками из ее нового текста в
+// переданной транзакции, чтобы ссылки менялись вместе с текстом задачи или заметки.
+// Ссылки на несуществующие и недоступные автору сущности не сохраняются
+func ReplaceLinks(ctx context.Context, tx *sql.Tx, sourceType string, sourceID, userID uuid.UUID, refs models.References) error {
+	if _, err := tx.ExecContext(ctx, queryDeleteSourceLinks, sourceType, sourceID); err != nil {
+		return fmt.Errorf("delete old links: %w", err)
+	}
+
+	now := time.Now()
+
+	if len(refs.IDs) > 0 {
+		ids := make([]string, len(refs.IDs))
+		for i, id := range refs.IDs {
+			ids[i] = id.String()
+		}
+		if _, err := tx.ExecContext(ctx, queryInsertLinksByID, sourceType, sourceID, pq.StringArray(ids), userID, now); err != nil {
+			return fmt.Errorf("save links by id: %w", err)
+		}
+	}
+
+	if len(refs.Titles) > 0 {
+		titles := make([]string, len(refs.Titles))
+		for i, title := range refs.Titles {
+			titles[i] = strings.ToLower(title)
+		}
+		if _, err := tx.ExecContext(ctx, queryInsertLinksByTitle, sourceType, sourceID, pq.StringArray(titles), now); err != nil {
+			return fmt.Errorf("save links by title: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetBacklinks возвращает сущности, ссылающиеся на указанную, из проектов пользователя
+func (r *LinkRepository) GetBacklinks(ctx context.Context, targetType string, targetID, userID uuid.UUID) ([]models.Backlink, error) {
+	const op = "LinkRepository.GetBacklinks"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("targetType", targetType).
+		WithField("targetID", targetID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetBacklinks, targetType, targetID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get backlinks")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	backlinks := make([]models.Backlink, 0)
+	for rows.Next() {
+		var b models.Backlink
+		if err := rows.Scan(&b.Type, &b.ID, &b.ProjectID, &b.Title); err != nil {
+			logger.WithError(err).Error("failed to scan backlink")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		backlinks = append(backlinks, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return backlinks, nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/link"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestReplaceLinks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	noteID := uuid.New()
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	tests := []struct {
+		name        string
+		refs        models.References
+		setupMocks  func()
+		expectedErr bool
+	}{
+		{
+			name: "links by id and title",
+			refs: models.References{
+				IDs:    []uuid.UUID{taskID},
+				Titles: []string{"Release Plan"},
+			},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM todo.entity_link`).
+					WithArgs(models.TypeNote, noteID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`t.id = ANY\(\$3::uuid\[\]\)`).
+					WithArgs(models.TypeNote, noteID, pq.StringArray{taskID.String()}, userID, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`lower\(t.title\) = ANY\(\$3::text\[\]\)`).
+					WithArgs(models.TypeNote, noteID, pq.StringArray{"release plan"}, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "no references clears links",
+			refs: models.References{},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM todo.entity_link`).
+					WithArgs(models.TypeNote, noteID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "insert error rolls back",
+			refs: models.References{Titles: []string{"Plan"}},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM todo.entity_link`).
+					WithArgs(models.TypeNote, noteID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO todo.entity_link`).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			tx, err := db.Begin()
+			assert.NoError(t, err)
+
+			err = ReplaceLinks(ctx, tx, models.TypeNote, noteID, userID, tt.refs)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "save links by title")
+				assert.NoError(t, tx.Rollback())
+			} else {
+				assert.NoError(t, err)
+				assert.NoError(t, tx.Commit())
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLinkRepository_GetBacklinks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	noteID := uuid.New()
+	projectID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func()
+		expectedErr   bool
+		expectResults int
+	}{
+		{
+			name: "backlinks from accessible sources",
+			setupMocks: func() {
+				rows := sqlmock.NewRows([]string{"source_type", "source_id", "project_id", "title"}).
+					AddRow(models.TypeNote, noteID, projectID, "Meeting notes")
+				mock.ExpectQuery(`FROM todo.entity_link l`).
+					WithArgs(models.TypeTask, taskID, userID).
+					WillReturnRows(rows)
+			},
+			expectResults: 1,
+		},
+		{
+			name: "no backlinks",
+			setupMocks: func() {
+				mock.ExpectQuery(`FROM todo.entity_link l`).
+					WithArgs(models.TypeTask, taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"source_type", "source_id", "project_id", "title"}))
+			},
+			expectResults: 0,
+		},
+		{
+			name: "database error",
+			setupMocks: func() {
+				mock.ExpectQuery(`FROM todo.entity_link l`).
+					WithArgs(models.TypeTask, taskID, userID).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			backlinks, err := repo.GetBacklinks(ctx, models.TypeTask, taskID, userID)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, backlinks)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, backlinks, tt.expectResults)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	outbox "github.com/lzimin05/course-todo/internal/infrastructure/repository/outbox"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	models "github.com/lzimin05/course-todo/internal/models/mention"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	queryResolveMembers = `
+	SELECT u.id, lower(u.login)
+	FROM todo."user" u
+	JOIN todo.project_member pm ON pm.user_id = u.id AND pm.project_id = $1
+	WHERE lower(u.login) = ANY($2::text[])`
+
+	querySourceProject = `
+	SELECT t.project_id, t.title FROM todo.task t WHERE $1::varchar = 'task' AND t.id = $2::uuid
+	UNION ALL
+	SELECT n.project_id, n.name FROM todo.note n WHERE $1::varchar = 'note' AND n.id = $2::uuid`
+
+	// Удаляются упоминания, которых больше нет в тексте, и упоминания из
+	// проекта, откуда источник перенесли
+	queryDeleteStaleMentions = `
+	DELETE FROM todo.mention m
+	WHERE m.source_type = $1 AND m.source_id = $2 AND (
+		m.project_id <> $3 OR NOT EXISTS (
+			SELECT 1 FROM todo."user" u WHERE u.id = m.user_id AND lower(u.login) = ANY($4::text[])
+		)
+	)`
+
+	// Сохраняются только упоминания участников проекта. RETURNING отдает
+	// только новые строки: о них и отправляются события
+	queryInsertMentions = `
+	INSERT INTO todo.mention (source_type, source_id, user_id, project_id, author_id, created_at)
+	SELECT $1::varchar, $2::uuid, u.id, $3::uuid, $5::uuid, $6::timestamp
+	FROM todo."user" u
+	JOIN todo.project_member pm ON pm.user_id = u.id AND pm.project_id = $3
+	WHERE lower(u.login) = ANY($4::text[])
+	ON CONFLICT (source_type, source_id, user_id) DO NOTHING
+	RETURNING user_id`
+
+	queryGetSourceMentions = `
+	SELECT u.id, lower(u.login)
+	FROM todo.mention m
+	JOIN todo."user" u ON u.id = m.user_id
+	WHERE m.source_type = $1 AND m.source_id = $2
+	ORDER BY lower(u.login)`
+
+	// Упоминания в удаленных источниках и в проектах, из которых пользователь
+	// вышел, отсекаются соединениями
+	queryGetUserMentions = `
+	SELECT m.source_type, m.source_id, COALESCE(t.title, n.name), m.project_id, p.name, m.author_id, m.user_id, m.created_at
+	FROM todo.mention m
+	LEFT JOIN todo.task t ON m.source_type = 'task' AND t.id = m.source_id
+	LEFT JOIN todo.note n ON m.source_type = 'note' AND n.id = m.source_id
+	JOIN todo.project p ON p.id = m.project_id
+	JOIN todo.project_member pm ON pm.project_id = m.project_id AND pm.user_id = m.user_id
+	WHERE m.user_id = $1 AND COALESCE(t.id, n.id) IS NOT NULL
+	ORDER BY m.created_at DESC, m.source_id
+	LIMIT $2 OFFSET $3`
+)
+
+type MentionRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *MentionRepository {
+	return &MentionRepository{db: db}
+}
+
+// ResolveMembers возвращает участников проекта с указанными логинами
+func (r *MentionRepository) ResolveMembers(ctx context.Context, projectID uuid.UUID, logins []string) ([]models.MentionedUser, error) {
+	const op = "MentionRepository.ResolveMembers"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	users, err := r.queryUsers(ctx, queryResolveMembers, projectID, pq.StringArray(logins))
+	if err != nil {
+		logger.WithError(err).Error("failed to resolve mentioned members")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return users, nil
+}
+
+// ReplaceMentions приводит упоминания источника к списку логинов из его нового текста
+// в переданной транзакции. О каждом новом упоминании в ней же пишется событие
+// mention.created, поэтому упоминания и уведомления сохраняются вместе с текстом
+// задачи или заметки. Если источник уже удален, ничего не делает
+func ReplaceMentions(ctx context.Context, tx *sql.Tx, sourceType string, sourceID, authorID uuid.UUID, logins []string) error {
+	var projectID uuid.UUID
+	var title string
+	err := tx.QueryRowContext(ctx, querySourceProject, sourceType, sourceID).Scan(&projectID, &title)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("get mention source: %w", err)
+	}
+
+	loginArray := pq.StringArray(logins)
+	if _, err := tx.ExecContext(ctx, queryDeleteStaleMentions, sourceType, sourceID, projectID, loginArray); err != nil {
+		return fmt.Errorf("delete stale mentions: %w", err)
+	}
+
+	if len(logins) == 0 {
+		return nil
+	}
+
+	rows, err := tx.QueryContext(ctx, queryInsertMentions, sourceType, sourceID, projectID, loginArray, authorID, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("save mentions: %w", err)
+	}
+
+	var events []eventmodels.Event
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan mentioned user: %w", err)
+		}
+		events = append(events, eventmodels.New(eventmodels.TypeMentionCreated, projectID, authorID, eventmodels.MentionData{
+			SourceType: sourceType,
+			SourceID:   sourceID,
+			ProjectID:  projectID,
+			Title:      title,
+			UserID:     userID,
+		}))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate mentioned users: %w", err)
+	}
+
+	if err := outbox.Write(ctx, tx, events...); err != nil {
+		return fmt.Errorf("write mention events: %w", err)
+	}
+	return nil
+}
+
+// GetSourceMentions возвращает пользователей, упомянутых в источнике
+func (r *MentionRepository) GetSourceMentions(ctx context.Context, sourceType string, sourceID uuid.UUID) ([]models.MentionedUser, error) {
+	const op = "MentionRepository.GetSourceMentions"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("sourceType", sourceType).
+		WithField("sourceID", sourceID)
+
+	users, err := r.queryUsers(ctx, queryGetSourceMentions, sourceType, sourceID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get source mentions")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return users, nil
+}
+
+// GetUserMentions возвращает упоминания пользователя, новые первыми
+func (r *MentionRepository) GetUserMentions(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.Mention, error) {
+	const op = "MentionRepository.GetUserMentions"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetUserMentions, userID, limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("failed to get user mentions")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	mentions := make([]models.Mention, 0)
+	for rows.Next() {
+		var m models.Mention
+		if err := rows.Scan(&m.SourceType, &m.SourceID, &m.Title, &m.ProjectID, &m.ProjectName, &m.AuthorID, &m.UserID, &m.CreatedAt); err != nil {
+			logger.WithError(err).Error("failed to scan mention")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		mentions = append(mentions, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return mentions, nil
+}
+
+func (r *MentionRepository) queryUsers(ctx context.Context, query string, args ...any) ([]models.MentionedUser, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []models.MentionedUser
+	for rows.Next() {
+		var u models.MentionedUser
+		if err := rows.Scan(&u.ID, &u.Login); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestReplaceMentions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	noteID := uuid.New()
+	authorID := uuid.New()
+	projectID := uuid.New()
+	annaID := uuid.New()
+
+	tests := []struct {
+		name        string
+		logins      []string
+		setupMocks  func()
+		expectedErr bool
+	}{
+		{
+			name:   "new mentions write events",
+			logins: []string{"anna", "bob"},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+					WithArgs("note", noteID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(projectID, "Plan"))
+				mock.ExpectExec(`DELETE FROM todo.mention`).
+					WithArgs("note", noteID, projectID, pq.StringArray{"anna", "bob"}).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`INSERT INTO todo.mention`).
+					WithArgs("note", noteID, projectID, pq.StringArray{"anna", "bob"}, authorID, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(annaID))
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "no mentions clears old ones",
+			logins: nil,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+					WithArgs("note", noteID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(projectID, "Plan"))
+				mock.ExpectExec(`DELETE FROM todo.mention`).
+					WithArgs("note", noteID, projectID, pq.StringArray(nil)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "deleted source is skipped",
+			logins: []string{"anna"},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+					WithArgs("note", noteID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "insert error rolls back",
+			logins: []string{"anna"},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+					WithArgs("note", noteID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(projectID, "Plan"))
+				mock.ExpectExec(`DELETE FROM todo.mention`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`INSERT INTO todo.mention`).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			tx, err := db.Begin()
+			assert.NoError(t, err)
+
+			err = ReplaceMentions(ctx, tx, "note", noteID, authorID, tt.logins)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "save mentions")
+				assert.NoError(t, tx.Rollback())
+			} else {
+				assert.NoError(t, err)
+				assert.NoError(t, tx.Commit())
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMentionRepository_ResolveMembers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	annaID := uuid.New()
+
+	mock.ExpectQuery(`JOIN todo.project_member pm`).
+		WithArgs(projectID, pq.StringArray{"anna", "bob"}).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "login"}).AddRow(annaID, "anna"))
+
+	users, err := repo.ResolveMembers(ctx, projectID, []string{"anna", "bob"})
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, annaID, users[0].ID)
+	assert.Equal(t, "anna", users[0].Login)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMentionRepository_GetUserMentions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	authorID := uuid.New()
+	now := time.Now()
+
+	t.Run("mentions", func(t *testing.T) {
+		mock.ExpectQuery(`FROM todo.mention m`).
+			WithArgs(userID, 20, 0).
+			WillReturnRows(sqlmock.NewRows([]string{"source_type", "source_id", "title", "project_id", "name", "author_id", "user_id", "created_at"}).
+				AddRow("task", uuid.New(), "Release", uuid.New(), "Project", authorID, userID, now).
+				AddRow("note", uuid.New(), "Plan", uuid.New(), "Project", nil, userID, now))
+
+		mentions, err := repo.GetUserMentions(ctx, userID, 20, 0)
+		assert.NoError(t, err)
+		assert.Len(t, mentions, 2)
+		assert.Equal(t, &authorID, mentions[0].AuthorID)
+		assert.Nil(t, mentions[1].AuthorID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mock.ExpectQuery(`FROM todo.mention m`).
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.GetUserMentions(ctx, userID, 20, 0)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,400 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/note"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	// Родительская папка должна быть в том же проекте, иначе строка не вставляется
+	createFolderQuery = `
+		INSERT INTO todo.note_folder (id, project_id, parent_id, name, created_at)
+		SELECT $1, $2, $3, $4, $5
+		WHERE $3::uuid IS NULL OR EXISTS (
+			SELECT 1 FROM todo.note_folder p WHERE p.id = $3 AND p.project_id = $2
+		)`
+
+	// Пути папок строятся одним рекурсивным запросом от корня проекта
+	getFoldersByProjectQuery = `
+		WITH RECURSIVE tree AS (
+			SELECT f.id, f.project_id, f.parent_id, f.name, f.created_at, f.name::text AS path
+			FROM todo.note_folder f
+			WHERE f.project_id = $1 AND f.parent_id IS NULL
+			UNION ALL
+			SELECT f.id, f.project_id, f.parent_id, f.name, f.created_at, tree.path || '/' || f.name
+			FROM todo.note_folder f
+			JOIN tree ON f.parent_id = tree.id
+		)
+		SELECT tree.id, tree.project_id, tree.parent_id, tree.name, tree.path, tree.created_at
+		FROM tree
+		JOIN todo.project_member pm ON pm.project_id = tree.project_id AND pm.user_id = $2
+		ORDER BY tree.path`
+
+	getFolderProjectQuery = `
+		SELECT f.project_id
+		FROM todo.note_folder f
+		JOIN todo.project_member pm ON f.project_id = pm.project_id
+		WHERE f.id = $1 AND pm.user_id = $2
+		FOR UPDATE OF f`
+
+	folderExistsInProjectQuery = `
+		SELECT EXISTS(SELECT 1 FROM todo.note_folder WHERE id = $1 AND project_id = $2)`
+
+	// Папку нельзя вложить в саму себя или в своего потомка
+	folderIsDescendantQuery = `
+		WITH RECURSIVE descendants AS (
+			SELECT id FROM todo.note_folder WHERE id = $1
+			UNION ALL
+			SELECT f.id FROM todo.note_folder f JOIN descendants d ON f.parent_id = d.id
+		)
+		SELECT EXISTS(SELECT 1 FROM descendants WHERE id = $2)`
+
+	updateFolderQuery = `
+		UPDATE todo.note_folder SET name = $2, parent_id = $3
+		WHERE id = $1`
+
+	deleteFolderQuery = `
+		DELETE FROM todo.note_folder WHERE id = $1`
+
+	// Заметки удаляемой папки и ее потомков переносятся в конец корня проекта:
+	// сначала заметки самой папки, затем вложенных, в прежнем порядке. Вложенные
+	// папки обходятся в порядке дерева, как в списке папок: path - имена и ID
+	// папок от удаляемой, ID различает одноименные папки
+	moveFolderNotesToRootQuery = `
+		WITH RECURSIVE subtree AS (
+			SELECT id, ARRAY[]::text[] AS path FROM todo.note_folder WHERE id = $1
+			UNION ALL
+			SELECT f.id, s.path || ARRAY[f.name::text, f.id::text]
+			FROM todo.note_folder f JOIN subtree s ON f.parent_id = s.id
+		), root_max AS (
+			SELECT COALESCE(MAX(position), -1) AS position
+			FROM todo.note
+			WHERE project_id = $2 AND folder_id IS NULL
+		), ordered AS (
+			SELECT n.id, ROW_NUMBER() OVER (ORDER BY s.path, n.position, n.id) AS rn
+			FROM todo.note n
+			JOIN subtree s ON n.folder_id = s.id
+		)
+		UPDATE todo.note n
+		SET folder_id = NULL, position = root_max.position + ordered.rn
+		FROM ordered, root_max
+		WHERE n.id = ordered.id`
+
+	getNotePlacementQuery = `
+		SELECT n.project_id, n.folder_id, n.position
+		FROM todo.note n
+		JOIN todo.project_member pm ON n.project_id = pm.project_id
+		WHERE n.id = $1 AND pm.user_id = $2
+		FOR UPDATE OF n`
+
+	countFolderNotesQuery = `
+		SELECT COUNT(*) FROM todo.note
+		WHERE project_id = $1 AND folder_id IS NOT DISTINCT FROM $2 AND id <> $3`
+
+	// Заметки после освободившегося места сдвигаются вверх
+	closeNoteGapQuery = `
+		UPDATE todo.note SET position = position - 1
+		WHERE project_id = $1 AND folder_id IS NOT DISTINCT FROM $2 AND position > $3 AND id <> $4`
+
+	// Заметки начиная с новой позиции сдвигаются вниз
+	openNoteGapQuery = `
+		UPDATE todo.note SET position = position + 1
+		WHERE project_id = $1 AND folder_id IS NOT DISTINCT FROM $2 AND position >= $3 AND id <> $4`
+
+	placeNoteQuery = `
+		UPDATE todo.note SET folder_id = $2, position = $3
+		WHERE id = $1`
+
+	setNoteFlagsQuery = `
+		INSERT INTO todo.note_user_flag (user_id, note_id, pinned, favourite)
+		SELECT $1, n.id, COALESCE($3::boolean, FALSE), COALESCE($4::boolean, FALSE)
+		FROM todo.note n
+		JOIN todo.project_member pm ON n.project_id = pm.project_id
+		WHERE n.id = $2 AND pm.user_id = $1
+		ON CONFLICT (user_id, note_id) DO UPDATE
+		SET pinned = COALESCE($3::boolean, todo.note_user_flag.pinned),
+			favourite = COALESCE($4::boolean, todo.note_user_flag.favourite)`
+)
+
+// CreateFolder создает папку. Доступ к проекту проверяется заранее
+func (r *NoteRepository) CreateFolder(ctx context.Context, folder *models.NoteFolder) error {
+	const op = "NoteRepository.CreateFolder"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", folder.ProjectID)
+
+	result, err := r.db.ExecContext(ctx, createFolderQuery,
+		folder.ID, folder.ProjectID, folder.ParentID, folder.Name, folder.CreatedAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to create folder")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Warn("parent folder not found in project")
+		return errs.NewNotFoundError("parent folder not found")
+	}
+
+	return nil
+}
+
+func (r *NoteRepository) GetFoldersByProject(ctx context.Context, projectID, userID uuid.UUID) ([]models.NoteFolder, error) {
+	const op = "NoteRepository.GetFoldersByProject"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", projectID).
+		WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, getFoldersByProjectQuery, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get folders")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var folders []models.NoteFolder
+	for rows.Next() {
+		var f models.NoteFolder
+		if err := rows.Scan(&f.ID, &f.ProjectID, &f.ParentID, &f.Name, &f.Path, &f.CreatedAt); err != nil {
+			logger.WithError(err).Error("failed to scan folder")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		folders = append(folders, f)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return folders, nil
+}
+
+// UpdateFolder переименовывает папку и переносит ее под другую родительскую папку
+func (r *NoteRepository) UpdateFolder(ctx context.Context, folderID, userID uuid.UUID, name string, parentID *uuid.UUID) error {
+	const op = "NoteRepository.UpdateFolder"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("folderID", folderID).
+		WithField("userID", userID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var projectID uuid.UUID
+	if err := tx.QueryRowContext(ctx, getFolderProjectQuery, folderID, userID).Scan(&projectID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("folder not found")
+			return errs.ErrNotFound
+		}
+		logger.WithError(err).Error("failed to get folder")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if parentID != nil {
+		if err := r.checkFolderParent(ctx, tx, folderID, projectID, *parentID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, updateFolderQuery, folderID, name, parentID); err != nil {
+		logger.WithError(err).Error("failed to update folder")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// checkFolderParent проверяет, что новая родительская папка из того же проекта
+// и не находится внутри переносимой папки
+func (r *NoteRepository) checkFolderParent(ctx context.Context, tx *sql.Tx, folderID, projectID, parentID uuid.UUID) error {
+	const op = "NoteRepository.checkFolderParent"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("folderID", folderID).
+		WithField("parentID", parentID)
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, folderExistsInProjectQuery, parentID, projectID).Scan(&exists); err != nil {
+		logger.WithError(err).Error("failed to check parent folder")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		logger.Warn("parent folder not found in project")
+		return errs.NewNotFoundError("parent folder not found")
+	}
+
+	var isDescendant bool
+	if err := tx.QueryRowContext(ctx, folderIsDescendantQuery, folderID, parentID).Scan(&isDescendant); err != nil {
+		logger.WithError(err).Error("failed to check folder cycle")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if isDescendant {
+		logger.Warn("folder cannot be moved into itself")
+		return errs.ErrFolderCycle
+	}
+
+	return nil
+}
+
+// DeleteFolder удаляет папку вместе с вложенными папками. Заметки из них попадают
+// в конец корня проекта, чтобы их позиции не совпали с позициями заметок в корне
+func (r *NoteRepository) DeleteFolder(ctx context.Context, folderID, userID uuid.UUID) error {
+	const op = "NoteRepository.DeleteFolder"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("folderID", folderID).
+		WithField("userID", userID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var projectID uuid.UUID
+	if err := tx.QueryRowContext(ctx, getFolderProjectQuery, folderID, userID).Scan(&projectID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("folder not found")
+			return errs.ErrNotFound
+		}
+		logger.WithError(err).Error("failed to get folder project")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, moveFolderNotesToRootQuery, folderID, projectID); err != nil {
+		logger.WithError(err).Error("failed to move folder notes to root")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteFolderQuery, folderID); err != nil {
+		logger.WithError(err).Error("failed to delete folder")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// MoveNote помещает заметку в папку (nil - корень проекта) на указанную позицию.
+// Позиция за пределами списка прижимается к его концу
+func (r *NoteRepository) MoveNote(ctx context.Context, noteID, userID uuid.UUID, folderID *uuid.UUID, position int) (int, error) {
+	const op = "NoteRepository.MoveNote"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID).
+		WithField("userID", userID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var (
+		projectID   uuid.UUID
+		oldFolderID *uuid.UUID
+		oldPosition int
+	)
+	err = tx.QueryRowContext(ctx, getNotePlacementQuery, noteID, userID).Scan(&projectID, &oldFolderID, &oldPosition)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("note not found")
+			return 0, errs.ErrNotFound
+		}
+		logger.WithError(err).Error("failed to get note placement")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if folderID != nil {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, folderExistsInProjectQuery, *folderID, projectID).Scan(&exists); err != nil {
+			logger.WithError(err).Error("failed to check folder")
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		if !exists {
+			logger.Warn("folder not found in note project")
+			return 0, errs.NewNotFoundError("folder not found")
+		}
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, countFolderNotesQuery, projectID, folderID, noteID).Scan(&count); err != nil {
+		logger.WithError(err).Error("failed to count folder notes")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if position > count {
+		position = count
+	}
+
+	if _, err := tx.ExecContext(ctx, closeNoteGapQuery, projectID, oldFolderID, oldPosition, noteID); err != nil {
+		logger.WithError(err).Error("failed to close gap in old folder")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, openNoteGapQuery, projectID, folderID, position, noteID); err != nil {
+		logger.WithError(err).Error("failed to open gap in new folder")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, placeNoteQuery, noteID, folderID, position); err != nil {
+		logger.WithError(err).Error("failed to place note")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return position, nil
+}
+
+// SetNoteFlags меняет личные отметки пользователя. nil оставляет отметку без изменений
+func (r *NoteRepository) SetNoteFlags(ctx context.Context, noteID, userID uuid.UUID, pinned, favourite *bool) error {
+	const op = "NoteRepository.SetNoteFlags"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID).
+		WithField("userID", userID)
+
+	result, err := r.db.ExecContext(ctx, setNoteFlagsQuery, userID, noteID, pinned, favourite)
+	if err != nil {
+		logger.WithError(err).Error("failed to set note flags")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		logger.Warn("note not found")
+		return errs.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,405 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/note"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestNoteRepository_CreateFolder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewNoteRepository(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	parentID := uuid.New()
+	folder := &models.NoteFolder{
+		ID:        uuid.New(),
+		ProjectID: uuid.New(),
+		ParentID:  &parentID,
+		Name:      "Docs",
+		CreatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "successful folder creation",
+			setupMocks: func() {
+				mock.ExpectExec(`INSERT INTO todo.note_folder`).
+					WithArgs(folder.ID, folder.ProjectID, folder.ParentID, folder.Name, folder.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "parent folder from another project",
+			setupMocks: func() {
+				mock.ExpectExec(`INSERT INTO todo.note_folder`).
+					WithArgs(folder.ID, folder.ProjectID, folder.ParentID, folder.Name, folder.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := repo.CreateFolder(ctx, folder)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNoteRepository_GetFoldersByProject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewNoteRepository(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	userID := uuid.New()
+	rootID := uuid.New()
+
+	rows := sqlmock.NewRows([]string{"id", "project_id", "parent_id", "name", "path", "created_at"}).
+		AddRow(rootID, projectID, nil, "Docs", "Docs", time.Now()).
+		AddRow(uuid.New(), projectID, rootID, "API", "Docs/API", time.Now())
+	mock.ExpectQuery(`WITH RECURSIVE tree`).
+		WithArgs(projectID, userID).
+		WillReturnRows(rows)
+
+	folders, err := repo.GetFoldersByProject(ctx, projectID, userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, folders, 2)
+	assert.Nil(t, folders[0].ParentID)
+	assert.Equal(t, rootID, *folders[1].ParentID)
+	assert.Equal(t, "Docs/API", folders[1].Path)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNoteRepository_UpdateFolder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewNoteRepository(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	folderID := uuid.New()
+	userID := uuid.New()
+	projectID := uuid.New()
+	parentID := uuid.New()
+
+	tests := []struct {
+		name        string
+		parentID    *uuid.UUID
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "rename root folder",
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT f.project_id`).
+					WithArgs(folderID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+				mock.ExpectExec(`UPDATE todo.note_folder`).
+					WithArgs(folderID, "Renamed", nil).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "move into another folder",
+			parentID: &parentID,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT f.project_id`).
+					WithArgs(folderID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+				mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM todo.note_folder WHERE id = \$1 AND project_id = \$2\)`).
+					WithArgs(parentID, projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery(`WITH RECURSIVE descendants`).
+					WithArgs(folderID, parentID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec(`UPDATE todo.note_folder`).
+					WithArgs(folderID, "Renamed", &parentID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:     "move into own descendant",
+			parentID: &parentID,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT f.project_id`).
+					WithArgs(folderID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+				mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM todo.note_folder WHERE id = \$1 AND project_id = \$2\)`).
+					WithArgs(parentID, projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery(`WITH RECURSIVE descendants`).
+					WithArgs(folderID, parentID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrFolderCycle,
+		},
+		{
+			name: "folder not found",
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT f.project_id`).
+					WithArgs(folderID, userID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := repo.UpdateFolder(ctx, folderID, userID, "Renamed", tt.parentID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNoteRepository_DeleteFolder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewNoteRepository(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	folderID := uuid.New()
+	userID := uuid.New()
+	projectID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "notes are appended to root",
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT f.project_id`).
+					WithArgs(folderID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+				mock.ExpectExec(`SET folder_id = NULL, position = root_max.position \+ ordered.rn`).
+					WithArgs(folderID, projectID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`DELETE FROM todo.note_folder WHERE id = \$1`).
+					WithArgs(folderID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "folder not found",
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT f.project_id`).
+					WithArgs(folderID, userID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := repo.DeleteFolder(ctx, folderID, userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNoteRepository_MoveNote(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewNoteRepository(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	noteID := uuid.New()
+	userID := uuid.New()
+	projectID := uuid.New()
+	folderID := uuid.New()
+
+	tests := []struct {
+		name             string
+		position         int
+		setupMocks       func()
+		expectedErr      error
+		expectedPosition int
+	}{
+		{
+			name:     "position is clamped to folder size",
+			position: 10,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT n.project_id, n.folder_id, n.position`).
+					WithArgs(noteID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "folder_id", "position"}).AddRow(projectID, nil, 2))
+				mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM todo.note_folder`).
+					WithArgs(folderID, projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM todo.note`).
+					WithArgs(projectID, &folderID, noteID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectExec(`SET position = position - 1`).
+					WithArgs(projectID, nil, 2, noteID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`SET position = position \+ 1`).
+					WithArgs(projectID, &folderID, 3, noteID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`UPDATE todo.note SET folder_id = \$2, position = \$3`).
+					WithArgs(noteID, &folderID, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedPosition: 3,
+		},
+		{
+			name:     "folder from another project",
+			position: 0,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT n.project_id, n.folder_id, n.position`).
+					WithArgs(noteID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "folder_id", "position"}).AddRow(projectID, nil, 0))
+				mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM todo.note_folder`).
+					WithArgs(folderID, projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+		{
+			name:     "database error",
+			position: 0,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT n.project_id, n.folder_id, n.position`).
+					WithArgs(noteID, userID).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			position, err := repo.MoveNote(ctx, noteID, userID, &folderID, tt.position)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				if tt.expectedErr == errs.ErrNotFound {
+					assert.ErrorIs(t, err, errs.ErrNotFound)
+				} else {
+					assert.Contains(t, err.Error(), "NoteRepository.MoveNote")
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPosition, position)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNoteRepository_SetNoteFlags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewNoteRepository(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	noteID := uuid.New()
+	userID := uuid.New()
+	pinned := true
+
+	tests := []struct {
+		name        string
+		rows        int64
+		expectedErr error
+	}{
+		{name: "flags updated", rows: 1},
+		{name: "note not accessible", rows: 0, expectedErr: errs.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectExec(`INSERT INTO todo.note_user_flag`).
+				WithArgs(userID, noteID, &pinned, nil).
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+
+			err := repo.SetNoteFlags(ctx, noteID, userID, &pinned, nil)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
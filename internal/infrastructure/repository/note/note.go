@@ -3,46 +3,103 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	linkrepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/link"
+	mentionrepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/mention"
+	outbox "github.com/lzimin05/course-todo/internal/infrastructure/repository/outbox"
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
 	models "github.com/lzimin05/course-todo/internal/models/note"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
 )
 
 const (
 	getAllNotesByProjectQuery = `
-		SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.created_at
+		SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.format, n.created_at, n.version,
+			n.folder_id, n.position, COALESCE(uf.pinned, FALSE), COALESCE(uf.favourite, FALSE)
 		FROM todo.note n
 		JOIN todo.project_member pm ON n.project_id = pm.project_id
-		WHERE n.project_id = $1 AND pm.user_id = $2`
+		LEFT JOIN todo.note_user_flag uf ON uf.note_id = n.id AND uf.user_id = pm.user_id
+		WHERE n.project_id = $1 AND pm.user_id = $2
+		ORDER BY COALESCE(uf.pinned, FALSE) DESC, n.position, n.created_at`
 
 	getAllNotesQuery = `
-		SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.created_at
+		SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.format, n.created_at, n.version,
+			n.folder_id, n.position, COALESCE(uf.pinned, FALSE), COALESCE(uf.favourite, FALSE)
 		FROM todo.note n
 		JOIN todo.project_member pm ON n.project_id = pm.project_id
+		LEFT JOIN todo.note_user_flag uf ON uf.note_id = n.id AND uf.user_id = $1
 		WHERE n.user_id = $1`
 
+	getNoteByIDQuery = `
+		SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.format, n.created_at, n.version,
+			n.folder_id, n.position, COALESCE(uf.pinned, FALSE), COALESCE(uf.favourite, FALSE)
+		FROM todo.note n
+		JOIN todo.project_member pm ON n.project_id = pm.project_id
+		LEFT JOIN todo.note_user_flag uf ON uf.note_id = n.id AND uf.user_id = pm.user_id
+		WHERE n.id = $1 AND pm.user_id = $2`
+
+	getNoteProjectQuery = `
+		SELECT n.project_id
+		FROM todo.note n
+		JOIN todo.project_member pm ON n.project_id = pm.project_id
+		WHERE n.id = $1 AND pm.user_id = $2`
+
 	createNoteQuery = `
-		INSERT INTO todo.note (id, project_id, user_id, name, description, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id`
+		INSERT INTO todo.note (id, project_id, user_id, name, description, format, created_at, position)
+		SELECT $1, $2, $3, $4, $5, $6, $7, COALESCE(MAX(n.position) + 1, 0)
+		FROM todo.note n
+		WHERE n.project_id = $2 AND n.folder_id IS NULL
+		RETURNING id, version`
 
 	updateNoteQuery = `
 		UPDATE todo.note 
-		SET name = $4, description = $5 
+		SET name = $4, description = $5, format = COALESCE(NULLIF($7, ''), format), version = version + 1
 		WHERE id = $1 AND project_id = $2 AND project_id IN (
 			SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $3
-		)`
+		) AND ($6::int[] IS NULL OR version = ANY($6))
+		RETURNING version, format`
+
+	insertNoteRevisionQuery = `
+		INSERT INTO todo.note_revision (note_id, revision, name, description, author_id, created_at, restored_from, format)
+		SELECT $1, COALESCE(MAX(r.revision), 0) + 1, $2, $3, $4, $5, $6, $7
+		FROM todo.note_revision r
+		WHERE r.note_id = $1
+		RETURNING revision`
+
+	getNoteRevisionsQuery = `
+		SELECT r.note_id, r.revision, r.name, r.format, r.author_id, r.created_at, r.restored_from
+		FROM todo.note_revision r
+		JOIN todo.note n ON n.id = r.note_id
+		JOIN todo.project_member pm ON n.project_id = pm.project_id
+		WHERE r.note_id = $1 AND pm.user_id = $2
+		ORDER BY r.revision DESC`
+
+	getNoteRevisionQuery = `
+		SELECT r.note_id, r.revision, r.name, COALESCE(r.description, ''), r.format, r.author_id, r.created_at, r.restored_from
+		FROM todo.note_revision r
+		JOIN todo.note n ON n.id = r.note_id
+		JOIN todo.project_member pm ON n.project_id = pm.project_id
+		WHERE r.note_id = $1 AND r.revision = $2 AND pm.user_id = $3`
+
+	restoreNoteQuery = `
+		UPDATE todo.note 
+		SET name = $2, description = $3, format = $4, version = version + 1
+		WHERE id = $1
+		RETURNING project_id, version`
 
 	deleteNoteQuery = `
 		DELETE FROM todo.note 
 		WHERE id = $1 AND project_id IN (
 			SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $2
-		)`
+		) AND ($3::int[] IS NULL OR version = ANY($3))
+		RETURNING project_id, name, version`
 )
 
 type NoteRepository struct {
@@ -69,7 +126,8 @@ func (r *NoteRepository) GetNotesByProject(ctx context.Context, projectID, userI
 	var notes []models.Note
 	for rows.Next() {
 		var n models.Note
-		err := rows.Scan(&n.ID, &n.ProjectID, &n.UserID, &n.Name, &n.Description, &n.CreatedAt)
+		err := rows.Scan(&n.ID, &n.ProjectID, &n.UserID, &n.Name, &n.Description, &n.Format, &n.CreatedAt, &n.Version,
+			&n.FolderID, &n.Position, &n.Pinned, &n.Favourite)
 		if err != nil {
 			logger.WithError(err).Error("failed to scan note")
 			return nil, fmt.Errorf("%s: %w", op, err)
@@ -100,7 +158,8 @@ func (r *NoteRepository) GetAllNotes(ctx context.Context, userID uuid.UUID) ([]m
 	var notes []models.Note
 	for rows.Next() {
 		var n models.Note
-		err := rows.Scan(&n.ID, &n.ProjectID, &n.UserID, &n.Name, &n.Description, &n.CreatedAt)
+		err := rows.Scan(&n.ID, &n.ProjectID, &n.UserID, &n.Name, &n.Description, &n.Format, &n.CreatedAt, &n.Version,
+			&n.FolderID, &n.Position, &n.Pinned, &n.Favourite)
 		if err != nil {
 			logger.WithError(err).Error("failed to scan note")
 			return nil, fmt.Errorf("%s: %w", op, err)
@@ -116,7 +175,30 @@ func (r *NoteRepository) GetAllNotes(ctx context.Context, userID uuid.UUID) ([]m
 	return notes, nil
 }
 
-func (r *NoteRepository) CreateNote(ctx context.Context, projectID, userID uuid.UUID, name, description string) (uuid.UUID, error) {
+func (r *NoteRepository) GetNoteByID(ctx context.Context, noteID, userID uuid.UUID) (*models.Note, error) {
+	const op = "NoteRepository.GetNoteByID"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID).
+		WithField("userID", userID)
+
+	var n models.Note
+	err := r.db.QueryRowContext(ctx, getNoteByIDQuery, noteID, userID).
+		Scan(&n.ID, &n.ProjectID, &n.UserID, &n.Name, &n.Description, &n.Format, &n.CreatedAt, &n.Version,
+			&n.FolderID, &n.Position, &n.Pinned, &n.Favourite)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("note not found")
+			return nil, errs.ErrNotFound
+		}
+		logger.WithError(err).Error("failed to get note")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &n, nil
+}
+
+// CreateNote создает заметку и в той же транзакции сохраняет ссылки и упоминания из ее текста
+func (r *NoteRepository) CreateNote(ctx context.Context, projectID, userID uuid.UUID, name, description, format string, refs linkmodels.References, mentions []string) (uuid.UUID, error) {
 	const op = "NoteRepository.CreateNote"
 	logger := logctx.GetLogger(ctx).WithField("op", op).
 		WithField("projectID", projectID).
@@ -128,77 +210,342 @@ func (r *NoteRepository) CreateNote(ctx context.Context, projectID, userID uuid.
 		UserID:      userID,
 		Name:        name,
 		Description: description,
+		Format:      format,
 		CreatedAt:   time.Now(),
 	}
 
-	var id uuid.UUID
-	err := r.db.QueryRowContext(ctx, createNoteQuery,
-		newNote.ID, newNote.ProjectID, newNote.UserID, newNote.Name, newNote.Description, newNote.CreatedAt).
-		Scan(&id)
-
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			logger.WithError(pqErr).Error("database error")
-			return uuid.Nil, fmt.Errorf("%s: %w", op, pqErr)
-		}
+		logger.WithError(err).Error("failed to begin transaction")
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := InsertNote(ctx, tx, &newNote); err != nil {
 		logger.WithError(err).Error("failed to create note")
 		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return id, nil
+	if err := linkrepo.ReplaceLinks(ctx, tx, linkmodels.TypeNote, newNote.ID, userID, refs); err != nil {
+		logger.WithError(err).Error("failed to save note links")
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := mentionrepo.ReplaceMentions(ctx, tx, linkmodels.TypeNote, newNote.ID, userID, mentions); err != nil {
+		logger.WithError(err).Error("failed to save note mentions")
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return newNote.ID, nil
+}
+
+// InsertNote создает заметку в переданной транзакции вместе с первой ревизией и событием в outbox
+func InsertNote(ctx context.Context, tx *sql.Tx, note *models.Note) error {
+	err := tx.QueryRowContext(ctx, createNoteQuery,
+		note.ID, note.ProjectID, note.UserID, note.Name, note.Description, note.Format, note.CreatedAt).
+		Scan(&note.ID, &note.Version)
+	if err != nil {
+		return fmt.Errorf("insert note: %w", err)
+	}
+
+	// Исходное содержимое заметки - первая ревизия
+	var revision int
+	err = tx.QueryRowContext(ctx, insertNoteRevisionQuery,
+		note.ID, note.Name, note.Description, note.UserID, note.CreatedAt, nil, note.Format).
+		Scan(&revision)
+	if err != nil {
+		return fmt.Errorf("save note revision: %w", err)
+	}
+
+	if err := outbox.Write(ctx, tx, noteEvent(eventmodels.TypeNoteCreated, note.UserID, note)); err != nil {
+		return fmt.Errorf("write note event: %w", err)
+	}
+	return nil
 }
 
-func (r *NoteRepository) UpdateNote(ctx context.Context, userID, noteID, projectID uuid.UUID, name, description string) error {
+// UpdateNote сохраняет правку заметки вместе со ссылками и упоминаниями из нового
+// текста. Пустой format оставляет прежний формат
+func (r *NoteRepository) UpdateNote(ctx context.Context, userID, noteID, projectID uuid.UUID, name, description, format string, expectedVersions []int, refs linkmodels.References, mentions []string) (int, error) {
 	const op = "NoteRepository.UpdateNote"
 	logger := logctx.GetLogger(ctx).WithField("op", op).
 		WithField("userID", userID).
 		WithField("noteID", noteID).
 		WithField("projectID", projectID)
 
-	result, err := r.db.ExecContext(ctx, updateNoteQuery,
-		noteID, projectID, userID, name, description)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	// Пустой format не меняет формат, поэтому в ревизию пишется сохраненный
+	var version int
+	err = tx.QueryRowContext(ctx, updateNoteQuery,
+		noteID, projectID, userID, name, description, pq.Array(expectedVersions), format).
+		Scan(&version, &format)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, r.notChangedReason(ctx, noteID, &projectID, userID)
+		}
 		logger.WithError(err).Error("failed to update note")
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	// Строка заметки заблокирована обновлением, поэтому номера ревизий не пересекаются
+	var revision int
+	err = tx.QueryRowContext(ctx, insertNoteRevisionQuery,
+		noteID, name, description, userID, time.Now(), nil, format).
+		Scan(&revision)
 	if err != nil {
-		logger.WithError(err).Error("failed to get rows affected")
-		return fmt.Errorf("%s: %w", op, err)
+		logger.WithError(err).Error("failed to save note revision")
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if rowsAffected == 0 {
-		logger.Warn("note not found or not owned by user")
-		return errs.ErrNotFound
+	if err := linkrepo.ReplaceLinks(ctx, tx, linkmodels.TypeNote, noteID, userID, refs); err != nil {
+		logger.WithError(err).Error("failed to save note links")
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	if err := mentionrepo.ReplaceMentions(ctx, tx, linkmodels.TypeNote, noteID, userID, mentions); err != nil {
+		logger.WithError(err).Error("failed to save note mentions")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	note := &models.Note{ID: noteID, ProjectID: projectID, Name: name, Version: version}
+	if err := outbox.Write(ctx, tx, noteEvent(eventmodels.TypeNoteUpdated, userID, note)); err != nil {
+		logger.WithError(err).Error("failed to write note event")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, nil
 }
 
-func (r *NoteRepository) DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error {
+func (r *NoteRepository) DeleteNote(ctx context.Context, userID, noteID uuid.UUID, expectedVersions []int) error {
 	const op = "NoteRepository.DeleteNote"
 	logger := logctx.GetLogger(ctx).WithField("op", op).
 		WithField("userID", userID).
 		WithField("noteID", noteID)
 
-	result, err := r.db.ExecContext(ctx, deleteNoteQuery, noteID, userID)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	note := &models.Note{ID: noteID}
+	err = tx.QueryRowContext(ctx, deleteNoteQuery, noteID, userID, pq.Array(expectedVersions)).
+		Scan(&note.ProjectID, &note.Name, &note.Version)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return r.notChangedReason(ctx, noteID, nil, userID)
+		}
 		logger.WithError(err).Error("failed to delete note")
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	if err := outbox.Write(ctx, tx, noteEvent(eventmodels.TypeNoteDeleted, userID, note)); err != nil {
+		logger.WithError(err).Error("failed to write note event")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// notChangedReason определяет, почему условное изменение не затронуло заметку:
+// ее нет у пользователя (или она в другом проекте) либо версия уже изменилась
+func (r *NoteRepository) notChangedReason(ctx context.Context, noteID uuid.UUID, projectID *uuid.UUID, userID uuid.UUID) error {
+	const op = "NoteRepository.notChangedReason"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID)
+
+	var actualProjectID uuid.UUID
+	err := r.db.QueryRowContext(ctx, getNoteProjectQuery, noteID, userID).Scan(&actualProjectID)
 	if err != nil {
-		logger.WithError(err).Error("failed to get rows affected")
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("note not found or not owned by user")
+			return errs.ErrNotFound
+		}
+		logger.WithError(err).Error("failed to check note existence")
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if rowsAffected == 0 {
-		logger.Warn("note not found or not owned by user")
+	if projectID != nil && *projectID != actualProjectID {
+		logger.Warn("note belongs to another project")
 		return errs.ErrNotFound
 	}
 
-	return nil
+	logger.Warn("note version mismatch")
+	return errs.ErrVersionMismatch
+}
+
+func (r *NoteRepository) GetNoteRevisions(ctx context.Context, noteID, userID uuid.UUID) ([]models.NoteRevision, error) {
+	const op = "NoteRepository.GetNoteRevisions"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("userID", userID).
+		WithField("noteID", noteID)
+
+	rows, err := r.db.QueryContext(ctx, getNoteRevisionsQuery, noteID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note revisions")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var revisions []models.NoteRevision
+	for rows.Next() {
+		var rev models.NoteRevision
+		err := rows.Scan(&rev.NoteID, &rev.Revision, &rev.Name, &rev.Format, &rev.AuthorID, &rev.CreatedAt, &rev.RestoredFrom)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan note revision")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		revisions = append(revisions, rev)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// У каждой доступной заметки есть хотя бы одна ревизия
+	if len(revisions) == 0 {
+		logger.Warn("note not found or not accessible by user")
+		return nil, errs.ErrNotFound
+	}
+
+	return revisions, nil
+}
+
+func (r *NoteRepository) GetNoteRevision(ctx context.Context, noteID uuid.UUID, revision int, userID uuid.UUID) (*models.NoteRevision, error) {
+	const op = "NoteRepository.GetNoteRevision"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("userID", userID).
+		WithField("noteID", noteID).
+		WithField("revision", revision)
+
+	rev, err := scanNoteRevision(r.db.QueryRowContext(ctx, getNoteRevisionQuery, noteID, revision, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("note revision not found")
+			return nil, errs.ErrNotFound
+		}
+		logger.WithError(err).Error("failed to get note revision")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rev, nil
+}
+
+// RestoreNoteRevision возвращает заметке содержимое старой ревизии и сохраняет его как новую ревизию.
+// refs и mentions - ссылки и упоминания из текста восстанавливаемой ревизии, они заменяют
+// текущие в той же транзакции
+func (r *NoteRepository) RestoreNoteRevision(ctx context.Context, noteID uuid.UUID, revision int, userID uuid.UUID, refs linkmodels.References, mentions []string) (*models.NoteRevision, error) {
+	const op = "NoteRepository.RestoreNoteRevision"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("userID", userID).
+		WithField("noteID", noteID).
+		WithField("revision", revision)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	old, err := scanNoteRevision(tx.QueryRowContext(ctx, getNoteRevisionQuery, noteID, revision, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("note revision not found")
+			return nil, errs.ErrNotFound
+		}
+		logger.WithError(err).Error("failed to get note revision")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	note := &models.Note{ID: noteID, Name: old.Name}
+	err = tx.QueryRowContext(ctx, restoreNoteQuery, noteID, old.Name, old.Description, old.Format).
+		Scan(&note.ProjectID, &note.Version)
+	if err != nil {
+		logger.WithError(err).Error("failed to restore note")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	restored := &models.NoteRevision{
+		NoteID:       noteID,
+		Name:         old.Name,
+		Description:  old.Description,
+		Format:       old.Format,
+		AuthorID:     &userID,
+		CreatedAt:    time.Now(),
+		RestoredFrom: &old.Revision,
+	}
+
+	err = tx.QueryRowContext(ctx, insertNoteRevisionQuery,
+		noteID, restored.Name, restored.Description, userID, restored.CreatedAt, old.Revision, restored.Format).
+		Scan(&restored.Revision)
+	if err != nil {
+		logger.WithError(err).Error("failed to save note revision")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := linkrepo.ReplaceLinks(ctx, tx, linkmodels.TypeNote, noteID, userID, refs); err != nil {
+		logger.WithError(err).Error("failed to save note links")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := mentionrepo.ReplaceMentions(ctx, tx, linkmodels.TypeNote, noteID, userID, mentions); err != nil {
+		logger.WithError(err).Error("failed to save note mentions")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := outbox.Write(ctx, tx, noteEvent(eventmodels.TypeNoteUpdated, userID, note)); err != nil {
+		logger.WithError(err).Error("failed to write note event")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return restored, nil
+}
+
+func scanNoteRevision(row *sql.Row) (*models.NoteRevision, error) {
+	var rev models.NoteRevision
+	err := row.Scan(&rev.NoteID, &rev.Revision, &rev.Name, &rev.Description, &rev.Format, &rev.AuthorID, &rev.CreatedAt, &rev.RestoredFrom)
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// noteEvent собирает событие о заметке для outbox
+func noteEvent(eventType string, actorID uuid.UUID, note *models.Note) eventmodels.Event {
+	data := eventmodels.NoteData{
+		ID:        note.ID,
+		ProjectID: note.ProjectID,
+		Name:      note.Name,
+		Version:   note.Version,
+	}
+	return eventmodels.New(eventType, note.ProjectID, actorID, data)
 }
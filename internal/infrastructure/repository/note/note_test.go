@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
 )
 
@@ -41,11 +43,11 @@ func TestNoteRepository_GetNotesByProject(t *testing.T) {
 			projectID: projectID,
 			userID:    userID,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "name", "description", "created_at"}).
-					AddRow(noteID, projectID, userID, "Note 1", "Description 1", createdAt).
-					AddRow(uuid.New(), projectID, userID, "Note 2", "Description 2", createdAt)
+				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "name", "description", "format", "created_at", "version", "folder_id", "position", "pinned", "favourite"}).
+					AddRow(noteID, projectID, userID, "Note 1", "Description 1", "plain", createdAt, 1, nil, 0, false, false).
+					AddRow(uuid.New(), projectID, userID, "Note 2", "Description 2", "markdown", createdAt, 1, nil, 0, false, false)
 
-				mock.ExpectQuery(`SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.created_at`).
+				mock.ExpectQuery(`SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.format, n.created_at`).
 					WithArgs(projectID, userID).
 					WillReturnRows(rows)
 			},
@@ -57,9 +59,9 @@ func TestNoteRepository_GetNotesByProject(t *testing.T) {
 			projectID: projectID,
 			userID:    userID,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "name", "description", "created_at"})
+				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "name", "description", "format", "created_at", "version", "folder_id", "position", "pinned", "favourite"})
 
-				mock.ExpectQuery(`SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.created_at`).
+				mock.ExpectQuery(`SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.format, n.created_at`).
 					WithArgs(projectID, userID).
 					WillReturnRows(rows)
 			},
@@ -71,7 +73,7 @@ func TestNoteRepository_GetNotesByProject(t *testing.T) {
 			projectID: projectID,
 			userID:    userID,
 			setupMocks: func() {
-				mock.ExpectQuery(`SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.created_at`).
+				mock.ExpectQuery(`SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.format, n.created_at`).
 					WithArgs(projectID, userID).
 					WillReturnError(errors.New("database connection error"))
 			},
@@ -124,10 +126,10 @@ func TestNoteRepository_GetAllNotes(t *testing.T) {
 			name:   "successful all notes retrieval",
 			userID: userID,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "name", "description", "created_at"}).
-					AddRow(noteID, projectID, userID, "User Note 1", "Description 1", createdAt)
+				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "name", "description", "format", "created_at", "version", "folder_id", "position", "pinned", "favourite"}).
+					AddRow(noteID, projectID, userID, "User Note 1", "Description 1", "plain", createdAt, 1, nil, 0, false, false)
 
-				mock.ExpectQuery(`SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.created_at`).
+				mock.ExpectQuery(`SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.format, n.created_at`).
 					WithArgs(userID).
 					WillReturnRows(rows)
 			},
@@ -138,7 +140,7 @@ func TestNoteRepository_GetAllNotes(t *testing.T) {
 			name:   "database error",
 			userID: userID,
 			setupMocks: func() {
-				mock.ExpectQuery(`SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.created_at`).
+				mock.ExpectQuery(`SELECT n.id, n.project_id, n.user_id, n.name, n.description, n.format, n.created_at`).
 					WithArgs(userID).
 					WillReturnError(errors.New("database connection error"))
 			},
@@ -178,6 +180,7 @@ func TestNoteRepository_CreateNote(t *testing.T) {
 	projectID := uuid.New()
 	userID := uuid.New()
 	noteID := uuid.New()
+	targetID := uuid.New()
 
 	tests := []struct {
 		name        string
@@ -195,11 +198,31 @@ func TestNoteRepository_CreateNote(t *testing.T) {
 			noteName:    "Test Note",
 			description: "Test Description",
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id"}).AddRow(noteID)
+				rows := sqlmock.NewRows([]string{"id", "version"}).AddRow(noteID, 1)
 
-				mock.ExpectQuery(`INSERT INTO todo.note`).
-					WithArgs(sqlmock.AnyArg(), projectID, userID, "Test Note", "Test Description", sqlmock.AnyArg()).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO todo.note `).
+					WithArgs(sqlmock.AnyArg(), projectID, userID, "Test Note", "Test Description", "markdown", sqlmock.AnyArg()).
 					WillReturnRows(rows)
+				mock.ExpectQuery(`INSERT INTO todo.note_revision`).
+					WithArgs(noteID, "Test Note", "Test Description", userID, sqlmock.AnyArg(), nil, "markdown").
+					WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(1))
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WithArgs(sqlmock.AnyArg(), "note.created", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM todo.entity_link`).
+					WithArgs("note", noteID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO todo.entity_link`).
+					WithArgs("note", noteID, pq.StringArray{targetID.String()}, userID, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+					WithArgs("note", noteID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(projectID, "Test Note"))
+				mock.ExpectExec(`DELETE FROM todo.mention`).
+					WithArgs("note", noteID, projectID, pq.StringArray(nil)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
 			},
 			expectedErr: false,
 		},
@@ -211,9 +234,11 @@ func TestNoteRepository_CreateNote(t *testing.T) {
 			description: "Test Description",
 			setupMocks: func() {
 				pqErr := &pq.Error{Code: "23505"}
-				mock.ExpectQuery(`INSERT INTO todo.note`).
-					WithArgs(sqlmock.AnyArg(), projectID, userID, "Test Note", "Test Description", sqlmock.AnyArg()).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO todo.note `).
+					WithArgs(sqlmock.AnyArg(), projectID, userID, "Test Note", "Test Description", "markdown", sqlmock.AnyArg()).
 					WillReturnError(pqErr)
+				mock.ExpectRollback()
 			},
 			expectedErr: true,
 		},
@@ -224,9 +249,11 @@ func TestNoteRepository_CreateNote(t *testing.T) {
 			noteName:    "Test Note",
 			description: "Test Description",
 			setupMocks: func() {
-				mock.ExpectQuery(`INSERT INTO todo.note`).
-					WithArgs(sqlmock.AnyArg(), projectID, userID, "Test Note", "Test Description", sqlmock.AnyArg()).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO todo.note `).
+					WithArgs(sqlmock.AnyArg(), projectID, userID, "Test Note", "Test Description", "markdown", sqlmock.AnyArg()).
 					WillReturnError(errors.New("database connection error"))
+				mock.ExpectRollback()
 			},
 			expectedErr: true,
 		},
@@ -236,7 +263,7 @@ func TestNoteRepository_CreateNote(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			id, err := repo.CreateNote(ctx, tt.projectID, tt.userID, tt.noteName, tt.description)
+			id, err := repo.CreateNote(ctx, tt.projectID, tt.userID, tt.noteName, tt.description, "markdown", linkmodels.References{IDs: []uuid.UUID{targetID}}, nil)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -263,16 +290,19 @@ func TestNoteRepository_UpdateNote(t *testing.T) {
 	userID := uuid.New()
 	noteID := uuid.New()
 	projectID := uuid.New()
+	staleVersion := 1
 
 	tests := []struct {
-		name        string
-		userID      uuid.UUID
-		noteID      uuid.UUID
-		projectID   uuid.UUID
-		noteName    string
-		description string
-		setupMocks  func()
-		expectedErr error
+		name             string
+		userID           uuid.UUID
+		noteID           uuid.UUID
+		projectID        uuid.UUID
+		noteName         string
+		description      string
+		format           string
+		expectedVersions []int
+		setupMocks       func()
+		expectedErr      error
 	}{
 		{
 			name:        "successful note update",
@@ -281,10 +311,28 @@ func TestNoteRepository_UpdateNote(t *testing.T) {
 			projectID:   projectID,
 			noteName:    "Updated Note",
 			description: "Updated Description",
+			format:      "markdown",
 			setupMocks: func() {
-				mock.ExpectExec(`UPDATE todo.note`).
-					WithArgs(noteID, projectID, userID, "Updated Note", "Updated Description").
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE todo.note`).
+					WithArgs(noteID, projectID, userID, "Updated Note", "Updated Description", nil, "markdown").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "format"}).AddRow(2, "markdown"))
+				mock.ExpectQuery(`INSERT INTO todo.note_revision`).
+					WithArgs(noteID, "Updated Note", "Updated Description", userID, sqlmock.AnyArg(), nil, "markdown").
+					WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(2))
+				mock.ExpectExec(`DELETE FROM todo.entity_link`).
+					WithArgs("note", noteID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+					WithArgs("note", noteID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(projectID, "Updated Note"))
+				mock.ExpectExec(`DELETE FROM todo.mention`).
+					WithArgs("note", noteID, projectID, pq.StringArray(nil)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WithArgs(sqlmock.AnyArg(), "note.updated", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			expectedErr: nil,
 		},
@@ -296,12 +344,37 @@ func TestNoteRepository_UpdateNote(t *testing.T) {
 			noteName:    "Updated Note",
 			description: "Updated Description",
 			setupMocks: func() {
-				mock.ExpectExec(`UPDATE todo.note`).
-					WithArgs(noteID, projectID, userID, "Updated Note", "Updated Description").
-					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE todo.note`).
+					WithArgs(noteID, projectID, userID, "Updated Note", "Updated Description", nil, "").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(`SELECT n.project_id`).
+					WithArgs(noteID, userID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
 			},
 			expectedErr: errs.ErrNotFound,
 		},
+		{
+			name:             "version mismatch",
+			userID:           userID,
+			noteID:           noteID,
+			projectID:        projectID,
+			noteName:         "Updated Note",
+			description:      "Updated Description",
+			expectedVersions: []int{staleVersion},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE todo.note`).
+					WithArgs(noteID, projectID, userID, "Updated Note", "Updated Description", pq.Array([]int{staleVersion}), "").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(`SELECT n.project_id`).
+					WithArgs(noteID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrVersionMismatch,
+		},
 		{
 			name:        "database error",
 			userID:      userID,
@@ -310,9 +383,11 @@ func TestNoteRepository_UpdateNote(t *testing.T) {
 			noteName:    "Updated Note",
 			description: "Updated Description",
 			setupMocks: func() {
-				mock.ExpectExec(`UPDATE todo.note`).
-					WithArgs(noteID, projectID, userID, "Updated Note", "Updated Description").
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE todo.note`).
+					WithArgs(noteID, projectID, userID, "Updated Note", "Updated Description", nil, "").
 					WillReturnError(errors.New("database connection error"))
+				mock.ExpectRollback()
 			},
 			expectedErr: errors.New("database connection error"),
 		},
@@ -322,12 +397,12 @@ func TestNoteRepository_UpdateNote(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			err := repo.UpdateNote(ctx, tt.userID, tt.noteID, tt.projectID, tt.noteName, tt.description)
+			_, err := repo.UpdateNote(ctx, tt.userID, tt.noteID, tt.projectID, tt.noteName, tt.description, tt.format, tt.expectedVersions, linkmodels.References{}, nil)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
-				if tt.expectedErr == errs.ErrNotFound {
-					assert.Equal(t, errs.ErrNotFound, err)
+				if tt.expectedErr == errs.ErrNotFound || tt.expectedErr == errs.ErrVersionMismatch {
+					assert.Equal(t, tt.expectedErr, err)
 				} else {
 					assert.Contains(t, err.Error(), "NoteRepository.UpdateNote")
 				}
@@ -350,22 +425,30 @@ func TestNoteRepository_DeleteNote(t *testing.T) {
 
 	userID := uuid.New()
 	noteID := uuid.New()
+	projectID := uuid.New()
+	staleVersion := 1
 
 	tests := []struct {
-		name        string
-		userID      uuid.UUID
-		noteID      uuid.UUID
-		setupMocks  func()
-		expectedErr error
+		name             string
+		userID           uuid.UUID
+		noteID           uuid.UUID
+		expectedVersions []int
+		setupMocks       func()
+		expectedErr      error
 	}{
 		{
 			name:   "successful note deletion",
 			userID: userID,
 			noteID: noteID,
 			setupMocks: func() {
-				mock.ExpectExec(`DELETE FROM todo.note`).
-					WithArgs(noteID, userID).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`DELETE FROM todo.note`).
+					WithArgs(noteID, userID, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "name", "version"}).AddRow(projectID, "Note", 3))
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WithArgs(sqlmock.AnyArg(), "note.deleted", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			expectedErr: nil,
 		},
@@ -374,20 +457,44 @@ func TestNoteRepository_DeleteNote(t *testing.T) {
 			userID: userID,
 			noteID: noteID,
 			setupMocks: func() {
-				mock.ExpectExec(`DELETE FROM todo.note`).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`DELETE FROM todo.note`).
+					WithArgs(noteID, userID, nil).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(`SELECT n.project_id`).
 					WithArgs(noteID, userID).
-					WillReturnResult(sqlmock.NewResult(0, 0))
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
 			},
 			expectedErr: errs.ErrNotFound,
 		},
+		{
+			name:             "version mismatch",
+			userID:           userID,
+			noteID:           noteID,
+			expectedVersions: []int{staleVersion},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`DELETE FROM todo.note`).
+					WithArgs(noteID, userID, pq.Array([]int{staleVersion})).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(`SELECT n.project_id`).
+					WithArgs(noteID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uuid.New()))
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrVersionMismatch,
+		},
 		{
 			name:   "database error",
 			userID: userID,
 			noteID: noteID,
 			setupMocks: func() {
-				mock.ExpectExec(`DELETE FROM todo.note`).
-					WithArgs(noteID, userID).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`DELETE FROM todo.note`).
+					WithArgs(noteID, userID, nil).
 					WillReturnError(errors.New("database connection error"))
+				mock.ExpectRollback()
 			},
 			expectedErr: errors.New("database connection error"),
 		},
@@ -397,12 +504,12 @@ func TestNoteRepository_DeleteNote(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			err := repo.DeleteNote(ctx, tt.userID, tt.noteID)
+			err := repo.DeleteNote(ctx, tt.userID, tt.noteID, tt.expectedVersions)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
-				if tt.expectedErr == errs.ErrNotFound {
-					assert.Equal(t, errs.ErrNotFound, err)
+				if tt.expectedErr == errs.ErrNotFound || tt.expectedErr == errs.ErrVersionMismatch {
+					assert.Equal(t, tt.expectedErr, err)
 				} else {
 					assert.Contains(t, err.Error(), "NoteRepository.DeleteNote")
 				}
@@ -415,6 +522,114 @@ func TestNoteRepository_DeleteNote(t *testing.T) {
 	}
 }
 
+func TestNoteRepository_GetNoteRevisions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewNoteRepository(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	restoredFrom := 1
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"note_id", "revision", "name", "format", "author_id", "created_at", "restored_from"}).
+			AddRow(noteID, 3, "Restored", "plain", userID, time.Now(), restoredFrom).
+			AddRow(noteID, 2, "Edited", "plain", nil, time.Now(), nil).
+			AddRow(noteID, 1, "Restored", "markdown", userID, time.Now(), nil)
+		mock.ExpectQuery(`SELECT (.+) FROM todo.note_revision`).
+			WithArgs(noteID, userID).
+			WillReturnRows(rows)
+
+		revisions, err := repo.GetNoteRevisions(ctx, noteID, userID)
+
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 3)
+		assert.Equal(t, 3, revisions[0].Revision)
+		assert.Equal(t, 1, *revisions[0].RestoredFrom)
+		assert.Nil(t, revisions[1].AuthorID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("note not accessible", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM todo.note_revision`).
+			WithArgs(noteID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"note_id", "revision", "name", "format", "author_id", "created_at", "restored_from"}))
+
+		revisions, err := repo.GetNoteRevisions(ctx, noteID, userID)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.Nil(t, revisions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestNoteRepository_RestoreNoteRevision(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewNoteRepository(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	projectID := uuid.New()
+	revisionColumns := []string{"note_id", "revision", "name", "description", "format", "author_id", "created_at", "restored_from"}
+
+	t.Run("successful restore", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT (.+) FROM todo.note_revision`).
+			WithArgs(noteID, 1, userID).
+			WillReturnRows(sqlmock.NewRows(revisionColumns).
+				AddRow(noteID, 1, "Original", "line 1\nline 2", "markdown", userID, time.Now(), nil))
+		mock.ExpectQuery(`UPDATE todo.note`).
+			WithArgs(noteID, "Original", "line 1\nline 2", "markdown").
+			WillReturnRows(sqlmock.NewRows([]string{"project_id", "version"}).AddRow(projectID, 5))
+		mock.ExpectQuery(`INSERT INTO todo.note_revision`).
+			WithArgs(noteID, "Original", "line 1\nline 2", userID, sqlmock.AnyArg(), 1, "markdown").
+			WillReturnRows(sqlmock.NewRows([]string{"revision"}).AddRow(4))
+		mock.ExpectExec(`DELETE FROM todo.entity_link`).
+			WithArgs("note", noteID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+			WithArgs("note", noteID).
+			WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(projectID, "Original"))
+		mock.ExpectExec(`DELETE FROM todo.mention`).
+			WithArgs("note", noteID, projectID, pq.StringArray(nil)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO todo.outbox`).
+			WithArgs(sqlmock.AnyArg(), "note.updated", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		restored, err := repo.RestoreNoteRevision(ctx, noteID, 1, userID, linkmodels.References{}, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, restored.Revision)
+		assert.Equal(t, 1, *restored.RestoredFrom)
+		assert.Equal(t, "Original", restored.Name)
+		assert.Equal(t, "markdown", restored.Format)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("revision not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT (.+) FROM todo.note_revision`).
+			WithArgs(noteID, 7, userID).
+			WillReturnRows(sqlmock.NewRows(revisionColumns))
+		mock.ExpectRollback()
+
+		restored, err := repo.RestoreNoteRevision(ctx, noteID, 7, userID, linkmodels.References{}, nil)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.Nil(t, restored)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestNewNoteRepository(t *testing.T) {
 	db, _, err := sqlmock.New()
 	assert.NoError(t, err)
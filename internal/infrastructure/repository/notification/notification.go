@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/notification"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	// Уведомление не создается, если пользователь отключил этот тип или проект
+	// уже удален. Повтор того же события отбрасывается ограничением уникальности
+	queryCreateNotification = `
+	INSERT INTO todo.notification (id, user_id, type, project_id, actor_id, event_id, data, created_at)
+	SELECT $1::uuid, $2::uuid, $3::varchar, $4::uuid, $5::uuid, $6::uuid, $7::jsonb, $8::timestamp
+	WHERE EXISTS (SELECT 1 FROM todo.project p WHERE p.id = $4)
+		AND NOT EXISTS (
+			SELECT 1 FROM todo.notification_preference np
+			WHERE np.user_id = $2 AND np.type = $3 AND NOT np.enabled
+		)
+	ON CONFLICT (user_id, event_id, type) DO NOTHING`
+
+	queryGetTaskAuthor = `SELECT user_id FROM todo.task WHERE id = $1`
+
+	queryGetNotifications = `
+	SELECT n.id, n.user_id, n.type, n.project_id, p.name, n.actor_id, n.event_id, n.data, n.created_at, n.read_at
+	FROM todo.notification n
+	JOIN todo.project p ON p.id = n.project_id
+	WHERE n.user_id = $1 AND (NOT $2::boolean OR n.read_at IS NULL)
+	ORDER BY n.created_at DESC, n.id
+	LIMIT $3 OFFSET $4`
+
+	queryCountUnread = `SELECT COUNT(*) FROM todo.notification WHERE user_id = $1 AND read_at IS NULL`
+
+	queryMarkRead = `
+	UPDATE todo.notification
+	SET read_at = COALESCE(read_at, $3)
+	WHERE id = $1 AND user_id = $2`
+
+	queryMarkAllRead = `
+	UPDATE todo.notification
+	SET read_at = $2
+	WHERE user_id = $1 AND read_at IS NULL`
+
+	queryGetPreferences = `SELECT type, enabled FROM todo.notification_preference WHERE user_id = $1`
+
+	querySetPreference = `
+	INSERT INTO todo.notification_preference (user_id, type, enabled)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id, type) DO UPDATE SET enabled = EXCLUDED.enabled`
+
+	queryDeleteRead = `
+	DELETE FROM todo.notification
+	WHERE read_at IS NOT NULL AND read_at < $1`
+)
+
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// CreateNotification сохраняет уведомление с учетом настроек получателя.
+// Возвращает false, если уведомление не создано
+func (r *NotificationRepository) CreateNotification(ctx context.Context, n *models.Notification) (bool, error) {
+	const op = "NotificationRepository.CreateNotification"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", n.UserID)
+
+	result, err := r.db.ExecContext(ctx, queryCreateNotification,
+		n.ID, n.UserID, n.Type, n.ProjectID, n.ActorID, n.EventID, n.Data, n.CreatedAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to create notification")
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count > 0, nil
+}
+
+// GetTaskAuthor возвращает создателя задачи
+func (r *NotificationRepository) GetTaskAuthor(ctx context.Context, taskID uuid.UUID) (uuid.UUID, error) {
+	const op = "NotificationRepository.GetTaskAuthor"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("taskID", taskID)
+
+	var authorID uuid.UUID
+	if err := r.db.QueryRowContext(ctx, queryGetTaskAuthor, taskID).Scan(&authorID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("%s: %w", op, errs.ErrTaskNotFound)
+		}
+		logger.WithError(err).Error("failed to get task author")
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return authorID, nil
+}
+
+// GetNotifications возвращает уведомления пользователя, новые первыми
+func (r *NotificationRepository) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]models.Notification, error) {
+	const op = "NotificationRepository.GetNotifications"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetNotifications, userID, unreadOnly, limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("failed to get notifications")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	notifications := make([]models.Notification, 0)
+	for rows.Next() {
+		var n models.Notification
+		err := rows.Scan(&n.ID, &n.UserID, &n.Type, &n.ProjectID, &n.ProjectName,
+			&n.ActorID, &n.EventID, &n.Data, &n.CreatedAt, &n.ReadAt)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan notification")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	const op = "NotificationRepository.CountUnread"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, queryCountUnread, userID).Scan(&count); err != nil {
+		logger.WithError(err).Error("failed to count unread notifications")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// MarkRead отмечает уведомление прочитанным. Время первого прочтения не меняется
+func (r *NotificationRepository) MarkRead(ctx context.Context, notificationID, userID uuid.UUID, readAt time.Time) error {
+	const op = "NotificationRepository.MarkRead"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("notificationID", notificationID)
+
+	result, err := r.db.ExecContext(ctx, queryMarkRead, notificationID, userID, readAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to mark notification read")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%s: %w", op, errs.NewNotFoundError("notification not found"))
+	}
+
+	return nil
+}
+
+// MarkAllRead отмечает прочитанными все уведомления пользователя и возвращает их число
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error) {
+	const op = "NotificationRepository.MarkAllRead"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	result, err := r.db.ExecContext(ctx, queryMarkAllRead, userID, readAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to mark notifications read")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// GetPreferences возвращает явно заданные настройки пользователя
+func (r *NotificationRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (map[string]bool, error) {
+	const op = "NotificationRepository.GetPreferences"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetPreferences, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get notification preferences")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	preferences := make(map[string]bool)
+	for rows.Next() {
+		var notificationType string
+		var enabled bool
+		if err := rows.Scan(&notificationType, &enabled); err != nil {
+			logger.WithError(err).Error("failed to scan notification preference")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		preferences[notificationType] = enabled
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return preferences, nil
+}
+
+// SetPreferences сохраняет переданные настройки, остальные не меняются
+func (r *NotificationRepository) SetPreferences(ctx context.Context, userID uuid.UUID, preferences map[string]bool) error {
+	const op = "NotificationRepository.SetPreferences"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	for notificationType, enabled := range preferences {
+		if _, err := tx.ExecContext(ctx, querySetPreference, userID, notificationType, enabled); err != nil {
+			logger.WithError(err).Error("failed to set notification preference")
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteRead удаляет уведомления, прочитанные раньше before, и возвращает их число
+func (r *NotificationRepository) DeleteRead(ctx context.Context, before time.Time) (int64, error) {
+	const op = "NotificationRepository.DeleteRead"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	result, err := r.db.ExecContext(ctx, queryDeleteRead, before)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete read notifications")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/notification"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestNotificationRepository_CreateNotification(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	actorID := uuid.New()
+	n := &models.Notification{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		Type:      models.TypeMemberAdded,
+		ProjectID: uuid.New(),
+		ActorID:   &actorID,
+		EventID:   uuid.New(),
+		Data:      []byte(`{}`),
+		CreatedAt: time.Now(),
+	}
+
+	t.Run("created", func(t *testing.T) {
+		mock.ExpectExec(`INSERT INTO todo.notification .+ ON CONFLICT \(user_id, event_id, type\) DO NOTHING`).
+			WithArgs(n.ID, n.UserID, n.Type, n.ProjectID, n.ActorID, n.EventID, n.Data, n.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		created, err := repo.CreateNotification(ctx, n)
+
+		assert.NoError(t, err)
+		assert.True(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("disabled or duplicate", func(t *testing.T) {
+		mock.ExpectExec(`INSERT INTO todo.notification`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		created, err := repo.CreateNotification(ctx, n)
+
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestNotificationRepository_GetNotifications(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	readAt := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "user_id", "type", "project_id", "name", "actor_id", "event_id", "data", "created_at", "read_at"}).
+		AddRow(uuid.New(), userID, models.TypeTaskCompleted, uuid.New(), "Проект", nil, uuid.New(), []byte(`{"title":"Задача"}`), time.Now(), nil).
+		AddRow(uuid.New(), userID, models.TypeMemberAdded, uuid.New(), "Другой", uuid.New(), uuid.New(), []byte(`{}`), time.Now(), readAt)
+	mock.ExpectQuery(`SELECT .+ FROM todo.notification n JOIN todo.project p`).
+		WithArgs(userID, false, 20, 0).
+		WillReturnRows(rows)
+
+	notifications, err := repo.GetNotifications(ctx, userID, false, 20, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 2)
+	assert.Equal(t, "Проект", notifications[0].ProjectName)
+	assert.Nil(t, notifications[0].ActorID)
+	assert.Nil(t, notifications[0].ReadAt)
+	assert.NotNil(t, notifications[1].ReadAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepository_MarkRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	notificationID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	t.Run("marked", func(t *testing.T) {
+		mock.ExpectExec(`UPDATE todo.notification SET read_at = COALESCE\(read_at, \$3\)`).
+			WithArgs(notificationID, userID, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		assert.NoError(t, repo.MarkRead(ctx, notificationID, userID, now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("someone else's notification", func(t *testing.T) {
+		mock.ExpectExec(`UPDATE todo.notification`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.MarkRead(ctx, notificationID, userID, now)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestNotificationRepository_SetPreferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO todo.notification_preference`).
+		WithArgs(userID, models.TypeTaskCompleted, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.SetPreferences(ctx, userID, map[string]bool{models.TypeTaskCompleted: false})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepository_DeleteRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	before := time.Now().Add(-30 * 24 * time.Hour)
+	mock.ExpectExec(`DELETE FROM todo.notification`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	count, err := repo.DeleteRead(ctx, before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
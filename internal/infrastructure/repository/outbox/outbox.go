@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	models "github.com/lzimin05/course-todo/internal/models/outbox"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	queryInsertMessage = `
+	INSERT INTO todo.outbox (id, event_type, project_id, payload, created_at, next_attempt_at)
+	VALUES ($1, $2, $3, $4, $5, $5)`
+
+	// Ретранслятор забирает созревшие события и сдвигает их на время аренды.
+	// SKIP LOCKED позволяет нескольким экземплярам работать с одной таблицей
+	queryClaimMessages = `
+	UPDATE todo.outbox o
+	SET next_attempt_at = $2
+	WHERE o.id IN (
+		SELECT q.id
+		FROM todo.outbox q
+		WHERE q.published_at IS NULL AND q.next_attempt_at <= $1
+		ORDER BY q.seq
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING o.id, o.seq, o.event_type, o.project_id, o.payload, o.created_at, o.attempts`
+
+	// Номер публикации выдается под блокировкой до конца транзакции: номера
+	// становятся видны читателям строго по возрастанию, и курсор подписчика
+	// не перескакивает через событие, отмеченное параллельно
+	queryMarkPublished = `
+	WITH lock AS (SELECT pg_advisory_xact_lock(hashtext('todo.outbox_published_seq')))
+	UPDATE todo.outbox
+	SET published_at = $2, published_seq = nextval('todo.outbox_published_seq'), attempts = attempts + 1, last_error = NULL
+	FROM lock
+	WHERE id = $1
+	RETURNING published_seq`
+
+	queryMarkFailed = `
+	UPDATE todo.outbox
+	SET attempts = $2, next_attempt_at = $3, last_error = $4
+	WHERE id = $1`
+
+	queryGetPublishedAfter = `
+	SELECT id, seq, published_seq, event_type, project_id, payload, created_at, attempts
+	FROM todo.outbox
+	WHERE published_seq > $1 AND project_id = ANY($2::uuid[])
+	ORDER BY published_seq
+	LIMIT $3`
+
+	queryDeletePublished = `
+	DELETE FROM todo.outbox
+	WHERE published_at IS NOT NULL AND published_at < $1`
+)
+
+// Write сохраняет события в outbox. Вызывается внутри транзакции изменения,
+// поэтому событие появляется тогда и только тогда, когда изменение зафиксировано
+func Write(ctx context.Context, tx *sql.Tx, events ...eventmodels.Event) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event %s: %w", event.Type, err)
+		}
+
+		_, err = tx.ExecContext(ctx, queryInsertMessage,
+			event.ID, event.Type, event.ProjectID, payload, event.OccurredAt)
+		if err != nil {
+			return fmt.Errorf("failed to write event %s to outbox: %w", event.Type, err)
+		}
+	}
+	return nil
+}
+
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// ClaimMessages забирает до limit неопубликованных событий, срок которых наступил к now,
+// в порядке записи. До leaseUntil их не возьмет другой ретранслятор
+func (r *OutboxRepository) ClaimMessages(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Message, error) {
+	const op = "OutboxRepository.ClaimMessages"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	rows, err := r.db.QueryContext(ctx, queryClaimMessages, now, leaseUntil, limit)
+	if err != nil {
+		logger.WithError(err).Error("failed to claim outbox messages")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var messages []models.Message
+	for rows.Next() {
+		var m models.Message
+		err := rows.Scan(&m.ID, &m.Seq, &m.Type, &m.ProjectID, &m.Payload, &m.CreatedAt, &m.Attempts)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan outbox message")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		messages = append(messages, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// RETURNING не сохраняет порядок подзапроса
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Seq < messages[j].Seq })
+
+	return messages, nil
+}
+
+// GetPublishedAfter возвращает до limit опубликованных событий проектов projectIDs
+// с номером публикации больше afterSeq. По ним подписчик догоняет пропущенное
+// после переподключения
+func (r *OutboxRepository) GetPublishedAfter(ctx context.Context, afterSeq int64, projectIDs []uuid.UUID, limit int) ([]models.Message, error) {
+	const op = "OutboxRepository.GetPublishedAfter"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	ids := make([]string, len(projectIDs))
+	for i, id := range projectIDs {
+		ids[i] = id.String()
+	}
+
+	rows, err := r.db.QueryContext(ctx, queryGetPublishedAfter, afterSeq, pq.Array(ids), limit)
+	if err != nil {
+		logger.WithError(err).Error("failed to get published outbox messages")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var messages []models.Message
+	for rows.Next() {
+		var m models.Message
+		err := rows.Scan(&m.ID, &m.Seq, &m.PublishedSeq, &m.Type, &m.ProjectID, &m.Payload, &m.CreatedAt, &m.Attempts)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan outbox message")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		messages = append(messages, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return messages, nil
+}
+
+// MarkPublished отмечает событие опубликованным и возвращает его номер публикации
+func (r *OutboxRepository) MarkPublished(ctx context.Context, messageID uuid.UUID, publishedAt time.Time) (int64, error) {
+	const op = "OutboxRepository.MarkPublished"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("messageID", messageID)
+
+	var publishedSeq int64
+	if err := r.db.QueryRowContext(ctx, queryMarkPublished, messageID, publishedAt).Scan(&publishedSeq); err != nil {
+		logger.WithError(err).Error("failed to mark outbox message published")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return publishedSeq, nil
+}
+
+// MarkFailed откладывает событие до nextAttemptAt после неудачной публикации
+func (r *OutboxRepository) MarkFailed(ctx context.Context, messageID uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string) error {
+	const op = "OutboxRepository.MarkFailed"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("messageID", messageID)
+
+	if _, err := r.db.ExecContext(ctx, queryMarkFailed, messageID, attempts, nextAttemptAt, lastError); err != nil {
+		logger.WithError(err).Error("failed to mark outbox message failed")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeletePublished удаляет события, опубликованные раньше before, и возвращает их число
+func (r *OutboxRepository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	const op = "OutboxRepository.DeletePublished"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	result, err := r.db.ExecContext(ctx, queryDeletePublished, before)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete published outbox messages")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestWrite(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectID := uuid.New()
+	event := eventmodels.New(eventmodels.TypeMemberAdded, projectID, uuid.New(),
+		eventmodels.MemberData{ProjectID: projectID, UserID: uuid.New()})
+	payload, err := json.Marshal(event)
+	assert.NoError(t, err)
+
+	t.Run("written in transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO todo.outbox`).
+			WithArgs(event.ID, event.Type, projectID, payload, event.OccurredAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, err := db.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, Write(ctx, tx, event))
+		assert.NoError(t, tx.Commit())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO todo.outbox`).WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		tx, err := db.BeginTx(ctx, nil)
+		assert.NoError(t, err)
+		assert.Error(t, Write(ctx, tx, event))
+		assert.NoError(t, tx.Rollback())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOutboxRepository_ClaimMessages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Now()
+	lease := now.Add(time.Minute)
+	first := uuid.New()
+	second := uuid.New()
+
+	rows := sqlmock.NewRows([]string{"id", "seq", "event_type", "project_id", "payload", "created_at", "attempts"}).
+		AddRow(second, int64(8), "task.updated", uuid.New(), []byte(`{}`), now, 0).
+		AddRow(first, int64(7), "task.created", uuid.New(), []byte(`{}`), now, 2)
+	mock.ExpectQuery(`UPDATE todo.outbox o .+ FOR UPDATE SKIP LOCKED`).
+		WithArgs(now, lease, 50).
+		WillReturnRows(rows)
+
+	messages, err := repo.ClaimMessages(ctx, now, lease, 50)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, first, messages[0].ID)
+	assert.Equal(t, 2, messages[0].Attempts)
+	assert.Equal(t, second, messages[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_GetPublishedAfter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	rows := sqlmock.NewRows([]string{"id", "seq", "published_seq", "event_type", "project_id", "payload", "created_at", "attempts"}).
+		AddRow(uuid.New(), int64(40), int64(43), "note.created", projectID, []byte(`{}`), time.Now(), 1)
+	mock.ExpectQuery(`SELECT .+ FROM todo.outbox WHERE published_seq > \$1 .+ ORDER BY published_seq`).
+		WithArgs(int64(42), sqlmock.AnyArg(), 100).
+		WillReturnRows(rows)
+
+	messages, err := repo.GetPublishedAfter(ctx, 42, []uuid.UUID{projectID}, 100)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, int64(43), messages[0].PublishedSeq)
+	assert.Equal(t, projectID, messages[0].ProjectID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_MarkPublished(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	messageID := uuid.New()
+	now := time.Now()
+	mock.ExpectQuery(`pg_advisory_xact_lock.+UPDATE todo.outbox SET published_at = \$2, published_seq = nextval`).
+		WithArgs(messageID, now).
+		WillReturnRows(sqlmock.NewRows([]string{"published_seq"}).AddRow(int64(44)))
+
+	publishedSeq, err := repo.MarkPublished(ctx, messageID, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(44), publishedSeq)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_MarkFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	messageID := uuid.New()
+	next := time.Now().Add(time.Minute)
+
+	mock.ExpectExec(`UPDATE todo.outbox`).
+		WithArgs(messageID, 3, next, "redis: connection refused").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.MarkFailed(ctx, messageID, 3, next, "redis: connection refused"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepository_DeletePublished(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	before := time.Now().Add(-24 * time.Hour)
+	mock.ExpectExec(`DELETE FROM todo.outbox`).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 12))
+
+	count, err := repo.DeletePublished(ctx, before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	outbox "github.com/lzimin05/course-todo/internal/infrastructure/repository/outbox"
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
 	models "github.com/lzimin05/course-todo/internal/models/project"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
 )
@@ -15,15 +19,15 @@ const (
 	queryCreateProject = `
 		INSERT INTO todo.project (name, description, owner_id)
 		VALUES ($1, $2, $3)
-		RETURNING id, created_at;`
+		RETURNING id, created_at, version;`
 
 	queryGetProjectByID = `
-		SELECT p.id, p.name, p.description, p.owner_id, p.created_at
+		SELECT p.id, p.name, p.description, p.owner_id, p.created_at, p.version
 		FROM todo.project p
 		WHERE p.id = $1;`
 
 	queryGetUserProjects = `
-		SELECT p.id, p.name, p.description, p.owner_id, p.created_at
+		SELECT p.id, p.name, p.description, p.owner_id, p.created_at, p.version
 		FROM todo.project p
 		JOIN todo.project_member pm ON p.id = pm.project_id
 		WHERE pm.user_id = $1;`
@@ -46,7 +50,10 @@ const (
 
 	queryDeleteProject = `
 		DELETE FROM todo.project
-		WHERE id = $1 AND owner_id = $2;`
+		WHERE id = $1 AND owner_id = $2 AND ($3::int[] IS NULL OR version = ANY($3));`
+
+	queryOwnedProjectExists = `
+		SELECT EXISTS(SELECT 1 FROM todo.project WHERE id = $1 AND owner_id = $2);`
 
 	queryRemoveProjectMember = `
 		DELETE FROM todo.project_member
@@ -54,8 +61,65 @@ const (
 
 	queryUpdateProject = `
 		UPDATE todo.project 
-		SET name = $2, description = $3
-		WHERE id = $1 AND owner_id = $4;`
+		SET name = $2, description = $3, version = version + 1
+		WHERE id = $1 AND owner_id = $4 AND ($5::int[] IS NULL OR version = ANY($5));`
+
+	queryStatsByStatus = `
+		SELECT t.status, COUNT(*)
+		FROM todo.task t
+		WHERE t.project_id = $1
+		GROUP BY t.status;`
+
+	queryStatsByImportance = `
+		SELECT t.importance, COUNT(*)
+		FROM todo.task t
+		WHERE t.project_id = $1
+		GROUP BY t.importance;`
+
+	queryStatsSummary = `
+		SELECT
+			COUNT(*) FILTER (
+				WHERE t.status <> 'completed'
+				AND t.deadline > '0001-01-01'::timestamp
+				AND t.deadline < CURRENT_TIMESTAMP
+			),
+			COALESCE(EXTRACT(EPOCH FROM AVG(t.completed_at - t.created_at)
+				FILTER (WHERE t.completed_at IS NOT NULL)), 0)
+		FROM todo.task t
+		WHERE t.project_id = $1;`
+
+	queryStatsCompletedPerDay = `
+		SELECT d::date, COUNT(t.id)
+		FROM generate_series(
+			date_trunc('day', CURRENT_TIMESTAMP) - INTERVAL '29 days',
+			date_trunc('day', CURRENT_TIMESTAMP),
+			INTERVAL '1 day'
+		) d
+		LEFT JOIN todo.task t ON t.project_id = $1 AND date_trunc('day', t.completed_at) = d
+		GROUP BY d
+		ORDER BY d;`
+
+	queryStatsCompletedPerWeek = `
+		SELECT d::date, COUNT(t.id)
+		FROM generate_series(
+			date_trunc('week', CURRENT_TIMESTAMP) - INTERVAL '11 weeks',
+			date_trunc('week', CURRENT_TIMESTAMP),
+			INTERVAL '1 week'
+		) d
+		LEFT JOIN todo.task t ON t.project_id = $1 AND date_trunc('week', t.completed_at) = d
+		GROUP BY d
+		ORDER BY d;`
+
+	queryStatsByMember = `
+		SELECT pm.user_id, u.username,
+			COUNT(t.id) FILTER (WHERE t.status <> 'completed'),
+			COUNT(t.id) FILTER (WHERE t.status = 'completed')
+		FROM todo.project_member pm
+		JOIN todo."user" u ON u.id = pm.user_id
+		LEFT JOIN todo.task t ON t.project_id = pm.project_id AND t.user_id = pm.user_id
+		WHERE pm.project_id = $1
+		GROUP BY pm.user_id, u.username
+		ORDER BY u.username;`
 )
 
 type ProjectRepository struct {
@@ -80,7 +144,7 @@ func (r *ProjectRepository) CreateProject(ctx context.Context, project *models.P
 	// Создаем проект
 	err = tx.QueryRowContext(ctx, queryCreateProject,
 		project.Name, project.Description, project.OwnerID).Scan(
-		&project.ID, &project.CreatedAt)
+		&project.ID, &project.CreatedAt, &project.Version)
 	if err != nil {
 		logger.WithError(err).Error("failed to create project")
 		return err
@@ -111,6 +175,7 @@ func (r *ProjectRepository) GetProjectByID(ctx context.Context, id uuid.UUID) (*
 		&project.Description,
 		&project.OwnerID,
 		&project.CreatedAt,
+		&project.Version,
 	)
 	if err != nil {
 		logger.WithError(err).Warn("failed to get project by id")
@@ -143,6 +208,7 @@ func (r *ProjectRepository) GetUserProjects(ctx context.Context, userID uuid.UUI
 			&project.Description,
 			&project.OwnerID,
 			&project.CreatedAt,
+			&project.Version,
 		)
 		if err != nil {
 			logger.WithError(err).Error("failed to scan project")
@@ -154,19 +220,37 @@ func (r *ProjectRepository) GetUserProjects(ctx context.Context, userID uuid.UUI
 	return projects, nil
 }
 
-func (r *ProjectRepository) AddProjectMember(ctx context.Context, projectID, userID uuid.UUID) error {
+// AddProjectMember добавляет участника, actorID - пользователь, который его пригласил
+func (r *ProjectRepository) AddProjectMember(ctx context.Context, projectID, userID, actorID uuid.UUID) error {
 	const op = "ProjectRepository.AddProjectMember"
 	logger := logctx.GetLogger(ctx).WithField("op", op)
 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+
 	var memberID uuid.UUID
 	var joinedAt interface{}
-	err := r.db.QueryRowContext(ctx, queryAddProjectMember,
+	err = tx.QueryRowContext(ctx, queryAddProjectMember,
 		projectID, userID, models.RoleMember).Scan(&memberID, &joinedAt)
 	if err != nil {
 		logger.WithError(err).Error("failed to add project member")
 		return err
 	}
 
+	if err := outbox.Write(ctx, tx, memberEvent(eventmodels.TypeMemberAdded, projectID, userID, actorID)); err != nil {
+		logger.WithError(err).Error("failed to write member event")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return err
+	}
+
 	return nil
 }
 
@@ -217,11 +301,11 @@ func (r *ProjectRepository) CheckProjectAccess(ctx context.Context, projectID, u
 	return count > 0, nil
 }
 
-func (r *ProjectRepository) DeleteProject(ctx context.Context, projectID, ownerID uuid.UUID) error {
+func (r *ProjectRepository) DeleteProject(ctx context.Context, projectID, ownerID uuid.UUID, expectedVersions []int) error {
 	const op = "ProjectRepository.DeleteProject"
 	logger := logctx.GetLogger(ctx).WithField("op", op)
 
-	result, err := r.db.ExecContext(ctx, queryDeleteProject, projectID, ownerID)
+	result, err := r.db.ExecContext(ctx, queryDeleteProject, projectID, ownerID, pq.Array(expectedVersions))
 	if err != nil {
 		logger.WithError(err).Error("failed to delete project")
 		return err
@@ -234,17 +318,25 @@ func (r *ProjectRepository) DeleteProject(ctx context.Context, projectID, ownerI
 	}
 
 	if rowsAffected == 0 {
-		return errs.ErrNotFound
+		return r.notChangedReason(ctx, projectID, ownerID, expectedVersions)
 	}
 
 	return nil
 }
 
-func (r *ProjectRepository) RemoveProjectMember(ctx context.Context, projectID, userID uuid.UUID) error {
+// RemoveProjectMember исключает участника. При выходе из проекта actorID совпадает с userID
+func (r *ProjectRepository) RemoveProjectMember(ctx context.Context, projectID, userID, actorID uuid.UUID) error {
 	const op = "ProjectRepository.RemoveProjectMember"
 	logger := logctx.GetLogger(ctx).WithField("op", op)
 
-	result, err := r.db.ExecContext(ctx, queryRemoveProjectMember, projectID, userID)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Error("failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, queryRemoveProjectMember, projectID, userID)
 	if err != nil {
 		logger.WithError(err).Error("failed to remove project member")
 		return err
@@ -260,14 +352,24 @@ func (r *ProjectRepository) RemoveProjectMember(ctx context.Context, projectID,
 		return errs.ErrNotFound
 	}
 
+	if err := outbox.Write(ctx, tx, memberEvent(eventmodels.TypeMemberRemoved, projectID, userID, actorID)); err != nil {
+		logger.WithError(err).Error("failed to write member event")
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Error("failed to commit transaction")
+		return err
+	}
+
 	return nil
 }
 
-func (r *ProjectRepository) UpdateProject(ctx context.Context, projectID uuid.UUID, name, description string, ownerID uuid.UUID) error {
+func (r *ProjectRepository) UpdateProject(ctx context.Context, projectID uuid.UUID, name, description string, ownerID uuid.UUID, expectedVersions []int) error {
 	const op = "ProjectRepository.UpdateProject"
 	logger := logctx.GetLogger(ctx).WithField("op", op)
 
-	result, err := r.db.ExecContext(ctx, queryUpdateProject, projectID, name, description, ownerID)
+	result, err := r.db.ExecContext(ctx, queryUpdateProject, projectID, name, description, ownerID, pq.Array(expectedVersions))
 	if err != nil {
 		logger.WithError(err).Error("failed to update project")
 		return err
@@ -280,8 +382,165 @@ func (r *ProjectRepository) UpdateProject(ctx context.Context, projectID uuid.UU
 	}
 
 	if rowsAffected == 0 {
-		return errs.ErrNotFound
+		return r.notChangedReason(ctx, projectID, ownerID, expectedVersions)
 	}
 
 	return nil
 }
+
+// notChangedReason определяет, почему условное изменение не затронуло проект:
+// его нет у владельца или версия уже изменилась
+func (r *ProjectRepository) notChangedReason(ctx context.Context, projectID, ownerID uuid.UUID, expectedVersions []int) error {
+	const op = "ProjectRepository.notChangedReason"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	if expectedVersions == nil {
+		return errs.ErrNotFound
+	}
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, queryOwnedProjectExists, projectID, ownerID).Scan(&exists); err != nil {
+		logger.WithError(err).Error("failed to check project existence")
+		return err
+	}
+	if !exists {
+		return errs.ErrNotFound
+	}
+
+	logger.Warn("project version mismatch")
+	return errs.ErrVersionMismatch
+}
+
+func (r *ProjectRepository) GetProjectStats(ctx context.Context, projectID uuid.UUID) (*models.ProjectStats, error) {
+	const op = "ProjectRepository.GetProjectStats"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	stats := &models.ProjectStats{ProjectID: projectID}
+
+	var err error
+
+	// Количество задач по статусам
+	stats.ByStatus, err = r.getStatusCounts(ctx, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get stats by status")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Количество задач по важности
+	stats.ByImportance, err = r.getImportanceCounts(ctx, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get stats by importance")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Просроченные задачи и среднее время выполнения
+	err = r.db.QueryRowContext(ctx, queryStatsSummary, projectID).Scan(&stats.Overdue, &stats.AvgCycleTimeSeconds)
+	if err != nil {
+		logger.WithError(err).Error("failed to get stats summary")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stats.CompletedPerDay, err = r.getDateCounts(ctx, queryStatsCompletedPerDay, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get completed per day")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	stats.CompletedPerWeek, err = r.getDateCounts(ctx, queryStatsCompletedPerWeek, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get completed per week")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Открытые и завершенные задачи участников
+	stats.Members, err = r.getMemberStats(ctx, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get stats by member")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stats, nil
+}
+
+func (r *ProjectRepository) getStatusCounts(ctx context.Context, projectID uuid.UUID) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, queryStatsByStatus, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+
+	return counts, rows.Err()
+}
+
+func (r *ProjectRepository) getImportanceCounts(ctx context.Context, projectID uuid.UUID) (map[int]int, error) {
+	rows, err := r.db.QueryContext(ctx, queryStatsByImportance, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var importance, count int
+		if err := rows.Scan(&importance, &count); err != nil {
+			return nil, err
+		}
+		counts[importance] = count
+	}
+
+	return counts, rows.Err()
+}
+
+func (r *ProjectRepository) getMemberStats(ctx context.Context, projectID uuid.UUID) ([]models.MemberStats, error) {
+	rows, err := r.db.QueryContext(ctx, queryStatsByMember, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []models.MemberStats
+	for rows.Next() {
+		var member models.MemberStats
+		if err := rows.Scan(&member.UserID, &member.Username, &member.Open, &member.Completed); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+func (r *ProjectRepository) getDateCounts(ctx context.Context, query string, projectID uuid.UUID) ([]models.DateCount, error) {
+	rows, err := r.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []models.DateCount
+	for rows.Next() {
+		var dc models.DateCount
+		if err := rows.Scan(&dc.Date, &dc.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, dc)
+	}
+
+	return counts, rows.Err()
+}
+
+// memberEvent собирает событие об изменении состава проекта для outbox
+func memberEvent(eventType string, projectID, memberID, actorID uuid.UUID) eventmodels.Event {
+	data := eventmodels.MemberData{ProjectID: projectID, UserID: memberID}
+	return eventmodels.New(eventType, projectID, actorID, data)
+}
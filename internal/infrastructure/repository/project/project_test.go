@@ -9,6 +9,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
@@ -49,8 +50,8 @@ func TestProjectRepository_CreateProject(t *testing.T) {
 				mock.ExpectBegin()
 
 				// Mock project creation
-				rows := sqlmock.NewRows([]string{"id", "created_at"}).
-					AddRow(projectID, createdAt)
+				rows := sqlmock.NewRows([]string{"id", "created_at", "version"}).
+					AddRow(projectID, createdAt, 1)
 				mock.ExpectQuery(`INSERT INTO todo.project`).
 					WithArgs("Test Project", "Test Description", ownerID).
 					WillReturnRows(rows)
@@ -130,8 +131,8 @@ func TestProjectRepository_GetProjectByID(t *testing.T) {
 			name:      "successful project retrieval",
 			projectID: projectID,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "owner_id", "created_at"}).
-					AddRow(projectID, "Test Project", "Test Description", ownerID, createdAt)
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "owner_id", "created_at", "version"}).
+					AddRow(projectID, "Test Project", "Test Description", ownerID, createdAt, 1)
 
 				mock.ExpectQuery(`SELECT p.id, p.name, p.description, p.owner_id, p.created_at`).
 					WithArgs(projectID).
@@ -212,9 +213,9 @@ func TestProjectRepository_GetUserProjects(t *testing.T) {
 			name:   "successful user projects retrieval",
 			userID: userID,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "owner_id", "created_at"}).
-					AddRow(projectID, "User Project 1", "Description 1", ownerID, createdAt).
-					AddRow(uuid.New(), "User Project 2", "Description 2", ownerID, createdAt)
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "owner_id", "created_at", "version"}).
+					AddRow(projectID, "User Project 1", "Description 1", ownerID, createdAt, 1).
+					AddRow(uuid.New(), "User Project 2", "Description 2", ownerID, createdAt, 1)
 
 				mock.ExpectQuery(`SELECT p.id, p.name, p.description, p.owner_id, p.created_at`).
 					WithArgs(userID).
@@ -227,7 +228,7 @@ func TestProjectRepository_GetUserProjects(t *testing.T) {
 			name:   "no projects found",
 			userID: userID,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "name", "description", "owner_id", "created_at"})
+				rows := sqlmock.NewRows([]string{"id", "name", "description", "owner_id", "created_at", "version"})
 
 				mock.ExpectQuery(`SELECT p.id, p.name, p.description, p.owner_id, p.created_at`).
 					WithArgs(userID).
@@ -356,13 +357,15 @@ func TestProjectRepository_DeleteProject(t *testing.T) {
 
 	projectID := uuid.New()
 	ownerID := uuid.New()
+	staleVersion := 1
 
 	tests := []struct {
-		name        string
-		projectID   uuid.UUID
-		ownerID     uuid.UUID
-		setupMocks  func()
-		expectedErr error
+		name             string
+		projectID        uuid.UUID
+		ownerID          uuid.UUID
+		expectedVersions []int
+		setupMocks       func()
+		expectedErr      error
 	}{
 		{
 			name:      "successful project deletion",
@@ -370,7 +373,7 @@ func TestProjectRepository_DeleteProject(t *testing.T) {
 			ownerID:   ownerID,
 			setupMocks: func() {
 				mock.ExpectExec(`DELETE FROM todo.project`).
-					WithArgs(projectID, ownerID).
+					WithArgs(projectID, ownerID, nil).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			expectedErr: nil,
@@ -381,18 +384,33 @@ func TestProjectRepository_DeleteProject(t *testing.T) {
 			ownerID:   ownerID,
 			setupMocks: func() {
 				mock.ExpectExec(`DELETE FROM todo.project`).
-					WithArgs(projectID, ownerID).
+					WithArgs(projectID, ownerID, nil).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			expectedErr: errs.ErrNotFound,
 		},
+		{
+			name:             "version mismatch",
+			projectID:        projectID,
+			ownerID:          ownerID,
+			expectedVersions: []int{staleVersion},
+			setupMocks: func() {
+				mock.ExpectExec(`DELETE FROM todo.project`).
+					WithArgs(projectID, ownerID, pq.Array([]int{staleVersion})).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`SELECT EXISTS`).
+					WithArgs(projectID, ownerID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			expectedErr: errs.ErrVersionMismatch,
+		},
 		{
 			name:      "database error",
 			projectID: projectID,
 			ownerID:   ownerID,
 			setupMocks: func() {
 				mock.ExpectExec(`DELETE FROM todo.project`).
-					WithArgs(projectID, ownerID).
+					WithArgs(projectID, ownerID, nil).
 					WillReturnError(errors.New("database connection error"))
 			},
 			expectedErr: errors.New("database connection error"),
@@ -403,12 +421,12 @@ func TestProjectRepository_DeleteProject(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			err := repo.DeleteProject(ctx, tt.projectID, tt.ownerID)
+			err := repo.DeleteProject(ctx, tt.projectID, tt.ownerID, tt.expectedVersions)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
-				if tt.expectedErr == errs.ErrNotFound {
-					assert.Equal(t, errs.ErrNotFound, err)
+				if tt.expectedErr == errs.ErrNotFound || tt.expectedErr == errs.ErrVersionMismatch {
+					assert.Equal(t, tt.expectedErr, err)
 				}
 			} else {
 				assert.NoError(t, err)
@@ -419,6 +437,144 @@ func TestProjectRepository_DeleteProject(t *testing.T) {
 	}
 }
 
+func TestProjectRepository_RemoveProjectMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	memberID := uuid.New()
+	ownerID := uuid.New()
+
+	t.Run("member removed with event", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM todo.project_member`).
+			WithArgs(projectID, memberID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO todo.outbox`).
+			WithArgs(sqlmock.AnyArg(), "member.removed", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.RemoveProjectMember(ctx, projectID, memberID, ownerID)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM todo.project_member`).
+			WithArgs(projectID, memberID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.RemoveProjectMember(ctx, projectID, memberID, ownerID)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestProjectRepository_GetProjectStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	userID := uuid.New()
+	day := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr bool
+	}{
+		{
+			name: "successful stats retrieval",
+			setupMocks: func() {
+				mock.ExpectQuery(`SELECT t.status, COUNT\(\*\)`).
+					WithArgs(projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
+						AddRow("waiting", 2).
+						AddRow("completed", 3))
+				mock.ExpectQuery(`SELECT t.importance, COUNT\(\*\)`).
+					WithArgs(projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"importance", "count"}).
+						AddRow(1, 4).
+						AddRow(3, 1))
+				mock.ExpectQuery(`COUNT\(\*\) FILTER`).
+					WithArgs(projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"overdue", "avg"}).AddRow(1, 3600.5))
+				mock.ExpectQuery(`date_trunc\('day'`).
+					WithArgs(projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"d", "count"}).AddRow(day, 2))
+				mock.ExpectQuery(`date_trunc\('week'`).
+					WithArgs(projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"d", "count"}).AddRow(day, 3))
+				mock.ExpectQuery(`FROM todo.project_member pm`).
+					WithArgs(projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id", "username", "open", "completed"}).
+						AddRow(userID, "user", 2, 3))
+			},
+			expectedErr: false,
+		},
+		{
+			name: "database error",
+			setupMocks: func() {
+				mock.ExpectQuery(`SELECT t.status, COUNT\(\*\)`).
+					WithArgs(projectID).
+					WillReturnError(errors.New("database connection error"))
+			},
+			expectedErr: true,
+		},
+		{
+			name: "rows interrupted",
+			setupMocks: func() {
+				mock.ExpectQuery(`SELECT t.status, COUNT\(\*\)`).
+					WithArgs(projectID).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).
+						AddRow("waiting", 2).
+						AddRow("completed", 3).
+						RowError(1, errors.New("connection reset")))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			stats, err := repo.GetProjectStats(ctx, projectID)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, stats)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, stats.ByStatus["waiting"])
+				assert.Equal(t, 3, stats.ByStatus["completed"])
+				assert.Equal(t, 4, stats.ByImportance[1])
+				assert.Equal(t, 1, stats.Overdue)
+				assert.Equal(t, 3600.5, stats.AvgCycleTimeSeconds)
+				assert.Len(t, stats.CompletedPerDay, 1)
+				assert.Len(t, stats.CompletedPerWeek, 1)
+				assert.Len(t, stats.Members, 1)
+				assert.Equal(t, 3, stats.Members[0].Completed)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestNew_ProjectRepository(t *testing.T) {
 	db, _, err := sqlmock.New()
 	assert.NoError(t, err)
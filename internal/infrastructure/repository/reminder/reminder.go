@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	notificationmodels "github.com/lzimin05/course-todo/internal/models/notification"
+	models "github.com/lzimin05/course-todo/internal/models/reminder"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+// Напоминания касаются незавершенных задач со сроком, автор которых еще состоит
+// в проекте. Срок задачи на весь день - конец указанного дня. Условие на сам
+// deadline повторяет условие на фактический срок, чтобы работал индекс
+const (
+	queryGetUpcomingTasks = `
+	SELECT t.id, t.project_id, p.name, t.user_id, t.title, d.deadline, t.all_day
+	FROM todo.task t
+	JOIN todo.project p ON p.id = t.project_id
+	JOIN todo.project_member pm ON pm.project_id = t.project_id AND pm.user_id = t.user_id
+	CROSS JOIN LATERAL (
+		SELECT CASE WHEN t.all_day THEN t.deadline + INTERVAL '1 day' ELSE t.deadline END AS deadline
+	) d
+	WHERE t.status <> 'completed' AND t.deadline > '0001-01-01'::timestamp
+		AND t.deadline > $1::timestamp - INTERVAL '1 day' AND t.deadline <= $2
+		AND d.deadline > $1 AND d.deadline <= $2
+	ORDER BY d.deadline, t.id`
+
+	queryGetOverdueTasks = `
+	SELECT t.id, t.project_id, p.name, t.user_id, t.title, d.deadline, t.all_day
+	FROM todo.task t
+	JOIN todo.project p ON p.id = t.project_id
+	JOIN todo.project_member pm ON pm.project_id = t.project_id AND pm.user_id = t.user_id
+	CROSS JOIN LATERAL (
+		SELECT CASE WHEN t.all_day THEN t.deadline + INTERVAL '1 day' ELSE t.deadline END AS deadline
+	) d
+	WHERE t.overdue_at IS NULL AND t.status <> 'completed'
+		AND t.deadline > '0001-01-01'::timestamp AND t.deadline <= $1
+		AND d.deadline <= $1
+	ORDER BY d.deadline, t.id
+	LIMIT $2`
+
+	queryMarkOverdue = `
+	UPDATE todo.task SET overdue_at = $2
+	WHERE id = ANY($1::uuid[]) AND overdue_at IS NULL`
+
+	// Сводка отправляется, когда у пользователя наступил DigestHour, и не чаще
+	// раза в его локальные сутки. Пользователи без задач в сводке пропускаются
+	queryGetDigestRecipients = `
+	SELECT u.id, u.email, u.username, u.timezone, l.local_now::date
+	FROM todo."user" u
+	CROSS JOIN LATERAL (SELECT $1::timestamptz AT TIME ZONE u.timezone AS local_now) l
+	LEFT JOIN todo.user_digest ud ON ud.user_id = u.id
+	WHERE u.email <> ''
+		AND EXTRACT(HOUR FROM l.local_now) >= $2
+		AND (ud.last_sent_on IS NULL OR ud.last_sent_on < l.local_now::date)
+		AND NOT EXISTS (
+			SELECT 1 FROM todo.notification_preference np
+			WHERE np.user_id = u.id AND np.type = $4 AND NOT np.enabled
+		)
+		AND EXISTS (
+			SELECT 1 FROM todo.task t
+			JOIN todo.project_member pm ON pm.project_id = t.project_id AND pm.user_id = t.user_id
+			WHERE t.user_id = u.id AND t.status <> 'completed'
+				AND t.deadline > '0001-01-01'::timestamp AND t.deadline <= $3
+		)
+	ORDER BY u.id
+	LIMIT $5`
+
+	queryGetDigestTasks = `
+	SELECT t.id, t.project_id, p.name, t.user_id, t.title, d.deadline, t.all_day
+	FROM todo.task t
+	JOIN todo.project p ON p.id = t.project_id
+	JOIN todo.project_member pm ON pm.project_id = t.project_id AND pm.user_id = t.user_id
+	CROSS JOIN LATERAL (
+		SELECT CASE WHEN t.all_day THEN t.deadline + INTERVAL '1 day' ELSE t.deadline END AS deadline
+	) d
+	WHERE t.user_id = $1 AND t.status <> 'completed'
+		AND t.deadline > '0001-01-01'::timestamp AND t.deadline <= $2
+		AND d.deadline <= $2
+	ORDER BY d.deadline, t.id`
+
+	queryMarkDigestSent = `
+	INSERT INTO todo.user_digest (user_id, last_sent_on)
+	VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET last_sent_on = EXCLUDED.last_sent_on`
+)
+
+type ReminderRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+// GetUpcomingTasks возвращает задачи, срок которых наступает в интервале (from, until]
+func (r *ReminderRepository) GetUpcomingTasks(ctx context.Context, from, until time.Time) ([]models.Task, error) {
+	const op = "ReminderRepository.GetUpcomingTasks"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	tasks, err := r.queryTasks(ctx, queryGetUpcomingTasks, from, until)
+	if err != nil {
+		logger.WithError(err).Error("failed to get upcoming tasks")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return tasks, nil
+}
+
+// GetOverdueTasks возвращает просроченные задачи, еще не отмеченные просроченными
+func (r *ReminderRepository) GetOverdueTasks(ctx context.Context, now time.Time, limit int) ([]models.Task, error) {
+	const op = "ReminderRepository.GetOverdueTasks"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	tasks, err := r.queryTasks(ctx, queryGetOverdueTasks, now, limit)
+	if err != nil {
+		logger.WithError(err).Error("failed to get overdue tasks")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return tasks, nil
+}
+
+// MarkOverdue отмечает задачи просроченными
+func (r *ReminderRepository) MarkOverdue(ctx context.Context, taskIDs []uuid.UUID, now time.Time) error {
+	const op = "ReminderRepository.MarkOverdue"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	if _, err := r.db.ExecContext(ctx, queryMarkOverdue, pq.Array(taskIDs), now); err != nil {
+		logger.WithError(err).Error("failed to mark tasks overdue")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// GetDigestRecipients возвращает пользователей, которым пора отправить сводку
+// со сроками до until
+func (r *ReminderRepository) GetDigestRecipients(ctx context.Context, now time.Time, hour int, until time.Time, limit int) ([]models.Recipient, error) {
+	const op = "ReminderRepository.GetDigestRecipients"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	rows, err := r.db.QueryContext(ctx, queryGetDigestRecipients, now, hour, until, notificationmodels.TypeDailyDigest, limit)
+	if err != nil {
+		logger.WithError(err).Error("failed to get digest recipients")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var recipients []models.Recipient
+	for rows.Next() {
+		var rc models.Recipient
+		if err := rows.Scan(&rc.UserID, &rc.Email, &rc.Username, &rc.Timezone, &rc.LocalDate); err != nil {
+			logger.WithError(err).Error("failed to scan digest recipient")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		recipients = append(recipients, rc)
+	}
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return recipients, nil
+}
+
+// GetDigestTasks возвращает незавершенные задачи пользователя со сроком до until,
+// включая просроченные
+func (r *ReminderRepository) GetDigestTasks(ctx context.Context, userID uuid.UUID, until time.Time) ([]models.Task, error) {
+	const op = "ReminderRepository.GetDigestTasks"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	tasks, err := r.queryTasks(ctx, queryGetDigestTasks, userID, until)
+	if err != nil {
+		logger.WithError(err).Error("failed to get digest tasks")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return tasks, nil
+}
+
+// MarkDigestSent запоминает локальную дату отправленной сводки
+func (r *ReminderRepository) MarkDigestSent(ctx context.Context, userID uuid.UUID, localDate time.Time) error {
+	const op = "ReminderRepository.MarkDigestSent"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	if _, err := r.db.ExecContext(ctx, queryMarkDigestSent, userID, localDate); err != nil {
+		logger.WithError(err).Error("failed to mark digest sent")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *ReminderRepository) queryTasks(ctx context.Context, query string, args ...any) ([]models.Task, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []models.Task
+	for rows.Next() {
+		var t models.Task
+		if err := rows.Scan(&t.ID, &t.ProjectID, &t.ProjectName, &t.AuthorID, &t.Title, &t.Deadline, &t.AllDay); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	notificationmodels "github.com/lzimin05/course-todo/internal/models/notification"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestReminderRepository_GetUpcomingTasks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Now().UTC()
+	until := now.Add(24 * time.Hour)
+	rows := sqlmock.NewRows([]string{"id", "project_id", "name", "user_id", "title", "deadline", "all_day"}).
+		AddRow(uuid.New(), uuid.New(), "Проект", uuid.New(), "Отчет", now.Add(time.Hour), false).
+		AddRow(uuid.New(), uuid.New(), "Проект", uuid.New(), "Отпуск", now.Add(10*time.Hour), true)
+	mock.ExpectQuery(`SELECT .+ FROM todo.task t .+ JOIN todo.project_member pm .+ WHERE t.status <> 'completed'`).
+		WithArgs(now, until).
+		WillReturnRows(rows)
+
+	tasks, err := repo.GetUpcomingTasks(ctx, now, until)
+
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	assert.Equal(t, "Проект", tasks[0].ProjectName)
+	assert.True(t, tasks[1].AllDay)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderRepository_MarkOverdue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Now().UTC()
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	mock.ExpectExec(`UPDATE todo.task SET overdue_at = \$2\s+WHERE id = ANY\(\$1::uuid\[\]\) AND overdue_at IS NULL`).
+		WithArgs(pq.Array(ids), now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert.NoError(t, repo.MarkOverdue(ctx, ids, now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderRepository_GetDigestRecipients(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Now().UTC()
+	until := now.Add(24 * time.Hour)
+	userID := uuid.New()
+	localDate := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "email", "username", "timezone", "local_now"}).
+		AddRow(userID, "anna@example.com", "anna", "Europe/Moscow", localDate)
+	mock.ExpectQuery(`SELECT .+ FROM todo."user" u .+ LEFT JOIN todo.user_digest ud`).
+		WithArgs(now, 8, until, notificationmodels.TypeDailyDigest, 100).
+		WillReturnRows(rows)
+
+	recipients, err := repo.GetDigestRecipients(ctx, now, 8, until, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, recipients, 1)
+	assert.Equal(t, userID, recipients[0].UserID)
+	assert.Equal(t, "Europe/Moscow", recipients[0].Timezone)
+	assert.Equal(t, localDate, recipients[0].LocalDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderRepository_MarkDigestSent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	localDate := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`INSERT INTO todo.user_digest .+ ON CONFLICT \(user_id\) DO UPDATE`).
+		WithArgs(userID, localDate).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.MarkDigestSent(ctx, userID, localDate))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
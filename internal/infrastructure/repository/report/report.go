@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/report"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	// Для каждого интервала берем последний статус каждой задачи на конец интервала
+	getCumulativeFlowQuery = `
+		SELECT b.bucket,
+			COUNT(*) FILTER (WHERE s.to_status = 'waiting'),
+			COUNT(*) FILTER (WHERE s.to_status = 'in_progress'),
+			COUNT(*) FILTER (WHERE s.to_status = 'completed')
+		FROM generate_series($2::timestamp, $3::timestamp, $4::interval) AS b(bucket)
+		LEFT JOIN LATERAL (
+			SELECT DISTINCT ON (h.task_id) h.to_status
+			FROM todo.task_status_history h
+			WHERE h.project_id = $1 AND h.changed_at < b.bucket + $4::interval
+			ORDER BY h.task_id, h.changed_at DESC
+		) s ON true
+		GROUP BY b.bucket
+		ORDER BY b.bucket;`
+)
+
+var bucketIntervals = map[string]string{
+	models.BucketDay:  "1 day",
+	models.BucketWeek: "1 week",
+}
+
+type ReportRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+func (r *ReportRepository) GetCumulativeFlow(ctx context.Context, projectID uuid.UUID, from, to time.Time, bucket string) ([]models.CFDPoint, error) {
+	const op = "ReportRepository.GetCumulativeFlow"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", projectID).
+		WithField("bucket", bucket)
+
+	interval, ok := bucketIntervals[bucket]
+	if !ok {
+		logger.Warn("unknown bucket")
+		return nil, fmt.Errorf("%s: unknown bucket %q", op, bucket)
+	}
+
+	rows, err := r.db.QueryContext(ctx, getCumulativeFlowQuery, projectID, from, to, interval)
+	if err != nil {
+		logger.WithError(err).Error("failed to get cumulative flow")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var points []models.CFDPoint
+	for rows.Next() {
+		var p models.CFDPoint
+		if err := rows.Scan(&p.Bucket, &p.Waiting, &p.InProgress, &p.Completed); err != nil {
+			logger.WithError(err).Error("failed to scan cumulative flow point")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		points = append(points, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return points, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/report"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestReportRepository_GetCumulativeFlow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		bucket       string
+		setupMocks   func()
+		expectedErr  bool
+		expectPoints int
+	}{
+		{
+			name:   "successful daily flow",
+			bucket: models.BucketDay,
+			setupMocks: func() {
+				rows := sqlmock.NewRows([]string{"bucket", "waiting", "in_progress", "completed"}).
+					AddRow(from, 3, 1, 0).
+					AddRow(to, 2, 1, 1)
+				mock.ExpectQuery(`FROM generate_series`).
+					WithArgs(projectID, from, to, "1 day").
+					WillReturnRows(rows)
+			},
+			expectedErr:  false,
+			expectPoints: 2,
+		},
+		{
+			name:   "weekly interval",
+			bucket: models.BucketWeek,
+			setupMocks: func() {
+				rows := sqlmock.NewRows([]string{"bucket", "waiting", "in_progress", "completed"}).
+					AddRow(from, 1, 0, 0)
+				mock.ExpectQuery(`FROM generate_series`).
+					WithArgs(projectID, from, to, "1 week").
+					WillReturnRows(rows)
+			},
+			expectedErr:  false,
+			expectPoints: 1,
+		},
+		{
+			name:        "unknown bucket",
+			bucket:      "month",
+			setupMocks:  func() {},
+			expectedErr: true,
+		},
+		{
+			name:   "database error",
+			bucket: models.BucketDay,
+			setupMocks: func() {
+				mock.ExpectQuery(`FROM generate_series`).
+					WithArgs(projectID, from, to, "1 day").
+					WillReturnError(errors.New("database connection error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			points, err := repo.GetCumulativeFlow(ctx, projectID, from, to, tt.bucket)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "ReportRepository.GetCumulativeFlow")
+				assert.Nil(t, points)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, points, tt.expectPoints)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
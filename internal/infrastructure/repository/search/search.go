@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/search"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	searchQuery = `
+		WITH q AS (
+			SELECT websearch_to_tsquery('todo.ru_en', $2) AS query
+		), member_projects AS (
+			SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $1
+		)
+		SELECT 'task', t.id, t.project_id,
+			ts_headline('todo.ru_en', t.title, q.query, $3),
+			ts_headline('todo.ru_en', coalesce(t.description, ''), q.query, $4),
+			ts_rank(t.search_vector, q.query)
+		FROM todo.task t, q
+		WHERE t.search_vector @@ q.query AND t.project_id IN (SELECT project_id FROM member_projects)
+		UNION ALL
+		SELECT 'note', n.id, n.project_id,
+			ts_headline('todo.ru_en', n.name, q.query, $3),
+			ts_headline('todo.ru_en', coalesce(n.description, ''), q.query, $4),
+			ts_rank(n.search_vector, q.query)
+		FROM todo.note n, q
+		WHERE n.search_vector @@ q.query AND n.project_id IN (SELECT project_id FROM member_projects)
+		UNION ALL
+		SELECT 'project', p.id, p.id,
+			ts_headline('todo.ru_en', p.name, q.query, $3),
+			ts_headline('todo.ru_en', coalesce(p.description, ''), q.query, $4),
+			ts_rank(p.search_vector, q.query)
+		FROM todo.project p, q
+		WHERE p.search_vector @@ q.query AND p.id IN (SELECT project_id FROM member_projects)
+		ORDER BY 6 DESC, 2
+		LIMIT $5 OFFSET $6;`
+)
+
+var (
+	titleHeadlineOptions = fmt.Sprintf("HighlightAll=true, StartSel=%s, StopSel=%s",
+		models.HighlightStart, models.HighlightStop)
+	snippetHeadlineOptions = fmt.Sprintf(`MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=" ... ", StartSel=%s, StopSel=%s`,
+		models.HighlightStart, models.HighlightStop)
+)
+
+type SearchRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+func (r *SearchRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]models.SearchResult, error) {
+	const op = "SearchRepository.Search"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, searchQuery,
+		userID, query, titleHeadlineOptions, snippetHeadlineOptions, limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("failed to search")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var results []models.SearchResult
+	for rows.Next() {
+		var res models.SearchResult
+		if err := rows.Scan(&res.Type, &res.ID, &res.ProjectID, &res.Title, &res.Snippet, &res.Rank); err != nil {
+			logger.WithError(err).Error("failed to scan search result")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		results = append(results, res)
+	}
+
+	if err = rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
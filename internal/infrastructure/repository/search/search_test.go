@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/search"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestSearchRepository_Search(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	taskID := uuid.New()
+	noteID := uuid.New()
+	projectID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func()
+		expectedErr   bool
+		expectResults int
+	}{
+		{
+			name: "successful search",
+			setupMocks: func() {
+				rows := sqlmock.NewRows([]string{"type", "id", "project_id", "title", "snippet", "rank"}).
+					AddRow(models.TypeTask, taskID, projectID, "Fix \x02login\x03 bug", "", 0.6).
+					AddRow(models.TypeNote, noteID, projectID, "Notes", "about \x02login\x03", 0.2)
+				mock.ExpectQuery(`websearch_to_tsquery`).
+					WithArgs(userID, "login", titleHeadlineOptions, snippetHeadlineOptions, 20, 0).
+					WillReturnRows(rows)
+			},
+			expectedErr:   false,
+			expectResults: 2,
+		},
+		{
+			name: "database error",
+			setupMocks: func() {
+				mock.ExpectQuery(`websearch_to_tsquery`).
+					WithArgs(userID, "login", titleHeadlineOptions, snippetHeadlineOptions, 20, 0).
+					WillReturnError(errors.New("database connection error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			results, err := repo.Search(ctx, userID, "login", 20, 0)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "SearchRepository.Search")
+				assert.Nil(t, results)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, results, tt.expectResults)
+				assert.Equal(t, models.TypeTask, results[0].Type)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	outbox "github.com/lzimin05/course-todo/internal/infrastructure/repository/outbox"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	models "github.com/lzimin05/course-todo/internal/models/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	GetChecklistQuery = `SELECT ci.id, ci.task_id, ci.text, ci.done, ci.assignee_id, ci.position, ci.created_at
+	FROM todo.task_checklist_item ci
+	JOIN todo.task t ON t.id = ci.task_id
+	JOIN todo.project_member pm ON t.project_id = pm.project_id
+	WHERE ci.task_id = $1 AND pm.user_id = $2
+	ORDER BY ci.position, ci.created_at`
+
+	// Блокировка задачи упорядочивает параллельные изменения ее чек-листа
+	LockTaskForChecklistQuery = `SELECT t.project_id
+	FROM todo.task t
+	WHERE t.id = $1 AND t.project_id IN (
+		SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $2
+	)
+	FOR UPDATE`
+
+	IsProjectMemberQuery = `SELECT EXISTS(
+		SELECT 1 FROM todo.project_member WHERE project_id = $1 AND user_id = $2
+	)`
+
+	CountChecklistItemsQuery = `SELECT COUNT(*) FROM todo.task_checklist_item WHERE task_id = $1 AND id <> $2`
+
+	GetChecklistItemPositionQuery = `SELECT position FROM todo.task_checklist_item WHERE id = $1 AND task_id = $2`
+
+	// $3 исключает сам перемещаемый пункт
+	OpenChecklistGapQuery = `UPDATE todo.task_checklist_item SET position = position + 1
+	WHERE task_id = $1 AND position >= $2 AND id <> $3`
+
+	CloseChecklistGapQuery = `UPDATE todo.task_checklist_item SET position = position - 1
+	WHERE task_id = $1 AND position > $2 AND id <> $3`
+
+	CreateChecklistItemQuery = `INSERT INTO todo.task_checklist_item (id, task_id, text, done, assignee_id, position, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	// Не переданные поля остаются без изменений, $6 снимает исполнителя.
+	// prev видит строку до изменения: по нему понятно, сменился ли исполнитель
+	UpdateChecklistItemQuery = `UPDATE todo.task_checklist_item SET
+		text = COALESCE($3, text),
+		done = COALESCE($4, done),
+		assignee_id = CASE WHEN $6::boolean THEN NULL ELSE COALESCE($5, assignee_id) END
+	FROM (
+		SELECT assignee_id AS previous_assignee_id
+		FROM todo.task_checklist_item WHERE id = $1 AND task_id = $2
+	) prev
+	WHERE id = $1 AND task_id = $2
+	RETURNING id, task_id, text, done, assignee_id, position, created_at, prev.previous_assignee_id`
+
+	PlaceChecklistItemQuery = `UPDATE todo.task_checklist_item SET position = $2 WHERE id = $1`
+
+	DeleteChecklistItemQuery = `DELETE FROM todo.task_checklist_item WHERE id = $1 AND task_id = $2
+	RETURNING position`
+)
+
+func (r *TaskRepository) GetChecklist(ctx context.Context, taskID, userID uuid.UUID) ([]models.ChecklistItem, error) {
+	const op = "TaskRepository.GetChecklist"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("TaskID", taskID)
+
+	rows, err := r.db.QueryContext(ctx, GetChecklistQuery, taskID, userID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get checklist")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	items := make([]models.ChecklistItem, 0)
+	for rows.Next() {
+		var item models.ChecklistItem
+		err := rows.Scan(&item.ID, &item.TaskID, &item.Text, &item.Done, &item.AssigneeID, &item.Position, &item.CreatedAt)
+		if err != nil {
+			logger.WithError(err).Warn("failed to scan checklist item")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Warn("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return items, nil
+}
+
+// CreateChecklistItem вставляет пункт на item.Position, сдвигая следующие.
+// Отрицательная позиция или позиция за пределами списка означает конец списка
+func (r *TaskRepository) CreateChecklistItem(ctx context.Context, item *models.ChecklistItem, userID uuid.UUID) error {
+	const op = "TaskRepository.CreateChecklistItem"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("TaskID", item.TaskID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Warn("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	projectID, err := lockTaskForChecklist(ctx, tx, item.TaskID, userID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to lock task")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := checkAssignee(ctx, tx, projectID, item.AssigneeID); err != nil {
+		logger.WithError(err).Warn("invalid assignee")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, CountChecklistItemsQuery, item.TaskID, item.ID).Scan(&count); err != nil {
+		logger.WithError(err).Warn("failed to count checklist items")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if item.Position < 0 || item.Position > count {
+		item.Position = count
+	}
+
+	if _, err := tx.ExecContext(ctx, OpenChecklistGapQuery, item.TaskID, item.Position, item.ID); err != nil {
+		logger.WithError(err).Warn("failed to open gap in checklist")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, CreateChecklistItemQuery,
+		item.ID, item.TaskID, item.Text, item.Done, item.AssigneeID, item.Position, item.CreatedAt)
+	if err != nil {
+		logger.WithError(err).Warn("failed to create checklist item")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if item.AssigneeID != nil {
+		if err := outbox.Write(ctx, tx, checklistAssignedEvent(userID, projectID, item)); err != nil {
+			logger.WithError(err).Warn("failed to write event")
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Warn("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// UpdateChecklistItem меняет текст, отметку о выполнении и исполнителя пункта.
+// nil оставляет поле без изменений
+func (r *TaskRepository) UpdateChecklistItem(ctx context.Context, itemID, taskID, userID uuid.UUID, text *string, done *bool, assigneeID *uuid.UUID, clearAssignee bool) (*models.ChecklistItem, error) {
+	const op = "TaskRepository.UpdateChecklistItem"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("TaskID", taskID).
+		WithField("ItemID", itemID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Warn("failed to begin transaction")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	projectID, err := lockTaskForChecklist(ctx, tx, taskID, userID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to lock task")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := checkAssignee(ctx, tx, projectID, assigneeID); err != nil {
+		logger.WithError(err).Warn("invalid assignee")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var item models.ChecklistItem
+	var previousAssigneeID *uuid.UUID
+	err = tx.QueryRowContext(ctx, UpdateChecklistItemQuery, itemID, taskID, text, done, assigneeID, clearAssignee).
+		Scan(&item.ID, &item.TaskID, &item.Text, &item.Done, &item.AssigneeID, &item.Position, &item.CreatedAt, &previousAssigneeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("checklist item not found")
+			return nil, fmt.Errorf("%s: %w", op, errs.NewNotFoundError("checklist item not found"))
+		}
+		logger.WithError(err).Warn("failed to update checklist item")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if item.AssigneeID != nil && (previousAssigneeID == nil || *previousAssigneeID != *item.AssigneeID) {
+		if err := outbox.Write(ctx, tx, checklistAssignedEvent(userID, projectID, &item)); err != nil {
+			logger.WithError(err).Warn("failed to write event")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Warn("failed to commit transaction")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &item, nil
+}
+
+// MoveChecklistItem переставляет пункт на указанную позицию и возвращает итоговую
+func (r *TaskRepository) MoveChecklistItem(ctx context.Context, itemID, taskID, userID uuid.UUID, position int) (int, error) {
+	const op = "TaskRepository.MoveChecklistItem"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("TaskID", taskID).
+		WithField("ItemID", itemID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Warn("failed to begin transaction")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTaskForChecklist(ctx, tx, taskID, userID); err != nil {
+		logger.WithError(err).Warn("failed to lock task")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var oldPosition int
+	if err := tx.QueryRowContext(ctx, GetChecklistItemPositionQuery, itemID, taskID).Scan(&oldPosition); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("checklist item not found")
+			return 0, fmt.Errorf("%s: %w", op, errs.NewNotFoundError("checklist item not found"))
+		}
+		logger.WithError(err).Warn("failed to get checklist item position")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, CountChecklistItemsQuery, taskID, itemID).Scan(&count); err != nil {
+		logger.WithError(err).Warn("failed to count checklist items")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if position > count {
+		position = count
+	}
+
+	if _, err := tx.ExecContext(ctx, CloseChecklistGapQuery, taskID, oldPosition, itemID); err != nil {
+		logger.WithError(err).Warn("failed to close gap in checklist")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, OpenChecklistGapQuery, taskID, position, itemID); err != nil {
+		logger.WithError(err).Warn("failed to open gap in checklist")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, PlaceChecklistItemQuery, itemID, position); err != nil {
+		logger.WithError(err).Warn("failed to place checklist item")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Warn("failed to commit transaction")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return position, nil
+}
+
+func (r *TaskRepository) DeleteChecklistItem(ctx context.Context, itemID, taskID, userID uuid.UUID) error {
+	const op = "TaskRepository.DeleteChecklistItem"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("TaskID", taskID).
+		WithField("ItemID", itemID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Warn("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := lockTaskForChecklist(ctx, tx, taskID, userID); err != nil {
+		logger.WithError(err).Warn("failed to lock task")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var position int
+	if err := tx.QueryRowContext(ctx, DeleteChecklistItemQuery, itemID, taskID).Scan(&position); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("checklist item not found")
+			return fmt.Errorf("%s: %w", op, errs.NewNotFoundError("checklist item not found"))
+		}
+		logger.WithError(err).Warn("failed to delete checklist item")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, CloseChecklistGapQuery, taskID, position, itemID); err != nil {
+		logger.WithError(err).Warn("failed to close gap in checklist")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Warn("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// lockTaskForChecklist блокирует задачу, доступную пользователю, и возвращает ее проект
+func lockTaskForChecklist(ctx context.Context, tx *sql.Tx, taskID, userID uuid.UUID) (uuid.UUID, error) {
+	var projectID uuid.UUID
+	if err := tx.QueryRowContext(ctx, LockTaskForChecklistQuery, taskID, userID).Scan(&projectID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, errs.ErrTaskNotFound
+		}
+		return uuid.Nil, err
+	}
+	return projectID, nil
+}
+
+// checkAssignee проверяет, что исполнитель пункта участвует в проекте задачи
+func checkAssignee(ctx context.Context, tx *sql.Tx, projectID uuid.UUID, assigneeID *uuid.UUID) error {
+	if assigneeID == nil {
+		return nil
+	}
+
+	var isMember bool
+	if err := tx.QueryRowContext(ctx, IsProjectMemberQuery, projectID, *assigneeID).Scan(&isMember); err != nil {
+		return err
+	}
+	if !isMember {
+		return errs.ErrAssigneeNotMember
+	}
+	return nil
+}
+
+func checklistAssignedEvent(actorID, projectID uuid.UUID, item *models.ChecklistItem) eventmodels.Event {
+	return eventmodels.New(eventmodels.TypeChecklistAssigned, projectID, actorID, eventmodels.ChecklistData{
+		ID:         item.ID,
+		TaskID:     item.TaskID,
+		ProjectID:  projectID,
+		Text:       item.Text,
+		AssigneeID: *item.AssigneeID,
+	})
+}
@@ -0,0 +1,283 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func TestTaskRepository_CreateChecklistItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	taskID := uuid.New()
+	projectID := uuid.New()
+	userID := uuid.New()
+	assigneeID := uuid.New()
+
+	tests := []struct {
+		name             string
+		position         int
+		assigneeID       *uuid.UUID
+		setupMocks       func(item *models.ChecklistItem)
+		expectedPosition int
+		expectedErr      error
+	}{
+		{
+			name:     "append to the end",
+			position: -1,
+			setupMocks: func(item *models.ChecklistItem) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.project_id`).
+					WithArgs(taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM todo.task_checklist_item`).
+					WithArgs(taskID, item.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+				mock.ExpectExec(`UPDATE todo.task_checklist_item SET position = position \+ 1`).
+					WithArgs(taskID, 3, item.ID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO todo.task_checklist_item`).
+					WithArgs(item.ID, taskID, "Купить молоко", false, nil, 3, item.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedPosition: 3,
+		},
+		{
+			name:       "insert at the top with assignee",
+			position:   0,
+			assigneeID: &assigneeID,
+			setupMocks: func(item *models.ChecklistItem) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.project_id`).
+					WithArgs(taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+				mock.ExpectQuery(`SELECT EXISTS`).
+					WithArgs(projectID, assigneeID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery(`SELECT COUNT\(\*\) FROM todo.task_checklist_item`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectExec(`UPDATE todo.task_checklist_item SET position = position \+ 1`).
+					WithArgs(taskID, 0, item.ID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`INSERT INTO todo.task_checklist_item`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WithArgs(sqlmock.AnyArg(), "checklist.assigned", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedPosition: 0,
+		},
+		{
+			name:       "assignee outside project",
+			position:   0,
+			assigneeID: &assigneeID,
+			setupMocks: func(item *models.ChecklistItem) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.project_id`).
+					WithArgs(taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+				mock.ExpectQuery(`SELECT EXISTS`).
+					WithArgs(projectID, assigneeID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrAssigneeNotMember,
+		},
+		{
+			name:     "task not accessible",
+			position: 0,
+			setupMocks: func(item *models.ChecklistItem) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.project_id`).
+					WithArgs(taskID, userID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedErr: errs.ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &models.ChecklistItem{
+				ID:         uuid.New(),
+				TaskID:     taskID,
+				Text:       "Купить молоко",
+				AssigneeID: tt.assigneeID,
+				Position:   tt.position,
+				CreatedAt:  time.Now(),
+			}
+			tt.setupMocks(item)
+
+			err := repo.CreateChecklistItem(ctx, item, userID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPosition, item.Position)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTaskRepository_UpdateChecklistItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	taskID := uuid.New()
+	itemID := uuid.New()
+	projectID := uuid.New()
+	userID := uuid.New()
+	done := true
+
+	t.Run("toggle done", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT t.project_id`).
+			WithArgs(taskID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+		mock.ExpectQuery(`UPDATE todo.task_checklist_item SET`).
+			WithArgs(itemID, taskID, nil, &done, nil, false).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "text", "done", "assignee_id", "position", "created_at", "previous_assignee_id"}).
+				AddRow(itemID, taskID, "Шаг", true, nil, 1, time.Now(), nil))
+		mock.ExpectCommit()
+
+		item, err := repo.UpdateChecklistItem(ctx, itemID, taskID, userID, nil, &done, nil, false)
+
+		assert.NoError(t, err)
+		assert.True(t, item.Done)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("new assignee", func(t *testing.T) {
+		assigneeID := uuid.New()
+		previousID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT t.project_id`).
+			WithArgs(taskID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs(projectID, assigneeID).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`UPDATE todo.task_checklist_item SET`).
+			WithArgs(itemID, taskID, nil, nil, &assigneeID, false).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "text", "done", "assignee_id", "position", "created_at", "previous_assignee_id"}).
+				AddRow(itemID, taskID, "Шаг", false, assigneeID, 1, time.Now(), previousID))
+		mock.ExpectExec(`INSERT INTO todo.outbox`).
+			WithArgs(sqlmock.AnyArg(), "checklist.assigned", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		item, err := repo.UpdateChecklistItem(ctx, itemID, taskID, userID, nil, nil, &assigneeID, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, assigneeID, *item.AssigneeID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("item not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT t.project_id`).
+			WithArgs(taskID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+		mock.ExpectQuery(`UPDATE todo.task_checklist_item SET`).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := repo.UpdateChecklistItem(ctx, itemID, taskID, userID, nil, &done, nil, false)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTaskRepository_MoveChecklistItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	taskID := uuid.New()
+	itemID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT t.project_id`).
+		WithArgs(taskID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uuid.New()))
+	mock.ExpectQuery(`SELECT position FROM todo.task_checklist_item`).
+		WithArgs(itemID, taskID).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(0))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM todo.task_checklist_item`).
+		WithArgs(taskID, itemID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectExec(`UPDATE todo.task_checklist_item SET position = position - 1`).
+		WithArgs(taskID, 0, itemID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE todo.task_checklist_item SET position = position \+ 1`).
+		WithArgs(taskID, 2, itemID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE todo.task_checklist_item SET position = \$2`).
+		WithArgs(itemID, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	position, err := repo.MoveChecklistItem(ctx, itemID, taskID, userID, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, position)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTaskRepository_DeleteChecklistItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	taskID := uuid.New()
+	itemID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT t.project_id`).
+		WithArgs(taskID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(uuid.New()))
+	mock.ExpectQuery(`DELETE FROM todo.task_checklist_item`).
+		WithArgs(itemID, taskID).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(1))
+	mock.ExpectExec(`UPDATE todo.task_checklist_item SET position = position - 1`).
+		WithArgs(taskID, 1, itemID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.DeleteChecklistItem(ctx, itemID, taskID, userID))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
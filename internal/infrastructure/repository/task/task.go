@@ -3,14 +3,29 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	linkrepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/link"
+	mentionrepo "github.com/lzimin05/course-todo/internal/infrastructure/repository/mention"
+	outbox "github.com/lzimin05/course-todo/internal/infrastructure/repository/outbox"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
 	models "github.com/lzimin05/course-todo/internal/models/task"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
 )
 
+const (
+	uniqueViolationCode = "23505"
+	// Имя ресурса CalDAV уникально в проекте
+	caldavNameConstraint = "idx_task_caldav_name"
+)
+
 type TaskRepository struct {
 	db *sql.DB
 }
@@ -20,34 +35,71 @@ func New(db *sql.DB) *TaskRepository {
 }
 
 const (
-	CreateTaskQuery = `INSERT INTO todo.task (id, project_id, user_id, title, description, importance, status, created_at, deadline)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	RETURNING id, project_id, user_id, title, description, importance, status, created_at, deadline`
+	// Имя ресурса и UID CalDAV сохраняются вместе с задачей, чтобы повтор PUT
+	// от клиента находил уже созданную задачу
+	CreateTaskQuery = `INSERT INTO todo.task (id, project_id, user_id, title, description, importance, status, created_at, deadline, estimate_minutes, start_at, all_day, completed_at, caldav_name, caldav_uid)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CASE WHEN $7 = 'completed' THEN $8::timestamptz END, NULLIF($13, ''), NULLIF($14, ''))
+	RETURNING id, project_id, user_id, title, description, importance, status, created_at, deadline, estimate_minutes, start_at, all_day, version`
 
-	GetTasksByProjectIDQuery = `SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline
+	GetTasksByProjectIDQuery = `SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline, t.completed_at, t.version, t.estimate_minutes, t.start_at, t.all_day,
+		cl.done, cl.total
 	FROM todo.task t
 	JOIN todo.project_member pm ON t.project_id = pm.project_id
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) FILTER (WHERE ci.done) AS done, COUNT(*) AS total
+		FROM todo.task_checklist_item ci WHERE ci.task_id = t.id
+	) cl
 	WHERE t.project_id = $1 AND pm.user_id = $2`
 
-	GetTasksByUserIDQuery = `SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline
+	GetTasksByUserIDQuery = `SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline, t.completed_at, t.version, t.estimate_minutes, t.start_at, t.all_day,
+		cl.done, cl.total
 	FROM todo.task t
 	JOIN todo.project_member pm ON t.project_id = pm.project_id
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) FILTER (WHERE ci.done) AS done, COUNT(*) AS total
+		FROM todo.task_checklist_item ci WHERE ci.task_id = t.id
+	) cl
 	WHERE pm.user_id = $1`
 
-	UpdateTaskQuery = `UPDATE todo.task SET title = $1, description = $2, importance = $3, deadline = $4
-	WHERE id = $5 AND project_id IN (
-		SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $6
-	)`
+	GetTaskByIDQuery = `SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline, t.completed_at, t.version, t.estimate_minutes, t.start_at, t.all_day,
+		cl.done, cl.total
+	FROM todo.task t
+	JOIN todo.project_member pm ON t.project_id = pm.project_id
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) FILTER (WHERE ci.done) AS done, COUNT(*) AS total
+		FROM todo.task_checklist_item ci WHERE ci.task_id = t.id
+	) cl
+	WHERE t.id = $1 AND pm.user_id = $2`
 
-	UpdateTaskStatusQuery = `UPDATE todo.task SET status = $1 
-	WHERE id = $2 AND project_id IN (
-		SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $3
-	)`
+	UpdateTaskQuery = `UPDATE todo.task SET title = $1, description = $2, importance = $3, deadline = $4, estimate_minutes = $5,
+		start_at = $6, all_day = $7, version = version + 1,
+		overdue_at = CASE WHEN deadline IS DISTINCT FROM $4 THEN NULL ELSE overdue_at END
+	WHERE id = $8 AND project_id IN (
+		SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $9
+	) AND ($10::int[] IS NULL OR version = ANY($10))
+	RETURNING version, project_id, status`
+
+	UpdateTaskStatusQuery = `UPDATE todo.task SET status = $1,
+		completed_at = CASE WHEN $1 = 'completed' THEN COALESCE(completed_at, $3) ELSE NULL END,
+		version = version + 1
+	WHERE id = $2
+	RETURNING version, title, importance, deadline`
 
 	DeleteTaskQuery = `DELETE FROM todo.task
 	WHERE id = $1 AND project_id IN (
 		SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $2
-	)`
+	) AND ($3::int[] IS NULL OR version = ANY($3))
+	RETURNING project_id, title, status, importance, deadline, version`
+
+	GetTaskStatusForUpdateQuery = `SELECT t.status, t.project_id, t.version
+	FROM todo.task t
+	WHERE t.id = $1 AND t.project_id IN (
+		SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $2
+	)
+	FOR UPDATE`
+
+	InsertStatusHistoryQuery = `INSERT INTO todo.task_status_history (task_id, project_id, from_status, to_status, actor_id, changed_at)
+	VALUES ($1, $2, $3, $4, $5, $6)`
 
 	TaskExistenceForUserQuery = `SELECT EXISTS(
 		SELECT 1 FROM todo.task t
@@ -56,21 +108,70 @@ const (
 	)`
 )
 
-func (r *TaskRepository) CreateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+// CreateTask создает задачу и в той же транзакции сохраняет ссылки и упоминания из ее описания
+func (r *TaskRepository) CreateTask(ctx context.Context, task *models.Task, refs linkmodels.References, mentions []string) (*models.Task, error) {
 	const op = "TaskRepository.CreateTask"
 	logger := logctx.GetLogger(ctx).WithField("op", op).
 		WithField("title", task.Title)
 
-	err := r.db.QueryRowContext(ctx, CreateTaskQuery,
-		task.ID, task.ProjectID, task.UserID, task.Title, task.Description, task.Importance, task.Status, task.CreatedAt, task.Deadline).
-		Scan(&task.ID, &task.ProjectID, &task.UserID, &task.Title, &task.Description, &task.Importance, &task.Status, &task.CreatedAt, &task.Deadline)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
+		logger.WithError(err).Warn("failed to begin transaction")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := InsertTask(ctx, tx, task); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode && pqErr.Constraint == caldavNameConstraint {
+			logger.Warn("caldav object name is already taken")
+			return nil, errs.ErrVersionMismatch
+		}
 		logger.WithError(err).Warn("failed to create task")
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+
+	if err := linkrepo.ReplaceLinks(ctx, tx, linkmodels.TypeTask, task.ID, task.UserID, refs); err != nil {
+		logger.WithError(err).Warn("failed to save task links")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := mentionrepo.ReplaceMentions(ctx, tx, linkmodels.TypeTask, task.ID, task.UserID, mentions); err != nil {
+		logger.WithError(err).Warn("failed to save task mentions")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Warn("failed to commit transaction")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	return task, nil
 }
 
+// InsertTask создает задачу в переданной транзакции вместе с начальным статусом в истории
+// и событием в outbox. Используется и при импорте, где все задачи пишутся одной транзакцией
+func InsertTask(ctx context.Context, tx *sql.Tx, task *models.Task) error {
+	err := tx.QueryRowContext(ctx, CreateTaskQuery,
+		task.ID, task.ProjectID, task.UserID, task.Title, task.Description, task.Importance, task.Status, task.CreatedAt, task.Deadline, task.EstimateMinutes, task.StartAt, task.AllDay,
+		task.CalDAVName, task.CalDAVUID).
+		Scan(&task.ID, &task.ProjectID, &task.UserID, &task.Title, &task.Description, &task.Importance, &task.Status, &task.CreatedAt, &task.Deadline, &task.EstimateMinutes, &task.StartAt, &task.AllDay, &task.Version)
+	if err != nil {
+		return fmt.Errorf("insert task: %w", err)
+	}
+
+	// Начальный статус задачи тоже попадает в историю
+	_, err = tx.ExecContext(ctx, InsertStatusHistoryQuery,
+		task.ID, task.ProjectID, nil, task.Status, task.UserID, task.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("save initial task status: %w", err)
+	}
+
+	if err := outbox.Write(ctx, tx, taskEvent(eventmodels.TypeTaskCreated, task.UserID, task, "")); err != nil {
+		return fmt.Errorf("write task event: %w", err)
+	}
+	return nil
+}
+
 func (r *TaskRepository) GetTasksByProjectID(ctx context.Context, projectID, userID uuid.UUID) ([]*models.Task, error) {
 	const op = "TaskRepository.GetTasksByProjectID"
 	logger := logctx.GetLogger(ctx).WithField("op", op).
@@ -86,7 +187,7 @@ func (r *TaskRepository) GetTasksByProjectID(ctx context.Context, projectID, use
 	var tasks []*models.Task
 	for rows.Next() {
 		var t models.Task
-		err := rows.Scan(&t.ID, &t.ProjectID, &t.UserID, &t.Title, &t.Description, &t.Importance, &t.Status, &t.CreatedAt, &t.Deadline)
+		err := rows.Scan(&t.ID, &t.ProjectID, &t.UserID, &t.Title, &t.Description, &t.Importance, &t.Status, &t.CreatedAt, &t.Deadline, &t.CompletedAt, &t.Version, &t.EstimateMinutes, &t.StartAt, &t.AllDay, &t.ChecklistDone, &t.ChecklistTotal)
 		if err != nil {
 			logger.WithError(err).Warn("failed to scan task")
 			return nil, fmt.Errorf("%s: %w", op, err)
@@ -110,7 +211,7 @@ func (r *TaskRepository) GetTasksByUserID(ctx context.Context, userID uuid.UUID)
 	var tasks []*models.Task
 	for rows.Next() {
 		var t models.Task
-		err := rows.Scan(&t.ID, &t.ProjectID, &t.UserID, &t.Title, &t.Description, &t.Importance, &t.Status, &t.CreatedAt, &t.Deadline)
+		err := rows.Scan(&t.ID, &t.ProjectID, &t.UserID, &t.Title, &t.Description, &t.Importance, &t.Status, &t.CreatedAt, &t.Deadline, &t.CompletedAt, &t.Version, &t.EstimateMinutes, &t.StartAt, &t.AllDay, &t.ChecklistDone, &t.ChecklistTotal)
 		if err != nil {
 			logger.WithError(err).Warn("failed to get task in loop")
 			return nil, fmt.Errorf("%s: %w", op, err)
@@ -120,37 +221,152 @@ func (r *TaskRepository) GetTasksByUserID(ctx context.Context, userID uuid.UUID)
 	return tasks, nil
 }
 
-func (r *TaskRepository) UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, taskID, userID uuid.UUID) error {
+func (r *TaskRepository) GetTaskByID(ctx context.Context, taskID, userID uuid.UUID) (*models.Task, error) {
+	const op = "TaskRepository.GetTaskByID"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("TaskID", taskID)
+
+	var t models.Task
+	err := r.db.QueryRowContext(ctx, GetTaskByIDQuery, taskID, userID).
+		Scan(&t.ID, &t.ProjectID, &t.UserID, &t.Title, &t.Description, &t.Importance, &t.Status, &t.CreatedAt, &t.Deadline, &t.CompletedAt, &t.Version, &t.EstimateMinutes, &t.StartAt, &t.AllDay, &t.ChecklistDone, &t.ChecklistTotal)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("task not found or doesn't belong to user")
+			return nil, fmt.Errorf("%s: %w", op, errs.ErrTaskNotFound)
+		}
+		logger.WithError(err).Warn("failed to get task")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &t, nil
+}
+
+// UpdateTask обновляет задачу и в той же транзакции заменяет ссылки и упоминания из ее описания
+func (r *TaskRepository) UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, estimateMinutes *int, startAt *time.Time, allDay bool, taskID, userID uuid.UUID, expectedVersions []int, refs linkmodels.References, mentions []string) (int, error) {
 	const op = "TaskRepository.UpdateTask"
 	logger := logctx.GetLogger(ctx).WithField("op", op).
 		WithField("TaskID", taskID)
-	_, err := r.db.ExecContext(ctx, UpdateTaskQuery, title, description, importance, deadline, taskID, userID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
+		logger.WithError(err).Warn("failed to begin transaction")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	task := &models.Task{ID: taskID, Title: title, Importance: importance, Deadline: deadline}
+	err = tx.QueryRowContext(ctx, UpdateTaskQuery, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, pq.Array(expectedVersions)).
+		Scan(&task.Version, &task.ProjectID, &task.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", op, r.notUpdatedReason(ctx, taskID, userID))
+		}
 		logger.WithError(err).Warn("failed to update task")
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
+
+	if err := linkrepo.ReplaceLinks(ctx, tx, linkmodels.TypeTask, taskID, userID, refs); err != nil {
+		logger.WithError(err).Warn("failed to save task links")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := mentionrepo.ReplaceMentions(ctx, tx, linkmodels.TypeTask, taskID, userID, mentions); err != nil {
+		logger.WithError(err).Warn("failed to save task mentions")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := outbox.Write(ctx, tx, taskEvent(eventmodels.TypeTaskUpdated, userID, task, "")); err != nil {
+		logger.WithError(err).Warn("failed to write task event")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Warn("failed to commit transaction")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return task.Version, nil
 }
 
-func (r *TaskRepository) UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID) error {
+func (r *TaskRepository) UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID, expectedVersions []int) (int, error) {
 	const op = "TaskRepository.UpdateTaskStatus"
 	logger := logctx.GetLogger(ctx).WithField("op", op).
 		WithField("TaskID", taskID)
-	_, err := r.db.ExecContext(ctx, UpdateTaskStatusQuery, status, taskID, userID)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Warn("failed to begin transaction")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var prevStatus string
+	var projectID uuid.UUID
+	var version int
+	err = tx.QueryRowContext(ctx, GetTaskStatusForUpdateQuery, taskID, userID).Scan(&prevStatus, &projectID, &version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("task not found or doesn't belong to user")
+			return 0, fmt.Errorf("%s: %w", op, errs.ErrTaskNotFound)
+		}
+		logger.WithError(err).Warn("failed to get current task status")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Строка заблокирована до конца транзакции, поэтому версию можно сравнить здесь
+	if expectedVersions != nil && !slices.Contains(expectedVersions, version) {
+		logger.Warn("task version mismatch")
+		return 0, fmt.Errorf("%s: %w", op, errs.ErrVersionMismatch)
+	}
+
+	// Повторная установка того же статуса не является переходом
+	if prevStatus == status {
+		return version, nil
+	}
+
+	changedAt := time.Now()
+	task := &models.Task{ID: taskID, ProjectID: projectID, Status: status}
+	err = tx.QueryRowContext(ctx, UpdateTaskStatusQuery, status, taskID, changedAt).
+		Scan(&task.Version, &task.Title, &task.Importance, &task.Deadline)
 	if err != nil {
 		logger.WithError(err).Warn("failed to update status for task")
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
+
+	_, err = tx.ExecContext(ctx, InsertStatusHistoryQuery, taskID, projectID, prevStatus, status, userID, changedAt)
+	if err != nil {
+		logger.WithError(err).Warn("failed to save status transition")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	events := []eventmodels.Event{taskEvent(eventmodels.TypeTaskStatusChanged, userID, task, prevStatus)}
+	if status == models.StatusCompleted {
+		events = append(events, taskEvent(eventmodels.TypeTaskCompleted, userID, task, prevStatus))
+	}
+	if err := outbox.Write(ctx, tx, events...); err != nil {
+		logger.WithError(err).Warn("failed to write task event")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Warn("failed to commit transaction")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return task.Version, nil
 }
 
-func (r *TaskRepository) DeleteTask(ctx context.Context, taskID, userID uuid.UUID) error {
+func (r *TaskRepository) DeleteTask(ctx context.Context, taskID, userID uuid.UUID, expectedVersions []int) error {
 	const op = "TaskRepository.DeleteTask"
 	logger := logctx.GetLogger(ctx).WithField("op", op).
 		WithField("TaskID", taskID)
 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.WithError(err).Warn("failed to begin transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
 	var exists bool
-	err := r.db.QueryRowContext(ctx, TaskExistenceForUserQuery, taskID, userID).Scan(&exists)
+	err = tx.QueryRowContext(ctx, TaskExistenceForUserQuery, taskID, userID).Scan(&exists)
 
 	if err != nil {
 		logger.WithError(err).Error("failed to check task existence")
@@ -162,10 +378,59 @@ func (r *TaskRepository) DeleteTask(ctx context.Context, taskID, userID uuid.UUI
 		return fmt.Errorf("%s: %w", op, sql.ErrNoRows)
 	}
 
-	_, err = r.db.ExecContext(ctx, DeleteTaskQuery, taskID, userID)
+	task := &models.Task{ID: taskID}
+	err = tx.QueryRowContext(ctx, DeleteTaskQuery, taskID, userID, pq.Array(expectedVersions)).
+		Scan(&task.ProjectID, &task.Title, &task.Status, &task.Importance, &task.Deadline, &task.Version)
 	if err != nil {
+		// Задача существует, значит ее успели изменить после чтения клиентом
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.Warn("task version mismatch")
+			return fmt.Errorf("%s: %w", op, errs.ErrVersionMismatch)
+		}
 		logger.WithError(err).Warn("failed to delete task")
 		return fmt.Errorf("%s: %w", op, err)
 	}
+
+	if err := outbox.Write(ctx, tx, taskEvent(eventmodels.TypeTaskDeleted, userID, task, "")); err != nil {
+		logger.WithError(err).Warn("failed to write task event")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.WithError(err).Warn("failed to commit transaction")
+		return fmt.Errorf("%s: %w", op, err)
+	}
 	return nil
 }
+
+// notUpdatedReason определяет, почему условное обновление не затронуло строку:
+// задачи нет у пользователя или ее версия уже изменилась
+func (r *TaskRepository) notUpdatedReason(ctx context.Context, taskID, userID uuid.UUID) error {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, TaskExistenceForUserQuery, taskID, userID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return errs.ErrTaskNotFound
+	}
+	return errs.ErrVersionMismatch
+}
+
+// taskEvent собирает событие о задаче для outbox. Дедлайн года 1 означает,
+// что дедлайна нет, и в событие не попадает
+func taskEvent(eventType string, actorID uuid.UUID, task *models.Task, previousStatus string) eventmodels.Event {
+	data := eventmodels.TaskData{
+		ID:             task.ID,
+		ProjectID:      task.ProjectID,
+		Title:          task.Title,
+		Status:         task.Status,
+		PreviousStatus: previousStatus,
+		Importance:     task.Importance,
+		Version:        task.Version,
+	}
+	if task.Deadline.Year() > 1 {
+		deadline := task.Deadline
+		data.Deadline = &deadline
+	}
+	return eventmodels.New(eventType, task.ProjectID, actorID, data)
+}
@@ -2,14 +2,18 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
 	models "github.com/lzimin05/course-todo/internal/models/task"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
 )
@@ -24,9 +28,11 @@ func TestTaskRepository_CreateTask(t *testing.T) {
 
 	taskID := uuid.New()
 	projectID := uuid.New()
+	annaID := uuid.New()
 	userID := uuid.New()
 	createdAt := time.Now()
 	deadline := time.Now().Add(24 * time.Hour)
+	estimate := 90
 
 	task := &models.Task{
 		ID:          taskID,
@@ -38,6 +44,8 @@ func TestTaskRepository_CreateTask(t *testing.T) {
 		Status:      "pending",
 		CreatedAt:   createdAt,
 		Deadline:    deadline,
+
+		EstimateMinutes: &estimate,
 	}
 
 	tests := []struct {
@@ -50,22 +58,75 @@ func TestTaskRepository_CreateTask(t *testing.T) {
 			name: "successful task creation",
 			task: task,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline"}).
-					AddRow(taskID, projectID, userID, "Test Task", "Test Description", 1, "pending", createdAt, deadline)
+				mock.ExpectBegin()
+
+				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline", "estimate_minutes", "start_at", "all_day", "version"}).
+					AddRow(taskID, projectID, userID, "Test Task", "Test Description", 1, "pending", createdAt, deadline, 90, nil, false, 1)
 
 				mock.ExpectQuery(`INSERT INTO todo.task`).
-					WithArgs(taskID, projectID, userID, "Test Task", "Test Description", 1, "pending", createdAt, deadline).
+					WithArgs(taskID, projectID, userID, "Test Task", "Test Description", 1, "pending", createdAt, deadline, 90, nil, false, "", "").
 					WillReturnRows(rows)
+
+				mock.ExpectExec(`INSERT INTO todo.task_status_history`).
+					WithArgs(taskID, projectID, nil, "pending", userID, createdAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WithArgs(sqlmock.AnyArg(), "task.created", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec(`DELETE FROM todo.entity_link`).
+					WithArgs("task", taskID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO todo.entity_link`).
+					WithArgs("task", taskID, pq.StringArray{"отчет"}, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				// Упоминание и событие о нем пишутся в транзакции задачи
+				mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+					WithArgs("task", taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(projectID, "Test Task"))
+				mock.ExpectExec(`DELETE FROM todo.mention`).
+					WithArgs("task", taskID, projectID, pq.StringArray{"anna"}).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`INSERT INTO todo.mention`).
+					WithArgs("task", taskID, projectID, pq.StringArray{"anna"}, userID, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(annaID))
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WithArgs(sqlmock.AnyArg(), "mention.created", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
 			},
 			expectedErr: false,
 		},
+		{
+			name: "links error",
+			task: task,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO todo.task`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline", "estimate_minutes", "start_at", "all_day", "version"}).
+						AddRow(taskID, projectID, userID, "Test Task", "Test Description", 1, "pending", createdAt, deadline, 90, nil, false, 1))
+				mock.ExpectExec(`INSERT INTO todo.task_status_history`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM todo.entity_link`).
+					WillReturnError(errors.New("database connection error"))
+				mock.ExpectRollback()
+			},
+			expectedErr: true,
+		},
 		{
 			name: "database error",
 			task: task,
 			setupMocks: func() {
+				mock.ExpectBegin()
 				mock.ExpectQuery(`INSERT INTO todo.task`).
-					WithArgs(taskID, projectID, userID, "Test Task", "Test Description", 1, "pending", createdAt, deadline).
+					WithArgs(taskID, projectID, userID, "Test Task", "Test Description", 1, "pending", createdAt, deadline, 90, nil, false, "", "").
 					WillReturnError(errors.New("database connection error"))
+				mock.ExpectRollback()
 			},
 			expectedErr: true,
 		},
@@ -75,7 +136,7 @@ func TestTaskRepository_CreateTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			result, err := repo.CreateTask(ctx, tt.task)
+			result, err := repo.CreateTask(ctx, tt.task, linkmodels.References{Titles: []string{"Отчет"}}, []string{"anna"})
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -94,6 +155,52 @@ func TestTaskRepository_CreateTask(t *testing.T) {
 	}
 }
 
+func TestTaskRepository_CreateTask_CalDAVNameTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	task := &models.Task{
+		ID:         uuid.New(),
+		ProjectID:  uuid.New(),
+		UserID:     uuid.New(),
+		Title:      "Купить молоко",
+		Importance: 2,
+		Status:     models.StatusCompleted,
+		CreatedAt:  time.Now(),
+		CalDAVName: "abc.ics",
+		CalDAVUID:  "abc-uid",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO todo.task`).
+		WithArgs(task.ID, task.ProjectID, task.UserID, "Купить молоко", "", 2, "completed", task.CreatedAt, time.Time{}, nil, nil, false, "abc.ics", "abc-uid").
+		WillReturnError(&pq.Error{Code: uniqueViolationCode, Constraint: caldavNameConstraint})
+	mock.ExpectRollback()
+
+	result, err := repo.CreateTask(ctx, task, linkmodels.References{}, nil)
+
+	assert.ErrorIs(t, err, errs.ErrVersionMismatch)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Другие нарушения уникальности не считаются конфликтом имени
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO todo.task`).
+		WillReturnError(&pq.Error{Code: uniqueViolationCode, Constraint: "task_pkey"})
+	mock.ExpectRollback()
+
+	result, err = repo.CreateTask(ctx, task, linkmodels.References{}, nil)
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errs.ErrVersionMismatch)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestTaskRepository_GetTasksByProjectID(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -121,11 +228,11 @@ func TestTaskRepository_GetTasksByProjectID(t *testing.T) {
 			projectID: projectID,
 			userID:    userID,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline"}).
-					AddRow(taskID, projectID, userID, "Task 1", "Description 1", 1, "pending", createdAt, deadline).
-					AddRow(uuid.New(), projectID, userID, "Task 2", "Description 2", 2, "completed", createdAt, deadline)
+				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline", "completed_at", "version", "estimate_minutes", "start_at", "all_day", "done", "total"}).
+					AddRow(taskID, projectID, userID, "Task 1", "Description 1", 1, "pending", createdAt, deadline, nil, 1, 120, nil, false, 1, 3).
+					AddRow(uuid.New(), projectID, userID, "Task 2", "Description 2", 2, "completed", createdAt, deadline, nil, 1, nil, nil, false, 0, 0)
 
-				mock.ExpectQuery(`SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline`).
+				mock.ExpectQuery(`SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline, t.completed_at`).
 					WithArgs(projectID, userID).
 					WillReturnRows(rows)
 			},
@@ -137,9 +244,9 @@ func TestTaskRepository_GetTasksByProjectID(t *testing.T) {
 			projectID: projectID,
 			userID:    userID,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline"})
+				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline", "completed_at", "version", "estimate_minutes", "start_at", "all_day", "done", "total"})
 
-				mock.ExpectQuery(`SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline`).
+				mock.ExpectQuery(`SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline, t.completed_at`).
 					WithArgs(projectID, userID).
 					WillReturnRows(rows)
 			},
@@ -151,7 +258,7 @@ func TestTaskRepository_GetTasksByProjectID(t *testing.T) {
 			projectID: projectID,
 			userID:    userID,
 			setupMocks: func() {
-				mock.ExpectQuery(`SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline`).
+				mock.ExpectQuery(`SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline, t.completed_at`).
 					WithArgs(projectID, userID).
 					WillReturnError(errors.New("database connection error"))
 			},
@@ -205,10 +312,10 @@ func TestTaskRepository_GetTasksByUserID(t *testing.T) {
 			name:   "successful tasks retrieval by user",
 			userID: userID,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline"}).
-					AddRow(taskID, projectID, userID, "User Task 1", "Description 1", 1, "pending", createdAt, deadline)
+				rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline", "completed_at", "version", "estimate_minutes", "start_at", "all_day", "done", "total"}).
+					AddRow(taskID, projectID, userID, "User Task 1", "Description 1", 1, "pending", createdAt, deadline, nil, 1, nil, nil, false, 2, 2)
 
-				mock.ExpectQuery(`SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline`).
+				mock.ExpectQuery(`SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline, t.completed_at`).
 					WithArgs(userID).
 					WillReturnRows(rows)
 			},
@@ -219,7 +326,7 @@ func TestTaskRepository_GetTasksByUserID(t *testing.T) {
 			name:   "database error",
 			userID: userID,
 			setupMocks: func() {
-				mock.ExpectQuery(`SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline`).
+				mock.ExpectQuery(`SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline, t.completed_at`).
 					WithArgs(userID).
 					WillReturnError(errors.New("database connection error"))
 			},
@@ -258,46 +365,100 @@ func TestTaskRepository_UpdateTask(t *testing.T) {
 
 	taskID := uuid.New()
 	userID := uuid.New()
+	projectID := uuid.New()
 	deadline := time.Now().Add(24 * time.Hour)
+	expectedVersion := 3
 
 	tests := []struct {
-		name        string
-		title       string
-		description string
-		importance  int
-		deadline    time.Time
-		taskID      uuid.UUID
-		userID      uuid.UUID
-		setupMocks  func()
-		expectedErr bool
+		name             string
+		expectedVersions []int
+		setupMocks       func()
+		expectedResult   int
+		expectedErr      bool
+		expectedErrIs    error
 	}{
 		{
-			name:        "successful task update",
-			title:       "Updated Task",
-			description: "Updated Description",
-			importance:  2,
-			deadline:    deadline,
-			taskID:      taskID,
-			userID:      userID,
+			name: "successful task update",
 			setupMocks: func() {
-				mock.ExpectExec(`UPDATE todo.task SET title = \$1, description = \$2, importance = \$3, deadline = \$4`).
-					WithArgs("Updated Task", "Updated Description", 2, deadline, taskID, userID).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE todo.task SET title = \$1, description = \$2, importance = \$3, deadline = \$4, estimate_minutes = \$5,\s+start_at = \$6, all_day = \$7, version = version \+ 1`).
+					WithArgs("Updated Task", "Updated Description", 2, deadline, nil, nil, false, taskID, userID, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "project_id", "status"}).AddRow(2, projectID, "waiting"))
+				mock.ExpectExec(`DELETE FROM todo.entity_link`).
+					WithArgs("task", taskID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+					WithArgs("task", taskID).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(projectID, "Updated Task"))
+				mock.ExpectExec(`DELETE FROM todo.mention`).
+					WithArgs("task", taskID, projectID, pq.StringArray(nil)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WithArgs(sqlmock.AnyArg(), "task.updated", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
-			expectedErr: false,
+			expectedResult: 2,
+		},
+		{
+			name:             "matching version",
+			expectedVersions: []int{expectedVersion},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE todo.task SET`).
+					WithArgs("Updated Task", "Updated Description", 2, deadline, nil, nil, false, taskID, userID, pq.Array([]int{expectedVersion})).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "project_id", "status"}).AddRow(4, projectID, "waiting"))
+				mock.ExpectExec(`DELETE FROM todo.entity_link`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`SELECT t.project_id, t.title FROM todo.task`).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "title"}).AddRow(projectID, "Updated Task"))
+				mock.ExpectExec(`DELETE FROM todo.mention`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedResult: 4,
+		},
+		{
+			name:             "version mismatch",
+			expectedVersions: []int{expectedVersion},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE todo.task SET`).
+					WithArgs("Updated Task", "Updated Description", 2, deadline, nil, nil, false, taskID, userID, pq.Array([]int{expectedVersion})).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(`SELECT EXISTS`).
+					WithArgs(taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			expectedErr:   true,
+			expectedErrIs: errs.ErrVersionMismatch,
+		},
+		{
+			name: "task not found",
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE todo.task SET`).
+					WithArgs("Updated Task", "Updated Description", 2, deadline, nil, nil, false, taskID, userID, nil).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(`SELECT EXISTS`).
+					WithArgs(taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+			expectedErr:   true,
+			expectedErrIs: errs.ErrTaskNotFound,
 		},
 		{
-			name:        "database error",
-			title:       "Updated Task",
-			description: "Updated Description",
-			importance:  2,
-			deadline:    deadline,
-			taskID:      taskID,
-			userID:      userID,
+			name: "database error",
 			setupMocks: func() {
-				mock.ExpectExec(`UPDATE todo.task SET title = \$1, description = \$2, importance = \$3, deadline = \$4`).
-					WithArgs("Updated Task", "Updated Description", 2, deadline, taskID, userID).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE todo.task SET`).
+					WithArgs("Updated Task", "Updated Description", 2, deadline, nil, nil, false, taskID, userID, nil).
 					WillReturnError(errors.New("database connection error"))
+				mock.ExpectRollback()
 			},
 			expectedErr: true,
 		},
@@ -307,13 +468,17 @@ func TestTaskRepository_UpdateTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			err := repo.UpdateTask(ctx, tt.title, tt.description, tt.importance, tt.deadline, tt.taskID, tt.userID)
+			version, err := repo.UpdateTask(ctx, "Updated Task", "Updated Description", 2, deadline, nil, nil, false, taskID, userID, tt.expectedVersions, linkmodels.References{}, nil)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), "TaskRepository.UpdateTask")
+				if tt.expectedErrIs != nil {
+					assert.ErrorIs(t, err, tt.expectedErrIs)
+				}
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, version)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -331,14 +496,17 @@ func TestTaskRepository_UpdateTaskStatus(t *testing.T) {
 
 	taskID := uuid.New()
 	userID := uuid.New()
+	projectID := uuid.New()
+	staleVersion := 1
 
 	tests := []struct {
-		name        string
-		status      string
-		taskID      uuid.UUID
-		userID      uuid.UUID
-		setupMocks  func()
-		expectedErr bool
+		name             string
+		status           string
+		taskID           uuid.UUID
+		userID           uuid.UUID
+		expectedVersions []int
+		setupMocks       func()
+		expectedErr      bool
 	}{
 		{
 			name:   "successful status update",
@@ -346,21 +514,85 @@ func TestTaskRepository_UpdateTaskStatus(t *testing.T) {
 			taskID: taskID,
 			userID: userID,
 			setupMocks: func() {
-				mock.ExpectExec(`UPDATE todo.task SET status = \$1`).
-					WithArgs("completed", taskID, userID).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.status, t.project_id`).
+					WithArgs(taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "project_id", "version"}).AddRow("waiting", projectID, 2))
+				mock.ExpectQuery(`UPDATE todo.task SET status = \$1`).
+					WithArgs("completed", taskID, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"version", "title", "importance", "deadline"}).
+						AddRow(3, "Task", 1, time.Time{}))
+				mock.ExpectExec(`INSERT INTO todo.task_status_history`).
+					WithArgs(taskID, projectID, "waiting", "completed", userID, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				// Завершение задачи порождает два события
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WithArgs(sqlmock.AnyArg(), "task.status_changed", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WithArgs(sqlmock.AnyArg(), "task.completed", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			expectedErr: false,
 		},
+		{
+			name:   "same status is not a transition",
+			status: "completed",
+			taskID: taskID,
+			userID: userID,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.status, t.project_id`).
+					WithArgs(taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "project_id", "version"}).AddRow("completed", projectID, 2))
+				mock.ExpectRollback()
+			},
+			expectedErr: false,
+		},
+		{
+			name:             "version mismatch",
+			status:           "completed",
+			taskID:           taskID,
+			userID:           userID,
+			expectedVersions: []int{staleVersion},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.status, t.project_id`).
+					WithArgs(taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "project_id", "version"}).AddRow("waiting", projectID, 2))
+				mock.ExpectRollback()
+			},
+			expectedErr: true,
+		},
+		{
+			name:   "task not found",
+			status: "completed",
+			taskID: taskID,
+			userID: userID,
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.status, t.project_id`).
+					WithArgs(taskID, userID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedErr: true,
+		},
 		{
 			name:   "database error",
 			status: "completed",
 			taskID: taskID,
 			userID: userID,
 			setupMocks: func() {
-				mock.ExpectExec(`UPDATE todo.task SET status = \$1`).
-					WithArgs("completed", taskID, userID).
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT t.status, t.project_id`).
+					WithArgs(taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "project_id", "version"}).AddRow("waiting", projectID, 2))
+				mock.ExpectQuery(`UPDATE todo.task SET status = \$1`).
+					WithArgs("completed", taskID, sqlmock.AnyArg()).
 					WillReturnError(errors.New("database connection error"))
+				mock.ExpectRollback()
 			},
 			expectedErr: true,
 		},
@@ -370,7 +602,7 @@ func TestTaskRepository_UpdateTaskStatus(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			err := repo.UpdateTaskStatus(ctx, tt.status, tt.taskID, tt.userID)
+			_, err := repo.UpdateTaskStatus(ctx, tt.status, tt.taskID, tt.userID, tt.expectedVersions)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -394,19 +626,24 @@ func TestTaskRepository_DeleteTask(t *testing.T) {
 
 	taskID := uuid.New()
 	userID := uuid.New()
+	projectID := uuid.New()
+	staleVersion := 1
 
 	tests := []struct {
-		name        string
-		taskID      uuid.UUID
-		userID      uuid.UUID
-		setupMocks  func()
-		expectedErr bool
+		name             string
+		taskID           uuid.UUID
+		userID           uuid.UUID
+		expectedVersions []int
+		setupMocks       func()
+		expectedErr      bool
 	}{
 		{
 			name:   "successful task deletion",
 			taskID: taskID,
 			userID: userID,
 			setupMocks: func() {
+				mock.ExpectBegin()
+
 				// Mock existence check
 				rows := sqlmock.NewRows([]string{"exists"}).AddRow(true)
 				mock.ExpectQuery(`SELECT EXISTS`).
@@ -414,22 +651,48 @@ func TestTaskRepository_DeleteTask(t *testing.T) {
 					WillReturnRows(rows)
 
 				// Mock deletion
-				mock.ExpectExec(`DELETE FROM todo.task`).
-					WithArgs(taskID, userID).
+				mock.ExpectQuery(`DELETE FROM todo.task`).
+					WithArgs(taskID, userID, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"project_id", "title", "status", "importance", "deadline", "version"}).
+						AddRow(projectID, "Task", "waiting", 1, time.Time{}, 2))
+
+				mock.ExpectExec(`INSERT INTO todo.outbox`).
+					WithArgs(sqlmock.AnyArg(), "task.deleted", projectID, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			expectedErr: false,
 		},
+		{
+			name:             "version mismatch",
+			taskID:           taskID,
+			userID:           userID,
+			expectedVersions: []int{staleVersion},
+			setupMocks: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT EXISTS`).
+					WithArgs(taskID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectQuery(`DELETE FROM todo.task`).
+					WithArgs(taskID, userID, pq.Array([]int{staleVersion})).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedErr: true,
+		},
 		{
 			name:   "task not found",
 			taskID: taskID,
 			userID: userID,
 			setupMocks: func() {
+				mock.ExpectBegin()
+
 				// Mock existence check - task doesn't exist
 				rows := sqlmock.NewRows([]string{"exists"}).AddRow(false)
 				mock.ExpectQuery(`SELECT EXISTS`).
 					WithArgs(taskID, userID).
 					WillReturnRows(rows)
+				mock.ExpectRollback()
 			},
 			expectedErr: true,
 		},
@@ -438,9 +701,11 @@ func TestTaskRepository_DeleteTask(t *testing.T) {
 			taskID: taskID,
 			userID: userID,
 			setupMocks: func() {
+				mock.ExpectBegin()
 				mock.ExpectQuery(`SELECT EXISTS`).
 					WithArgs(taskID, userID).
 					WillReturnError(errors.New("database connection error"))
+				mock.ExpectRollback()
 			},
 			expectedErr: true,
 		},
@@ -450,7 +715,7 @@ func TestTaskRepository_DeleteTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMocks()
 
-			err := repo.DeleteTask(ctx, tt.taskID, tt.userID)
+			err := repo.DeleteTask(ctx, tt.taskID, tt.userID, tt.expectedVersions)
 
 			if tt.expectedErr {
 				assert.Error(t, err)
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	models "github.com/lzimin05/course-todo/internal/models/task"
+	"github.com/lzimin05/course-todo/internal/taskquery"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	// $1 всегда ID пользователя: задачи берутся только из его проектов
+	queryTasksBase = `
+	SELECT t.id, t.project_id, t.user_id, t.title, t.description, t.importance, t.status, t.created_at, t.deadline, t.completed_at, t.version, t.estimate_minutes, t.start_at, t.all_day,
+		cl.done, cl.total
+	FROM todo.task t
+	JOIN todo.project_member pm ON t.project_id = pm.project_id
+	JOIN todo.project p ON p.id = t.project_id
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) FILTER (WHERE ci.done) AS done, COUNT(*) AS total
+		FROM todo.task_checklist_item ci WHERE ci.task_id = t.id
+	) cl
+	WHERE pm.user_id = $1 AND `
+
+	queryTasksOrder = `
+	ORDER BY t.created_at DESC, t.id`
+
+	noDeadline = `'0001-01-01'::timestamp`
+)
+
+type TaskQueryRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *TaskQueryRepository {
+	return &TaskQueryRepository{db: db}
+}
+
+func (r *TaskQueryRepository) SearchTasks(ctx context.Context, query taskquery.Node, params taskquery.Params) ([]*models.Task, error) {
+	const op = "TaskQueryRepository.SearchTasks"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", params.UserID)
+
+	sqlQuery, args := Compile(query, params)
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		logger.WithError(err).Error("failed to search tasks")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []*models.Task
+	for rows.Next() {
+		var t models.Task
+		err := rows.Scan(&t.ID, &t.ProjectID, &t.UserID, &t.Title, &t.Description, &t.Importance, &t.Status, &t.CreatedAt, &t.Deadline, &t.CompletedAt, &t.Version, &t.EstimateMinutes, &t.StartAt, &t.AllDay, &t.ChecklistDone, &t.ChecklistTotal)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan task")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tasks = append(tasks, &t)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// Compile переводит дерево запроса в параметризованный SQL. Значения из запроса
+// никогда не попадают в текст SQL, только в аргументы
+func Compile(query taskquery.Node, params taskquery.Params) (string, []interface{}) {
+	loc := params.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	c := &compiler{
+		args: []interface{}{params.UserID},
+		now:  params.Now.In(loc),
+	}
+	where := c.compile(query)
+
+	limit := c.arg(params.Limit)
+	offset := c.arg(params.Offset)
+
+	return queryTasksBase + where + queryTasksOrder + "\n\tLIMIT " + limit + " OFFSET " + offset, c.args
+}
+
+type compiler struct {
+	args []interface{}
+	now  time.Time
+}
+
+func (c *compiler) arg(value interface{}) string {
+	c.args = append(c.args, value)
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+func (c *compiler) compile(node taskquery.Node) string {
+	switch n := node.(type) {
+	case *taskquery.And:
+		return "(" + c.compile(n.Left) + " AND " + c.compile(n.Right) + ")"
+	case *taskquery.Or:
+		return "(" + c.compile(n.Left) + " OR " + c.compile(n.Right) + ")"
+	case *taskquery.Not:
+		return "NOT " + c.compile(n.Expr)
+	case *taskquery.Text:
+		pattern := c.arg("%" + escapeLike(n.Value) + "%")
+		return "(t.title ILIKE " + pattern + " OR t.description ILIKE " + pattern + ")"
+	case *taskquery.Comparison:
+		return c.comparison(n)
+	default:
+		// Parse возвращает только перечисленные узлы
+		panic(fmt.Sprintf("taskquery: unexpected node %T", node))
+	}
+}
+
+func (c *compiler) comparison(n *taskquery.Comparison) string {
+	switch n.Field {
+	case taskquery.FieldStatus:
+		return "(t.status = " + c.arg(n.Raw) + ")"
+	case taskquery.FieldImportance:
+		return "(t.importance " + sqlOp(n.Op) + " " + c.arg(n.Int) + ")"
+	case taskquery.FieldProject:
+		return "(p.name ILIKE " + c.arg(escapeLike(n.Raw)) + ")"
+	case taskquery.FieldAuthor:
+		if strings.EqualFold(n.Raw, "me") {
+			return "(t.user_id = $1)"
+		}
+		return `(t.user_id IN (SELECT u.id FROM todo."user" u WHERE u.login = ` + c.arg(n.Raw) + "))"
+	case taskquery.FieldDue:
+		if n.Date.None {
+			return "(t.deadline <= " + noDeadline + ")"
+		}
+		// Задачи без дедлайна не попадают ни в какой диапазон дат
+		return "(t.deadline > " + noDeadline + " AND " + c.dateRange("t.deadline", n) + ")"
+	case taskquery.FieldCreated:
+		return "(" + c.dateRange("t.created_at", n) + ")"
+	default:
+		panic(fmt.Sprintf("taskquery: unexpected field %q", n.Field))
+	}
+}
+
+// dateRange сравнивает колонку с днем [start, end): due<D - раньше начала дня,
+// due<=D - раньше конца дня, due:D - в течение дня
+func (c *compiler) dateRange(column string, n *taskquery.Comparison) string {
+	start := c.resolve(n.Date)
+	end := start.AddDate(0, 0, 1)
+
+	switch n.Op {
+	case taskquery.OpLt:
+		return column + " < " + c.arg(start.UTC())
+	case taskquery.OpLte:
+		return column + " < " + c.arg(end.UTC())
+	case taskquery.OpGt:
+		return column + " >= " + c.arg(end.UTC())
+	case taskquery.OpGte:
+		return column + " >= " + c.arg(start.UTC())
+	default:
+		return column + " >= " + c.arg(start.UTC()) + " AND " + column + " < " + c.arg(end.UTC())
+	}
+}
+
+// resolve возвращает начало дня в часовом поясе пользователя
+func (c *compiler) resolve(date taskquery.DateValue) time.Time {
+	loc := c.now.Location()
+	if date.Absolute {
+		return time.Date(date.Date.Year(), date.Date.Month(), date.Date.Day(), 0, 0, 0, 0, loc)
+	}
+
+	today := time.Date(c.now.Year(), c.now.Month(), c.now.Day(), 0, 0, 0, 0, loc)
+	switch date.Unit {
+	case taskquery.UnitWeek:
+		return today.AddDate(0, 0, 7*date.Offset)
+	case taskquery.UnitMonth:
+		return today.AddDate(0, date.Offset, 0)
+	default:
+		return today.AddDate(0, 0, date.Offset)
+	}
+}
+
+func sqlOp(op taskquery.Op) string {
+	if op == taskquery.OpEq {
+		return "="
+	}
+	return string(op)
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/taskquery"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+func mustParse(t testing.TB, query string) taskquery.Node {
+	node, err := taskquery.Parse(query)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", query, err)
+	}
+	return node
+}
+
+func TestCompile(t *testing.T) {
+	userID := uuid.New()
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+
+	// В Москве уже 11 марта
+	now := time.Date(2025, 3, 10, 22, 30, 0, 0, time.UTC)
+	params := taskquery.Params{UserID: userID, Now: now, Location: moscow, Limit: 20, Offset: 0}
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedWhere string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "status and importance",
+			query:         "status:in_progress importance>=2",
+			expectedWhere: "((t.status = $2) AND (t.importance >= $3))",
+			expectedArgs:  []interface{}{"in_progress", 2},
+		},
+		{
+			name:          "relative due date in user time zone",
+			query:         "due<+3d",
+			expectedWhere: "(t.deadline > '0001-01-01'::timestamp AND t.deadline < $2)",
+			expectedArgs:  []interface{}{time.Date(2025, 3, 13, 21, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:          "due on a day",
+			query:         "due:2025-04-01",
+			expectedWhere: "(t.deadline > '0001-01-01'::timestamp AND t.deadline >= $2 AND t.deadline < $3)",
+			expectedArgs: []interface{}{
+				time.Date(2025, 3, 31, 21, 0, 0, 0, time.UTC),
+				time.Date(2025, 4, 1, 21, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:          "no deadline",
+			query:         "due:none",
+			expectedWhere: "(t.deadline <= '0001-01-01'::timestamp)",
+			expectedArgs:  []interface{}{},
+		},
+		{
+			name:          "project negation and text",
+			query:         `project:"Back_end" -author:me "50% done"`,
+			expectedWhere: `(((p.name ILIKE $2) AND NOT (t.user_id = $1)) AND (t.title ILIKE $3 OR t.description ILIKE $3))`,
+			expectedArgs:  []interface{}{`Back\_end`, `%50\% done%`},
+		},
+		{
+			name:          "author by login",
+			query:         "author:ivan OR created>-1w",
+			expectedWhere: `((t.user_id IN (SELECT u.id FROM todo."user" u WHERE u.login = $2)) OR (t.created_at >= $3))`,
+			expectedArgs:  []interface{}{"ivan", time.Date(2025, 3, 4, 21, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlQuery, args := Compile(mustParse(t, tt.query), params)
+
+			assert.Contains(t, sqlQuery, "WHERE pm.user_id = $1 AND "+tt.expectedWhere+"\n")
+			assert.Equal(t, userID, args[0])
+			assert.Equal(t, tt.expectedArgs, args[1:len(args)-2])
+			assert.Equal(t, []interface{}{20, 0}, args[len(args)-2:])
+		})
+	}
+}
+
+func TestTaskQueryRepository_SearchTasks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	params := taskquery.Params{UserID: userID, Now: time.Now(), Location: time.UTC, Limit: 20}
+	query := mustParse(t, "status:waiting")
+
+	t.Run("successful search", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "project_id", "user_id", "title", "description", "importance", "status", "created_at", "deadline", "completed_at", "version", "estimate_minutes", "start_at", "all_day", "done", "total"}).
+			AddRow(uuid.New(), uuid.New(), userID, "Задача", "", 2, "waiting", time.Now(), time.Time{}, nil, 1, nil, nil, false, 0, 0)
+		mock.ExpectQuery(regexp.QuoteMeta("(t.status = $2)")).
+			WithArgs(userID, "waiting", 20, 0).
+			WillReturnRows(rows)
+
+		tasks, err := repo.SearchTasks(ctx, query, params)
+
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("(t.status = $2)")).
+			WillReturnError(errors.New("database error"))
+
+		tasks, err := repo.SearchTasks(ctx, query, params)
+
+		assert.Error(t, err)
+		assert.Nil(t, tasks)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+var placeholderRe = regexp.MustCompile(`\$(\d+)`)
+
+func FuzzCompile(f *testing.F) {
+	f.Add(`status:in_progress importance>=2 due<+3d project:"Backend" "login bug"`)
+	f.Add(`-(author:me OR created>=2025-01-31) due:none`)
+	f.Add(`project:"'; DROP TABLE todo.task; --"`)
+
+	params := taskquery.Params{UserID: uuid.New(), Now: time.Now(), Location: time.UTC, Limit: 20}
+
+	f.Fuzz(func(t *testing.T, query string) {
+		node, err := taskquery.Parse(query)
+		if err != nil {
+			return
+		}
+
+		sqlQuery, args := Compile(node, params)
+
+		// Каждый аргумент используется, и нет ссылок на несуществующие аргументы
+		used := make(map[int]bool)
+		for _, m := range placeholderRe.FindAllStringSubmatch(sqlQuery, -1) {
+			n, _ := strconv.Atoi(m[1])
+			if n < 1 || n > len(args) {
+				t.Fatalf("placeholder $%d out of range for %d args in %q", n, len(args), sqlQuery)
+			}
+			used[n] = true
+		}
+		if len(used) != len(args) {
+			t.Fatalf("%d args but %d placeholders used in %q", len(args), len(used), sqlQuery)
+		}
+
+		// Пользовательский текст попадает только в аргументы
+		if strings.Contains(sqlQuery, ";") || strings.Contains(sqlQuery, "--") {
+			t.Fatalf("query text leaked into SQL: %q", sqlQuery)
+		}
+	})
+}
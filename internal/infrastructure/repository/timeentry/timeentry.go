@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/timeentry"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	uniqueViolationCode = "23505"
+
+	queryGetTaskProject = `
+		SELECT t.project_id
+		FROM todo.task t
+		JOIN todo.project_member pm ON pm.project_id = t.project_id
+		WHERE t.id = $1 AND pm.user_id = $2`
+
+	// Второй запущенный таймер пользователя отсекает частичный уникальный индекс
+	queryCreateTimeEntry = `
+		INSERT INTO todo.time_entry (id, task_id, user_id, started_at, ended_at, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	queryStopTimer = `
+		UPDATE todo.time_entry SET ended_at = GREATEST($2, started_at)
+		WHERE user_id = $1 AND ended_at IS NULL
+		RETURNING id, task_id, user_id, started_at, ended_at, note, created_at`
+
+	queryGetRunningTimer = `
+		SELECT id, task_id, user_id, started_at, ended_at, note, created_at
+		FROM todo.time_entry
+		WHERE user_id = $1 AND ended_at IS NULL`
+
+	queryGetTaskTimeEntries = `
+		SELECT id, task_id, user_id, started_at, ended_at, note, created_at
+		FROM todo.time_entry
+		WHERE task_id = $1
+		ORDER BY started_at DESC, id`
+
+	queryDeleteTimeEntry = `
+		DELETE FROM todo.time_entry WHERE id = $1 AND user_id = $2`
+
+	// Запись относится к дню своего начала в часовом поясе $4.
+	// Запущенные таймеры в отчет не попадают
+	queryGetTimeReport = `
+		SELECT p.id, p.name, u.id, u.login,
+			(te.started_at AT TIME ZONE 'UTC' AT TIME ZONE $4)::date AS day,
+			SUM(EXTRACT(EPOCH FROM te.ended_at - te.started_at))::bigint
+		FROM todo.time_entry te
+		JOIN todo.task t ON t.id = te.task_id
+		JOIN todo.project p ON p.id = t.project_id
+		JOIN todo."user" u ON u.id = te.user_id
+		WHERE t.project_id IN (
+			SELECT pm.project_id FROM todo.project_member pm WHERE pm.user_id = $1
+		)
+			AND ($5::uuid IS NULL OR t.project_id = $5)
+			AND te.ended_at IS NOT NULL
+			AND te.started_at >= $2 AND te.started_at < $3
+		GROUP BY p.id, p.name, u.id, u.login, day
+		ORDER BY p.name, p.id, u.login, day`
+)
+
+type TimeEntryRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{db: db}
+}
+
+// GetTaskProject возвращает проект задачи, если пользователь в нем участвует
+func (r *TimeEntryRepository) GetTaskProject(ctx context.Context, taskID, userID uuid.UUID) (uuid.UUID, error) {
+	const op = "TimeEntryRepository.GetTaskProject"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("taskID", taskID)
+
+	var projectID uuid.UUID
+	err := r.db.QueryRowContext(ctx, queryGetTaskProject, taskID, userID).Scan(&projectID)
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Warn("task not found or not accessible")
+		return uuid.Nil, errs.ErrTaskNotFound
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to get task project")
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return projectID, nil
+}
+
+// CreateTimeEntry сохраняет запись. Запись без EndedAt - запущенный таймер
+func (r *TimeEntryRepository) CreateTimeEntry(ctx context.Context, entry *models.TimeEntry) error {
+	const op = "TimeEntryRepository.CreateTimeEntry"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("taskID", entry.TaskID).
+		WithField("userID", entry.UserID)
+
+	_, err := r.db.ExecContext(ctx, queryCreateTimeEntry,
+		entry.ID, entry.TaskID, entry.UserID, entry.StartedAt, entry.EndedAt, entry.Note, entry.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolationCode {
+			logger.Warn("timer is already running")
+			return errs.ErrTimerRunning
+		}
+		logger.WithError(err).Error("failed to create time entry")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *TimeEntryRepository) StopTimer(ctx context.Context, userID uuid.UUID, endedAt time.Time) (*models.TimeEntry, error) {
+	const op = "TimeEntryRepository.StopTimer"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	entry, err := scanTimeEntry(r.db.QueryRowContext(ctx, queryStopTimer, userID, endedAt))
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Warn("no running timer")
+		return nil, errs.NewNotFoundError("no running timer")
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to stop timer")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+func (r *TimeEntryRepository) GetRunningTimer(ctx context.Context, userID uuid.UUID) (*models.TimeEntry, error) {
+	const op = "TimeEntryRepository.GetRunningTimer"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	entry, err := scanTimeEntry(r.db.QueryRowContext(ctx, queryGetRunningTimer, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NewNotFoundError("no running timer")
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to get running timer")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entry, nil
+}
+
+func (r *TimeEntryRepository) GetTaskTimeEntries(ctx context.Context, taskID uuid.UUID) ([]models.TimeEntry, error) {
+	const op = "TimeEntryRepository.GetTaskTimeEntries"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("taskID", taskID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetTaskTimeEntries, taskID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get time entries")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var entries []models.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan time entry")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+// DeleteTimeEntry удаляет запись. Удалить можно только свою запись
+func (r *TimeEntryRepository) DeleteTimeEntry(ctx context.Context, entryID, userID uuid.UUID) error {
+	const op = "TimeEntryRepository.DeleteTimeEntry"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("entryID", entryID)
+
+	result, err := r.db.ExecContext(ctx, queryDeleteTimeEntry, entryID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete time entry")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		logger.Warn("time entry not found")
+		return errs.NewNotFoundError("time entry not found")
+	}
+
+	return nil
+}
+
+// GetTimeReport суммирует завершенные записи по проектам, участникам и дням
+// в проектах пользователя. from и to - границы периода в UTC, [from, to)
+func (r *TimeEntryRepository) GetTimeReport(ctx context.Context, userID uuid.UUID, projectID *uuid.UUID, from, to time.Time, timezone string) ([]models.ReportRow, error) {
+	const op = "TimeEntryRepository.GetTimeReport"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetTimeReport, userID, from, to, timezone, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get time report")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var report []models.ReportRow
+	for rows.Next() {
+		var row models.ReportRow
+		if err := rows.Scan(&row.ProjectID, &row.ProjectName, &row.UserID, &row.Login, &row.Day, &row.Seconds); err != nil {
+			logger.WithError(err).Error("failed to scan report row")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		report = append(report, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return report, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTimeEntry(s rowScanner) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := s.Scan(&entry.ID, &entry.TaskID, &entry.UserID, &entry.StartedAt, &entry.EndedAt, &entry.Note, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/timeentry"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+var timeEntryColumns = []string{"id", "task_id", "user_id", "started_at", "ended_at", "note", "created_at"}
+
+func TestTimeEntryRepository_CreateTimeEntry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	entry := &models.TimeEntry{
+		ID:        uuid.New(),
+		TaskID:    uuid.New(),
+		UserID:    uuid.New(),
+		StartedAt: time.Now().UTC(),
+		Note:      "Созвон",
+		CreatedAt: time.Now().UTC(),
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "timer started",
+			setupMocks: func() {
+				mock.ExpectExec(`INSERT INTO todo.time_entry`).
+					WithArgs(entry.ID, entry.TaskID, entry.UserID, entry.StartedAt, nil, "Созвон", entry.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "second running timer",
+			setupMocks: func() {
+				mock.ExpectExec(`INSERT INTO todo.time_entry`).
+					WillReturnError(&pq.Error{Code: uniqueViolationCode})
+			},
+			expectedErr: errs.ErrTimerRunning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := repo.CreateTimeEntry(ctx, entry)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTimeEntryRepository_StopTimer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	startedAt := time.Now().UTC().Add(-time.Hour)
+	endedAt := time.Now().UTC()
+
+	t.Run("running timer", func(t *testing.T) {
+		mock.ExpectQuery(`UPDATE todo.time_entry SET ended_at`).
+			WithArgs(userID, endedAt).
+			WillReturnRows(sqlmock.NewRows(timeEntryColumns).
+				AddRow(uuid.New(), uuid.New(), userID, startedAt, endedAt, "", startedAt))
+
+		entry, err := repo.StopTimer(ctx, userID, endedAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, endedAt, *entry.EndedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no running timer", func(t *testing.T) {
+		mock.ExpectQuery(`UPDATE todo.time_entry SET ended_at`).
+			WithArgs(userID, endedAt).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.StopTimer(ctx, userID, endedAt)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTimeEntryRepository_GetTaskProject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	taskID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectQuery(`SELECT t.project_id`).
+		WithArgs(taskID, userID).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetTaskProject(ctx, taskID, userID)
+
+	assert.ErrorIs(t, err, errs.ErrTaskNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeEntryRepository_DeleteTimeEntry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	entryID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectExec(`DELETE FROM todo.time_entry`).
+		WithArgs(entryID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteTimeEntry(ctx, entryID, userID)
+
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTimeEntryRepository_GetTimeReport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`AT TIME ZONE 'UTC' AT TIME ZONE \$4`).
+		WithArgs(userID, from, to, "Europe/Moscow", &projectID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "id", "login", "day", "sum"}).
+			AddRow(projectID, "Backend", userID, "ivan", day, 5400))
+
+	rows, err := repo.GetTimeReport(ctx, userID, &projectID, from, to, "Europe/Moscow")
+
+	assert.NoError(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, int64(5400), rows[0].Seconds)
+	assert.Equal(t, "ivan", rows[0].Login)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -18,7 +18,8 @@ const (
 		u.login,
 		u.username, 
 		u.email, 
-		u.password_hash
+		u.password_hash,
+		u.timezone
 	FROM todo.user u
 	WHERE u.id = $1;`
 
@@ -28,7 +29,8 @@ const (
 		u.login,
 		u.username, 
 		u.email, 
-		u.password_hash
+		u.password_hash,
+		u.timezone
 	FROM todo.user u
 	WHERE u.email = $1;`
 
@@ -38,7 +40,8 @@ const (
 		u.login,
 		u.username, 
 		u.email, 
-		u.password_hash
+		u.password_hash,
+		u.timezone
 	FROM todo.user u
 	WHERE u.login = $1;`
 
@@ -46,6 +49,11 @@ const (
 		UPDATE todo.user 
 		SET username = $2 
 		WHERE id = $1`
+
+	queryUpdateTimezone = `
+		UPDATE todo.user 
+		SET timezone = $2 
+		WHERE id = $1`
 )
 
 type UserRepository struct {
@@ -68,6 +76,7 @@ func (r *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*models
 		&user.Username,
 		&user.Email,
 		&user.PasswordHash,
+		&user.Timezone,
 	)
 	if err != nil {
 		logger.WithError(err).Warn("err get user by id")
@@ -92,6 +101,7 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*mod
 		&user.Username,
 		&user.Email,
 		&user.PasswordHash,
+		&user.Timezone,
 	)
 	if err != nil {
 		logger.WithError(err).Warn("err get user by email")
@@ -116,6 +126,7 @@ func (r *UserRepository) GetUserByLogin(ctx context.Context, login string) (*mod
 		&user.Username,
 		&user.Email,
 		&user.PasswordHash,
+		&user.Timezone,
 	)
 	if err != nil {
 		logger.WithError(err).Warn("err get user by login")
@@ -151,3 +162,27 @@ func (r *UserRepository) UpdateUsername(ctx context.Context, userID uuid.UUID, u
 
 	return nil
 }
+
+func (r *UserRepository) UpdateTimezone(ctx context.Context, userID uuid.UUID, timezone string) error {
+	const op = "UserRepository.UpdateTimezone"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("userID", userID)
+
+	result, err := r.db.ExecContext(ctx, queryUpdateTimezone, userID, timezone)
+	if err != nil {
+		logger.WithError(err).Error("failed to update timezone")
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return err
+	}
+
+	if rowsAffected == 0 {
+		logger.Warn("user not found")
+		return errs.ErrInvalidCredentials
+	}
+
+	return nil
+}
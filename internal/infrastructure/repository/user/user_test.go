@@ -35,8 +35,8 @@ func TestUserRepository_GetUserByID(t *testing.T) {
 			name:   "successful user retrieval by ID",
 			userID: userID,
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "login", "username", "email", "password_hash"}).
-					AddRow(userID, "testuser", "Test User", "test@example.com", []byte("hashedpassword"))
+				rows := sqlmock.NewRows([]string{"id", "login", "username", "email", "password_hash", "timezone"}).
+					AddRow(userID, "testuser", "Test User", "test@example.com", []byte("hashedpassword"), "UTC")
 
 				mock.ExpectQuery(`SELECT`).
 					WithArgs(userID).
@@ -119,8 +119,8 @@ func TestUserRepository_GetUserByEmail(t *testing.T) {
 			name:  "successful user retrieval by email",
 			email: "test@example.com",
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "login", "username", "email", "password_hash"}).
-					AddRow(userID, "testuser", "Test User", "test@example.com", []byte("hashedpassword"))
+				rows := sqlmock.NewRows([]string{"id", "login", "username", "email", "password_hash", "timezone"}).
+					AddRow(userID, "testuser", "Test User", "test@example.com", []byte("hashedpassword"), "UTC")
 
 				mock.ExpectQuery(`SELECT`).
 					WithArgs("test@example.com").
@@ -203,8 +203,8 @@ func TestUserRepository_GetUserByLogin(t *testing.T) {
 			name:  "successful user retrieval by login",
 			login: "testuser",
 			setupMocks: func() {
-				rows := sqlmock.NewRows([]string{"id", "login", "username", "email", "password_hash"}).
-					AddRow(userID, "testuser", "Test User", "test@example.com", []byte("hashedpassword"))
+				rows := sqlmock.NewRows([]string{"id", "login", "username", "email", "password_hash", "timezone"}).
+					AddRow(userID, "testuser", "Test User", "test@example.com", []byte("hashedpassword"), "UTC")
 
 				mock.ExpectQuery(`SELECT`).
 					WithArgs("testuser").
@@ -0,0 +1,365 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/webhook"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	webhookColumns = `id, project_id, created_by, url, secret, events, is_active, created_at, updated_at`
+
+	queryCreateWebhook = `
+	INSERT INTO todo.webhook (` + webhookColumns + `)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	queryGetWebhooks = `
+	SELECT ` + webhookColumns + `
+	FROM todo.webhook
+	WHERE project_id = $1
+	ORDER BY created_at, id`
+
+	queryGetWebhookByID = `
+	SELECT ` + webhookColumns + `
+	FROM todo.webhook
+	WHERE id = $1 AND project_id = $2`
+
+	queryUpdateWebhook = `
+	UPDATE todo.webhook
+	SET url = $3, events = $4, is_active = $5, updated_at = $6
+	WHERE id = $1 AND project_id = $2`
+
+	queryDeleteWebhook = `
+	DELETE FROM todo.webhook
+	WHERE id = $1 AND project_id = $2`
+
+	// Одна строка очереди на каждый активный вебхук проекта, подписанный на событие.
+	// Событие публикуется не реже одного раза, поэтому повтор того же event_id пропускается
+	queryEnqueueDeliveries = `
+	INSERT INTO todo.webhook_delivery (webhook_id, event_id, event_type, payload, next_attempt_at, created_at)
+	SELECT w.id, $2, $3, $4, $5, $5
+	FROM todo.webhook w
+	WHERE w.project_id = $1 AND w.is_active AND $3 = ANY(w.events)
+	ON CONFLICT (webhook_id, event_id) WHERE NOT redelivery DO NOTHING`
+
+	deliveryColumns = `d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+		d.next_attempt_at, d.last_attempt_at, d.response_code, COALESCE(d.last_error, ''), d.created_at, d.delivered_at`
+
+	queryGetDeliveries = `
+	SELECT ` + deliveryColumns + `
+	FROM todo.webhook_delivery d
+	WHERE d.webhook_id = $1 AND ($2 = '' OR d.status = $2)
+	ORDER BY d.created_at DESC, d.id
+	LIMIT $3`
+
+	// Повторная доставка - новая строка с тем же event_id, журнал прошлых попыток сохраняется
+	queryRedeliver = `
+	INSERT INTO todo.webhook_delivery (webhook_id, event_id, event_type, payload, next_attempt_at, created_at, redelivery)
+	SELECT d.webhook_id, d.event_id, d.event_type, d.payload, $3, $3, TRUE
+	FROM todo.webhook_delivery d
+	WHERE d.id = $1 AND d.webhook_id = $2
+	RETURNING id, webhook_id, event_id, event_type, payload, status, attempts,
+		next_attempt_at, last_attempt_at, response_code, COALESCE(last_error, ''), created_at, delivered_at`
+
+	// Воркер забирает созревшие доставки и сдвигает их на время аренды.
+	// SKIP LOCKED позволяет нескольким экземплярам работать с одной очередью
+	queryClaimDeliveries = `
+	UPDATE todo.webhook_delivery d
+	SET next_attempt_at = $2
+	FROM todo.webhook w
+	WHERE w.id = d.webhook_id AND d.id IN (
+		SELECT q.id
+		FROM todo.webhook_delivery q
+		JOIN todo.webhook qw ON qw.id = q.webhook_id AND qw.is_active
+		WHERE q.status = 'pending' AND q.next_attempt_at <= $1
+		ORDER BY q.next_attempt_at
+		LIMIT $3
+		FOR UPDATE OF q SKIP LOCKED
+	)
+	RETURNING ` + deliveryColumns + `, w.url, w.secret`
+
+	querySaveAttempt = `
+	UPDATE todo.webhook_delivery
+	SET status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5,
+		response_code = $6, last_error = NULLIF($7, ''), delivered_at = $8
+	WHERE id = $1`
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func New(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	const op = "WebhookRepository.CreateWebhook"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", webhook.ProjectID)
+
+	_, err := r.db.ExecContext(ctx, queryCreateWebhook,
+		webhook.ID, webhook.ProjectID, webhook.CreatedBy, webhook.URL, webhook.Secret,
+		pq.Array(webhook.Events), webhook.IsActive, webhook.CreatedAt, webhook.UpdatedAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to create webhook")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetWebhooks(ctx context.Context, projectID uuid.UUID) ([]models.Webhook, error) {
+	const op = "WebhookRepository.GetWebhooks"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetWebhooks, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get webhooks")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var webhooks []models.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan webhook")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) GetWebhookByID(ctx context.Context, webhookID, projectID uuid.UUID) (*models.Webhook, error) {
+	const op = "WebhookRepository.GetWebhookByID"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("webhookID", webhookID)
+
+	webhook, err := scanWebhook(r.db.QueryRowContext(ctx, queryGetWebhookByID, webhookID, projectID))
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Warn("webhook not found")
+		return nil, errs.NewNotFoundError("webhook not found")
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to get webhook")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+func (r *WebhookRepository) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	const op = "WebhookRepository.UpdateWebhook"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("webhookID", webhook.ID)
+
+	result, err := r.db.ExecContext(ctx, queryUpdateWebhook,
+		webhook.ID, webhook.ProjectID, webhook.URL, pq.Array(webhook.Events), webhook.IsActive, webhook.UpdatedAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to update webhook")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		logger.Warn("webhook not found")
+		return errs.NewNotFoundError("webhook not found")
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, webhookID, projectID uuid.UUID) error {
+	const op = "WebhookRepository.DeleteWebhook"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("webhookID", webhookID)
+
+	result, err := r.db.ExecContext(ctx, queryDeleteWebhook, webhookID, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to delete webhook")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if rowsAffected == 0 {
+		logger.Warn("webhook not found")
+		return errs.NewNotFoundError("webhook not found")
+	}
+
+	return nil
+}
+
+// EnqueueDeliveries ставит событие в очередь всех подписанных вебхуков проекта
+// и возвращает число созданных доставок. Повторный вызов с тем же eventID ничего не добавляет
+func (r *WebhookRepository) EnqueueDeliveries(ctx context.Context, projectID, eventID uuid.UUID, eventType string, payload []byte, now time.Time) (int64, error) {
+	const op = "WebhookRepository.EnqueueDeliveries"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", projectID).
+		WithField("eventType", eventType)
+
+	result, err := r.db.ExecContext(ctx, queryEnqueueDeliveries, projectID, eventID, eventType, payload, now)
+	if err != nil {
+		logger.WithError(err).Error("failed to enqueue deliveries")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		logger.WithError(err).Error("failed to get rows affected")
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return count, nil
+}
+
+// GetDeliveries возвращает журнал доставок вебхука, новые сначала.
+// Пустой status - доставки в любом статусе
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, webhookID uuid.UUID, status string, limit int) ([]models.Delivery, error) {
+	const op = "WebhookRepository.GetDeliveries"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("webhookID", webhookID)
+
+	rows, err := r.db.QueryContext(ctx, queryGetDeliveries, webhookID, status, limit)
+	if err != nil {
+		logger.WithError(err).Error("failed to get deliveries")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.Delivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			logger.WithError(err).Error("failed to scan delivery")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver ставит в очередь копию доставки
+func (r *WebhookRepository) Redeliver(ctx context.Context, deliveryID, webhookID uuid.UUID, now time.Time) (*models.Delivery, error) {
+	const op = "WebhookRepository.Redeliver"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("deliveryID", deliveryID)
+
+	delivery, err := scanDelivery(r.db.QueryRowContext(ctx, queryRedeliver, deliveryID, webhookID, now))
+	if errors.Is(err, sql.ErrNoRows) {
+		logger.Warn("delivery not found")
+		return nil, errs.NewNotFoundError("delivery not found")
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to redeliver")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return delivery, nil
+}
+
+// ClaimDeliveries забирает до limit доставок, срок которых наступил к now.
+// До leaseUntil их не возьмет другой воркер
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Job, error) {
+	const op = "WebhookRepository.ClaimDeliveries"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	rows, err := r.db.QueryContext(ctx, queryClaimDeliveries, now, leaseUntil, limit)
+	if err != nil {
+		logger.WithError(err).Error("failed to claim deliveries")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var jobs []models.Job
+	for rows.Next() {
+		var job models.Job
+		if err := scanDeliveryInto(rows, &job.Delivery, &job.URL, &job.Secret); err != nil {
+			logger.WithError(err).Error("failed to scan delivery")
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.WithError(err).Error("rows iteration error")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return jobs, nil
+}
+
+// SaveAttempt сохраняет результат попытки доставки
+func (r *WebhookRepository) SaveAttempt(ctx context.Context, delivery *models.Delivery) error {
+	const op = "WebhookRepository.SaveAttempt"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("deliveryID", delivery.ID)
+
+	_, err := r.db.ExecContext(ctx, querySaveAttempt,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastAttemptAt,
+		delivery.ResponseCode, delivery.LastError, delivery.DeliveredAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to save delivery attempt")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWebhook(row rowScanner) (*models.Webhook, error) {
+	var w models.Webhook
+	err := row.Scan(&w.ID, &w.ProjectID, &w.CreatedBy, &w.URL, &w.Secret,
+		pq.Array(&w.Events), &w.IsActive, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func scanDelivery(row rowScanner) (*models.Delivery, error) {
+	var d models.Delivery
+	if err := scanDeliveryInto(row, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func scanDeliveryInto(row rowScanner, d *models.Delivery, extra ...interface{}) error {
+	var responseCode sql.NullInt32
+	dest := []interface{}{&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastAttemptAt, &responseCode, &d.LastError, &d.CreatedAt, &d.DeliveredAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	if responseCode.Valid {
+		code := int(responseCode.Int32)
+		d.ResponseCode = &code
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/webhook"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+var deliveryRowColumns = []string{"id", "webhook_id", "event_id", "event_type", "payload", "status", "attempts",
+	"next_attempt_at", "last_attempt_at", "response_code", "last_error", "created_at", "delivered_at"}
+
+func TestWebhookRepository_CreateWebhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Now()
+	webhook := &models.Webhook{
+		ID:        uuid.New(),
+		ProjectID: uuid.New(),
+		CreatedBy: uuid.New(),
+		URL:       "https://example.com/hook",
+		Secret:    "secret",
+		Events:    []string{"task.created"},
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr bool
+	}{
+		{
+			name: "successful creation",
+			setupMocks: func() {
+				mock.ExpectExec(`INSERT INTO todo.webhook`).
+					WithArgs(webhook.ID, webhook.ProjectID, webhook.CreatedBy, webhook.URL, webhook.Secret,
+						pq.Array(webhook.Events), true, now, now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "database error",
+			setupMocks: func() {
+				mock.ExpectExec(`INSERT INTO todo.webhook`).WillReturnError(errors.New("db error"))
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			err := repo.CreateWebhook(ctx, webhook)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookRepository_GetWebhookByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	webhookID := uuid.New()
+	projectID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "found",
+			setupMocks: func() {
+				rows := sqlmock.NewRows([]string{"id", "project_id", "created_by", "url", "secret", "events",
+					"is_active", "created_at", "updated_at"}).
+					AddRow(webhookID, projectID, uuid.New(), "https://example.com/hook", "secret",
+						"{task.created,note.updated}", true, now, now)
+				mock.ExpectQuery(`SELECT .+ FROM todo.webhook`).
+					WithArgs(webhookID, projectID).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "not found",
+			setupMocks: func() {
+				mock.ExpectQuery(`SELECT .+ FROM todo.webhook`).
+					WithArgs(webhookID, projectID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			webhook, err := repo.GetWebhookByID(ctx, webhookID, projectID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, webhook)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"task.created", "note.updated"}, webhook.Events)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookRepository_DeleteWebhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	webhookID := uuid.New()
+	projectID := uuid.New()
+
+	mock.ExpectExec(`DELETE FROM todo.webhook`).
+		WithArgs(webhookID, projectID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.DeleteWebhook(ctx, webhookID, projectID)
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepository_EnqueueDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	eventID := uuid.New()
+	payload := []byte(`{"type":"task.created"}`)
+	now := time.Now()
+
+	mock.ExpectExec(`INSERT INTO todo.webhook_delivery .+ FROM todo.webhook w .+ ON CONFLICT \(webhook_id, event_id\) WHERE NOT redelivery DO NOTHING`).
+		WithArgs(projectID, eventID, "task.created", payload, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	count, err := repo.EnqueueDeliveries(ctx, projectID, eventID, "task.created", payload, now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepository_Redeliver(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	deliveryID := uuid.New()
+	webhookID := uuid.New()
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "successful redelivery",
+			setupMocks: func() {
+				rows := sqlmock.NewRows(deliveryRowColumns).
+					AddRow(uuid.New(), webhookID, uuid.New(), "task.created", []byte(`{}`), "pending", 0,
+						now, nil, nil, "", now, nil)
+				mock.ExpectQuery(`INSERT INTO todo.webhook_delivery .+ RETURNING`).
+					WithArgs(deliveryID, webhookID, now).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "delivery not found",
+			setupMocks: func() {
+				mock.ExpectQuery(`INSERT INTO todo.webhook_delivery .+ RETURNING`).
+					WithArgs(deliveryID, webhookID, now).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			delivery, err := repo.Redeliver(ctx, deliveryID, webhookID, now)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.DeliveryStatusPending, delivery.Status)
+				assert.Nil(t, delivery.ResponseCode)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookRepository_ClaimDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Now()
+	lease := now.Add(time.Minute)
+	deliveryID := uuid.New()
+	lastAttempt := now.Add(-time.Minute)
+
+	rows := sqlmock.NewRows(append(deliveryRowColumns, "url", "secret")).
+		AddRow(deliveryID, uuid.New(), uuid.New(), "task.created", []byte(`{}`), "pending", 1,
+			now, lastAttempt, 500, "HTTP 500", now, nil, "https://example.com/hook", "secret")
+	mock.ExpectQuery(`UPDATE todo.webhook_delivery d .+ FOR UPDATE OF q SKIP LOCKED`).
+		WithArgs(now, lease, 10).
+		WillReturnRows(rows)
+
+	jobs, err := repo.ClaimDeliveries(ctx, now, lease, 10)
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, deliveryID, jobs[0].ID)
+	assert.Equal(t, "https://example.com/hook", jobs[0].URL)
+	assert.Equal(t, "secret", jobs[0].Secret)
+	assert.Equal(t, 500, *jobs[0].ResponseCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepository_SaveAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := New(db)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Now()
+	code := 200
+	delivery := &models.Delivery{
+		ID:            uuid.New(),
+		Status:        models.DeliveryStatusDelivered,
+		Attempts:      1,
+		NextAttemptAt: now,
+		LastAttemptAt: &now,
+		ResponseCode:  &code,
+		DeliveredAt:   &now,
+	}
+
+	mock.ExpectExec(`UPDATE todo.webhook_delivery`).
+		WithArgs(delivery.ID, "delivered", 1, now, &now, &code, "", &now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SaveAttempt(ctx, delivery)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+)
+
+// LocalFilesPath - префикс адресов, по которым LocalStorage отдает файлы по подписанным ссылкам
+const LocalFilesPath = "/api/attachments/files/"
+
+// LocalStorage хранит файлы в каталоге на диске. Ссылки на скачивание
+// подписываются HMAC и обслуживаются самим приложением
+type LocalStorage struct {
+	root       string
+	publicURL  string
+	signingKey []byte
+	now        func() time.Time
+}
+
+func NewLocalStorage(cfg *config.StorageConfig) (*LocalStorage, error) {
+	root, err := filepath.Abs(cfg.LocalPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage path: %v", err)
+	}
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %v", err)
+	}
+
+	return &LocalStorage{
+		root:       root,
+		publicURL:  cfg.PublicURL,
+		signingKey: []byte(cfg.SigningKey),
+		now:        time.Now,
+	}, nil
+}
+
+// Put записывает файл во временный файл рядом с целевым и переименовывает его,
+// поэтому прерванная загрузка не оставляет обрезанных файлов
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errs.NewNotFoundError("blob not found")
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	return f, nil
+}
+
+// Delete удаляет файл. Отсутствующий файл ошибкой не считается
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+// SignedURL возвращает ссылку на скачивание, которая действует ttl
+func (s *LocalStorage) SignedURL(ctx context.Context, key, fileName, contentType string, ttl time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("name", fileName)
+	query.Set("type", contentType)
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, fileName, contentType, expires))
+
+	return s.publicURL + LocalFilesPath + key + "?" + query.Encode(), nil
+}
+
+// VerifySignedURL проверяет подпись и срок действия ссылки и возвращает
+// имя и тип файла, зашитые в ссылку
+func (s *LocalStorage) VerifySignedURL(key string, query url.Values) (string, string, error) {
+	fileName := query.Get("name")
+	contentType := query.Get("type")
+	expires := query.Get("expires")
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: invalid expires", errs.ErrNoAccess)
+	}
+
+	if s.now().Unix() > expiresAt {
+		return "", "", fmt.Errorf("%w: link expired", errs.ErrNoAccess)
+	}
+
+	expected := s.sign(key, fileName, contentType, expires)
+	if !hmac.Equal([]byte(expected), []byte(query.Get("signature"))) {
+		return "", "", fmt.Errorf("%w: invalid signature", errs.ErrNoAccess)
+	}
+
+	return fileName, contentType, nil
+}
+
+func (s *LocalStorage) sign(key, fileName, contentType, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(strings.Join([]string{key, fileName, contentType, expires}, "\n")))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// path переводит ключ в путь внутри корневого каталога
+func (s *LocalStorage) path(key string) (string, error) {
+	if key == "" || strings.Contains(key, "..") || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
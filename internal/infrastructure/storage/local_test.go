@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+)
+
+func newTestLocalStorage(t *testing.T) *LocalStorage {
+	s, err := NewLocalStorage(&config.StorageConfig{
+		LocalPath:  t.TempDir(),
+		PublicURL:  "http://localhost:8080",
+		SigningKey: "secret",
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestLocalStorage_PutGetDelete(t *testing.T) {
+	s := newTestLocalStorage(t)
+	ctx := context.Background()
+
+	err := s.Put(ctx, "projects/p1/a1", strings.NewReader("hello"), "text/plain")
+	require.NoError(t, err)
+
+	r, err := s.Get(ctx, "projects/p1/a1")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	r.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	assert.NoError(t, s.Delete(ctx, "projects/p1/a1"))
+	assert.NoError(t, s.Delete(ctx, "projects/p1/a1"))
+
+	_, err = s.Get(ctx, "projects/p1/a1")
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+}
+
+func TestLocalStorage_RejectsKeysOutsideRoot(t *testing.T) {
+	s := newTestLocalStorage(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"", "../etc/passwd", "/etc/passwd", "a/../../b"} {
+		assert.Error(t, s.Put(ctx, key, strings.NewReader("x"), "text/plain"), key)
+	}
+}
+
+func TestLocalStorage_FailedPutLeavesNoFile(t *testing.T) {
+	s := newTestLocalStorage(t)
+	ctx := context.Background()
+
+	err := s.Put(ctx, "projects/p1/a1", io.MultiReader(strings.NewReader("part"), errReader{}), "text/plain")
+	assert.Error(t, err)
+
+	_, err = s.Get(ctx, "projects/p1/a1")
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+}
+
+func TestLocalStorage_SignedURL(t *testing.T) {
+	s := newTestLocalStorage(t)
+	now := time.Unix(1700000000, 0)
+	s.now = func() time.Time { return now }
+
+	link, err := s.SignedURL(context.Background(), "projects/p1/a1", "report.pdf", "application/pdf", time.Minute)
+	require.NoError(t, err)
+
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, LocalFilesPath+"projects/p1/a1", u.Path)
+
+	key := strings.TrimPrefix(u.Path, LocalFilesPath)
+
+	name, contentType, err := s.VerifySignedURL(key, u.Query())
+	assert.NoError(t, err)
+	assert.Equal(t, "report.pdf", name)
+	assert.Equal(t, "application/pdf", contentType)
+
+	tampered := u.Query()
+	tampered.Set("type", "text/html")
+	_, _, err = s.VerifySignedURL(key, tampered)
+	assert.ErrorIs(t, err, errs.ErrNoAccess)
+
+	_, _, err = s.VerifySignedURL("projects/p1/other", u.Query())
+	assert.ErrorIs(t, err, errs.ErrNoAccess)
+
+	now = now.Add(2 * time.Minute)
+	_, _, err = s.VerifySignedURL(key, u.Query())
+	assert.ErrorIs(t, err, errs.ErrNoAccess)
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, io.ErrClosedPipe
+}
@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/webhook"
+)
+
+const (
+	userAgent = "course-todo-webhooks"
+
+	// Тело ответа получателя не нужно, но дочитываем его немного, чтобы
+	// соединение вернулось в пул
+	maxResponseDrain = 4 << 10
+)
+
+var errBlockedAddress = errors.New("webhook address is not public")
+
+// HTTPSender доставляет события POST-запросом с JSON-телом и подписью HMAC-SHA256
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(cfg *config.WebhookConfig) *HTTPSender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !cfg.AllowPrivateNetworks {
+		// Адрес проверяется после разрешения имени, прямо перед соединением:
+		// так имя не подменить на внутренний адрес между проверкой и отправкой.
+		// Через прокси адрес получателя проверить нельзя, поэтому он не используется
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   checkAddress,
+		}
+		transport.DialContext = dialer.DialContext
+		transport.Proxy = nil
+	}
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+			// Перенаправления не выполняются: подпись относится к исходному адресу
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func checkAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !models.IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", errBlockedAddress, host)
+	}
+	return nil
+}
+
+func (s *HTTPSender) Send(ctx context.Context, job models.Job) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(job.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(models.HeaderEvent, job.EventType)
+	req.Header.Set(models.HeaderEventID, job.EventID.String())
+	req.Header.Set(models.HeaderDelivery, job.ID.String())
+	req.Header.Set(models.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(models.HeaderSignature, models.Sign(job.Secret, timestamp, job.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseDrain))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/webhook"
+)
+
+func TestHTTPSender_Send(t *testing.T) {
+	job := models.Job{
+		Delivery: models.Delivery{
+			ID:        uuid.New(),
+			EventID:   uuid.New(),
+			EventType: "task.created",
+			Payload:   []byte(`{"type":"task.created"}`),
+		},
+		Secret: "secret",
+	}
+
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	job.URL = receiver.URL
+
+	sender := NewHTTPSender(&config.WebhookConfig{Timeout: time.Second, AllowPrivateNetworks: true})
+	code, err := sender.Send(context.Background(), job)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "task.created", received.Header.Get(models.HeaderEvent))
+	assert.Equal(t, job.EventID.String(), received.Header.Get(models.HeaderEventID))
+	assert.Equal(t, job.ID.String(), received.Header.Get(models.HeaderDelivery))
+	assert.Equal(t, job.Payload, body)
+
+	timestamp, err := strconv.ParseInt(received.Header.Get(models.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.True(t, models.Verify("secret", timestamp, body, received.Header.Get(models.HeaderSignature)))
+	assert.False(t, models.Verify("other", timestamp, body, received.Header.Get(models.HeaderSignature)))
+}
+
+func TestHTTPSender_SendFailures(t *testing.T) {
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://example.com", http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	sender := NewHTTPSender(&config.WebhookConfig{Timeout: 50 * time.Millisecond, AllowPrivateNetworks: true})
+
+	code, err := sender.Send(context.Background(), models.Job{URL: redirect.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusFound, code)
+
+	code, err = sender.Send(context.Background(), models.Job{URL: slow.URL})
+	assert.Error(t, err)
+	assert.Equal(t, 0, code)
+}
+
+func TestHTTPSender_SendBlocksPrivateAddress(t *testing.T) {
+	var called bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	// Имя проверяется при соединении, после разрешения в адрес
+	url := strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1)
+
+	sender := NewHTTPSender(&config.WebhookConfig{Timeout: time.Second})
+	code, err := sender.Send(context.Background(), models.Job{URL: url})
+
+	assert.ErrorIs(t, err, errBlockedAddress)
+	assert.Equal(t, 0, code)
+	assert.False(t, called)
+}
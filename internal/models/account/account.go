@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Решения по проекту, которым владеет удаляемый пользователь
+const (
+	ActionTransfer = "transfer"
+	ActionDelete   = "delete"
+)
+
+// Profile - данные аккаунта для выгрузки
+type Profile struct {
+	ID        uuid.UUID
+	Login     string
+	Username  string
+	Email     string
+	Timezone  string
+	CreatedAt time.Time
+}
+
+// Project - проект, в котором состоит пользователь
+type Project struct {
+	ID      uuid.UUID
+	Name    string
+	Role    string
+	OwnerID uuid.UUID
+}
+
+// TimeEntry - запись учета времени пользователя вместе с задачей и проектом
+type TimeEntry struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID
+	TaskTitle string
+	ProjectID uuid.UUID
+	StartedAt time.Time
+	EndedAt   *time.Time
+	Note      string
+}
+
+// Transfer передает проект участнику, который станет владельцем
+type Transfer struct {
+	ProjectID  uuid.UUID
+	NewOwnerID uuid.UUID
+}
+
+// Deletion описывает удаление аккаунта: каждый проект пользователя
+// должен быть либо передан, либо удален
+type Deletion struct {
+	UserID    uuid.UUID
+	Transfers []Transfer
+	Deletes   []uuid.UUID
+}
+
+// Covers сообщает, есть ли решение по проекту
+func (d Deletion) Covers(projectID uuid.UUID) bool {
+	for _, t := range d.Transfers {
+		if t.ProjectID == projectID {
+			return true
+		}
+	}
+	for _, id := range d.Deletes {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы сущностей, к которым прикрепляются файлы
+const (
+	ParentTask = "task"
+	ParentNote = "note"
+)
+
+type Attachment struct {
+	ID          uuid.UUID
+	ProjectID   uuid.UUID
+	ParentType  string
+	ParentID    uuid.UUID
+	UserID      *uuid.UUID
+	FileName    string
+	ContentType string
+	Size        int64
+	StorageKey  string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+)
+
+// Collection - проект, который CalDAV-клиенты видят как календарь задач.
+// SyncSeq - номер последнего изменения задач проекта, из него строятся
+// sync-token и getctag
+type Collection struct {
+	ProjectID   uuid.UUID
+	Name        string
+	Description string
+	SyncSeq     int64
+}
+
+// Object - задача как календарный ресурс. Name - имя ресурса в коллекции,
+// UID - идентификатор VTODO. Для задач, созданных через API, они строятся из ID
+type Object struct {
+	TaskID      uuid.UUID
+	ProjectID   uuid.UUID
+	Name        string
+	UID         string
+	Title       string
+	Description string
+	Status      string
+	Importance  int
+	StartAt     *time.Time
+	Deadline    time.Time
+	AllDay      bool
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	Version     int
+	SyncSeq     int64
+
+	EstimateMinutes *int
+}
+
+// Changes - изменения коллекции после номера из sync-token
+type Changes struct {
+	Objects []Object
+	Deleted []string
+	SyncSeq int64
+}
+
+// Principal - пользователь, от имени которого работает CalDAV-клиент
+type Principal struct {
+	UserID      uuid.UUID
+	Login       string
+	Email       string
+	DisplayName string
+}
+
+// Preconditions - условные заголовки запроса. ETag ресурса - его SyncSeq
+type Preconditions struct {
+	// IfMatch - ETag из If-Match, nil если заголовка нет
+	IfMatch *int64
+	// IfMatchAny - If-Match: *, ресурс должен существовать
+	IfMatchAny bool
+	// IfNoneMatchAny - If-None-Match: *, ресурс не должен существовать
+	IfNoneMatchAny bool
+}
+
+const syncTokenPrefix = "urn:course-todo:sync:"
+
+func SyncToken(seq int64) string {
+	return syncTokenPrefix + strconv.FormatInt(seq, 10)
+}
+
+// ParseSyncToken разбирает sync-token. Пустой токен означает первую синхронизацию
+func ParseSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	seq, err := strconv.ParseInt(strings.TrimPrefix(token, syncTokenPrefix), 10, 64)
+	if err != nil || !strings.HasPrefix(token, syncTokenPrefix) || seq < 0 {
+		return 0, errs.ErrInvalidSyncToken
+	}
+	return seq, nil
+}
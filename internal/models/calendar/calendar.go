@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Вид компонентов ICS-фида. Google Calendar не показывает VTODO,
+// поэтому по умолчанию задачи выгружаются как события
+const (
+	FeedKindEvent = "event"
+	FeedKindTodo  = "todo"
+)
+
+// Entry - задача, попавшая в окно календаря
+type Entry struct {
+	TaskID      uuid.UUID
+	ProjectID   uuid.UUID
+	ProjectName string
+	Title       string
+	Status      string
+	Importance  int
+	StartAt     *time.Time
+	Deadline    time.Time
+	AllDay      bool
+}
+
+// Feed - секретная ссылка на ICS-фид. ProjectID == nil - фид по всем проектам пользователя
+type Feed struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ProjectID *uuid.UUID
+	CreatedAt time.Time
+}
+
+// FeedTask - задача с дедлайном, попадающая в ICS-фид
+type FeedTask struct {
+	TaskID      uuid.UUID
+	ProjectName string
+	Title       string
+	Description string
+	Status      string
+	Importance  int
+	StartAt     *time.Time
+	Deadline    time.Time
+	AllDay      bool
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	Version     int
+}
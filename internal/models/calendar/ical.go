@@ -0,0 +1,62 @@
+package models
+
+import taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+
+// Статусы VTODO из RFC 5545
+const (
+	TodoStatusNeedsAction = "NEEDS-ACTION"
+	TodoStatusInProcess   = "IN-PROCESS"
+	TodoStatusCompleted   = "COMPLETED"
+	TodoStatusCancelled   = "CANCELLED"
+)
+
+// Priority переводит важность задачи (3 - самая важная) в PRIORITY из RFC 5545,
+// где 1 - высший приоритет, 5 - средний, 9 - низший
+func Priority(importance int) int {
+	switch importance {
+	case 3:
+		return 1
+	case 2:
+		return 5
+	case 1:
+		return 9
+	default:
+		return 0
+	}
+}
+
+// Importance - обратное преобразование. PRIORITY:0 (не задан) дает среднюю важность
+func Importance(priority int) int {
+	switch {
+	case priority >= 1 && priority <= 4:
+		return 3
+	case priority >= 6 && priority <= 9:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func TodoStatus(status string) string {
+	switch status {
+	case taskmodels.StatusInProgress:
+		return TodoStatusInProcess
+	case taskmodels.StatusCompleted:
+		return TodoStatusCompleted
+	default:
+		return TodoStatusNeedsAction
+	}
+}
+
+// TaskStatus - обратное преобразование. Отмененные задачи в модели не различаются
+// и считаются завершенными
+func TaskStatus(todoStatus string) string {
+	switch todoStatus {
+	case TodoStatusInProcess:
+		return taskmodels.StatusInProgress
+	case TodoStatusCompleted, TodoStatusCancelled:
+		return taskmodels.StatusCompleted
+	default:
+		return taskmodels.StatusWaiting
+	}
+}
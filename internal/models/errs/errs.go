@@ -18,6 +18,21 @@ var (
 	ErrOwnerCannotLeave   = errors.New("project owner cannot leave project")
 	ErrTaskNotFound       = errors.New("task not found")
 	ErrCannotAddSelf      = errors.New("cannot add yourself as project member")
+	ErrFilterNameTaken    = errors.New("filter with this name already exists")
+	ErrVersionMismatch    = errors.New("resource version mismatch")
+	ErrFolderCycle        = errors.New("folder cannot be moved into itself")
+	ErrFileTooLarge       = errors.New("file is too large")
+	ErrQuotaExceeded      = errors.New("project storage quota exceeded")
+	ErrAssigneeNotMember  = errors.New("assignee is not a project member")
+	ErrTimerRunning       = errors.New("timer is already running")
+	ErrInvalidCalendar    = errors.New("invalid calendar data")
+	ErrInvalidSyncToken   = errors.New("invalid sync token")
+	ErrMentionNotMember   = errors.New("mentioned user is not a project member")
+	ErrImportNotReady     = errors.New("import job is not ready to commit")
+	ErrImportHasErrors    = errors.New("import job has validation errors")
+	ErrImportClaimLost    = errors.New("import job was claimed by another worker")
+	ErrProjectsUnresolved = errors.New("owned projects must be transferred or deleted")
+	ErrNewOwnerNotMember  = errors.New("new owner is not a project member")
 )
 
 func NewNotFoundError(msg string) error {
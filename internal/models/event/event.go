@@ -0,0 +1,116 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы событий, на которые можно подписать вебхук
+const (
+	TypeTaskCreated       = "task.created"
+	TypeTaskUpdated       = "task.updated"
+	TypeTaskStatusChanged = "task.status_changed"
+	TypeTaskCompleted     = "task.completed"
+	TypeTaskDeleted       = "task.deleted"
+	TypeChecklistAssigned = "checklist.assigned"
+	TypeNoteCreated       = "note.created"
+	TypeNoteUpdated       = "note.updated"
+	TypeNoteDeleted       = "note.deleted"
+	TypeMemberAdded       = "member.added"
+	TypeMemberRemoved     = "member.removed"
+	TypeMentionCreated    = "mention.created"
+)
+
+var Types = []string{
+	TypeTaskCreated,
+	TypeTaskUpdated,
+	TypeTaskStatusChanged,
+	TypeTaskCompleted,
+	TypeTaskDeleted,
+	TypeChecklistAssigned,
+	TypeNoteCreated,
+	TypeNoteUpdated,
+	TypeNoteDeleted,
+	TypeMemberAdded,
+	TypeMemberRemoved,
+	TypeMentionCreated,
+}
+
+func IsValidType(eventType string) bool {
+	for _, t := range Types {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event - изменение в проекте. ID не меняется при повторных доставках,
+// получатель может по нему отбрасывать дубли
+type Event struct {
+	ID         uuid.UUID `json:"id"`
+	Type       string    `json:"type"`
+	ProjectID  uuid.UUID `json:"project_id"`
+	ActorID    uuid.UUID `json:"actor_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+}
+
+func New(eventType string, projectID, actorID uuid.UUID, data any) Event {
+	return Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		ProjectID:  projectID,
+		ActorID:    actorID,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+// TaskData - данные событий task.*. Deadline не передается, если дедлайна нет,
+// PreviousStatus - только в task.status_changed и task.completed
+type TaskData struct {
+	ID             uuid.UUID  `json:"id"`
+	ProjectID      uuid.UUID  `json:"project_id"`
+	Title          string     `json:"title"`
+	Status         string     `json:"status"`
+	PreviousStatus string     `json:"previous_status,omitempty"`
+	Importance     int        `json:"importance"`
+	Deadline       *time.Time `json:"deadline,omitempty"`
+	Version        int        `json:"version"`
+}
+
+// ChecklistData - данные события checklist.assigned: пункту чек-листа
+// назначен новый исполнитель
+type ChecklistData struct {
+	ID         uuid.UUID `json:"id"`
+	TaskID     uuid.UUID `json:"task_id"`
+	ProjectID  uuid.UUID `json:"project_id"`
+	Text       string    `json:"text"`
+	AssigneeID uuid.UUID `json:"assignee_id"`
+}
+
+// NoteData - данные событий note.*
+type NoteData struct {
+	ID        uuid.UUID `json:"id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Name      string    `json:"name"`
+	Version   int       `json:"version,omitempty"`
+}
+
+// MemberData - данные событий member.*
+type MemberData struct {
+	ProjectID uuid.UUID `json:"project_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+// MentionData - данные события mention.created: пользователя упомянули
+// в задаче или заметке. SourceType - task или note
+type MentionData struct {
+	SourceType string    `json:"source_type"`
+	SourceID   uuid.UUID `json:"source_id"`
+	ProjectID  uuid.UUID `json:"project_id"`
+	Title      string    `json:"title"`
+	UserID     uuid.UUID `json:"user_id"`
+}
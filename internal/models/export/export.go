@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version - версия формата JSON-выгрузки. Меняется при несовместимом изменении
+// структуры, чтобы импорт мог понять, как читать файл
+const Version = 1
+
+// Kind - значение поля format в JSON-выгрузке
+const Kind = "course-todo-export"
+
+// Форматы выгрузки
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// Типы записей выгрузки
+const (
+	RecordProject = "project"
+	RecordMember  = "member"
+	RecordTask    = "task"
+	RecordNote    = "note"
+)
+
+type Project struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	OwnerID     uuid.UUID
+	CreatedAt   time.Time
+}
+
+type Member struct {
+	UserID   uuid.UUID
+	Login    string
+	Username string
+	Role     string
+	JoinedAt time.Time
+}
+
+type Task struct {
+	ID              uuid.UUID
+	AuthorID        uuid.UUID
+	AuthorLogin     string
+	Title           string
+	Description     string
+	Status          string
+	Importance      int
+	Deadline        *time.Time
+	StartAt         *time.Time
+	AllDay          bool
+	EstimateMinutes *int
+	CreatedAt       time.Time
+	CompletedAt     *time.Time
+}
+
+type Note struct {
+	ID          uuid.UUID
+	AuthorID    uuid.UUID
+	AuthorLogin string
+	Name        string
+	Description string
+	Format      string
+	CreatedAt   time.Time
+}
+
+// Writer получает записи выгрузки по мере чтения из базы: сначала проект,
+// затем участников, задачи и заметки
+type Writer interface {
+	WriteProject(project Project) error
+	WriteMember(member Member) error
+	WriteTask(task Task) error
+	WriteNote(note Note) error
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DateLayout - формат абсолютной даты в фильтре
+const DateLayout = "2006-01-02"
+
+// DateBound задает границу диапазона дат: либо конкретную дату (YYYY-MM-DD),
+// либо смещение в днях от текущего дня пользователя
+type DateBound struct {
+	Date string `json:"date,omitempty"`
+	Days *int   `json:"days,omitempty"`
+}
+
+// Definition описывает условия сохраненного фильтра и хранится в JSONB
+type Definition struct {
+	ProjectIDs   []uuid.UUID `json:"project_ids,omitempty"`
+	Statuses     []string    `json:"statuses,omitempty"`
+	Importance   []int       `json:"importance,omitempty"`
+	OnlyMine     bool        `json:"only_mine,omitempty"`
+	Overdue      bool        `json:"overdue,omitempty"`
+	DeadlineFrom *DateBound  `json:"deadline_from,omitempty"`
+	DeadlineTo   *DateBound  `json:"deadline_to,omitempty"`
+	Text         string      `json:"text,omitempty"`
+}
+
+type SavedFilter struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Definition Definition
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TaskQuery - фильтр с уже вычисленными абсолютными датами
+type TaskQuery struct {
+	UserID         uuid.UUID
+	ProjectIDs     []uuid.UUID
+	Statuses       []string
+	Importance     []int
+	OnlyMine       bool
+	OverdueAt      *time.Time
+	DeadlineFrom   *time.Time
+	DeadlineBefore *time.Time
+	Text           string
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
+)
+
+// Источники импорта
+const (
+	SourceCSV     = "csv"
+	SourceTodoist = "todoist"
+	SourceTrello  = "trello"
+	// SourceExport - файл собственной выгрузки проекта в формате json
+	SourceExport = "course-todo"
+)
+
+var Sources = []string{SourceCSV, SourceTodoist, SourceTrello, SourceExport}
+
+// Статусы задания. Задание сначала проверяется без записи (queued -> validating -> validated),
+// после подтверждения пользователем записывается (pending_commit -> importing -> completed)
+const (
+	StatusQueued        = "queued"
+	StatusValidating    = "validating"
+	StatusValidated     = "validated"
+	StatusPendingCommit = "pending_commit"
+	StatusImporting     = "importing"
+	StatusCompleted     = "completed"
+	StatusFailed        = "failed"
+)
+
+// Поля задачи и заметки, в которые сопоставляются колонки CSV
+const (
+	FieldType            = "type"
+	FieldTitle           = "title"
+	FieldDescription     = "description"
+	FieldStatus          = "status"
+	FieldImportance      = "importance"
+	FieldDeadline        = "deadline"
+	FieldStartAt         = "start_at"
+	FieldAllDay          = "all_day"
+	FieldEstimateMinutes = "estimate_minutes"
+	FieldLabels          = "labels"
+	FieldFormat          = "format"
+)
+
+var Fields = []string{
+	FieldType, FieldTitle, FieldDescription, FieldStatus, FieldImportance, FieldDeadline,
+	FieldStartAt, FieldAllDay, FieldEstimateMinutes, FieldLabels, FieldFormat,
+}
+
+// Виды записей импорта
+const (
+	KindTask = "task"
+	KindNote = "note"
+)
+
+// Mapping - настройка сопоставления, задается при загрузке файла.
+// Columns: колонка CSV -> поле (пустое поле - колонка пропускается);
+// Statuses: статус или список Trello -> статус задачи;
+// Labels: метка -> важность задачи
+type Mapping struct {
+	Columns  map[string]string `json:"columns,omitempty"`
+	Statuses map[string]string `json:"statuses,omitempty"`
+	Labels   map[string]int    `json:"labels,omitempty"`
+}
+
+type Job struct {
+	ID        uuid.UUID
+	ProjectID uuid.UUID
+	UserID    uuid.UUID
+	Source    string
+	Status    string
+	FileName  string
+	// File заполняется только у задания, взятого обработчиком
+	File       []byte
+	Mapping    Mapping
+	Total      int
+	Processed  int
+	Report     *Report
+	Error      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+	// ClaimToken - метка обработчика, взявшего задание. Заполняется только у
+	// задания, взятого обработчиком
+	ClaimToken uuid.UUID
+}
+
+// RowError - ошибка в записи файла. Row - номер строки CSV или порядковый номер записи в json
+type RowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// Report - результат проверки файла. Errors содержит не больше MaxReportErrors
+// ошибок, полное число - ErrorCount. Unmapped* - данные источника, которые не будут импортированы
+type Report struct {
+	Total            int        `json:"total"`
+	Tasks            int        `json:"tasks"`
+	Notes            int        `json:"notes"`
+	Skipped          int        `json:"skipped"`
+	ErrorCount       int        `json:"error_count"`
+	Errors           []RowError `json:"errors"`
+	UnmappedFields   []string   `json:"unmapped_fields"`
+	UnmappedStatuses []string   `json:"unmapped_statuses"`
+	UnmappedLabels   []string   `json:"unmapped_labels"`
+}
+
+const MaxReportErrors = 100
+
+// Item - проверенная запись, готовая к записи в проект
+type Item struct {
+	// ID выдается при записи
+	ID              uuid.UUID
+	Row             int
+	Kind            string
+	Title           string
+	Description     string
+	Status          string
+	Importance      int
+	Deadline        time.Time
+	StartAt         *time.Time
+	AllDay          bool
+	EstimateMinutes *int
+	// Format - формат заметки
+	Format string
+	// Links и Mentions - ссылки и упоминания из описания. Они сохраняются после
+	// записи всего файла, поэтому записи могут ссылаться друг на друга
+	Links    linkmodels.References
+	Mentions []string
+}
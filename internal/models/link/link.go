@@ -0,0 +1,23 @@
+package models
+
+import "github.com/google/uuid"
+
+// Типы сущностей, между которыми строятся ссылки
+const (
+	TypeTask string = "task"
+	TypeNote string = "note"
+)
+
+// References - ссылки, найденные в тексте: по ID (#uuid) и по названию ([[Название]])
+type References struct {
+	IDs    []uuid.UUID
+	Titles []string
+}
+
+// Backlink - сущность, в тексте которой есть ссылка на текущую
+type Backlink struct {
+	Type      string
+	ID        uuid.UUID
+	ProjectID uuid.UUID
+	Title     string
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	outbox "github.com/lzimin05/course-todo/internal/models/outbox"
+)
+
+// Writer - соединение подписчика на изменения (SSE или WebSocket).
+// Open вызывается один раз, когда подписка готова, до остальных методов
+type Writer interface {
+	Open() error
+	// WriteEvent отправляет событие, Seq события - его номер для возобновления
+	WriteEvent(msg outbox.Message) error
+	// WriteReset сообщает, что пропущено слишком много событий
+	// и клиенту нужно заново загрузить данные
+	WriteReset() error
+	WriteHeartbeat() error
+}
@@ -0,0 +1,8 @@
+package models
+
+// Mail - письмо с текстовым телом
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
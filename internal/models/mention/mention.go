@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Mention - упоминание пользователя в задаче или заметке. SourceType - тип
+// источника (task, note), Title - название задачи или заметки
+type Mention struct {
+	SourceType  string
+	SourceID    uuid.UUID
+	Title       string
+	ProjectID   uuid.UUID
+	ProjectName string
+	AuthorID    *uuid.UUID
+	UserID      uuid.UUID
+	CreatedAt   time.Time
+}
+
+// MentionedUser - пользователь, на которого указывает @login
+type MentionedUser struct {
+	ID    uuid.UUID
+	Login string
+}
@@ -6,11 +6,61 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	DiffEqual  string = "equal"
+	DiffInsert string = "insert"
+	DiffDelete string = "delete"
+)
+
+// Форматы текста заметки
+const (
+	FormatPlain    string = "plain"
+	FormatMarkdown string = "markdown"
+)
+
 type Note struct {
 	ID          uuid.UUID
 	ProjectID   uuid.UUID
 	UserID      uuid.UUID
 	Name        string
 	Description string
+	Format      string
 	CreatedAt   time.Time
+	Version     int
+	FolderID    *uuid.UUID
+	Position    int
+	// Pinned и Favourite - личные отметки пользователя, запросившего заметку
+	Pinned    bool
+	Favourite bool
+}
+
+// NoteFolder - папка заметок. Path - имена папок от корня проекта через "/"
+type NoteFolder struct {
+	ID        uuid.UUID
+	ProjectID uuid.UUID
+	ParentID  *uuid.UUID
+	Name      string
+	Path      string
+	CreatedAt time.Time
+}
+
+// NoteRevision - неизменяемый снимок заметки после очередной правки
+type NoteRevision struct {
+	NoteID       uuid.UUID
+	Revision     int
+	Name         string
+	Description  string
+	Format       string
+	AuthorID     *uuid.UUID
+	CreatedAt    time.Time
+	RestoredFrom *int
+}
+
+// DiffLine - строка построчного сравнения. Номера строк начинаются с 1,
+// у добавленной строки нет старого номера, у удаленной - нового
+type DiffLine struct {
+	Op      string
+	OldLine int
+	NewLine int
+	Text    string
 }
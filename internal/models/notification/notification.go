@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы уведомлений, каждый можно отключить в настройках
+const (
+	TypeMemberAdded         = "member_added"
+	TypeTaskCompleted       = "task_completed"
+	TypeChecklistAssigned   = "checklist_assigned"
+	TypeMentioned           = "mentioned"
+	TypeDeadlineApproaching = "deadline_approaching"
+	TypeTaskOverdue         = "task_overdue"
+	// TypeDailyDigest - ежедневная сводка на почту, в списке уведомлений не появляется
+	TypeDailyDigest = "daily_digest"
+)
+
+var Types = []string{
+	TypeMemberAdded,
+	TypeTaskCompleted,
+	TypeChecklistAssigned,
+	TypeMentioned,
+	TypeDeadlineApproaching,
+	TypeTaskOverdue,
+	TypeDailyDigest,
+}
+
+func IsValidType(notificationType string) bool {
+	for _, t := range Types {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification - уведомление пользователя. EventID - ключ дедупликации:
+// у одного пользователя не бывает двух уведомлений одного типа об одном событии.
+// Data - данные события, ProjectName заполняется при чтении
+type Notification struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Type        string
+	ProjectID   uuid.UUID
+	ProjectName string
+	ActorID     *uuid.UUID
+	EventID     uuid.UUID
+	Data        []byte
+	CreatedAt   time.Time
+	ReadAt      *time.Time
+}
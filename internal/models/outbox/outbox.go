@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message - событие из таблицы outbox. ID совпадает с ID события и не меняется
+// при повторной публикации, по нему получатели отбрасывают дубли.
+// Seq - порядок записи, PublishedSeq - порядок публикации, он заполнен только
+// у опубликованных событий и служит курсором потока событий.
+// Payload - событие в JSON, в том виде, в каком его получают подписчики
+type Message struct {
+	ID           uuid.UUID
+	Seq          int64
+	PublishedSeq int64
+	Type         string
+	ProjectID    uuid.UUID
+	Payload      []byte
+	CreatedAt    time.Time
+	Attempts     int
+}
@@ -12,6 +12,7 @@ type Project struct {
 	Description string
 	OwnerID     uuid.UUID
 	CreatedAt   time.Time
+	Version     int
 }
 
 const (
@@ -28,3 +29,26 @@ type ProjectMember struct {
 	Role      string
 	JoinedAt  time.Time
 }
+
+type DateCount struct {
+	Date  time.Time
+	Count int
+}
+
+type MemberStats struct {
+	UserID    uuid.UUID
+	Username  string
+	Open      int
+	Completed int
+}
+
+type ProjectStats struct {
+	ProjectID           uuid.UUID
+	ByStatus            map[string]int
+	ByImportance        map[int]int
+	Overdue             int
+	CompletedPerDay     []DateCount
+	CompletedPerWeek    []DateCount
+	AvgCycleTimeSeconds float64
+	Members             []MemberStats
+}
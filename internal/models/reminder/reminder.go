@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Task - незавершенная задача со сроком. Deadline - фактический срок:
+// у задач на весь день это конец указанного дня
+type Task struct {
+	ID          uuid.UUID
+	ProjectID   uuid.UUID
+	ProjectName string
+	AuthorID    uuid.UUID
+	Title       string
+	Deadline    time.Time
+	AllDay      bool
+}
+
+// Recipient - получатель ежедневной сводки. LocalDate - текущая дата в его часовом поясе
+type Recipient struct {
+	UserID    uuid.UUID
+	Email     string
+	Username  string
+	Timezone  string
+	LocalDate time.Time
+}
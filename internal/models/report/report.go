@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+const (
+	BucketDay  string = "day"
+	BucketWeek string = "week"
+)
+
+type CFDPoint struct {
+	Bucket     time.Time
+	Waiting    int
+	InProgress int
+	Completed  int
+}
@@ -0,0 +1,25 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	TypeTask    string = "task"
+	TypeNote    string = "note"
+	TypeProject string = "project"
+)
+
+type SearchResult struct {
+	Type      string
+	ID        uuid.UUID
+	ProjectID uuid.UUID
+	Title     string
+	Snippet   string
+	Rank      float64
+}
+
+// Маркеры подсветки совпадений в заголовках и фрагментах. Используются управляющие
+// символы, чтобы их нельзя было спутать с пользовательским текстом.
+const (
+	HighlightStart string = "\x02"
+	HighlightStop  string = "\x03"
+)
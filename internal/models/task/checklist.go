@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChecklistItem - пункт чек-листа задачи. Position задает порядок внутри задачи
+type ChecklistItem struct {
+	ID         uuid.UUID
+	TaskID     uuid.UUID
+	Text       string
+	Done       bool
+	AssigneeID *uuid.UUID
+	Position   int
+	CreatedAt  time.Time
+}
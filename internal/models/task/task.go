@@ -22,4 +22,28 @@ type Task struct {
 	Deadline    time.Time
 	CreatedAt   time.Time
 	Status      string
+	CompletedAt *time.Time
+	Version     int
+	// EstimateMinutes - оценка трудозатрат в минутах, nil если не задана
+	EstimateMinutes *int
+	// StartAt - начало работы над задачей, nil если не задано
+	StartAt *time.Time
+	// AllDay - StartAt и Deadline задают дни, а не моменты времени.
+	// Такие даты хранятся как полночь UTC и не сдвигаются часовым поясом
+	AllDay bool
+	// CalDAVName и CalDAVUID - имя ресурса и UID, выбранные CalDAV-клиентом.
+	// Пустые у задач, созданных через API
+	CalDAVName string
+	CalDAVUID  string
+
+	ChecklistDone  int
+	ChecklistTotal int
+}
+
+// CalDAVOrigin - данные задачи, созданной CalDAV-клиентом: начальный статус,
+// имя ресурса и UID сохраняются в той же транзакции, что и задача
+type CalDAVOrigin struct {
+	Status string
+	Name   string
+	UID    string
 }
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimeEntry struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID
+	UserID    uuid.UUID
+	StartedAt time.Time
+	// EndedAt равен nil, пока таймер запущен
+	EndedAt   *time.Time
+	Note      string
+	CreatedAt time.Time
+}
+
+// ReportRow - время участника в проекте за один день
+type ReportRow struct {
+	ProjectID   uuid.UUID
+	ProjectName string
+	UserID      uuid.UUID
+	Login       string
+	Day         time.Time
+	Seconds     int64
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppPassword - пароль приложения для клиентов без поддержки JWT (CalDAV).
+// Сам пароль не хранится, только его хеш
+type AppPassword struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
@@ -2,10 +2,18 @@ package models
 
 import "github.com/google/uuid"
 
+// DefaultTimezone используется, пока пользователь не выбрал свой часовой пояс
+const DefaultTimezone = "UTC"
+
+// DeletedUserID - служебный пользователь, которому переходит авторство данных
+// удаленных аккаунтов в общих проектах
+var DeletedUserID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
 type User struct {
 	ID           uuid.UUID
 	Login        string 
 	Username     string 
 	Email        string 
 	PasswordHash []byte 
+	Timezone     string
 }
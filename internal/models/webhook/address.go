@@ -0,0 +1,59 @@
+package models
+
+import (
+	"net"
+	"strings"
+)
+
+// Диапазоны, которые не покрываются методами net.IP: "этот" сегмент
+// и разделяемое адресное пространство операторов (CGNAT)
+var reservedNets = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+}
+
+// Имена, которые всегда указывают на саму машину или сервис метаданных облака
+var internalHosts = []string{
+	"localhost",
+	"metadata",
+	"metadata.google.internal",
+}
+
+// IsPublicIP сообщает, можно ли отправлять вебхук на адрес. Запрещены
+// внутренние сети, loopback, link-local (в том числе 169.254.169.254 -
+// метаданные облака) и multicast
+func IsPublicIP(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, n := range reservedNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPublicHost проверяет адрес вебхука без обращения к DNS: IP-адрес
+// проверяется целиком, а имя - по списку заведомо внутренних. Имена,
+// которые разрешаются во внутренние адреса, отсекаются при соединении
+func IsPublicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if ip := net.ParseIP(host); ip != nil {
+		return IsPublicIP(ip)
+	}
+	for _, internal := range internalHosts {
+		if host == internal || strings.HasSuffix(host, "."+internal) {
+			return false
+		}
+	}
+	return true
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
@@ -0,0 +1,142 @@
+package taskquery
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Params - контекст выполнения запроса. Относительные даты считаются от Now
+// в часовом поясе Location
+type Params struct {
+	UserID   uuid.UUID
+	Now      time.Time
+	Location *time.Location
+	Limit    int
+	Offset   int
+}
+
+// Field - поле задачи, по которому можно фильтровать
+type Field string
+
+const (
+	FieldStatus     Field = "status"
+	FieldImportance Field = "importance"
+	FieldDue        Field = "due"
+	FieldCreated    Field = "created"
+	FieldProject    Field = "project"
+	FieldAuthor     Field = "author"
+)
+
+// Op - оператор сравнения. ":" и "=" эквивалентны
+type Op string
+
+const (
+	OpEq  Op = ":"
+	OpLt  Op = "<"
+	OpLte Op = "<="
+	OpGt  Op = ">"
+	OpGte Op = ">="
+)
+
+// DateUnit - единица относительной даты
+type DateUnit byte
+
+const (
+	UnitDay   DateUnit = 'd'
+	UnitWeek  DateUnit = 'w'
+	UnitMonth DateUnit = 'm'
+)
+
+// DateValue - дата в запросе: абсолютная, относительная (+3d) или none.
+// Относительные даты вычисляются при компиляции запроса
+type DateValue struct {
+	None     bool
+	Absolute bool
+	Date     time.Time
+	Offset   int
+	Unit     DateUnit
+}
+
+// Node - узел дерева разбора запроса
+type Node interface {
+	// Pos возвращает позицию начала узла в запросе (в символах, с нуля)
+	Pos() int
+	String() string
+}
+
+type And struct {
+	Left, Right Node
+}
+
+type Or struct {
+	Left, Right Node
+}
+
+type Not struct {
+	Expr     Node
+	Position int
+}
+
+// Comparison - условие вида field<op>value
+type Comparison struct {
+	Field    Field
+	Op       Op
+	Raw      string
+	Int      int
+	Date     DateValue
+	Position int
+}
+
+// Text - поиск подстроки в названии и описании задачи
+type Text struct {
+	Value    string
+	Position int
+}
+
+func (n *And) Pos() int        { return n.Left.Pos() }
+func (n *Or) Pos() int         { return n.Left.Pos() }
+func (n *Not) Pos() int        { return n.Position }
+func (n *Comparison) Pos() int { return n.Position }
+func (n *Text) Pos() int       { return n.Position }
+
+func (n *And) String() string { return "(" + n.Left.String() + " AND " + n.Right.String() + ")" }
+func (n *Or) String() string  { return "(" + n.Left.String() + " OR " + n.Right.String() + ")" }
+func (n *Not) String() string { return "NOT " + n.Expr.String() }
+
+func (n *Comparison) String() string {
+	return string(n.Field) + string(n.Op) + quote(n.Raw)
+}
+
+func (n *Text) String() string { return quote(n.Value) }
+
+func (d DateValue) String() string {
+	switch {
+	case d.None:
+		return "none"
+	case d.Absolute:
+		return d.Date.Format(DateLayout)
+	default:
+		sign := "+"
+		if d.Offset < 0 {
+			sign = ""
+		}
+		return sign + strconv.Itoa(d.Offset) + string(d.Unit)
+	}
+}
+
+// quote экранирует значение так, чтобы его можно было снова разобрать
+func quote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		if r == '"' || r == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
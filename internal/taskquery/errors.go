@@ -0,0 +1,17 @@
+package taskquery
+
+import "fmt"
+
+// Error - ошибка разбора запроса с позицией (в символах, с нуля)
+type Error struct {
+	Position int
+	Message  string
+}
+
+func newError(pos int, format string, args ...interface{}) *Error {
+	return &Error{Position: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Position, e.Message)
+}
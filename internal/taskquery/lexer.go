@@ -0,0 +1,118 @@
+package taskquery
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenMinus
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	// attached - токен идет сразу за предыдущим, без пробела
+	attached bool
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return quote(t.text)
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+func isOpRune(r rune) bool {
+	return r == ':' || r == '=' || r == '<' || r == '>' || r == '!'
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && !isOpRune(r) && r != '(' && r != ')' && r != '"'
+}
+
+// lex разбивает запрос на токены. Позиции считаются в символах
+func lex(input []rune) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(input) {
+		r := input[i]
+		if unicode.IsSpace(r) {
+			i++
+			continue
+		}
+
+		attached := i > 0 && !unicode.IsSpace(input[i-1])
+		prevOp := len(tokens) > 0 && tokens[len(tokens)-1].kind == tokenOp && attached
+
+		switch {
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i, attached: attached})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i, attached: attached})
+			i++
+		case r == '-' && !prevOp && i+1 < len(input) && !unicode.IsSpace(input[i+1]):
+			// Минус перед условием означает отрицание, а в значении (due>-2d) - часть слова
+			tokens = append(tokens, token{kind: tokenMinus, text: "-", pos: i, attached: attached})
+			i++
+		case isOpRune(r):
+			start := i
+			op := string(r)
+			if i+1 < len(input) && input[i+1] == '=' && (r == '<' || r == '>' || r == '!') {
+				op += "="
+			}
+			if op == "!" {
+				return nil, newError(start, "unexpected '!', did you mean '!='?")
+			}
+			i += len([]rune(op))
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: start, attached: attached})
+		case r == '"':
+			start := i
+			i++
+			var sb strings.Builder
+			closed := false
+			for i < len(input) {
+				c := input[i]
+				if c == '\\' && i+1 < len(input) {
+					sb.WriteRune(input[i+1])
+					i += 2
+					continue
+				}
+				if c == '"' {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(c)
+				i++
+			}
+			if !closed {
+				return nil, newError(start, "unterminated quoted string")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: start, attached: attached})
+		default:
+			start := i
+			for i < len(input) && isWordRune(input[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(input[start:i]), pos: start, attached: attached})
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(input)})
+	return tokens, nil
+}
@@ -0,0 +1,337 @@
+// Package taskquery реализует язык запросов к задачам, например:
+//
+//	status:in_progress importance>=2 due<+3d project:"Backend" -author:me "login bug"
+//
+// Условия через пробел объединяются по AND, также поддерживаются OR, NOT,
+// отрицание через минус и группировка скобками.
+package taskquery
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	models "github.com/lzimin05/course-todo/internal/models/task"
+)
+
+const (
+	// DateLayout - формат абсолютной даты в запросе
+	DateLayout = "2006-01-02"
+
+	MaxQueryLength = 500
+	maxDepth       = 32
+	maxTerms       = 50
+	maxOffset      = 1000
+)
+
+var relativeDateRe = regexp.MustCompile(`^([+-]?)(\d{1,4})([dwm])$`)
+
+// unsupportedFields - поля, которые пользователи ожидают увидеть, но которых нет у задач
+var unsupportedFields = map[string]string{
+	"label":    "tasks have no labels",
+	"tag":      "tasks have no labels",
+	"assignee": "tasks have no assignees",
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+	terms  int
+}
+
+// Parse разбирает запрос и проверяет поля, операторы и значения
+func Parse(query string) (Node, error) {
+	if !utf8.ValidString(query) {
+		return nil, newError(0, "query must be valid UTF-8")
+	}
+
+	input := []rune(query)
+	if len(input) > MaxQueryLength {
+		return nil, newError(MaxQueryLength, "query must be at most %d characters", MaxQueryLength)
+	}
+
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, newError(0, "query is empty")
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, newError(tok.pos, "unexpected %s", tok.describe())
+	}
+
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isKeyword проверяет ключевое слово AND, OR или NOT. Имя поля (например, or:...)
+// ключевым словом не считается - это проверяет вызывающий код через isField
+func isKeyword(tok token, keyword string) bool {
+	return tok.kind == tokenWord && tok.text == keyword
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for isKeyword(p.peek(), "OR") && !p.isField() {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.kind == tokenEOF || tok.kind == tokenRParen || (isKeyword(tok, "OR") && !p.isField()) {
+			return left, nil
+		}
+		if isKeyword(tok, "AND") && !p.isField() {
+			p.next()
+		}
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	tok := p.peek()
+	if tok.kind == tokenMinus || (isKeyword(tok, "NOT") && !p.isField()) {
+		p.next()
+		if err := p.enter(tok.pos); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		p.depth--
+		return &Not{Expr: expr, Position: tok.pos}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.peek()
+
+	switch tok.kind {
+	case tokenLParen:
+		p.next()
+		if err := p.enter(tok.pos); err != nil {
+			return nil, err
+		}
+		if p.peek().kind == tokenRParen {
+			return nil, newError(p.peek().pos, "empty group")
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing := p.next()
+		if closing.kind != tokenRParen {
+			return nil, newError(tok.pos, "unclosed '('")
+		}
+		p.depth--
+		return node, nil
+	case tokenWord:
+		if p.isField() {
+			return p.parseComparison()
+		}
+		p.next()
+		return p.text(tok)
+	case tokenString:
+		p.next()
+		return p.text(tok)
+	case tokenEOF:
+		return nil, newError(tok.pos, "unexpected end of query, expected a condition")
+	default:
+		return nil, newError(tok.pos, "unexpected %s", tok.describe())
+	}
+}
+
+// isField сообщает, что текущее слово - имя поля (сразу за ним идет оператор)
+func (p *parser) isField() bool {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenWord || p.pos+1 >= len(p.tokens) {
+		return false
+	}
+	op := p.tokens[p.pos+1]
+	return op.kind == tokenOp && op.attached
+}
+
+func (p *parser) enter(pos int) error {
+	p.depth++
+	if p.depth > maxDepth {
+		return newError(pos, "query is nested too deeply")
+	}
+	return nil
+}
+
+func (p *parser) countTerm(pos int) error {
+	p.terms++
+	if p.terms > maxTerms {
+		return newError(pos, "query must contain at most %d conditions", maxTerms)
+	}
+	return nil
+}
+
+func (p *parser) text(tok token) (Node, error) {
+	if err := p.countTerm(tok.pos); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(tok.text) == "" {
+		return nil, newError(tok.pos, "search text is empty")
+	}
+	return &Text{Value: tok.text, Position: tok.pos}, nil
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	fieldTok := p.next()
+	opTok := p.next()
+	valueTok := p.peek()
+
+	if err := p.countTerm(fieldTok.pos); err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(fieldTok.text)
+	if reason, ok := unsupportedFields[name]; ok {
+		return nil, newError(fieldTok.pos, "unsupported field %q: %s", fieldTok.text, reason)
+	}
+
+	field := Field(name)
+	switch field {
+	case FieldStatus, FieldImportance, FieldDue, FieldCreated, FieldProject, FieldAuthor:
+	default:
+		return nil, newError(fieldTok.pos, "unknown field %q", fieldTok.text)
+	}
+
+	if (valueTok.kind != tokenWord && valueTok.kind != tokenString) || !valueTok.attached {
+		return nil, newError(opTok.pos+utf8.RuneCountInString(opTok.text), "expected a value after %s", opTok.describe())
+	}
+	p.next()
+
+	negate := opTok.text == "!="
+	op := Op(opTok.text)
+	if op == "=" || negate {
+		op = OpEq
+	}
+
+	cmp := &Comparison{Field: field, Op: op, Raw: valueTok.text, Position: fieldTok.pos}
+	if err := checkComparison(cmp, opTok, valueTok); err != nil {
+		return nil, err
+	}
+
+	if negate {
+		return &Not{Expr: cmp, Position: fieldTok.pos}, nil
+	}
+	return cmp, nil
+}
+
+func checkComparison(cmp *Comparison, opTok, valueTok token) error {
+	ordered := cmp.Field == FieldImportance || cmp.Field == FieldDue || cmp.Field == FieldCreated
+	if cmp.Op != OpEq && !ordered {
+		return newError(opTok.pos, "operator %s is not supported for field %q", opTok.describe(), cmp.Field)
+	}
+
+	switch cmp.Field {
+	case FieldStatus:
+		status := strings.ToLower(cmp.Raw)
+		if status != models.StatusWaiting && status != models.StatusInProgress && status != models.StatusCompleted {
+			return newError(valueTok.pos, "invalid status %q, expected waiting, in_progress or completed", cmp.Raw)
+		}
+		cmp.Raw = status
+	case FieldImportance:
+		importance, err := strconv.Atoi(cmp.Raw)
+		if err != nil || importance < 1 || importance > 3 {
+			return newError(valueTok.pos, "invalid importance %q, expected a number from 1 to 3", cmp.Raw)
+		}
+		cmp.Int = importance
+	case FieldDue, FieldCreated:
+		date, err := parseDate(cmp.Raw)
+		if err != nil {
+			return newError(valueTok.pos, "invalid date %q, expected YYYY-MM-DD, today, tomorrow, yesterday or offset like +3d, -1w, +2m", cmp.Raw)
+		}
+		if date.None && (cmp.Field != FieldDue || cmp.Op != OpEq) {
+			return newError(valueTok.pos, "value \"none\" is only supported as due:none")
+		}
+		cmp.Date = date
+	case FieldProject, FieldAuthor:
+		if strings.TrimSpace(cmp.Raw) == "" {
+			return newError(valueTok.pos, "value of field %q is empty", cmp.Field)
+		}
+	}
+
+	return nil
+}
+
+func parseDate(raw string) (DateValue, error) {
+	switch strings.ToLower(raw) {
+	case "none":
+		return DateValue{None: true}, nil
+	case "today":
+		return DateValue{Offset: 0, Unit: UnitDay}, nil
+	case "tomorrow":
+		return DateValue{Offset: 1, Unit: UnitDay}, nil
+	case "yesterday":
+		return DateValue{Offset: -1, Unit: UnitDay}, nil
+	}
+
+	if m := relativeDateRe.FindStringSubmatch(raw); m != nil {
+		offset, _ := strconv.Atoi(m[2])
+		if offset > maxOffset {
+			return DateValue{}, newError(0, "offset is too large")
+		}
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return DateValue{Offset: offset, Unit: DateUnit(m[3][0])}, nil
+	}
+
+	date, err := time.Parse(DateLayout, raw)
+	if err != nil {
+		return DateValue{}, err
+	}
+	return DateValue{Absolute: true, Date: date}, nil
+}
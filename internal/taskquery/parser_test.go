@@ -0,0 +1,185 @@
+package taskquery
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "single comparison",
+			query:    "status:in_progress",
+			expected: `status:"in_progress"`,
+		},
+		{
+			name:     "implicit and",
+			query:    `status:in_progress importance>=2 due<+3d project:"Backend" "login bug"`,
+			expected: `((((status:"in_progress" AND importance>="2") AND due<"+3d") AND project:"Backend") AND "login bug")`,
+		},
+		{
+			name:     "or has lower precedence than and",
+			query:    "a b OR c",
+			expected: `(("a" AND "b") OR "c")`,
+		},
+		{
+			name:     "groups and negation",
+			query:    "-(status:completed OR importance<2) NOT author:me",
+			expected: `(NOT (status:"completed" OR importance<"2") AND NOT author:"me")`,
+		},
+		{
+			name:     "not equal is negation",
+			query:    "status!=waiting",
+			expected: `NOT status:"waiting"`,
+		},
+		{
+			name:     "equals sign and case insensitive field",
+			query:    "Status=Completed",
+			expected: `status:"completed"`,
+		},
+		{
+			name:     "negative relative date is a value",
+			query:    "due>-2w created>=2025-01-31",
+			expected: `(due>"-2w" AND created>="2025-01-31")`,
+		},
+		{
+			name:     "escaped quotes",
+			query:    `"say \"hi\""`,
+			expected: `"say \"hi\""`,
+		},
+		{
+			name:     "field named like keyword",
+			query:    `a OR project:OR`,
+			expected: `("a" OR project:"OR")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, node.String())
+		})
+	}
+}
+
+func TestParse_Values(t *testing.T) {
+	node, err := Parse("importance>=2")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, node.(*Comparison).Int)
+
+	node, err = Parse("due<+3d")
+	assert.NoError(t, err)
+	assert.Equal(t, DateValue{Offset: 3, Unit: UnitDay}, node.(*Comparison).Date)
+
+	node, err = Parse("due:tomorrow")
+	assert.NoError(t, err)
+	assert.Equal(t, DateValue{Offset: 1, Unit: UnitDay}, node.(*Comparison).Date)
+
+	node, err = Parse("due:none")
+	assert.NoError(t, err)
+	assert.True(t, node.(*Comparison).Date.None)
+
+	node, err = Parse("created<2025-02-01")
+	assert.NoError(t, err)
+	assert.True(t, node.(*Comparison).Date.Absolute)
+	assert.Equal(t, "2025-02-01", node.(*Comparison).Date.String())
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		position    int
+		expectedMsg string
+	}{
+		{name: "empty query", query: "   ", position: 0, expectedMsg: "query is empty"},
+		{name: "unsupported label", query: `status:waiting -label:blocked`, position: 16, expectedMsg: `unsupported field "label"`},
+		{name: "unknown field", query: "foo:bar", position: 0, expectedMsg: `unknown field "foo"`},
+		{name: "invalid status", query: "status:done", position: 7, expectedMsg: `invalid status "done"`},
+		{name: "invalid importance", query: "importance>5", position: 11, expectedMsg: `invalid importance "5"`},
+		{name: "ordered operator on status", query: "status>waiting", position: 6, expectedMsg: `operator '>' is not supported for field "status"`},
+		{name: "invalid date", query: "due<soon", position: 4, expectedMsg: `invalid date "soon"`},
+		{name: "none with ordered operator", query: "due<none", position: 4, expectedMsg: `value "none" is only supported as due:none`},
+		{name: "missing value", query: "status:", position: 7, expectedMsg: "expected a value after ':'"},
+		{name: "value separated by space", query: "status: waiting", position: 7, expectedMsg: "expected a value after ':'"},
+		{name: "unterminated string", query: `a "login bug`, position: 2, expectedMsg: "unterminated quoted string"},
+		{name: "unclosed group", query: "(a OR b", position: 0, expectedMsg: "unclosed '('"},
+		{name: "unexpected closing paren", query: "a)", position: 1, expectedMsg: "unexpected ')'"},
+		{name: "empty group", query: "a ()", position: 3, expectedMsg: "empty group"},
+		{name: "dangling or", query: "a OR", position: 4, expectedMsg: "unexpected end of query"},
+		{name: "lone bang", query: "status!waiting", position: 6, expectedMsg: "unexpected '!'"},
+		{name: "positions count characters", query: "задача foo:bar", position: 7, expectedMsg: `unknown field "foo"`},
+		{name: "too deep", query: strings.Repeat("(", 40) + "a" + strings.Repeat(")", 40), position: 32, expectedMsg: "nested too deeply"},
+		{name: "too many terms", query: strings.Repeat("a ", 51), position: 100, expectedMsg: "at most 50 conditions"},
+		{name: "too long", query: strings.Repeat("a", 501), position: 500, expectedMsg: "at most 500 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.query)
+			assert.Nil(t, node)
+
+			var qErr *Error
+			if assert.True(t, errors.As(err, &qErr), "expected *Error, got %v", err) {
+				assert.Equal(t, tt.position, qErr.Position)
+				assert.Contains(t, qErr.Message, tt.expectedMsg)
+			}
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	seeds := []string{
+		`status:in_progress importance>=2 due<+3d project:"Backend" -label:blocked "login bug"`,
+		`(a OR b) AND NOT c`,
+		`-(-(due:none))`,
+		`status!=completed OR importance<=1`,
+		`created>=2025-01-31 author:me`,
+		`"unterminated`,
+		`a)`,
+		`due>-2w`,
+		`задача "с \"кавычками\""`,
+		`OR AND NOT`,
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, query string) {
+		node, err := Parse(query)
+		if err != nil {
+			var qErr *Error
+			if !errors.As(err, &qErr) {
+				t.Fatalf("Parse(%q) returned error without position: %v", query, err)
+			}
+			if qErr.Position < 0 || qErr.Position > utf8.RuneCountInString(query) {
+				t.Fatalf("Parse(%q) returned position %d out of range", query, qErr.Position)
+			}
+			return
+		}
+
+		// Текстовое представление разбирается в то же самое дерево
+		printed := node.String()
+		reparsed, err := Parse(printed)
+		if err != nil {
+			// Печать добавляет кавычки и скобки, поэтому длинные запросы могут не пройти лимиты
+			var qErr *Error
+			if errors.As(err, &qErr) && (strings.Contains(qErr.Message, "at most") || strings.Contains(qErr.Message, "nested too deeply")) {
+				return
+			}
+			t.Fatalf("Parse(%q) of printed %q failed: %v", query, printed, err)
+		}
+		if reparsed.String() != printed {
+			t.Fatalf("round trip mismatch for %q: %q != %q", query, reparsed.String(), printed)
+		}
+	})
+}
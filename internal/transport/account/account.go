@@ -0,0 +1,134 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/account"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/cookie"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/account"
+)
+
+//go:generate mockgen -source=account.go -destination=../../usecase/mocks/account_usecase_mock.go -package=mocks AccountUsecase
+type AccountUsecase interface {
+	ExportAccount(ctx context.Context, w io.Writer) error
+	DeleteAccount(ctx context.Context, req dto.DeleteAccountDTO) error
+}
+
+type AccountHandler struct {
+	uc     AccountUsecase
+	config *config.Config
+}
+
+func New(uc AccountUsecase, cfg *config.Config) *AccountHandler {
+	return &AccountHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// ExportAccount выгружает все данные пользователя
+// @Summary      Выгрузить данные аккаунта
+// @Description  Отдает zip-архив потоком: profile.json - профиль, projects.json - проекты пользователя с ролью, projects/{id}.json - выгрузка каждого проекта с задачами, заметками и участниками в формате повторного импорта, time_entries.json - записи учета времени пользователя
+// @Tags         user
+// @Produce      application/zip
+// @Success      200  {file}   file "Архив с данными"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /users/me/export [get]
+func (h *AccountHandler) ExportAccount(w http.ResponseWriter, r *http.Request) {
+	const op = "AccountHandler.ExportAccount"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	out := &archiveWriter{
+		w:        w,
+		filename: "course-todo-account-" + time.Now().UTC().Format(time.DateOnly) + ".zip",
+	}
+	if err := h.uc.ExportAccount(r.Context(), out); err != nil {
+		if out.started {
+			// Заголовки уже отправлены, клиент получит оборванный архив
+			logger.WithError(err).Error("export interrupted")
+			return
+		}
+		logger.WithError(err).Error("failed to export account")
+		handler.HandleError(r.Context(), w, err, "Failed to export account")
+	}
+}
+
+// DeleteAccount удаляет аккаунт текущего пользователя
+// @Summary      Удалить аккаунт
+// @Description  Удаляет аккаунт после повторного ввода пароля. Для каждого проекта, которым владеет пользователь, нужно явно выбрать действие: transfer - передать участнику new_owner_id, delete - удалить проект со всеми данными. Задачи, заметки, записи времени и вебхуки пользователя в остальных проектах переходят служебному пользователю "Deleted user", все сессии отзываются
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.DeleteAccountDTO true "Пароль и решения по проектам"
+// @Success      204  "Аккаунт удален"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос или новый владелец не участник проекта"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Неверный пароль или пользователь не владеет проектом"
+// @Failure      409  {object} dto.ErrorResponse "Не выбрано действие для проектов пользователя"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /users/me [delete]
+func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	const op = "AccountHandler.DeleteAccount"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	var req dto.DeleteAccountDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := validation.ValidationDeleteAccount(req); err != nil {
+		logger.Warn("delete account validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.uc.DeleteAccount(r.Context(), req); err != nil {
+		if errors.Is(err, errs.ErrInvalidCredentials) {
+			logger.Warn("invalid password")
+			response.SendError(r.Context(), w, http.StatusForbidden, "Invalid password")
+			return
+		}
+		logger.WithError(err).Warn("failed to delete account")
+		handler.HandleError(r.Context(), w, err, "Failed to delete account")
+		return
+	}
+
+	cookieProvider := cookie.NewCookieProvider(h.config)
+	cookieProvider.Unset(w, domains.TokenCookieName)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// archiveWriter отправляет заголовки ответа при первой записи, чтобы ошибки,
+// случившиеся до начала выгрузки, вернулись обычным JSON-ответом
+type archiveWriter struct {
+	w        http.ResponseWriter
+	filename string
+	started  bool
+}
+
+func (a *archiveWriter) Write(p []byte) (int, error) {
+	if !a.started {
+		a.started = true
+		a.w.Header().Set("Content-Type", "application/zip")
+		a.w.Header().Set("Content-Disposition", `attachment; filename="`+a.filename+`"`)
+		a.w.WriteHeader(http.StatusOK)
+	}
+	return a.w.Write(p)
+}
@@ -0,0 +1,144 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newAccountRequest(method string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, "/api/users/me", body)
+	ctx := logctx.WithLogger(req.Context(), logctx.NewLogger())
+	ctx = context.WithValue(ctx, domains.UserIDKey{}, uuid.New().String())
+	return req.WithContext(ctx)
+}
+
+func TestAccountHandler_ExportAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockAccountUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	mockUsecase.EXPECT().ExportAccount(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, w io.Writer) error {
+			_, err := io.WriteString(w, "PK")
+			return err
+		})
+
+	w := httptest.NewRecorder()
+	handler.ExportAccount(w, newAccountRequest(http.MethodGet, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "course-todo-account-")
+	assert.Equal(t, "PK", w.Body.String())
+
+	mockUsecase.EXPECT().ExportAccount(gomock.Any(), gomock.Any()).Return(errs.NewNotFoundError("user not found"))
+
+	w = httptest.NewRecorder()
+	handler.ExportAccount(w, newAccountRequest(http.MethodGet, nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
+
+func TestAccountHandler_DeleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockAccountUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	projectID := uuid.New()
+	body := fmt.Sprintf(`{"password": "secret", "projects": [{"project_id": %q, "action": "delete"}]}`, projectID)
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func()
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			body: body,
+			setupMocks: func() {
+				mockUsecase.EXPECT().DeleteAccount(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "missing password",
+			body:           `{"projects": []}`,
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "password is required",
+		},
+		{
+			name: "wrong password",
+			body: body,
+			setupMocks: func() {
+				mockUsecase.EXPECT().DeleteAccount(gomock.Any(), gomock.Any()).Return(errs.ErrInvalidCredentials)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   "Invalid password",
+		},
+		{
+			name: "unresolved projects",
+			body: body,
+			setupMocks: func() {
+				mockUsecase.EXPECT().DeleteAccount(gomock.Any(), gomock.Any()).
+					Return(fmt.Errorf("%w: %s", errs.ErrProjectsUnresolved, projectID))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   projectID.String(),
+		},
+		{
+			name: "new owner is not a member",
+			body: body,
+			setupMocks: func() {
+				mockUsecase.EXPECT().DeleteAccount(gomock.Any(), gomock.Any()).Return(errs.ErrNewOwnerNotMember)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "redis unavailable",
+			body: body,
+			setupMocks: func() {
+				mockUsecase.EXPECT().DeleteAccount(gomock.Any(), gomock.Any()).Return(errors.New("redis error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			w := httptest.NewRecorder()
+			handler.DeleteAccount(w, newAccountRequest(http.MethodDelete, strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.Contains(t, w.Header().Get("Set-Cookie"), domains.TokenCookieName+"=;")
+			}
+		})
+	}
+}
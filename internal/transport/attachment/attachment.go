@@ -0,0 +1,318 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/lzimin05/course-todo/config"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/attachment"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	"github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/attachment"
+)
+
+// Запас на заголовки и границы multipart поверх размера самого файла
+const multipartOverhead = 1 << 20
+
+//go:generate mockgen -source=attachment.go -destination=../../usecase/mocks/attachment_usecase_mock.go -package=mocks IAttachmentUsecase,SignedFileStorage
+type IAttachmentUsecase interface {
+	Upload(ctx context.Context, parentType string, parentID uuid.UUID, fileName string, content io.Reader) (*dto.AttachmentDTO, error)
+	GetAttachments(ctx context.Context, parentType string, parentID uuid.UUID) ([]*dto.AttachmentDTO, error)
+	GetDownloadURL(ctx context.Context, attachmentID uuid.UUID) (*dto.AttachmentURLDTO, error)
+	DeleteAttachment(ctx context.Context, attachmentID uuid.UUID) error
+	GetProjectUsage(ctx context.Context, projectID uuid.UUID) (*dto.AttachmentUsageDTO, error)
+}
+
+// SignedFileStorage - хранилище, которое отдает файлы через приложение
+// по подписанным ссылкам (локальный диск)
+type SignedFileStorage interface {
+	VerifySignedURL(key string, query url.Values) (string, string, error)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+type AttachmentHandler struct {
+	uc     IAttachmentUsecase
+	files  SignedFileStorage
+	config *config.Config
+}
+
+func New(uc IAttachmentUsecase, files SignedFileStorage, conf *config.Config) *AttachmentHandler {
+	return &AttachmentHandler{
+		uc:     uc,
+		files:  files,
+		config: conf,
+	}
+}
+
+// Upload загружает вложение
+// @Summary      Загрузить вложение
+// @Description  Прикрепляет файл к задаче или заметке. Файл передается в поле file формы multipart/form-data. Тип содержимого определяется по самому файлу
+// @Tags         attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        parent_type  query     string  true  "Тип сущности: task или note"
+// @Param        parent_id    query     string  true  "ID задачи или заметки"
+// @Param        file         formData  file    true  "Файл"
+// @Success      201  {object} dto.AttachmentDTO "Загруженное вложение"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача или заметка не найдена"
+// @Failure      413  {object} dto.ErrorResponse "Файл слишком большой или превышена квота проекта"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /attachments [post]
+func (h *AttachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	const op = "AttachmentHandler.Upload"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	parentType := r.URL.Query().Get("parent_type")
+	parentID, err := validation.ValidationAttachmentParent(parentType, r.URL.Query().Get("parent_id"))
+	if err != nil {
+		logger.WithError(err).Warn("invalid attachment parent")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.config.StorageConfig.MaxFileSize+multipartOverhead)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		logger.WithError(err).Warn("request is not multipart")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Request must be multipart/form-data")
+		return
+	}
+
+	// Файл читается из тела запроса потоком, без сохранения во временный файл
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				logger.Warn("file part is missing")
+				response.SendError(r.Context(), w, http.StatusBadRequest, "file is required")
+				return
+			}
+			h.handleUploadError(r.Context(), w, err)
+			return
+		}
+
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := h.uc.Upload(r.Context(), parentType, parentID, part.FileName(), part)
+		part.Close()
+		if err != nil {
+			h.handleUploadError(r.Context(), w, err)
+			return
+		}
+
+		response.SendJSONResponse(r.Context(), w, http.StatusCreated, attachment)
+		return
+	}
+}
+
+func (h *AttachmentHandler) handleUploadError(ctx context.Context, w http.ResponseWriter, err error) {
+	logger := logctx.GetLogger(ctx).WithField("op", "AttachmentHandler.Upload")
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		logger.WithError(err).Warn("request body is too large")
+		response.SendError(ctx, w, http.StatusRequestEntityTooLarge, "File is too large")
+		return
+	}
+
+	logger.WithError(err).Error("failed to upload attachment")
+	handler.HandleError(ctx, w, err, "Failed to upload attachment")
+}
+
+// GetAttachments получает вложения задачи или заметки
+// @Summary      Получить вложения
+// @Description  Возвращает вложения задачи или заметки в порядке загрузки
+// @Tags         attachments
+// @Produce      json
+// @Param        parent_type  query  string  true  "Тип сущности: task или note"
+// @Param        parent_id    query  string  true  "ID задачи или заметки"
+// @Success      200  {array}  dto.AttachmentDTO "Список вложений"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача или заметка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /attachments [get]
+func (h *AttachmentHandler) GetAttachments(w http.ResponseWriter, r *http.Request) {
+	const op = "AttachmentHandler.GetAttachments"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	parentType := r.URL.Query().Get("parent_type")
+	parentID, err := validation.ValidationAttachmentParent(parentType, r.URL.Query().Get("parent_id"))
+	if err != nil {
+		logger.WithError(err).Warn("invalid attachment parent")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachments, err := h.uc.GetAttachments(r.Context(), parentType, parentID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get attachments")
+		handler.HandleError(r.Context(), w, err, "Failed to get attachments")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, attachments)
+}
+
+// GetDownloadURL выдает ссылку на скачивание вложения
+// @Summary      Получить ссылку на скачивание
+// @Description  Возвращает подписанную ссылку на файл с ограниченным сроком действия
+// @Tags         attachments
+// @Produce      json
+// @Param        attachmentId  path  string  true  "ID вложения"
+// @Success      200  {object} dto.AttachmentURLDTO "Ссылка на скачивание"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Вложение не найдено"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /attachments/{attachmentId}/url [get]
+func (h *AttachmentHandler) GetDownloadURL(w http.ResponseWriter, r *http.Request) {
+	const op = "AttachmentHandler.GetDownloadURL"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	attachmentID, err := uuid.Parse(mux.Vars(r)["attachmentId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid attachment ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid attachment ID format")
+		return
+	}
+
+	link, err := h.uc.GetDownloadURL(r.Context(), attachmentID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get download url")
+		handler.HandleError(r.Context(), w, err, "Failed to get download url")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, link)
+}
+
+// DeleteAttachment удаляет вложение
+// @Summary      Удалить вложение
+// @Description  Удаляет вложение и его файл из хранилища
+// @Tags         attachments
+// @Param        attachmentId  path  string  true  "ID вложения"
+// @Success      204  "Вложение успешно удалено"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Вложение не найдено"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	const op = "AttachmentHandler.DeleteAttachment"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	attachmentID, err := uuid.Parse(mux.Vars(r)["attachmentId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid attachment ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid attachment ID format")
+		return
+	}
+
+	if err := h.uc.DeleteAttachment(r.Context(), attachmentID); err != nil {
+		logger.WithError(err).Error("failed to delete attachment")
+		handler.HandleError(r.Context(), w, err, "Failed to delete attachment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetProjectUsage получает занятое вложениями место в проекте
+// @Summary      Получить использование хранилища
+// @Description  Возвращает суммарный размер вложений проекта, квоту и максимальный размер файла в байтах
+// @Tags         attachments
+// @Produce      json
+// @Param        projectId  path  string  true  "ID проекта"
+// @Success      200  {object} dto.AttachmentUsageDTO "Использование хранилища"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/attachments/usage [get]
+func (h *AttachmentHandler) GetProjectUsage(w http.ResponseWriter, r *http.Request) {
+	const op = "AttachmentHandler.GetProjectUsage"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid project ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID format")
+		return
+	}
+
+	usage, err := h.uc.GetProjectUsage(r.Context(), projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get project usage")
+		handler.HandleError(r.Context(), w, err, "Failed to get project usage")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, usage)
+}
+
+// ServeFile отдает файл по подписанной ссылке. Используется только с локальным хранилищем,
+// авторизация не требуется: доступ подтверждается подписью ссылки
+// @Summary      Скачать файл
+// @Description  Отдает файл вложения по подписанной ссылке, полученной из /attachments/{attachmentId}/url
+// @Tags         attachments
+// @Produce      octet-stream
+// @Param        key        path   string  true  "Ключ файла"
+// @Param        name       query  string  true  "Имя файла"
+// @Param        type       query  string  true  "Тип содержимого"
+// @Param        expires    query  integer true  "Время истечения ссылки (unix)"
+// @Param        signature  query  string  true  "Подпись ссылки"
+// @Success      200  {file}   file "Содержимое файла"
+// @Failure      403  {object} dto.ErrorResponse "Ссылка недействительна или истекла"
+// @Failure      404  {object} dto.ErrorResponse "Файл не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /attachments/files/{key} [get]
+func (h *AttachmentHandler) ServeFile(w http.ResponseWriter, r *http.Request) {
+	const op = "AttachmentHandler.ServeFile"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	key := mux.Vars(r)["key"]
+	fileName, contentType, err := h.files.VerifySignedURL(key, r.URL.Query())
+	if err != nil {
+		logger.WithError(err).Warn("invalid signed url")
+		handler.HandleError(r.Context(), w, err, "Invalid download link")
+		return
+	}
+
+	file, err := h.files.Get(r.Context(), key)
+	if err != nil {
+		logger.WithError(err).Error("failed to open file")
+		handler.HandleError(r.Context(), w, err, "Failed to get file")
+		return
+	}
+	defer file.Close()
+
+	// Файлы всегда скачиваются, а не открываются в браузере, чтобы загруженный
+	// HTML не исполнялся в контексте приложения
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, file); err != nil {
+		logger.WithError(err).Warn("failed to write file")
+	}
+}
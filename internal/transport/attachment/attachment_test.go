@@ -0,0 +1,300 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/attachment"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newAttachmentRequest(method, url string, body io.Reader, vars map[string]string) *http.Request {
+	req := httptest.NewRequest(method, url, body)
+	ctx := logctx.WithLogger(req.Context(), logctx.NewLogger())
+	ctx = context.WithValue(ctx, domains.UserIDKey{}, uuid.New().String())
+	req = req.WithContext(ctx)
+	if vars != nil {
+		req = mux.SetURLVars(req, vars)
+	}
+	return req
+}
+
+func multipartBody(t *testing.T, field, fileName, content string) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	assert.NoError(t, writer.WriteField("comment", "ignored"))
+	part, err := writer.CreateFormFile(field, fileName)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	return &body, writer.FormDataContentType()
+}
+
+func testAttachmentConfig() *config.Config {
+	return &config.Config{StorageConfig: &config.StorageConfig{MaxFileSize: 100}}
+}
+
+func TestAttachmentHandler_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIAttachmentUsecase(ctrl)
+	handler := New(mockUsecase, nil, testAttachmentConfig())
+
+	taskID := uuid.New()
+
+	tests := []struct {
+		name           string
+		query          string
+		field          string
+		content        string
+		setupMocks     func()
+		expectedStatus int
+	}{
+		{
+			name:    "successful upload",
+			query:   "?parent_type=task&parent_id=" + taskID.String(),
+			field:   "file",
+			content: "hello",
+			setupMocks: func() {
+				mockUsecase.EXPECT().Upload(gomock.Any(), "task", taskID, "hello.txt", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, _ uuid.UUID, name string, r io.Reader) (*dto.AttachmentDTO, error) {
+						data, _ := io.ReadAll(r)
+						assert.Equal(t, "hello", string(data))
+						return &dto.AttachmentDTO{ID: uuid.New(), FileName: name, Size: int64(len(data))}, nil
+					})
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid parent type",
+			query:          "?parent_type=project&parent_id=" + taskID.String(),
+			field:          "file",
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid parent ID",
+			query:          "?parent_type=task&parent_id=invalid-uuid",
+			field:          "file",
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "file part is missing",
+			query:          "?parent_type=task&parent_id=" + taskID.String(),
+			field:          "other",
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "quota exceeded",
+			query:   "?parent_type=task&parent_id=" + taskID.String(),
+			field:   "file",
+			content: "hello",
+			setupMocks: func() {
+				mockUsecase.EXPECT().Upload(gomock.Any(), "task", taskID, gomock.Any(), gomock.Any()).Return(nil, errs.ErrQuotaExceeded)
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:    "body larger than request limit",
+			query:   "?parent_type=task&parent_id=" + taskID.String(),
+			field:   "file",
+			content: strings.Repeat("a", 100+multipartOverhead),
+			setupMocks: func() {
+				mockUsecase.EXPECT().Upload(gomock.Any(), "task", taskID, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, _ uuid.UUID, _ string, r io.Reader) (*dto.AttachmentDTO, error) {
+						_, err := io.Copy(io.Discard, r)
+						return nil, err
+					})
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			body, contentType := multipartBody(t, tt.field, "hello.txt", tt.content)
+			req := newAttachmentRequest(http.MethodPost, "/attachments"+tt.query, body, nil)
+			req.Header.Set("Content-Type", contentType)
+			rr := httptest.NewRecorder()
+			handler.Upload(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestAttachmentHandler_Upload_NotMultipart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := New(mocks.NewMockIAttachmentUsecase(ctrl), nil, testAttachmentConfig())
+
+	req := newAttachmentRequest(http.MethodPost, "/attachments?parent_type=note&parent_id="+uuid.New().String(),
+		strings.NewReader(`{}`), nil)
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler.Upload(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestAttachmentHandler_GetAttachments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIAttachmentUsecase(ctrl)
+	handler := New(mockUsecase, nil, testAttachmentConfig())
+
+	noteID := uuid.New()
+
+	tests := []struct {
+		name           string
+		query          string
+		setupMocks     func()
+		expectedStatus int
+	}{
+		{
+			name:  "successful get",
+			query: "?parent_type=note&parent_id=" + noteID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().GetAttachments(gomock.Any(), "note", noteID).
+					Return([]*dto.AttachmentDTO{{ID: uuid.New(), FileName: "a.png"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "note not found",
+			query: "?parent_type=note&parent_id=" + noteID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().GetAttachments(gomock.Any(), "note", noteID).Return(nil, errs.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "missing parent type",
+			query:          "?parent_id=" + noteID.String(),
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			req := newAttachmentRequest(http.MethodGet, "/attachments"+tt.query, nil, nil)
+			rr := httptest.NewRecorder()
+			handler.GetAttachments(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestAttachmentHandler_DeleteAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockIAttachmentUsecase(ctrl)
+	handler := New(mockUsecase, nil, testAttachmentConfig())
+
+	attachmentID := uuid.New()
+
+	tests := []struct {
+		name           string
+		attachmentID   string
+		setupMocks     func()
+		expectedStatus int
+	}{
+		{
+			name:         "successful delete",
+			attachmentID: attachmentID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().DeleteAttachment(gomock.Any(), attachmentID).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:         "attachment not found",
+			attachmentID: attachmentID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().DeleteAttachment(gomock.Any(), attachmentID).Return(errs.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid attachment ID",
+			attachmentID:   "invalid-uuid",
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			req := newAttachmentRequest(http.MethodDelete, "/attachments/"+tt.attachmentID, nil,
+				map[string]string{"attachmentId": tt.attachmentID})
+			rr := httptest.NewRecorder()
+			handler.DeleteAttachment(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestAttachmentHandler_ServeFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	files := mocks.NewMockSignedFileStorage(ctrl)
+	handler := New(mocks.NewMockIAttachmentUsecase(ctrl), files, testAttachmentConfig())
+
+	t.Run("valid signature", func(t *testing.T) {
+		files.EXPECT().VerifySignedURL("projects/p/a", gomock.Any()).Return("отчет.html", "text/html; charset=utf-8", nil)
+		files.EXPECT().Get(gomock.Any(), "projects/p/a").Return(io.NopCloser(strings.NewReader("<b>hi</b>")), nil)
+
+		req := newAttachmentRequest(http.MethodGet, "/api/attachments/files/projects/p/a?signature=x", nil,
+			map[string]string{"key": "projects/p/a"})
+		rr := httptest.NewRecorder()
+		handler.ServeFile(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "<b>hi</b>", rr.Body.String())
+		assert.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+		assert.True(t, strings.HasPrefix(rr.Header().Get("Content-Disposition"), "attachment;"))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		files.EXPECT().VerifySignedURL("projects/p/a", gomock.Any()).Return("", "", errs.ErrNoAccess)
+
+		req := newAttachmentRequest(http.MethodGet, "/api/attachments/files/projects/p/a", nil,
+			map[string]string{"key": "projects/p/a"})
+		rr := httptest.NewRecorder()
+		handler.ServeFile(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
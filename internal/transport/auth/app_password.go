@@ -0,0 +1,110 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/auth"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/auth"
+)
+
+// CreateAppPassword выпускает пароль приложения
+// @Summary      Создать пароль приложения
+// @Description  Выпускает пароль для CalDAV-клиентов, которые не умеют работать с JWT. В клиенте указывается логин (или email) и этот пароль. Пароль показывается только в этом ответе
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        password  body  dto.CreateAppPasswordRequest  true  "Название пароля"
+// @Success      201  {object} dto.AppPasswordDTO "Пароль приложения"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /auth/app-passwords [post]
+func (h *AuthHandler) CreateAppPassword(w http.ResponseWriter, r *http.Request) {
+	const op = "AuthHandler.CreateAppPassword"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	var req dto.CreateAppPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if err := validation.ValidateAppPasswordName(req.Name); err != nil {
+		logger.WithError(err).Warn("invalid app password name")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	password, err := h.uc.CreateAppPassword(r.Context(), req.Name)
+	if err != nil {
+		logger.WithError(err).Error("failed to create app password")
+		handler.HandleError(r.Context(), w, err, "Failed to create app password")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, password)
+}
+
+// GetAppPasswords возвращает пароли приложений пользователя
+// @Summary      Пароли приложений
+// @Description  Возвращает пароли приложений без самих паролей, с временем последнего использования
+// @Tags         auth
+// @Produce      json
+// @Success      200  {array}  dto.AppPasswordDTO "Пароли приложений"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /auth/app-passwords [get]
+func (h *AuthHandler) GetAppPasswords(w http.ResponseWriter, r *http.Request) {
+	const op = "AuthHandler.GetAppPasswords"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	passwords, err := h.uc.GetAppPasswords(r.Context())
+	if err != nil {
+		logger.WithError(err).Error("failed to get app passwords")
+		handler.HandleError(r.Context(), w, err, "Failed to get app passwords")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, passwords)
+}
+
+// DeleteAppPassword отзывает пароль приложения
+// @Summary      Отозвать пароль приложения
+// @Description  Удаляет пароль приложения, клиенты с ним перестают синхронизироваться
+// @Tags         auth
+// @Param        passwordId  path  string  true  "ID пароля приложения"
+// @Success      204  "Пароль удален"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Пароль не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /auth/app-passwords/{passwordId} [delete]
+func (h *AuthHandler) DeleteAppPassword(w http.ResponseWriter, r *http.Request) {
+	const op = "AuthHandler.DeleteAppPassword"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	id, err := uuid.Parse(mux.Vars(r)["passwordId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid app password ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid app password ID")
+		return
+	}
+
+	if err := h.uc.DeleteAppPassword(r.Context(), id); err != nil {
+		logger.WithError(err).Error("failed to delete app password")
+		handler.HandleError(r.Context(), w, err, "Failed to delete app password")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
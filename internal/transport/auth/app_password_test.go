@@ -0,0 +1,81 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/auth"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestAuthHandler_CreateAppPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func()
+		expectedStatus int
+	}{
+		{
+			name: "created",
+			body: `{"name":"iPhone"}`,
+			setupMock: func() {
+				mockUsecase.EXPECT().CreateAppPassword(gomock.Any(), "iPhone").
+					Return(&dto.AppPasswordDTO{ID: uuid.New(), Name: "iPhone", Password: "abcd-efgh"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "empty name",
+			body:           `{"name":""}`,
+			setupMock:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/app-passwords", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.CreateAppPassword(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestAuthHandler_DeleteAppPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/auth/app-passwords/{passwordId}", handler.DeleteAppPassword).Methods(http.MethodDelete)
+
+	id := uuid.New()
+	mockUsecase.EXPECT().DeleteAppPassword(gomock.Any(), id).Return(errs.NewNotFoundError("app password not found"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/auth/app-passwords/"+id.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/lzimin05/course-todo/config"
 	"github.com/lzimin05/course-todo/internal/models/domains"
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
@@ -21,6 +22,9 @@ type AuthUsecase interface {
 	Authenticate(ctx context.Context, login_or_email, password string) (string, error)
 	Register(ctx context.Context, login, username, email, password string) (string, error)
 	Logout(ctx context.Context, token string) error
+	CreateAppPassword(ctx context.Context, name string) (*dto.AppPasswordDTO, error)
+	GetAppPasswords(ctx context.Context) ([]dto.AppPasswordDTO, error)
+	DeleteAppPassword(ctx context.Context, id uuid.UUID) error
 }
 
 type AuthHandler struct {
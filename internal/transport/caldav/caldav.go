@@ -0,0 +1,506 @@
+package transport
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/caldav"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+)
+
+const (
+	RootPath = "/caldav/"
+	homePath = RootPath + "projects/"
+
+	methodPropfind = "PROPFIND"
+	methodReport   = "REPORT"
+
+	maxObjectSize = 1 << 20
+
+	calendarContentType = "text/calendar; charset=utf-8"
+	objectContentType   = "text/calendar; charset=utf-8; component=VTODO"
+)
+
+//go:generate mockgen -source=caldav.go -destination=../../usecase/mocks/caldav_usecase_mock.go -package=mocks CalDAVUsecase
+type CalDAVUsecase interface {
+	GetPrincipal(ctx context.Context) (*models.Principal, error)
+	GetCollections(ctx context.Context) ([]models.Collection, error)
+	GetCollection(ctx context.Context, projectID uuid.UUID) (*models.Collection, error)
+	GetObjects(ctx context.Context, projectID uuid.UUID) ([]models.Object, error)
+	GetObject(ctx context.Context, projectID uuid.UUID, name string) (*models.Object, error)
+	GetObjectsByNames(ctx context.Context, projectID uuid.UUID, names []string) ([]models.Object, error)
+	GetChanges(ctx context.Context, projectID uuid.UUID, syncToken string) (*models.Changes, error)
+	PutObject(ctx context.Context, projectID uuid.UUID, name string, data []byte, cond models.Preconditions) (bool, error)
+	DeleteObject(ctx context.Context, projectID uuid.UUID, name string, cond models.Preconditions) error
+	CalendarData(o models.Object) []byte
+}
+
+// CalDAVHandler - минимальный CalDAV-сервер (RFC 4791): проекты пользователя
+// видны как календари задач VTODO. Маршруты не описаны в swagger, это WebDAV
+type CalDAVHandler struct {
+	uc     CalDAVUsecase
+	config *config.Config
+}
+
+func New(uc CalDAVUsecase, cfg *config.Config) *CalDAVHandler {
+	return &CalDAVHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// WellKnown перенаправляет клиентов с /.well-known/caldav (RFC 6764)
+func (h *CalDAVHandler) WellKnown(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, RootPath, http.StatusMovedPermanently)
+}
+
+// Principal обслуживает /caldav/ - ресурс текущего пользователя
+func (h *CalDAVHandler) Principal(w http.ResponseWriter, r *http.Request) {
+	const op = "CalDAVHandler.Principal"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	switch r.Method {
+	case http.MethodOptions:
+		writeOptions(w, "OPTIONS, PROPFIND")
+		return
+	case methodPropfind:
+	default:
+		methodNotAllowed(w, "OPTIONS, PROPFIND")
+		return
+	}
+
+	requested, ok := h.decodePropfind(w, r)
+	if !ok {
+		return
+	}
+
+	principal, err := h.uc.GetPrincipal(r.Context())
+	if err != nil {
+		logger.WithError(err).Error("failed to get principal")
+		h.handleError(w, r, err, "Failed to get principal")
+		return
+	}
+
+	responses := []davResponse{principalResource(principal).response(requested)}
+	if depth(r) > 0 {
+		responses = append(responses, homeResource().response(requested))
+	}
+	h.writeMultistatus(w, r, newMultistatus(responses))
+}
+
+// Home обслуживает /caldav/projects/ - набор календарей пользователя
+func (h *CalDAVHandler) Home(w http.ResponseWriter, r *http.Request) {
+	const op = "CalDAVHandler.Home"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	switch r.Method {
+	case http.MethodOptions:
+		writeOptions(w, "OPTIONS, PROPFIND")
+		return
+	case methodPropfind:
+	default:
+		methodNotAllowed(w, "OPTIONS, PROPFIND")
+		return
+	}
+
+	requested, ok := h.decodePropfind(w, r)
+	if !ok {
+		return
+	}
+
+	responses := []davResponse{homeResource().response(requested)}
+	if depth(r) > 0 {
+		collections, err := h.uc.GetCollections(r.Context())
+		if err != nil {
+			logger.WithError(err).Error("failed to get collections")
+			h.handleError(w, r, err, "Failed to get calendars")
+			return
+		}
+		for _, c := range collections {
+			responses = append(responses, collectionResource(c).response(requested))
+		}
+	}
+	h.writeMultistatus(w, r, newMultistatus(responses))
+}
+
+// Collection обслуживает календарь проекта: PROPFIND и отчеты REPORT
+func (h *CalDAVHandler) Collection(w http.ResponseWriter, r *http.Request) {
+	const op = "CalDAVHandler.Collection"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	const allow = "OPTIONS, PROPFIND, REPORT"
+	projectID, ok := projectIDFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodOptions:
+		writeOptions(w, allow)
+	case methodPropfind:
+		requested, ok := h.decodePropfind(w, r)
+		if !ok {
+			return
+		}
+
+		collection, err := h.uc.GetCollection(r.Context(), projectID)
+		if err != nil {
+			logger.WithError(err).Warn("failed to get collection")
+			h.handleError(w, r, err, "Failed to get calendar")
+			return
+		}
+
+		responses := []davResponse{collectionResource(*collection).response(requested)}
+		if depth(r) > 0 {
+			objects, err := h.uc.GetObjects(r.Context(), projectID)
+			if err != nil {
+				logger.WithError(err).Error("failed to get objects")
+				h.handleError(w, r, err, "Failed to get calendar objects")
+				return
+			}
+			for _, o := range objects {
+				responses = append(responses, h.objectResource(o).response(requested))
+			}
+		}
+		h.writeMultistatus(w, r, newMultistatus(responses))
+	case methodReport:
+		h.report(w, r, projectID)
+	default:
+		methodNotAllowed(w, allow)
+	}
+}
+
+// Object обслуживает задачу как ресурс календаря. ETag ресурса - номер
+// последнего изменения задачи, PUT и DELETE проверяют If-Match и If-None-Match
+func (h *CalDAVHandler) Object(w http.ResponseWriter, r *http.Request) {
+	const op = "CalDAVHandler.Object"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	const allow = "OPTIONS, GET, PUT, DELETE, PROPFIND"
+	projectID, ok := projectIDFromPath(w, r)
+	if !ok {
+		return
+	}
+	name := mux.Vars(r)["name"]
+
+	switch r.Method {
+	case http.MethodOptions:
+		writeOptions(w, allow)
+	case http.MethodGet, http.MethodHead:
+		object, err := h.uc.GetObject(r.Context(), projectID, name)
+		if err != nil {
+			logger.WithError(err).Warn("failed to get object")
+			h.handleError(w, r, err, "Failed to get calendar object")
+			return
+		}
+
+		w.Header().Set("Content-Type", calendarContentType)
+		w.Header().Set("ETag", etag(object.SyncSeq))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			if _, err := w.Write(h.uc.CalendarData(*object)); err != nil {
+				logger.WithError(err).Error("failed to write calendar object")
+			}
+		}
+	case http.MethodPut:
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxObjectSize))
+		if err != nil {
+			logger.WithError(err).Warn("failed to read calendar object")
+			response.SendError(r.Context(), w, http.StatusRequestEntityTooLarge, "Calendar object is too large")
+			return
+		}
+
+		created, err := h.uc.PutObject(r.Context(), projectID, name, data, preconditions(r))
+		if err != nil {
+			logger.WithError(err).Warn("failed to put object")
+			h.handleError(w, r, err, "Failed to save calendar object")
+			return
+		}
+
+		// ETag не возвращается: сервер хранит не весь VTODO, и клиент
+		// должен перечитать ресурс (RFC 4791, раздел 5.3.4)
+		if created {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	case http.MethodDelete:
+		if err := h.uc.DeleteObject(r.Context(), projectID, name, preconditions(r)); err != nil {
+			logger.WithError(err).Warn("failed to delete object")
+			h.handleError(w, r, err, "Failed to delete calendar object")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case methodPropfind:
+		requested, ok := h.decodePropfind(w, r)
+		if !ok {
+			return
+		}
+
+		object, err := h.uc.GetObject(r.Context(), projectID, name)
+		if err != nil {
+			logger.WithError(err).Warn("failed to get object")
+			h.handleError(w, r, err, "Failed to get calendar object")
+			return
+		}
+		h.writeMultistatus(w, r, newMultistatus([]davResponse{h.objectResource(*object).response(requested)}))
+	default:
+		methodNotAllowed(w, allow)
+	}
+}
+
+func (h *CalDAVHandler) report(w http.ResponseWriter, r *http.Request, projectID uuid.UUID) {
+	const op = "CalDAVHandler.report"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	var req reportRequest
+	if ok, err := decodeXML(r, &req); err != nil || !ok {
+		logger.WithError(err).Warn("invalid report request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid report request")
+		return
+	}
+	requested := req.props()
+
+	switch req.XMLName {
+	case reportCalendarQuery:
+		var responses []davResponse
+		if req.Filter.matchesTodo() {
+			objects, err := h.uc.GetObjects(r.Context(), projectID)
+			if err != nil {
+				logger.WithError(err).Error("failed to get objects")
+				h.handleError(w, r, err, "Failed to get calendar objects")
+				return
+			}
+			for _, o := range objects {
+				responses = append(responses, h.objectResource(o).response(requested))
+			}
+		}
+		h.writeMultistatus(w, r, newMultistatus(responses))
+	case reportCalendarMultiget:
+		var names []string
+		for _, href := range req.Hrefs {
+			if name, ok := objectName(href, projectID); ok {
+				names = append(names, name)
+			}
+		}
+
+		objects, err := h.uc.GetObjectsByNames(r.Context(), projectID, names)
+		if err != nil {
+			logger.WithError(err).Error("failed to get objects by names")
+			h.handleError(w, r, err, "Failed to get calendar objects")
+			return
+		}
+
+		found := make(map[string]bool, len(objects))
+		var responses []davResponse
+		for _, o := range objects {
+			found[o.Name] = true
+			responses = append(responses, h.objectResource(o).response(requested))
+		}
+		for _, href := range req.Hrefs {
+			if name, ok := objectName(href, projectID); !ok || !found[name] {
+				responses = append(responses, davResponse{Href: href, Status: statusLine(http.StatusNotFound)})
+			}
+		}
+		h.writeMultistatus(w, r, newMultistatus(responses))
+	case reportSyncCollection:
+		changes, err := h.uc.GetChanges(r.Context(), projectID, req.SyncToken)
+		if err != nil {
+			logger.WithError(err).Warn("failed to get changes")
+			h.handleError(w, r, err, "Failed to get calendar changes")
+			return
+		}
+
+		var responses []davResponse
+		for _, o := range changes.Objects {
+			responses = append(responses, h.objectResource(o).response(requested))
+		}
+		for _, name := range changes.Deleted {
+			responses = append(responses, davResponse{
+				Href:   objectHref(projectID, name),
+				Status: statusLine(http.StatusNotFound),
+			})
+		}
+		ms := newMultistatus(responses)
+		ms.SyncToken = models.SyncToken(changes.SyncSeq)
+		h.writeMultistatus(w, r, ms)
+	default:
+		logger.WithField("report", req.XMLName.Local).Warn("unsupported report")
+		response.SendError(r.Context(), w, http.StatusForbidden, "Unsupported report")
+	}
+}
+
+// decodePropfind возвращает запрошенные свойства, nil - allprop.
+// propname не поддерживается и обрабатывается как allprop
+func (h *CalDAVHandler) decodePropfind(w http.ResponseWriter, r *http.Request) ([]xml.Name, bool) {
+	var req propfindRequest
+	ok, err := decodeXML(r, &req)
+	if err != nil {
+		logctx.GetLogger(r.Context()).WithError(err).Warn("invalid propfind request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid propfind request")
+		return nil, false
+	}
+	if !ok || req.Prop == nil {
+		return nil, true
+	}
+	return req.Prop.names(), true
+}
+
+func (h *CalDAVHandler) writeMultistatus(w http.ResponseWriter, r *http.Request, ms *multistatus) {
+	if err := writeXML(w, http.StatusMultiStatus, ms); err != nil {
+		logctx.GetLogger(r.Context()).WithError(err).Error("failed to write multistatus")
+	}
+}
+
+// handleError отвечает на устаревший sync-token предусловием из RFC 6578,
+// по которому клиент начинает синхронизацию заново
+func (h *CalDAVHandler) handleError(w http.ResponseWriter, r *http.Request, err error, defaultMsg string) {
+	if errors.Is(err, errs.ErrInvalidSyncToken) {
+		body := davError{DAV: nsDAV, Condition: property{XMLName: xml.Name{Local: "D:valid-sync-token"}}}
+		if err := writeXML(w, http.StatusForbidden, body); err != nil {
+			logctx.GetLogger(r.Context()).WithError(err).Error("failed to write error")
+		}
+		return
+	}
+	handler.HandleError(r.Context(), w, err, defaultMsg)
+}
+
+func (h *CalDAVHandler) objectResource(o models.Object) *resource {
+	res := newResource(objectHref(o.ProjectID, o.Name))
+	res.set(propResourceType, true, constant(""))
+	res.set(propGetETag, true, constant(etag(o.SyncSeq)))
+	res.set(propGetContentType, true, constant(objectContentType))
+	res.set(propCalendarData, false, func() string {
+		return escapeText(string(h.uc.CalendarData(o)))
+	})
+	return res
+}
+
+func principalResource(p *models.Principal) *resource {
+	res := newResource(RootPath)
+	res.set(propResourceType, true, constant("<D:collection/><D:principal/>"))
+	res.set(propDisplayName, true, constant(escapeText(p.DisplayName)))
+	res.set(propCurrentUserPrincipal, true, constant(hrefValue(RootPath)))
+	res.set(propPrincipalURL, true, constant(hrefValue(RootPath)))
+	res.set(propCalendarHomeSet, true, constant(hrefValue(homePath)))
+	res.set(propCalendarUserAddressSet, true, constant(hrefValue("mailto:"+p.Email)))
+	return res
+}
+
+func homeResource() *resource {
+	res := newResource(homePath)
+	res.set(propResourceType, true, constant("<D:collection/>"))
+	res.set(propCurrentUserPrincipal, true, constant(hrefValue(RootPath)))
+	return res
+}
+
+func collectionResource(c models.Collection) *resource {
+	res := newResource(collectionHref(c.ProjectID))
+	res.set(propResourceType, true, constant("<D:collection/><C:calendar/>"))
+	res.set(propDisplayName, true, constant(escapeText(c.Name)))
+	res.set(propCalendarDescription, true, constant(escapeText(c.Description)))
+	res.set(propSupportedComponentSet, true, constant(`<C:comp name="VTODO"/>`))
+	res.set(propGetCTag, true, constant(escapeText(models.SyncToken(c.SyncSeq))))
+	res.set(propSyncToken, true, constant(escapeText(models.SyncToken(c.SyncSeq))))
+	res.set(propCurrentUserPrincipal, false, constant(hrefValue(RootPath)))
+	res.set(propCurrentUserPrivilegeSet, false, constant(
+		"<D:privilege><D:read/></D:privilege>"+
+			"<D:privilege><D:write/></D:privilege>"+
+			"<D:privilege><D:write-content/></D:privilege>"+
+			"<D:privilege><D:bind/></D:privilege>"+
+			"<D:privilege><D:unbind/></D:privilege>"))
+	res.set(propSupportedReportSet, false, constant(
+		"<D:supported-report><D:report><C:calendar-query/></D:report></D:supported-report>"+
+			"<D:supported-report><D:report><C:calendar-multiget/></D:report></D:supported-report>"+
+			"<D:supported-report><D:report><D:sync-collection/></D:report></D:supported-report>"))
+	return res
+}
+
+func constant(value string) func() string {
+	return func() string { return value }
+}
+
+func collectionHref(projectID uuid.UUID) string {
+	return homePath + projectID.String() + "/"
+}
+
+func objectHref(projectID uuid.UUID, name string) string {
+	return collectionHref(projectID) + url.PathEscape(name)
+}
+
+// objectName извлекает имя ресурса из href calendar-multiget. Ссылки на
+// другие коллекции не обслуживаются
+func objectName(href string, projectID uuid.UUID) (string, bool) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+	name, ok := strings.CutPrefix(u.Path, collectionHref(projectID))
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return "", false
+	}
+	return name, true
+}
+
+func projectIDFromPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		response.SendError(r.Context(), w, http.StatusNotFound, "Resource not found")
+		return uuid.Nil, false
+	}
+	return projectID, true
+}
+
+func etag(syncSeq int64) string {
+	return `"` + strconv.FormatInt(syncSeq, 10) + `"`
+}
+
+// preconditions разбирает If-Match и If-None-Match. Чужой ETag не совпадет
+// ни с одним ресурсом
+func preconditions(r *http.Request) models.Preconditions {
+	var cond models.Preconditions
+
+	if value := strings.TrimSpace(r.Header.Get("If-Match")); value == "*" {
+		cond.IfMatchAny = true
+	} else if value != "" {
+		seq, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(value, "W/"), `"`), 10, 64)
+		if err != nil {
+			seq = -1
+		}
+		cond.IfMatch = &seq
+	}
+	cond.IfNoneMatchAny = strings.TrimSpace(r.Header.Get("If-None-Match")) == "*"
+
+	return cond
+}
+
+// depth - заголовок Depth. infinity обрабатывается как 1
+func depth(r *http.Request) int {
+	if r.Header.Get("Depth") == "0" {
+		return 0
+	}
+	return 1
+}
+
+func writeOptions(w http.ResponseWriter, allow string) {
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	w.Header().Set("Allow", allow)
+	w.WriteHeader(http.StatusOK)
+}
+
+func methodNotAllowed(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	w.WriteHeader(http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,270 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/caldav"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newRouter(h *CalDAVHandler) *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/caldav/", h.Principal)
+	router.HandleFunc("/caldav/projects/", h.Home)
+	router.HandleFunc("/caldav/projects/{projectId}/", h.Collection)
+	router.HandleFunc("/caldav/projects/{projectId}/{name}", h.Object)
+	return router
+}
+
+func TestCalDAVTransport_PropfindHome(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCalDAVUsecase(ctrl)
+	router := newRouter(New(mockUsecase, &config.Config{}))
+
+	projectID := uuid.New()
+	mockUsecase.EXPECT().GetCollections(gomock.Any()).
+		Return([]models.Collection{{ProjectID: projectID, Name: "Работа & дом", SyncSeq: 42}}, nil)
+
+	body := `<?xml version="1.0"?>
+<d:propfind xmlns:d="DAV:" xmlns:cs="http://calendarserver.org/ns/" xmlns:x="http://apple.com/ns/ical/">
+  <d:prop><d:displayname/><cs:getctag/><d:resourcetype/><x:calendar-color/></d:prop>
+</d:propfind>`
+	req := httptest.NewRequest("PROPFIND", "/caldav/projects/", strings.NewReader(body))
+	req.Header.Set("Depth", "1")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusMultiStatus, rr.Code)
+	got := rr.Body.String()
+	assert.Contains(t, got, `<D:href>/caldav/projects/`+projectID.String()+`/</D:href>`)
+	assert.Contains(t, got, `<D:displayname>Работа &amp; дом</D:displayname>`)
+	assert.Contains(t, got, `<CS:getctag>urn:course-todo:sync:42</CS:getctag>`)
+	assert.Contains(t, got, `<D:resourcetype><D:collection/><C:calendar/></D:resourcetype>`)
+	// Неизвестное свойство возвращается со статусом 404
+	assert.Contains(t, got, `<calendar-color xmlns="http://apple.com/ns/ical/"></calendar-color>`)
+	assert.Contains(t, got, `HTTP/1.1 404 Not Found`)
+}
+
+func TestCalDAVTransport_Report(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCalDAVUsecase(ctrl)
+	router := newRouter(New(mockUsecase, &config.Config{}))
+
+	projectID := uuid.New()
+	object := models.Object{ProjectID: projectID, Name: "a b.ics", SyncSeq: 7}
+	collectionPath := "/caldav/projects/" + projectID.String() + "/"
+
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func()
+		statusCode int
+		contains   []string
+	}{
+		{
+			name: "Calendar query for todos",
+			body: `<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO"/></c:comp-filter></c:filter>
+</c:calendar-query>`,
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetObjects(gomock.Any(), projectID).Return([]models.Object{object}, nil)
+				mockUsecase.EXPECT().CalendarData(object).Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"))
+			},
+			statusCode: http.StatusMultiStatus,
+			contains: []string{
+				`<D:href>` + collectionPath + `a%20b.ics</D:href>`,
+				`<D:getetag>"7"</D:getetag>`,
+				`<C:calendar-data>BEGIN:VCALENDAR&#xD;&#xA;END:VCALENDAR&#xD;&#xA;</C:calendar-data>`,
+			},
+		},
+		{
+			name: "Calendar query for events",
+			body: `<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VEVENT"/></c:comp-filter></c:filter>
+</c:calendar-query>`,
+			mockFunc:   func() {},
+			statusCode: http.StatusMultiStatus,
+		},
+		{
+			name: "Multiget with missing object",
+			body: `<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/></d:prop>
+  <d:href>` + collectionPath + `a%20b.ics</d:href>
+  <d:href>` + collectionPath + `missing.ics</d:href>
+</c:calendar-multiget>`,
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetObjectsByNames(gomock.Any(), projectID, []string{"a b.ics", "missing.ics"}).
+					Return([]models.Object{object}, nil)
+			},
+			statusCode: http.StatusMultiStatus,
+			contains: []string{
+				`<D:response><D:href>` + collectionPath + `missing.ics</D:href><D:status>HTTP/1.1 404 Not Found</D:status></D:response>`,
+			},
+		},
+		{
+			name: "Sync collection",
+			body: `<d:sync-collection xmlns:d="DAV:">
+  <d:sync-token>urn:course-todo:sync:5</d:sync-token>
+  <d:sync-level>1</d:sync-level>
+  <d:prop><d:getetag/></d:prop>
+</d:sync-collection>`,
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetChanges(gomock.Any(), projectID, "urn:course-todo:sync:5").
+					Return(&models.Changes{Objects: []models.Object{object}, Deleted: []string{"old.ics"}, SyncSeq: 9}, nil)
+			},
+			statusCode: http.StatusMultiStatus,
+			contains: []string{
+				`<D:href>` + collectionPath + `old.ics</D:href><D:status>HTTP/1.1 404 Not Found</D:status>`,
+				`<D:sync-token>urn:course-todo:sync:9</D:sync-token>`,
+			},
+		},
+		{
+			name: "Invalid sync token",
+			body: `<d:sync-collection xmlns:d="DAV:"><d:sync-token>bogus</d:sync-token></d:sync-collection>`,
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetChanges(gomock.Any(), projectID, "bogus").Return(nil, errs.ErrInvalidSyncToken)
+			},
+			statusCode: http.StatusForbidden,
+			contains:   []string{`<D:valid-sync-token></D:valid-sync-token>`},
+		},
+		{
+			name:       "Empty body",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest("REPORT", collectionPath, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			for _, s := range tt.contains {
+				assert.Contains(t, rr.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestCalDAVTransport_Object(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCalDAVUsecase(ctrl)
+	router := newRouter(New(mockUsecase, &config.Config{}))
+
+	projectID := uuid.New()
+	path := "/caldav/projects/" + projectID.String() + "/abc.ics"
+	data := "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"
+
+	tests := []struct {
+		name       string
+		method     string
+		headers    map[string]string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:   "Get",
+			method: http.MethodGet,
+			mockFunc: func() {
+				object := &models.Object{Name: "abc.ics", SyncSeq: 3}
+				mockUsecase.EXPECT().GetObject(gomock.Any(), projectID, "abc.ics").Return(object, nil)
+				mockUsecase.EXPECT().CalendarData(*object).Return([]byte(data))
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:    "Create",
+			method:  http.MethodPut,
+			headers: map[string]string{"If-None-Match": "*"},
+			mockFunc: func() {
+				mockUsecase.EXPECT().PutObject(gomock.Any(), projectID, "abc.ics", []byte(data),
+					models.Preconditions{IfNoneMatchAny: true}).Return(true, nil)
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:    "Update with stale etag",
+			method:  http.MethodPut,
+			headers: map[string]string{"If-Match": `"3"`},
+			mockFunc: func() {
+				seq := int64(3)
+				mockUsecase.EXPECT().PutObject(gomock.Any(), projectID, "abc.ics", []byte(data),
+					models.Preconditions{IfMatch: &seq}).Return(false, errs.ErrVersionMismatch)
+			},
+			statusCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "Invalid calendar",
+			method: http.MethodPut,
+			mockFunc: func() {
+				mockUsecase.EXPECT().PutObject(gomock.Any(), projectID, "abc.ics", []byte(data),
+					models.Preconditions{}).Return(false, errs.ErrInvalidCalendar)
+			},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Delete",
+			method: http.MethodDelete,
+			mockFunc: func() {
+				mockUsecase.EXPECT().DeleteObject(gomock.Any(), projectID, "abc.ics", models.Preconditions{}).Return(nil)
+			},
+			statusCode: http.StatusNoContent,
+		},
+		{
+			name:   "Delete missing",
+			method: http.MethodDelete,
+			mockFunc: func() {
+				mockUsecase.EXPECT().DeleteObject(gomock.Any(), projectID, "abc.ics", models.Preconditions{}).
+					Return(errs.NewNotFoundError("calendar object not found"))
+			},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Method not allowed",
+			method:     http.MethodPost,
+			mockFunc:   func() {},
+			statusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(tt.method, path, strings.NewReader(data))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			if tt.method == http.MethodGet {
+				assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+				assert.Equal(t, data, rr.Body.String())
+			}
+		})
+	}
+}
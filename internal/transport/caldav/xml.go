@@ -0,0 +1,256 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+	nsCS     = "http://calendarserver.org/ns/"
+
+	maxXMLBodySize = 1 << 20
+)
+
+// Префиксы объявляются в корне multistatus, свойства из других пространств
+// имен выводятся с собственным xmlns
+var namespacePrefixes = map[string]string{
+	nsDAV:    "D",
+	nsCalDAV: "C",
+	nsCS:     "CS",
+}
+
+var (
+	propResourceType            = xml.Name{Space: nsDAV, Local: "resourcetype"}
+	propDisplayName             = xml.Name{Space: nsDAV, Local: "displayname"}
+	propCurrentUserPrincipal    = xml.Name{Space: nsDAV, Local: "current-user-principal"}
+	propPrincipalURL            = xml.Name{Space: nsDAV, Local: "principal-URL"}
+	propCurrentUserPrivilegeSet = xml.Name{Space: nsDAV, Local: "current-user-privilege-set"}
+	propSupportedReportSet      = xml.Name{Space: nsDAV, Local: "supported-report-set"}
+	propSyncToken               = xml.Name{Space: nsDAV, Local: "sync-token"}
+	propGetETag                 = xml.Name{Space: nsDAV, Local: "getetag"}
+	propGetContentType          = xml.Name{Space: nsDAV, Local: "getcontenttype"}
+
+	propCalendarHomeSet        = xml.Name{Space: nsCalDAV, Local: "calendar-home-set"}
+	propCalendarUserAddressSet = xml.Name{Space: nsCalDAV, Local: "calendar-user-address-set"}
+	propCalendarDescription    = xml.Name{Space: nsCalDAV, Local: "calendar-description"}
+	propSupportedComponentSet  = xml.Name{Space: nsCalDAV, Local: "supported-calendar-component-set"}
+	propCalendarData           = xml.Name{Space: nsCalDAV, Local: "calendar-data"}
+
+	propGetCTag = xml.Name{Space: nsCS, Local: "getctag"}
+)
+
+var (
+	reportCalendarQuery    = xml.Name{Space: nsCalDAV, Local: "calendar-query"}
+	reportCalendarMultiget = xml.Name{Space: nsCalDAV, Local: "calendar-multiget"}
+	reportSyncCollection   = xml.Name{Space: nsDAV, Local: "sync-collection"}
+)
+
+type anyElement struct {
+	XMLName xml.Name
+}
+
+type propList struct {
+	Names []anyElement `xml:",any"`
+}
+
+func (p *propList) names() []xml.Name {
+	names := make([]xml.Name, len(p.Names))
+	for i, n := range p.Names {
+		names[i] = n.XMLName
+	}
+	return names
+}
+
+type propfindRequest struct {
+	XMLName  xml.Name  `xml:"DAV: propfind"`
+	AllProp  *struct{} `xml:"DAV: allprop"`
+	PropName *struct{} `xml:"DAV: propname"`
+	Prop     *propList `xml:"DAV: prop"`
+}
+
+type compFilter struct {
+	Name        string       `xml:"name,attr"`
+	CompFilters []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type queryFilter struct {
+	CompFilter *compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// reportRequest - общий вид calendar-query, calendar-multiget и sync-collection,
+// тип отчета определяется корневым элементом
+type reportRequest struct {
+	XMLName   xml.Name
+	AllProp   *struct{}    `xml:"DAV: allprop"`
+	Prop      *propList    `xml:"DAV: prop"`
+	Hrefs     []string     `xml:"DAV: href"`
+	SyncToken string       `xml:"DAV: sync-token"`
+	Filter    *queryFilter `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+func (r *reportRequest) props() []xml.Name {
+	if r.Prop == nil || r.AllProp != nil {
+		return nil
+	}
+	return r.Prop.names()
+}
+
+// matchesTodo проверяет фильтр calendar-query. Учитываются только фильтры
+// компонентов: условия на время и свойства не сужают выборку, клиенты
+// отбрасывают лишнее сами
+func (f *queryFilter) matchesTodo() bool {
+	if f == nil || f.CompFilter == nil {
+		return true
+	}
+	if f.CompFilter.Name != "VCALENDAR" {
+		return false
+	}
+	if len(f.CompFilter.CompFilters) == 0 {
+		return true
+	}
+	for _, c := range f.CompFilter.CompFilters {
+		if c.Name == "VTODO" {
+			return true
+		}
+	}
+	return false
+}
+
+type property struct {
+	XMLName xml.Name
+	Value   string `xml:",innerxml"`
+}
+
+type prop struct {
+	Properties []property
+}
+
+type propstat struct {
+	Prop   prop   `xml:"D:prop"`
+	Status string `xml:"D:status"`
+}
+
+type davResponse struct {
+	Href      string     `xml:"D:href"`
+	Propstats []propstat `xml:"D:propstat,omitempty"`
+	Status    string     `xml:"D:status,omitempty"`
+}
+
+type multistatus struct {
+	XMLName   xml.Name      `xml:"D:multistatus"`
+	DAV       string        `xml:"xmlns:D,attr"`
+	CalDAV    string        `xml:"xmlns:C,attr"`
+	CS        string        `xml:"xmlns:CS,attr"`
+	Responses []davResponse `xml:"D:response"`
+	SyncToken string        `xml:"D:sync-token,omitempty"`
+}
+
+type davError struct {
+	XMLName   xml.Name `xml:"D:error"`
+	DAV       string   `xml:"xmlns:D,attr"`
+	Condition property
+}
+
+// resource - свойства ресурса. Значения вычисляются только для запрошенных
+// свойств: calendar-data нужен не в каждом ответе
+type resource struct {
+	href  string
+	names []xml.Name
+	props map[xml.Name]func() string
+}
+
+func newResource(href string) *resource {
+	return &resource{href: href, props: make(map[xml.Name]func() string)}
+}
+
+// set добавляет свойство. inAllProp - свойство выводится в ответ на allprop
+func (res *resource) set(name xml.Name, inAllProp bool, value func() string) {
+	if inAllProp {
+		res.names = append(res.names, name)
+	}
+	res.props[name] = value
+}
+
+// response собирает ответ по списку свойств. nil - allprop. Неизвестные
+// свойства попадают в propstat со статусом 404
+func (res *resource) response(requested []xml.Name) davResponse {
+	if requested == nil {
+		requested = res.names
+	}
+
+	var found, missing []property
+	for _, name := range requested {
+		value, ok := res.props[name]
+		if !ok {
+			missing = append(missing, property{XMLName: prefixed(name)})
+			continue
+		}
+		found = append(found, property{XMLName: prefixed(name), Value: value()})
+	}
+
+	resp := davResponse{Href: res.href}
+	if len(found) > 0 || len(missing) == 0 {
+		resp.Propstats = append(resp.Propstats, propstat{Prop: prop{found}, Status: statusLine(http.StatusOK)})
+	}
+	if len(missing) > 0 {
+		resp.Propstats = append(resp.Propstats, propstat{Prop: prop{missing}, Status: statusLine(http.StatusNotFound)})
+	}
+	return resp
+}
+
+func prefixed(name xml.Name) xml.Name {
+	if prefix, ok := namespacePrefixes[name.Space]; ok {
+		return xml.Name{Local: prefix + ":" + name.Local}
+	}
+	return name
+}
+
+func statusLine(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
+
+func hrefValue(href string) string {
+	return "<D:href>" + escapeText(href) + "</D:href>"
+}
+
+func escapeText(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// decodeXML разбирает тело запроса. Пустое тело не ошибка: v остается нулевым
+func decodeXML(r *http.Request, v any) (bool, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxXMLBodySize))
+	if err != nil {
+		return false, err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return false, nil
+	}
+	return true, xml.Unmarshal(body, v)
+}
+
+func writeXML(w http.ResponseWriter, status int, v any) error {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(status)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(v)
+}
+
+func newMultistatus(responses []davResponse) *multistatus {
+	return &multistatus{
+		DAV:       nsDAV,
+		CalDAV:    nsCalDAV,
+		CS:        nsCS,
+		Responses: responses,
+	}
+}
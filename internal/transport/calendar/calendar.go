@@ -0,0 +1,71 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/config"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/calendar"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/calendar"
+)
+
+//go:generate mockgen -source=calendar.go -destination=../../usecase/mocks/calendar_usecase_mock.go -package=mocks CalendarUsecase
+type CalendarUsecase interface {
+	GetCalendar(ctx context.Context, from, to time.Time) (*dto.CalendarDTO, error)
+	CreateFeed(ctx context.Context, projectID *uuid.UUID) (*dto.CalendarFeedDTO, error)
+	GetFeeds(ctx context.Context) ([]dto.CalendarFeedDTO, error)
+	DeleteFeed(ctx context.Context, feedID uuid.UUID) error
+	GetFeedICS(ctx context.Context, token, kind string) ([]byte, error)
+}
+
+type CalendarHandler struct {
+	uc     CalendarUsecase
+	config *config.Config
+}
+
+func New(uc CalendarUsecase, cfg *config.Config) *CalendarHandler {
+	return &CalendarHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// GetCalendar возвращает задачи для календаря
+// @Summary      Календарь задач
+// @Description  Возвращает задачи из всех проектов пользователя, которые пересекают период. Задача занимает отрезок от start_at до дедлайна; задачи без обеих дат не попадают в календарь. Дни считаются в часовом поясе пользователя
+// @Tags         calendar
+// @Produce      json
+// @Param        from  query  string  true  "Первый день периода (YYYY-MM-DD)"
+// @Param        to    query  string  true  "Последний день периода (YYYY-MM-DD)"
+// @Success      200  {object} dto.CalendarDTO "События календаря"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /calendar [get]
+func (h *CalendarHandler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	const op = "CalendarHandler.GetCalendar"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	query := r.URL.Query()
+	from, to, err := validation.ValidationCalendarRange(query.Get("from"), query.Get("to"))
+	if err != nil {
+		logger.Warn("calendar range validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	calendar, err := h.uc.GetCalendar(r.Context(), from, to)
+	if err != nil {
+		logger.WithError(err).Error("failed to get calendar")
+		handler.HandleError(r.Context(), w, err, "Failed to get calendar")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, calendar)
+}
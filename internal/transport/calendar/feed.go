@@ -0,0 +1,148 @@
+package transport
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/calendar"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/calendar"
+)
+
+// CreateFeed создает или перевыпускает ссылку на ICS-фид
+// @Summary      Создать ссылку на ICS-фид
+// @Description  Создает секретную ссылку на календарь задач с дедлайнами для Google Calendar, Thunderbird и Outlook. Без project_id в фид попадают все проекты пользователя. Повторный вызов выдает новую ссылку, старая перестает работать. Ссылка возвращается только в этом ответе
+// @Tags         calendar
+// @Accept       json
+// @Produce      json
+// @Param        feed  body  dto.CreateCalendarFeedDTO  false  "Проект фида"
+// @Success      201  {object} dto.CalendarFeedDTO "Фид со ссылкой"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /calendar/feeds [post]
+func (h *CalendarHandler) CreateFeed(w http.ResponseWriter, r *http.Request) {
+	const op = "CalendarHandler.CreateFeed"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	// Тело необязательно
+	var req dto.CreateCalendarFeedDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	feed, err := h.uc.CreateFeed(r.Context(), req.ProjectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to create feed")
+		handler.HandleError(r.Context(), w, err, "Failed to create calendar feed")
+		return
+	}
+
+	feed.URL = h.config.ServerConfig.PublicURL + "/api/calendar/feed/" + feed.Token + ".ics"
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, feed)
+}
+
+// GetFeeds возвращает ICS-фиды пользователя
+// @Summary      Список ICS-фидов
+// @Description  Возвращает фиды пользователя без ссылок: токены хранятся только в виде хеша
+// @Tags         calendar
+// @Produce      json
+// @Success      200  {array}  dto.CalendarFeedDTO "Фиды"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /calendar/feeds [get]
+func (h *CalendarHandler) GetFeeds(w http.ResponseWriter, r *http.Request) {
+	const op = "CalendarHandler.GetFeeds"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	feeds, err := h.uc.GetFeeds(r.Context())
+	if err != nil {
+		logger.WithError(err).Error("failed to get feeds")
+		handler.HandleError(r.Context(), w, err, "Failed to get calendar feeds")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, feeds)
+}
+
+// DeleteFeed отзывает ссылку на ICS-фид
+// @Summary      Отозвать ICS-фид
+// @Description  Удаляет фид, ссылка на него перестает работать
+// @Tags         calendar
+// @Param        feedId  path  string  true  "ID фида"
+// @Success      204  "Фид удален"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Фид не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /calendar/feeds/{feedId} [delete]
+func (h *CalendarHandler) DeleteFeed(w http.ResponseWriter, r *http.Request) {
+	const op = "CalendarHandler.DeleteFeed"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	feedID, err := uuid.Parse(mux.Vars(r)["feedId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid feed ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid feed ID")
+		return
+	}
+
+	if err := h.uc.DeleteFeed(r.Context(), feedID); err != nil {
+		logger.WithError(err).Error("failed to delete feed")
+		handler.HandleError(r.Context(), w, err, "Failed to delete calendar feed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetFeedICS отдает календарь по секретной ссылке
+// @Summary      ICS-фид
+// @Description  Календарь задач с дедлайнами в формате iCalendar. Авторизация не нужна: доступ дает токен из ссылки. kind=todo выгружает задачи как VTODO (Google Calendar их не показывает), по умолчанию - VEVENT
+// @Tags         calendar
+// @Produce      text/calendar
+// @Param        token  path   string  true   "Токен фида"
+// @Param        kind   query  string  false  "Вид компонентов: event или todo"
+// @Success      200  {string} string "Календарь"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      404  {object} dto.ErrorResponse "Фид не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Router       /calendar/feed/{token}.ics [get]
+func (h *CalendarHandler) GetFeedICS(w http.ResponseWriter, r *http.Request) {
+	const op = "CalendarHandler.GetFeedICS"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	kind, err := validation.ValidationFeedKind(r.URL.Query().Get("kind"))
+	if err != nil {
+		logger.Warn("feed kind validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	calendar, err := h.uc.GetFeedICS(r.Context(), mux.Vars(r)["token"], kind)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get feed")
+		handler.HandleError(r.Context(), w, err, "Failed to get calendar feed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="tasks.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(calendar); err != nil {
+		logger.WithError(err).Warn("failed to write calendar")
+	}
+}
@@ -0,0 +1,137 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/calendar"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestCalendarTransport_CreateFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCalendarUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{ServerConfig: &config.ServerConfig{PublicURL: "https://todo.example.com"}})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/calendar/feeds", handler.CreateFeed).Methods(http.MethodPost)
+
+	projectID := uuid.New()
+
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name: "Feed for all projects",
+			mockFunc: func() {
+				mockUsecase.EXPECT().CreateFeed(gomock.Any(), (*uuid.UUID)(nil)).
+					Return(&dto.CalendarFeedDTO{ID: uuid.New(), Token: "secret"}, nil)
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name: "No access to project",
+			body: `{"project_id":"` + projectID.String() + `"}`,
+			mockFunc: func() {
+				mockUsecase.EXPECT().CreateFeed(gomock.Any(), &projectID).Return(nil, errs.ErrNoAccess)
+			},
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Invalid body",
+			body:       `{"project_id":"oops"}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodPost, "/calendar/feeds", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			if rr.Code == http.StatusCreated {
+				var feed dto.CalendarFeedDTO
+				assert.NoError(t, json.NewDecoder(rr.Body).Decode(&feed))
+				assert.Equal(t, "https://todo.example.com/api/calendar/feed/secret.ics", feed.URL)
+			}
+		})
+	}
+}
+
+func TestCalendarTransport_GetFeedICS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCalendarUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/calendar/feed/{token:[A-Za-z0-9_-]+}.ics", handler.GetFeedICS).Methods(http.MethodGet)
+
+	tests := []struct {
+		name       string
+		url        string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name: "Events by default",
+			url:  "/calendar/feed/secret.ics",
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetFeedICS(gomock.Any(), "secret", "event").
+					Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "Revoked token",
+			url:  "/calendar/feed/revoked.ics?kind=todo",
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetFeedICS(gomock.Any(), "revoked", "todo").
+					Return(nil, errs.NewNotFoundError("calendar feed not found"))
+			},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Unknown kind",
+			url:        "/calendar/feed/secret.ics?kind=journal",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			if rr.Code == http.StatusOK {
+				assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
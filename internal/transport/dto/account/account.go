@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeleteAccountDTO - запрос на удаление аккаунта. Пароль подтверждает владельца,
+// в projects нужно указать решение по каждому проекту, которым владеет пользователь
+type DeleteAccountDTO struct {
+	Password string                  `json:"password"`
+	Projects []OwnedProjectActionDTO `json:"projects"`
+}
+
+// OwnedProjectActionDTO - решение по проекту: transfer передает его участнику
+// new_owner_id, delete удаляет вместе со всеми данными
+type OwnedProjectActionDTO struct {
+	ProjectID  uuid.UUID  `json:"project_id"`
+	Action     string     `json:"action"`
+	NewOwnerID *uuid.UUID `json:"new_owner_id,omitempty"`
+}
+
+// AccountProfileDTO - profile.json в выгрузке аккаунта
+type AccountProfileDTO struct {
+	ID         uuid.UUID `json:"id"`
+	Login      string    `json:"login"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	Timezone   string    `json:"timezone"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// AccountProjectDTO - запись projects.json: file - путь к выгрузке проекта внутри архива
+type AccountProjectDTO struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Role string    `json:"role"`
+	File string    `json:"file"`
+}
+
+// AccountTimeEntryDTO - запись time_entries.json
+type AccountTimeEntryDTO struct {
+	ID        uuid.UUID  `json:"id"`
+	TaskID    uuid.UUID  `json:"task_id"`
+	TaskTitle string     `json:"task_title"`
+	ProjectID uuid.UUID  `json:"project_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Note      string     `json:"note"`
+}
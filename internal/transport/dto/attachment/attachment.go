@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttachmentDTO struct {
+	ID          uuid.UUID  `json:"id"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	ParentType  string     `json:"parent_type" enums:"task,note"`
+	ParentID    uuid.UUID  `json:"parent_id"`
+	UserID      *uuid.UUID `json:"user_id"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// AttachmentURLDTO - временная ссылка на скачивание вложения
+type AttachmentURLDTO struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AttachmentUsageDTO - занятое вложениями место в проекте, в байтах
+type AttachmentUsageDTO struct {
+	ProjectID   uuid.UUID `json:"project_id"`
+	Used        int64     `json:"used"`
+	Quota       int64     `json:"quota"`
+	MaxFileSize int64     `json:"max_file_size"`
+}
@@ -1,5 +1,11 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type TokenResponse struct {
 	Token string `json:"token"`
 }
@@ -14,4 +20,16 @@ type RegisterRequest struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
-}
\ No newline at end of file
+}
+// AppPasswordDTO - пароль приложения. Сам пароль возвращается только при создании
+type AppPasswordDTO struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	Password   string     `json:"password,omitempty"`
+}
+
+type CreateAppPasswordRequest struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarItemDTO - событие календаря. У задач на весь день start и end - даты
+// (YYYY-MM-DD, end включительно), у остальных - время в часовом поясе пользователя (RFC 3339)
+type CalendarItemDTO struct {
+	TaskID      uuid.UUID `json:"task_id"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	ProjectName string    `json:"project_name"`
+	Title       string    `json:"title"`
+	Status      string    `json:"status"`
+	Importance  int       `json:"importance"`
+	AllDay      bool      `json:"all_day"`
+	Start       string    `json:"start"`
+	End         string    `json:"end"`
+}
+
+type CalendarDTO struct {
+	From     string            `json:"from"`
+	To       string            `json:"to"`
+	Timezone string            `json:"timezone"`
+	Items    []CalendarItemDTO `json:"items"`
+}
+
+// CalendarFeedDTO - ICS-фид пользователя. Токен и ссылка возвращаются
+// только при создании: в базе хранится лишь хеш токена
+type CalendarFeedDTO struct {
+	ID        uuid.UUID  `json:"id"`
+	ProjectID *uuid.UUID `json:"project_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	Token     string     `json:"token,omitempty"`
+	URL       string     `json:"url,omitempty"`
+}
+
+// CreateCalendarFeedDTO - без project_id создается фид по всем проектам
+type CreateCalendarFeedDTO struct {
+	ProjectID *uuid.UUID `json:"project_id,omitempty"`
+}
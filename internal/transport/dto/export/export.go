@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportDTO - JSON-выгрузка проекта. Format всегда course-todo-export,
+// Version - версия структуры файла
+type ExportDTO struct {
+	Format     string         `json:"format"`
+	Version    int            `json:"version"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Project    ExportProject  `json:"project"`
+	Members    []ExportMember `json:"members"`
+	Tasks      []ExportTask   `json:"tasks"`
+	Notes      []ExportNote   `json:"notes"`
+}
+
+type ExportProject struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	OwnerID     uuid.UUID `json:"owner_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ExportMember struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Login    string    `json:"login"`
+	Username string    `json:"username"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type ExportTask struct {
+	ID              uuid.UUID  `json:"id"`
+	AuthorID        uuid.UUID  `json:"author_id"`
+	AuthorLogin     string     `json:"author_login"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Status          string     `json:"status"`
+	Importance      int        `json:"importance"`
+	Deadline        *time.Time `json:"deadline"`
+	StartAt         *time.Time `json:"start_at"`
+	AllDay          bool       `json:"all_day"`
+	EstimateMinutes *int       `json:"estimate_minutes"`
+	CreatedAt       time.Time  `json:"created_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+}
+
+type ExportNote struct {
+	ID          uuid.UUID `json:"id"`
+	AuthorID    uuid.UUID `json:"author_id"`
+	AuthorLogin string    `json:"author_login"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Format      string    `json:"format"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ExportHeaderDTO - первая строка NDJSON-выгрузки
+type ExportHeaderDTO struct {
+	Type       string    `json:"type"`
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// ExportRecordDTO - строка NDJSON-выгрузки: type - project, member, task или note,
+// data - запись в том же виде, что и в JSON-выгрузке
+type ExportRecordDTO struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DateBoundDTO - граница диапазона дат. Указывается либо date (YYYY-MM-DD),
+// либо days - смещение в днях от текущего дня в часовом поясе пользователя
+type DateBoundDTO struct {
+	Date string `json:"date,omitempty"`
+	Days *int   `json:"days,omitempty"`
+}
+
+type FilterDefinitionDTO struct {
+	ProjectIDs   []uuid.UUID   `json:"project_ids,omitempty"`
+	Statuses     []string      `json:"statuses,omitempty"`
+	Importance   []int         `json:"importance,omitempty"`
+	OnlyMine     bool          `json:"only_mine,omitempty"`
+	Overdue      bool          `json:"overdue,omitempty"`
+	DeadlineFrom *DateBoundDTO `json:"deadline_from,omitempty"`
+	DeadlineTo   *DateBoundDTO `json:"deadline_to,omitempty"`
+	Text         string        `json:"text,omitempty"`
+}
+
+type SavedFilterDTO struct {
+	ID         uuid.UUID           `json:"id"`
+	Name       string              `json:"name"`
+	Definition FilterDefinitionDTO `json:"definition"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+type PostFilterDTO struct {
+	Name       string              `json:"name" validate:"required"`
+	Definition FilterDefinitionDTO `json:"definition"`
+}
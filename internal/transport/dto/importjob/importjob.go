@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportMappingDTO - настройка сопоставления, передается полем mapping при загрузке файла.
+// columns: колонка CSV -> поле задачи (пустая строка - пропустить колонку);
+// statuses: статус источника или список Trello -> waiting, in_progress или completed;
+// labels: метка -> важность 1..3
+type ImportMappingDTO struct {
+	Columns  map[string]string `json:"columns,omitempty"`
+	Statuses map[string]string `json:"statuses,omitempty"`
+	Labels   map[string]int    `json:"labels,omitempty"`
+}
+
+// ImportJobDTO - задание импорта. Progress - процент обработанных записей,
+// Report появляется после проверки файла
+type ImportJobDTO struct {
+	ID         uuid.UUID        `json:"id"`
+	ProjectID  uuid.UUID        `json:"project_id"`
+	UserID     uuid.UUID        `json:"user_id"`
+	Source     string           `json:"source"`
+	Status     string           `json:"status"`
+	FileName   string           `json:"file_name"`
+	Mapping    ImportMappingDTO `json:"mapping"`
+	Total      int              `json:"total"`
+	Processed  int              `json:"processed"`
+	Progress   int              `json:"progress"`
+	Report     *ImportReportDTO `json:"report,omitempty"`
+	Error      string           `json:"error,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+}
+
+// ImportReportDTO - результат проверки файла. errors содержит первые 100 ошибок,
+// error_count - их полное число; unmapped_* - данные, которые не будут импортированы
+type ImportReportDTO struct {
+	Total            int                 `json:"total"`
+	Tasks            int                 `json:"tasks"`
+	Notes            int                 `json:"notes"`
+	Skipped          int                 `json:"skipped"`
+	ErrorCount       int                 `json:"error_count"`
+	Errors           []ImportRowErrorDTO `json:"errors"`
+	UnmappedFields   []string            `json:"unmapped_fields"`
+	UnmappedStatuses []string            `json:"unmapped_statuses"`
+	UnmappedLabels   []string            `json:"unmapped_labels"`
+}
+
+// ImportRowErrorDTO - ошибка в записи: row - строка CSV или порядковый номер записи в json
+type ImportRowErrorDTO struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
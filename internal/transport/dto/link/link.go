@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/google/uuid"
+
+type BacklinkDTO struct {
+	Type      string    `json:"type" enums:"task,note"`
+	ID        uuid.UUID `json:"id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Title     string    `json:"title"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "encoding/json"
+
+// EventDTO - сообщение WebSocket-подписки. ID - номер события для возобновления
+// через last_event_id, Data - событие в том же формате, что и тело вебхука.
+// Сообщение с типом reset и без данных означает, что клиенту нужно заново загрузить данные
+type EventDTO struct {
+	ID   string          `json:"id,omitempty"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+}
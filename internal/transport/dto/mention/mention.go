@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MentionDTO - упоминание текущего пользователя. SourceType - task или note,
+// Title - название задачи или заметки
+type MentionDTO struct {
+	SourceType  string     `json:"source_type"`
+	SourceID    uuid.UUID  `json:"source_id"`
+	Title       string     `json:"title"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	ProjectName string     `json:"project_name"`
+	AuthorID    *uuid.UUID `json:"author_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
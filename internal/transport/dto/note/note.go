@@ -7,20 +7,119 @@ import (
 )
 
 type NoteDTO struct {
-	ID          uuid.UUID `json:"id"`
-	ProjectID   uuid.UUID `json:"project_id"`
-	UserID      uuid.UUID `json:"user_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uuid.UUID  `json:"id"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Format      string     `json:"format" enums:"plain,markdown"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Version     int        `json:"version"`
+	FolderID    *uuid.UUID `json:"folder_id"`
+	Path        string     `json:"path,omitempty"`
+	Position    int        `json:"position"`
+	Pinned      bool       `json:"pinned"`
+	Favourite   bool       `json:"favourite"`
 }
 
 type CreateOrUpdateNote struct {
 	ProjectID   uuid.UUID `json:"project_id" validate:"required"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
+	Format      string    `json:"format" enums:"plain,markdown"`
 }
 
 type CreateNoteDTO struct {
 	ID uuid.UUID `json:"id"`
 }
+
+type RenderedNoteDTO struct {
+	NoteID  uuid.UUID `json:"note_id"`
+	Format  string    `json:"format"`
+	Version int       `json:"version"`
+	HTML    string    `json:"html"`
+}
+
+type NoteRevisionSummaryDTO struct {
+	Revision     int        `json:"revision"`
+	Name         string     `json:"name"`
+	AuthorID     *uuid.UUID `json:"author_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	RestoredFrom *int       `json:"restored_from,omitempty"`
+}
+
+type NoteRevisionDTO struct {
+	NoteID       uuid.UUID  `json:"note_id"`
+	Revision     int        `json:"revision"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Format       string     `json:"format"`
+	AuthorID     *uuid.UUID `json:"author_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	RestoredFrom *int       `json:"restored_from,omitempty"`
+}
+
+type DiffLineDTO struct {
+	Op      string `json:"op"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+	Text    string `json:"text"`
+}
+
+type NoteDiffDTO struct {
+	From        int           `json:"from"`
+	To          int           `json:"to"`
+	NameChanged bool          `json:"name_changed"`
+	OldName     string        `json:"old_name"`
+	NewName     string        `json:"new_name"`
+	Added       int           `json:"added"`
+	Removed     int           `json:"removed"`
+	Lines       []DiffLineDTO `json:"lines"`
+}
+
+type NoteFolderDTO struct {
+	ID        uuid.UUID  `json:"id"`
+	ProjectID uuid.UUID  `json:"project_id"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	Name      string     `json:"name"`
+	Path      string     `json:"path"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type CreateNoteFolderDTO struct {
+	ProjectID uuid.UUID  `json:"project_id" validate:"required"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	Name      string     `json:"name"`
+}
+
+type UpdateNoteFolderDTO struct {
+	ParentID *uuid.UUID `json:"parent_id"`
+	Name     string     `json:"name"`
+}
+
+// NoteFolderNodeDTO - папка вместе с вложенными папками и заметками
+type NoteFolderNodeDTO struct {
+	ID       uuid.UUID            `json:"id"`
+	ParentID *uuid.UUID           `json:"parent_id"`
+	Name     string               `json:"name"`
+	Path     string               `json:"path"`
+	Folders  []*NoteFolderNodeDTO `json:"folders"`
+	Notes    []*NoteDTO           `json:"notes"`
+}
+
+// NoteTreeDTO - дерево заметок проекта. Notes содержит заметки вне папок
+type NoteTreeDTO struct {
+	ProjectID uuid.UUID            `json:"project_id"`
+	Folders   []*NoteFolderNodeDTO `json:"folders"`
+	Notes     []*NoteDTO           `json:"notes"`
+}
+
+type MoveNoteDTO struct {
+	FolderID *uuid.UUID `json:"folder_id"`
+	Position int        `json:"position"`
+}
+
+type NoteFlagsDTO struct {
+	Pinned    *bool `json:"pinned"`
+	Favourite *bool `json:"favourite"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationDTO - уведомление. Data - данные события, из которого оно получено
+// (задача, пункт чек-листа или участник), ReadAt пуст у непрочитанных
+type NotificationDTO struct {
+	ID          uuid.UUID       `json:"id"`
+	Type        string          `json:"type"`
+	ProjectID   uuid.UUID       `json:"project_id"`
+	ProjectName string          `json:"project_name"`
+	ActorID     *uuid.UUID      `json:"actor_id,omitempty"`
+	Data        json.RawMessage `json:"data" swaggertype:"object"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ReadAt      *time.Time      `json:"read_at,omitempty"`
+}
+
+type NotificationListDTO struct {
+	Items       []NotificationDTO `json:"items"`
+	UnreadCount int               `json:"unread_count"`
+}
+
+type UnreadCountDTO struct {
+	UnreadCount int `json:"unread_count"`
+}
+
+type PreferenceDTO struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// UpdatePreferencesDTO - тип уведомления и включено ли оно. Не переданные типы не меняются
+type UpdatePreferencesDTO map[string]bool
@@ -12,6 +12,7 @@ type ProjectDTO struct {
 	Description string    `json:"description"`
 	OwnerID     uuid.UUID `json:"owner_id"`
 	CreatedAt   time.Time `json:"created_at"`
+	Version     int       `json:"version"`
 }
 
 type PostProjectDTO struct {
@@ -37,3 +38,26 @@ type ProjectMemberDTO struct {
 type AddMemberDTO struct {
 	UserID uuid.UUID `json:"user_id" validate:"required"`
 }
+
+type DateCountDTO struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+type MemberStatsDTO struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	Open      int       `json:"open"`
+	Completed int       `json:"completed"`
+}
+
+type ProjectStatsDTO struct {
+	ProjectID           uuid.UUID        `json:"project_id"`
+	ByStatus            map[string]int   `json:"by_status"`
+	ByImportance        map[string]int   `json:"by_importance"`
+	Overdue             int              `json:"overdue"`
+	CompletedPerDay     []DateCountDTO   `json:"completed_per_day"`
+	CompletedPerWeek    []DateCountDTO   `json:"completed_per_week"`
+	AvgCycleTimeSeconds float64          `json:"avg_cycle_time_seconds"`
+	Members             []MemberStatsDTO `json:"members"`
+}
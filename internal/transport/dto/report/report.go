@@ -0,0 +1,32 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CFDPointDTO struct {
+	Date       string `json:"date"`
+	Waiting    int    `json:"waiting"`
+	InProgress int    `json:"in_progress"`
+	Completed  int    `json:"completed"`
+}
+
+type CFDDTO struct {
+	ProjectID uuid.UUID     `json:"project_id"`
+	From      string        `json:"from"`
+	To        string        `json:"to"`
+	Bucket    string        `json:"bucket"`
+	Points    []CFDPointDTO `json:"points"`
+}
+
+type BurndownPointDTO struct {
+	Date  string  `json:"date"`
+	Open  int     `json:"open"`
+	Ideal float64 `json:"ideal"`
+}
+
+type BurndownDTO struct {
+	ProjectID uuid.UUID          `json:"project_id"`
+	From      string             `json:"from"`
+	To        string             `json:"to"`
+	Bucket    string             `json:"bucket"`
+	Points    []BurndownPointDTO `json:"points"`
+}
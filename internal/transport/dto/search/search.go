@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/google/uuid"
+
+type SearchResultDTO struct {
+	Type      string    `json:"type"`
+	ID        uuid.UUID `json:"id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Title     string    `json:"title"`
+	Snippet   string    `json:"snippet"`
+	Rank      float64   `json:"rank"`
+}
+
+type SearchResponseDTO struct {
+	Query   string            `json:"query"`
+	Results []SearchResultDTO `json:"results"`
+}
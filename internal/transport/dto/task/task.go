@@ -7,15 +7,24 @@ import (
 )
 
 type TaskDTO struct {
-	ID          uuid.UUID `json:"id"`
-	ProjectID   uuid.UUID `json:"project_id"`
-	UserID      uuid.UUID `json:"user_id"`
-	Title       string    `json:"title" validate:"required"`
-	Description string    `json:"description"`
-	Importance  int       `json:"importance" validate:"required, min=1, max=3"`
-	Deadline    time.Time `json:"deadline"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          uuid.UUID  `json:"id"`
+	ProjectID   uuid.UUID  `json:"project_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Title       string     `json:"title" validate:"required"`
+	Description string     `json:"description"`
+	Importance  int        `json:"importance" validate:"required, min=1, max=3"`
+	Deadline    time.Time  `json:"deadline"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Version     int        `json:"version"`
+
+	EstimateMinutes *int       `json:"estimate_minutes"`
+	StartAt         *time.Time `json:"start_at"`
+	AllDay          bool       `json:"all_day"`
+
+	ChecklistDone  int `json:"checklist_done"`
+	ChecklistTotal int `json:"checklist_total"`
 }
 
 type PostTaskDTO struct {
@@ -24,8 +33,42 @@ type PostTaskDTO struct {
 	Description string    `json:"description"`
 	Importance  int       `json:"importance" validate:"required, min=1, max=3"`
 	Deadline    time.Time `json:"deadline"`
+
+	EstimateMinutes *int `json:"estimate_minutes,omitempty"`
+	// StartAt не позже Deadline. При all_day учитываются только даты
+	StartAt *time.Time `json:"start_at,omitempty"`
+	AllDay  bool       `json:"all_day"`
 }
 
 type CreateTaskDTO struct {
 	ID uuid.UUID `json:"id"`
 }
+
+type ChecklistItemDTO struct {
+	ID         uuid.UUID  `json:"id"`
+	TaskID     uuid.UUID  `json:"task_id"`
+	Text       string     `json:"text"`
+	Done       bool       `json:"done"`
+	AssigneeID *uuid.UUID `json:"assignee_id"`
+	Position   int        `json:"position"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateChecklistItemDTO - новый пункт чек-листа. Без position пункт добавляется в конец
+type CreateChecklistItemDTO struct {
+	Text       string     `json:"text" validate:"required"`
+	AssigneeID *uuid.UUID `json:"assignee_id,omitempty"`
+	Position   *int       `json:"position,omitempty"`
+}
+
+// UpdateChecklistItemDTO меняет только переданные поля. clear_assignee снимает исполнителя
+type UpdateChecklistItemDTO struct {
+	Text          *string    `json:"text,omitempty"`
+	Done          *bool      `json:"done,omitempty"`
+	AssigneeID    *uuid.UUID `json:"assignee_id,omitempty"`
+	ClearAssignee bool       `json:"clear_assignee,omitempty"`
+}
+
+type MoveChecklistItemDTO struct {
+	Position int `json:"position"`
+}
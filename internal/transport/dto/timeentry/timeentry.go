@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimeEntryDTO struct {
+	ID        uuid.UUID  `json:"id"`
+	TaskID    uuid.UUID  `json:"task_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	// DurationSeconds у запущенного таймера - время с момента запуска
+	DurationSeconds int64     `json:"duration_seconds"`
+	Note            string    `json:"note"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type StartTimerDTO struct {
+	Note string `json:"note"`
+}
+
+// CreateTimeEntryDTO - запись задним числом. Нужно указать ended_at или duration_minutes
+type CreateTimeEntryDTO struct {
+	StartedAt       time.Time  `json:"started_at" validate:"required"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	DurationMinutes *int       `json:"duration_minutes,omitempty"`
+	Note            string     `json:"note"`
+}
+
+type TaskTimeDTO struct {
+	TaskID        uuid.UUID      `json:"task_id"`
+	LoggedSeconds int64          `json:"logged_seconds"`
+	Entries       []TimeEntryDTO `json:"entries"`
+}
+
+type TimeReportRowDTO struct {
+	ProjectID   uuid.UUID `json:"project_id"`
+	ProjectName string    `json:"project_name"`
+	UserID      uuid.UUID `json:"user_id"`
+	Login       string    `json:"login"`
+	Date        string    `json:"date"`
+	Seconds     int64     `json:"seconds"`
+	Hours       float64   `json:"hours"`
+}
+
+type TimeReportDTO struct {
+	From         string             `json:"from"`
+	To           string             `json:"to"`
+	Timezone     string             `json:"timezone"`
+	TotalSeconds int64              `json:"total_seconds"`
+	Rows         []TimeReportRowDTO `json:"rows"`
+}
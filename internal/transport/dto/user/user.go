@@ -7,9 +7,14 @@ type UserDTO struct {
 	Login        string    `json:"login"`
 	Username     string    `json:"username"`
 	Email        string    `json:"email"`
+	Timezone     string    `json:"timezone"`
 	PasswordHash []byte    `json:"-"`
 }
 
 type UpdateUsernameRequest struct {
 	Username string `json:"username"`
 }
+
+type UpdateTimezoneRequest struct {
+	Timezone string `json:"timezone"`
+}
@@ -1,5 +1,6 @@
 package dto
 
 type ErrorResponse struct {
-	Message string `json:"message"`
+	Message  string `json:"message"`
+	Position *int   `json:"position,omitempty"`
 }
@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/export"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/export"
+)
+
+//go:generate mockgen -source=export.go -destination=../../usecase/mocks/export_usecase_mock.go -package=mocks ExportUsecase
+type ExportUsecase interface {
+	ExportProject(ctx context.Context, projectID uuid.UUID, format string, w io.Writer) error
+}
+
+type ExportHandler struct {
+	uc     ExportUsecase
+	config *config.Config
+}
+
+func New(uc ExportUsecase, cfg *config.Config) *ExportHandler {
+	return &ExportHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+var contentTypes = map[string]string{
+	models.FormatCSV:    "text/csv; charset=utf-8",
+	models.FormatJSON:   "application/json",
+	models.FormatNDJSON: "application/x-ndjson",
+}
+
+// ExportProject выгружает данные проекта
+// @Summary      Выгрузить проект
+// @Description  Отдает задачи, заметки и участников проекта файлом. Данные читаются и отправляются потоком. csv - одна таблица по RFC 4180 с колонкой record_type и постоянным порядком колонок; json - документ с полями format и version для повторного импорта; ndjson - по записи на строку, первая строка - заголовок с версией
+// @Tags         projects
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        projectId  path   string  true   "ID проекта"
+// @Param        format     query  string  false  "csv, json (по умолчанию) или ndjson"
+// @Success      200  {object} dto.ExportDTO "Выгрузка"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      404  {object} dto.ErrorResponse "Проект не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/export [get]
+func (h *ExportHandler) ExportProject(w http.ResponseWriter, r *http.Request) {
+	const op = "ExportHandler.ExportProject"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid project ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	format, err := validation.ValidationExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		logger.Warn("export format validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	out := &exportWriter{
+		w:           w,
+		contentType: contentTypes[format],
+		filename:    "project-" + projectID.String() + "." + format,
+	}
+	if err := h.uc.ExportProject(r.Context(), projectID, format, out); err != nil {
+		if out.started {
+			// Заголовки уже отправлены, клиент получит оборванный файл
+			logger.WithError(err).Error("export interrupted")
+			return
+		}
+		logger.WithError(err).Error("failed to export project")
+		handler.HandleError(r.Context(), w, err, "Failed to export project")
+	}
+}
+
+// exportWriter отправляет заголовки ответа при первой записи, чтобы ошибки,
+// случившиеся до начала выгрузки, вернулись обычным JSON-ответом
+type exportWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	if !e.started {
+		e.started = true
+		e.w.Header().Set("Content-Type", e.contentType)
+		e.w.Header().Set("Content-Disposition", `attachment; filename="`+e.filename+`"`)
+		e.w.WriteHeader(http.StatusOK)
+	}
+	return e.w.Write(p)
+}
@@ -0,0 +1,124 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestExportTransport_ExportProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockExportUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/projects/{projectId}/export", handler.ExportProject).Methods(http.MethodGet)
+
+	projectID := uuid.New()
+
+	tests := []struct {
+		name        string
+		path        string
+		mockFunc    func()
+		statusCode  int
+		contentType string
+		contains    string
+	}{
+		{
+			name: "CSV",
+			path: "/projects/" + projectID.String() + "/export?format=csv",
+			mockFunc: func() {
+				mockUsecase.EXPECT().ExportProject(gomock.Any(), projectID, "csv", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, projectID uuid.UUID, format string, w io.Writer) error {
+						_, err := io.WriteString(w, "record_type,id\r\n")
+						return err
+					})
+			},
+			statusCode:  http.StatusOK,
+			contentType: "text/csv; charset=utf-8",
+			contains:    "record_type,id",
+		},
+		{
+			name: "JSON by default",
+			path: "/projects/" + projectID.String() + "/export",
+			mockFunc: func() {
+				mockUsecase.EXPECT().ExportProject(gomock.Any(), projectID, "json", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, projectID uuid.UUID, format string, w io.Writer) error {
+						_, err := io.WriteString(w, `{"format":"course-todo-export"}`)
+						return err
+					})
+			},
+			statusCode:  http.StatusOK,
+			contentType: "application/json",
+			contains:    "course-todo-export",
+		},
+		{
+			name: "No access",
+			path: "/projects/" + projectID.String() + "/export?format=ndjson",
+			mockFunc: func() {
+				mockUsecase.EXPECT().ExportProject(gomock.Any(), projectID, "ndjson", gomock.Any()).Return(errs.ErrNoAccess)
+			},
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name: "Error after start keeps status",
+			path: "/projects/" + projectID.String() + "/export?format=ndjson",
+			mockFunc: func() {
+				mockUsecase.EXPECT().ExportProject(gomock.Any(), projectID, "ndjson", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, projectID uuid.UUID, format string, w io.Writer) error {
+						_, _ = io.WriteString(w, "{\"type\":\"header\"}\n")
+						return errors.New("db error")
+					})
+			},
+			statusCode:  http.StatusOK,
+			contentType: "application/x-ndjson",
+		},
+		{
+			name:       "Invalid format",
+			path:       "/projects/" + projectID.String() + "/export?format=xlsx",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+			contains:   "format must be csv, json or ndjson",
+		},
+		{
+			name:       "Invalid project ID",
+			path:       "/projects/bad/export",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			if tt.contentType != "" {
+				assert.Equal(t, tt.contentType, rr.Header().Get("Content-Type"))
+				assert.Contains(t, rr.Header().Get("Content-Disposition"), "project-"+projectID.String())
+			}
+			if tt.contains != "" {
+				assert.Contains(t, rr.Body.String(), tt.contains)
+			}
+		})
+	}
+}
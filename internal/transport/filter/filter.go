@@ -0,0 +1,255 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/lzimin05/course-todo/config"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/filter"
+	taskdto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/filter"
+)
+
+type FilterUsecase interface {
+	CreateFilter(ctx context.Context, req *dto.PostFilterDTO) (*dto.SavedFilterDTO, error)
+	GetFilters(ctx context.Context) ([]*dto.SavedFilterDTO, error)
+	GetFilterByID(ctx context.Context, filterID uuid.UUID) (*dto.SavedFilterDTO, error)
+	UpdateFilter(ctx context.Context, filterID uuid.UUID, req *dto.PostFilterDTO) (*dto.SavedFilterDTO, error)
+	DeleteFilter(ctx context.Context, filterID uuid.UUID) error
+	GetFilterTasks(ctx context.Context, filterID uuid.UUID) ([]*taskdto.TaskDTO, error)
+}
+
+type FilterHandler struct {
+	uc     FilterUsecase
+	config *config.Config
+}
+
+func New(uc FilterUsecase, cfg *config.Config) *FilterHandler {
+	return &FilterHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// CreateFilter создает сохраненный фильтр
+// @Summary      Создать сохраненный фильтр
+// @Description  Сохраняет именованный фильтр задач текущего пользователя. Границы дедлайна задаются датой (date) или смещением в днях от сегодняшнего дня (days), которое вычисляется при каждом запуске в часовом поясе пользователя
+// @Tags         filters
+// @Accept       json
+// @Produce      json
+// @Param        filter  body  dto.PostFilterDTO  true  "Имя и условия фильтра"
+// @Success      201  {object} dto.SavedFilterDTO "Фильтр создан"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту из фильтра"
+// @Failure      409  {object} dto.ErrorResponse "Фильтр с таким именем уже существует"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /filters [post]
+func (h *FilterHandler) CreateFilter(w http.ResponseWriter, r *http.Request) {
+	const op = "FilterHandler.CreateFilter"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	var req dto.PostFilterDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode filter")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validation.ValidationFilter(&req); err != nil {
+		logger.Warn("filter validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter, err := h.uc.CreateFilter(r.Context(), &req)
+	if err != nil {
+		logger.WithError(err).Error("failed to create filter")
+		handler.HandleError(r.Context(), w, err, "Failed to create filter")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, filter)
+}
+
+// GetFilters возвращает сохраненные фильтры пользователя
+// @Summary      Получить сохраненные фильтры
+// @Description  Возвращает все сохраненные фильтры текущего пользователя
+// @Tags         filters
+// @Produce      json
+// @Success      200  {array}  dto.SavedFilterDTO "Список фильтров"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /filters [get]
+func (h *FilterHandler) GetFilters(w http.ResponseWriter, r *http.Request) {
+	const op = "FilterHandler.GetFilters"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	filters, err := h.uc.GetFilters(r.Context())
+	if err != nil {
+		logger.WithError(err).Error("failed to get filters")
+		handler.HandleError(r.Context(), w, err, "Failed to get filters")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, filters)
+}
+
+// GetFilterByID возвращает сохраненный фильтр
+// @Summary      Получить сохраненный фильтр
+// @Description  Возвращает сохраненный фильтр по его ID
+// @Tags         filters
+// @Produce      json
+// @Param        filterId  path  string  true  "ID фильтра"
+// @Success      200  {object} dto.SavedFilterDTO "Фильтр"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Фильтр не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /filters/{filterId} [get]
+func (h *FilterHandler) GetFilterByID(w http.ResponseWriter, r *http.Request) {
+	const op = "FilterHandler.GetFilterByID"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	filterID, err := uuid.Parse(mux.Vars(r)["filterId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid filter ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid filter ID")
+		return
+	}
+
+	filter, err := h.uc.GetFilterByID(r.Context(), filterID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get filter")
+		handler.HandleError(r.Context(), w, err, "Failed to get filter")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, filter)
+}
+
+// UpdateFilter обновляет сохраненный фильтр
+// @Summary      Обновить сохраненный фильтр
+// @Description  Заменяет имя и условия сохраненного фильтра
+// @Tags         filters
+// @Accept       json
+// @Produce      json
+// @Param        filterId  path  string  true  "ID фильтра"
+// @Param        filter    body  dto.PostFilterDTO  true  "Имя и условия фильтра"
+// @Success      200  {object} dto.SavedFilterDTO "Фильтр обновлен"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту из фильтра"
+// @Failure      404  {object} dto.ErrorResponse "Фильтр не найден"
+// @Failure      409  {object} dto.ErrorResponse "Фильтр с таким именем уже существует"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /filters/{filterId} [put]
+func (h *FilterHandler) UpdateFilter(w http.ResponseWriter, r *http.Request) {
+	const op = "FilterHandler.UpdateFilter"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	filterID, err := uuid.Parse(mux.Vars(r)["filterId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid filter ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid filter ID")
+		return
+	}
+
+	var req dto.PostFilterDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode filter")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validation.ValidationFilter(&req); err != nil {
+		logger.Warn("filter validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter, err := h.uc.UpdateFilter(r.Context(), filterID, &req)
+	if err != nil {
+		logger.WithError(err).Error("failed to update filter")
+		handler.HandleError(r.Context(), w, err, "Failed to update filter")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, filter)
+}
+
+// DeleteFilter удаляет сохраненный фильтр
+// @Summary      Удалить сохраненный фильтр
+// @Description  Удаляет сохраненный фильтр текущего пользователя
+// @Tags         filters
+// @Param        filterId  path  string  true  "ID фильтра"
+// @Success      204  "Фильтр удален"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Фильтр не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /filters/{filterId} [delete]
+func (h *FilterHandler) DeleteFilter(w http.ResponseWriter, r *http.Request) {
+	const op = "FilterHandler.DeleteFilter"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	filterID, err := uuid.Parse(mux.Vars(r)["filterId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid filter ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid filter ID")
+		return
+	}
+
+	if err := h.uc.DeleteFilter(r.Context(), filterID); err != nil {
+		logger.WithError(err).Error("failed to delete filter")
+		handler.HandleError(r.Context(), w, err, "Failed to delete filter")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetFilterTasks выполняет сохраненный фильтр
+// @Summary      Получить задачи по фильтру
+// @Description  Возвращает задачи из проектов пользователя, подходящие под условия фильтра. Относительные даты вычисляются на момент запроса в часовом поясе пользователя
+// @Tags         filters
+// @Produce      json
+// @Param        filterId  path  string  true  "ID фильтра"
+// @Success      200  {array}  dto.TaskDTO "Список задач"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Фильтр не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /filters/{filterId}/tasks [get]
+func (h *FilterHandler) GetFilterTasks(w http.ResponseWriter, r *http.Request) {
+	const op = "FilterHandler.GetFilterTasks"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	filterID, err := uuid.Parse(mux.Vars(r)["filterId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid filter ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid filter ID")
+		return
+	}
+
+	tasks, err := h.uc.GetFilterTasks(r.Context(), filterID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get filter tasks")
+		handler.HandleError(r.Context(), w, err, "Failed to get filter tasks")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, tasks)
+}
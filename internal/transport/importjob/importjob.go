@@ -0,0 +1,280 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/lzimin05/course-todo/config"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/importjob"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/importjob"
+)
+
+const (
+	// Запас на заголовки и границы multipart поверх размера самого файла
+	multipartOverhead = 1 << 20
+	// Наибольший размер полей source и mapping
+	maxFieldSize = 64 << 10
+)
+
+//go:generate mockgen -source=importjob.go -destination=../../usecase/mocks/importjob_usecase_mock.go -package=mocks ImportUsecase
+type ImportUsecase interface {
+	CreateImport(ctx context.Context, projectID uuid.UUID, source, fileName string, mapping dto.ImportMappingDTO, content io.Reader) (*dto.ImportJobDTO, error)
+	GetImport(ctx context.Context, projectID, jobID uuid.UUID) (*dto.ImportJobDTO, error)
+	GetImports(ctx context.Context, projectID uuid.UUID) ([]dto.ImportJobDTO, error)
+	CommitImport(ctx context.Context, projectID, jobID uuid.UUID) (*dto.ImportJobDTO, error)
+}
+
+type ImportHandler struct {
+	uc     ImportUsecase
+	config *config.Config
+}
+
+func New(uc ImportUsecase, cfg *config.Config) *ImportHandler {
+	return &ImportHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// CreateImport загружает файл для импорта
+// @Summary      Загрузить файл импорта
+// @Description  Ставит файл в очередь на проверку и возвращает задание. Проверка выполняется в фоне без изменения проекта: статус задания queued -> validating -> validated, в report - число записей, ошибки по строкам и данные, которые не будут перенесены. Импорт запускается отдельным подтверждением. Источники: csv (первая строка - заголовок), todoist (JSON REST или Sync API), trello (JSON-выгрузка доски), course-todo (JSON-выгрузка проекта)
+// @Tags         imports
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        projectId  path      string  true   "ID проекта"
+// @Param        source     formData  string  true   "csv, todoist, trello или course-todo"
+// @Param        mapping    formData  string  false  "Настройка сопоставления, JSON в формате dto.ImportMappingDTO"
+// @Param        file       formData  file    true   "Файл"
+// @Success      202  {object} dto.ImportJobDTO "Задание импорта"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      413  {object} dto.ErrorResponse "Файл слишком большой"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/imports [post]
+func (h *ImportHandler) CreateImport(w http.ResponseWriter, r *http.Request) {
+	const op = "ImportHandler.CreateImport"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid project ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.config.ImportConfig.MaxFileSize+multipartOverhead)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		logger.WithError(err).Warn("request is not multipart")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Request must be multipart/form-data")
+		return
+	}
+
+	// Файл целиком хранится в задании, поэтому поля формы можно передавать в любом порядке
+	var (
+		source, rawMapping, fileName string
+		file                         []byte
+		hasFile                      bool
+	)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			h.handleUploadError(r.Context(), w, err)
+			return
+		}
+
+		switch part.FormName() {
+		case "source":
+			source, err = readField(part)
+		case "mapping":
+			rawMapping, err = readField(part)
+		case "file":
+			fileName, hasFile = part.FileName(), true
+			file, err = io.ReadAll(part)
+		}
+		part.Close()
+		if err != nil {
+			h.handleUploadError(r.Context(), w, err)
+			return
+		}
+	}
+
+	if err := validation.ValidationImportSource(source); err != nil {
+		logger.Warn("import source validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+	mapping, err := validation.ValidationImportMapping(rawMapping)
+	if err != nil {
+		logger.Warn("import mapping validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !hasFile {
+		logger.Warn("file part is missing")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "file is required")
+		return
+	}
+
+	job, err := h.uc.CreateImport(r.Context(), projectID, source, fileName, mapping, bytes.NewReader(file))
+	if err != nil {
+		h.handleUploadError(r.Context(), w, err)
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusAccepted, job)
+}
+
+// GetImports возвращает задания импорта проекта
+// @Summary      Получить задания импорта
+// @Description  Возвращает последние 20 заданий импорта проекта, новые первыми
+// @Tags         imports
+// @Produce      json
+// @Param        projectId  path  string  true  "ID проекта"
+// @Success      200  {array}  dto.ImportJobDTO "Задания импорта"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/imports [get]
+func (h *ImportHandler) GetImports(w http.ResponseWriter, r *http.Request) {
+	const op = "ImportHandler.GetImports"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid project ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	jobs, err := h.uc.GetImports(r.Context(), projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get import jobs")
+		handler.HandleError(r.Context(), w, err, "Failed to get import jobs")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, jobs)
+}
+
+// GetImport возвращает задание импорта
+// @Summary      Получить задание импорта
+// @Description  Возвращает статус задания, прогресс в процентах и отчет проверки
+// @Tags         imports
+// @Produce      json
+// @Param        projectId  path  string  true  "ID проекта"
+// @Param        jobId      path  string  true  "ID задания"
+// @Success      200  {object} dto.ImportJobDTO "Задание импорта"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      404  {object} dto.ErrorResponse "Задание не найдено"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/imports/{jobId} [get]
+func (h *ImportHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	const op = "ImportHandler.GetImport"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, jobID, ok := parseJobVars(w, r)
+	if !ok {
+		logger.Warn("invalid import job path")
+		return
+	}
+
+	job, err := h.uc.GetImport(r.Context(), projectID, jobID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get import job")
+		handler.HandleError(r.Context(), w, err, "Failed to get import job")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, job)
+}
+
+// CommitImport подтверждает импорт
+// @Summary      Подтвердить импорт
+// @Description  Запускает запись проверенного файла в проект. Задание должно быть в статусе validated и без ошибок в отчете. Все записи сохраняются одной транзакцией: при сбое задание получает статус failed, а проект не меняется
+// @Tags         imports
+// @Produce      json
+// @Param        projectId  path  string  true  "ID проекта"
+// @Param        jobId      path  string  true  "ID задания"
+// @Success      202  {object} dto.ImportJobDTO "Задание импорта"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      404  {object} dto.ErrorResponse "Задание не найдено"
+// @Failure      409  {object} dto.ErrorResponse "Задание еще не проверено, уже запущено или содержит ошибки"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/imports/{jobId}/commit [post]
+func (h *ImportHandler) CommitImport(w http.ResponseWriter, r *http.Request) {
+	const op = "ImportHandler.CommitImport"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, jobID, ok := parseJobVars(w, r)
+	if !ok {
+		logger.Warn("invalid import job path")
+		return
+	}
+
+	job, err := h.uc.CommitImport(r.Context(), projectID, jobID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to commit import")
+		handler.HandleError(r.Context(), w, err, "Failed to commit import")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusAccepted, job)
+}
+
+func (h *ImportHandler) handleUploadError(ctx context.Context, w http.ResponseWriter, err error) {
+	logger := logctx.GetLogger(ctx).WithField("op", "ImportHandler.CreateImport")
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		logger.WithError(err).Warn("request body is too large")
+		response.SendError(ctx, w, http.StatusRequestEntityTooLarge, "File is too large")
+		return
+	}
+
+	logger.WithError(err).Error("failed to create import job")
+	handler.HandleError(ctx, w, err, "Failed to create import job")
+}
+
+func parseJobVars(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+	projectID, err := uuid.Parse(vars["projectId"])
+	if err != nil {
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	jobID, err := uuid.Parse(vars["jobId"])
+	if err != nil {
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid import job ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return projectID, jobID, true
+}
+
+func readField(part io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(part, maxFieldSize))
+	return string(data), err
+}
@@ -0,0 +1,236 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/importjob"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newImportRequest(method, url string, body io.Reader, vars map[string]string) *http.Request {
+	req := httptest.NewRequest(method, url, body)
+	ctx := logctx.WithLogger(req.Context(), logctx.NewLogger())
+	ctx = context.WithValue(ctx, domains.UserIDKey{}, uuid.New().String())
+	req = req.WithContext(ctx)
+	return mux.SetURLVars(req, vars)
+}
+
+// importBody собирает форму. Файл идет первым, чтобы проверить, что порядок полей не важен
+func importBody(t *testing.T, fields map[string]string, content string) (*bytes.Buffer, string) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if content != "" {
+		part, err := writer.CreateFormFile("file", "tasks.csv")
+		assert.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	for name, value := range fields {
+		assert.NoError(t, writer.WriteField(name, value))
+	}
+	assert.NoError(t, writer.Close())
+	return &body, writer.FormDataContentType()
+}
+
+func TestImportHandler_CreateImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockImportUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{ImportConfig: &config.ImportConfig{MaxFileSize: 100}})
+
+	projectID := uuid.New()
+
+	tests := []struct {
+		name           string
+		fields         map[string]string
+		content        string
+		setupMocks     func()
+		expectedStatus int
+	}{
+		{
+			name:    "success",
+			fields:  map[string]string{"source": "csv", "mapping": `{"labels": {"urgent": 3}}`},
+			content: "title\nКупить молоко\n",
+			setupMocks: func() {
+				mockUsecase.EXPECT().CreateImport(gomock.Any(), projectID, "csv", "tasks.csv", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, source, fileName string, mapping dto.ImportMappingDTO, r io.Reader) (*dto.ImportJobDTO, error) {
+						data, _ := io.ReadAll(r)
+						assert.Equal(t, "title\nКупить молоко\n", string(data))
+						assert.Equal(t, 3, mapping.Labels["urgent"])
+						return &dto.ImportJobDTO{ID: uuid.New(), Source: source, Status: "queued", FileName: fileName}, nil
+					})
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "unknown source",
+			fields:         map[string]string{"source": "asana"},
+			content:        "title\n",
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid mapping",
+			fields:         map[string]string{"source": "csv", "mapping": `{"statuses": {"Done": "closed"}}`},
+			content:        "title\n",
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing file",
+			fields:         map[string]string{"source": "csv"},
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "no access",
+			fields:  map[string]string{"source": "trello"},
+			content: "{}",
+			setupMocks: func() {
+				mockUsecase.EXPECT().CreateImport(gomock.Any(), projectID, "trello", "tasks.csv", gomock.Any(), gomock.Any()).
+					Return(nil, errs.ErrNoAccess)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "file too large",
+			fields:         map[string]string{"source": "csv"},
+			content:        strings.Repeat("x", 2<<20),
+			setupMocks:     func() {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			body, contentType := importBody(t, tt.fields, tt.content)
+			req := newImportRequest(http.MethodPost, "/api/projects/"+projectID.String()+"/imports", body,
+				map[string]string{"projectId": projectID.String()})
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			handler.CreateImport(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestImportHandler_CommitImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockImportUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{ImportConfig: &config.ImportConfig{MaxFileSize: 100}})
+
+	projectID := uuid.New()
+	jobID := uuid.New()
+
+	tests := []struct {
+		name           string
+		jobID          string
+		setupMocks     func()
+		expectedStatus int
+	}{
+		{
+			name:  "success",
+			jobID: jobID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().CommitImport(gomock.Any(), projectID, jobID).
+					Return(&dto.ImportJobDTO{ID: jobID, Status: "pending_commit"}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:  "not validated",
+			jobID: jobID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().CommitImport(gomock.Any(), projectID, jobID).Return(nil, errs.ErrImportNotReady)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:  "validation errors",
+			jobID: jobID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().CommitImport(gomock.Any(), projectID, jobID).Return(nil, errs.ErrImportHasErrors)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "invalid job ID",
+			jobID:          "bad",
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			req := newImportRequest(http.MethodPost, "/commit", nil,
+				map[string]string{"projectId": projectID.String(), "jobId": tt.jobID})
+			w := httptest.NewRecorder()
+
+			handler.CommitImport(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestImportHandler_GetImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockImportUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{ImportConfig: &config.ImportConfig{MaxFileSize: 100}})
+
+	projectID := uuid.New()
+	jobID := uuid.New()
+
+	mockUsecase.EXPECT().GetImport(gomock.Any(), projectID, jobID).Return(&dto.ImportJobDTO{
+		ID:       jobID,
+		Status:   "validated",
+		Progress: 100,
+		Report:   &dto.ImportReportDTO{Total: 2, Tasks: 2, Errors: []dto.ImportRowErrorDTO{}},
+	}, nil)
+
+	req := newImportRequest(http.MethodGet, "/", nil,
+		map[string]string{"projectId": projectID.String(), "jobId": jobID.String()})
+	w := httptest.NewRecorder()
+
+	handler.GetImport(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var job dto.ImportJobDTO
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&job))
+	assert.Equal(t, 100, job.Progress)
+	assert.Equal(t, 2, job.Report.Tasks)
+
+	mockUsecase.EXPECT().GetImport(gomock.Any(), projectID, jobID).Return(nil, errs.NewNotFoundError("import job not found"))
+	w = httptest.NewRecorder()
+	handler.GetImport(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,255 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/live"
+	outboxmodels "github.com/lzimin05/course-todo/internal/models/outbox"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/live"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+)
+
+const (
+	// sseRetry - через сколько миллисекунд браузер переподключается после обрыва
+	sseRetry       = 3000
+	wsWriteTimeout = 10 * time.Second
+	wsReadLimit    = 512
+	eventTypeReset = "reset"
+)
+
+//go:generate mockgen -source=live.go -destination=../../usecase/mocks/live_usecase_mock.go -package=mocks LiveUsecase
+type LiveUsecase interface {
+	Stream(ctx context.Context, afterSeq int64, w models.Writer) error
+}
+
+type LiveHandler struct {
+	uc       LiveUsecase
+	config   *config.Config
+	upgrader websocket.Upgrader
+}
+
+func New(uc LiveUsecase, cfg *config.Config) *LiveHandler {
+	return &LiveHandler{
+		uc:     uc,
+		config: cfg,
+		// Проверка Origin по умолчанию пропускает только страницы с того же хоста:
+		// авторизация по cookie, чужой сайт не должен открыть подписку
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+		},
+	}
+}
+
+// Events отправляет изменения проектов пользователя через Server-Sent Events
+// @Summary      Подписка на изменения (SSE)
+// @Description  Поток text/event-stream с событиями задач, заметок и участников всех проектов пользователя. Каждое событие: "id: <номер>", "event: <тип>", "data: <JSON события, как в теле вебхука>". После обрыва браузер переподключается с заголовком Last-Event-ID и получает пропущенные события. Событие reset означает, что пропущено слишком много и данные нужно загрузить заново. Раз в LIVE_HEARTBEAT приходит комментарий ": ping"
+// @Tags         live
+// @Produce      text/event-stream
+// @Param        Last-Event-ID  header  string  false  "Номер последнего полученного события"
+// @Param        last_event_id  query   string  false  "То же, что Last-Event-ID, для клиентов без заголовков"
+// @Success      200  {string} string "Поток событий"
+// @Failure      400  {object} dto.ErrorResponse "Неверный номер события"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /events [get]
+func (h *LiveHandler) Events(w http.ResponseWriter, r *http.Request) {
+	const op = "LiveHandler.Events"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	afterSeq, err := parseLastEventID(r)
+	if err != nil {
+		logger.WithError(err).Warn("invalid last event ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid last event ID")
+		return
+	}
+
+	writer := &sseWriter{w: w, rc: http.NewResponseController(w)}
+	err = h.uc.Stream(r.Context(), afterSeq, writer)
+	if err != nil && !writer.opened {
+		logger.WithError(err).Error("failed to subscribe to events")
+		handler.HandleError(r.Context(), w, err, "Failed to subscribe to events")
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Warn("event stream closed")
+	}
+}
+
+// WebSocket отправляет изменения проектов пользователя через WebSocket
+// @Summary      Подписка на изменения (WebSocket)
+// @Description  То же, что /events, но через WebSocket. Каждое сообщение - JSON {"id", "type", "data"}; {"type": "reset"} означает, что данные нужно загрузить заново. Для возобновления передайте номер последнего события в last_event_id. Сообщения от клиента игнорируются, сервер периодически отправляет ping
+// @Tags         live
+// @Param        last_event_id  query   string  false  "Номер последнего полученного события"
+// @Success      101  {object} dto.EventDTO "Переключение на WebSocket"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /events/ws [get]
+func (h *LiveHandler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	const op = "LiveHandler.WebSocket"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	afterSeq, err := parseLastEventID(r)
+	if err != nil {
+		logger.WithError(err).Warn("invalid last event ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid last event ID")
+		return
+	}
+
+	// Соединение закрывается и при обрыве со стороны клиента, о котором
+	// узнает только читающая горутина
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	writer := &wsWriter{w: w, r: r, upgrader: &h.upgrader, cancel: cancel}
+	err = h.uc.Stream(ctx, afterSeq, writer)
+	if err != nil && !writer.opened {
+		logger.WithError(err).Error("failed to subscribe to events")
+		handler.HandleError(r.Context(), w, err, "Failed to subscribe to events")
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Warn("event stream closed")
+	}
+	writer.Close(err)
+}
+
+func parseLastEventID(r *http.Request) (int64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	seq, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, fmt.Errorf("invalid last event ID %q", value)
+	}
+	return seq, nil
+}
+
+type sseWriter struct {
+	w      http.ResponseWriter
+	rc     *http.ResponseController
+	opened bool
+}
+
+func (s *sseWriter) Open() error {
+	s.opened = true
+
+	// Поток живет дольше любого таймаута записи сервера
+	if err := s.rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	header := s.w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	s.w.WriteHeader(http.StatusOK)
+
+	return s.write(fmt.Sprintf("retry: %d\n\n", sseRetry))
+}
+
+func (s *sseWriter) WriteEvent(msg outboxmodels.Message) error {
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", msg.PublishedSeq, msg.Type, msg.Payload))
+}
+
+func (s *sseWriter) WriteReset() error {
+	return s.write(fmt.Sprintf("event: %s\ndata: {}\n\n", eventTypeReset))
+}
+
+func (s *sseWriter) WriteHeartbeat() error {
+	return s.write(": ping\n\n")
+}
+
+func (s *sseWriter) write(chunk string) error {
+	if _, err := s.w.Write([]byte(chunk)); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+type wsWriter struct {
+	w        http.ResponseWriter
+	r        *http.Request
+	upgrader *websocket.Upgrader
+	cancel   context.CancelFunc
+	conn     *websocket.Conn
+	opened   bool
+}
+
+func (s *wsWriter) Open() error {
+	// При ошибке Upgrade сам отвечает клиенту
+	s.opened = true
+
+	conn, err := s.upgrader.Upgrade(s.w, s.r, nil)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+
+	conn.SetReadLimit(wsReadLimit)
+	go func() {
+		defer s.cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *wsWriter) WriteEvent(msg outboxmodels.Message) error {
+	return s.writeJSON(dto.EventDTO{
+		ID:   strconv.FormatInt(msg.PublishedSeq, 10),
+		Type: msg.Type,
+		Data: msg.Payload,
+	})
+}
+
+func (s *wsWriter) WriteReset() error {
+	return s.writeJSON(dto.EventDTO{Type: eventTypeReset})
+}
+
+func (s *wsWriter) WriteHeartbeat() error {
+	return s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout))
+}
+
+func (s *wsWriter) writeJSON(v dto.EventDTO) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+	return s.conn.WriteJSON(v)
+}
+
+// Close закрывает соединение. Если подписка прервана сервером с ошибкой,
+// клиент получает код 1013 и может переподключиться
+func (s *wsWriter) Close(err error) {
+	if s.conn == nil {
+		return
+	}
+
+	code := websocket.CloseNormalClosure
+	if err != nil {
+		code = websocket.CloseTryAgainLater
+	}
+	_ = s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, ""), time.Now().Add(wsWriteTimeout))
+	_ = s.conn.Close()
+}
@@ -0,0 +1,139 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/live"
+	outboxmodels "github.com/lzimin05/course-todo/internal/models/outbox"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/live"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestLiveTransport_Events(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockLiveUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	msg := outboxmodels.Message{ID: uuid.New(), PublishedSeq: 43, Type: "task.updated", Payload: []byte(`{"type":"task.updated"}`)}
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		query       string
+		mockFunc    func()
+		statusCode  int
+		contains    []string
+	}{
+		{
+			name:        "Stream with resume",
+			lastEventID: "42",
+			mockFunc: func() {
+				mockUsecase.EXPECT().Stream(gomock.Any(), int64(42), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, afterSeq int64, w models.Writer) error {
+						assert.NoError(t, w.Open())
+						assert.NoError(t, w.WriteReset())
+						assert.NoError(t, w.WriteEvent(msg))
+						assert.NoError(t, w.WriteHeartbeat())
+						return nil
+					})
+			},
+			statusCode: http.StatusOK,
+			contains: []string{
+				"retry: 3000\n\n",
+				"event: reset\ndata: {}\n\n",
+				"id: 43\nevent: task.updated\ndata: {\"type\":\"task.updated\"}\n\n",
+				": ping\n\n",
+			},
+		},
+		{
+			name:  "Resume from query",
+			query: "?last_event_id=7",
+			mockFunc: func() {
+				mockUsecase.EXPECT().Stream(gomock.Any(), int64(7), gomock.Any()).Return(nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:        "Invalid last event ID",
+			lastEventID: "abc",
+			mockFunc:    func() {},
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "Subscription failed",
+			mockFunc: func() {
+				mockUsecase.EXPECT().Stream(gomock.Any(), int64(0), gomock.Any()).Return(errors.New("db error"))
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodGet, "/events"+tt.query, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.Events(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			for _, part := range tt.contains {
+				assert.Contains(t, rr.Body.String(), part)
+			}
+			if len(tt.contains) > 0 {
+				assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestLiveTransport_WebSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockLiveUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	server := httptest.NewServer(http.HandlerFunc(handler.WebSocket))
+	defer server.Close()
+
+	msg := outboxmodels.Message{ID: uuid.New(), PublishedSeq: 5, Type: "member.added", Payload: []byte(`{"type":"member.added"}`)}
+	mockUsecase.EXPECT().Stream(gomock.Any(), int64(4), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, afterSeq int64, w models.Writer) error {
+			if err := w.Open(); err != nil {
+				return err
+			}
+			return w.WriteEvent(msg)
+		})
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/?last_event_id=4"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	var event dto.EventDTO
+	assert.NoError(t, conn.ReadJSON(&event))
+	assert.Equal(t, "5", event.ID)
+	assert.Equal(t, "member.added", event.Type)
+	assert.JSONEq(t, `{"type":"member.added"}`, string(event.Data))
+
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+}
@@ -0,0 +1,65 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/lzimin05/course-todo/config"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/mention"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	searchvalidation "github.com/lzimin05/course-todo/internal/transport/utils/validation/search"
+)
+
+//go:generate mockgen -source=mention.go -destination=../../usecase/mocks/mention_usecase_mock.go -package=mocks MentionUsecase
+type MentionUsecase interface {
+	GetMyMentions(ctx context.Context, limit, offset int) ([]dto.MentionDTO, error)
+}
+
+type MentionHandler struct {
+	uc     MentionUsecase
+	config *config.Config
+}
+
+func New(uc MentionUsecase, cfg *config.Config) *MentionHandler {
+	return &MentionHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// GetMyMentions возвращает упоминания текущего пользователя
+// @Summary      Получить мои упоминания
+// @Description  Возвращает задачи и заметки, в тексте которых упомянут текущий пользователь (@login), новые первыми
+// @Tags         users
+// @Produce      json
+// @Param        limit   query  int  false  "Количество (1-100), по умолчанию 20"
+// @Param        offset  query  int  false  "Смещение"
+// @Success      200  {array}  dto.MentionDTO "Упоминания"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /users/me/mentions [get]
+func (h *MentionHandler) GetMyMentions(w http.ResponseWriter, r *http.Request) {
+	const op = "MentionHandler.GetMyMentions"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	query := r.URL.Query()
+	limit, offset, err := searchvalidation.ValidationPagination(query.Get("limit"), query.Get("offset"))
+	if err != nil {
+		logger.Warn("pagination validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	mentions, err := h.uc.GetMyMentions(r.Context(), limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("failed to get mentions")
+		handler.HandleError(r.Context(), w, err, "Failed to get mentions")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, mentions)
+}
@@ -0,0 +1,84 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/mention"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestMentionTransport_GetMyMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockMentionUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	sourceID := uuid.New()
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func()
+		statusCode int
+		contains   string
+	}{
+		{
+			name:  "Success",
+			query: "?limit=10&offset=5",
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetMyMentions(gomock.Any(), 10, 5).
+					Return([]dto.MentionDTO{{SourceType: "note", SourceID: sourceID, Title: "Plan", CreatedAt: time.Now()}}, nil)
+			},
+			statusCode: http.StatusOK,
+			contains:   sourceID.String(),
+		},
+		{
+			name:  "Defaults",
+			query: "",
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetMyMentions(gomock.Any(), 20, 0).Return([]dto.MentionDTO{}, nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Invalid limit",
+			query:      "?limit=1000",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "Internal error",
+			query: "",
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetMyMentions(gomock.Any(), 20, 0).Return(nil, errors.New("db error"))
+			},
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodGet, "/users/me/mentions"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.GetMyMentions(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			if tt.contains != "" {
+				assert.Contains(t, rr.Body.String(), tt.contains)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+)
+
+// AppPasswordAuthenticator проверяет логин и пароль приложения
+type AppPasswordAuthenticator interface {
+	AuthenticateAppPassword(ctx context.Context, loginOrEmail, password string) (uuid.UUID, error)
+}
+
+// BasicAuthMiddleware авторизует запросы по паролю приложения в заголовке
+// Authorization: Basic. Используется для CalDAV: клиенты не умеют передавать JWT
+func BasicAuthMiddleware(auth AppPasswordAuthenticator, realm string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			login, password, ok := r.BasicAuth()
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Basic realm="`+realm+`", charset="UTF-8"`)
+				response.SendError(r.Context(), w, http.StatusUnauthorized, "Basic authorization is required")
+				return
+			}
+
+			userID, err := auth.AuthenticateAppPassword(r.Context(), login, password)
+			if err != nil {
+				if errors.Is(err, errs.ErrInvalidCredentials) {
+					w.Header().Set("WWW-Authenticate", `Basic realm="`+realm+`", charset="UTF-8"`)
+					response.SendError(r.Context(), w, http.StatusUnauthorized, "Invalid credentials")
+					return
+				}
+				response.SendError(r.Context(), w, http.StatusInternalServerError, "Internal server error")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), domains.UserIDKey{}, userID.String())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
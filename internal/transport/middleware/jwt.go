@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/lzimin05/course-todo/internal/infrastructure/redis"
 	"github.com/lzimin05/course-todo/internal/models/domains"
@@ -10,7 +11,7 @@ import (
 	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
 )
 
-// AuthMiddleware создает middleware для проверки аутентификации и blacklist в Redis
+// AuthMiddleware создает middleware для проверки аутентификации, blacklist и отзыва сессий в Redis
 func AuthMiddleware(tokenator *jwt.Tokenator, redisRepo *redis.AuthRepository) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -35,6 +36,18 @@ func AuthMiddleware(tokenator *jwt.Tokenator, redisRepo *redis.AuthRepository) f
 					response.SendError(r.Context(), w, http.StatusInternalServerError, "Internal server error")
 					return
 				}
+				// Токены, выпущенные до отзыва всех сессий пользователя, недействительны
+				if !blacklisted {
+					var issuedAt time.Time
+					if claims.IssuedAt != nil {
+						issuedAt = claims.IssuedAt.Time
+					}
+					blacklisted, err = redisRepo.IsRevoked(r.Context(), claims.UserID, issuedAt)
+					if err != nil {
+						response.SendError(r.Context(), w, http.StatusInternalServerError, "Internal server error")
+						return
+					}
+				}
 				if blacklisted {
 					http.SetCookie(w, &http.Cookie{
 						Name:     domains.TokenCookieName,
@@ -0,0 +1,268 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/note"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	"github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/note"
+)
+
+// CreateFolder создает папку заметок
+// @Summary      Создать папку заметок
+// @Description  Создает папку в проекте. Если указан parent_id, папка создается внутри него
+// @Tags         notes
+// @Accept       json
+// @Produce      json
+// @Param        folder  body  dto.CreateNoteFolderDTO  true  "Данные папки"
+// @Success      201  {object} dto.NoteFolderDTO "Созданная папка"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      404  {object} dto.ErrorResponse "Родительская папка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/folders [post]
+func (h *NoteHandler) CreateFolder(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.CreateFolder"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	var req dto.CreateNoteFolderDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if err := validation.ValidationFolderName(req.Name); err != nil {
+		logger.WithError(err).Warn("invalid folder name")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	folder, err := h.uc.CreateFolder(r.Context(), req)
+	if err != nil {
+		logger.WithError(err).Error("failed to create folder")
+		handler.HandleError(r.Context(), w, err, "Failed to create folder")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, folder)
+}
+
+// UpdateFolder переименовывает или переносит папку
+// @Summary      Изменить папку заметок
+// @Description  Меняет название папки и ее родителя. parent_id = null переносит папку в корень проекта
+// @Tags         notes
+// @Accept       json
+// @Produce      json
+// @Param        folderId  path  string  true  "ID папки"
+// @Param        folder    body  dto.UpdateNoteFolderDTO  true  "Данные папки"
+// @Success      204  "Папка успешно обновлена"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос или перенос папки в саму себя"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Папка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/folders/{folderId} [put]
+func (h *NoteHandler) UpdateFolder(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.UpdateFolder"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	folderID, err := uuid.Parse(mux.Vars(r)["folderId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid folder ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid folder ID format")
+		return
+	}
+
+	var req dto.UpdateNoteFolderDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if err := validation.ValidationFolderName(req.Name); err != nil {
+		logger.WithError(err).Warn("invalid folder name")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.uc.UpdateFolder(r.Context(), folderID, req); err != nil {
+		logger.WithError(err).Error("failed to update folder")
+		handler.HandleError(r.Context(), w, err, "Failed to update folder")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteFolder удаляет папку
+// @Summary      Удалить папку заметок
+// @Description  Удаляет папку и вложенные папки. Заметки из них не удаляются и переходят в конец корня проекта
+// @Tags         notes
+// @Produce      json
+// @Param        folderId  path  string  true  "ID папки"
+// @Success      204  "Папка успешно удалена"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Папка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/folders/{folderId} [delete]
+func (h *NoteHandler) DeleteFolder(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.DeleteFolder"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	folderID, err := uuid.Parse(mux.Vars(r)["folderId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid folder ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid folder ID format")
+		return
+	}
+
+	if err := h.uc.DeleteFolder(r.Context(), folderID); err != nil {
+		logger.WithError(err).Error("failed to delete folder")
+		handler.HandleError(r.Context(), w, err, "Failed to delete folder")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetNoteTree получает дерево заметок проекта
+// @Summary      Получить дерево заметок
+// @Description  Возвращает папки проекта с вложенными папками и заметками одним ответом. Заметки вне папок лежат в корне
+// @Tags         notes
+// @Produce      json
+// @Param        projectId  path  string  true  "ID проекта"
+// @Success      200  {object} dto.NoteTreeDTO "Дерево заметок"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/notes/tree [get]
+func (h *NoteHandler) GetNoteTree(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.GetNoteTree"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid project ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	tree, err := h.uc.GetNoteTree(r.Context(), projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note tree")
+		handler.HandleError(r.Context(), w, err, "Failed to get note tree")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, tree)
+}
+
+// MoveNote переносит заметку
+// @Summary      Переместить заметку
+// @Description  Помещает заметку в папку (folder_id = null - корень проекта) на указанную позицию. Позиция больше числа заметок ставит заметку в конец
+// @Tags         notes
+// @Accept       json
+// @Produce      json
+// @Param        noteId  path  string  true  "ID заметки"
+// @Param        move    body  dto.MoveNoteDTO  true  "Папка и позиция"
+// @Success      200  {object} dto.MoveNoteDTO "Итоговое положение заметки"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Заметка или папка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/{noteId}/move [put]
+func (h *NoteHandler) MoveNote(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.MoveNote"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	noteID, err := uuid.Parse(mux.Vars(r)["noteId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid note ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid note ID format")
+		return
+	}
+
+	var req dto.MoveNoteDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if err := validation.ValidationNotePosition(req.Position); err != nil {
+		logger.WithError(err).Warn("invalid note position")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.uc.MoveNote(r.Context(), noteID, req)
+	if err != nil {
+		logger.WithError(err).Error("failed to move note")
+		handler.HandleError(r.Context(), w, err, "Failed to move note")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, result)
+}
+
+// SetNoteFlags меняет личные отметки заметки
+// @Summary      Закрепить заметку или добавить в избранное
+// @Description  Отметки видит только текущий пользователь. Непереданные поля не меняются
+// @Tags         notes
+// @Accept       json
+// @Produce      json
+// @Param        noteId  path  string  true  "ID заметки"
+// @Param        flags   body  dto.NoteFlagsDTO  true  "Отметки"
+// @Success      204  "Отметки сохранены"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Заметка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/{noteId}/flags [patch]
+func (h *NoteHandler) SetNoteFlags(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.SetNoteFlags"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	noteID, err := uuid.Parse(mux.Vars(r)["noteId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid note ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid note ID format")
+		return
+	}
+
+	var req dto.NoteFlagsDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if req.Pinned == nil && req.Favourite == nil {
+		logger.Warn("no flags to update")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "pinned or favourite is required")
+		return
+	}
+
+	if err := h.uc.SetNoteFlags(r.Context(), noteID, req); err != nil {
+		logger.WithError(err).Error("failed to set note flags")
+		handler.HandleError(r.Context(), w, err, "Failed to set note flags")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,276 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/note"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newFolderRequest(method, url string, body []byte, vars map[string]string) *http.Request {
+	req := httptest.NewRequest(method, url, bytes.NewReader(body))
+	ctx := logctx.WithLogger(req.Context(), logctx.NewLogger())
+	ctx = context.WithValue(ctx, domains.UserIDKey{}, uuid.New().String())
+	req = req.WithContext(ctx)
+	if vars != nil {
+		req = mux.SetURLVars(req, vars)
+	}
+	return req
+}
+
+func TestNoteHandler_CreateFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockINoteUsecase(ctrl)
+	handler := NewNoteHandler(mockUsecase, &config.Config{})
+
+	projectID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func()
+		expectedStatus int
+	}{
+		{
+			name: "successful creation",
+			body: `{"project_id":"` + projectID.String() + `","name":"Docs"}`,
+			setupMocks: func() {
+				mockUsecase.EXPECT().CreateFolder(gomock.Any(), dto.CreateNoteFolderDTO{ProjectID: projectID, Name: "Docs"}).
+					Return(&dto.NoteFolderDTO{ID: uuid.New(), ProjectID: projectID, Name: "Docs", Path: "Docs"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "name with slash",
+			body:           `{"project_id":"` + projectID.String() + `","name":"a/b"}`,
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid json",
+			body:           `{`,
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "no project access",
+			body: `{"project_id":"` + projectID.String() + `","name":"Docs"}`,
+			setupMocks: func() {
+				mockUsecase.EXPECT().CreateFolder(gomock.Any(), gomock.Any()).Return(nil, errs.ErrNoAccess)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			req := newFolderRequest(http.MethodPost, "/notes/folders", []byte(tt.body), nil)
+			rr := httptest.NewRecorder()
+			handler.CreateFolder(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestNoteHandler_UpdateFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockINoteUsecase(ctrl)
+	handler := NewNoteHandler(mockUsecase, &config.Config{})
+
+	folderID := uuid.New()
+
+	tests := []struct {
+		name           string
+		folderID       string
+		setupMocks     func()
+		expectedStatus int
+	}{
+		{
+			name:     "successful update",
+			folderID: folderID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().UpdateFolder(gomock.Any(), folderID, dto.UpdateNoteFolderDTO{Name: "Docs"}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:     "cycle",
+			folderID: folderID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().UpdateFolder(gomock.Any(), folderID, gomock.Any()).Return(errs.ErrFolderCycle)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid folder ID",
+			folderID:       "invalid-uuid",
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			req := newFolderRequest(http.MethodPut, "/notes/folders/"+tt.folderID, []byte(`{"name":"Docs"}`),
+				map[string]string{"folderId": tt.folderID})
+			rr := httptest.NewRecorder()
+			handler.UpdateFolder(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestNoteHandler_GetNoteTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockINoteUsecase(ctrl)
+	handler := NewNoteHandler(mockUsecase, &config.Config{})
+
+	projectID := uuid.New()
+	tree := &dto.NoteTreeDTO{
+		ProjectID: projectID,
+		Folders: []*dto.NoteFolderNodeDTO{
+			{ID: uuid.New(), Name: "Docs", Path: "Docs", Folders: []*dto.NoteFolderNodeDTO{}, Notes: []*dto.NoteDTO{}},
+		},
+		Notes: []*dto.NoteDTO{},
+	}
+
+	mockUsecase.EXPECT().GetNoteTree(gomock.Any(), projectID).Return(tree, nil)
+
+	req := newFolderRequest(http.MethodGet, "/projects/"+projectID.String()+"/notes/tree", nil,
+		map[string]string{"projectId": projectID.String()})
+	rr := httptest.NewRecorder()
+	handler.GetNoteTree(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var result dto.NoteTreeDTO
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+	assert.Len(t, result.Folders, 1)
+	assert.Equal(t, "Docs", result.Folders[0].Path)
+}
+
+func TestNoteHandler_MoveNote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockINoteUsecase(ctrl)
+	handler := NewNoteHandler(mockUsecase, &config.Config{})
+
+	noteID := uuid.New()
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func()
+		expectedStatus int
+	}{
+		{
+			name: "move to root",
+			body: `{"folder_id":null,"position":2}`,
+			setupMocks: func() {
+				mockUsecase.EXPECT().MoveNote(gomock.Any(), noteID, dto.MoveNoteDTO{Position: 2}).
+					Return(&dto.MoveNoteDTO{Position: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "negative position",
+			body:           `{"position":-1}`,
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "note not found",
+			body: `{"position":0}`,
+			setupMocks: func() {
+				mockUsecase.EXPECT().MoveNote(gomock.Any(), noteID, gomock.Any()).Return(nil, errs.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			req := newFolderRequest(http.MethodPut, "/notes/"+noteID.String()+"/move", []byte(tt.body),
+				map[string]string{"noteId": noteID.String()})
+			rr := httptest.NewRecorder()
+			handler.MoveNote(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestNoteHandler_SetNoteFlags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockINoteUsecase(ctrl)
+	handler := NewNoteHandler(mockUsecase, &config.Config{})
+
+	noteID := uuid.New()
+	pinned := true
+
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func()
+		expectedStatus int
+	}{
+		{
+			name: "pin note",
+			body: `{"pinned":true}`,
+			setupMocks: func() {
+				mockUsecase.EXPECT().SetNoteFlags(gomock.Any(), noteID, dto.NoteFlagsDTO{Pinned: &pinned}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "empty body",
+			body:           `{}`,
+			setupMocks:     func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			req := newFolderRequest(http.MethodPatch, "/notes/"+noteID.String()+"/flags", []byte(tt.body),
+				map[string]string{"noteId": noteID.String()})
+			rr := httptest.NewRecorder()
+			handler.SetNoteFlags(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
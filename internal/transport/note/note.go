@@ -8,10 +8,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/lzimin05/course-todo/config"
+	linkdto "github.com/lzimin05/course-todo/internal/transport/dto/link"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/note"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/etag"
 	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
 	"github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/note"
 )
 
 //go:generate mockgen -source=note.go -destination=../../usecase/mocks/note_usecase_mock.go -package=mocks INoteUsecase
@@ -19,8 +22,21 @@ type INoteUsecase interface {
 	GetAllNotes(ctx context.Context) ([]*dto.NoteDTO, error)
 	GetNotesByProject(ctx context.Context, projectID uuid.UUID) ([]*dto.NoteDTO, error)
 	CreateNote(ctx context.Context, req dto.CreateOrUpdateNote) (*dto.CreateNoteDTO, error)
-	UpdateNote(ctx context.Context, noteID uuid.UUID, req dto.CreateOrUpdateNote) error
-	DeleteNote(ctx context.Context, noteID uuid.UUID) error
+	GetNoteByID(ctx context.Context, noteID uuid.UUID) (*dto.NoteDTO, error)
+	UpdateNote(ctx context.Context, noteID uuid.UUID, req dto.CreateOrUpdateNote, expectedVersions []int) (int, error)
+	DeleteNote(ctx context.Context, noteID uuid.UUID, expectedVersions []int) error
+	GetNoteRevisions(ctx context.Context, noteID uuid.UUID) ([]*dto.NoteRevisionSummaryDTO, error)
+	GetNoteRevision(ctx context.Context, noteID uuid.UUID, revision int) (*dto.NoteRevisionDTO, error)
+	DiffNoteRevisions(ctx context.Context, noteID uuid.UUID, from, to int) (*dto.NoteDiffDTO, error)
+	RestoreNoteRevision(ctx context.Context, noteID uuid.UUID, revision int) (*dto.NoteRevisionDTO, error)
+	RenderNote(ctx context.Context, noteID uuid.UUID) (*dto.RenderedNoteDTO, error)
+	GetBacklinks(ctx context.Context, noteID uuid.UUID) ([]*linkdto.BacklinkDTO, error)
+	CreateFolder(ctx context.Context, req dto.CreateNoteFolderDTO) (*dto.NoteFolderDTO, error)
+	UpdateFolder(ctx context.Context, folderID uuid.UUID, req dto.UpdateNoteFolderDTO) error
+	DeleteFolder(ctx context.Context, folderID uuid.UUID) error
+	GetNoteTree(ctx context.Context, projectID uuid.UUID) (*dto.NoteTreeDTO, error)
+	MoveNote(ctx context.Context, noteID uuid.UUID, req dto.MoveNoteDTO) (*dto.MoveNoteDTO, error)
+	SetNoteFlags(ctx context.Context, noteID uuid.UUID, req dto.NoteFlagsDTO) error
 }
 
 type NoteHandler struct {
@@ -62,7 +78,7 @@ func (h *NoteHandler) GetAllNotes(w http.ResponseWriter, r *http.Request) {
 
 // GetNotesByProject получает все заметки проекта
 // @Summary      Получить заметки проекта
-// @Description  Возвращает список всех заметок указанного проекта
+// @Description  Возвращает плоский список заметок проекта: закрепленные первыми, затем в ручном порядке. Для заметок в папках заполняется путь папки
 // @Tags         notes
 // @Produce      json
 // @Param        projectId  path  string  true  "ID проекта"
@@ -94,9 +110,45 @@ func (h *NoteHandler) GetNotesByProject(w http.ResponseWriter, r *http.Request)
 	response.SendJSONResponse(r.Context(), w, http.StatusOK, notes)
 }
 
+// GetNoteByID получает заметку
+// @Summary      Получить заметку
+// @Description  Возвращает заметку по ID. Текущая версия заметки передается в заголовке ETag
+// @Tags         notes
+// @Produce      json
+// @Param        noteId  path  string  true  "ID заметки"
+// @Success      200  {object} dto.NoteDTO "Заметка"
+// @Header       200  {string} ETag "Версия заметки"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Заметка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/{noteId} [get]
+func (h *NoteHandler) GetNoteByID(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.GetNoteByID"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	noteID, err := uuid.Parse(mux.Vars(r)["noteId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid note ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid note ID format")
+		return
+	}
+
+	note, err := h.uc.GetNoteByID(r.Context(), noteID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note")
+		handler.HandleError(r.Context(), w, err, "Failed to get note")
+		return
+	}
+
+	etag.Set(w, note.Version)
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, note)
+}
+
 // CreateNote создает новую заметку
 // @Summary      Создать новую заметку
-// @Description  Создает новую заметку для пользователя
+// @Description  Создает новую заметку для пользователя. Формат текста plain (по умолчанию) или markdown
 // @Tags         notes
 // @Accept       json
 // @Produce      json
@@ -118,6 +170,12 @@ func (h *NoteHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := validation.ValidationNoteFormat(req.Format); err != nil {
+		logger.WithError(err).Warn("invalid note format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	noteID, err := h.uc.CreateNote(r.Context(), req)
 	if err != nil {
 		logger.WithError(err).Error("failed to create note")
@@ -130,16 +188,19 @@ func (h *NoteHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
 
 // UpdateNote обновляет заметку
 // @Summary      Обновить заметку
-// @Description  Обновляет существующую заметку пользователя
+// @Description  Обновляет существующую заметку пользователя. Если формат не передан, он не меняется
 // @Tags         notes
 // @Accept       json
 // @Produce      json
-// @Param        noteId  path  string  true  "ID заметки"
-// @Param        note    body  dto.CreateOrUpdateNote  true  "Данные для обновления заметки"
+// @Param        noteId    path    string  true   "ID заметки"
+// @Param        If-Match  header  string  false  "ETag, полученный при чтении заметки"
+// @Param        note      body    dto.CreateOrUpdateNote  true  "Данные для обновления заметки"
 // @Success      204  "Заметка успешно обновлена"
+// @Header       204  {string} ETag "Новая версия заметки"
 // @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
 // @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
 // @Failure      404  {object} dto.ErrorResponse "Заметка не найдена"
+// @Failure      412  {object} dto.ErrorResponse "Заметка изменена другим запросом"
 // @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
 // @Security     BearerAuth
 // @Router       /notes/{noteId}/edit [put]
@@ -161,13 +222,20 @@ func (h *NoteHandler) UpdateNote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.uc.UpdateNote(r.Context(), noteID, req)
+	if err := validation.ValidationNoteFormat(req.Format); err != nil {
+		logger.WithError(err).Warn("invalid note format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	version, err := h.uc.UpdateNote(r.Context(), noteID, req, etag.IfMatch(r))
 	if err != nil {
 		logger.WithError(err).Error("failed to update note")
 		handler.HandleError(r.Context(), w, err, "Failed to update note")
 		return
 	}
 
+	etag.Set(w, version)
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -176,11 +244,13 @@ func (h *NoteHandler) UpdateNote(w http.ResponseWriter, r *http.Request) {
 // @Description  Удаляет существующую заметку пользователя
 // @Tags         notes
 // @Produce      json
-// @Param        noteId  path  string  true  "ID заметки"
+// @Param        noteId    path    string  true   "ID заметки"
+// @Param        If-Match  header  string  false  "ETag, полученный при чтении заметки"
 // @Success      204  "Заметка успешно удалена"
 // @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
 // @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
 // @Failure      404  {object} dto.ErrorResponse "Заметка не найдена"
+// @Failure      412  {object} dto.ErrorResponse "Заметка изменена другим запросом"
 // @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
 // @Security     BearerAuth
 // @Router       /notes/{noteId} [delete]
@@ -195,7 +265,7 @@ func (h *NoteHandler) DeleteNote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.uc.DeleteNote(r.Context(), noteID)
+	err = h.uc.DeleteNote(r.Context(), noteID, etag.IfMatch(r))
 	if err != nil {
 		logger.WithError(err).Error("failed to delete note")
 		handler.HandleError(r.Context(), w, err, "Failed to delete note")
@@ -204,3 +274,235 @@ func (h *NoteHandler) DeleteNote(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetNoteRevisions получает историю правок заметки
+// @Summary      Получить историю заметки
+// @Description  Возвращает список ревизий заметки от новых к старым, без текста
+// @Tags         notes
+// @Produce      json
+// @Param        noteId  path  string  true  "ID заметки"
+// @Success      200  {array}  dto.NoteRevisionSummaryDTO "Список ревизий"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Заметка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/{noteId}/revisions [get]
+func (h *NoteHandler) GetNoteRevisions(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.GetNoteRevisions"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	noteID, err := uuid.Parse(mux.Vars(r)["noteId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid note ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid note ID format")
+		return
+	}
+
+	revisions, err := h.uc.GetNoteRevisions(r.Context(), noteID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note revisions")
+		handler.HandleError(r.Context(), w, err, "Failed to get note revisions")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, revisions)
+}
+
+// GetNoteRevision получает ревизию заметки
+// @Summary      Получить ревизию заметки
+// @Description  Возвращает заметку в состоянии указанной ревизии
+// @Tags         notes
+// @Produce      json
+// @Param        noteId    path  string   true  "ID заметки"
+// @Param        revision  path  integer  true  "Номер ревизии"
+// @Success      200  {object} dto.NoteRevisionDTO "Ревизия заметки"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Ревизия не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/{noteId}/revisions/{revision} [get]
+func (h *NoteHandler) GetNoteRevision(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.GetNoteRevision"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	noteID, err := uuid.Parse(mux.Vars(r)["noteId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid note ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid note ID format")
+		return
+	}
+
+	revision, err := validation.ValidationRevision(mux.Vars(r)["revision"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid revision")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	noteRevision, err := h.uc.GetNoteRevision(r.Context(), noteID, revision)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note revision")
+		handler.HandleError(r.Context(), w, err, "Failed to get note revision")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, noteRevision)
+}
+
+// DiffNoteRevisions сравнивает две ревизии заметки
+// @Summary      Сравнить ревизии заметки
+// @Description  Возвращает построчный diff описания и признак смены названия между ревизиями from и to
+// @Tags         notes
+// @Produce      json
+// @Param        noteId  path   string   true  "ID заметки"
+// @Param        from    query  integer  true  "Исходная ревизия"
+// @Param        to      query  integer  true  "Целевая ревизия"
+// @Success      200  {object} dto.NoteDiffDTO "Результат сравнения"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Ревизия не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/{noteId}/revisions/diff [get]
+func (h *NoteHandler) DiffNoteRevisions(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.DiffNoteRevisions"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	noteID, err := uuid.Parse(mux.Vars(r)["noteId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid note ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid note ID format")
+		return
+	}
+
+	query := r.URL.Query()
+	from, to, err := validation.ValidationRevisionRange(query.Get("from"), query.Get("to"))
+	if err != nil {
+		logger.WithError(err).Warn("invalid revision range")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	diff, err := h.uc.DiffNoteRevisions(r.Context(), noteID, from, to)
+	if err != nil {
+		logger.WithError(err).Error("failed to diff note revisions")
+		handler.HandleError(r.Context(), w, err, "Failed to diff note revisions")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, diff)
+}
+
+// RestoreNoteRevision восстанавливает заметку из ревизии
+// @Summary      Восстановить ревизию заметки
+// @Description  Возвращает заметке название и текст указанной ревизии. История не переписывается: восстановление создает новую ревизию
+// @Tags         notes
+// @Produce      json
+// @Param        noteId    path  string   true  "ID заметки"
+// @Param        revision  path  integer  true  "Номер ревизии"
+// @Success      200  {object} dto.NoteRevisionDTO "Новая ревизия заметки"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Ревизия не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/{noteId}/revisions/{revision}/restore [post]
+func (h *NoteHandler) RestoreNoteRevision(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.RestoreNoteRevision"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	noteID, err := uuid.Parse(mux.Vars(r)["noteId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid note ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid note ID format")
+		return
+	}
+
+	revision, err := validation.ValidationRevision(mux.Vars(r)["revision"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid revision")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	restored, err := h.uc.RestoreNoteRevision(r.Context(), noteID, revision)
+	if err != nil {
+		logger.WithError(err).Error("failed to restore note revision")
+		handler.HandleError(r.Context(), w, err, "Failed to restore note revision")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, restored)
+}
+
+// RenderNote отдает заметку в виде HTML
+// @Summary      Получить HTML заметки
+// @Description  Возвращает описание заметки, отрисованное на сервере: Markdown (CommonMark и GFM: таблицы, списки задач) или обычный текст. HTML очищен от опасной разметки
+// @Tags         notes
+// @Produce      json
+// @Param        noteId  path  string  true  "ID заметки"
+// @Success      200  {object} dto.RenderedNoteDTO "HTML заметки"
+// @Header       200  {string} ETag "Версия заметки"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Заметка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/{noteId}/html [get]
+func (h *NoteHandler) RenderNote(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.RenderNote"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	noteID, err := uuid.Parse(mux.Vars(r)["noteId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid note ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid note ID format")
+		return
+	}
+
+	rendered, err := h.uc.RenderNote(r.Context(), noteID)
+	if err != nil {
+		logger.WithError(err).Error("failed to render note")
+		handler.HandleError(r.Context(), w, err, "Failed to render note")
+		return
+	}
+
+	etag.Set(w, rendered.Version)
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, rendered)
+}
+
+// GetBacklinks получает обратные ссылки на заметку
+// @Summary      Получить обратные ссылки на заметку
+// @Description  Возвращает задачи и заметки, в тексте которых есть ссылка на заметку ([[Название]] или #ID). Удаленные и недоступные пользователю источники не возвращаются
+// @Tags         notes
+// @Produce      json
+// @Param        noteId  path  string  true  "ID заметки"
+// @Success      200  {array}  dto.BacklinkDTO "Обратные ссылки"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Заметка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notes/{noteId}/backlinks [get]
+func (h *NoteHandler) GetBacklinks(w http.ResponseWriter, r *http.Request) {
+	const op = "NoteHandler.GetBacklinks"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	noteID, err := uuid.Parse(mux.Vars(r)["noteId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid note ID format")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid note ID format")
+		return
+	}
+
+	backlinks, err := h.uc.GetBacklinks(r.Context(), noteID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get backlinks")
+		handler.HandleError(r.Context(), w, err, "Failed to get backlinks")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, backlinks)
+}
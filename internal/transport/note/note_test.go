@@ -18,6 +18,7 @@ import (
 	"github.com/lzimin05/course-todo/config"
 	"github.com/lzimin05/course-todo/internal/models/domains"
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	linkdto "github.com/lzimin05/course-todo/internal/transport/dto/link"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/note"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
 	"github.com/lzimin05/course-todo/internal/usecase/mocks"
@@ -223,6 +224,20 @@ func TestNoteHandler_CreateNote(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  true,
 		},
+		{
+			name: "unknown note format",
+			requestBody: dto.CreateOrUpdateNote{
+				ProjectID:   projectID,
+				Name:        "New Note",
+				Description: "Note Description",
+				Format:      "html",
+			},
+			setupMocks: func() {
+				// No mock expectations as validation happens before usecase call
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  true,
+		},
 		{
 			name: "validation error - missing project ID",
 			requestBody: dto.CreateOrUpdateNote{
@@ -301,12 +316,16 @@ func TestNoteHandler_UpdateNote(t *testing.T) {
 	noteID := uuid.New()
 	projectID := uuid.New()
 
+	expectedVersion := 2
+
 	tests := []struct {
 		name           string
 		noteID         string
+		ifMatch        string
 		requestBody    interface{}
 		setupMocks     func()
 		expectedStatus int
+		expectedETag   string
 		expectedError  bool
 	}{
 		{
@@ -323,11 +342,32 @@ func TestNoteHandler_UpdateNote(t *testing.T) {
 					Name:        "Updated Note",
 					Description: "Updated Description",
 				}
-				mockUsecase.EXPECT().UpdateNote(gomock.Any(), noteID, expectedReq).Return(nil)
+				mockUsecase.EXPECT().UpdateNote(gomock.Any(), noteID, expectedReq, nil).Return(2, nil)
 			},
 			expectedStatus: http.StatusNoContent,
+			expectedETag:   `"2"`,
 			expectedError:  false,
 		},
+		{
+			name:    "stale If-Match",
+			noteID:  noteID.String(),
+			ifMatch: `"2"`,
+			requestBody: dto.CreateOrUpdateNote{
+				ProjectID:   projectID,
+				Name:        "Updated Note",
+				Description: "Updated Description",
+			},
+			setupMocks: func() {
+				expectedReq := dto.CreateOrUpdateNote{
+					ProjectID:   projectID,
+					Name:        "Updated Note",
+					Description: "Updated Description",
+				}
+				mockUsecase.EXPECT().UpdateNote(gomock.Any(), noteID, expectedReq, []int{expectedVersion}).Return(0, errs.ErrVersionMismatch)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedError:  true,
+		},
 		{
 			name:   "invalid note ID",
 			noteID: "invalid-uuid",
@@ -368,6 +408,9 @@ func TestNoteHandler_UpdateNote(t *testing.T) {
 			}
 
 			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/notes/%s", tt.noteID), bytes.NewReader(reqBody))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 			ctx := req.Context()
 			ctx = logctx.WithLogger(ctx, logctx.NewLogger())
 			ctx = context.WithValue(ctx, domains.UserIDKey{}, uuid.New().String())
@@ -380,6 +423,7 @@ func TestNoteHandler_UpdateNote(t *testing.T) {
 			handler.UpdateNote(rr, req)
 
 			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectedETag, rr.Header().Get("ETag"))
 		})
 	}
 }
@@ -405,7 +449,7 @@ func TestNoteHandler_DeleteNote(t *testing.T) {
 			name:   "successful deletion",
 			noteID: noteID.String(),
 			setupMocks: func() {
-				mockUsecase.EXPECT().DeleteNote(gomock.Any(), noteID).Return(nil)
+				mockUsecase.EXPECT().DeleteNote(gomock.Any(), noteID, nil).Return(nil)
 			},
 			expectedStatus: http.StatusNoContent,
 			expectedError:  false,
@@ -423,7 +467,7 @@ func TestNoteHandler_DeleteNote(t *testing.T) {
 			name:   "usecase error",
 			noteID: noteID.String(),
 			setupMocks: func() {
-				mockUsecase.EXPECT().DeleteNote(gomock.Any(), noteID).Return(errs.ErrNotFound)
+				mockUsecase.EXPECT().DeleteNote(gomock.Any(), noteID, nil).Return(errs.ErrNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectedError:  true,
@@ -451,6 +495,159 @@ func TestNoteHandler_DeleteNote(t *testing.T) {
 	}
 }
 
+func TestNoteHandler_RenderNote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockINoteUsecase(ctrl)
+	config := &config.Config{}
+	handler := NewNoteHandler(mockUsecase, config)
+
+	noteID := uuid.New()
+
+	tests := []struct {
+		name           string
+		noteID         string
+		setupMocks     func()
+		expectedStatus int
+		expectedError  bool
+	}{
+		{
+			name:   "successful render",
+			noteID: noteID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().RenderNote(gomock.Any(), noteID).Return(&dto.RenderedNoteDTO{
+					NoteID:  noteID,
+					Format:  "markdown",
+					Version: 2,
+					HTML:    "<h1>Title</h1>",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedError:  false,
+		},
+		{
+			name:   "invalid note ID",
+			noteID: "invalid-uuid",
+			setupMocks: func() {
+				// No mock expectations as validation happens before usecase call
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  true,
+		},
+		{
+			name:   "note not found",
+			noteID: noteID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().RenderNote(gomock.Any(), noteID).Return(nil, errs.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/notes/%s/html", tt.noteID), nil)
+			ctx := req.Context()
+			ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+			ctx = context.WithValue(ctx, domains.UserIDKey{}, uuid.New().String())
+			req = req.WithContext(ctx)
+
+			req = mux.SetURLVars(req, map[string]string{"noteId": tt.noteID})
+
+			rr := httptest.NewRecorder()
+			handler.RenderNote(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+
+			if !tt.expectedError {
+				var result dto.RenderedNoteDTO
+				err := json.Unmarshal(rr.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, "<h1>Title</h1>", result.HTML)
+				assert.Equal(t, `"2"`, rr.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestNoteHandler_GetBacklinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockINoteUsecase(ctrl)
+	config := &config.Config{}
+	handler := NewNoteHandler(mockUsecase, config)
+
+	noteID := uuid.New()
+	taskID := uuid.New()
+
+	tests := []struct {
+		name           string
+		noteID         string
+		setupMocks     func()
+		expectedStatus int
+		expectedLen    int
+	}{
+		{
+			name:   "successful retrieval",
+			noteID: noteID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().GetBacklinks(gomock.Any(), noteID).Return([]*linkdto.BacklinkDTO{
+					{Type: "task", ID: taskID, Title: "Fix login"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedLen:    1,
+		},
+		{
+			name:   "invalid note ID",
+			noteID: "invalid-uuid",
+			setupMocks: func() {
+				// No mock expectations as validation happens before usecase call
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "note not found",
+			noteID: noteID.String(),
+			setupMocks: func() {
+				mockUsecase.EXPECT().GetBacklinks(gomock.Any(), noteID).Return(nil, errs.ErrNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/notes/%s/backlinks", tt.noteID), nil)
+			ctx := req.Context()
+			ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+			ctx = context.WithValue(ctx, domains.UserIDKey{}, uuid.New().String())
+			req = req.WithContext(ctx)
+
+			req = mux.SetURLVars(req, map[string]string{"noteId": tt.noteID})
+
+			rr := httptest.NewRecorder()
+			handler.GetBacklinks(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var result []linkdto.BacklinkDTO
+				err := json.Unmarshal(rr.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Len(t, result, tt.expectedLen)
+			}
+		})
+	}
+}
+
 func TestNewNoteHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,229 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/lzimin05/course-todo/config"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/notification"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/notification"
+	searchvalidation "github.com/lzimin05/course-todo/internal/transport/utils/validation/search"
+)
+
+//go:generate mockgen -source=notification.go -destination=../../usecase/mocks/notification_usecase_mock.go -package=mocks NotificationUsecase
+type NotificationUsecase interface {
+	GetNotifications(ctx context.Context, unreadOnly bool, limit, offset int) (*dto.NotificationListDTO, error)
+	GetUnreadCount(ctx context.Context) (*dto.UnreadCountDTO, error)
+	MarkRead(ctx context.Context, notificationID uuid.UUID) (*dto.UnreadCountDTO, error)
+	MarkAllRead(ctx context.Context) (*dto.UnreadCountDTO, error)
+	GetPreferences(ctx context.Context) ([]dto.PreferenceDTO, error)
+	UpdatePreferences(ctx context.Context, req dto.UpdatePreferencesDTO) ([]dto.PreferenceDTO, error)
+}
+
+type NotificationHandler struct {
+	uc     NotificationUsecase
+	config *config.Config
+}
+
+func New(uc NotificationUsecase, cfg *config.Config) *NotificationHandler {
+	return &NotificationHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// GetNotifications возвращает уведомления текущего пользователя
+// @Summary      Получить уведомления
+// @Description  Возвращает уведомления пользователя, новые первыми, и общее число непрочитанных. Типы: member_added, task_completed, checklist_assigned, mentioned, deadline_approaching, task_overdue
+// @Tags         notifications
+// @Produce      json
+// @Param        unread  query  bool  false  "Только непрочитанные"
+// @Param        limit   query  int   false  "Количество (1-100), по умолчанию 20"
+// @Param        offset  query  int   false  "Смещение"
+// @Success      200  {object} dto.NotificationListDTO "Уведомления"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notifications [get]
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	const op = "NotificationHandler.GetNotifications"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	query := r.URL.Query()
+	limit, offset, err := searchvalidation.ValidationPagination(query.Get("limit"), query.Get("offset"))
+	if err != nil {
+		logger.Warn("pagination validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	unreadOnly := false
+	if v := query.Get("unread"); v != "" {
+		unreadOnly, err = strconv.ParseBool(v)
+		if err != nil {
+			logger.WithError(err).Warn("invalid unread flag")
+			response.SendError(r.Context(), w, http.StatusBadRequest, "unread must be true or false")
+			return
+		}
+	}
+
+	notifications, err := h.uc.GetNotifications(r.Context(), unreadOnly, limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("failed to get notifications")
+		handler.HandleError(r.Context(), w, err, "Failed to get notifications")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, notifications)
+}
+
+// GetUnreadCount возвращает число непрочитанных уведомлений
+// @Summary      Число непрочитанных уведомлений
+// @Tags         notifications
+// @Produce      json
+// @Success      200  {object} dto.UnreadCountDTO "Число непрочитанных"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	const op = "NotificationHandler.GetUnreadCount"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	count, err := h.uc.GetUnreadCount(r.Context())
+	if err != nil {
+		logger.WithError(err).Error("failed to get unread count")
+		handler.HandleError(r.Context(), w, err, "Failed to get unread count")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, count)
+}
+
+// MarkRead отмечает уведомление прочитанным
+// @Summary      Отметить уведомление прочитанным
+// @Tags         notifications
+// @Produce      json
+// @Param        notificationId  path  string  true  "ID уведомления"
+// @Success      200  {object} dto.UnreadCountDTO "Оставшееся число непрочитанных"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Уведомление не найдено"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notifications/{notificationId}/read [post]
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	const op = "NotificationHandler.MarkRead"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	notificationID, err := uuid.Parse(mux.Vars(r)["notificationId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid notification ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+
+	count, err := h.uc.MarkRead(r.Context(), notificationID)
+	if err != nil {
+		logger.WithError(err).Error("failed to mark notification read")
+		handler.HandleError(r.Context(), w, err, "Failed to mark notification read")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, count)
+}
+
+// MarkAllRead отмечает прочитанными все уведомления
+// @Summary      Отметить все уведомления прочитанными
+// @Tags         notifications
+// @Produce      json
+// @Success      200  {object} dto.UnreadCountDTO "Число непрочитанных"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	const op = "NotificationHandler.MarkAllRead"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	count, err := h.uc.MarkAllRead(r.Context())
+	if err != nil {
+		logger.WithError(err).Error("failed to mark notifications read")
+		handler.HandleError(r.Context(), w, err, "Failed to mark notifications read")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, count)
+}
+
+// GetPreferences возвращает настройки уведомлений
+// @Summary      Настройки уведомлений
+// @Description  Возвращает все типы уведомлений и включены ли они. По умолчанию включены все
+// @Tags         notifications
+// @Produce      json
+// @Success      200  {array}  dto.PreferenceDTO "Настройки"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notifications/preferences [get]
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	const op = "NotificationHandler.GetPreferences"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	preferences, err := h.uc.GetPreferences(r.Context())
+	if err != nil {
+		logger.WithError(err).Error("failed to get notification preferences")
+		handler.HandleError(r.Context(), w, err, "Failed to get notification preferences")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, preferences)
+}
+
+// UpdatePreferences включает и отключает типы уведомлений
+// @Summary      Изменить настройки уведомлений
+// @Description  Принимает объект {"<тип>": true|false}, не переданные типы не меняются. Отключенный тип перестает создавать новые уведомления
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        preferences  body  dto.UpdatePreferencesDTO  true  "Типы уведомлений"
+// @Success      200  {array}  dto.PreferenceDTO "Настройки"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /notifications/preferences [patch]
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	const op = "NotificationHandler.UpdatePreferences"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	var req dto.UpdatePreferencesDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode preferences")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validation.ValidationUpdatePreferences(req); err != nil {
+		logger.Warn("preferences validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	preferences, err := h.uc.UpdatePreferences(r.Context(), req)
+	if err != nil {
+		logger.WithError(err).Error("failed to update notification preferences")
+		handler.HandleError(r.Context(), w, err, "Failed to update notification preferences")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, preferences)
+}
@@ -0,0 +1,195 @@
+package transport
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/notification"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestNotificationTransport_GetNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockNotificationUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func()
+		statusCode int
+		contains   string
+	}{
+		{
+			name:  "Unread only",
+			query: "?unread=true&limit=10&offset=5",
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetNotifications(gomock.Any(), true, 10, 5).
+					Return(&dto.NotificationListDTO{Items: []dto.NotificationDTO{}, UnreadCount: 2}, nil)
+			},
+			statusCode: http.StatusOK,
+			contains:   `"unread_count":2`,
+		},
+		{
+			name:  "Defaults",
+			query: "",
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetNotifications(gomock.Any(), false, 20, 0).
+					Return(&dto.NotificationListDTO{Items: []dto.NotificationDTO{}}, nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Invalid unread flag",
+			query:      "?unread=maybe",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid limit",
+			query:      "?limit=1000",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodGet, "/notifications"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.GetNotifications(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			if tt.contains != "" {
+				assert.Contains(t, rr.Body.String(), tt.contains)
+			}
+		})
+	}
+}
+
+func TestNotificationTransport_MarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockNotificationUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/notifications/{notificationId}/read", handler.MarkRead).Methods(http.MethodPost)
+
+	notificationID := uuid.New()
+
+	tests := []struct {
+		name       string
+		id         string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name: "Marked",
+			id:   notificationID.String(),
+			mockFunc: func() {
+				mockUsecase.EXPECT().MarkRead(gomock.Any(), notificationID).Return(&dto.UnreadCountDTO{UnreadCount: 0}, nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "Not found",
+			id:   notificationID.String(),
+			mockFunc: func() {
+				mockUsecase.EXPECT().MarkRead(gomock.Any(), notificationID).
+					Return(nil, errs.NewNotFoundError("notification not found"))
+			},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Invalid ID",
+			id:         "not-a-uuid",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodPost, "/notifications/"+tt.id+"/read", nil)
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+		})
+	}
+}
+
+func TestNotificationTransport_UpdatePreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockNotificationUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func()
+		statusCode int
+		contains   string
+	}{
+		{
+			name: "Updated",
+			body: `{"task_completed":false}`,
+			mockFunc: func() {
+				mockUsecase.EXPECT().UpdatePreferences(gomock.Any(), dto.UpdatePreferencesDTO{"task_completed": false}).
+					Return([]dto.PreferenceDTO{{Type: "task_completed", Enabled: false}}, nil)
+			},
+			statusCode: http.StatusOK,
+			contains:   `"enabled":false`,
+		},
+		{
+			name:       "Unknown type",
+			body:       `{"task_archived":true}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+			contains:   "unknown notification type: task_archived",
+		},
+		{
+			name:       "Empty",
+			body:       `{}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodPatch, "/notifications/preferences", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.UpdatePreferences(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			if tt.contains != "" {
+				assert.Contains(t, rr.Body.String(), tt.contains)
+			}
+		})
+	}
+}
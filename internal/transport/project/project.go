@@ -10,6 +10,7 @@ import (
 	"github.com/lzimin05/course-todo/config"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/project"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/etag"
 	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
 	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
 	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/project"
@@ -21,10 +22,11 @@ type ProjectUsecase interface {
 	GetProjectByID(ctx context.Context, projectID uuid.UUID) (*dto.ProjectDTO, error)
 	AddProjectMember(ctx context.Context, projectID uuid.UUID, req *dto.AddMemberDTO) error
 	GetProjectMembers(ctx context.Context, projectID uuid.UUID) ([]*dto.ProjectMemberDTO, error)
-	DeleteProject(ctx context.Context, projectID uuid.UUID) error
+	DeleteProject(ctx context.Context, projectID uuid.UUID, expectedVersions []int) error
 	RemoveProjectMember(ctx context.Context, projectID, memberUserID uuid.UUID) error
-	UpdateProject(ctx context.Context, projectID uuid.UUID, req *dto.UpdateProjectDTO) (*dto.ProjectDTO, error)
+	UpdateProject(ctx context.Context, projectID uuid.UUID, req *dto.UpdateProjectDTO, expectedVersions []int) (*dto.ProjectDTO, error)
 	LeaveProject(ctx context.Context, projectID uuid.UUID) error
+	GetProjectStats(ctx context.Context, projectID uuid.UUID) (*dto.ProjectStatsDTO, error)
 }
 
 type ProjectHandler struct {
@@ -106,11 +108,12 @@ func (h *ProjectHandler) GetUserProjects(w http.ResponseWriter, r *http.Request)
 
 // GetProjectByID получает проект по ID
 // @Summary      Получить проект по ID
-// @Description  Возвращает информацию о проекте по его ID
+// @Description  Возвращает информацию о проекте по его ID. Текущая версия проекта передается в заголовке ETag
 // @Tags         projects
 // @Produce      json
 // @Param        projectId  path  string  true  "ID проекта"
 // @Success      200  {object} dto.ProjectDTO "Информация о проекте"
+// @Header       200  {string} ETag "Версия проекта"
 // @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
 // @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
 // @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
@@ -136,6 +139,7 @@ func (h *ProjectHandler) GetProjectByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	etag.Set(w, project.Version)
 	response.SendJSONResponse(r.Context(), w, http.StatusOK, project)
 }
 
@@ -221,12 +225,14 @@ func (h *ProjectHandler) GetProjectMembers(w http.ResponseWriter, r *http.Reques
 // @Description  Удаляет проект (только владелец)
 // @Tags         projects
 // @Produce      json
-// @Param        projectId  path  string  true  "ID проекта"
+// @Param        projectId  path    string  true   "ID проекта"
+// @Param        If-Match   header  string  false  "ETag, полученный при чтении проекта"
 // @Success      200  "Проект удален"
 // @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
 // @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
 // @Failure      403  {object} dto.ErrorResponse "Недостаточно прав"
 // @Failure      404  {object} dto.ErrorResponse "Проект не найден"
+// @Failure      412  {object} dto.ErrorResponse "Проект изменен другим запросом"
 // @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
 // @Security     BearerAuth
 // @Router       /projects/{projectId} [delete]
@@ -241,7 +247,7 @@ func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.uc.DeleteProject(r.Context(), projectID)
+	err = h.uc.DeleteProject(r.Context(), projectID, etag.IfMatch(r))
 	if err != nil {
 		logger.WithError(err).Error("failed to delete project")
 		handler.HandleError(r.Context(), w, err, "Failed to delete project")
@@ -302,13 +308,16 @@ func (h *ProjectHandler) RemoveProjectMember(w http.ResponseWriter, r *http.Requ
 // @Tags         projects
 // @Accept       json
 // @Produce      json
-// @Param        projectId  path  string  true  "ID проекта"
-// @Param        project    body  dto.UpdateProjectDTO  true  "Данные для обновления проекта"
+// @Param        projectId  path    string  true   "ID проекта"
+// @Param        If-Match   header  string  false  "ETag, полученный при чтении проекта"
+// @Param        project    body    dto.UpdateProjectDTO  true  "Данные для обновления проекта"
 // @Success      200  {object} dto.ProjectDTO "Обновленный проект"
+// @Header       200  {string} ETag "Новая версия проекта"
 // @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
 // @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
 // @Failure      403  {object} dto.ErrorResponse "Недостаточно прав"
 // @Failure      404  {object} dto.ErrorResponse "Проект не найден"
+// @Failure      412  {object} dto.ErrorResponse "Проект изменен другим запросом"
 // @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
 // @Security     BearerAuth
 // @Router       /projects/{projectId} [put]
@@ -336,13 +345,14 @@ func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	project, err := h.uc.UpdateProject(r.Context(), projectID, &req)
+	project, err := h.uc.UpdateProject(r.Context(), projectID, &req, etag.IfMatch(r))
 	if err != nil {
 		logger.WithError(err).Error("failed to update project")
 		handler.HandleError(r.Context(), w, err, "Failed to update project")
 		return
 	}
 
+	etag.Set(w, project.Version)
 	response.SendJSONResponse(r.Context(), w, http.StatusOK, project)
 }
 
@@ -380,3 +390,37 @@ func (h *ProjectHandler) LeaveProject(w http.ResponseWriter, r *http.Request) {
 
 	response.SendJSONResponse(r.Context(), w, http.StatusOK, nil)
 }
+
+// GetProjectStats возвращает статистику проекта
+// @Summary      Получить статистику проекта
+// @Description  Возвращает количество задач по статусам и важности, просроченные задачи, завершенные задачи по дням и неделям, среднее время выполнения и статистику участников
+// @Tags         projects
+// @Produce      json
+// @Param        projectId  path  string  true  "ID проекта"
+// @Success      200  {object} dto.ProjectStatsDTO "Статистика проекта"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/stats [get]
+func (h *ProjectHandler) GetProjectStats(w http.ResponseWriter, r *http.Request) {
+	const op = "ProjectHandler.GetProjectStats"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid project ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	stats, err := h.uc.GetProjectStats(r.Context(), projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get project stats")
+		handler.HandleError(r.Context(), w, err, "Failed to get project stats")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, stats)
+}
@@ -0,0 +1,124 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/lzimin05/course-todo/config"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/report"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/report"
+)
+
+type ReportUsecase interface {
+	GetCumulativeFlow(ctx context.Context, projectID uuid.UUID, from, to time.Time, bucket string) (*dto.CFDDTO, error)
+	GetBurndown(ctx context.Context, projectID uuid.UUID, from, to time.Time, bucket string) (*dto.BurndownDTO, error)
+}
+
+type ReportHandler struct {
+	uc     ReportUsecase
+	config *config.Config
+}
+
+func New(uc ReportUsecase, cfg *config.Config) *ReportHandler {
+	return &ReportHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// GetCumulativeFlow возвращает данные для диаграммы накопленного потока
+// @Summary      Диаграмма накопленного потока
+// @Description  Возвращает количество задач в каждом статусе на конец каждого интервала периода
+// @Tags         reports
+// @Produce      json
+// @Param        projectId  path   string  true   "ID проекта"
+// @Param        from       query  string  false  "Начало периода (YYYY-MM-DD), по умолчанию 30 дней назад"
+// @Param        to         query  string  false  "Конец периода (YYYY-MM-DD), по умолчанию сегодня"
+// @Param        bucket     query  string  false  "Интервал: day или week"
+// @Success      200  {object} dto.CFDDTO "Данные диаграммы"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/reports/cfd [get]
+func (h *ReportHandler) GetCumulativeFlow(w http.ResponseWriter, r *http.Request) {
+	const op = "ReportHandler.GetCumulativeFlow"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, from, to, bucket, ok := parseReportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	cfd, err := h.uc.GetCumulativeFlow(r.Context(), projectID, from, to, bucket)
+	if err != nil {
+		logger.WithError(err).Error("failed to get cumulative flow")
+		handler.HandleError(r.Context(), w, err, "Failed to get cumulative flow")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, cfd)
+}
+
+// GetBurndown возвращает данные для диаграммы сгорания задач
+// @Summary      Диаграмма сгорания задач
+// @Description  Возвращает количество открытых задач на конец каждого интервала и идеальную линию
+// @Tags         reports
+// @Produce      json
+// @Param        projectId  path   string  true   "ID проекта"
+// @Param        from       query  string  false  "Начало периода (YYYY-MM-DD), по умолчанию 30 дней назад"
+// @Param        to         query  string  false  "Конец периода (YYYY-MM-DD), по умолчанию сегодня"
+// @Param        bucket     query  string  false  "Интервал: day или week"
+// @Success      200  {object} dto.BurndownDTO "Данные диаграммы"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/reports/burndown [get]
+func (h *ReportHandler) GetBurndown(w http.ResponseWriter, r *http.Request) {
+	const op = "ReportHandler.GetBurndown"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, from, to, bucket, ok := parseReportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	burndown, err := h.uc.GetBurndown(r.Context(), projectID, from, to, bucket)
+	if err != nil {
+		logger.WithError(err).Error("failed to get burndown")
+		handler.HandleError(r.Context(), w, err, "Failed to get burndown")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, burndown)
+}
+
+func parseReportRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, time.Time, time.Time, string, bool) {
+	logger := logctx.GetLogger(r.Context())
+
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid project ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+		return uuid.Nil, time.Time{}, time.Time{}, "", false
+	}
+
+	query := r.URL.Query()
+	from, to, bucket, err := validation.ValidationReportRange(query.Get("from"), query.Get("to"), query.Get("bucket"), time.Now())
+	if err != nil {
+		logger.Warn("report range validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return uuid.Nil, time.Time{}, time.Time{}, "", false
+	}
+
+	return projectID, from, to, bucket, true
+}
@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/lzimin05/course-todo/config"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/search"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/search"
+)
+
+type SearchUsecase interface {
+	Search(ctx context.Context, query string, limit, offset int) (*dto.SearchResponseDTO, error)
+}
+
+type SearchHandler struct {
+	uc     SearchUsecase
+	config *config.Config
+}
+
+func New(uc SearchUsecase, cfg *config.Config) *SearchHandler {
+	return &SearchHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// Search выполняет полнотекстовый поиск
+// @Summary      Полнотекстовый поиск
+// @Description  Ищет по задачам, заметкам и проектам, в которых состоит пользователь. Результаты отсортированы по релевантности, совпадения выделены тегом <mark>
+// @Tags         search
+// @Produce      json
+// @Param        q       query  string  true   "Поисковый запрос"
+// @Param        limit   query  int     false  "Количество результатов (1-100), по умолчанию 20"
+// @Param        offset  query  int     false  "Смещение"
+// @Success      200  {object} dto.SearchResponseDTO "Результаты поиска"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /search [get]
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	const op = "SearchHandler.Search"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	query := r.URL.Query()
+	q := query.Get("q")
+	if err := validation.ValidationSearchQuery(q); err != nil {
+		logger.Warn("search validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit, offset, err := validation.ValidationPagination(query.Get("limit"), query.Get("offset"))
+	if err != nil {
+		logger.Warn("pagination validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := h.uc.Search(r.Context(), q, limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("failed to search")
+		handler.HandleError(r.Context(), w, err, "Failed to search")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, results)
+}
@@ -0,0 +1,260 @@
+package transport
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/task"
+)
+
+// GetChecklist получает чек-лист задачи
+// @Summary      Получить чек-лист задачи
+// @Description  Возвращает пункты чек-листа задачи в заданном порядке
+// @Tags         tasks
+// @Produce      json
+// @Param        taskId  path  string  true  "ID задачи"
+// @Success      200  {array}  dto.ChecklistItemDTO "Пункты чек-листа"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/{taskId}/checklist [get]
+func (h *TaskHandler) GetChecklist(w http.ResponseWriter, r *http.Request) {
+	const op = "TaskHandler.GetChecklist"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	taskID, err := uuid.Parse(mux.Vars(r)["taskId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid task ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	items, err := h.uc.GetChecklist(r.Context(), taskID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get checklist")
+		handler.HandleError(r.Context(), w, err, "Failed to get checklist")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, items)
+}
+
+// AddChecklistItem добавляет пункт в чек-лист
+// @Summary      Добавить пункт чек-листа
+// @Description  Добавляет пункт в чек-лист задачи. Без position пункт добавляется в конец. Исполнитель должен быть участником проекта
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        taskId  path  string  true  "ID задачи"
+// @Param        item    body  dto.CreateChecklistItemDTO  true  "Пункт чек-листа"
+// @Success      201  {object} dto.ChecklistItemDTO "Созданный пункт"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос или исполнитель не участвует в проекте"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/{taskId}/checklist [post]
+func (h *TaskHandler) AddChecklistItem(w http.ResponseWriter, r *http.Request) {
+	const op = "TaskHandler.AddChecklistItem"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	taskID, err := uuid.Parse(mux.Vars(r)["taskId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid task ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req dto.CreateChecklistItemDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if err := validation.ValidationChecklistText(req.Text); err != nil {
+		logger.WithError(err).Warn("invalid checklist item text")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.Position != nil {
+		if err := validation.ValidationChecklistPosition(*req.Position); err != nil {
+			logger.WithError(err).Warn("invalid checklist item position")
+			response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	item, err := h.uc.AddChecklistItem(r.Context(), taskID, req)
+	if err != nil {
+		logger.WithError(err).Error("failed to add checklist item")
+		handler.HandleError(r.Context(), w, err, "Failed to add checklist item")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, item)
+}
+
+// UpdateChecklistItem изменяет пункт чек-листа
+// @Summary      Изменить пункт чек-листа
+// @Description  Отмечает пункт выполненным или невыполненным, меняет текст или исполнителя. Непереданные поля не меняются, clear_assignee снимает исполнителя
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        taskId  path  string  true  "ID задачи"
+// @Param        itemId  path  string  true  "ID пункта"
+// @Param        item    body  dto.UpdateChecklistItemDTO  true  "Изменения пункта"
+// @Success      200  {object} dto.ChecklistItemDTO "Обновленный пункт"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос или исполнитель не участвует в проекте"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача или пункт не найдены"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/{taskId}/checklist/{itemId} [patch]
+func (h *TaskHandler) UpdateChecklistItem(w http.ResponseWriter, r *http.Request) {
+	const op = "TaskHandler.UpdateChecklistItem"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	taskID, itemID, ok := parseChecklistIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateChecklistItemDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if req.Text != nil {
+		if err := validation.ValidationChecklistText(*req.Text); err != nil {
+			logger.WithError(err).Warn("invalid checklist item text")
+			response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if req.ClearAssignee && req.AssigneeID != nil {
+		logger.Warn("assignee_id and clear_assignee are both set")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "assignee_id and clear_assignee cannot be used together")
+		return
+	}
+
+	item, err := h.uc.UpdateChecklistItem(r.Context(), taskID, itemID, req)
+	if err != nil {
+		logger.WithError(err).Error("failed to update checklist item")
+		handler.HandleError(r.Context(), w, err, "Failed to update checklist item")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, item)
+}
+
+// MoveChecklistItem переставляет пункт чек-листа
+// @Summary      Переставить пункт чек-листа
+// @Description  Переставляет пункт на указанную позицию. Позиция за пределами списка прижимается к его концу
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Param        taskId  path  string  true  "ID задачи"
+// @Param        itemId  path  string  true  "ID пункта"
+// @Param        move    body  dto.MoveChecklistItemDTO  true  "Новая позиция"
+// @Success      200  {object} dto.MoveChecklistItemDTO "Итоговая позиция"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача или пункт не найдены"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/{taskId}/checklist/{itemId}/move [put]
+func (h *TaskHandler) MoveChecklistItem(w http.ResponseWriter, r *http.Request) {
+	const op = "TaskHandler.MoveChecklistItem"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	taskID, itemID, ok := parseChecklistIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.MoveChecklistItemDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if err := validation.ValidationChecklistPosition(req.Position); err != nil {
+		logger.WithError(err).Warn("invalid checklist item position")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.uc.MoveChecklistItem(r.Context(), taskID, itemID, req)
+	if err != nil {
+		logger.WithError(err).Error("failed to move checklist item")
+		handler.HandleError(r.Context(), w, err, "Failed to move checklist item")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, result)
+}
+
+// DeleteChecklistItem удаляет пункт чек-листа
+// @Summary      Удалить пункт чек-листа
+// @Description  Удаляет пункт, следующие пункты сдвигаются вверх
+// @Tags         tasks
+// @Param        taskId  path  string  true  "ID задачи"
+// @Param        itemId  path  string  true  "ID пункта"
+// @Success      204  "Пункт успешно удален"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача или пункт не найдены"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/{taskId}/checklist/{itemId} [delete]
+func (h *TaskHandler) DeleteChecklistItem(w http.ResponseWriter, r *http.Request) {
+	const op = "TaskHandler.DeleteChecklistItem"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	taskID, itemID, ok := parseChecklistIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.DeleteChecklistItem(r.Context(), taskID, itemID); err != nil {
+		logger.WithError(err).Error("failed to delete checklist item")
+		handler.HandleError(r.Context(), w, err, "Failed to delete checklist item")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseChecklistIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	logger := logctx.GetLogger(r.Context())
+
+	taskID, err := uuid.Parse(mux.Vars(r)["taskId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid task ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid task ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	itemID, err := uuid.Parse(mux.Vars(r)["itemId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid checklist item ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid checklist item ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return taskID, itemID, true
+}
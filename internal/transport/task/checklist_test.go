@@ -0,0 +1,186 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestTaskTransport_AddChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskUsecase := mocks.NewMockTaskUsecase(ctrl)
+	handler := New(mockTaskUsecase, &config.Config{})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/todo/{taskId}/checklist", handler.AddChecklistItem).Methods(http.MethodPost)
+
+	taskID := uuid.New()
+	negative := -1
+
+	tests := []struct {
+		name       string
+		body       interface{}
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name: "Success",
+			body: dto.CreateChecklistItemDTO{Text: "Купить молоко"},
+			mockFunc: func() {
+				mockTaskUsecase.EXPECT().AddChecklistItem(gomock.Any(), taskID, gomock.Any()).
+					Return(&dto.ChecklistItemDTO{ID: uuid.New(), TaskID: taskID, Text: "Купить молоко"}, nil)
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name:       "Empty text",
+			body:       dto.CreateChecklistItemDTO{Text: "   "},
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Negative position",
+			body:       dto.CreateChecklistItemDTO{Text: "Шаг", Position: &negative},
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Assignee outside project",
+			body: dto.CreateChecklistItemDTO{Text: "Шаг"},
+			mockFunc: func() {
+				mockTaskUsecase.EXPECT().AddChecklistItem(gomock.Any(), taskID, gomock.Any()).
+					Return(nil, errs.ErrAssigneeNotMember)
+			},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/todo/"+taskID.String()+"/checklist", bytes.NewBuffer(body))
+			req = req.WithContext(context.WithValue(req.Context(), domains.UserIDKey{}, uuid.New().String()))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+		})
+	}
+}
+
+func TestTaskTransport_UpdateChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskUsecase := mocks.NewMockTaskUsecase(ctrl)
+	handler := New(mockTaskUsecase, &config.Config{})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/todo/{taskId}/checklist/{itemId}", handler.UpdateChecklistItem).Methods(http.MethodPatch)
+
+	taskID := uuid.New()
+	itemID := uuid.New()
+	assigneeID := uuid.New()
+	done := true
+
+	tests := []struct {
+		name       string
+		itemID     string
+		body       interface{}
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:   "Toggle done",
+			itemID: itemID.String(),
+			body:   dto.UpdateChecklistItemDTO{Done: &done},
+			mockFunc: func() {
+				mockTaskUsecase.EXPECT().UpdateChecklistItem(gomock.Any(), taskID, itemID, gomock.Any()).
+					Return(&dto.ChecklistItemDTO{ID: itemID, TaskID: taskID, Done: true}, nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Assignee and clear together",
+			itemID:     itemID.String(),
+			body:       dto.UpdateChecklistItemDTO{AssigneeID: &assigneeID, ClearAssignee: true},
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid item ID",
+			itemID:     "invalid-uuid",
+			body:       dto.UpdateChecklistItemDTO{Done: &done},
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Item not found",
+			itemID: itemID.String(),
+			body:   dto.UpdateChecklistItemDTO{Done: &done},
+			mockFunc: func() {
+				mockTaskUsecase.EXPECT().UpdateChecklistItem(gomock.Any(), taskID, itemID, gomock.Any()).
+					Return(nil, errs.NewNotFoundError("checklist item not found"))
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPatch, "/todo/"+taskID.String()+"/checklist/"+tt.itemID, bytes.NewBuffer(body))
+			req = req.WithContext(context.WithValue(req.Context(), domains.UserIDKey{}, uuid.New().String()))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+		})
+	}
+}
+
+func TestTaskTransport_DeleteChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskUsecase := mocks.NewMockTaskUsecase(ctrl)
+	handler := New(mockTaskUsecase, &config.Config{})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/todo/{taskId}/checklist/{itemId}", handler.DeleteChecklistItem).Methods(http.MethodDelete)
+
+	taskID := uuid.New()
+	itemID := uuid.New()
+
+	mockTaskUsecase.EXPECT().DeleteChecklistItem(gomock.Any(), taskID, itemID).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/todo/"+taskID.String()+"/checklist/"+itemID.String(), nil)
+	req = req.WithContext(context.WithValue(req.Context(), domains.UserIDKey{}, uuid.New().String()))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
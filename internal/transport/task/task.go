@@ -12,8 +12,10 @@ import (
 	"github.com/lzimin05/course-todo/config"
 	"github.com/lzimin05/course-todo/internal/models/domains"
 	models "github.com/lzimin05/course-todo/internal/models/task"
+	linkdto "github.com/lzimin05/course-todo/internal/transport/dto/link"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/etag"
 	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
 	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
 	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/task"
@@ -24,9 +26,16 @@ type TaskUsecase interface {
 	CreateTask(ctx context.Context, req *dto.PostTaskDTO) (*dto.CreateTaskDTO, error)
 	GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*dto.TaskDTO, error)
 	GetTasksByProjectID(ctx context.Context, projectID uuid.UUID) ([]*dto.TaskDTO, error)
-	UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, taskID, userID uuid.UUID) error
-	UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID) error
-	DeleteTask(ctx context.Context, taskID, userID uuid.UUID) error
+	GetTaskByID(ctx context.Context, taskID uuid.UUID) (*dto.TaskDTO, error)
+	UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, estimateMinutes *int, startAt *time.Time, allDay bool, taskID, userID uuid.UUID, expectedVersions []int) (int, error)
+	UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID, expectedVersions []int) (int, error)
+	DeleteTask(ctx context.Context, taskID, userID uuid.UUID, expectedVersions []int) error
+	GetBacklinks(ctx context.Context, taskID uuid.UUID) ([]*linkdto.BacklinkDTO, error)
+	GetChecklist(ctx context.Context, taskID uuid.UUID) ([]*dto.ChecklistItemDTO, error)
+	AddChecklistItem(ctx context.Context, taskID uuid.UUID, req dto.CreateChecklistItemDTO) (*dto.ChecklistItemDTO, error)
+	UpdateChecklistItem(ctx context.Context, taskID, itemID uuid.UUID, req dto.UpdateChecklistItemDTO) (*dto.ChecklistItemDTO, error)
+	MoveChecklistItem(ctx context.Context, taskID, itemID uuid.UUID, req dto.MoveChecklistItemDTO) (*dto.MoveChecklistItemDTO, error)
+	DeleteChecklistItem(ctx context.Context, taskID, itemID uuid.UUID) error
 }
 
 type TaskHandler struct {
@@ -72,6 +81,18 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := validation.ValidationEstimate(req.EstimateMinutes); err != nil {
+		logger.Warn("validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validation.ValidationTaskSchedule(req.StartAt, req.Deadline, req.AllDay); err != nil {
+		logger.Warn("validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	taskID, err := h.uc.CreateTask(r.Context(), &req)
 	if err != nil {
 		logger.WithError(err).Error("failed to create task")
@@ -151,17 +172,91 @@ func (h *TaskHandler) GetTasksByProjectID(w http.ResponseWriter, r *http.Request
 	response.SendJSONResponse(r.Context(), w, http.StatusOK, tasks)
 }
 
+// GetTaskByID получает задачу
+// @Summary      Получить задачу
+// @Description  Возвращает задачу по ID. Текущая версия задачи передается в заголовке ETag
+// @Tags         tasks
+// @Produce      json
+// @Param        taskId  path  string  true  "ID задачи"
+// @Success      200  {object} dto.TaskDTO "Задача"
+// @Header       200  {string} ETag "Версия задачи"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/{taskId} [get]
+func (h *TaskHandler) GetTaskByID(w http.ResponseWriter, r *http.Request) {
+	const op = "TaskHandler.GetTaskByID"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	taskID, err := uuid.Parse(mux.Vars(r)["taskId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid task ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	task, err := h.uc.GetTaskByID(r.Context(), taskID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get task")
+		handler.HandleError(r.Context(), w, err, "Failed to get task")
+		return
+	}
+
+	etag.Set(w, task.Version)
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, task)
+}
+
+// GetBacklinks получает обратные ссылки на задачу
+// @Summary      Получить обратные ссылки на задачу
+// @Description  Возвращает задачи и заметки, в тексте которых есть ссылка на задачу ([[Название]] или #ID). Удаленные и недоступные пользователю источники не возвращаются
+// @Tags         tasks
+// @Produce      json
+// @Param        taskId  path  string  true  "ID задачи"
+// @Success      200  {array}  dto.BacklinkDTO "Обратные ссылки"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/{taskId}/backlinks [get]
+func (h *TaskHandler) GetBacklinks(w http.ResponseWriter, r *http.Request) {
+	const op = "TaskHandler.GetBacklinks"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	taskID, err := uuid.Parse(mux.Vars(r)["taskId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid task ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	backlinks, err := h.uc.GetBacklinks(r.Context(), taskID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get backlinks")
+		handler.HandleError(r.Context(), w, err, "Failed to get backlinks")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, backlinks)
+}
+
 // UpdateTask обновляет задачу
 // @Summary      Обновить задачу
 // @Description  Обновляет существующую задачу пользователя
 // @Tags         tasks
 // @Accept       json
 // @Produce      json
-// @Param        taskId  path  string  true  "ID задачи"
-// @Param        task    body  dto.PostTaskDTO  true  "Данные для обновления задачи"
+// @Param        taskId    path    string  true   "ID задачи"
+// @Param        If-Match  header  string  false  "ETag, полученный при чтении задачи"
+// @Param        task      body    dto.PostTaskDTO  true  "Данные для обновления задачи"
 // @Success      200  "Задача обновлена"
+// @Header       200  {string} ETag "Новая версия задачи"
 // @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
 // @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача не найдена"
+// @Failure      412  {object} dto.ErrorResponse "Задача изменена другим запросом"
 // @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
 // @Security     BearerAuth
 // @Router       /todo/{taskId}/edit [put]
@@ -202,12 +297,25 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.uc.UpdateTask(r.Context(), req.Title, req.Description, req.Importance, req.Deadline, taskID, userID)
+	if err := validation.ValidationEstimate(req.EstimateMinutes); err != nil {
+		logger.Warn("validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := validation.ValidationTaskSchedule(req.StartAt, req.Deadline, req.AllDay); err != nil {
+		logger.Warn("validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	version, err := h.uc.UpdateTask(r.Context(), req.Title, req.Description, req.Importance, req.Deadline, req.EstimateMinutes, req.StartAt, req.AllDay, taskID, userID, etag.IfMatch(r))
 	if err != nil {
 		logger.WithError(err).Error("failed to update task")
-		response.SendError(r.Context(), w, http.StatusInternalServerError, "failed to update task")
+		handler.HandleError(r.Context(), w, err, "failed to update task")
 		return
 	}
+	etag.Set(w, version)
 	response.SendJSONResponse(r.Context(), w, http.StatusOK, nil)
 }
 
@@ -216,11 +324,15 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 // @Description  Обновляет статус существующей задачи пользователя
 // @Tags         tasks
 // @Produce      json
-// @Param        taskId  path   string  true  "ID задачи"
-// @Param        status  query  string  true  "Новый статус задачи (waiting, in_progress, completed)"
+// @Param        taskId    path    string  true   "ID задачи"
+// @Param        status    query   string  true   "Новый статус задачи (waiting, in_progress, completed)"
+// @Param        If-Match  header  string  false  "ETag, полученный при чтении задачи"
 // @Success      200  "Статус задачи обновлен"
+// @Header       200  {string} ETag "Новая версия задачи"
 // @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
 // @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача не найдена"
+// @Failure      412  {object} dto.ErrorResponse "Задача изменена другим запросом"
 // @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
 // @Security     BearerAuth
 // @Router       /todo/{taskId}/edit [patch]
@@ -262,12 +374,13 @@ func (h *TaskHandler) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
 			fmt.Sprintf("Invalid status. Allowed values: %s, %s, %s", models.StatusWaiting, models.StatusInProgress, models.StatusCompleted))
 		return
 	}
-	err = h.uc.UpdateTaskStatus(r.Context(), status, taskID, userID)
+	version, err := h.uc.UpdateTaskStatus(r.Context(), status, taskID, userID, etag.IfMatch(r))
 	if err != nil {
 		logger.WithError(err).Error("failed to update status for task")
-		response.SendError(r.Context(), w, http.StatusInternalServerError, "failed to update status for task")
+		handler.HandleError(r.Context(), w, err, "failed to update status for task")
 		return
 	}
+	etag.Set(w, version)
 	response.SendJSONResponse(r.Context(), w, http.StatusOK, nil)
 }
 
@@ -276,10 +389,12 @@ func (h *TaskHandler) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
 // @Description  Удаляет существующую задачу пользователя
 // @Tags         tasks
 // @Produce      json
-// @Param        taskId  path  string  true  "ID задачи"
+// @Param        taskId    path    string  true   "ID задачи"
+// @Param        If-Match  header  string  false  "ETag, полученный при чтении задачи"
 // @Success      200  "Задача удалена"
 // @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
 // @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      412  {object} dto.ErrorResponse "Задача изменена другим запросом"
 // @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
 // @Security     BearerAuth
 // @Router       /todo/{taskId} [delete]
@@ -306,10 +421,10 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "failed to parse taskID", http.StatusBadRequest)
 		return
 	}
-	err = h.uc.DeleteTask(r.Context(), taskID, userID)
+	err = h.uc.DeleteTask(r.Context(), taskID, userID, etag.IfMatch(r))
 	if err != nil {
 		logger.WithError(err).Error("failed to delete task")
-		response.SendError(r.Context(), w, http.StatusInternalServerError, "failed to delete task")
+		handler.HandleError(r.Context(), w, err, "failed to delete task")
 		return
 	}
 	response.SendJSONResponse(r.Context(), w, http.StatusOK, nil)
@@ -16,6 +16,8 @@ import (
 
 	"github.com/lzimin05/course-todo/config"
 	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	linkdto "github.com/lzimin05/course-todo/internal/transport/dto/link"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
 	"github.com/lzimin05/course-todo/internal/usecase/mocks"
 )
@@ -203,7 +205,7 @@ func TestTaskTransport_UpdateTaskStatus(t *testing.T) {
 			taskID: uuid.New(),
 			body:   map[string]string{},
 			mockFunc: func() {
-				mockTaskUsecase.EXPECT().UpdateTaskStatus(gomock.Any(), "waiting", gomock.Any(), gomock.Any()).Return(nil)
+				mockTaskUsecase.EXPECT().UpdateTaskStatus(gomock.Any(), "waiting", gomock.Any(), gomock.Any(), gomock.Any()).Return(2, nil)
 			},
 			statusCode: http.StatusOK,
 		},
@@ -244,6 +246,7 @@ func TestTaskTransport_DeleteTask(t *testing.T) {
 	tests := []struct {
 		name       string
 		taskID     uuid.UUID
+		ifMatch    string
 		mockFunc   func()
 		statusCode int
 	}{
@@ -251,10 +254,20 @@ func TestTaskTransport_DeleteTask(t *testing.T) {
 			name:   "Success",
 			taskID: uuid.New(),
 			mockFunc: func() {
-				mockTaskUsecase.EXPECT().DeleteTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mockTaskUsecase.EXPECT().DeleteTask(gomock.Any(), gomock.Any(), gomock.Any(), nil).Return(nil)
 			},
 			statusCode: http.StatusOK,
 		},
+		{
+			name:    "Stale If-Match",
+			taskID:  uuid.New(),
+			ifMatch: `"1"`,
+			mockFunc: func() {
+				expectedVersion := 1
+				mockTaskUsecase.EXPECT().DeleteTask(gomock.Any(), gomock.Any(), gomock.Any(), []int{expectedVersion}).Return(errs.ErrVersionMismatch)
+			},
+			statusCode: http.StatusPreconditionFailed,
+		},
 	}
 
 	for _, tt := range tests {
@@ -263,6 +276,9 @@ func TestTaskTransport_DeleteTask(t *testing.T) {
 
 			url := "/tasks/" + tt.taskID.String()
 			req := httptest.NewRequest(http.MethodDelete, url, nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
 
 			// Add user ID to context
 			ctx := context.WithValue(req.Context(), domains.UserIDKey{}, uuid.New().String())
@@ -275,3 +291,134 @@ func TestTaskTransport_DeleteTask(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskTransport_GetTaskByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskUsecase := mocks.NewMockTaskUsecase(ctrl)
+	cfg := &config.Config{}
+	handler := New(mockTaskUsecase, cfg)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/tasks/{taskId}", handler.GetTaskByID).Methods("GET")
+
+	taskID := uuid.New()
+
+	tests := []struct {
+		name         string
+		taskID       string
+		mockFunc     func()
+		statusCode   int
+		expectedETag string
+	}{
+		{
+			name:   "Success",
+			taskID: taskID.String(),
+			mockFunc: func() {
+				mockTaskUsecase.EXPECT().GetTaskByID(gomock.Any(), taskID).Return(&dto.TaskDTO{ID: taskID, Version: 4}, nil)
+			},
+			statusCode:   http.StatusOK,
+			expectedETag: `"4"`,
+		},
+		{
+			name:   "Not found",
+			taskID: taskID.String(),
+			mockFunc: func() {
+				mockTaskUsecase.EXPECT().GetTaskByID(gomock.Any(), taskID).Return(nil, errs.ErrTaskNotFound)
+			},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Invalid task ID",
+			taskID:     "invalid-uuid",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodGet, "/tasks/"+tt.taskID, nil)
+			ctx := context.WithValue(req.Context(), domains.UserIDKey{}, uuid.New().String())
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			assert.Equal(t, tt.expectedETag, rr.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestTaskTransport_GetBacklinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskUsecase := mocks.NewMockTaskUsecase(ctrl)
+	cfg := &config.Config{}
+	handler := New(mockTaskUsecase, cfg)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/tasks/{taskId}/backlinks", handler.GetBacklinks).Methods("GET")
+
+	taskID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name        string
+		taskID      string
+		mockFunc    func()
+		statusCode  int
+		expectedLen int
+	}{
+		{
+			name:   "Success",
+			taskID: taskID.String(),
+			mockFunc: func() {
+				mockTaskUsecase.EXPECT().GetBacklinks(gomock.Any(), taskID).Return([]*linkdto.BacklinkDTO{
+					{Type: "note", ID: noteID, Title: "Meeting notes"},
+				}, nil)
+			},
+			statusCode:  http.StatusOK,
+			expectedLen: 1,
+		},
+		{
+			name:   "Not found",
+			taskID: taskID.String(),
+			mockFunc: func() {
+				mockTaskUsecase.EXPECT().GetBacklinks(gomock.Any(), taskID).Return(nil, errs.ErrTaskNotFound)
+			},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Invalid task ID",
+			taskID:     "invalid-uuid",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodGet, "/tasks/"+tt.taskID+"/backlinks", nil)
+			ctx := context.WithValue(req.Context(), domains.UserIDKey{}, uuid.New().String())
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			if tt.statusCode == http.StatusOK {
+				var result []linkdto.BacklinkDTO
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+				assert.Len(t, result, tt.expectedLen)
+			}
+		})
+	}
+}
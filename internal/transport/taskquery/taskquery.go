@@ -0,0 +1,74 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/taskquery"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/search"
+)
+
+type TaskQueryUsecase interface {
+	SearchTasks(ctx context.Context, query string, limit, offset int) ([]*dto.TaskDTO, error)
+}
+
+type TaskQueryHandler struct {
+	uc     TaskQueryUsecase
+	config *config.Config
+}
+
+func New(uc TaskQueryUsecase, cfg *config.Config) *TaskQueryHandler {
+	return &TaskQueryHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// SearchTasks ищет задачи по запросу на языке фильтров
+// @Summary      Поиск задач по запросу
+// @Description  Выполняет запрос вида status:in_progress importance>=2 due<+3d project:"Backend" -author:me "login bug". Поля: status, importance, due, created, project, author (логин или me). Операторы: ":" (или "="), "!=", "<", "<=", ">", ">=". Даты: YYYY-MM-DD, today, tomorrow, yesterday, смещения +3d, -1w, +2m (в часовом поясе пользователя), due:none - без дедлайна. Условия через пробел объединяются по AND, поддерживаются OR, NOT, "-" и скобки. При ошибке в запросе в ответе возвращается position - номер символа (с нуля)
+// @Tags         tasks
+// @Produce      json
+// @Param        query   query  string  true   "Запрос"
+// @Param        limit   query  int     false  "Количество задач (1-100), по умолчанию 20"
+// @Param        offset  query  int     false  "Смещение"
+// @Success      200  {array}  dto.TaskDTO "Список задач"
+// @Failure      400  {object} dto.ErrorResponse "Ошибка в запросе"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/search [get]
+func (h *TaskQueryHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	const op = "TaskQueryHandler.SearchTasks"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	query := r.URL.Query()
+
+	limit, offset, err := validation.ValidationPagination(query.Get("limit"), query.Get("offset"))
+	if err != nil {
+		logger.Warn("pagination validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tasks, err := h.uc.SearchTasks(r.Context(), query.Get("query"), limit, offset)
+	if err != nil {
+		var queryErr *taskquery.Error
+		if errors.As(err, &queryErr) {
+			logger.Warn("invalid task query: ", queryErr.Error())
+			response.SendErrorAt(r.Context(), w, http.StatusBadRequest, queryErr.Message, queryErr.Position)
+			return
+		}
+		logger.WithError(err).Error("failed to search tasks")
+		handler.HandleError(r.Context(), w, err, "Failed to search tasks")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, tasks)
+}
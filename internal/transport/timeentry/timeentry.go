@@ -0,0 +1,365 @@
+package transport
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/lzimin05/course-todo/config"
+	reportmodels "github.com/lzimin05/course-todo/internal/models/report"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/timeentry"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	reportvalidation "github.com/lzimin05/course-todo/internal/transport/utils/validation/report"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/timeentry"
+)
+
+//go:generate mockgen -source=timeentry.go -destination=../../usecase/mocks/timeentry_usecase_mock.go -package=mocks TimeEntryUsecase
+type TimeEntryUsecase interface {
+	StartTimer(ctx context.Context, taskID uuid.UUID, req dto.StartTimerDTO) (*dto.TimeEntryDTO, error)
+	StopTimer(ctx context.Context) (*dto.TimeEntryDTO, error)
+	GetRunningTimer(ctx context.Context) (*dto.TimeEntryDTO, error)
+	AddTimeEntry(ctx context.Context, taskID uuid.UUID, req dto.CreateTimeEntryDTO) (*dto.TimeEntryDTO, error)
+	GetTaskTimeEntries(ctx context.Context, taskID uuid.UUID) (*dto.TaskTimeDTO, error)
+	DeleteTimeEntry(ctx context.Context, entryID uuid.UUID) error
+	GetTimeReport(ctx context.Context, projectID *uuid.UUID, from, to time.Time) (*dto.TimeReportDTO, error)
+}
+
+type TimeEntryHandler struct {
+	uc     TimeEntryUsecase
+	config *config.Config
+}
+
+func New(uc TimeEntryUsecase, cfg *config.Config) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// StartTimer запускает таймер по задаче
+// @Summary      Запустить таймер
+// @Description  Запускает таймер по задаче. У пользователя может быть только один запущенный таймер
+// @Tags         time
+// @Accept       json
+// @Produce      json
+// @Param        taskId  path  string  true   "ID задачи"
+// @Param        timer   body  dto.StartTimerDTO  false  "Комментарий к записи"
+// @Success      201  {object} dto.TimeEntryDTO "Запущенный таймер"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача не найдена"
+// @Failure      409  {object} dto.ErrorResponse "Таймер уже запущен"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/{taskId}/timer/start [post]
+func (h *TimeEntryHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	const op = "TimeEntryHandler.StartTimer"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	taskID, err := uuid.Parse(mux.Vars(r)["taskId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid task ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	// Тело необязательно
+	var req dto.StartTimerDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if err := validation.ValidationTimeEntryNote(req.Note); err != nil {
+		logger.WithError(err).Warn("invalid note")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entry, err := h.uc.StartTimer(r.Context(), taskID, req)
+	if err != nil {
+		logger.WithError(err).Error("failed to start timer")
+		handler.HandleError(r.Context(), w, err, "Failed to start timer")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, entry)
+}
+
+// StopTimer останавливает запущенный таймер
+// @Summary      Остановить таймер
+// @Description  Останавливает запущенный таймер пользователя и возвращает итоговую запись
+// @Tags         time
+// @Produce      json
+// @Success      200  {object} dto.TimeEntryDTO "Завершенная запись"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Нет запущенного таймера"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /time/timer/stop [post]
+func (h *TimeEntryHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	const op = "TimeEntryHandler.StopTimer"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	entry, err := h.uc.StopTimer(r.Context())
+	if err != nil {
+		logger.WithError(err).Error("failed to stop timer")
+		handler.HandleError(r.Context(), w, err, "Failed to stop timer")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, entry)
+}
+
+// GetRunningTimer возвращает запущенный таймер
+// @Summary      Текущий таймер
+// @Description  Возвращает запущенный таймер пользователя
+// @Tags         time
+// @Produce      json
+// @Success      200  {object} dto.TimeEntryDTO "Запущенный таймер"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Нет запущенного таймера"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /time/timer [get]
+func (h *TimeEntryHandler) GetRunningTimer(w http.ResponseWriter, r *http.Request) {
+	const op = "TimeEntryHandler.GetRunningTimer"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	entry, err := h.uc.GetRunningTimer(r.Context())
+	if err != nil {
+		logger.WithError(err).Warn("failed to get running timer")
+		handler.HandleError(r.Context(), w, err, "Failed to get running timer")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, entry)
+}
+
+// AddTimeEntry добавляет запись задним числом
+// @Summary      Добавить запись времени
+// @Description  Добавляет завершенную запись по задаче. Нужно указать ended_at или duration_minutes
+// @Tags         time
+// @Accept       json
+// @Produce      json
+// @Param        taskId  path  string  true  "ID задачи"
+// @Param        entry   body  dto.CreateTimeEntryDTO  true  "Запись времени"
+// @Success      201  {object} dto.TimeEntryDTO "Созданная запись"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/{taskId}/time [post]
+func (h *TimeEntryHandler) AddTimeEntry(w http.ResponseWriter, r *http.Request) {
+	const op = "TimeEntryHandler.AddTimeEntry"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	taskID, err := uuid.Parse(mux.Vars(r)["taskId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid task ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	var req dto.CreateTimeEntryDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request format")
+		return
+	}
+
+	if err := validation.ValidationTimeEntry(req, time.Now()); err != nil {
+		logger.WithError(err).Warn("invalid time entry")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entry, err := h.uc.AddTimeEntry(r.Context(), taskID, req)
+	if err != nil {
+		logger.WithError(err).Error("failed to add time entry")
+		handler.HandleError(r.Context(), w, err, "Failed to add time entry")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, entry)
+}
+
+// GetTaskTimeEntries возвращает записи времени по задаче
+// @Summary      Записи времени по задаче
+// @Description  Возвращает записи всех участников по задаче и сумму завершенных записей
+// @Tags         time
+// @Produce      json
+// @Param        taskId  path  string  true  "ID задачи"
+// @Success      200  {object} dto.TaskTimeDTO "Записи времени"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Задача не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /todo/{taskId}/time [get]
+func (h *TimeEntryHandler) GetTaskTimeEntries(w http.ResponseWriter, r *http.Request) {
+	const op = "TimeEntryHandler.GetTaskTimeEntries"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	taskID, err := uuid.Parse(mux.Vars(r)["taskId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid task ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid task ID")
+		return
+	}
+
+	entries, err := h.uc.GetTaskTimeEntries(r.Context(), taskID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get time entries")
+		handler.HandleError(r.Context(), w, err, "Failed to get time entries")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, entries)
+}
+
+// DeleteTimeEntry удаляет запись времени
+// @Summary      Удалить запись времени
+// @Description  Удаляет свою запись времени
+// @Tags         time
+// @Param        entryId  path  string  true  "ID записи"
+// @Success      204  "Запись удалена"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      404  {object} dto.ErrorResponse "Запись не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /time/entries/{entryId} [delete]
+func (h *TimeEntryHandler) DeleteTimeEntry(w http.ResponseWriter, r *http.Request) {
+	const op = "TimeEntryHandler.DeleteTimeEntry"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	entryID, err := uuid.Parse(mux.Vars(r)["entryId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid time entry ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid time entry ID")
+		return
+	}
+
+	if err := h.uc.DeleteTimeEntry(r.Context(), entryID); err != nil {
+		logger.WithError(err).Error("failed to delete time entry")
+		handler.HandleError(r.Context(), w, err, "Failed to delete time entry")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetTimeReport возвращает отчет по учету времени
+// @Summary      Отчет по времени
+// @Description  Суммирует завершенные записи по проектам, участникам и дням за период. Дни считаются в часовом поясе пользователя, запись относится к дню своего начала
+// @Tags         time
+// @Produce      json
+// @Produce      text/csv
+// @Param        from        query  string  false  "Начало периода (YYYY-MM-DD), по умолчанию 30 дней назад"
+// @Param        to          query  string  false  "Конец периода (YYYY-MM-DD), по умолчанию сегодня"
+// @Param        project_id  query  string  false  "Только один проект"
+// @Param        format      query  string  false  "json (по умолчанию) или csv"
+// @Success      200  {object} dto.TimeReportDTO "Отчет"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Нет доступа к проекту"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /time/report [get]
+func (h *TimeEntryHandler) GetTimeReport(w http.ResponseWriter, r *http.Request) {
+	const op = "TimeEntryHandler.GetTimeReport"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	query := r.URL.Query()
+	from, to, _, err := reportvalidation.ValidationReportRange(query.Get("from"), query.Get("to"), reportmodels.BucketDay, time.Now())
+	if err != nil {
+		logger.Warn("report range validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	format, err := validation.ValidationReportFormat(query.Get("format"))
+	if err != nil {
+		logger.Warn("report format validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var projectID *uuid.UUID
+	if raw := query.Get("project_id"); raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			logger.WithError(err).Warn("invalid project ID")
+			response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+			return
+		}
+		projectID = &parsed
+	}
+
+	report, err := h.uc.GetTimeReport(r.Context(), projectID, from, to)
+	if err != nil {
+		logger.WithError(err).Error("failed to get time report")
+		handler.HandleError(r.Context(), w, err, "Failed to get time report")
+		return
+	}
+
+	if format == validation.FormatJSON {
+		response.SendJSONResponse(r.Context(), w, http.StatusOK, report)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="time-report-`+report.From+`-`+report.To+`.csv"`)
+	w.WriteHeader(http.StatusOK)
+	if err := writeReportCSV(w, report); err != nil {
+		logger.WithError(err).Warn("failed to write csv report")
+	}
+}
+
+func writeReportCSV(w io.Writer, report *dto.TimeReportDTO) error {
+	cw := csv.NewWriter(w)
+	// Excel и LibreOffice ждут в CSV разделитель строк CRLF
+	cw.UseCRLF = true
+
+	if err := cw.Write([]string{"project_id", "project_name", "user_id", "login", "date", "seconds", "hours"}); err != nil {
+		return err
+	}
+	for _, row := range report.Rows {
+		record := []string{
+			row.ProjectID.String(),
+			csvText(row.ProjectName),
+			row.UserID.String(),
+			csvText(row.Login),
+			row.Date,
+			strconv.FormatInt(row.Seconds, 10),
+			strconv.FormatFloat(row.Hours, 'f', 2, 64),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvText не дает табличным редакторам принять пользовательский текст за формулу
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
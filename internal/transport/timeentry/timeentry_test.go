@@ -0,0 +1,143 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/timeentry"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestTimeEntryTransport_StartTimer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockTimeEntryUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/todo/{taskId}/timer/start", handler.StartTimer).Methods(http.MethodPost)
+
+	taskID := uuid.New()
+
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name: "Started without body",
+			mockFunc: func() {
+				mockUsecase.EXPECT().StartTimer(gomock.Any(), taskID, dto.StartTimerDTO{}).
+					Return(&dto.TimeEntryDTO{ID: uuid.New(), TaskID: taskID}, nil)
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name: "Started with note",
+			body: `{"note":"Созвон"}`,
+			mockFunc: func() {
+				mockUsecase.EXPECT().StartTimer(gomock.Any(), taskID, dto.StartTimerDTO{Note: "Созвон"}).
+					Return(&dto.TimeEntryDTO{ID: uuid.New(), TaskID: taskID}, nil)
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name: "Timer already running",
+			mockFunc: func() {
+				mockUsecase.EXPECT().StartTimer(gomock.Any(), taskID, gomock.Any()).Return(nil, errs.ErrTimerRunning)
+			},
+			statusCode: http.StatusConflict,
+		},
+		{
+			name:       "Invalid body",
+			body:       `{"note":`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodPost, "/todo/"+taskID.String()+"/timer/start", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), domains.UserIDKey{}, uuid.New().String()))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+		})
+	}
+}
+
+func TestTimeEntryTransport_GetTimeReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockTimeEntryUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{})
+
+	projectID := uuid.New()
+	userID := uuid.New()
+	report := &dto.TimeReportDTO{
+		From:         "2025-03-01",
+		To:           "2025-03-07",
+		Timezone:     "UTC",
+		TotalSeconds: 5400,
+		Rows: []dto.TimeReportRowDTO{
+			{ProjectID: projectID, ProjectName: "=SUM(A1)", UserID: userID, Login: "ivan", Date: "2025-03-03", Seconds: 5400, Hours: 1.5},
+		},
+	}
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)
+
+	t.Run("CSV", func(t *testing.T) {
+		mockUsecase.EXPECT().GetTimeReport(gomock.Any(), &projectID, from, to).Return(report, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/time/report?from=2025-03-01&to=2025-03-07&format=csv&project_id="+projectID.String(), nil)
+		rr := httptest.NewRecorder()
+		handler.GetTimeReport(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "time-report-2025-03-01-2025-03-07.csv")
+		assert.Equal(t,
+			"project_id,project_name,user_id,login,date,seconds,hours\r\n"+
+				projectID.String()+",'=SUM(A1),"+userID.String()+",ivan,2025-03-03,5400,1.50\r\n",
+			rr.Body.String())
+	})
+
+	t.Run("JSON by default", func(t *testing.T) {
+		mockUsecase.EXPECT().GetTimeReport(gomock.Any(), nil, from, to).Return(report, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/time/report?from=2025-03-01&to=2025-03-07", nil)
+		rr := httptest.NewRecorder()
+		handler.GetTimeReport(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"total_seconds":5400`)
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/time/report?format=xlsx", nil)
+		rr := httptest.NewRecorder()
+		handler.GetTimeReport(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
@@ -18,6 +18,7 @@ type IUserUsecase interface {
 	GetUserByEmail(context.Context, string) (*dto.UserDTO, error)
 	GetUserByLogin(context.Context, string) (*dto.UserDTO, error)
 	UpdateUsername(context.Context, string) error
+	UpdateTimezone(context.Context, string) error
 }
 
 type UserHandler struct {
@@ -159,3 +160,42 @@ func (h *UserHandler) UpdateUsername(w http.ResponseWriter, r *http.Request) {
 
 	response.SendJSONResponse(r.Context(), w, http.StatusOK, nil)
 }
+
+// UpdateTimezone обновляет часовой пояс пользователя
+// @Summary      Обновить часовой пояс
+// @Description  Устанавливает часовой пояс текущего пользователя в формате IANA (например, Europe/Moscow). Используется для вычисления относительных дат в фильтрах
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateTimezoneRequest true "Новый часовой пояс"
+// @Success      200  "Часовой пояс обновлен"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Security     BearerAuth
+// @Router       /users/timezone [patch]
+func (h *UserHandler) UpdateTimezone(w http.ResponseWriter, r *http.Request) {
+	const op = "UserHandler.UpdateTimezone"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	var req dto.UpdateTimezoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode request")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+
+	if err := validation.ValidateUpdateTimezoneRequest(req); err != nil {
+		logger.WithError(err).Warn("validation failed")
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := h.uc.UpdateTimezone(r.Context(), req.Timezone)
+	if err != nil {
+		logger.WithError(err).Error("failed to update timezone")
+		response.SendError(r.Context(), w, http.StatusInternalServerError, "failed to update timezone")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, nil)
+}
@@ -0,0 +1,46 @@
+package etag
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Format возвращает сильный ETag для версии сущности
+func Format(version int) string {
+	return fmt.Sprintf("%q", strconv.Itoa(version))
+}
+
+// Set выставляет заголовок ETag ответа
+func Set(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", Format(version))
+}
+
+// IfMatch возвращает версии из заголовка If-Match: изменение разрешено, если
+// текущая версия совпадает с любой из них. nil означает, что изменение безусловное:
+// заголовка нет или передан "*". Нераспознанные значения пропускаются: если не
+// распознано ни одно, список пуст, не совпадет ни с одной версией, и клиент получит 412
+func IfMatch(r *http.Request) []int {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	versions := make([]int, 0)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		// Для If-Match сравнение строгое, поэтому слабые ETag (W/"...") не подходят
+		if !strings.HasPrefix(tag, `"`) {
+			continue
+		}
+		unquoted, err := strconv.Unquote(tag)
+		if err != nil {
+			continue
+		}
+		if parsed, err := strconv.Atoi(unquoted); err == nil && parsed > 0 {
+			versions = append(versions, parsed)
+		}
+	}
+	return versions
+}
@@ -0,0 +1,46 @@
+package etag
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, `"7"`, Format(7))
+}
+
+func TestSet(t *testing.T) {
+	w := httptest.NewRecorder()
+	Set(w, 3)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected []int
+	}{
+		{name: "no header", header: "", expected: nil},
+		{name: "any version", header: "*", expected: nil},
+		{name: "strong etag", header: `"5"`, expected: []int{5}},
+		{name: "surrounding spaces", header: ` "5" `, expected: []int{5}},
+		{name: "list of etags", header: `"3", "4"`, expected: []int{3, 4}},
+		{name: "weak etags in list are skipped", header: `W/"3", "4"`, expected: []int{4}},
+		{name: "weak etag never matches", header: `W/"5"`, expected: []int{}},
+		{name: "unquoted value never matches", header: "5", expected: []int{}},
+		{name: "not a number never matches", header: `"abc"`, expected: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("PUT", "/", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+			assert.Equal(t, tt.expected, IfMatch(r))
+		})
+	}
+}
@@ -19,6 +19,36 @@ func HandleError(ctx context.Context, w http.ResponseWriter, err error, defaultM
 		response.SendError(ctx, w, http.StatusBadRequest, "Cannot add yourself as project member")
 	case errors.Is(err, errs.ErrOwnerCannotLeave):
 		response.SendError(ctx, w, http.StatusForbidden, "Project owner cannot leave project")
+	case errors.Is(err, errs.ErrFilterNameTaken):
+		response.SendError(ctx, w, http.StatusConflict, "Filter with this name already exists")
+	case errors.Is(err, errs.ErrVersionMismatch):
+		response.SendError(ctx, w, http.StatusPreconditionFailed, "Resource was modified by another request")
+	case errors.Is(err, errs.ErrFolderCycle):
+		response.SendError(ctx, w, http.StatusBadRequest, "Folder cannot be moved into itself")
+	case errors.Is(err, errs.ErrFileTooLarge):
+		response.SendError(ctx, w, http.StatusRequestEntityTooLarge, "File is too large")
+	case errors.Is(err, errs.ErrQuotaExceeded):
+		response.SendError(ctx, w, http.StatusRequestEntityTooLarge, "Project storage quota exceeded")
+	case errors.Is(err, errs.ErrAssigneeNotMember):
+		response.SendError(ctx, w, http.StatusBadRequest, "Assignee is not a project member")
+	case errors.Is(err, errs.ErrMentionNotMember):
+		response.SendError(ctx, w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, errs.ErrTimerRunning):
+		response.SendError(ctx, w, http.StatusConflict, "Timer is already running")
+	case errors.Is(err, errs.ErrInvalidCalendar):
+		response.SendError(ctx, w, http.StatusBadRequest, "Invalid calendar data")
+	case errors.Is(err, errs.ErrInvalidSyncToken):
+		response.SendError(ctx, w, http.StatusForbidden, "Invalid sync token")
+	case errors.Is(err, errs.ErrImportNotReady):
+		response.SendError(ctx, w, http.StatusConflict, "Import job is not ready to commit")
+	case errors.Is(err, errs.ErrImportHasErrors):
+		response.SendError(ctx, w, http.StatusConflict, "Import job has validation errors")
+	case errors.Is(err, errs.ErrProjectsUnresolved):
+		response.SendError(ctx, w, http.StatusConflict, err.Error())
+	case errors.Is(err, errs.ErrNewOwnerNotMember):
+		response.SendError(ctx, w, http.StatusBadRequest, "New owner is not a project member")
+	case errors.Is(err, errs.ErrTaskNotFound):
+		response.SendError(ctx, w, http.StatusNotFound, "Task not found")
 	case errors.Is(err, errs.ErrNotFound):
 		response.SendError(ctx, w, http.StatusNotFound, "Resource not found")
 	case errors.Is(err, errs.ErrInvalidID):
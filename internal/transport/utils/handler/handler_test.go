@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http/httptest"
 	"testing"
 
@@ -48,6 +49,69 @@ func TestHandleError(t *testing.T) {
 			expectedStatus: 403,
 			expectedMsg:    "Project owner cannot leave project",
 		},
+		{
+			name:           "ErrFilterNameTaken",
+			err:            errs.ErrFilterNameTaken,
+			defaultMsg:     "Default message",
+			expectedStatus: 409,
+			expectedMsg:    "Filter with this name already exists",
+		},
+		{
+			name:           "ErrTimerRunning",
+			err:            errs.ErrTimerRunning,
+			defaultMsg:     "Default message",
+			expectedStatus: 409,
+			expectedMsg:    "Timer is already running",
+		},
+		{
+			name:           "ErrInvalidCalendar",
+			err:            fmt.Errorf("%w: VTODO is required", errs.ErrInvalidCalendar),
+			defaultMsg:     "Default message",
+			expectedStatus: 400,
+			expectedMsg:    "Invalid calendar data",
+		},
+		{
+			name:           "ErrVersionMismatch",
+			err:            fmt.Errorf("TaskRepository.UpdateTask: %w", errs.ErrVersionMismatch),
+			defaultMsg:     "Default message",
+			expectedStatus: 412,
+			expectedMsg:    "Resource was modified by another request",
+		},
+		{
+			name:           "ErrFolderCycle",
+			err:            fmt.Errorf("NoteRepository.UpdateFolder: %w", errs.ErrFolderCycle),
+			defaultMsg:     "Default message",
+			expectedStatus: 400,
+			expectedMsg:    "Folder cannot be moved into itself",
+		},
+		{
+			name:           "ErrFileTooLarge",
+			err:            errs.ErrFileTooLarge,
+			defaultMsg:     "Default message",
+			expectedStatus: 413,
+			expectedMsg:    "File is too large",
+		},
+		{
+			name:           "ErrQuotaExceeded",
+			err:            fmt.Errorf("AttachmentRepository.CreateAttachment: %w", errs.ErrQuotaExceeded),
+			defaultMsg:     "Default message",
+			expectedStatus: 413,
+			expectedMsg:    "Project storage quota exceeded",
+		},
+		{
+			name:           "ErrAssigneeNotMember",
+			err:            fmt.Errorf("TaskRepository.CreateChecklistItem: %w", errs.ErrAssigneeNotMember),
+			defaultMsg:     "Default message",
+			expectedStatus: 400,
+			expectedMsg:    "Assignee is not a project member",
+		},
+		{
+			name:           "ErrMentionNotMember",
+			err:            fmt.Errorf("%w: @bob, @eve", errs.ErrMentionNotMember),
+			defaultMsg:     "Default message",
+			expectedStatus: 400,
+			expectedMsg:    "mentioned user is not a project member: @bob, @eve",
+		},
 		{
 			name:           "ErrNotFound",
 			err:            errs.ErrNotFound,
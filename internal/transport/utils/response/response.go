@@ -25,6 +25,22 @@ func SendError(ctx context.Context, w http.ResponseWriter, status int, message s
 	}
 }
 
+// SendErrorAt отправляет ошибку с позицией в разобранном запросе (в символах, с нуля)
+func SendErrorAt(ctx context.Context, w http.ResponseWriter, status int, message string, position int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	resp, err := json.Marshal(dto.ErrorResponse{Message: message, Position: &position})
+	if err != nil {
+		logctx.GetLogger(ctx).Error("failed to marshal response: ", err.Error())
+		return
+	}
+
+	if _, err := w.Write(resp); err != nil {
+		logctx.GetLogger(ctx).Error("failed to write response: ", err.Error())
+	}
+}
+
 func SendJSONResponse(ctx context.Context, w http.ResponseWriter, statusCode int, body any) {
 	const op = "response.SendJSONResponse"
 	if body == nil {
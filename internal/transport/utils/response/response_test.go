@@ -75,6 +75,17 @@ func TestSendError(t *testing.T) {
 	}
 }
 
+func TestSendErrorAt(t *testing.T) {
+	ctx := setupResponseTest()
+	w := httptest.NewRecorder()
+
+	SendErrorAt(ctx, w, 400, "unknown field \"foo\"", 7)
+
+	assert.Equal(t, 400, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"message":"unknown field \"foo\"","position":7}`, w.Body.String())
+}
+
 func TestSendJSONResponse_WithBody(t *testing.T) {
 	type TestData struct {
 		ID   int    `json:"id"`
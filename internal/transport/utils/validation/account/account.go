@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/account"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/account"
+)
+
+// ValidationDeleteAccount проверяет запрос на удаление аккаунта.
+// Наличие решения по каждому проекту проверяет usecase
+func ValidationDeleteAccount(req dto.DeleteAccountDTO) error {
+	if req.Password == "" {
+		return errors.New("password is required")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.Projects))
+	for _, p := range req.Projects {
+		if p.ProjectID == uuid.Nil {
+			return errors.New("project_id is required")
+		}
+		if seen[p.ProjectID] {
+			return fmt.Errorf("project %s is listed more than once", p.ProjectID)
+		}
+		seen[p.ProjectID] = true
+
+		switch p.Action {
+		case models.ActionTransfer:
+			if p.NewOwnerID == nil || *p.NewOwnerID == uuid.Nil {
+				return fmt.Errorf("new_owner_id is required to transfer project %s", p.ProjectID)
+			}
+		case models.ActionDelete:
+			if p.NewOwnerID != nil {
+				return fmt.Errorf("new_owner_id is not allowed when deleting project %s", p.ProjectID)
+			}
+		default:
+			return fmt.Errorf("action must be %s or %s", models.ActionTransfer, models.ActionDelete)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/account"
+)
+
+func TestValidationDeleteAccount(t *testing.T) {
+	projectID := uuid.New()
+	newOwnerID := uuid.New()
+
+	tests := []struct {
+		name        string
+		req         dto.DeleteAccountDTO
+		expectedErr string
+	}{
+		{
+			name: "transfer and delete",
+			req: dto.DeleteAccountDTO{Password: "secret", Projects: []dto.OwnedProjectActionDTO{
+				{ProjectID: projectID, Action: "transfer", NewOwnerID: &newOwnerID},
+				{ProjectID: uuid.New(), Action: "delete"},
+			}},
+		},
+		{
+			name: "no owned projects",
+			req:  dto.DeleteAccountDTO{Password: "secret"},
+		},
+		{
+			name:        "missing password",
+			req:         dto.DeleteAccountDTO{},
+			expectedErr: "password is required",
+		},
+		{
+			name: "missing project ID",
+			req: dto.DeleteAccountDTO{Password: "secret", Projects: []dto.OwnedProjectActionDTO{
+				{Action: "delete"},
+			}},
+			expectedErr: "project_id is required",
+		},
+		{
+			name: "duplicate project",
+			req: dto.DeleteAccountDTO{Password: "secret", Projects: []dto.OwnedProjectActionDTO{
+				{ProjectID: projectID, Action: "delete"},
+				{ProjectID: projectID, Action: "transfer", NewOwnerID: &newOwnerID},
+			}},
+			expectedErr: "project " + projectID.String() + " is listed more than once",
+		},
+		{
+			name: "transfer without new owner",
+			req: dto.DeleteAccountDTO{Password: "secret", Projects: []dto.OwnedProjectActionDTO{
+				{ProjectID: projectID, Action: "transfer"},
+			}},
+			expectedErr: "new_owner_id is required to transfer project " + projectID.String(),
+		},
+		{
+			name: "delete with new owner",
+			req: dto.DeleteAccountDTO{Password: "secret", Projects: []dto.OwnedProjectActionDTO{
+				{ProjectID: projectID, Action: "delete", NewOwnerID: &newOwnerID},
+			}},
+			expectedErr: "new_owner_id is not allowed when deleting project " + projectID.String(),
+		},
+		{
+			name: "unknown action",
+			req: dto.DeleteAccountDTO{Password: "secret", Projects: []dto.OwnedProjectActionDTO{
+				{ProjectID: projectID, Action: "archive"},
+			}},
+			expectedErr: "action must be transfer or delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidationDeleteAccount(tt.req)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
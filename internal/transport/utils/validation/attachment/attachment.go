@@ -0,0 +1,20 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/attachment"
+)
+
+// ValidationAttachmentParent разбирает сущность, к которой относится вложение
+func ValidationAttachmentParent(parentType, parentIDStr string) (uuid.UUID, error) {
+	if parentType != models.ParentTask && parentType != models.ParentNote {
+		return uuid.Nil, errors.New("parent_type must be task or note")
+	}
+	parentID, err := uuid.Parse(parentIDStr)
+	if err != nil {
+		return uuid.Nil, errors.New("parent_id must be a valid UUID")
+	}
+	return parentID, nil
+}
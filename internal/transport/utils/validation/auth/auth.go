@@ -4,6 +4,7 @@ import (
 	"errors"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/auth"
 )
@@ -53,3 +54,14 @@ func ValidateRegisterRequest(req dto.RegisterRequest) error {
 
 	return nil
 }
+
+// ValidateAppPasswordName проверяет название пароля приложения ("iPhone", "DAVx5")
+func ValidateAppPasswordName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	return nil
+}
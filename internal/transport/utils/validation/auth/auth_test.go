@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -300,3 +301,9 @@ func TestValidateRegisterRequest_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAppPasswordName(t *testing.T) {
+	assert.NoError(t, ValidateAppPasswordName("iPhone"))
+	assert.EqualError(t, ValidateAppPasswordName("  "), "name is required")
+	assert.EqualError(t, ValidateAppPasswordName(strings.Repeat("я", 101)), "name must be at most 100 characters")
+}
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"errors"
+	"time"
+
+	models "github.com/lzimin05/course-todo/internal/models/calendar"
+)
+
+const (
+	DateLayout = "2006-01-02"
+	maxDays    = 366
+)
+
+// ValidationCalendarRange разбирает обязательные даты from и to окна календаря
+func ValidationCalendarRange(fromStr, toStr string) (time.Time, time.Time, error) {
+	if fromStr == "" || toStr == "" {
+		return time.Time{}, time.Time{}, errors.New("from and to are required")
+	}
+
+	from, err := time.Parse(DateLayout, fromStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("from must be a date in YYYY-MM-DD format")
+	}
+	to, err := time.Parse(DateLayout, toStr)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("to must be a date in YYYY-MM-DD format")
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.New("from must be before or equal to to")
+	}
+	if int(to.Sub(from).Hours()/24)+1 > maxDays {
+		return time.Time{}, time.Time{}, errors.New("range is too large")
+	}
+
+	return from, to, nil
+}
+
+// ValidationFeedKind проверяет вид компонентов ICS-фида. По умолчанию - события
+func ValidationFeedKind(kind string) (string, error) {
+	switch kind {
+	case "", models.FeedKindEvent:
+		return models.FeedKindEvent, nil
+	case models.FeedKindTodo:
+		return models.FeedKindTodo, nil
+	default:
+		return "", errors.New("kind must be event or todo")
+	}
+}
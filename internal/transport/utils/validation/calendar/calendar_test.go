@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationCalendarRange(t *testing.T) {
+	from, to, err := ValidationCalendarRange("2025-03-01", "2025-03-31")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), to)
+
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		expectedErr string
+	}{
+		{name: "missing from", to: "2025-03-31", expectedErr: "from and to are required"},
+		{name: "bad from", from: "01.03.2025", to: "2025-03-31", expectedErr: "from must be a date in YYYY-MM-DD format"},
+		{name: "bad to", from: "2025-03-01", to: "tomorrow", expectedErr: "to must be a date in YYYY-MM-DD format"},
+		{name: "reversed", from: "2025-03-31", to: "2025-03-01", expectedErr: "from must be before or equal to to"},
+		{name: "too large", from: "2024-01-01", to: "2025-03-01", expectedErr: "range is too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ValidationCalendarRange(tt.from, tt.to)
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestValidationFeedKind(t *testing.T) {
+	kind, err := ValidationFeedKind("")
+	assert.NoError(t, err)
+	assert.Equal(t, "event", kind)
+
+	kind, err = ValidationFeedKind("todo")
+	assert.NoError(t, err)
+	assert.Equal(t, "todo", kind)
+
+	_, err = ValidationFeedKind("journal")
+	assert.EqualError(t, err, "kind must be event or todo")
+}
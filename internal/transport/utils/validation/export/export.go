@@ -0,0 +1,19 @@
+package validation
+
+import (
+	"errors"
+
+	models "github.com/lzimin05/course-todo/internal/models/export"
+)
+
+// ValidationExportFormat возвращает формат выгрузки, по умолчанию json
+func ValidationExportFormat(format string) (string, error) {
+	switch format {
+	case "", models.FormatJSON:
+		return models.FormatJSON, nil
+	case models.FormatCSV, models.FormatNDJSON:
+		return format, nil
+	default:
+		return "", errors.New("format must be csv, json or ndjson")
+	}
+}
@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/export"
+)
+
+func TestValidationExportFormat(t *testing.T) {
+	format, err := ValidationExportFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, models.FormatJSON, format)
+
+	for _, f := range []string{models.FormatCSV, models.FormatJSON, models.FormatNDJSON} {
+		format, err = ValidationExportFormat(f)
+		assert.NoError(t, err)
+		assert.Equal(t, f, format)
+	}
+
+	_, err = ValidationExportFormat("xlsx")
+	assert.EqualError(t, err, "format must be csv, json or ndjson")
+}
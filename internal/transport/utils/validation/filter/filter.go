@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/filter"
+	taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/filter"
+)
+
+const (
+	maxNameLength   = 100
+	maxTextLength   = 200
+	maxProjectIDs   = 50
+	maxRelativeDays = 366
+)
+
+// ValidationFilter проверяет имя и условия сохраненного фильтра
+func ValidationFilter(req *dto.PostFilterDTO) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("name must be at most %d characters", maxNameLength)
+	}
+
+	return validationDefinition(&req.Definition)
+}
+
+func validationDefinition(def *dto.FilterDefinitionDTO) error {
+	if len(def.ProjectIDs) > maxProjectIDs {
+		return fmt.Errorf("project_ids must contain at most %d items", maxProjectIDs)
+	}
+	seen := make(map[uuid.UUID]struct{}, len(def.ProjectIDs))
+	for _, id := range def.ProjectIDs {
+		if id == uuid.Nil {
+			return errors.New("project_ids must not contain empty ids")
+		}
+		if _, ok := seen[id]; ok {
+			return errors.New("project_ids must not contain duplicates")
+		}
+		seen[id] = struct{}{}
+	}
+
+	for i, status := range def.Statuses {
+		if status != taskmodels.StatusWaiting && status != taskmodels.StatusInProgress && status != taskmodels.StatusCompleted {
+			return errors.New("statuses must be one of: waiting, in_progress, completed")
+		}
+		for _, prev := range def.Statuses[:i] {
+			if prev == status {
+				return errors.New("statuses must not contain duplicates")
+			}
+		}
+	}
+
+	for i, importance := range def.Importance {
+		if importance < 1 || importance > 3 {
+			return errors.New("importance must be between 1 and 3")
+		}
+		for _, prev := range def.Importance[:i] {
+			if prev == importance {
+				return errors.New("importance must not contain duplicates")
+			}
+		}
+	}
+
+	if utf8.RuneCountInString(def.Text) > maxTextLength {
+		return fmt.Errorf("text must be at most %d characters", maxTextLength)
+	}
+
+	if err := validationDateBound("deadline_from", def.DeadlineFrom); err != nil {
+		return err
+	}
+	if err := validationDateBound("deadline_to", def.DeadlineTo); err != nil {
+		return err
+	}
+
+	// Порядок границ можно проверить заранее, только если обе заданы одинаково
+	from, to := def.DeadlineFrom, def.DeadlineTo
+	if from != nil && to != nil {
+		if from.Days != nil && to.Days != nil && *from.Days > *to.Days {
+			return errors.New("deadline_from must not be after deadline_to")
+		}
+		if from.Date != "" && to.Date != "" && from.Date > to.Date {
+			return errors.New("deadline_from must not be after deadline_to")
+		}
+	}
+
+	return nil
+}
+
+func validationDateBound(field string, bound *dto.DateBoundDTO) error {
+	if bound == nil {
+		return nil
+	}
+
+	if (bound.Date == "") == (bound.Days == nil) {
+		return fmt.Errorf("%s must contain exactly one of date or days", field)
+	}
+
+	if bound.Date != "" {
+		if _, err := time.Parse(models.DateLayout, bound.Date); err != nil {
+			return fmt.Errorf("%s.date must be a date in YYYY-MM-DD format", field)
+		}
+		return nil
+	}
+
+	if *bound.Days < -maxRelativeDays || *bound.Days > maxRelativeDays {
+		return fmt.Errorf("%s.days must be between %d and %d", field, -maxRelativeDays, maxRelativeDays)
+	}
+
+	return nil
+}
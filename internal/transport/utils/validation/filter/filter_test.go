@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/filter"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestValidationFilter(t *testing.T) {
+	projectID := uuid.New()
+
+	tests := []struct {
+		name        string
+		req         dto.PostFilterDTO
+		expectedErr string
+	}{
+		{
+			name: "valid filter",
+			req: dto.PostFilterDTO{
+				Name: "Горящие",
+				Definition: dto.FilterDefinitionDTO{
+					ProjectIDs:   []uuid.UUID{projectID},
+					Statuses:     []string{"waiting", "in_progress"},
+					Importance:   []int{3},
+					DeadlineFrom: &dto.DateBoundDTO{Days: intPtr(0)},
+					DeadlineTo:   &dto.DateBoundDTO{Days: intPtr(7)},
+				},
+			},
+		},
+		{
+			name:        "empty name",
+			req:         dto.PostFilterDTO{Name: "  "},
+			expectedErr: "name is required",
+		},
+		{
+			name:        "name too long",
+			req:         dto.PostFilterDTO{Name: strings.Repeat("ф", 101)},
+			expectedErr: "name must be at most 100 characters",
+		},
+		{
+			name: "unknown status",
+			req: dto.PostFilterDTO{Name: "f", Definition: dto.FilterDefinitionDTO{
+				Statuses: []string{"done"},
+			}},
+			expectedErr: "statuses must be one of",
+		},
+		{
+			name: "duplicate project",
+			req: dto.PostFilterDTO{Name: "f", Definition: dto.FilterDefinitionDTO{
+				ProjectIDs: []uuid.UUID{projectID, projectID},
+			}},
+			expectedErr: "project_ids must not contain duplicates",
+		},
+		{
+			name: "importance out of range",
+			req: dto.PostFilterDTO{Name: "f", Definition: dto.FilterDefinitionDTO{
+				Importance: []int{4},
+			}},
+			expectedErr: "importance must be between 1 and 3",
+		},
+		{
+			name: "date bound with both date and days",
+			req: dto.PostFilterDTO{Name: "f", Definition: dto.FilterDefinitionDTO{
+				DeadlineTo: &dto.DateBoundDTO{Date: "2025-01-01", Days: intPtr(1)},
+			}},
+			expectedErr: "deadline_to must contain exactly one of date or days",
+		},
+		{
+			name: "empty date bound",
+			req: dto.PostFilterDTO{Name: "f", Definition: dto.FilterDefinitionDTO{
+				DeadlineFrom: &dto.DateBoundDTO{},
+			}},
+			expectedErr: "deadline_from must contain exactly one of date or days",
+		},
+		{
+			name: "invalid date",
+			req: dto.PostFilterDTO{Name: "f", Definition: dto.FilterDefinitionDTO{
+				DeadlineFrom: &dto.DateBoundDTO{Date: "01.01.2025"},
+			}},
+			expectedErr: "deadline_from.date must be a date in YYYY-MM-DD format",
+		},
+		{
+			name: "days out of range",
+			req: dto.PostFilterDTO{Name: "f", Definition: dto.FilterDefinitionDTO{
+				DeadlineTo: &dto.DateBoundDTO{Days: intPtr(1000)},
+			}},
+			expectedErr: "deadline_to.days must be between -366 and 366",
+		},
+		{
+			name: "inverted relative range",
+			req: dto.PostFilterDTO{Name: "f", Definition: dto.FilterDefinitionDTO{
+				DeadlineFrom: &dto.DateBoundDTO{Days: intPtr(7)},
+				DeadlineTo:   &dto.DateBoundDTO{Days: intPtr(0)},
+			}},
+			expectedErr: "deadline_from must not be after deadline_to",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidationFilter(&tt.req)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
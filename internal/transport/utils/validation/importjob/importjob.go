@@ -0,0 +1,52 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	models "github.com/lzimin05/course-todo/internal/models/importjob"
+	taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/importjob"
+)
+
+// ValidationImportSource проверяет источник импорта
+func ValidationImportSource(source string) error {
+	if !slices.Contains(models.Sources, source) {
+		return fmt.Errorf("source must be one of: %s", strings.Join(models.Sources, ", "))
+	}
+	return nil
+}
+
+// ValidationImportMapping разбирает и проверяет настройку сопоставления.
+// Пустая строка - настройка не задана
+func ValidationImportMapping(raw string) (dto.ImportMappingDTO, error) {
+	var mapping dto.ImportMappingDTO
+	if strings.TrimSpace(raw) == "" {
+		return mapping, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+		return mapping, errors.New("mapping must be a valid JSON object")
+	}
+
+	for column, field := range mapping.Columns {
+		if field != "" && !slices.Contains(models.Fields, field) {
+			return mapping, fmt.Errorf("mapping for column %q must be one of: %s", column, strings.Join(models.Fields, ", "))
+		}
+	}
+	for value, status := range mapping.Statuses {
+		switch status {
+		case taskmodels.StatusWaiting, taskmodels.StatusInProgress, taskmodels.StatusCompleted:
+		default:
+			return mapping, fmt.Errorf("status for %q must be waiting, in_progress or completed", value)
+		}
+	}
+	for label, importance := range mapping.Labels {
+		if importance < 1 || importance > 3 {
+			return mapping, fmt.Errorf("importance for label %q must be between 1 and 3", label)
+		}
+	}
+	return mapping, nil
+}
@@ -0,0 +1,43 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationImportSource(t *testing.T) {
+	for _, source := range []string{"csv", "todoist", "trello", "course-todo"} {
+		assert.NoError(t, ValidationImportSource(source))
+	}
+	assert.Error(t, ValidationImportSource(""))
+	assert.Error(t, ValidationImportSource("asana"))
+}
+
+func TestValidationImportMapping(t *testing.T) {
+	mapping, err := ValidationImportMapping("")
+	assert.NoError(t, err)
+	assert.Nil(t, mapping.Columns)
+
+	mapping, err = ValidationImportMapping(`{"columns": {"Task": "title", "Owner": ""}, "statuses": {"Done": "completed"}, "labels": {"urgent": 3}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "title", mapping.Columns["Task"])
+	assert.Equal(t, 3, mapping.Labels["urgent"])
+
+	tests := []struct {
+		name        string
+		raw         string
+		expectedErr string
+	}{
+		{name: "not json", raw: "columns", expectedErr: "mapping must be a valid JSON object"},
+		{name: "unknown field", raw: `{"columns": {"Owner": "assignee"}}`, expectedErr: `mapping for column "Owner" must be one of: type, title, description, status, importance, deadline, start_at, all_day, estimate_minutes, labels, format`},
+		{name: "unknown status", raw: `{"statuses": {"Done": "closed"}}`, expectedErr: `status for "Done" must be waiting, in_progress or completed`},
+		{name: "importance out of range", raw: `{"labels": {"urgent": 5}}`, expectedErr: `importance for label "urgent" must be between 1 and 3`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidationImportMapping(tt.raw)
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
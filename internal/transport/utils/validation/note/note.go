@@ -1,6 +1,10 @@
 package validation
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
 
 func ValidationNote(name string, description string) error {
 	if name == "" {
@@ -14,3 +18,59 @@ func ValidationNote(name string, description string) error {
 	}
 	return nil
 }
+
+// ValidationNoteFormat проверяет формат заметки. Пустое значение означает формат по умолчанию
+func ValidationNoteFormat(format string) error {
+	switch format {
+	case "", "plain", "markdown":
+		return nil
+	}
+	return errors.New("format must be plain or markdown")
+}
+
+// ValidationRevision разбирает номер ревизии заметки
+func ValidationRevision(revisionStr string) (int, error) {
+	revision, err := strconv.Atoi(revisionStr)
+	if err != nil || revision < 1 {
+		return 0, errors.New("revision must be a positive integer")
+	}
+	return revision, nil
+}
+
+// ValidationRevisionRange разбирает пару ревизий для сравнения
+func ValidationRevisionRange(fromStr, toStr string) (int, int, error) {
+	if fromStr == "" || toStr == "" {
+		return 0, 0, errors.New("from and to are required")
+	}
+	from, err := ValidationRevision(fromStr)
+	if err != nil {
+		return 0, 0, errors.New("from must be a positive integer")
+	}
+	to, err := ValidationRevision(toStr)
+	if err != nil {
+		return 0, 0, errors.New("to must be a positive integer")
+	}
+	return from, to, nil
+}
+
+// ValidationFolderName проверяет название папки. Символ / зарезервирован для пути
+func ValidationFolderName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("name is required")
+	}
+	if len(name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if strings.Contains(name, "/") {
+		return errors.New("name must not contain /")
+	}
+	return nil
+}
+
+// ValidationNotePosition проверяет позицию заметки в папке
+func ValidationNotePosition(position int) error {
+	if position < 0 {
+		return errors.New("position must be non-negative")
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+
+	models "github.com/lzimin05/course-todo/internal/models/notification"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/notification"
+)
+
+func ValidationUpdatePreferences(req dto.UpdatePreferencesDTO) error {
+	if len(req) == 0 {
+		return errors.New("at least one notification type must be provided")
+	}
+	for notificationType := range req {
+		if !models.IsValidType(notificationType) {
+			return fmt.Errorf("unknown notification type: %s", notificationType)
+		}
+	}
+	return nil
+}
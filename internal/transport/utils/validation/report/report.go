@@ -0,0 +1,63 @@
+package validation
+
+import (
+	"errors"
+	"time"
+
+	models "github.com/lzimin05/course-todo/internal/models/report"
+)
+
+const (
+	DateLayout       = "2006-01-02"
+	defaultRangeDays = 30
+	maxBuckets       = 366
+)
+
+// ValidationReportRange разбирает параметры from, to и bucket отчета.
+// По умолчанию берутся последние 30 дней с разбивкой по дням.
+func ValidationReportRange(fromStr, toStr, bucket string, now time.Time) (time.Time, time.Time, string, error) {
+	if bucket == "" {
+		bucket = models.BucketDay
+	}
+	if bucket != models.BucketDay && bucket != models.BucketWeek {
+		return time.Time{}, time.Time{}, "", errors.New("bucket must be day or week")
+	}
+
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if toStr != "" {
+		parsed, err := time.Parse(DateLayout, toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, "", errors.New("to must be a date in YYYY-MM-DD format")
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultRangeDays - 1))
+	if fromStr != "" {
+		parsed, err := time.Parse(DateLayout, fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, "", errors.New("from must be a date in YYYY-MM-DD format")
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, "", errors.New("from must be before or equal to to")
+	}
+
+	// Недельные интервалы начинаются с понедельника
+	if bucket == models.BucketWeek {
+		from = from.AddDate(0, 0, -((int(from.Weekday()) + 6) % 7))
+		to = to.AddDate(0, 0, -((int(to.Weekday()) + 6) % 7))
+	}
+
+	days := int(to.Sub(from).Hours()/24) + 1
+	if bucket == models.BucketWeek {
+		days /= 7
+	}
+	if days > maxBuckets {
+		return time.Time{}, time.Time{}, "", errors.New("range is too large")
+	}
+
+	return from, to, bucket, nil
+}
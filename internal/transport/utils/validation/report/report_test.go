@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/report"
+)
+
+func TestValidationReportRange_Defaults(t *testing.T) {
+	now := time.Date(2025, 3, 15, 13, 45, 0, 0, time.UTC)
+
+	from, to, bucket, err := ValidationReportRange("", "", "", now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.BucketDay, bucket)
+	assert.Equal(t, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), to)
+	assert.Equal(t, time.Date(2025, 2, 14, 0, 0, 0, 0, time.UTC), from)
+}
+
+func TestValidationReportRange_WeekAlignsToMonday(t *testing.T) {
+	now := time.Now()
+
+	// 2025-03-13 — четверг, 2025-03-23 — воскресенье
+	from, to, bucket, err := ValidationReportRange("2025-03-13", "2025-03-23", "week", now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.BucketWeek, bucket)
+	assert.Equal(t, time.Monday, from.Weekday())
+	assert.Equal(t, "2025-03-10", from.Format(DateLayout))
+	assert.Equal(t, "2025-03-17", to.Format(DateLayout))
+}
+
+func TestValidationReportRange_Errors(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		bucket      string
+		expectedErr string
+	}{
+		{
+			name:        "unknown bucket",
+			bucket:      "month",
+			expectedErr: "bucket must be day or week",
+		},
+		{
+			name:        "invalid from",
+			from:        "01.01.2025",
+			to:          "2025-01-10",
+			expectedErr: "from must be a date",
+		},
+		{
+			name:        "invalid to",
+			to:          "tomorrow",
+			expectedErr: "to must be a date",
+		},
+		{
+			name:        "from after to",
+			from:        "2025-02-01",
+			to:          "2025-01-01",
+			expectedErr: "from must be before or equal to to",
+		},
+		{
+			name:        "range too large",
+			from:        "2020-01-01",
+			to:          "2025-01-01",
+			bucket:      "day",
+			expectedErr: "range is too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := ValidationReportRange(tt.from, tt.to, tt.bucket, now)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+func ValidationSearchQuery(query string) error {
+	if strings.TrimSpace(query) == "" {
+		return errors.New("q is required")
+	}
+	if utf8.RuneCountInString(query) > 200 {
+		return errors.New("q must be at most 200 characters")
+	}
+	return nil
+}
+
+// ValidationPagination разбирает limit и offset, подставляя значения по умолчанию
+func ValidationPagination(limitStr, offsetStr string) (int, int, error) {
+	limit := DefaultLimit
+	if limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > MaxLimit {
+			return 0, 0, errors.New("limit must be between 1 and 100")
+		}
+		limit = parsed
+	}
+
+	offset := 0
+	if offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			return 0, 0, errors.New("offset must be a non-negative integer")
+		}
+		offset = parsed
+	}
+
+	return limit, offset, nil
+}
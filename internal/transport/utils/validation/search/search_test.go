@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationSearchQuery(t *testing.T) {
+	assert.NoError(t, ValidationSearchQuery("отчет login"))
+	assert.Error(t, ValidationSearchQuery(""))
+	assert.Error(t, ValidationSearchQuery("   "))
+	assert.Error(t, ValidationSearchQuery(strings.Repeat("я", 201)))
+}
+
+func TestValidationPagination(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit          string
+		offset         string
+		expectedLimit  int
+		expectedOffset int
+		expectedErr    bool
+	}{
+		{name: "defaults", expectedLimit: DefaultLimit, expectedOffset: 0},
+		{name: "custom values", limit: "50", offset: "10", expectedLimit: 50, expectedOffset: 10},
+		{name: "limit too large", limit: "101", expectedErr: true},
+		{name: "limit not a number", limit: "abc", expectedErr: true},
+		{name: "negative offset", offset: "-1", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, offset, err := ValidationPagination(tt.limit, tt.offset)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLimit, limit)
+			assert.Equal(t, tt.expectedOffset, offset)
+		})
+	}
+}
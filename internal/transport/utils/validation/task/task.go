@@ -2,9 +2,14 @@ package validation
 
 import (
 	"errors"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
+// Оценка не больше 10000 часов
+const maxEstimateMinutes = 600000
+
 func ValidationTask(title string, importance int, deaadline time.Time) error {
 	if title == "" {
 		return errors.New("title is required")
@@ -23,3 +28,54 @@ func ValidationTask(title string, importance int, deaadline time.Time) error {
 
 	return nil
 }
+
+// ValidationEstimate проверяет оценку трудозатрат в минутах. nil - оценка не задана
+func ValidationEstimate(estimateMinutes *int) error {
+	if estimateMinutes == nil {
+		return nil
+	}
+	if *estimateMinutes < 0 {
+		return errors.New("estimate_minutes must be non-negative")
+	}
+	if *estimateMinutes > maxEstimateMinutes {
+		return errors.New("estimate_minutes is too large")
+	}
+	return nil
+}
+
+// ValidationTaskSchedule проверяет, что начало задачи не позже дедлайна.
+// Для задач на весь день сравниваются только даты
+func ValidationTaskSchedule(startAt *time.Time, deadline time.Time, allDay bool) error {
+	if startAt == nil || deadline.IsZero() {
+		return nil
+	}
+
+	start, end := *startAt, deadline
+	if allDay {
+		start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	if start.After(end) {
+		return errors.New("start_at must be at or before deadline")
+	}
+	return nil
+}
+
+// ValidationChecklistText проверяет текст пункта чек-листа
+func ValidationChecklistText(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return errors.New("text is required")
+	}
+	if utf8.RuneCountInString(text) > 500 {
+		return errors.New("text must be at most 500 characters")
+	}
+	return nil
+}
+
+// ValidationChecklistPosition проверяет позицию пункта в чек-листе
+func ValidationChecklistPosition(position int) error {
+	if position < 0 {
+		return errors.New("position must be non-negative")
+	}
+	return nil
+}
@@ -188,3 +188,29 @@ func TestValidationTask_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestValidationEstimate(t *testing.T) {
+	zero := 0
+	valid := 90
+	negative := -5
+	tooLarge := 600001
+
+	assert.NoError(t, ValidationEstimate(nil))
+	assert.NoError(t, ValidationEstimate(&zero))
+	assert.NoError(t, ValidationEstimate(&valid))
+	assert.EqualError(t, ValidationEstimate(&negative), "estimate_minutes must be non-negative")
+	assert.EqualError(t, ValidationEstimate(&tooLarge), "estimate_minutes is too large")
+}
+
+func TestValidationTaskSchedule(t *testing.T) {
+	deadline := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	before := deadline.Add(-48 * time.Hour)
+	sameDayLater := time.Date(2025, 3, 10, 18, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, ValidationTaskSchedule(nil, deadline, false))
+	assert.NoError(t, ValidationTaskSchedule(&before, deadline, false))
+	assert.NoError(t, ValidationTaskSchedule(&sameDayLater, time.Time{}, false))
+	assert.EqualError(t, ValidationTaskSchedule(&sameDayLater, deadline, false), "start_at must be at or before deadline")
+	// Для задачи на весь день время внутри дня не важно
+	assert.NoError(t, ValidationTaskSchedule(&sameDayLater, deadline, true))
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/timeentry"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+
+	maxNoteLength = 500
+	// Одна запись не длиннее суток
+	maxEntryDuration = 24 * time.Hour
+)
+
+func ValidationTimeEntryNote(note string) error {
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return errors.New("note must be at most 500 characters")
+	}
+	return nil
+}
+
+// ValidationTimeEntry проверяет запись задним числом: нужен ровно один из
+// ended_at и duration_minutes, запись не может закончиться в будущем
+func ValidationTimeEntry(req dto.CreateTimeEntryDTO, now time.Time) error {
+	if req.StartedAt.IsZero() {
+		return errors.New("started_at is required")
+	}
+	if (req.EndedAt == nil) == (req.DurationMinutes == nil) {
+		return errors.New("exactly one of ended_at and duration_minutes is required")
+	}
+
+	var duration time.Duration
+	if req.EndedAt != nil {
+		duration = req.EndedAt.Sub(req.StartedAt)
+	} else {
+		duration = time.Duration(*req.DurationMinutes) * time.Minute
+	}
+
+	if duration <= 0 {
+		return errors.New("time entry must have a positive duration")
+	}
+	if duration > maxEntryDuration {
+		return errors.New("time entry must be at most 24 hours long")
+	}
+	if req.StartedAt.Add(duration).After(now) {
+		return errors.New("time entry cannot end in the future")
+	}
+
+	return ValidationTimeEntryNote(req.Note)
+}
+
+// ValidationReportFormat возвращает формат отчета, по умолчанию json
+func ValidationReportFormat(format string) (string, error) {
+	switch format {
+	case "", FormatJSON:
+		return FormatJSON, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	default:
+		return "", errors.New("format must be json or csv")
+	}
+}
@@ -0,0 +1,93 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/timeentry"
+)
+
+func TestValidationTimeEntry(t *testing.T) {
+	now := time.Date(2025, 3, 15, 18, 0, 0, 0, time.UTC)
+	start := now.Add(-2 * time.Hour)
+	end := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	minutes := 45
+	zero := 0
+
+	tests := []struct {
+		name        string
+		req         dto.CreateTimeEntryDTO
+		expectedErr string
+	}{
+		{
+			name: "with ended_at",
+			req:  dto.CreateTimeEntryDTO{StartedAt: start, EndedAt: &end},
+		},
+		{
+			name: "with duration",
+			req:  dto.CreateTimeEntryDTO{StartedAt: start, DurationMinutes: &minutes},
+		},
+		{
+			name:        "missing start",
+			req:         dto.CreateTimeEntryDTO{DurationMinutes: &minutes},
+			expectedErr: "started_at is required",
+		},
+		{
+			name:        "both end and duration",
+			req:         dto.CreateTimeEntryDTO{StartedAt: start, EndedAt: &end, DurationMinutes: &minutes},
+			expectedErr: "exactly one of ended_at and duration_minutes is required",
+		},
+		{
+			name:        "neither end nor duration",
+			req:         dto.CreateTimeEntryDTO{StartedAt: start},
+			expectedErr: "exactly one of ended_at and duration_minutes is required",
+		},
+		{
+			name:        "zero duration",
+			req:         dto.CreateTimeEntryDTO{StartedAt: start, DurationMinutes: &zero},
+			expectedErr: "time entry must have a positive duration",
+		},
+		{
+			name:        "ends before start",
+			req:         dto.CreateTimeEntryDTO{StartedAt: end, EndedAt: &start},
+			expectedErr: "time entry must have a positive duration",
+		},
+		{
+			name:        "ends in the future",
+			req:         dto.CreateTimeEntryDTO{StartedAt: start, EndedAt: &future},
+			expectedErr: "time entry cannot end in the future",
+		},
+		{
+			name:        "longer than a day",
+			req:         dto.CreateTimeEntryDTO{StartedAt: now.Add(-30 * time.Hour), EndedAt: &end},
+			expectedErr: "time entry must be at most 24 hours long",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidationTimeEntry(tt.req, now)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidationReportFormat(t *testing.T) {
+	format, err := ValidationReportFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	format, err = ValidationReportFormat("csv")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	_, err = ValidationReportFormat("xlsx")
+	assert.EqualError(t, err, "format must be json or csv")
+}
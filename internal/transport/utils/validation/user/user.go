@@ -3,6 +3,7 @@ package validation
 import (
 	"errors"
 	"strings"
+	"time"
 
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/user"
 )
@@ -17,4 +18,19 @@ func ValidateUpdateUsernameRequest(req dto.UpdateUsernameRequest) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+func ValidateUpdateTimezoneRequest(req dto.UpdateTimezoneRequest) error {
+	if strings.TrimSpace(req.Timezone) == "" {
+		return errors.New("timezone is required")
+	}
+	// Local зависит от настроек сервера, поэтому явно запрещаем его
+	if req.Timezone == "Local" {
+		return errors.New("invalid timezone")
+	}
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return errors.New("invalid timezone")
+	}
+
+	return nil
+}
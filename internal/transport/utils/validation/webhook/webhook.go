@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	models "github.com/lzimin05/course-todo/internal/models/webhook"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/webhook"
+)
+
+const (
+	maxURLLength = 2048
+
+	DefaultDeliveriesLimit = 50
+	MaxDeliveriesLimit     = 200
+)
+
+// ValidationPostWebhook проверяет вебхук. allowPrivate разрешает адреса
+// во внутренних сетях, это нужно только для локальной отладки
+func ValidationPostWebhook(req *dto.PostWebhookDTO, allowPrivate bool) error {
+	if err := validationURL(req.URL, allowPrivate); err != nil {
+		return err
+	}
+	return validationEvents(req.Events)
+}
+
+func ValidationUpdateWebhook(req *dto.UpdateWebhookDTO, allowPrivate bool) error {
+	if req.URL == nil && req.Events == nil && req.IsActive == nil {
+		return errors.New("at least one field must be provided")
+	}
+	if req.URL != nil {
+		if err := validationURL(*req.URL, allowPrivate); err != nil {
+			return err
+		}
+	}
+	if req.Events != nil {
+		return validationEvents(req.Events)
+	}
+	return nil
+}
+
+// ValidationDeliveriesQuery проверяет фильтр журнала доставок
+func ValidationDeliveriesQuery(status, limitStr string) (int, error) {
+	if status != "" && status != models.DeliveryStatusPending &&
+		status != models.DeliveryStatusDelivered && status != models.DeliveryStatusFailed {
+		return 0, errors.New("status must be one of: pending, delivered, failed")
+	}
+
+	limit := DefaultDeliveriesLimit
+	if limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 || parsed > MaxDeliveriesLimit {
+			return 0, fmt.Errorf("limit must be between 1 and %d", MaxDeliveriesLimit)
+		}
+		limit = parsed
+	}
+
+	return limit, nil
+}
+
+func validationURL(rawURL string, allowPrivate bool) error {
+	if rawURL == "" {
+		return errors.New("url is required")
+	}
+	if len(rawURL) > maxURLLength {
+		return fmt.Errorf("url must be at most %d characters", maxURLLength)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if !allowPrivate && !models.IsPublicHost(u.Hostname()) {
+		return errors.New("url must not point to a private or local address")
+	}
+	return nil
+}
+
+func validationEvents(events []string) error {
+	if len(events) == 0 {
+		return errors.New("events must not be empty")
+	}
+	for i, event := range events {
+		if !eventmodels.IsValidType(event) {
+			return fmt.Errorf("unknown event type: %s", event)
+		}
+		for _, prev := range events[:i] {
+			if prev == event {
+				return errors.New("events must not contain duplicates")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/webhook"
+)
+
+func TestValidationPostWebhook(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         dto.PostWebhookDTO
+		expectedErr string
+	}{
+		{name: "valid", req: dto.PostWebhookDTO{URL: "https://example.com/hook", Events: []string{"task.created", "member.added"}}},
+		{name: "missing url", req: dto.PostWebhookDTO{Events: []string{"task.created"}}, expectedErr: "url is required"},
+		{name: "relative url", req: dto.PostWebhookDTO{URL: "/hook", Events: []string{"task.created"}}, expectedErr: "url must be an absolute http or https URL"},
+		{name: "ftp url", req: dto.PostWebhookDTO{URL: "ftp://example.com", Events: []string{"task.created"}}, expectedErr: "url must be an absolute http or https URL"},
+		{name: "no events", req: dto.PostWebhookDTO{URL: "https://example.com"}, expectedErr: "events must not be empty"},
+		{name: "unknown event", req: dto.PostWebhookDTO{URL: "https://example.com", Events: []string{"task.archived"}}, expectedErr: "unknown event type: task.archived"},
+		{name: "duplicate event", req: dto.PostWebhookDTO{URL: "https://example.com", Events: []string{"note.updated", "note.updated"}}, expectedErr: "events must not contain duplicates"},
+		{name: "loopback", req: dto.PostWebhookDTO{URL: "http://127.0.0.1:8080/hook", Events: []string{"task.created"}}, expectedErr: "url must not point to a private or local address"},
+		{name: "localhost", req: dto.PostWebhookDTO{URL: "http://LocalHost./hook", Events: []string{"task.created"}}, expectedErr: "url must not point to a private or local address"},
+		{name: "private network", req: dto.PostWebhookDTO{URL: "http://10.0.0.5/hook", Events: []string{"task.created"}}, expectedErr: "url must not point to a private or local address"},
+		{name: "cloud metadata", req: dto.PostWebhookDTO{URL: "http://169.254.169.254/latest/meta-data", Events: []string{"task.created"}}, expectedErr: "url must not point to a private or local address"},
+		{name: "metadata host", req: dto.PostWebhookDTO{URL: "http://metadata.google.internal/", Events: []string{"task.created"}}, expectedErr: "url must not point to a private or local address"},
+		{name: "ipv6 loopback", req: dto.PostWebhookDTO{URL: "http://[::1]/hook", Events: []string{"task.created"}}, expectedErr: "url must not point to a private or local address"},
+		{name: "ipv4-mapped private", req: dto.PostWebhookDTO{URL: "http://[::ffff:192.168.1.1]/hook", Events: []string{"task.created"}}, expectedErr: "url must not point to a private or local address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidationPostWebhook(&tt.req, false)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidationUpdateWebhook(t *testing.T) {
+	active := false
+	assert.NoError(t, ValidationUpdateWebhook(&dto.UpdateWebhookDTO{IsActive: &active}, false))
+	assert.EqualError(t, ValidationUpdateWebhook(&dto.UpdateWebhookDTO{}, false), "at least one field must be provided")
+
+	bad := "example.com"
+	assert.EqualError(t, ValidationUpdateWebhook(&dto.UpdateWebhookDTO{URL: &bad}, false), "url must be an absolute http or https URL")
+	assert.EqualError(t, ValidationUpdateWebhook(&dto.UpdateWebhookDTO{Events: []string{}}, false), "events must not be empty")
+
+	local := "http://localhost:9000/hook"
+	assert.EqualError(t, ValidationUpdateWebhook(&dto.UpdateWebhookDTO{URL: &local}, false), "url must not point to a private or local address")
+	assert.NoError(t, ValidationUpdateWebhook(&dto.UpdateWebhookDTO{URL: &local}, true))
+}
+
+func TestValidationDeliveriesQuery(t *testing.T) {
+	limit, err := ValidationDeliveriesQuery("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultDeliveriesLimit, limit)
+
+	limit, err = ValidationDeliveriesQuery("failed", "10")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, limit)
+
+	_, err = ValidationDeliveriesQuery("lost", "")
+	assert.EqualError(t, err, "status must be one of: pending, delivered, failed")
+
+	_, err = ValidationDeliveriesQuery("", "1000")
+	assert.EqualError(t, err, "limit must be between 1 and 200")
+}
@@ -0,0 +1,310 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/lzimin05/course-todo/config"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/webhook"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/transport/utils/handler"
+	response "github.com/lzimin05/course-todo/internal/transport/utils/response"
+	validation "github.com/lzimin05/course-todo/internal/transport/utils/validation/webhook"
+)
+
+//go:generate mockgen -source=webhook.go -destination=../../usecase/mocks/webhook_usecase_mock.go -package=mocks WebhookUsecase
+type WebhookUsecase interface {
+	CreateWebhook(ctx context.Context, projectID uuid.UUID, req *dto.PostWebhookDTO) (*dto.WebhookDTO, error)
+	GetWebhooks(ctx context.Context, projectID uuid.UUID) ([]*dto.WebhookDTO, error)
+	UpdateWebhook(ctx context.Context, projectID, webhookID uuid.UUID, req *dto.UpdateWebhookDTO) (*dto.WebhookDTO, error)
+	DeleteWebhook(ctx context.Context, projectID, webhookID uuid.UUID) error
+	GetDeliveries(ctx context.Context, projectID, webhookID uuid.UUID, status string, limit int) ([]*dto.DeliveryDTO, error)
+	Redeliver(ctx context.Context, projectID, webhookID, deliveryID uuid.UUID) (*dto.DeliveryDTO, error)
+}
+
+type WebhookHandler struct {
+	uc     WebhookUsecase
+	config *config.Config
+}
+
+func New(uc WebhookUsecase, cfg *config.Config) *WebhookHandler {
+	return &WebhookHandler{
+		uc:     uc,
+		config: cfg,
+	}
+}
+
+// CreateWebhook регистрирует вебхук проекта
+// @Summary      Создать вебхук
+// @Description  Регистрирует адрес, на который POST-запросами отправляются события проекта. Доступно только владельцу проекта. Каждый запрос подписывается: заголовок X-Webhook-Signature содержит "sha256=" и HMAC-SHA256 секрета от строки "<X-Webhook-Timestamp>.<тело запроса>". Секрет возвращается только в этом ответе
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        projectId  path  string  true  "ID проекта"
+// @Param        webhook    body  dto.PostWebhookDTO  true  "Адрес и типы событий: task.created, task.updated, task.status_changed, task.completed, task.deleted, checklist.assigned, note.created, note.updated, note.deleted, member.added, member.removed"
+// @Success      201  {object} dto.WebhookDTO "Вебхук создан"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Недостаточно прав"
+// @Failure      404  {object} dto.ErrorResponse "Проект не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.CreateWebhook"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid project ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	var req dto.PostWebhookDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode webhook")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validation.ValidationPostWebhook(&req, h.config.WebhookConfig.AllowPrivateNetworks); err != nil {
+		logger.Warn("webhook validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.uc.CreateWebhook(r.Context(), projectID, &req)
+	if err != nil {
+		logger.WithError(err).Error("failed to create webhook")
+		handler.HandleError(r.Context(), w, err, "Failed to create webhook")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusCreated, webhook)
+}
+
+// GetWebhooks возвращает вебхуки проекта
+// @Summary      Получить вебхуки проекта
+// @Description  Возвращает вебхуки проекта без секретов. Доступно только владельцу проекта
+// @Tags         webhooks
+// @Produce      json
+// @Param        projectId  path  string  true  "ID проекта"
+// @Success      200  {array}  dto.WebhookDTO "Список вебхуков"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Недостаточно прав"
+// @Failure      404  {object} dto.ErrorResponse "Проект не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/webhooks [get]
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.GetWebhooks"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid project ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	webhooks, err := h.uc.GetWebhooks(r.Context(), projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get webhooks")
+		handler.HandleError(r.Context(), w, err, "Failed to get webhooks")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, webhooks)
+}
+
+// UpdateWebhook изменяет вебхук
+// @Summary      Изменить вебхук
+// @Description  Меняет адрес, подписку на события или включает и отключает вебхук. Передаются только изменяемые поля. Доставки отключенного вебхука остаются в очереди до его включения
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        projectId  path  string  true  "ID проекта"
+// @Param        webhookId  path  string  true  "ID вебхука"
+// @Param        webhook    body  dto.UpdateWebhookDTO  true  "Изменяемые поля"
+// @Success      200  {object} dto.WebhookDTO "Вебхук изменен"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Недостаточно прав"
+// @Failure      404  {object} dto.ErrorResponse "Вебхук не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/webhooks/{webhookId} [patch]
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.UpdateWebhook"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, webhookID, ok := parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.WithError(err).Warn("failed to decode webhook")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid request")
+		return
+	}
+
+	if err := validation.ValidationUpdateWebhook(&req, h.config.WebhookConfig.AllowPrivateNetworks); err != nil {
+		logger.Warn("webhook validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	webhook, err := h.uc.UpdateWebhook(r.Context(), projectID, webhookID, &req)
+	if err != nil {
+		logger.WithError(err).Error("failed to update webhook")
+		handler.HandleError(r.Context(), w, err, "Failed to update webhook")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, webhook)
+}
+
+// DeleteWebhook удаляет вебхук
+// @Summary      Удалить вебхук
+// @Description  Удаляет вебхук вместе с очередью и журналом его доставок
+// @Tags         webhooks
+// @Param        projectId  path  string  true  "ID проекта"
+// @Param        webhookId  path  string  true  "ID вебхука"
+// @Success      204  "Вебхук удален"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Недостаточно прав"
+// @Failure      404  {object} dto.ErrorResponse "Вебхук не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/webhooks/{webhookId} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.DeleteWebhook"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, webhookID, ok := parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.DeleteWebhook(r.Context(), projectID, webhookID); err != nil {
+		logger.WithError(err).Error("failed to delete webhook")
+		handler.HandleError(r.Context(), w, err, "Failed to delete webhook")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeliveries возвращает журнал доставок вебхука
+// @Summary      Журнал доставок вебхука
+// @Description  Возвращает доставки вебхука, новые сначала: статус, число попыток, код последнего ответа, ошибку и время следующей попытки. Неудачные доставки повторяются с экспоненциально растущей паузой, после исчерпания попыток получают статус failed
+// @Tags         webhooks
+// @Produce      json
+// @Param        projectId  path   string  true   "ID проекта"
+// @Param        webhookId  path   string  true   "ID вебхука"
+// @Param        status     query  string  false  "Статус доставки: pending, delivered, failed"
+// @Param        limit      query  int     false  "Количество записей (1-200, по умолчанию 50)"
+// @Success      200  {array}  dto.DeliveryDTO "Журнал доставок"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Недостаточно прав"
+// @Failure      404  {object} dto.ErrorResponse "Вебхук не найден"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/webhooks/{webhookId}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.GetDeliveries"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, webhookID, ok := parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	limit, err := validation.ValidationDeliveriesQuery(status, r.URL.Query().Get("limit"))
+	if err != nil {
+		logger.Warn("deliveries query validation failed: ", err.Error())
+		response.SendError(r.Context(), w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deliveries, err := h.uc.GetDeliveries(r.Context(), projectID, webhookID, status, limit)
+	if err != nil {
+		logger.WithError(err).Error("failed to get deliveries")
+		handler.HandleError(r.Context(), w, err, "Failed to get deliveries")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusOK, deliveries)
+}
+
+// Redeliver повторно отправляет событие
+// @Summary      Повторить доставку
+// @Description  Ставит событие из журнала в очередь еще раз. Создается новая доставка с тем же X-Webhook-Event-Id, по которому получатель может отбросить дубль
+// @Tags         webhooks
+// @Produce      json
+// @Param        projectId   path  string  true  "ID проекта"
+// @Param        webhookId   path  string  true  "ID вебхука"
+// @Param        deliveryId  path  string  true  "ID доставки"
+// @Success      202  {object} dto.DeliveryDTO "Доставка поставлена в очередь"
+// @Failure      400  {object} dto.ErrorResponse "Неверный запрос"
+// @Failure      401  {object} dto.ErrorResponse "Пользователь не авторизован"
+// @Failure      403  {object} dto.ErrorResponse "Недостаточно прав"
+// @Failure      404  {object} dto.ErrorResponse "Доставка не найдена"
+// @Failure      500  {object} dto.ErrorResponse "Внутренняя ошибка сервера"
+// @Security     BearerAuth
+// @Router       /projects/{projectId}/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	const op = "WebhookHandler.Redeliver"
+	logger := logctx.GetLogger(r.Context()).WithField("op", op)
+
+	projectID, webhookID, ok := parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := uuid.Parse(mux.Vars(r)["deliveryId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid delivery ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid delivery ID")
+		return
+	}
+
+	delivery, err := h.uc.Redeliver(r.Context(), projectID, webhookID, deliveryID)
+	if err != nil {
+		logger.WithError(err).Error("failed to redeliver")
+		handler.HandleError(r.Context(), w, err, "Failed to redeliver")
+		return
+	}
+
+	response.SendJSONResponse(r.Context(), w, http.StatusAccepted, delivery)
+}
+
+func parseIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	logger := logctx.GetLogger(r.Context())
+
+	projectID, err := uuid.Parse(mux.Vars(r)["projectId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid project ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid project ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	webhookID, err := uuid.Parse(mux.Vars(r)["webhookId"])
+	if err != nil {
+		logger.WithError(err).Warn("invalid webhook ID")
+		response.SendError(r.Context(), w, http.StatusBadRequest, "Invalid webhook ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return projectID, webhookID, true
+}
@@ -0,0 +1,175 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/webhook"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestWebhookTransport_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockWebhookUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{WebhookConfig: &config.WebhookConfig{}})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/projects/{projectId}/webhooks", handler.CreateWebhook).Methods(http.MethodPost)
+
+	projectID := uuid.New()
+
+	tests := []struct {
+		name       string
+		body       string
+		mockFunc   func()
+		statusCode int
+		contains   string
+	}{
+		{
+			name: "Created",
+			body: `{"url":"https://example.com/hook","events":["task.created","member.added"]}`,
+			mockFunc: func() {
+				mockUsecase.EXPECT().CreateWebhook(gomock.Any(), projectID, &dto.PostWebhookDTO{
+					URL:    "https://example.com/hook",
+					Events: []string{"task.created", "member.added"},
+				}).Return(&dto.WebhookDTO{ID: uuid.New(), ProjectID: projectID, Secret: "s3cr3t"}, nil)
+			},
+			statusCode: http.StatusCreated,
+			contains:   `"secret":"s3cr3t"`,
+		},
+		{
+			name:       "Unknown event",
+			body:       `{"url":"https://example.com/hook","events":["task.archived"]}`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+			contains:   "unknown event type: task.archived",
+		},
+		{
+			name: "Not owner",
+			body: `{"url":"https://example.com/hook","events":["note.updated"]}`,
+			mockFunc: func() {
+				mockUsecase.EXPECT().CreateWebhook(gomock.Any(), projectID, gomock.Any()).Return(nil, errs.ErrNotOwner)
+			},
+			statusCode: http.StatusForbidden,
+		},
+		{
+			name:       "Invalid body",
+			body:       `{"url":`,
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodPost, "/projects/"+projectID.String()+"/webhooks", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), domains.UserIDKey{}, uuid.New().String()))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.contains)
+		})
+	}
+}
+
+func TestWebhookTransport_GetDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockWebhookUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{WebhookConfig: &config.WebhookConfig{}})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/projects/{projectId}/webhooks/{webhookId}/deliveries", handler.GetDeliveries).Methods(http.MethodGet)
+
+	projectID := uuid.New()
+	webhookID := uuid.New()
+	path := "/projects/" + projectID.String() + "/webhooks/" + webhookID.String() + "/deliveries"
+
+	tests := []struct {
+		name       string
+		query      string
+		mockFunc   func()
+		statusCode int
+	}{
+		{
+			name:  "Failed deliveries",
+			query: "?status=failed&limit=10",
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetDeliveries(gomock.Any(), projectID, webhookID, "failed", 10).
+					Return([]*dto.DeliveryDTO{{ID: uuid.New(), Status: "failed", Payload: []byte(`{}`)}}, nil)
+			},
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Unknown status",
+			query:      "?status=lost",
+			mockFunc:   func() {},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Webhook not found",
+			mockFunc: func() {
+				mockUsecase.EXPECT().GetDeliveries(gomock.Any(), projectID, webhookID, "", 50).
+					Return(nil, errs.NewNotFoundError("webhook not found"))
+			},
+			statusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+
+			req := httptest.NewRequest(http.MethodGet, path+tt.query, nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.statusCode, rr.Code)
+		})
+	}
+}
+
+func TestWebhookTransport_Redeliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockWebhookUsecase(ctrl)
+	handler := New(mockUsecase, &config.Config{WebhookConfig: &config.WebhookConfig{}})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/projects/{projectId}/webhooks/{webhookId}/deliveries/{deliveryId}/redeliver", handler.Redeliver).
+		Methods(http.MethodPost)
+
+	projectID := uuid.New()
+	webhookID := uuid.New()
+	deliveryID := uuid.New()
+
+	mockUsecase.EXPECT().Redeliver(gomock.Any(), projectID, webhookID, deliveryID).
+		Return(&dto.DeliveryDTO{ID: uuid.New(), Status: "pending", Payload: []byte(`{}`)}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/projects/"+projectID.String()+"/webhooks/"+webhookID.String()+
+		"/deliveries/"+deliveryID.String()+"/redeliver", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"status":"pending"`)
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/account"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	exportModels "github.com/lzimin05/course-todo/internal/models/export"
+	userModels "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/account"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+	"golang.org/x/crypto/bcrypt"
+)
+
+//go:generate mockgen -source=account.go -destination=../mocks/account_mocks.go -package=mocks AccountRepository,AccountUserRepository,AccountProjectRepository,AccountProjectExporter,AccountSessionRevoker,AccountAttachmentCleaner
+type AccountRepository interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*models.Profile, error)
+	GetProjects(ctx context.Context, userID uuid.UUID) ([]models.Project, error)
+	GetTimeEntries(ctx context.Context, userID uuid.UUID) ([]models.TimeEntry, error)
+	DeleteAccount(ctx context.Context, deletion models.Deletion, now time.Time) error
+}
+
+type AccountUserRepository interface {
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*userModels.User, error)
+}
+
+type AccountProjectRepository interface {
+	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
+}
+
+// AccountProjectExporter выгружает проект целиком в формате повторного импорта
+type AccountProjectExporter interface {
+	ExportProject(ctx context.Context, projectID uuid.UUID, format string, w io.Writer) error
+}
+
+// AccountSessionRevoker отзывает все выпущенные токены пользователя
+type AccountSessionRevoker interface {
+	RevokeSessions(ctx context.Context, userID string, before time.Time) error
+}
+
+// AccountAttachmentCleaner удаляет из хранилища файлы вложений удаленных проектов
+type AccountAttachmentCleaner interface {
+	PurgeDeleted(ctx context.Context) error
+}
+
+// AccountUsecase выгружает данные пользователя и удаляет аккаунт
+type AccountUsecase struct {
+	repo        AccountRepository
+	userRepo    AccountUserRepository
+	projectRepo AccountProjectRepository
+	exporter    AccountProjectExporter
+	sessions    AccountSessionRevoker
+	attachments AccountAttachmentCleaner
+}
+
+func New(repo AccountRepository, userRepo AccountUserRepository, projectRepo AccountProjectRepository,
+	exporter AccountProjectExporter, sessions AccountSessionRevoker, attachments AccountAttachmentCleaner) *AccountUsecase {
+	return &AccountUsecase{
+		repo:        repo,
+		userRepo:    userRepo,
+		projectRepo: projectRepo,
+		exporter:    exporter,
+		sessions:    sessions,
+		attachments: attachments,
+	}
+}
+
+// ExportAccount пишет в w zip-архив с профилем, списком проектов, выгрузкой
+// каждого проекта в JSON и записями учета времени. Профиль и списки читаются
+// до первой записи в w, поэтому их ошибки возвращаются обычным ответом
+func (uc *AccountUsecase) ExportAccount(ctx context.Context, w io.Writer) error {
+	const op = "AccountUsecase.ExportAccount"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return err
+	}
+
+	profile, err := uc.repo.GetProfile(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get profile")
+		return err
+	}
+	projects, err := uc.repo.GetProjects(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get projects")
+		return err
+	}
+	entries, err := uc.repo.GetTimeEntries(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get time entries")
+		return err
+	}
+
+	now := time.Now().UTC()
+	archive := &zipArchive{zw: zip.NewWriter(w), modified: now}
+
+	if err := archive.writeJSON("profile.json", dto.AccountProfileDTO{
+		ID:         profile.ID,
+		Login:      profile.Login,
+		Username:   profile.Username,
+		Email:      profile.Email,
+		Timezone:   profile.Timezone,
+		CreatedAt:  profile.CreatedAt.UTC(),
+		ExportedAt: now,
+	}); err != nil {
+		logger.WithError(err).Error("failed to write profile")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	projectDTOs := make([]dto.AccountProjectDTO, 0, len(projects))
+	for _, p := range projects {
+		projectDTOs = append(projectDTOs, dto.AccountProjectDTO{
+			ID:   p.ID,
+			Name: p.Name,
+			Role: p.Role,
+			File: projectFile(p.ID),
+		})
+	}
+	if err := archive.writeJSON("projects.json", projectDTOs); err != nil {
+		logger.WithError(err).Error("failed to write projects")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, p := range projects {
+		f, err := archive.create(projectFile(p.ID))
+		if err != nil {
+			logger.WithError(err).Error("failed to add project file")
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if err := uc.exporter.ExportProject(ctx, p.ID, exportModels.FormatJSON, f); err != nil {
+			logger.WithError(err).WithField("projectID", p.ID).Error("failed to export project")
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	entryDTOs := make([]dto.AccountTimeEntryDTO, 0, len(entries))
+	for _, e := range entries {
+		entryDTOs = append(entryDTOs, toTimeEntryDTO(e))
+	}
+	if err := archive.writeJSON("time_entries.json", entryDTOs); err != nil {
+		logger.WithError(err).Error("failed to write time entries")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := archive.zw.Close(); err != nil {
+		logger.WithError(err).Error("failed to finish archive")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteAccount удаляет аккаунт после повторной проверки пароля. Сессии
+// отзываются до удаления: если удаление не удастся, пользователь просто
+// войдет заново, а обратный порядок оставил бы рабочие токены удаленного аккаунта
+func (uc *AccountUsecase) DeleteAccount(ctx context.Context, req dto.DeleteAccountDTO) error {
+	const op = "AccountUsecase.DeleteAccount"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return err
+	}
+	logger = logger.WithField("userID", userID)
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get user")
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword(user.PasswordHash, []byte(req.Password)); err != nil {
+		logger.Warn("invalid password")
+		return errs.ErrInvalidCredentials
+	}
+
+	projects, err := uc.repo.GetProjects(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get projects")
+		return err
+	}
+	deletion, err := uc.buildDeletion(ctx, userID, projects, req.Projects)
+	if err != nil {
+		logger.WithError(err).Warn("invalid owned projects decision")
+		return err
+	}
+
+	now := time.Now().UTC()
+	if err := uc.sessions.RevokeSessions(ctx, userID.String(), now); err != nil {
+		logger.WithError(err).Error("failed to revoke sessions")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := uc.repo.DeleteAccount(ctx, deletion, now); err != nil {
+		logger.WithError(err).Error("failed to delete account")
+		return err
+	}
+
+	if len(deletion.Deletes) > 0 {
+		if err := uc.attachments.PurgeDeleted(ctx); err != nil {
+			logger.WithError(err).Warn("failed to purge attachment files")
+		}
+	}
+
+	logger.WithField("transferred", len(deletion.Transfers)).
+		WithField("deleted", len(deletion.Deletes)).
+		Info("account deleted")
+	return nil
+}
+
+// buildDeletion сопоставляет решения из запроса с проектами, которыми владеет
+// пользователь: решение нужно по каждому такому проекту и только по ним
+func (uc *AccountUsecase) buildDeletion(ctx context.Context, userID uuid.UUID, projects []models.Project, actions []dto.OwnedProjectActionDTO) (models.Deletion, error) {
+	deletion := models.Deletion{UserID: userID}
+
+	owned := make(map[uuid.UUID]bool)
+	for _, p := range projects {
+		if p.OwnerID == userID {
+			owned[p.ID] = true
+		}
+	}
+
+	for _, a := range actions {
+		if !owned[a.ProjectID] {
+			return deletion, fmt.Errorf("%w: %s", errs.ErrNotOwner, a.ProjectID)
+		}
+		if a.Action == models.ActionDelete {
+			deletion.Deletes = append(deletion.Deletes, a.ProjectID)
+			continue
+		}
+
+		newOwnerID := *a.NewOwnerID
+		if newOwnerID == userID || newOwnerID == userModels.DeletedUserID {
+			return deletion, errs.ErrNewOwnerNotMember
+		}
+		isMember, err := uc.projectRepo.CheckProjectAccess(ctx, a.ProjectID, newOwnerID)
+		if err != nil {
+			return deletion, err
+		}
+		if !isMember {
+			return deletion, errs.ErrNewOwnerNotMember
+		}
+		deletion.Transfers = append(deletion.Transfers, models.Transfer{ProjectID: a.ProjectID, NewOwnerID: newOwnerID})
+	}
+
+	var unresolved []string
+	for _, p := range projects {
+		if owned[p.ID] && !deletion.Covers(p.ID) {
+			unresolved = append(unresolved, p.ID.String())
+		}
+	}
+	if len(unresolved) > 0 {
+		return deletion, fmt.Errorf("%w: %s", errs.ErrProjectsUnresolved, strings.Join(unresolved, ", "))
+	}
+
+	return deletion, nil
+}
+
+// zipArchive добавляет файлы в архив с одинаковым временем изменения
+type zipArchive struct {
+	zw       *zip.Writer
+	modified time.Time
+}
+
+func (a *zipArchive) create(name string) (io.Writer, error) {
+	return a.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: a.modified,
+	})
+}
+
+func (a *zipArchive) writeJSON(name string, v any) error {
+	f, err := a.create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func projectFile(projectID uuid.UUID) string {
+	return "projects/" + projectID.String() + ".json"
+}
+
+func toTimeEntryDTO(e models.TimeEntry) dto.AccountTimeEntryDTO {
+	var endedAt *time.Time
+	if e.EndedAt != nil {
+		t := e.EndedAt.UTC()
+		endedAt = &t
+	}
+	return dto.AccountTimeEntryDTO{
+		ID:        e.ID,
+		TaskID:    e.TaskID,
+		TaskTitle: e.TaskTitle,
+		ProjectID: e.ProjectID,
+		StartedAt: e.StartedAt.UTC(),
+		EndedAt:   endedAt,
+		Note:      e.Note,
+	}
+}
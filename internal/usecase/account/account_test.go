@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	models "github.com/lzimin05/course-todo/internal/models/account"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	userModels "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/account"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+type accountMocks struct {
+	repo        *mocks.MockAccountRepository
+	userRepo    *mocks.MockAccountUserRepository
+	projectRepo *mocks.MockAccountProjectRepository
+	exporter    *mocks.MockAccountProjectExporter
+	sessions    *mocks.MockAccountSessionRevoker
+	attachments *mocks.MockAccountAttachmentCleaner
+}
+
+func newTestUsecase(ctrl *gomock.Controller) (*AccountUsecase, accountMocks) {
+	m := accountMocks{
+		repo:        mocks.NewMockAccountRepository(ctrl),
+		userRepo:    mocks.NewMockAccountUserRepository(ctrl),
+		projectRepo: mocks.NewMockAccountProjectRepository(ctrl),
+		exporter:    mocks.NewMockAccountProjectExporter(ctrl),
+		sessions:    mocks.NewMockAccountSessionRevoker(ctrl),
+		attachments: mocks.NewMockAccountAttachmentCleaner(ctrl),
+	}
+	return New(m.repo, m.userRepo, m.projectRepo, m.exporter, m.sessions, m.attachments), m
+}
+
+func TestAccountUsecase_ExportAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, m := newTestUsecase(ctrl)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	m.repo.EXPECT().GetProfile(gomock.Any(), userID).
+		Return(&models.Profile{ID: userID, Login: "ivan", Email: "ivan@example.com", CreatedAt: time.Now()}, nil)
+	m.repo.EXPECT().GetProjects(gomock.Any(), userID).
+		Return([]models.Project{{ID: projectID, Name: "Работа", Role: "owner", OwnerID: userID}}, nil)
+	m.repo.EXPECT().GetTimeEntries(gomock.Any(), userID).
+		Return([]models.TimeEntry{{ID: uuid.New(), ProjectID: projectID, TaskTitle: "Макет", StartedAt: time.Now()}}, nil)
+	m.exporter.EXPECT().ExportProject(gomock.Any(), projectID, "json", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _ string, w io.Writer) error {
+			_, err := w.Write([]byte(`{"format":"course-todo-export"}`))
+			return err
+		})
+
+	var buf bytes.Buffer
+	require.NoError(t, uc.ExportAccount(ctx, &buf))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+	}
+
+	projectFile := "projects/" + projectID.String() + ".json"
+	assert.Len(t, files, 4)
+	assert.Equal(t, `{"format":"course-todo-export"}`, string(files[projectFile]))
+
+	var profile dto.AccountProfileDTO
+	require.NoError(t, json.Unmarshal(files["profile.json"], &profile))
+	assert.Equal(t, "ivan", profile.Login)
+
+	var projects []dto.AccountProjectDTO
+	require.NoError(t, json.Unmarshal(files["projects.json"], &projects))
+	require.Len(t, projects, 1)
+	assert.Equal(t, projectFile, projects[0].File)
+
+	var entries []dto.AccountTimeEntryDTO
+	require.NoError(t, json.Unmarshal(files["time_entries.json"], &entries))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Макет", entries[0].TaskTitle)
+}
+
+func TestAccountUsecase_ExportAccount_NothingWrittenOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, m := newTestUsecase(ctrl)
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	m.repo.EXPECT().GetProfile(gomock.Any(), userID).Return(&models.Profile{ID: userID}, nil)
+	m.repo.EXPECT().GetProjects(gomock.Any(), userID).Return(nil, errors.New("db error"))
+
+	var buf bytes.Buffer
+	assert.Error(t, uc.ExportAccount(ctx, &buf))
+	assert.Zero(t, buf.Len())
+}
+
+func TestAccountUsecase_DeleteAccount(t *testing.T) {
+	userID := uuid.New()
+	memberID := uuid.New()
+	sharedID := uuid.New()
+	personalID := uuid.New()
+	joinedID := uuid.New()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	projects := []models.Project{
+		{ID: sharedID, Role: "owner", OwnerID: userID},
+		{ID: personalID, Role: "owner", OwnerID: userID},
+		{ID: joinedID, Role: "member", OwnerID: memberID},
+	}
+	valid := []dto.OwnedProjectActionDTO{
+		{ProjectID: sharedID, Action: models.ActionTransfer, NewOwnerID: &memberID},
+		{ProjectID: personalID, Action: models.ActionDelete},
+	}
+	deletedUserID := userModels.DeletedUserID
+	redisErr := errors.New("redis error")
+
+	tests := []struct {
+		name        string
+		req         dto.DeleteAccountDTO
+		setupMocks  func(m accountMocks)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			req:  dto.DeleteAccountDTO{Password: "secret", Projects: valid},
+			setupMocks: func(m accountMocks) {
+				m.repo.EXPECT().GetProjects(gomock.Any(), userID).Return(projects, nil)
+				m.projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), sharedID, memberID).Return(true, nil)
+				gomock.InOrder(
+					m.sessions.EXPECT().RevokeSessions(gomock.Any(), userID.String(), gomock.Any()).Return(nil),
+					m.repo.EXPECT().DeleteAccount(gomock.Any(), models.Deletion{
+						UserID:    userID,
+						Transfers: []models.Transfer{{ProjectID: sharedID, NewOwnerID: memberID}},
+						Deletes:   []uuid.UUID{personalID},
+					}, gomock.Any()).Return(nil),
+					m.attachments.EXPECT().PurgeDeleted(gomock.Any()).Return(errors.New("storage error")),
+				)
+			},
+		},
+		{
+			name:        "wrong password",
+			req:         dto.DeleteAccountDTO{Password: "wrong", Projects: valid},
+			setupMocks:  func(m accountMocks) {},
+			expectedErr: errs.ErrInvalidCredentials,
+		},
+		{
+			name: "owned project without decision",
+			req:  dto.DeleteAccountDTO{Password: "secret", Projects: valid[1:]},
+			setupMocks: func(m accountMocks) {
+				m.repo.EXPECT().GetProjects(gomock.Any(), userID).Return(projects, nil)
+			},
+			expectedErr: errs.ErrProjectsUnresolved,
+		},
+		{
+			name: "decision for foreign project",
+			req: dto.DeleteAccountDTO{Password: "secret", Projects: append(valid[:2:2],
+				dto.OwnedProjectActionDTO{ProjectID: joinedID, Action: models.ActionDelete})},
+			setupMocks: func(m accountMocks) {
+				m.repo.EXPECT().GetProjects(gomock.Any(), userID).Return(projects, nil)
+				m.projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), sharedID, memberID).Return(true, nil)
+			},
+			expectedErr: errs.ErrNotOwner,
+		},
+		{
+			name: "new owner is not a member",
+			req:  dto.DeleteAccountDTO{Password: "secret", Projects: valid},
+			setupMocks: func(m accountMocks) {
+				m.repo.EXPECT().GetProjects(gomock.Any(), userID).Return(projects, nil)
+				m.projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), sharedID, memberID).Return(false, nil)
+			},
+			expectedErr: errs.ErrNewOwnerNotMember,
+		},
+		{
+			name: "transfer to deleted user placeholder",
+			req: dto.DeleteAccountDTO{Password: "secret", Projects: []dto.OwnedProjectActionDTO{
+				{ProjectID: sharedID, Action: models.ActionTransfer, NewOwnerID: &deletedUserID},
+				{ProjectID: personalID, Action: models.ActionDelete},
+			}},
+			setupMocks: func(m accountMocks) {
+				m.repo.EXPECT().GetProjects(gomock.Any(), userID).Return(projects, nil)
+			},
+			expectedErr: errs.ErrNewOwnerNotMember,
+		},
+		{
+			name: "redis unavailable",
+			req:  dto.DeleteAccountDTO{Password: "secret", Projects: valid},
+			setupMocks: func(m accountMocks) {
+				m.repo.EXPECT().GetProjects(gomock.Any(), userID).Return(projects, nil)
+				m.projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), sharedID, memberID).Return(true, nil)
+				m.sessions.EXPECT().RevokeSessions(gomock.Any(), userID.String(), gomock.Any()).Return(redisErr)
+			},
+			expectedErr: redisErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uc, m := newTestUsecase(ctrl)
+			m.userRepo.EXPECT().GetUserByID(gomock.Any(), userID).
+				Return(&userModels.User{ID: userID, PasswordHash: hash}, nil)
+			tt.setupMocks(m)
+
+			err := uc.DeleteAccount(ctx, tt.req)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/attachment"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/attachment"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const (
+	// Сколько байт смотрит http.DetectContentType
+	sniffLength = 512
+
+	maxFileNameLength = 255
+
+	// Сколько ключей удаленных файлов обрабатывается за один проход очистки
+	purgeBatchSize = 100
+)
+
+//go:generate mockgen -source=attachment.go -destination=../mocks/attachment_mocks.go -package=mocks AttachmentRepository,AttachmentProjectRepository,BlobStorage
+type AttachmentRepository interface {
+	GetParentProject(ctx context.Context, parentType string, parentID, userID uuid.UUID) (uuid.UUID, error)
+	GetProjectUsage(ctx context.Context, projectID uuid.UUID) (int64, error)
+	CreateAttachment(ctx context.Context, attachment *models.Attachment, quota int64) error
+	GetAttachmentsByParent(ctx context.Context, parentType string, parentID, userID uuid.UUID) ([]models.Attachment, error)
+	GetAttachmentByID(ctx context.Context, attachmentID, userID uuid.UUID) (*models.Attachment, error)
+	DeleteAttachment(ctx context.Context, attachmentID, userID uuid.UUID) error
+	GetDeletedBlobs(ctx context.Context, limit int) ([]string, error)
+	RemoveDeletedBlobs(ctx context.Context, keys []string) error
+}
+
+type AttachmentProjectRepository interface {
+	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
+}
+
+// BlobStorage хранит содержимое файлов. Реализации: локальный диск и S3-совместимое хранилище
+type BlobStorage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key, fileName, contentType string, ttl time.Duration) (string, error)
+}
+
+type AttachmentUsecase struct {
+	repo        AttachmentRepository
+	projectRepo AttachmentProjectRepository
+	storage     BlobStorage
+	conf        *config.StorageConfig
+}
+
+func New(repo AttachmentRepository, projectRepo AttachmentProjectRepository, storage BlobStorage, conf *config.StorageConfig) *AttachmentUsecase {
+	return &AttachmentUsecase{
+		repo:        repo,
+		projectRepo: projectRepo,
+		storage:     storage,
+		conf:        conf,
+	}
+}
+
+// Upload сохраняет файл потоком, не читая его целиком в память. Тип содержимого
+// определяется по первым байтам файла, заголовок клиента не учитывается
+func (u *AttachmentUsecase) Upload(ctx context.Context, parentType string, parentID uuid.UUID, fileName string, content io.Reader) (*dto.AttachmentDTO, error) {
+	const op = "AttachmentUsecase.Upload"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("parentType", parentType).
+		WithField("parentID", parentID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	projectID, err := u.repo.GetParentProject(ctx, parentType, parentID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get parent project")
+		return nil, err
+	}
+
+	used, err := u.repo.GetProjectUsage(ctx, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get project usage")
+		return nil, err
+	}
+
+	// Файл обрывается на том лимите, который наступит раньше
+	limit, limitErr := u.conf.MaxFileSize, errs.ErrFileTooLarge
+	if remaining := u.conf.ProjectQuota - used; remaining < limit {
+		limit, limitErr = remaining, errs.ErrQuotaExceeded
+	}
+	if limit <= 0 {
+		logger.Warn("project storage quota exceeded")
+		return nil, errs.ErrQuotaExceeded
+	}
+
+	buffered := bufio.NewReaderSize(content, sniffLength)
+	head, err := buffered.Peek(sniffLength)
+	if err != nil && !errors.Is(err, io.EOF) {
+		logger.WithError(err).Error("failed to read file")
+		return nil, err
+	}
+
+	attachment := &models.Attachment{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		ParentType:  parentType,
+		ParentID:    parentID,
+		UserID:      &userID,
+		FileName:    cleanFileName(fileName),
+		ContentType: http.DetectContentType(head),
+		CreatedAt:   time.Now(),
+	}
+	attachment.StorageKey = fmt.Sprintf("projects/%s/%s", projectID, attachment.ID)
+
+	counter := &limitedReader{r: buffered, limit: limit, err: limitErr}
+	if err := u.storage.Put(ctx, attachment.StorageKey, counter, attachment.ContentType); err != nil {
+		logger.WithError(err).Error("failed to store file")
+		return nil, err
+	}
+	attachment.Size = counter.n
+
+	if err := u.repo.CreateAttachment(ctx, attachment, u.conf.ProjectQuota); err != nil {
+		logger.WithError(err).Error("failed to create attachment in repository")
+		if delErr := u.storage.Delete(ctx, attachment.StorageKey); delErr != nil {
+			logger.WithError(delErr).Warn("failed to delete orphaned file")
+		}
+		return nil, err
+	}
+
+	return attachmentToDTO(attachment), nil
+}
+
+func (u *AttachmentUsecase) GetAttachments(ctx context.Context, parentType string, parentID uuid.UUID) ([]*dto.AttachmentDTO, error) {
+	const op = "AttachmentUsecase.GetAttachments"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("parentType", parentType).
+		WithField("parentID", parentID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	// Для недоступной или несуществующей сущности отвечаем 404, а не пустым списком
+	if _, err := u.repo.GetParentProject(ctx, parentType, parentID, userID); err != nil {
+		logger.WithError(err).Error("failed to get parent project")
+		return nil, err
+	}
+
+	attachments, err := u.repo.GetAttachmentsByParent(ctx, parentType, parentID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get attachments from repository")
+		return nil, err
+	}
+
+	result := make([]*dto.AttachmentDTO, len(attachments))
+	for i := range attachments {
+		result[i] = attachmentToDTO(&attachments[i])
+	}
+
+	return result, nil
+}
+
+// GetDownloadURL выдает участнику проекта временную ссылку на скачивание
+func (u *AttachmentUsecase) GetDownloadURL(ctx context.Context, attachmentID uuid.UUID) (*dto.AttachmentURLDTO, error) {
+	const op = "AttachmentUsecase.GetDownloadURL"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("attachmentID", attachmentID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	attachment, err := u.repo.GetAttachmentByID(ctx, attachmentID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get attachment from repository")
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(u.conf.URLLifeSpan)
+	url, err := u.storage.SignedURL(ctx, attachment.StorageKey, attachment.FileName, attachment.ContentType, u.conf.URLLifeSpan)
+	if err != nil {
+		logger.WithError(err).Error("failed to sign download url")
+		return nil, err
+	}
+
+	return &dto.AttachmentURLDTO{
+		URL:       url,
+		ExpiresAt: expiresAt.Truncate(time.Second),
+	}, nil
+}
+
+func (u *AttachmentUsecase) DeleteAttachment(ctx context.Context, attachmentID uuid.UUID) error {
+	const op = "AttachmentUsecase.DeleteAttachment"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("attachmentID", attachmentID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	if err := u.repo.DeleteAttachment(ctx, attachmentID, userID); err != nil {
+		logger.WithError(err).Error("failed to delete attachment from repository")
+		return err
+	}
+
+	if err := u.PurgeDeleted(ctx); err != nil {
+		logger.WithError(err).Warn("failed to purge deleted files")
+	}
+
+	return nil
+}
+
+func (u *AttachmentUsecase) GetProjectUsage(ctx context.Context, projectID uuid.UUID) (*dto.AttachmentUsageDTO, error) {
+	const op = "AttachmentUsecase.GetProjectUsage"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	hasAccess, err := u.projectRepo.CheckProjectAccess(ctx, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to check project access")
+		return nil, err
+	}
+
+	if !hasAccess {
+		logger.Warn("user doesn't have access to project")
+		return nil, errs.ErrNoAccess
+	}
+
+	used, err := u.repo.GetProjectUsage(ctx, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get project usage")
+		return nil, err
+	}
+
+	return &dto.AttachmentUsageDTO{
+		ProjectID:   projectID,
+		Used:        used,
+		Quota:       u.conf.ProjectQuota,
+		MaxFileSize: u.conf.MaxFileSize,
+	}, nil
+}
+
+// PurgeDeleted удаляет из хранилища файлы вложений, строки которых уже удалены:
+// напрямую, вместе с задачей или заметкой или вместе с проектом.
+// Ключи, которые не удалось удалить, остаются в очереди до следующего вызова
+func (u *AttachmentUsecase) PurgeDeleted(ctx context.Context) error {
+	const op = "AttachmentUsecase.PurgeDeleted"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	for {
+		keys, err := u.repo.GetDeletedBlobs(ctx, purgeBatchSize)
+		if err != nil {
+			logger.WithError(err).Error("failed to get deleted files")
+			return err
+		}
+
+		var (
+			removed  []string
+			firstErr error
+		)
+		for _, key := range keys {
+			if err := u.storage.Delete(ctx, key); err != nil {
+				logger.WithError(err).WithField("key", key).Warn("failed to delete file")
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			removed = append(removed, key)
+		}
+
+		if len(removed) > 0 {
+			if err := u.repo.RemoveDeletedBlobs(ctx, removed); err != nil {
+				logger.WithError(err).Error("failed to remove deleted files from queue")
+				return err
+			}
+		}
+
+		if firstErr != nil {
+			return firstErr
+		}
+
+		if len(keys) < purgeBatchSize {
+			return nil
+		}
+	}
+}
+
+func attachmentToDTO(attachment *models.Attachment) *dto.AttachmentDTO {
+	return &dto.AttachmentDTO{
+		ID:          attachment.ID,
+		ProjectID:   attachment.ProjectID,
+		ParentType:  attachment.ParentType,
+		ParentID:    attachment.ParentID,
+		UserID:      attachment.UserID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   attachment.CreatedAt.Truncate(time.Second),
+	}
+}
+
+// cleanFileName оставляет от имени файла только последнюю часть пути
+// без управляющих символов и обрезает его до допустимой длины
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	for len(name) > maxFileNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "file"
+	}
+	return name
+}
+
+// limitedReader считает прочитанные байты и возвращает err, как только поток
+// становится длиннее limit
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	n     int64
+	err   error
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > l.limit {
+		return n, l.err
+	}
+	return n, err
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/attachment"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func setupAttachmentTest(t *testing.T) (context.Context, uuid.UUID, *mocks.MockAttachmentRepository, *mocks.MockAttachmentProjectRepository, *mocks.MockBlobStorage, *AttachmentUsecase) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	repo := mocks.NewMockAttachmentRepository(ctrl)
+	projectRepo := mocks.NewMockAttachmentProjectRepository(ctrl)
+	storage := mocks.NewMockBlobStorage(ctrl)
+	uc := New(repo, projectRepo, storage, &config.StorageConfig{
+		MaxFileSize:  100,
+		ProjectQuota: 1000,
+		URLLifeSpan:  15 * time.Minute,
+	})
+
+	return ctx, userID, repo, projectRepo, storage, uc
+}
+
+// storeBlob читает поток так же, как настоящее хранилище
+func storeBlob(stored *bytes.Buffer) func(context.Context, string, io.Reader, string) error {
+	return func(_ context.Context, _ string, r io.Reader, _ string) error {
+		_, err := io.Copy(stored, r)
+		return err
+	}
+}
+
+func TestAttachmentUsecase_Upload(t *testing.T) {
+	taskID := uuid.New()
+	projectID := uuid.New()
+
+	t.Run("content type is sniffed", func(t *testing.T) {
+		ctx, userID, repo, _, storage, uc := setupAttachmentTest(t)
+		var stored bytes.Buffer
+
+		repo.EXPECT().GetParentProject(gomock.Any(), models.ParentTask, taskID, userID).Return(projectID, nil)
+		repo.EXPECT().GetProjectUsage(gomock.Any(), projectID).Return(int64(0), nil)
+		storage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), "image/png").DoAndReturn(storeBlob(&stored))
+		repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any(), int64(1000)).
+			DoAndReturn(func(_ context.Context, a *models.Attachment, _ int64) error {
+				assert.Equal(t, "projects/"+projectID.String()+"/"+a.ID.String(), a.StorageKey)
+				return nil
+			})
+
+		result, err := uc.Upload(ctx, models.ParentTask, taskID, "C:\\Users\\me\\shot.txt", bytes.NewReader(pngHeader))
+
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", result.ContentType)
+		assert.Equal(t, "shot.txt", result.FileName)
+		assert.Equal(t, int64(len(pngHeader)), result.Size)
+		assert.Equal(t, pngHeader, stored.Bytes())
+	})
+
+	t.Run("file larger than limit", func(t *testing.T) {
+		ctx, userID, repo, _, storage, uc := setupAttachmentTest(t)
+		var stored bytes.Buffer
+
+		repo.EXPECT().GetParentProject(gomock.Any(), models.ParentTask, taskID, userID).Return(projectID, nil)
+		repo.EXPECT().GetProjectUsage(gomock.Any(), projectID).Return(int64(0), nil)
+		storage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(storeBlob(&stored))
+
+		result, err := uc.Upload(ctx, models.ParentTask, taskID, "big.txt", strings.NewReader(strings.Repeat("a", 101)))
+
+		assert.ErrorIs(t, err, errs.ErrFileTooLarge)
+		assert.Nil(t, result)
+	})
+
+	t.Run("remaining quota smaller than file", func(t *testing.T) {
+		ctx, userID, repo, _, storage, uc := setupAttachmentTest(t)
+		var stored bytes.Buffer
+
+		repo.EXPECT().GetParentProject(gomock.Any(), models.ParentTask, taskID, userID).Return(projectID, nil)
+		repo.EXPECT().GetProjectUsage(gomock.Any(), projectID).Return(int64(950), nil)
+		storage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(storeBlob(&stored))
+
+		_, err := uc.Upload(ctx, models.ParentTask, taskID, "a.txt", strings.NewReader(strings.Repeat("a", 60)))
+
+		assert.ErrorIs(t, err, errs.ErrQuotaExceeded)
+	})
+
+	t.Run("quota already used", func(t *testing.T) {
+		ctx, userID, repo, _, _, uc := setupAttachmentTest(t)
+
+		repo.EXPECT().GetParentProject(gomock.Any(), models.ParentTask, taskID, userID).Return(projectID, nil)
+		repo.EXPECT().GetProjectUsage(gomock.Any(), projectID).Return(int64(1000), nil)
+
+		_, err := uc.Upload(ctx, models.ParentTask, taskID, "a.txt", strings.NewReader("a"))
+
+		assert.ErrorIs(t, err, errs.ErrQuotaExceeded)
+	})
+
+	t.Run("failed insert removes stored file", func(t *testing.T) {
+		ctx, userID, repo, _, storage, uc := setupAttachmentTest(t)
+		var stored bytes.Buffer
+
+		repo.EXPECT().GetParentProject(gomock.Any(), models.ParentTask, taskID, userID).Return(projectID, nil)
+		repo.EXPECT().GetProjectUsage(gomock.Any(), projectID).Return(int64(0), nil)
+		storage.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(storeBlob(&stored))
+		repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any(), int64(1000)).Return(errs.ErrQuotaExceeded)
+		storage.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err := uc.Upload(ctx, models.ParentTask, taskID, "a.txt", strings.NewReader("text"))
+
+		assert.ErrorIs(t, err, errs.ErrQuotaExceeded)
+	})
+
+	t.Run("parent not accessible", func(t *testing.T) {
+		ctx, userID, repo, _, _, uc := setupAttachmentTest(t)
+
+		repo.EXPECT().GetParentProject(gomock.Any(), models.ParentNote, taskID, userID).Return(uuid.Nil, errs.ErrNotFound)
+
+		_, err := uc.Upload(ctx, models.ParentNote, taskID, "a.txt", strings.NewReader("text"))
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+}
+
+func TestAttachmentUsecase_GetDownloadURL(t *testing.T) {
+	ctx, userID, repo, _, storage, uc := setupAttachmentTest(t)
+
+	attachment := &models.Attachment{
+		ID:          uuid.New(),
+		FileName:    "report.pdf",
+		ContentType: "application/pdf",
+		StorageKey:  "projects/p/a",
+	}
+
+	repo.EXPECT().GetAttachmentByID(gomock.Any(), attachment.ID, userID).Return(attachment, nil)
+	storage.EXPECT().SignedURL(gomock.Any(), "projects/p/a", "report.pdf", "application/pdf", 15*time.Minute).
+		Return("http://files/signed", nil)
+
+	result, err := uc.GetDownloadURL(ctx, attachment.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "http://files/signed", result.URL)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), result.ExpiresAt, 2*time.Second)
+}
+
+func TestAttachmentUsecase_DeleteAttachment(t *testing.T) {
+	ctx, userID, repo, _, storage, uc := setupAttachmentTest(t)
+	attachmentID := uuid.New()
+
+	repo.EXPECT().DeleteAttachment(gomock.Any(), attachmentID, userID).Return(nil)
+	repo.EXPECT().GetDeletedBlobs(gomock.Any(), purgeBatchSize).Return([]string{"k1", "k2"}, nil)
+	storage.EXPECT().Delete(gomock.Any(), "k1").Return(nil)
+	storage.EXPECT().Delete(gomock.Any(), "k2").Return(errors.New("storage down"))
+	repo.EXPECT().RemoveDeletedBlobs(gomock.Any(), []string{"k1"}).Return(nil)
+
+	assert.NoError(t, uc.DeleteAttachment(ctx, attachmentID))
+}
+
+func TestAttachmentUsecase_GetProjectUsage(t *testing.T) {
+	ctx, userID, repo, projectRepo, _, uc := setupAttachmentTest(t)
+	projectID := uuid.New()
+
+	projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+	repo.EXPECT().GetProjectUsage(gomock.Any(), projectID).Return(int64(250), nil)
+
+	result, err := uc.GetProjectUsage(ctx, projectID)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(250), result.Used)
+	assert.Equal(t, int64(1000), result.Quota)
+}
+
+func TestCleanFileName(t *testing.T) {
+	tests := map[string]string{
+		"report.pdf":             "report.pdf",
+		"../../etc/passwd":       "passwd",
+		"C:\\tmp\\a.png":         "a.png",
+		"  name\x00\n\".txt  ":   "name.txt",
+		"":                       "file",
+		"..":                     "file",
+		strings.Repeat("я", 200): strings.Repeat("я", 127),
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, cleanFileName(input), input)
+	}
+}
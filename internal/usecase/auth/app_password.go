@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/auth"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const (
+	appPasswordBytes = 20
+	appPasswordGroup = 4
+)
+
+var appPasswordEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// CreateAppPassword выпускает пароль приложения. Пароль случайный и длинный,
+// поэтому хранится SHA-256, а не bcrypt: проверка выполняется на каждый CalDAV-запрос
+func (uc *AuthUsecase) CreateAppPassword(ctx context.Context, name string) (*dto.AppPasswordDTO, error) {
+	const op = "AuthUsecase.CreateAppPassword"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	password, err := newAppPassword()
+	if err != nil {
+		logger.WithError(err).Error("failed to generate app password")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	appPassword := &models.AppPassword{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := uc.repo.CreateAppPassword(ctx, appPassword, hashAppPassword(password)); err != nil {
+		logger.WithError(err).Error("failed to save app password")
+		return nil, err
+	}
+
+	result := appPasswordDTO(*appPassword)
+	result.Password = password
+	return &result, nil
+}
+
+func (uc *AuthUsecase) GetAppPasswords(ctx context.Context) ([]dto.AppPasswordDTO, error) {
+	const op = "AuthUsecase.GetAppPasswords"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	passwords, err := uc.repo.GetAppPasswords(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get app passwords")
+		return nil, err
+	}
+
+	result := make([]dto.AppPasswordDTO, len(passwords))
+	for i, p := range passwords {
+		result[i] = appPasswordDTO(p)
+	}
+	return result, nil
+}
+
+func (uc *AuthUsecase) DeleteAppPassword(ctx context.Context, id uuid.UUID) error {
+	const op = "AuthUsecase.DeleteAppPassword"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	if err := uc.repo.DeleteAppPassword(ctx, id, userID); err != nil {
+		logger.WithError(err).Error("failed to delete app password")
+		return err
+	}
+
+	return nil
+}
+
+// AuthenticateAppPassword проверяет пару логин (или email) и пароль приложения
+// из Basic-авторизации и возвращает ID пользователя
+func (uc *AuthUsecase) AuthenticateAppPassword(ctx context.Context, loginOrEmail, password string) (uuid.UUID, error) {
+	const op = "AuthUsecase.AuthenticateAppPassword"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("login", loginOrEmail)
+
+	userID, err := uc.repo.UseAppPassword(ctx, loginOrEmail, hashAppPassword(password), time.Now().UTC())
+	if err != nil {
+		logger.WithError(err).Warn("app password authentication failed")
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
+
+func appPasswordDTO(p models.AppPassword) dto.AppPasswordDTO {
+	return dto.AppPasswordDTO{
+		ID:         p.ID,
+		Name:       p.Name,
+		CreatedAt:  p.CreatedAt,
+		LastUsedAt: p.LastUsedAt,
+	}
+}
+
+// newAppPassword возвращает пароль вида abcd-efgh-...: группы удобно переписывать
+// с экрана в настройки телефона
+func newAppPassword() (string, error) {
+	b := make([]byte, appPasswordBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	raw := strings.ToLower(appPasswordEncoding.EncodeToString(b))
+
+	groups := make([]string, 0, len(raw)/appPasswordGroup)
+	for i := 0; i < len(raw); i += appPasswordGroup {
+		groups = append(groups, raw[i:min(i+appPasswordGroup, len(raw))])
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+// hashAppPassword не учитывает регистр, дефисы и пробелы, которые клиенты
+// могут добавить или убрать при вводе
+func hashAppPassword(password string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(password))
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/user"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestAuthUsecase_CreateAppPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepository(ctrl)
+	uc := New(mockRepo, mocks.NewMockITokenator(ctrl), mocks.NewMockIAuthRedisRepository(ctrl), mocks.NewMockProjectRepository(ctrl))
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	var storedHash string
+	mockRepo.EXPECT().CreateAppPassword(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, p *models.AppPassword, passwordHash string) error {
+			assert.Equal(t, userID, p.UserID)
+			assert.Equal(t, "iPhone", p.Name)
+			storedHash = passwordHash
+			return nil
+		})
+
+	result, err := uc.CreateAppPassword(ctx, "iPhone")
+
+	assert.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[a-z2-7]{4}(-[a-z2-7]{4}){7}$`), result.Password)
+	assert.Equal(t, hashAppPassword(result.Password), storedHash)
+}
+
+func TestAuthUsecase_AuthenticateAppPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepository(ctrl)
+	uc := New(mockRepo, mocks.NewMockITokenator(ctrl), mocks.NewMockIAuthRedisRepository(ctrl), mocks.NewMockProjectRepository(ctrl))
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+
+	// Дефисы, пробелы и регистр не влияют на хеш
+	mockRepo.EXPECT().UseAppPassword(gomock.Any(), "testuser", hashAppPassword("abcdefgh"), gomock.Any()).
+		Return(userID, nil)
+	id, err := uc.AuthenticateAppPassword(ctx, "testuser", "ABCD-efgh")
+	assert.NoError(t, err)
+	assert.Equal(t, userID, id)
+
+	mockRepo.EXPECT().UseAppPassword(gomock.Any(), "testuser", gomock.Any(), gomock.Any()).
+		Return(uuid.Nil, errs.ErrInvalidCredentials)
+	_, err = uc.AuthenticateAppPassword(ctx, "testuser", "wrong")
+	assert.ErrorIs(t, err, errs.ErrInvalidCredentials)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
@@ -24,6 +25,10 @@ type AuthRepository interface {
 	CreateUser(ctx context.Context, login string, username string, email string, passwordHash []byte) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	GetUserByEmailOrLogin(ctx context.Context, emailOrLogin string) (*models.User, error)
+	CreateAppPassword(ctx context.Context, password *models.AppPassword, passwordHash string) error
+	GetAppPasswords(ctx context.Context, userID uuid.UUID) ([]models.AppPassword, error)
+	DeleteAppPassword(ctx context.Context, id, userID uuid.UUID) error
+	UseAppPassword(ctx context.Context, loginOrEmail, passwordHash string, usedAt time.Time) (uuid.UUID, error)
 }
 
 type IAuthRedisRepository interface {
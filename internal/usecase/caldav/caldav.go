@@ -0,0 +1,503 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/ical"
+	models "github.com/lzimin05/course-todo/internal/models/caldav"
+	calendarmodels "github.com/lzimin05/course-todo/internal/models/calendar"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+	usermodels "github.com/lzimin05/course-todo/internal/models/user"
+	taskdto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const (
+	productID      = "-//course-todo//CalDAV//RU"
+	maxTitleLength = 100
+)
+
+//go:generate mockgen -source=caldav.go -destination=../mocks/caldav_mocks.go -package=mocks CalDAVRepository,CalDAVTaskUsecase,CalDAVUserRepository
+type CalDAVRepository interface {
+	GetCollections(ctx context.Context, userID uuid.UUID) ([]models.Collection, error)
+	GetCollection(ctx context.Context, projectID, userID uuid.UUID) (*models.Collection, error)
+	GetObjects(ctx context.Context, projectID, userID uuid.UUID) ([]models.Object, error)
+	GetObjectByName(ctx context.Context, projectID, userID uuid.UUID, name string) (*models.Object, error)
+	GetObjectsByNames(ctx context.Context, projectID, userID uuid.UUID, names []string) ([]models.Object, error)
+	GetChanges(ctx context.Context, projectID, userID uuid.UUID, since int64) ([]models.Object, []string, error)
+}
+
+// CalDAVTaskUsecase - изменения из CalDAV проходят через те же сценарии,
+// что и запросы к REST API: проверки доступа, версии и ссылки в описаниях
+type CalDAVTaskUsecase interface {
+	CreateCalDAVTask(ctx context.Context, req *taskdto.PostTaskDTO, origin taskmodels.CalDAVOrigin) (*taskdto.CreateTaskDTO, error)
+	UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, estimateMinutes *int, startAt *time.Time, allDay bool, taskID, userID uuid.UUID, expectedVersions []int) (int, error)
+	UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID, expectedVersions []int) (int, error)
+	DeleteTask(ctx context.Context, taskID, userID uuid.UUID, expectedVersions []int) error
+}
+
+type CalDAVUserRepository interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*usermodels.User, error)
+}
+
+type CalDAVUsecase struct {
+	repo     CalDAVRepository
+	tasks    CalDAVTaskUsecase
+	userRepo CalDAVUserRepository
+}
+
+func New(repo CalDAVRepository, tasks CalDAVTaskUsecase, userRepo CalDAVUserRepository) *CalDAVUsecase {
+	return &CalDAVUsecase{
+		repo:     repo,
+		tasks:    tasks,
+		userRepo: userRepo,
+	}
+}
+
+// todo - поля задачи, разобранные из VTODO
+type todo struct {
+	UID         string
+	Title       string
+	Description string
+	Importance  int
+	Status      string
+	StartAt     *time.Time
+	Deadline    time.Time
+	AllDay      bool
+}
+
+func (uc *CalDAVUsecase) GetPrincipal(ctx context.Context) (*models.Principal, error) {
+	const op = "CalDAVUsecase.GetPrincipal"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get user")
+		return nil, err
+	}
+
+	return &models.Principal{
+		UserID:      user.ID,
+		Login:       user.Login,
+		Email:       user.Email,
+		DisplayName: user.Username,
+	}, nil
+}
+
+func (uc *CalDAVUsecase) GetCollections(ctx context.Context) ([]models.Collection, error) {
+	const op = "CalDAVUsecase.GetCollections"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	collections, err := uc.repo.GetCollections(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get collections")
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+func (uc *CalDAVUsecase) GetCollection(ctx context.Context, projectID uuid.UUID) (*models.Collection, error) {
+	const op = "CalDAVUsecase.GetCollection"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	collection, err := uc.repo.GetCollection(ctx, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get collection")
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (uc *CalDAVUsecase) GetObjects(ctx context.Context, projectID uuid.UUID) ([]models.Object, error) {
+	const op = "CalDAVUsecase.GetObjects"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	objects, err := uc.repo.GetObjects(ctx, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get objects")
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+func (uc *CalDAVUsecase) GetObject(ctx context.Context, projectID uuid.UUID, name string) (*models.Object, error) {
+	const op = "CalDAVUsecase.GetObject"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	object, err := uc.repo.GetObjectByName(ctx, projectID, userID, name)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get object")
+		return nil, err
+	}
+
+	return object, nil
+}
+
+func (uc *CalDAVUsecase) GetObjectsByNames(ctx context.Context, projectID uuid.UUID, names []string) ([]models.Object, error) {
+	const op = "CalDAVUsecase.GetObjectsByNames"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	objects, err := uc.repo.GetObjectsByNames(ctx, projectID, userID, names)
+	if err != nil {
+		logger.WithError(err).Error("failed to get objects by names")
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+// GetChanges возвращает изменения коллекции после syncToken (RFC 6578).
+// При первой синхронизации (пустой токен) удаленные ресурсы не передаются
+func (uc *CalDAVUsecase) GetChanges(ctx context.Context, projectID uuid.UUID, syncToken string) (*models.Changes, error) {
+	const op = "CalDAVUsecase.GetChanges"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	since, err := models.ParseSyncToken(syncToken)
+	if err != nil {
+		logger.WithField("token", syncToken).Warn("invalid sync token")
+		return nil, err
+	}
+
+	// Номер берется до выборки изменений: изменение, пришедшее между запросами,
+	// клиент получит повторно при следующей синхронизации, но не потеряет
+	collection, err := uc.repo.GetCollection(ctx, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get collection")
+		return nil, err
+	}
+	if since > collection.SyncSeq {
+		logger.WithField("token", syncToken).Warn("sync token from the future")
+		return nil, errs.ErrInvalidSyncToken
+	}
+
+	objects, deleted, err := uc.repo.GetChanges(ctx, projectID, userID, since)
+	if err != nil {
+		logger.WithError(err).Error("failed to get changes")
+		return nil, err
+	}
+	if since == 0 {
+		deleted = nil
+	}
+
+	return &models.Changes{
+		Objects: objects,
+		Deleted: deleted,
+		SyncSeq: collection.SyncSeq,
+	}, nil
+}
+
+// PutObject создает или обновляет задачу из VTODO. created - ресурс создан
+func (uc *CalDAVUsecase) PutObject(ctx context.Context, projectID uuid.UUID, name string, data []byte, cond models.Preconditions) (bool, error) {
+	const op = "CalDAVUsecase.PutObject"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("name", name)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return false, err
+	}
+
+	t, err := parseTodo(data, uc.userLocation(ctx, userID))
+	if err != nil {
+		logger.WithError(err).Warn("invalid calendar data")
+		return false, err
+	}
+
+	existing, err := uc.repo.GetObjectByName(ctx, projectID, userID, name)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		logger.WithError(err).Error("failed to get object")
+		return false, err
+	}
+
+	if existing == nil {
+		if cond.IfMatch != nil || cond.IfMatchAny {
+			logger.Warn("object does not exist")
+			return false, errs.ErrVersionMismatch
+		}
+		return true, uc.createObject(ctx, projectID, name, t)
+	}
+
+	if cond.IfNoneMatchAny || (cond.IfMatch != nil && *cond.IfMatch != existing.SyncSeq) {
+		logger.Warn("object etag mismatch")
+		return false, errs.ErrVersionMismatch
+	}
+	return false, uc.updateObject(ctx, userID, existing, t)
+}
+
+func (uc *CalDAVUsecase) DeleteObject(ctx context.Context, projectID uuid.UUID, name string, cond models.Preconditions) error {
+	const op = "CalDAVUsecase.DeleteObject"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("name", name)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	object, err := uc.repo.GetObjectByName(ctx, projectID, userID, name)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get object")
+		return err
+	}
+	if cond.IfMatch != nil && *cond.IfMatch != object.SyncSeq {
+		logger.Warn("object etag mismatch")
+		return errs.ErrVersionMismatch
+	}
+
+	if err := uc.tasks.DeleteTask(ctx, object.TaskID, userID, []int{object.Version}); err != nil {
+		logger.WithError(err).Error("failed to delete task")
+		return err
+	}
+
+	return nil
+}
+
+// CalendarData возвращает задачу в виде VCALENDAR с одним VTODO
+func (uc *CalDAVUsecase) CalendarData(o models.Object) []byte {
+	w := ical.NewWriter()
+	w.Begin("VCALENDAR")
+	w.Raw("VERSION", "2.0")
+	w.Text("PRODID", productID)
+	w.Begin("VTODO")
+
+	w.Text("UID", o.UID)
+	w.Time("DTSTAMP", o.CreatedAt)
+	w.Time("CREATED", o.CreatedAt)
+	w.Raw("SEQUENCE", strconv.Itoa(max(o.Version-1, 0)))
+	w.Text("SUMMARY", o.Title)
+	if o.Description != "" {
+		w.Text("DESCRIPTION", o.Description)
+	}
+	w.Raw("PRIORITY", strconv.Itoa(calendarmodels.Priority(o.Importance)))
+	if o.StartAt != nil {
+		writeTime(w, "DTSTART", *o.StartAt, o.AllDay)
+	}
+	if hasDeadline(o.Deadline) {
+		writeTime(w, "DUE", o.Deadline, o.AllDay)
+	}
+	w.Raw("STATUS", calendarmodels.TodoStatus(o.Status))
+	if o.Status == taskmodels.StatusCompleted {
+		w.Raw("PERCENT-COMPLETE", "100")
+		if o.CompletedAt != nil {
+			w.Time("COMPLETED", *o.CompletedAt)
+		}
+	}
+
+	w.End("VTODO")
+	w.End("VCALENDAR")
+	return w.Bytes()
+}
+
+// createObject создает задачу вместе со статусом, именем ресурса и UID одной
+// транзакцией, чтобы сбой не оставил задачу, которую клиент не найдет по имени
+func (uc *CalDAVUsecase) createObject(ctx context.Context, projectID uuid.UUID, name string, t *todo) error {
+	const op = "CalDAVUsecase.createObject"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("name", name)
+
+	_, err := uc.tasks.CreateCalDAVTask(ctx, &taskdto.PostTaskDTO{
+		ProjectID:   projectID,
+		Title:       t.Title,
+		Description: t.Description,
+		Importance:  t.Importance,
+		Deadline:    t.Deadline,
+		StartAt:     t.StartAt,
+		AllDay:      t.AllDay,
+	}, taskmodels.CalDAVOrigin{Status: t.Status, Name: name, UID: t.UID})
+	if err != nil {
+		logger.WithError(err).Error("failed to create task")
+		return err
+	}
+
+	return nil
+}
+
+// updateObject сохраняет поля и статус задачи. Ожидаемая версия защищает
+// от изменений, сделанных после чтения ресурса
+func (uc *CalDAVUsecase) updateObject(ctx context.Context, userID uuid.UUID, existing *models.Object, t *todo) error {
+	const op = "CalDAVUsecase.updateObject"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("taskID", existing.TaskID)
+
+	version, err := uc.tasks.UpdateTask(ctx, t.Title, t.Description, t.Importance, t.Deadline,
+		existing.EstimateMinutes, t.StartAt, t.AllDay, existing.TaskID, userID, []int{existing.Version})
+	if err != nil {
+		logger.WithError(err).Error("failed to update task")
+		return err
+	}
+
+	if t.Status != existing.Status {
+		if _, err := uc.tasks.UpdateTaskStatus(ctx, t.Status, existing.TaskID, userID, []int{version}); err != nil {
+			logger.WithError(err).Error("failed to update task status")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// userLocation - часовой пояс для "плавающего" времени без зоны
+func (uc *CalDAVUsecase) userLocation(ctx context.Context, userID uuid.UUID) *time.Location {
+	logger := logctx.GetLogger(ctx)
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user, falling back to UTC")
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil || user.Timezone == "" {
+		logger.WithField("timezone", user.Timezone).Warn("unknown user timezone, falling back to UTC")
+		return time.UTC
+	}
+	return loc
+}
+
+// parseTodo извлекает задачу из календаря с единственным VTODO.
+// Свойства, которых нет в модели задачи, отбрасываются
+func parseTodo(data []byte, floating *time.Location) (*todo, error) {
+	cal, err := ical.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errs.ErrInvalidCalendar, err)
+	}
+	if cal.Name != "VCALENDAR" {
+		return nil, fmt.Errorf("%w: VCALENDAR is required", errs.ErrInvalidCalendar)
+	}
+
+	var component *ical.Component
+	for _, c := range cal.Children {
+		switch c.Name {
+		case "VTIMEZONE":
+		case "VTODO":
+			if component != nil {
+				return nil, fmt.Errorf("%w: only one VTODO is allowed", errs.ErrInvalidCalendar)
+			}
+			component = c
+		default:
+			return nil, fmt.Errorf("%w: unsupported component %s", errs.ErrInvalidCalendar, c.Name)
+		}
+	}
+	if component == nil {
+		return nil, fmt.Errorf("%w: VTODO is required", errs.ErrInvalidCalendar)
+	}
+
+	t := &todo{Importance: calendarmodels.Importance(0), Status: taskmodels.StatusWaiting}
+
+	uid := component.Prop("UID")
+	if uid == nil || strings.TrimSpace(uid.Text()) == "" {
+		return nil, fmt.Errorf("%w: UID is required", errs.ErrInvalidCalendar)
+	}
+	t.UID = uid.Text()
+
+	if p := component.Prop("SUMMARY"); p != nil {
+		t.Title = helpers.Truncate(strings.TrimSpace(p.Text()), maxTitleLength)
+	}
+	if len(t.Title) < 2 {
+		return nil, fmt.Errorf("%w: SUMMARY must be at least 2 characters", errs.ErrInvalidCalendar)
+	}
+	if p := component.Prop("DESCRIPTION"); p != nil {
+		t.Description = p.Text()
+	}
+	if p := component.Prop("PRIORITY"); p != nil {
+		priority, err := strconv.Atoi(strings.TrimSpace(p.Value))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid PRIORITY", errs.ErrInvalidCalendar)
+		}
+		t.Importance = calendarmodels.Importance(priority)
+	}
+	if p := component.Prop("STATUS"); p != nil {
+		t.Status = calendarmodels.TaskStatus(strings.ToUpper(p.Value))
+	}
+	if component.Prop("COMPLETED") != nil {
+		t.Status = taskmodels.StatusCompleted
+	}
+
+	var startAllDay bool
+	if p := component.Prop("DTSTART"); p != nil {
+		start, allDay, err := p.Time(floating)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errs.ErrInvalidCalendar, err)
+		}
+		t.StartAt, startAllDay = &start, allDay
+	}
+	if p := component.Prop("DUE"); p != nil {
+		due, allDay, err := p.Time(floating)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errs.ErrInvalidCalendar, err)
+		}
+		t.Deadline, t.AllDay = due, allDay
+	} else {
+		t.AllDay = startAllDay
+	}
+
+	// Клиенты допускают начало позже срока, модель задачи - нет
+	if t.StartAt != nil && !t.Deadline.IsZero() && t.StartAt.After(t.Deadline) {
+		t.StartAt = nil
+	}
+
+	return t, nil
+}
+
+func writeTime(w *ical.Writer, name string, t time.Time, allDay bool) {
+	if allDay {
+		w.Date(name, t)
+	} else {
+		w.Time(name, t)
+	}
+}
+
+// hasDeadline - дедлайн не задан, если хранится как 0001-01-01
+func hasDeadline(deadline time.Time) bool {
+	return deadline.Year() > 1
+}
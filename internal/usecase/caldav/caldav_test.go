@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/caldav"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+	usermodels "github.com/lzimin05/course-todo/internal/models/user"
+	taskdto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func vtodo(lines ...string) []byte {
+	body := append([]string{"BEGIN:VCALENDAR", "VERSION:2.0", "BEGIN:VTODO"}, lines...)
+	body = append(body, "END:VTODO", "END:VCALENDAR", "")
+	return []byte(strings.Join(body, "\r\n"))
+}
+
+func TestCalDAVUsecase_PutObject_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCalDAVRepository(ctrl)
+	mockTasks := mocks.NewMockCalDAVTaskUsecase(ctrl)
+	mockUserRepo := mocks.NewMockCalDAVUserRepository(ctrl)
+	uc := New(mockRepo, mockTasks, mockUserRepo)
+
+	userID, projectID, taskID := uuid.New(), uuid.New(), uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).
+		Return(&usermodels.User{ID: userID, Timezone: "Europe/Moscow"}, nil)
+	mockRepo.EXPECT().GetObjectByName(gomock.Any(), projectID, userID, "abc.ics").
+		Return(nil, errs.NewNotFoundError("calendar object not found"))
+	// Плавающее время клиента трактуется в часовом поясе пользователя
+	mockTasks.EXPECT().CreateCalDAVTask(gomock.Any(), &taskdto.PostTaskDTO{
+		ProjectID:   projectID,
+		Title:       "Купить молоко",
+		Description: "2 литра",
+		Importance:  3,
+		Deadline:    time.Date(2025, 3, 4, 6, 0, 0, 0, time.UTC),
+	}, taskmodels.CalDAVOrigin{Status: taskmodels.StatusCompleted, Name: "abc.ics", UID: "abc-uid"}).Return(&taskdto.CreateTaskDTO{ID: taskID}, nil)
+
+	created, err := uc.PutObject(ctx, projectID, "abc.ics", vtodo(
+		"UID:abc-uid",
+		"SUMMARY: Купить молоко ",
+		"DESCRIPTION:2 литра",
+		"PRIORITY:1",
+		"DUE:20250304T090000",
+		"STATUS:COMPLETED",
+	), models.Preconditions{})
+
+	assert.NoError(t, err)
+	assert.True(t, created)
+}
+
+func TestCalDAVUsecase_PutObject_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCalDAVRepository(ctrl)
+	mockTasks := mocks.NewMockCalDAVTaskUsecase(ctrl)
+	mockUserRepo := mocks.NewMockCalDAVUserRepository(ctrl)
+	uc := New(mockRepo, mockTasks, mockUserRepo)
+
+	userID, projectID, taskID := uuid.New(), uuid.New(), uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	estimate := 30
+	existing := &models.Object{
+		TaskID:          taskID,
+		ProjectID:       projectID,
+		Name:            "abc.ics",
+		Status:          taskmodels.StatusWaiting,
+		Version:         4,
+		SyncSeq:         17,
+		EstimateMinutes: &estimate,
+	}
+	data := vtodo("UID:abc", "SUMMARY:Релиз", "DTSTART;VALUE=DATE:20250303", "DUE;VALUE=DATE:20250305")
+
+	tests := []struct {
+		name      string
+		cond      models.Preconditions
+		setupMock func()
+		wantErr   error
+	}{
+		{
+			name: "Success",
+			cond: models.Preconditions{IfMatch: ptr(int64(17))},
+			setupMock: func() {
+				mockRepo.EXPECT().GetObjectByName(gomock.Any(), projectID, userID, "abc.ics").Return(existing, nil)
+				start := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+				mockTasks.EXPECT().UpdateTask(gomock.Any(), "Релиз", "", 2,
+					time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), &estimate, &start, true, taskID, userID, []int{existing.Version}).
+					Return(5, nil)
+			},
+		},
+		{
+			name: "ETag mismatch",
+			cond: models.Preconditions{IfMatch: ptr(int64(16))},
+			setupMock: func() {
+				mockRepo.EXPECT().GetObjectByName(gomock.Any(), projectID, userID, "abc.ics").Return(existing, nil)
+			},
+			wantErr: errs.ErrVersionMismatch,
+		},
+		{
+			name: "If-None-Match on existing object",
+			cond: models.Preconditions{IfNoneMatchAny: true},
+			setupMock: func() {
+				mockRepo.EXPECT().GetObjectByName(gomock.Any(), projectID, userID, "abc.ics").Return(existing, nil)
+			},
+			wantErr: errs.ErrVersionMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).
+				Return(&usermodels.User{ID: userID, Timezone: "UTC"}, nil)
+			tt.setupMock()
+
+			created, err := uc.PutObject(ctx, projectID, "abc.ics", data, tt.cond)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.False(t, created)
+			}
+		})
+	}
+}
+
+func TestCalDAVUsecase_PutObject_InvalidCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockCalDAVUserRepository(ctrl)
+	uc := New(mocks.NewMockCalDAVRepository(ctrl), mocks.NewMockCalDAVTaskUsecase(ctrl), mockUserRepo)
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "Not a calendar", data: []byte("hello")},
+		{name: "Missing UID", data: vtodo("SUMMARY:Задача")},
+		{name: "Short summary", data: vtodo("UID:a", "SUMMARY:a")},
+		{name: "Invalid priority", data: vtodo("UID:a", "SUMMARY:Задача", "PRIORITY:high")},
+		{name: "Event instead of todo", data: []byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:a\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).
+				Return(&usermodels.User{ID: userID}, nil)
+
+			_, err := uc.PutObject(ctx, uuid.New(), "a.ics", tt.data, models.Preconditions{})
+
+			assert.ErrorIs(t, err, errs.ErrInvalidCalendar)
+		})
+	}
+}
+
+func TestCalDAVUsecase_GetChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCalDAVRepository(ctrl)
+	uc := New(mockRepo, mocks.NewMockCalDAVTaskUsecase(ctrl), mocks.NewMockCalDAVUserRepository(ctrl))
+
+	userID, projectID := uuid.New(), uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	mockRepo.EXPECT().GetCollection(gomock.Any(), projectID, userID).
+		Return(&models.Collection{ProjectID: projectID, SyncSeq: 20}, nil).Times(3)
+	mockRepo.EXPECT().GetChanges(gomock.Any(), projectID, userID, int64(10)).
+		Return([]models.Object{{Name: "a.ics"}}, []string{"b.ics"}, nil)
+	mockRepo.EXPECT().GetChanges(gomock.Any(), projectID, userID, int64(0)).
+		Return([]models.Object{{Name: "a.ics"}}, []string{"b.ics"}, nil)
+
+	changes, err := uc.GetChanges(ctx, projectID, models.SyncToken(10))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b.ics"}, changes.Deleted)
+	assert.Equal(t, int64(20), changes.SyncSeq)
+
+	// Первая синхронизация не сообщает об удалениях
+	changes, err = uc.GetChanges(ctx, projectID, "")
+	assert.NoError(t, err)
+	assert.Empty(t, changes.Deleted)
+
+	_, err = uc.GetChanges(ctx, projectID, models.SyncToken(21))
+	assert.ErrorIs(t, err, errs.ErrInvalidSyncToken)
+
+	_, err = uc.GetChanges(ctx, projectID, "garbage")
+	assert.ErrorIs(t, err, errs.ErrInvalidSyncToken)
+}
+
+func TestCalDAVUsecase_DeleteObject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCalDAVRepository(ctrl)
+	mockTasks := mocks.NewMockCalDAVTaskUsecase(ctrl)
+	uc := New(mockRepo, mockTasks, mocks.NewMockCalDAVUserRepository(ctrl))
+
+	userID, projectID, taskID := uuid.New(), uuid.New(), uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	object := &models.Object{TaskID: taskID, Version: 3, SyncSeq: 9}
+	mockRepo.EXPECT().GetObjectByName(gomock.Any(), projectID, userID, "a.ics").Return(object, nil).Times(2)
+	mockTasks.EXPECT().DeleteTask(gomock.Any(), taskID, userID, []int{object.Version}).Return(nil)
+
+	assert.ErrorIs(t, uc.DeleteObject(ctx, projectID, "a.ics", models.Preconditions{IfMatch: ptr(int64(8))}), errs.ErrVersionMismatch)
+	assert.NoError(t, uc.DeleteObject(ctx, projectID, "a.ics", models.Preconditions{IfMatch: ptr(int64(9))}))
+}
+
+func TestCalDAVUsecase_CalendarData(t *testing.T) {
+	uc := New(nil, nil, nil)
+
+	completed := time.Date(2025, 3, 5, 10, 0, 0, 0, time.UTC)
+	data := string(uc.CalendarData(models.Object{
+		UID:         "abc-uid",
+		Title:       "Релиз",
+		Status:      taskmodels.StatusCompleted,
+		Importance:  3,
+		Deadline:    time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC),
+		AllDay:      true,
+		CreatedAt:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		CompletedAt: &completed,
+		Version:     3,
+	}))
+
+	assert.Contains(t, data, "UID:abc-uid\r\n")
+	assert.Contains(t, data, "SEQUENCE:2\r\n")
+	assert.Contains(t, data, "PRIORITY:1\r\n")
+	assert.Contains(t, data, "DUE;VALUE=DATE:20250305\r\n")
+	assert.Contains(t, data, "STATUS:COMPLETED\r\n")
+	assert.Contains(t, data, "COMPLETED:20250305T100000Z\r\n")
+	assert.NotContains(t, data, "DTSTART")
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
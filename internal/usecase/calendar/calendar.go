@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/calendar"
+	usermodels "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/calendar"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const dateLayout = "2006-01-02"
+
+//go:generate mockgen -source=calendar.go -destination=../mocks/calendar_mocks.go -package=mocks CalendarRepository,CalendarProjectRepository,CalendarUserRepository
+type CalendarRepository interface {
+	GetCalendarEntries(ctx context.Context, userID uuid.UUID, from, to, fromDate, toDate time.Time) ([]models.Entry, error)
+	ReplaceFeed(ctx context.Context, feed *models.Feed, tokenHash string) error
+	DeleteFeed(ctx context.Context, feedID, userID uuid.UUID) error
+	GetFeeds(ctx context.Context, userID uuid.UUID) ([]models.Feed, error)
+	GetFeedByTokenHash(ctx context.Context, tokenHash string) (*models.Feed, error)
+	GetFeedTasks(ctx context.Context, userID uuid.UUID, projectID *uuid.UUID) ([]models.FeedTask, error)
+}
+
+type CalendarProjectRepository interface {
+	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
+}
+
+type CalendarUserRepository interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*usermodels.User, error)
+}
+
+type CalendarUsecase struct {
+	repo        CalendarRepository
+	projectRepo CalendarProjectRepository
+	userRepo    CalendarUserRepository
+}
+
+func New(repo CalendarRepository, projectRepo CalendarProjectRepository, userRepo CalendarUserRepository) *CalendarUsecase {
+	return &CalendarUsecase{
+		repo:        repo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// GetCalendar возвращает задачи из всех проектов пользователя, пересекающие
+// дни [from, to] в его часовом поясе. Повторяющихся задач в модели нет,
+// поэтому каждая задача дает ровно одно событие
+func (uc *CalendarUsecase) GetCalendar(ctx context.Context, from, to time.Time) (*dto.CalendarDTO, error) {
+	const op = "CalendarUsecase.GetCalendar"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get user")
+		return nil, err
+	}
+
+	timezone := user.Timezone
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		logger.WithField("timezone", timezone).Warn("unknown user timezone, falling back to UTC")
+		timezone = usermodels.DefaultTimezone
+		loc = time.UTC
+	}
+
+	windowStart := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	windowEnd := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+
+	entries, err := uc.repo.GetCalendarEntries(ctx, userID, windowStart.UTC(), windowEnd.UTC(), fromDate, toDate)
+	if err != nil {
+		logger.WithError(err).Error("failed to get calendar entries")
+		return nil, err
+	}
+
+	result := &dto.CalendarDTO{
+		From:     from.Format(dateLayout),
+		To:       to.Format(dateLayout),
+		Timezone: timezone,
+		Items:    make([]dto.CalendarItemDTO, len(entries)),
+	}
+	for i, e := range entries {
+		result.Items[i] = calendarItem(e, loc)
+	}
+
+	return result, nil
+}
+
+func calendarItem(e models.Entry, loc *time.Location) dto.CalendarItemDTO {
+	start, end := e.Deadline, e.Deadline
+	if e.StartAt != nil {
+		start = *e.StartAt
+		// Дедлайн не задан: в базе он хранится как 0001-01-01
+		if e.Deadline.Year() <= 1 {
+			end = start
+		}
+	}
+
+	item := dto.CalendarItemDTO{
+		TaskID:      e.TaskID,
+		ProjectID:   e.ProjectID,
+		ProjectName: e.ProjectName,
+		Title:       e.Title,
+		Status:      e.Status,
+		Importance:  e.Importance,
+		AllDay:      e.AllDay,
+	}
+
+	// Даты задач на весь день хранятся в UTC и не переводятся в часовой пояс
+	if e.AllDay {
+		item.Start = start.UTC().Format(dateLayout)
+		item.End = end.UTC().Format(dateLayout)
+	} else {
+		item.Start = start.In(loc).Format(time.RFC3339)
+		item.End = end.In(loc).Format(time.RFC3339)
+	}
+
+	return item
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/calendar"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	usermodels "github.com/lzimin05/course-todo/internal/models/user"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestCalendarUsecase_GetCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCalendarRepository(ctrl)
+	mockUserRepo := mocks.NewMockCalendarUserRepository(ctrl)
+	uc := New(mockRepo, mocks.NewMockCalendarProjectRepository(ctrl), mockUserRepo)
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)
+	allDayStart := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	timedStart := time.Date(2025, 3, 4, 6, 0, 0, 0, time.UTC)
+
+	mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).
+		Return(&usermodels.User{ID: userID, Timezone: "Europe/Moscow"}, nil)
+	// Москва UTC+3: окно по времени сдвинуто на три часа, окно по датам - нет
+	mockRepo.EXPECT().GetCalendarEntries(gomock.Any(), userID,
+		time.Date(2025, 2, 28, 21, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 7, 21, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC),
+	).Return([]models.Entry{
+		{TaskID: uuid.New(), Title: "Релиз", StartAt: &allDayStart, Deadline: allDayStart.AddDate(0, 0, 2), AllDay: true},
+		{TaskID: uuid.New(), Title: "Ревью", StartAt: &timedStart, Deadline: timedStart.Add(2 * time.Hour)},
+		{TaskID: uuid.New(), Title: "Только дедлайн", Deadline: timedStart},
+		{TaskID: uuid.New(), Title: "Только начало", StartAt: &timedStart, Deadline: time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	calendar, err := uc.GetCalendar(ctx, from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Moscow", calendar.Timezone)
+	assert.Len(t, calendar.Items, 4)
+
+	assert.Equal(t, "2025-03-03", calendar.Items[0].Start)
+	assert.Equal(t, "2025-03-05", calendar.Items[0].End)
+
+	assert.Equal(t, "2025-03-04T09:00:00+03:00", calendar.Items[1].Start)
+	assert.Equal(t, "2025-03-04T11:00:00+03:00", calendar.Items[1].End)
+
+	assert.Equal(t, calendar.Items[2].Start, calendar.Items[2].End)
+	assert.Equal(t, "2025-03-04T09:00:00+03:00", calendar.Items[3].End)
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/ical"
+	models "github.com/lzimin05/course-todo/internal/models/calendar"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/calendar"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const (
+	feedTokenBytes = 32
+
+	// Домен в UID не меняется, чтобы календари не дублировали задачи после переезда сервиса
+	feedUIDDomain = "course-todo"
+	feedProductID = "-//course-todo//Tasks//RU"
+	feedName      = "Задачи"
+)
+
+// CreateFeed создает секретную ссылку на ICS-фид. Повторный вызов для того же
+// проекта выдает новый токен, а старая ссылка перестает работать
+func (uc *CalendarUsecase) CreateFeed(ctx context.Context, projectID *uuid.UUID) (*dto.CalendarFeedDTO, error) {
+	const op = "CalendarUsecase.CreateFeed"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	if projectID != nil {
+		hasAccess, err := uc.projectRepo.CheckProjectAccess(ctx, *projectID, userID)
+		if err != nil {
+			logger.WithError(err).Error("failed to check project access")
+			return nil, err
+		}
+		if !hasAccess {
+			logger.Warn("user doesn't have access to project")
+			return nil, errs.ErrNoAccess
+		}
+	}
+
+	token, err := newFeedToken()
+	if err != nil {
+		logger.WithError(err).Error("failed to generate feed token")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	feed := &models.Feed{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ProjectID: projectID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := uc.repo.ReplaceFeed(ctx, feed, hashFeedToken(token)); err != nil {
+		logger.WithError(err).Error("failed to save feed")
+		return nil, err
+	}
+
+	result := feedDTO(*feed)
+	result.Token = token
+	return &result, nil
+}
+
+func (uc *CalendarUsecase) GetFeeds(ctx context.Context) ([]dto.CalendarFeedDTO, error) {
+	const op = "CalendarUsecase.GetFeeds"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	feeds, err := uc.repo.GetFeeds(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get feeds")
+		return nil, err
+	}
+
+	result := make([]dto.CalendarFeedDTO, len(feeds))
+	for i, f := range feeds {
+		result[i] = feedDTO(f)
+	}
+	return result, nil
+}
+
+// DeleteFeed отзывает ссылку на фид
+func (uc *CalendarUsecase) DeleteFeed(ctx context.Context, feedID uuid.UUID) error {
+	const op = "CalendarUsecase.DeleteFeed"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	if err := uc.repo.DeleteFeed(ctx, feedID, userID); err != nil {
+		logger.WithError(err).Error("failed to delete feed")
+		return err
+	}
+
+	return nil
+}
+
+// GetFeedICS собирает календарь по токену из ссылки. Запрос не авторизован:
+// доступ дает только знание токена. В фид попадают задачи с дедлайном
+func (uc *CalendarUsecase) GetFeedICS(ctx context.Context, token, kind string) ([]byte, error) {
+	const op = "CalendarUsecase.GetFeedICS"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	feed, err := uc.repo.GetFeedByTokenHash(ctx, hashFeedToken(token))
+	if err != nil {
+		logger.WithError(err).Warn("failed to get feed")
+		return nil, err
+	}
+
+	tasks, err := uc.repo.GetFeedTasks(ctx, feed.UserID, feed.ProjectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get feed tasks")
+		return nil, err
+	}
+
+	return renderFeed(tasks, kind, time.Now().UTC()), nil
+}
+
+func renderFeed(tasks []models.FeedTask, kind string, now time.Time) []byte {
+	w := ical.NewWriter()
+	w.Begin("VCALENDAR")
+	w.Raw("VERSION", "2.0")
+	w.Text("PRODID", feedProductID)
+	w.Raw("CALSCALE", "GREGORIAN")
+	w.Raw("METHOD", "PUBLISH")
+	w.Text("X-WR-CALNAME", feedName)
+
+	for _, t := range tasks {
+		if kind == models.FeedKindTodo {
+			writeTodo(w, t, now)
+		} else {
+			writeEvent(w, t, now)
+		}
+	}
+
+	w.End("VCALENDAR")
+	return w.Bytes()
+}
+
+// writeEvent выгружает задачу событием от start_at (или дедлайна) до дедлайна.
+// У задач на весь день DTEND - следующий день после дедлайна, он не входит в событие
+func writeEvent(w *ical.Writer, t models.FeedTask, now time.Time) {
+	w.Begin("VEVENT")
+	writeCommon(w, t, now)
+
+	start := t.Deadline
+	if t.StartAt != nil {
+		start = *t.StartAt
+	}
+	if t.AllDay {
+		w.Date("DTSTART", start)
+		w.Date("DTEND", t.Deadline.AddDate(0, 0, 1))
+	} else {
+		w.Time("DTSTART", start)
+		// Без start_at событие длится ноль минут: DTEND не указывается
+		if start.Before(t.Deadline) {
+			w.Time("DTEND", t.Deadline)
+		}
+	}
+	w.Raw("STATUS", "CONFIRMED")
+	w.Raw("TRANSP", "TRANSPARENT")
+
+	w.End("VEVENT")
+}
+
+func writeTodo(w *ical.Writer, t models.FeedTask, now time.Time) {
+	w.Begin("VTODO")
+	writeCommon(w, t, now)
+
+	if t.AllDay {
+		if t.StartAt != nil {
+			w.Date("DTSTART", *t.StartAt)
+		}
+		w.Date("DUE", t.Deadline)
+	} else {
+		if t.StartAt != nil {
+			w.Time("DTSTART", *t.StartAt)
+		}
+		w.Time("DUE", t.Deadline)
+	}
+	w.Raw("STATUS", models.TodoStatus(t.Status))
+	if t.Status == taskmodels.StatusCompleted {
+		w.Raw("PERCENT-COMPLETE", "100")
+		if t.CompletedAt != nil {
+			w.Time("COMPLETED", *t.CompletedAt)
+		}
+	}
+
+	w.End("VTODO")
+}
+
+// writeCommon записывает свойства, общие для VEVENT и VTODO. UID зависит только
+// от ID задачи, а SEQUENCE растет с каждой правкой, поэтому клиенты обновляют
+// существующие записи вместо создания новых
+func writeCommon(w *ical.Writer, t models.FeedTask, now time.Time) {
+	w.Raw("UID", t.TaskID.String()+"@"+feedUIDDomain)
+	w.Time("DTSTAMP", now)
+	w.Time("CREATED", t.CreatedAt)
+	w.Raw("SEQUENCE", strconv.Itoa(max(t.Version-1, 0)))
+	w.Text("SUMMARY", t.Title)
+	if t.Description != "" {
+		w.Text("DESCRIPTION", t.Description)
+	}
+	w.Text("CATEGORIES", t.ProjectName)
+	w.Raw("PRIORITY", strconv.Itoa(models.Priority(t.Importance)))
+}
+
+func feedDTO(f models.Feed) dto.CalendarFeedDTO {
+	return dto.CalendarFeedDTO{
+		ID:        f.ID,
+		ProjectID: f.ProjectID,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+func newFeedToken() (string, error) {
+	b := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashFeedToken - в базе хранится только хеш, утечка таблицы не раскрывает ссылки
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
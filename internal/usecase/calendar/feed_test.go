@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/calendar"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestCalendarUsecase_CreateFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCalendarRepository(ctrl)
+	mockProjectRepo := mocks.NewMockCalendarProjectRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo, mocks.NewMockCalendarUserRepository(ctrl))
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	t.Run("Stores hash instead of token", func(t *testing.T) {
+		var storedHash string
+		mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+		mockRepo.EXPECT().ReplaceFeed(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, feed *models.Feed, tokenHash string) error {
+				assert.Equal(t, userID, feed.UserID)
+				assert.Equal(t, projectID, *feed.ProjectID)
+				storedHash = tokenHash
+				return nil
+			})
+
+		feed, err := uc.CreateFeed(ctx, &projectID)
+
+		assert.NoError(t, err)
+		assert.Len(t, feed.Token, 43)
+		assert.NotEqual(t, feed.Token, storedHash)
+		assert.Equal(t, hashFeedToken(feed.Token), storedHash)
+	})
+
+	t.Run("No access to project", func(t *testing.T) {
+		mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(false, nil)
+
+		_, err := uc.CreateFeed(ctx, &projectID)
+
+		assert.ErrorIs(t, err, errs.ErrNoAccess)
+	})
+}
+
+func TestCalendarUsecase_GetFeedICS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCalendarRepository(ctrl)
+	uc := New(mockRepo, mocks.NewMockCalendarProjectRepository(ctrl), mocks.NewMockCalendarUserRepository(ctrl))
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	userID := uuid.New()
+	taskID := uuid.New()
+	deadline := time.Date(2025, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	mockRepo.EXPECT().GetFeedByTokenHash(gomock.Any(), hashFeedToken("secret")).
+		Return(&models.Feed{ID: uuid.New(), UserID: userID}, nil)
+	mockRepo.EXPECT().GetFeedTasks(gomock.Any(), userID, nil).
+		Return([]models.FeedTask{
+			{TaskID: taskID, ProjectName: "Backend", Title: "Релиз, этап 1", Status: "in_progress", Importance: 3, Deadline: deadline, Version: 3},
+		}, nil)
+
+	calendar, err := uc.GetFeedICS(ctx, "secret", models.FeedKindTodo)
+
+	assert.NoError(t, err)
+	ics := string(calendar)
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.Contains(t, ics, "UID:"+taskID.String()+"@course-todo\r\n")
+	assert.Contains(t, ics, "SUMMARY:Релиз\\, этап 1\r\n")
+	assert.Contains(t, ics, "DUE:20250304T090000Z\r\n")
+	assert.Contains(t, ics, "STATUS:IN-PROCESS\r\n")
+	assert.Contains(t, ics, "PRIORITY:1\r\n")
+	assert.Contains(t, ics, "SEQUENCE:2\r\n")
+}
+
+func TestRenderFeed_Events(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	startAt := time.Date(2025, 3, 4, 7, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	tasks := []models.FeedTask{
+		{TaskID: uuid.New(), Title: "Отпуск", Status: "waiting", Importance: 1, StartAt: &day, Deadline: day.AddDate(0, 0, 2), AllDay: true, Version: 1},
+		{TaskID: uuid.New(), Title: "Ревью", Status: "completed", Importance: 2, StartAt: &startAt, Deadline: startAt.Add(2 * time.Hour), CompletedAt: &completedAt, Version: 1},
+	}
+
+	events := string(renderFeed(tasks, models.FeedKindEvent, now))
+	assert.Equal(t, 2, strings.Count(events, "BEGIN:VEVENT\r\n"))
+	assert.Contains(t, events, "DTSTART;VALUE=DATE:20250303\r\nDTEND;VALUE=DATE:20250306\r\n")
+	assert.Contains(t, events, "DTSTART:20250304T070000Z\r\nDTEND:20250304T090000Z\r\n")
+	assert.Contains(t, events, "PRIORITY:9\r\n")
+	assert.Contains(t, events, "PRIORITY:5\r\n")
+	assert.NotContains(t, events, "VTODO")
+
+	todos := string(renderFeed(tasks, models.FeedKindTodo, now))
+	assert.Contains(t, todos, "DTSTART;VALUE=DATE:20250303\r\nDUE;VALUE=DATE:20250305\r\n")
+	assert.Contains(t, todos, "STATUS:NEEDS-ACTION\r\n")
+	assert.Contains(t, todos, "STATUS:COMPLETED\r\nPERCENT-COMPLETE:100\r\nCOMPLETED:20250302T100000Z\r\n")
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	models "github.com/lzimin05/course-todo/internal/models/export"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/export"
+)
+
+// encoder пишет записи выгрузки в выбранном формате. Close дописывает
+// окончание файла и сбрасывает буфер
+type encoder interface {
+	models.Writer
+	Close() error
+}
+
+func newEncoder(format string, w io.Writer, exportedAt time.Time) encoder {
+	switch format {
+	case models.FormatCSV:
+		return newCSVEncoder(w)
+	case models.FormatNDJSON:
+		return newNDJSONEncoder(w, exportedAt)
+	default:
+		return newJSONEncoder(w, exportedAt)
+	}
+}
+
+// csvColumns - колонки CSV-выгрузки. Порядок не меняется: новые колонки
+// добавляются только в конец
+var csvColumns = []string{
+	"record_type", "id", "name", "description", "status", "importance",
+	"deadline", "start_at", "all_day", "estimate_minutes", "format",
+	"author_id", "author_login", "login", "username", "role",
+	"created_at", "completed_at",
+}
+
+// csvEncoder пишет все записи в одну таблицу по RFC 4180: тип записи в первой
+// колонке, неподходящие типу колонки пустые. Для проекта author_id - владелец,
+// для участника id - пользователь, created_at - дата вступления
+type csvEncoder struct {
+	cw *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	return &csvEncoder{cw: cw}
+}
+
+func (e *csvEncoder) write(record map[string]string) error {
+	row := make([]string, len(csvColumns))
+	for i, column := range csvColumns {
+		row[i] = record[column]
+	}
+	return e.cw.Write(row)
+}
+
+func (e *csvEncoder) WriteProject(p models.Project) error {
+	if err := e.cw.Write(csvColumns); err != nil {
+		return err
+	}
+	return e.write(map[string]string{
+		"record_type": models.RecordProject,
+		"id":          p.ID.String(),
+		"name":        p.Name,
+		"description": p.Description,
+		"author_id":   p.OwnerID.String(),
+		"created_at":  formatTime(p.CreatedAt),
+	})
+}
+
+func (e *csvEncoder) WriteMember(m models.Member) error {
+	return e.write(map[string]string{
+		"record_type": models.RecordMember,
+		"id":          m.UserID.String(),
+		"login":       m.Login,
+		"username":    m.Username,
+		"role":        m.Role,
+		"created_at":  formatTime(m.JoinedAt),
+	})
+}
+
+func (e *csvEncoder) WriteTask(t models.Task) error {
+	estimate := ""
+	if t.EstimateMinutes != nil {
+		estimate = strconv.Itoa(*t.EstimateMinutes)
+	}
+	return e.write(map[string]string{
+		"record_type":      models.RecordTask,
+		"id":               t.ID.String(),
+		"name":             t.Title,
+		"description":      t.Description,
+		"status":           t.Status,
+		"importance":       strconv.Itoa(t.Importance),
+		"deadline":         formatTimePtr(deadlinePtr(t.Deadline)),
+		"start_at":         formatTimePtr(t.StartAt),
+		"all_day":          strconv.FormatBool(t.AllDay),
+		"estimate_minutes": estimate,
+		"author_id":        t.AuthorID.String(),
+		"author_login":     t.AuthorLogin,
+		"created_at":       formatTime(t.CreatedAt),
+		"completed_at":     formatTimePtr(t.CompletedAt),
+	})
+}
+
+func (e *csvEncoder) WriteNote(n models.Note) error {
+	return e.write(map[string]string{
+		"record_type":  models.RecordNote,
+		"id":           n.ID.String(),
+		"name":         n.Name,
+		"description":  n.Description,
+		"format":       n.Format,
+		"author_id":    n.AuthorID.String(),
+		"author_login": n.AuthorLogin,
+		"created_at":   formatTime(n.CreatedAt),
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	e.cw.Flush()
+	return e.cw.Error()
+}
+
+// jsonSections - массивы JSON-выгрузки в порядке записи
+var jsonSections = []string{"members", "tasks", "notes"}
+
+// jsonEncoder собирает документ dto.ExportDTO по частям: массив открывается
+// при первой записи своего типа, пустые массивы дописываются при переходе
+// к следующему
+type jsonEncoder struct {
+	w          *bufio.Writer
+	exportedAt time.Time
+	opened     int
+	empty      bool
+}
+
+func newJSONEncoder(w io.Writer, exportedAt time.Time) *jsonEncoder {
+	return &jsonEncoder{w: bufio.NewWriter(w), exportedAt: exportedAt}
+}
+
+func (e *jsonEncoder) WriteProject(p models.Project) error {
+	header, err := json.Marshal(struct {
+		Format     string            `json:"format"`
+		Version    int               `json:"version"`
+		ExportedAt time.Time         `json:"exported_at"`
+		Project    dto.ExportProject `json:"project"`
+	}{models.Kind, models.Version, e.exportedAt, toProjectDTO(p)})
+	if err != nil {
+		return err
+	}
+	// Заголовок без закрывающей скобки: дальше идут массивы
+	_, err = e.w.Write(header[:len(header)-1])
+	return err
+}
+
+func (e *jsonEncoder) WriteMember(m models.Member) error { return e.item(0, toMemberDTO(m)) }
+func (e *jsonEncoder) WriteTask(t models.Task) error     { return e.item(1, toTaskDTO(t)) }
+func (e *jsonEncoder) WriteNote(n models.Note) error     { return e.item(2, toNoteDTO(n)) }
+
+func (e *jsonEncoder) item(section int, v any) error {
+	if err := e.openSections(section); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if !e.empty {
+		if err := e.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	e.empty = false
+	_, err = e.w.Write(data)
+	return err
+}
+
+// openSections закрывает текущий массив и открывает следующие до section включительно
+func (e *jsonEncoder) openSections(section int) error {
+	for e.opened <= section {
+		prefix := `,"`
+		if e.opened > 0 {
+			prefix = `],"`
+		}
+		if _, err := e.w.WriteString(prefix + jsonSections[e.opened] + `":[`); err != nil {
+			return err
+		}
+		e.opened++
+		e.empty = true
+	}
+	return nil
+}
+
+func (e *jsonEncoder) Close() error {
+	if err := e.openSections(len(jsonSections) - 1); err != nil {
+		return err
+	}
+	if _, err := e.w.WriteString("]}\n"); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// ndjsonEncoder пишет по одному JSON-объекту на строку: первая строка -
+// заголовок с версией, дальше записи dto.ExportRecordDTO
+type ndjsonEncoder struct {
+	w          *bufio.Writer
+	enc        *json.Encoder
+	exportedAt time.Time
+}
+
+func newNDJSONEncoder(w io.Writer, exportedAt time.Time) *ndjsonEncoder {
+	bw := bufio.NewWriter(w)
+	return &ndjsonEncoder{w: bw, enc: json.NewEncoder(bw), exportedAt: exportedAt}
+}
+
+func (e *ndjsonEncoder) WriteProject(p models.Project) error {
+	header := dto.ExportHeaderDTO{Type: "header", Format: models.Kind, Version: models.Version, ExportedAt: e.exportedAt}
+	if err := e.enc.Encode(header); err != nil {
+		return err
+	}
+	return e.enc.Encode(dto.ExportRecordDTO{Type: models.RecordProject, Data: toProjectDTO(p)})
+}
+
+func (e *ndjsonEncoder) WriteMember(m models.Member) error {
+	return e.enc.Encode(dto.ExportRecordDTO{Type: models.RecordMember, Data: toMemberDTO(m)})
+}
+
+func (e *ndjsonEncoder) WriteTask(t models.Task) error {
+	return e.enc.Encode(dto.ExportRecordDTO{Type: models.RecordTask, Data: toTaskDTO(t)})
+}
+
+func (e *ndjsonEncoder) WriteNote(n models.Note) error {
+	return e.enc.Encode(dto.ExportRecordDTO{Type: models.RecordNote, Data: toNoteDTO(n)})
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return e.w.Flush()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
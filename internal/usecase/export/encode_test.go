@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	models "github.com/lzimin05/course-todo/internal/models/export"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/export"
+)
+
+var (
+	testProjectID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	testUserID    = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	testTaskID    = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	testNoteID    = uuid.MustParse("44444444-4444-4444-4444-444444444444")
+	testCreatedAt = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+)
+
+// writeTestExport пишет проект с одним участником, задачей и заметкой.
+// Без notes и members проверяется дописывание пустых массивов
+func writeTestExport(t *testing.T, enc encoder, withMembers, withNotes bool) {
+	t.Helper()
+	deadline := testCreatedAt.Add(48 * time.Hour)
+	estimate := 90
+
+	require.NoError(t, enc.WriteProject(models.Project{ID: testProjectID, Name: "Launch", Description: "Q2", OwnerID: testUserID, CreatedAt: testCreatedAt}))
+	if withMembers {
+		require.NoError(t, enc.WriteMember(models.Member{UserID: testUserID, Login: "anna", Username: "Anna", Role: "owner", JoinedAt: testCreatedAt}))
+	}
+	require.NoError(t, enc.WriteTask(models.Task{
+		ID:              testTaskID,
+		AuthorID:        testUserID,
+		AuthorLogin:     "anna",
+		Title:           `Say "hi", team`,
+		Description:     "line 1\nline 2",
+		Status:          "waiting",
+		Importance:      3,
+		Deadline:        &deadline,
+		EstimateMinutes: &estimate,
+		CreatedAt:       testCreatedAt,
+	}))
+	if withNotes {
+		require.NoError(t, enc.WriteNote(models.Note{ID: testNoteID, AuthorID: testUserID, AuthorLogin: "anna", Name: "Plan", Description: "- step", Format: "markdown", CreatedAt: testCreatedAt}))
+	}
+	require.NoError(t, enc.Close())
+}
+
+func TestCSVEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestExport(t, newEncoder(models.FormatCSV, &buf, testCreatedAt), true, true)
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, strings.Join(csvColumns, ",")+"\r\n"))
+	// RFC 4180: поля с кавычками, запятыми и переносами в кавычках, кавычки удваиваются
+	assert.Contains(t, out, `"Say ""hi"", team","line 1`+"\r\n"+`line 2"`)
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+	for _, record := range records {
+		assert.Len(t, record, len(csvColumns))
+	}
+
+	task := records[3]
+	assert.Equal(t, []string{
+		"task", testTaskID.String(), `Say "hi", team`, "line 1\nline 2", "waiting", "3",
+		"2025-03-03T10:00:00Z", "", "false", "90", "",
+		testUserID.String(), "anna", "", "", "",
+		"2025-03-01T10:00:00Z", "",
+	}, task)
+	assert.Equal(t, "member", records[2][0])
+	assert.Equal(t, "- step", records[4][3])
+}
+
+func TestJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestExport(t, newEncoder(models.FormatJSON, &buf, testCreatedAt), true, true)
+
+	var doc dto.ExportDTO
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, models.Kind, doc.Format)
+	assert.Equal(t, models.Version, doc.Version)
+	assert.Equal(t, testProjectID, doc.Project.ID)
+	require.Len(t, doc.Members, 1)
+	require.Len(t, doc.Tasks, 1)
+	require.Len(t, doc.Notes, 1)
+	assert.Equal(t, `Say "hi", team`, doc.Tasks[0].Title)
+	assert.Equal(t, 90, *doc.Tasks[0].EstimateMinutes)
+	assert.Nil(t, doc.Tasks[0].CompletedAt)
+}
+
+func TestJSONEncoder_EmptySections(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestExport(t, newEncoder(models.FormatJSON, &buf, testCreatedAt), false, false)
+
+	var doc map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.JSONEq(t, `[]`, string(doc["members"]))
+	assert.JSONEq(t, `[]`, string(doc["notes"]))
+	assert.Contains(t, string(doc["tasks"]), testTaskID.String())
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	writeTestExport(t, newEncoder(models.FormatNDJSON, &buf, testCreatedAt), true, true)
+
+	var types []string
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var line struct {
+			Type    string `json:"type"`
+			Version int    `json:"version"`
+		}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		if line.Type == "header" {
+			assert.Equal(t, models.Version, line.Version)
+		}
+		types = append(types, line.Type)
+	}
+	assert.Equal(t, []string{"header", "project", "member", "task", "note"}, types)
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/export"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/export"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+//go:generate mockgen -source=export.go -destination=../mocks/export_mocks.go -package=mocks ExportRepository,ExportProjectRepository
+type ExportRepository interface {
+	ExportProject(ctx context.Context, projectID uuid.UUID, w models.Writer) error
+}
+
+type ExportProjectRepository interface {
+	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
+}
+
+// ExportUsecase выгружает задачи, заметки и участников проекта в CSV, JSON или NDJSON
+type ExportUsecase struct {
+	repo        ExportRepository
+	projectRepo ExportProjectRepository
+}
+
+func New(repo ExportRepository, projectRepo ExportProjectRepository) *ExportUsecase {
+	return &ExportUsecase{
+		repo:        repo,
+		projectRepo: projectRepo,
+	}
+}
+
+// ExportProject пишет выгрузку проекта в w по мере чтения из базы. Ошибки
+// доступа и отсутствия проекта возвращаются до первой записи в w
+func (uc *ExportUsecase) ExportProject(ctx context.Context, projectID uuid.UUID, format string, w io.Writer) error {
+	const op = "ExportUsecase.ExportProject"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", projectID).
+		WithField("format", format)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return err
+	}
+
+	hasAccess, err := uc.projectRepo.CheckProjectAccess(ctx, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to check project access")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !hasAccess {
+		logger.Warn("user doesn't have access to project")
+		return errs.ErrNoAccess
+	}
+
+	enc := newEncoder(format, w, time.Now().UTC())
+	if err := uc.repo.ExportProject(ctx, projectID, enc); err != nil {
+		logger.WithError(err).Error("failed to export project")
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		logger.WithError(err).Error("failed to finish export")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func toProjectDTO(p models.Project) dto.ExportProject {
+	return dto.ExportProject{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		OwnerID:     p.OwnerID,
+		CreatedAt:   p.CreatedAt.UTC(),
+	}
+}
+
+func toMemberDTO(m models.Member) dto.ExportMember {
+	return dto.ExportMember{
+		UserID:   m.UserID,
+		Login:    m.Login,
+		Username: m.Username,
+		Role:     m.Role,
+		JoinedAt: m.JoinedAt.UTC(),
+	}
+}
+
+func toTaskDTO(t models.Task) dto.ExportTask {
+	return dto.ExportTask{
+		ID:              t.ID,
+		AuthorID:        t.AuthorID,
+		AuthorLogin:     t.AuthorLogin,
+		Title:           t.Title,
+		Description:     t.Description,
+		Status:          t.Status,
+		Importance:      t.Importance,
+		Deadline:        deadlinePtr(t.Deadline),
+		StartAt:         utcPtr(t.StartAt),
+		AllDay:          t.AllDay,
+		EstimateMinutes: t.EstimateMinutes,
+		CreatedAt:       t.CreatedAt.UTC(),
+		CompletedAt:     utcPtr(t.CompletedAt),
+	}
+}
+
+func toNoteDTO(n models.Note) dto.ExportNote {
+	return dto.ExportNote{
+		ID:          n.ID,
+		AuthorID:    n.AuthorID,
+		AuthorLogin: n.AuthorLogin,
+		Name:        n.Name,
+		Description: n.Description,
+		Format:      n.Format,
+		CreatedAt:   n.CreatedAt.UTC(),
+	}
+}
+
+// deadlinePtr - срок задачи в UTC. Нулевая дата означает, что срока нет
+func deadlinePtr(t *time.Time) *time.Time {
+	if t == nil || t.Year() <= 1 {
+		return nil
+	}
+	return utcPtr(t)
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
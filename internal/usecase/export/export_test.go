@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/export"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestExportUsecase_ExportProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockExportRepository(ctrl)
+	mockProjectRepo := mocks.NewMockExportProjectRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	tests := []struct {
+		name        string
+		setupMocks  func()
+		expectedErr error
+		expectBody  bool
+	}{
+		{
+			name: "success",
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+				mockRepo.EXPECT().ExportProject(gomock.Any(), projectID, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, projectID uuid.UUID, w models.Writer) error {
+						return w.WriteProject(models.Project{ID: projectID, Name: "Launch"})
+					})
+			},
+			expectBody: true,
+		},
+		{
+			name: "no access",
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(false, nil)
+			},
+			expectedErr: errs.ErrNoAccess,
+		},
+		{
+			name: "project not found",
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+				mockRepo.EXPECT().ExportProject(gomock.Any(), projectID, gomock.Any()).Return(errs.ErrNotFound)
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+		{
+			name: "access check error",
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(false, errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			var buf bytes.Buffer
+			err := uc.ExportProject(ctx, projectID, models.FormatJSON, &buf)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				assert.Zero(t, buf.Len())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Contains(t, buf.String(), `"name":"Launch"`)
+			assert.Contains(t, buf.String(), `"notes":[]}`)
+		})
+	}
+}
+
+func TestExportUsecase_ExportProject_TaskWithoutDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockExportRepository(ctrl)
+	mockProjectRepo := mocks.NewMockExportProjectRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	// Задача без срока хранится с нулевой датой
+	var noDeadline time.Time
+	mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil).Times(2)
+	mockRepo.EXPECT().ExportProject(gomock.Any(), projectID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, projectID uuid.UUID, w models.Writer) error {
+			createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+			if err := w.WriteProject(models.Project{ID: projectID, Name: "Launch", CreatedAt: createdAt}); err != nil {
+				return err
+			}
+			return w.WriteTask(models.Task{ID: uuid.New(), Title: "Someday", Status: "waiting", Deadline: &noDeadline, CreatedAt: createdAt})
+		}).Times(2)
+
+	var buf bytes.Buffer
+	assert.NoError(t, uc.ExportProject(ctx, projectID, models.FormatJSON, &buf))
+	assert.Contains(t, buf.String(), `"deadline":null`)
+	assert.NotContains(t, buf.String(), "0001-01-01")
+
+	buf.Reset()
+	assert.NoError(t, uc.ExportProject(ctx, projectID, models.FormatCSV, &buf))
+	assert.Contains(t, buf.String(), "Someday")
+	assert.NotContains(t, buf.String(), "0001-01-01")
+}
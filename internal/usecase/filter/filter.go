@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/filter"
+	taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+	usermodels "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/filter"
+	taskdto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+//go:generate mockgen -source=filter.go -destination=../mocks/filter_mocks.go -package=mocks FilterRepository,FilterProjectRepository,FilterUserRepository
+type FilterRepository interface {
+	CreateFilter(ctx context.Context, filter *models.SavedFilter) error
+	GetFiltersByUserID(ctx context.Context, userID uuid.UUID) ([]*models.SavedFilter, error)
+	GetFilterByID(ctx context.Context, filterID, userID uuid.UUID) (*models.SavedFilter, error)
+	UpdateFilter(ctx context.Context, filter *models.SavedFilter) error
+	DeleteFilter(ctx context.Context, filterID, userID uuid.UUID) error
+	GetTasksByQuery(ctx context.Context, query models.TaskQuery) ([]*taskmodels.Task, error)
+}
+
+type FilterProjectRepository interface {
+	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
+}
+
+type FilterUserRepository interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*usermodels.User, error)
+}
+
+type FilterUsecase struct {
+	repo        FilterRepository
+	projectRepo FilterProjectRepository
+	userRepo    FilterUserRepository
+}
+
+func New(repo FilterRepository, projectRepo FilterProjectRepository, userRepo FilterUserRepository) *FilterUsecase {
+	return &FilterUsecase{
+		repo:        repo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (uc *FilterUsecase) CreateFilter(ctx context.Context, req *dto.PostFilterDTO) (*dto.SavedFilterDTO, error) {
+	const op = "FilterUsecase.CreateFilter"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	if err := uc.checkProjectsAccess(ctx, req.Definition.ProjectIDs, userID); err != nil {
+		logger.WithError(err).Warn("failed to check projects access")
+		return nil, err
+	}
+
+	now := time.Now()
+	filter := &models.SavedFilter{
+		ID:         uuid.New(),
+		UserID:     userID,
+		Name:       strings.TrimSpace(req.Name),
+		Definition: toDefinitionModel(req.Definition),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := uc.repo.CreateFilter(ctx, filter); err != nil {
+		logger.WithError(err).Error("failed to create filter")
+		return nil, err
+	}
+
+	return toSavedFilterDTO(filter), nil
+}
+
+func (uc *FilterUsecase) GetFilters(ctx context.Context) ([]*dto.SavedFilterDTO, error) {
+	const op = "FilterUsecase.GetFilters"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	filters, err := uc.repo.GetFiltersByUserID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get filters")
+		return nil, err
+	}
+
+	filterDTOs := make([]*dto.SavedFilterDTO, len(filters))
+	for i, filter := range filters {
+		filterDTOs[i] = toSavedFilterDTO(filter)
+	}
+
+	return filterDTOs, nil
+}
+
+func (uc *FilterUsecase) GetFilterByID(ctx context.Context, filterID uuid.UUID) (*dto.SavedFilterDTO, error) {
+	const op = "FilterUsecase.GetFilterByID"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("filterID", filterID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	filter, err := uc.repo.GetFilterByID(ctx, filterID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get filter")
+		return nil, err
+	}
+
+	return toSavedFilterDTO(filter), nil
+}
+
+func (uc *FilterUsecase) UpdateFilter(ctx context.Context, filterID uuid.UUID, req *dto.PostFilterDTO) (*dto.SavedFilterDTO, error) {
+	const op = "FilterUsecase.UpdateFilter"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("filterID", filterID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	filter, err := uc.repo.GetFilterByID(ctx, filterID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get filter")
+		return nil, err
+	}
+
+	if err := uc.checkProjectsAccess(ctx, req.Definition.ProjectIDs, userID); err != nil {
+		logger.WithError(err).Warn("failed to check projects access")
+		return nil, err
+	}
+
+	filter.Name = strings.TrimSpace(req.Name)
+	filter.Definition = toDefinitionModel(req.Definition)
+	filter.UpdatedAt = time.Now()
+
+	if err := uc.repo.UpdateFilter(ctx, filter); err != nil {
+		logger.WithError(err).Error("failed to update filter")
+		return nil, err
+	}
+
+	return toSavedFilterDTO(filter), nil
+}
+
+func (uc *FilterUsecase) DeleteFilter(ctx context.Context, filterID uuid.UUID) error {
+	const op = "FilterUsecase.DeleteFilter"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("filterID", filterID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	if err := uc.repo.DeleteFilter(ctx, filterID, userID); err != nil {
+		logger.WithError(err).Error("failed to delete filter")
+		return err
+	}
+
+	return nil
+}
+
+// GetFilterTasks выполняет сохраненный фильтр. Относительные даты вычисляются
+// в момент запроса в часовом поясе пользователя
+func (uc *FilterUsecase) GetFilterTasks(ctx context.Context, filterID uuid.UUID) ([]*taskdto.TaskDTO, error) {
+	const op = "FilterUsecase.GetFilterTasks"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("filterID", filterID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	filter, err := uc.repo.GetFilterByID(ctx, filterID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get filter")
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get user")
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil || user.Timezone == "" {
+		logger.WithField("timezone", user.Timezone).Warn("unknown user timezone, falling back to UTC")
+		loc = time.UTC
+	}
+
+	query, err := resolveQuery(filter.Definition, userID, time.Now(), loc)
+	if err != nil {
+		logger.WithError(err).Error("failed to resolve filter definition")
+		return nil, err
+	}
+
+	tasks, err := uc.repo.GetTasksByQuery(ctx, query)
+	if err != nil {
+		logger.WithError(err).Error("failed to get filtered tasks")
+		return nil, err
+	}
+
+	taskDTOs := make([]*taskdto.TaskDTO, len(tasks))
+	for i, task := range tasks {
+		taskDTOs[i] = &taskdto.TaskDTO{
+			ID:          task.ID,
+			ProjectID:   task.ProjectID,
+			UserID:      task.UserID,
+			Title:       task.Title,
+			Description: task.Description,
+			Importance:  task.Importance,
+			Deadline:    task.Deadline,
+			Status:      task.Status,
+			CreatedAt:   task.CreatedAt,
+			CompletedAt: task.CompletedAt,
+			Version:     task.Version,
+
+			EstimateMinutes: task.EstimateMinutes,
+			StartAt:         task.StartAt,
+			AllDay:          task.AllDay,
+			ChecklistDone:   task.ChecklistDone,
+			ChecklistTotal:  task.ChecklistTotal,
+		}
+	}
+
+	return taskDTOs, nil
+}
+
+func (uc *FilterUsecase) checkProjectsAccess(ctx context.Context, projectIDs []uuid.UUID, userID uuid.UUID) error {
+	for _, projectID := range projectIDs {
+		hasAccess, err := uc.projectRepo.CheckProjectAccess(ctx, projectID, userID)
+		if err != nil {
+			return err
+		}
+		if !hasAccess {
+			return errs.ErrNoAccess
+		}
+	}
+	return nil
+}
+
+// resolveQuery превращает условия фильтра в запрос с абсолютными датами.
+// Граница deadline_to включает весь указанный день
+func resolveQuery(def models.Definition, userID uuid.UUID, now time.Time, loc *time.Location) (models.TaskQuery, error) {
+	query := models.TaskQuery{
+		UserID:     userID,
+		ProjectIDs: def.ProjectIDs,
+		Statuses:   def.Statuses,
+		Importance: def.Importance,
+		OnlyMine:   def.OnlyMine,
+		Text:       def.Text,
+	}
+
+	if def.Overdue {
+		overdueAt := now
+		query.OverdueAt = &overdueAt
+	}
+
+	if def.DeadlineFrom != nil {
+		from, err := resolveDate(*def.DeadlineFrom, now, loc)
+		if err != nil {
+			return models.TaskQuery{}, err
+		}
+		query.DeadlineFrom = &from
+	}
+
+	if def.DeadlineTo != nil {
+		to, err := resolveDate(*def.DeadlineTo, now, loc)
+		if err != nil {
+			return models.TaskQuery{}, err
+		}
+		before := to.AddDate(0, 0, 1)
+		query.DeadlineBefore = &before
+	}
+
+	return query, nil
+}
+
+// resolveDate возвращает начало дня, заданного границей, в часовом поясе loc
+func resolveDate(bound models.DateBound, now time.Time, loc *time.Location) (time.Time, error) {
+	if bound.Days != nil {
+		local := now.In(loc)
+		return time.Date(local.Year(), local.Month(), local.Day()+*bound.Days, 0, 0, 0, 0, loc), nil
+	}
+
+	date, err := time.ParseInLocation(models.DateLayout, bound.Date, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return date, nil
+}
+
+func toDefinitionModel(def dto.FilterDefinitionDTO) models.Definition {
+	return models.Definition{
+		ProjectIDs:   def.ProjectIDs,
+		Statuses:     def.Statuses,
+		Importance:   def.Importance,
+		OnlyMine:     def.OnlyMine,
+		Overdue:      def.Overdue,
+		DeadlineFrom: toDateBoundModel(def.DeadlineFrom),
+		DeadlineTo:   toDateBoundModel(def.DeadlineTo),
+		Text:         def.Text,
+	}
+}
+
+func toDateBoundModel(bound *dto.DateBoundDTO) *models.DateBound {
+	if bound == nil {
+		return nil
+	}
+	return &models.DateBound{Date: bound.Date, Days: bound.Days}
+}
+
+func toDateBoundDTO(bound *models.DateBound) *dto.DateBoundDTO {
+	if bound == nil {
+		return nil
+	}
+	return &dto.DateBoundDTO{Date: bound.Date, Days: bound.Days}
+}
+
+func toSavedFilterDTO(filter *models.SavedFilter) *dto.SavedFilterDTO {
+	return &dto.SavedFilterDTO{
+		ID:   filter.ID,
+		Name: filter.Name,
+		Definition: dto.FilterDefinitionDTO{
+			ProjectIDs:   filter.Definition.ProjectIDs,
+			Statuses:     filter.Definition.Statuses,
+			Importance:   filter.Definition.Importance,
+			OnlyMine:     filter.Definition.OnlyMine,
+			Overdue:      filter.Definition.Overdue,
+			DeadlineFrom: toDateBoundDTO(filter.Definition.DeadlineFrom),
+			DeadlineTo:   toDateBoundDTO(filter.Definition.DeadlineTo),
+			Text:         filter.Definition.Text,
+		},
+		CreatedAt: filter.CreatedAt,
+		UpdatedAt: filter.UpdatedAt,
+	}
+}
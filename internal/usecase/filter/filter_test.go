@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/filter"
+	taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+	usermodels "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/filter"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestResolveQuery(t *testing.T) {
+	userID := uuid.New()
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+
+	// 22:30 UTC 10 марта - в Москве уже 11 марта
+	now := time.Date(2025, 3, 10, 22, 30, 0, 0, time.UTC)
+
+	def := models.Definition{
+		Overdue:      true,
+		DeadlineFrom: &models.DateBound{Days: intPtr(0)},
+		DeadlineTo:   &models.DateBound{Days: intPtr(7)},
+	}
+
+	query, err := resolveQuery(def, userID, now, moscow)
+	assert.NoError(t, err)
+
+	assert.Equal(t, userID, query.UserID)
+	assert.Equal(t, now, *query.OverdueAt)
+	assert.True(t, query.DeadlineFrom.Equal(time.Date(2025, 3, 10, 21, 0, 0, 0, time.UTC)))
+	assert.True(t, query.DeadlineBefore.Equal(time.Date(2025, 3, 18, 21, 0, 0, 0, time.UTC)))
+
+	query, err = resolveQuery(def, userID, now, time.UTC)
+	assert.NoError(t, err)
+	assert.True(t, query.DeadlineFrom.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)))
+
+	absolute := models.Definition{
+		DeadlineFrom: &models.DateBound{Date: "2025-01-01"},
+		DeadlineTo:   &models.DateBound{Date: "2025-01-31"},
+	}
+	query, err = resolveQuery(absolute, userID, now, moscow)
+	assert.NoError(t, err)
+	assert.Nil(t, query.OverdueAt)
+	assert.True(t, query.DeadlineFrom.Equal(time.Date(2024, 12, 31, 21, 0, 0, 0, time.UTC)))
+	assert.True(t, query.DeadlineBefore.Equal(time.Date(2025, 1, 31, 21, 0, 0, 0, time.UTC)))
+}
+
+func TestFilterUsecase_CreateFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockFilterRepository(ctrl)
+	mockProjectRepo := mocks.NewMockFilterProjectRepository(ctrl)
+	mockUserRepo := mocks.NewMockFilterUserRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo, mockUserRepo)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	ctx := logctx.WithLogger(context.WithValue(context.Background(), domains.UserIDKey{}, userID.String()), logctx.NewLogger())
+
+	req := &dto.PostFilterDTO{
+		Name: "  Проект  ",
+		Definition: dto.FilterDefinitionDTO{
+			ProjectIDs: []uuid.UUID{projectID},
+			DeadlineTo: &dto.DateBoundDTO{Days: intPtr(7)},
+		},
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+		mockRepo.EXPECT().CreateFilter(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, filter *models.SavedFilter) error {
+				assert.Equal(t, userID, filter.UserID)
+				assert.Equal(t, "Проект", filter.Name)
+				assert.Equal(t, 7, *filter.Definition.DeadlineTo.Days)
+				return nil
+			})
+
+		result, err := uc.CreateFilter(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Проект", result.Name)
+		assert.Equal(t, []uuid.UUID{projectID}, result.Definition.ProjectIDs)
+	})
+
+	t.Run("no access to project", func(t *testing.T) {
+		mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(false, nil)
+
+		result, err := uc.CreateFilter(ctx, req)
+
+		assert.ErrorIs(t, err, errs.ErrNoAccess)
+		assert.Nil(t, result)
+	})
+}
+
+func TestFilterUsecase_GetFilterTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockFilterRepository(ctrl)
+	mockProjectRepo := mocks.NewMockFilterProjectRepository(ctrl)
+	mockUserRepo := mocks.NewMockFilterUserRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo, mockUserRepo)
+
+	userID := uuid.New()
+	filterID := uuid.New()
+	ctx := logctx.WithLogger(context.WithValue(context.Background(), domains.UserIDKey{}, userID.String()), logctx.NewLogger())
+
+	t.Run("successful execution", func(t *testing.T) {
+		mockRepo.EXPECT().GetFilterByID(gomock.Any(), filterID, userID).Return(&models.SavedFilter{
+			ID:     filterID,
+			UserID: userID,
+			Definition: models.Definition{
+				Importance: []int{3},
+				DeadlineTo: &models.DateBound{Days: intPtr(7)},
+			},
+		}, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).
+			Return(&usermodels.User{ID: userID, Timezone: "Asia/Vladivostok"}, nil)
+		mockRepo.EXPECT().GetTasksByQuery(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query models.TaskQuery) ([]*taskmodels.Task, error) {
+				assert.Equal(t, userID, query.UserID)
+				assert.Equal(t, []int{3}, query.Importance)
+				assert.NotNil(t, query.DeadlineBefore)
+				assert.Nil(t, query.DeadlineFrom)
+				assert.Equal(t, "Asia/Vladivostok", query.DeadlineBefore.Location().String())
+				return []*taskmodels.Task{{ID: uuid.New(), Title: "Задача", Importance: 3}}, nil
+			})
+
+		tasks, err := uc.GetFilterTasks(ctx, filterID)
+
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, "Задача", tasks[0].Title)
+	})
+
+	t.Run("filter not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetFilterByID(gomock.Any(), filterID, userID).Return(nil, errs.ErrNotFound)
+
+		tasks, err := uc.GetFilterTasks(ctx, filterID)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.Nil(t, tasks)
+	})
+}
@@ -0,0 +1,51 @@
+package helpers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/link"
+)
+
+const maxLinkTitleLength = 255
+
+var (
+	titleLinkPattern = regexp.MustCompile(`\[\[([^\[\]\n]+)\]\]`)
+	idLinkPattern    = regexp.MustCompile(`(?:^|[^\w&/])#([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})\b`)
+)
+
+// ParseReferences находит в тексте ссылки [[Название]] и #uuid.
+// Повторы отбрасываются, названия сравниваются без учета регистра
+func ParseReferences(text string) models.References {
+	var refs models.References
+
+	seenTitles := make(map[string]struct{})
+	for _, match := range titleLinkPattern.FindAllStringSubmatch(text, -1) {
+		title := strings.TrimSpace(match[1])
+		if title == "" || len(title) > maxLinkTitleLength {
+			continue
+		}
+		key := strings.ToLower(title)
+		if _, ok := seenTitles[key]; ok {
+			continue
+		}
+		seenTitles[key] = struct{}{}
+		refs.Titles = append(refs.Titles, title)
+	}
+
+	seenIDs := make(map[uuid.UUID]struct{})
+	for _, match := range idLinkPattern.FindAllStringSubmatch(text, -1) {
+		id, err := uuid.Parse(match[1])
+		if err != nil {
+			continue
+		}
+		if _, ok := seenIDs[id]; ok {
+			continue
+		}
+		seenIDs[id] = struct{}{}
+		refs.IDs = append(refs.IDs, id)
+	}
+
+	return refs
+}
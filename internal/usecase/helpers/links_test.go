@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/link"
+)
+
+func TestParseReferences(t *testing.T) {
+	taskID := uuid.MustParse("0b7c6a52-3f0e-4d43-9d8e-2f0c9f5e7a11")
+	noteID := uuid.MustParse("5d1e2c3b-4a59-4f68-8e7d-6c5b4a392817")
+
+	tests := []struct {
+		name     string
+		text     string
+		expected models.References
+	}{
+		{
+			name:     "no references",
+			text:     "plain text with [single] brackets and # hash",
+			expected: models.References{},
+		},
+		{
+			name: "title references",
+			text: "see [[Release plan]] and [[ Fix login ]]",
+			expected: models.References{
+				Titles: []string{"Release plan", "Fix login"},
+			},
+		},
+		{
+			name: "duplicate titles ignore case",
+			text: "[[Release plan]], [[release PLAN]]",
+			expected: models.References{
+				Titles: []string{"Release plan"},
+			},
+		},
+		{
+			name:     "empty and multiline titles are skipped",
+			text:     "[[   ]] [[first\nsecond]]",
+			expected: models.References{},
+		},
+		{
+			name: "id references",
+			text: "blocked by #" + taskID.String() + ", notes in #" + noteID.String() + ".",
+			expected: models.References{
+				IDs: []uuid.UUID{taskID, noteID},
+			},
+		},
+		{
+			name: "upper case id at line start",
+			text: "#0B7C6A52-3F0E-4D43-9D8E-2F0C9F5E7A11 again #" + taskID.String(),
+			expected: models.References{
+				IDs: []uuid.UUID{taskID},
+			},
+		},
+		{
+			name:     "url fragments and words are not references",
+			text:     "https://example.com/#" + taskID.String() + " and word#" + noteID.String(),
+			expected: models.References{},
+		},
+		{
+			name: "mixed references",
+			text: "[[Design]] depends on #" + taskID.String(),
+			expected: models.References{
+				IDs:    []uuid.UUID{taskID},
+				Titles: []string{"Design"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseReferences(tt.text))
+		})
+	}
+}
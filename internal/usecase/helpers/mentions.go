@@ -0,0 +1,53 @@
+package helpers
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern - @login, где login - от 3 до 50 латинских букв, цифр и
+// подчеркиваний, как при регистрации. Перед @ не должно быть буквы, точки
+// или слеша, чтобы адреса почты и ссылки не считались упоминаниями
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@./])@([A-Za-z0-9_]{3,50})\b`)
+
+// ParseMentions находит в тексте упоминания @login и возвращает логины
+// в нижнем регистре без повторов
+func ParseMentions(text string) []string {
+	var logins []string
+	seen := make(map[string]struct{})
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		login := strings.ToLower(match[1])
+		if _, ok := seen[login]; ok {
+			continue
+		}
+		seen[login] = struct{}{}
+		logins = append(logins, login)
+	}
+	return logins
+}
+
+// ReplaceMentions заменяет упоминания @login результатом replace. Логин
+// передается в нижнем регистре; если replace возвращает false, упоминание
+// остается как есть. Остальной текст не меняется
+func ReplaceMentions(text string, replace func(login, mention string) (string, bool)) string {
+	matches := mentionPattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		// m[2]:m[3] - логин, перед ним всегда стоит @
+		start, end := m[2]-1, m[3]
+		replacement, ok := replace(strings.ToLower(text[m[2]:m[3]]), text[start:end])
+		if !ok {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(replacement)
+		last = end
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
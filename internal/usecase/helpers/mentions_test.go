@@ -0,0 +1,61 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "no mentions",
+			text:     "plain text @ and @ab",
+			expected: nil,
+		},
+		{
+			name:     "mentions at start, inside and after punctuation",
+			text:     "@anna please review, cc (@Ivan_P),@olga.",
+			expected: []string{"anna", "ivan_p", "olga"},
+		},
+		{
+			name:     "duplicates ignore case",
+			text:     "@Anna and @anna",
+			expected: []string{"anna"},
+		},
+		{
+			name:     "emails and urls are not mentions",
+			text:     "mail anna@example.com or https://example.com/@anna",
+			expected: nil,
+		},
+		{
+			name:     "too long login",
+			text:     "@" + "a123456789b123456789c123456789d123456789e123456789f",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseMentions(tt.text))
+		})
+	}
+}
+
+func TestReplaceMentions(t *testing.T) {
+	known := map[string]string{"anna": "A", "ivan": "I"}
+
+	result := ReplaceMentions("hi @Anna, @bob and @ivan!", func(login, mention string) (string, bool) {
+		id, ok := known[login]
+		if !ok {
+			return "", false
+		}
+		return "[" + mention + "|" + id + "]", true
+	})
+
+	assert.Equal(t, "hi [@Anna|A], @bob and [@ivan|I]!", result)
+}
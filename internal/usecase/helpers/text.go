@@ -0,0 +1,11 @@
+package helpers
+
+import "unicode/utf8"
+
+// Truncate обрезает строку до maxRunes символов, не разрывая многобайтовые символы
+func Truncate(s string, maxRunes int) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	return string([]rune(s)[:maxRunes])
+}
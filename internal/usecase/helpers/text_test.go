@@ -0,0 +1,13 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "короткая", Truncate("короткая", 10))
+	assert.Equal(t, "длин", Truncate("длинная строка", 4))
+	assert.Equal(t, "", Truncate("строка", 0))
+}
@@ -0,0 +1,378 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/importjob"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/importjob"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const (
+	// Сколько последних заданий возвращается в списке
+	jobsLimit = 20
+	// Как часто удаляются старые задания
+	cleanupInterval = time.Hour
+	// Сообщение задания, запись которого не удалась. Подробности - в логах
+	commitFailedMessage = "failed to import records, no changes were made"
+)
+
+//go:generate mockgen -source=importjob.go -destination=../mocks/importjob_mocks.go -package=mocks ImportRepository,ImportProjectRepository
+type ImportRepository interface {
+	CreateJob(ctx context.Context, job *models.Job) error
+	GetJob(ctx context.Context, projectID, jobID uuid.UUID) (*models.Job, error)
+	GetJobs(ctx context.Context, projectID uuid.UUID, limit int) ([]*models.Job, error)
+	ClaimJob(ctx context.Context, now, staleBefore time.Time) (*models.Job, error)
+	SaveValidation(ctx context.Context, jobID uuid.UUID, report *models.Report, now time.Time) error
+	RequestCommit(ctx context.Context, projectID, jobID uuid.UUID, now time.Time) error
+	CommitImport(ctx context.Context, job *models.Job, items []models.Item, now time.Time) error
+	FailJob(ctx context.Context, jobID uuid.UUID, message string, now time.Time) error
+	DeleteFinished(ctx context.Context, before time.Time) (int64, error)
+}
+
+type ImportProjectRepository interface {
+	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
+}
+
+// ImportUsecase импортирует задачи и заметки из файлов. Загрузка только ставит задание
+// в очередь: файл проверяется и записывается фоновым обработчиком (Run)
+type ImportUsecase struct {
+	repo        ImportRepository
+	projectRepo ImportProjectRepository
+	cfg         *config.ImportConfig
+}
+
+func New(repo ImportRepository, projectRepo ImportProjectRepository, cfg *config.ImportConfig) *ImportUsecase {
+	return &ImportUsecase{
+		repo:        repo,
+		projectRepo: projectRepo,
+		cfg:         cfg,
+	}
+}
+
+// CreateImport сохраняет файл и ставит задание на проверку
+func (uc *ImportUsecase) CreateImport(ctx context.Context, projectID uuid.UUID, source, fileName string, mapping dto.ImportMappingDTO, content io.Reader) (*dto.ImportJobDTO, error) {
+	const op = "ImportUsecase.CreateImport"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", projectID).
+		WithField("source", source)
+
+	userID, err := uc.checkAccess(ctx, projectID)
+	if err != nil {
+		logger.WithError(err).Warn("import is not allowed")
+		return nil, err
+	}
+
+	file, err := io.ReadAll(io.LimitReader(content, uc.cfg.MaxFileSize+1))
+	if err != nil {
+		logger.WithError(err).Warn("failed to read import file")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if int64(len(file)) > uc.cfg.MaxFileSize {
+		logger.Warn("import file is too large")
+		return nil, errs.ErrFileTooLarge
+	}
+
+	now := time.Now().UTC()
+	job := &models.Job{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		UserID:    userID,
+		Source:    source,
+		Status:    models.StatusQueued,
+		FileName:  fileName,
+		File:      file,
+		Mapping: models.Mapping{
+			Columns:  mapping.Columns,
+			Statuses: mapping.Statuses,
+			Labels:   mapping.Labels,
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.repo.CreateJob(ctx, job); err != nil {
+		logger.WithError(err).Error("failed to create import job")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := toJobDTO(job)
+	return &result, nil
+}
+
+// GetImport возвращает состояние задания и отчет проверки
+func (uc *ImportUsecase) GetImport(ctx context.Context, projectID, jobID uuid.UUID) (*dto.ImportJobDTO, error) {
+	const op = "ImportUsecase.GetImport"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("jobID", jobID)
+
+	if _, err := uc.checkAccess(ctx, projectID); err != nil {
+		logger.WithError(err).Warn("import is not allowed")
+		return nil, err
+	}
+
+	job, err := uc.repo.GetJob(ctx, projectID, jobID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get import job")
+		return nil, err
+	}
+
+	result := toJobDTO(job)
+	return &result, nil
+}
+
+// GetImports возвращает последние задания проекта
+func (uc *ImportUsecase) GetImports(ctx context.Context, projectID uuid.UUID) ([]dto.ImportJobDTO, error) {
+	const op = "ImportUsecase.GetImports"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	if _, err := uc.checkAccess(ctx, projectID); err != nil {
+		logger.WithError(err).Warn("import is not allowed")
+		return nil, err
+	}
+
+	jobs, err := uc.repo.GetJobs(ctx, projectID, jobsLimit)
+	if err != nil {
+		logger.WithError(err).Error("failed to get import jobs")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]dto.ImportJobDTO, 0, len(jobs))
+	for _, job := range jobs {
+		result = append(result, toJobDTO(job))
+	}
+	return result, nil
+}
+
+// CommitImport подтверждает импорт проверенного файла. Запись выполняет обработчик;
+// файл с ошибками в записях не импортируется
+func (uc *ImportUsecase) CommitImport(ctx context.Context, projectID, jobID uuid.UUID) (*dto.ImportJobDTO, error) {
+	const op = "ImportUsecase.CommitImport"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("jobID", jobID)
+
+	if _, err := uc.checkAccess(ctx, projectID); err != nil {
+		logger.WithError(err).Warn("import is not allowed")
+		return nil, err
+	}
+
+	job, err := uc.repo.GetJob(ctx, projectID, jobID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get import job")
+		return nil, err
+	}
+	if job.Status != models.StatusValidated {
+		logger.WithField("status", job.Status).Warn("import job is not validated")
+		return nil, errs.ErrImportNotReady
+	}
+	if job.Report != nil && job.Report.ErrorCount > 0 {
+		logger.Warn("import job has validation errors")
+		return nil, errs.ErrImportHasErrors
+	}
+
+	now := time.Now().UTC()
+	if err := uc.repo.RequestCommit(ctx, projectID, jobID, now); err != nil {
+		logger.WithError(err).Warn("failed to request import commit")
+		return nil, err
+	}
+
+	job.Status = models.StatusPendingCommit
+	job.Processed = 0
+	job.UpdatedAt = now
+	result := toJobDTO(job)
+	return &result, nil
+}
+
+// Run обрабатывает очередь заданий, пока не отменен ctx. После пустой
+// очереди ждет PollInterval и раз в час удаляет старые задания
+func (uc *ImportUsecase) Run(ctx context.Context) {
+	logger := logctx.GetLogger(ctx).WithField("op", "ImportUsecase.Run")
+
+	ticker := time.NewTicker(uc.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		for ctx.Err() == nil {
+			processed, err := uc.ProcessNext(ctx)
+			if err != nil {
+				logger.WithError(err).Error("failed to process import job")
+				break
+			}
+			if !processed {
+				break
+			}
+		}
+
+		if time.Since(lastCleanup) >= cleanupInterval {
+			if _, err := uc.Cleanup(ctx); err != nil {
+				logger.WithError(err).Error("failed to clean up import jobs")
+			}
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessNext берет одно задание из очереди и выполняет очередной шаг:
+// проверку файла или запись. false - очередь пуста
+func (uc *ImportUsecase) ProcessNext(ctx context.Context) (bool, error) {
+	const op = "ImportUsecase.ProcessNext"
+
+	now := time.Now().UTC()
+	job, err := uc.repo.ClaimJob(ctx, now, now.Add(-uc.cfg.StaleAfter))
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("jobID", job.ID).
+		WithField("status", job.Status)
+
+	// Разобранные записи не хранятся: перед записью файл разбирается заново
+	items, report, err := parseFile(job.Source, job.File, job.Mapping, uc.cfg.MaxItems)
+	if err != nil {
+		logger.WithError(err).Warn("failed to parse import file")
+		if err := uc.repo.FailJob(ctx, job.ID, err.Error(), time.Now().UTC()); err != nil {
+			return true, fmt.Errorf("%s: %w", op, err)
+		}
+		return true, nil
+	}
+
+	switch job.Status {
+	case models.StatusValidating:
+		if err := uc.repo.SaveValidation(ctx, job.ID, report, time.Now().UTC()); err != nil {
+			return true, fmt.Errorf("%s: %w", op, err)
+		}
+		logger.WithField("total", report.Total).
+			WithField("errors", report.ErrorCount).
+			Info("import file validated")
+
+	case models.StatusImporting:
+		if report.ErrorCount > 0 {
+			if err := uc.repo.FailJob(ctx, job.ID, errs.ErrImportHasErrors.Error(), time.Now().UTC()); err != nil {
+				return true, fmt.Errorf("%s: %w", op, err)
+			}
+			return true, nil
+		}
+
+		parseReferences(items)
+		if err := uc.repo.CommitImport(ctx, job, items, time.Now().UTC()); err != nil {
+			// При остановке сервиса задание не проваливается: после StaleAfter
+			// его возьмет обработчик другого экземпляра
+			if ctx.Err() != nil {
+				return true, fmt.Errorf("%s: %w", op, err)
+			}
+			// Задание брошено по StaleAfter и уже записывается другим обработчиком
+			if errors.Is(err, errs.ErrImportClaimLost) {
+				logger.Warn("import job was claimed by another worker")
+				return true, nil
+			}
+			logger.WithError(err).Error("failed to commit import")
+			if err := uc.repo.FailJob(ctx, job.ID, commitFailedMessage, time.Now().UTC()); err != nil {
+				return true, fmt.Errorf("%s: %w", op, err)
+			}
+			return true, nil
+		}
+		logger.WithField("tasks", report.Tasks).
+			WithField("notes", report.Notes).
+			Info("import committed")
+	}
+	return true, nil
+}
+
+// Cleanup удаляет задания, завершенные раньше Retention, и возвращает их число
+func (uc *ImportUsecase) Cleanup(ctx context.Context) (int64, error) {
+	const op = "ImportUsecase.Cleanup"
+
+	count, err := uc.repo.DeleteFinished(ctx, time.Now().UTC().Add(-uc.cfg.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return count, nil
+}
+
+// parseReferences разбирает ссылки и упоминания в описаниях записей
+func parseReferences(items []models.Item) {
+	for i := range items {
+		items[i].Links = helpers.ParseReferences(items[i].Description)
+		items[i].Mentions = helpers.ParseMentions(items[i].Description)
+	}
+}
+
+func (uc *ImportUsecase) checkAccess(ctx context.Context, projectID uuid.UUID) (uuid.UUID, error) {
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	hasAccess, err := uc.projectRepo.CheckProjectAccess(ctx, projectID, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !hasAccess {
+		return uuid.Nil, errs.ErrNoAccess
+	}
+	return userID, nil
+}
+
+func toJobDTO(job *models.Job) dto.ImportJobDTO {
+	result := dto.ImportJobDTO{
+		ID:        job.ID,
+		ProjectID: job.ProjectID,
+		UserID:    job.UserID,
+		Source:    job.Source,
+		Status:    job.Status,
+		FileName:  job.FileName,
+		Mapping: dto.ImportMappingDTO{
+			Columns:  job.Mapping.Columns,
+			Statuses: job.Mapping.Statuses,
+			Labels:   job.Mapping.Labels,
+		},
+		Total:      job.Total,
+		Processed:  job.Processed,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		UpdatedAt:  job.UpdatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+
+	switch {
+	case job.Status == models.StatusValidated || job.Status == models.StatusCompleted:
+		result.Progress = 100
+	case job.Status == models.StatusImporting && job.Total > 0:
+		result.Progress = job.Processed * 100 / job.Total
+	}
+
+	if job.Report != nil {
+		report := &dto.ImportReportDTO{
+			Total:            job.Report.Total,
+			Tasks:            job.Report.Tasks,
+			Notes:            job.Report.Notes,
+			Skipped:          job.Report.Skipped,
+			ErrorCount:       job.Report.ErrorCount,
+			Errors:           make([]dto.ImportRowErrorDTO, 0, len(job.Report.Errors)),
+			UnmappedFields:   job.Report.UnmappedFields,
+			UnmappedStatuses: job.Report.UnmappedStatuses,
+			UnmappedLabels:   job.Report.UnmappedLabels,
+		}
+		for _, e := range job.Report.Errors {
+			report.Errors = append(report.Errors, dto.ImportRowErrorDTO{Row: e.Row, Field: e.Field, Message: e.Message})
+		}
+		result.Report = report
+	}
+	return result
+}
@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/importjob"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/importjob"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+var testConfig = &config.ImportConfig{
+	MaxFileSize:  64,
+	MaxItems:     10,
+	PollInterval: time.Second,
+	StaleAfter:   10 * time.Minute,
+	Retention:    7 * 24 * time.Hour,
+}
+
+func TestImportUsecase_CreateImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockImportRepository(ctrl)
+	mockProjectRepo := mocks.NewMockImportProjectRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo, testConfig)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+	mapping := dto.ImportMappingDTO{Labels: map[string]int{"urgent": 3}}
+
+	tests := []struct {
+		name        string
+		content     string
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name:    "success",
+			content: "title\nКупить молоко\n",
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+				mockRepo.EXPECT().CreateJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, job *models.Job) error {
+						assert.Equal(t, models.StatusQueued, job.Status)
+						assert.Equal(t, userID, job.UserID)
+						assert.Equal(t, 3, job.Mapping.Labels["urgent"])
+						assert.Equal(t, "title\nКупить молоко\n", string(job.File))
+						return nil
+					})
+			},
+		},
+		{
+			name:    "no access",
+			content: "title\n",
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(false, nil)
+			},
+			expectedErr: errs.ErrNoAccess,
+		},
+		{
+			name:    "file too large",
+			content: strings.Repeat("x", 65),
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+			},
+			expectedErr: errs.ErrFileTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			job, err := uc.CreateImport(ctx, projectID, models.SourceCSV, "tasks.csv", mapping, strings.NewReader(tt.content))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, models.StatusQueued, job.Status)
+			assert.Equal(t, "tasks.csv", job.FileName)
+		})
+	}
+}
+
+func TestImportUsecase_CommitImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockImportRepository(ctrl)
+	mockProjectRepo := mocks.NewMockImportProjectRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo, testConfig)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	jobID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	tests := []struct {
+		name        string
+		job         *models.Job
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "success",
+			job:  &models.Job{ID: jobID, Status: models.StatusValidated, Total: 2, Report: &models.Report{Tasks: 2}},
+			setupMocks: func() {
+				mockRepo.EXPECT().RequestCommit(gomock.Any(), projectID, jobID, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "still validating",
+			job:         &models.Job{ID: jobID, Status: models.StatusValidating},
+			setupMocks:  func() {},
+			expectedErr: errs.ErrImportNotReady,
+		},
+		{
+			name:        "validation errors",
+			job:         &models.Job{ID: jobID, Status: models.StatusValidated, Report: &models.Report{ErrorCount: 1}},
+			setupMocks:  func() {},
+			expectedErr: errs.ErrImportHasErrors,
+		},
+		{
+			name: "committed concurrently",
+			job:  &models.Job{ID: jobID, Status: models.StatusValidated, Report: &models.Report{}},
+			setupMocks: func() {
+				mockRepo.EXPECT().RequestCommit(gomock.Any(), projectID, jobID, gomock.Any()).Return(errs.ErrImportNotReady)
+			},
+			expectedErr: errs.ErrImportNotReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+			mockRepo.EXPECT().GetJob(gomock.Any(), projectID, jobID).Return(tt.job, nil)
+			tt.setupMocks()
+
+			job, err := uc.CommitImport(ctx, projectID, jobID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, models.StatusPendingCommit, job.Status)
+			assert.Equal(t, 0, job.Progress)
+		})
+	}
+}
+
+func newProcessUsecase(ctrl *gomock.Controller, repo *mocks.MockImportRepository) *ImportUsecase {
+	return New(repo, mocks.NewMockImportProjectRepository(ctrl), testConfig)
+}
+
+func TestImportUsecase_ProcessNext(t *testing.T) {
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+	file := []byte("title,status\nКупить молоко,completed\nПозвонить,\n")
+
+	t.Run("empty queue", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockImportRepository(ctrl)
+		uc := newProcessUsecase(ctrl, mockRepo)
+
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		processed, err := uc.ProcessNext(ctx)
+
+		assert.NoError(t, err)
+		assert.False(t, processed)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockImportRepository(ctrl)
+		uc := newProcessUsecase(ctrl, mockRepo)
+
+		job := &models.Job{ID: uuid.New(), Source: models.SourceCSV, Status: models.StatusValidating, File: file}
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, now, staleBefore time.Time) (*models.Job, error) {
+				assert.Equal(t, testConfig.StaleAfter, now.Sub(staleBefore))
+				return job, nil
+			})
+		mockRepo.EXPECT().SaveValidation(gomock.Any(), job.ID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, jobID uuid.UUID, report *models.Report, now time.Time) error {
+				assert.Equal(t, 2, report.Tasks)
+				assert.Equal(t, 0, report.ErrorCount)
+				return nil
+			})
+
+		processed, err := uc.ProcessNext(ctx)
+
+		assert.NoError(t, err)
+		assert.True(t, processed)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockImportRepository(ctrl)
+		uc := newProcessUsecase(ctrl, mockRepo)
+
+		file := []byte("title,status,description\nКупить молоко,completed,после [[Позвонить]] @Anna\nПозвонить,,\n")
+		job := &models.Job{ID: uuid.New(), UserID: uuid.New(), Source: models.SourceCSV, Status: models.StatusImporting, File: file}
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().CommitImport(gomock.Any(), job, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, job *models.Job, items []models.Item, now time.Time) error {
+				require.Len(t, items, 2)
+				assert.Equal(t, "completed", items[0].Status)
+				assert.Equal(t, "waiting", items[1].Status)
+				// Ссылки разобраны до записи и сохраняются в ее транзакции
+				assert.Equal(t, linkmodels.References{Titles: []string{"Позвонить"}}, items[0].Links)
+				assert.Equal(t, []string{"anna"}, items[0].Mentions)
+				assert.Empty(t, items[1].Mentions)
+				return nil
+			})
+
+		processed, err := uc.ProcessNext(ctx)
+
+		assert.NoError(t, err)
+		assert.True(t, processed)
+	})
+
+	t.Run("import failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockImportRepository(ctrl)
+		uc := newProcessUsecase(ctrl, mockRepo)
+
+		job := &models.Job{ID: uuid.New(), Source: models.SourceCSV, Status: models.StatusImporting, File: file}
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().CommitImport(gomock.Any(), job, gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+		mockRepo.EXPECT().FailJob(gomock.Any(), job.ID, commitFailedMessage, gomock.Any()).Return(nil)
+
+		processed, err := uc.ProcessNext(ctx)
+
+		assert.NoError(t, err)
+		assert.True(t, processed)
+	})
+
+	t.Run("claimed by another worker", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockImportRepository(ctrl)
+		uc := newProcessUsecase(ctrl, mockRepo)
+
+		job := &models.Job{ID: uuid.New(), Source: models.SourceCSV, Status: models.StatusImporting, File: file}
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().CommitImport(gomock.Any(), job, gomock.Any(), gomock.Any()).Return(errs.ErrImportClaimLost)
+
+		processed, err := uc.ProcessNext(ctx)
+
+		assert.NoError(t, err)
+		assert.True(t, processed)
+	})
+
+	t.Run("unreadable file", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockImportRepository(ctrl)
+		uc := newProcessUsecase(ctrl, mockRepo)
+
+		job := &models.Job{ID: uuid.New(), Source: models.SourceTrello, Status: models.StatusValidating, File: []byte("[]")}
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().FailJob(gomock.Any(), job.ID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, jobID uuid.UUID, message string, now time.Time) error {
+				assert.Contains(t, message, "invalid Trello JSON")
+				return nil
+			})
+
+		processed, err := uc.ProcessNext(ctx)
+
+		assert.NoError(t, err)
+		assert.True(t, processed)
+	})
+}
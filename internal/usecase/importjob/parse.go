@@ -0,0 +1,670 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	exportmodels "github.com/lzimin05/course-todo/internal/models/export"
+	models "github.com/lzimin05/course-todo/internal/models/importjob"
+	taskmodels "github.com/lzimin05/course-todo/internal/models/task"
+	exportdto "github.com/lzimin05/course-todo/internal/transport/dto/export"
+)
+
+// Ограничения совпадают с проверками при создании задач и заметок через API
+const (
+	minTitleLength      = 2
+	maxTitleLength      = 100
+	maxNoteDescription  = 5000
+	maxImportance       = 3
+	maxEstimateMinutes  = 600000
+	defaultImportance   = 1
+	defaultNoteFormat   = "plain"
+	minutesPerDay       = 24 * 60
+	trelloCommentAction = "commentCard"
+	trelloCommentsField = "comments"
+)
+
+// csvAliases - распространенные названия колонок, которые сопоставляются без настройки.
+// Сюда же входят колонки CSV-выгрузки проекта
+var csvAliases = map[string]string{
+	"name":        models.FieldTitle,
+	"content":     models.FieldTitle,
+	"record_type": models.FieldType,
+	"kind":        models.FieldType,
+	"due":         models.FieldDeadline,
+	"due_date":    models.FieldDeadline,
+	"priority":    models.FieldImportance,
+	"estimate":    models.FieldEstimateMinutes,
+	"tags":        models.FieldLabels,
+}
+
+// Поля Todoist и Trello с данными, которые не переносятся. Они попадают
+// в отчет, только если в файле заполнены
+var (
+	todoistDroppedFields = []string{"section_id", "parent_id", "assignee_id", "responsible_uid", "deadline", "comment_count", "note_count"}
+	trelloDroppedFields  = []string{"idMembers", "idChecklists", "attachments", "customFieldItems"}
+)
+
+// rawItem - запись источника до проверки. Пустая строка - значение не задано
+type rawItem struct {
+	row         int
+	kind        string
+	title       string
+	description string
+	status      string
+	importance  string
+	deadline    string
+	startAt     string
+	allDay      string
+	estimate    string
+	labels      []string
+	format      string
+}
+
+// parser проверяет записи файла и собирает отчет. Записи с ошибками в items не попадают
+type parser struct {
+	maxItems int
+	columns  map[string]string
+	statuses map[string]string
+	labels   map[string]int
+
+	items            []models.Item
+	report           models.Report
+	unmappedFields   map[string]struct{}
+	unmappedStatuses map[string]struct{}
+	unmappedLabels   map[string]struct{}
+}
+
+// parseFile разбирает файл источника. Ошибка означает, что файл не удалось разобрать
+// целиком; ошибки в отдельных записях попадают в отчет
+func parseFile(source string, data []byte, mapping models.Mapping, maxItems int) ([]models.Item, *models.Report, error) {
+	p := newParser(mapping, maxItems)
+
+	var err error
+	switch source {
+	case models.SourceCSV:
+		err = p.parseCSV(data)
+	case models.SourceTodoist:
+		err = p.parseTodoist(data)
+	case models.SourceTrello:
+		err = p.parseTrello(data)
+	case models.SourceExport:
+		err = p.parseExport(data)
+	default:
+		err = fmt.Errorf("unsupported source %q", source)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.items, p.finish(), nil
+}
+
+func newParser(mapping models.Mapping, maxItems int) *parser {
+	p := &parser{
+		maxItems:         maxItems,
+		columns:          make(map[string]string, len(mapping.Columns)),
+		statuses:         make(map[string]string, len(mapping.Statuses)),
+		labels:           make(map[string]int, len(mapping.Labels)),
+		unmappedFields:   make(map[string]struct{}),
+		unmappedStatuses: make(map[string]struct{}),
+		unmappedLabels:   make(map[string]struct{}),
+	}
+	// Названия колонок, статусов и меток сравниваются без учета регистра
+	for k, v := range mapping.Columns {
+		p.columns[normalize(k)] = v
+	}
+	for k, v := range mapping.Statuses {
+		p.statuses[normalize(k)] = v
+	}
+	for k, v := range mapping.Labels {
+		p.labels[normalize(k)] = v
+	}
+	return p
+}
+
+func (p *parser) parseCSV(data []byte) error {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return errors.New("file is empty")
+	}
+	if err != nil {
+		return fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	fields := make([]string, len(header))
+	for i, name := range header {
+		fields[i] = p.columnField(name)
+	}
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		row, _ := r.FieldPos(0)
+		raw := rawItem{row: row}
+		for i, value := range record {
+			if i >= len(fields) {
+				break
+			}
+			setField(&raw, fields[i], value)
+		}
+		if err := p.add(raw); err != nil {
+			return err
+		}
+	}
+}
+
+// columnField возвращает поле для колонки CSV: из настройки, по совпадению названия
+// или по известному синониму. Пустая строка - колонка не импортируется
+func (p *parser) columnField(name string) string {
+	key := normalize(name)
+	if field, ok := p.columns[key]; ok {
+		return field
+	}
+	for _, field := range models.Fields {
+		if key == field {
+			return field
+		}
+	}
+	if field, ok := csvAliases[key]; ok {
+		return field
+	}
+	if key != "" {
+		p.unmappedFields[strings.TrimSpace(name)] = struct{}{}
+	}
+	return ""
+}
+
+func setField(raw *rawItem, field, value string) {
+	switch field {
+	case models.FieldType:
+		raw.kind = value
+	case models.FieldTitle:
+		raw.title = value
+	case models.FieldDescription:
+		raw.description = value
+	case models.FieldStatus:
+		raw.status = value
+	case models.FieldImportance:
+		raw.importance = value
+	case models.FieldDeadline:
+		raw.deadline = value
+	case models.FieldStartAt:
+		raw.startAt = value
+	case models.FieldAllDay:
+		raw.allDay = value
+	case models.FieldEstimateMinutes:
+		raw.estimate = value
+	case models.FieldLabels:
+		raw.labels = strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' })
+	case models.FieldFormat:
+		raw.format = value
+	}
+}
+
+type todoistDue struct {
+	Date     string `json:"date"`
+	Datetime string `json:"datetime"`
+}
+
+type todoistDuration struct {
+	Amount int    `json:"amount"`
+	Unit   string `json:"unit"`
+}
+
+type todoistTask struct {
+	Content     string           `json:"content"`
+	Description string           `json:"description"`
+	Priority    int              `json:"priority"`
+	Labels      []string         `json:"labels"`
+	Due         *todoistDue      `json:"due"`
+	Duration    *todoistDuration `json:"duration"`
+	IsCompleted bool             `json:"is_completed"`
+	Checked     bool             `json:"checked"`
+}
+
+// parseTodoist принимает массив задач REST API или объект Sync API с полем items
+// (или tasks). Приоритет Todoist 1..4 переводится в важность 1..3
+func (p *parser) parseTodoist(data []byte) error {
+	var elements []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &elements); err != nil {
+			return fmt.Errorf("invalid Todoist JSON: %w", err)
+		}
+	} else {
+		var wrapper struct {
+			Items []json.RawMessage `json:"items"`
+			Tasks []json.RawMessage `json:"tasks"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return fmt.Errorf("invalid Todoist JSON: %w", err)
+		}
+		if wrapper.Items == nil && wrapper.Tasks == nil {
+			return errors.New("file must contain an array of Todoist tasks or items")
+		}
+		elements = append(wrapper.Items, wrapper.Tasks...)
+	}
+
+	for i, element := range elements {
+		raw := rawItem{row: i + 1}
+
+		var task todoistTask
+		if err := json.Unmarshal(element, &task); err != nil {
+			p.report.Total++
+			p.rowError(raw.row, "", "record is not a Todoist task")
+			continue
+		}
+		p.collectDropped(element, todoistDroppedFields)
+
+		raw.title = task.Content
+		raw.description = task.Description
+		raw.labels = task.Labels
+		if task.IsCompleted || task.Checked {
+			raw.status = taskmodels.StatusCompleted
+		}
+		if task.Priority > 0 {
+			raw.importance = strconv.Itoa(min(max(task.Priority-1, 1), maxImportance))
+		}
+		if task.Due != nil {
+			raw.deadline = task.Due.Datetime
+			if raw.deadline == "" {
+				raw.deadline = task.Due.Date
+			}
+		}
+		if task.Duration != nil {
+			switch task.Duration.Unit {
+			case "minute":
+				raw.estimate = strconv.Itoa(task.Duration.Amount)
+			case "day":
+				raw.estimate = strconv.Itoa(task.Duration.Amount * minutesPerDay)
+			}
+		}
+
+		if err := p.add(raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type trelloLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type trelloCard struct {
+	Name        string        `json:"name"`
+	Desc        string        `json:"desc"`
+	IDList      string        `json:"idList"`
+	Due         string        `json:"due"`
+	Start       string        `json:"start"`
+	DueComplete bool          `json:"dueComplete"`
+	Closed      bool          `json:"closed"`
+	Labels      []trelloLabel `json:"labels"`
+}
+
+type trelloBoard struct {
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Cards   []json.RawMessage `json:"cards"`
+	Actions []struct {
+		Type string `json:"type"`
+	} `json:"actions"`
+}
+
+// parseTrello разбирает выгрузку доски Trello. Название списка карточки сопоставляется
+// со статусом, выполненный срок дает статус completed. Архивные карточки пропускаются
+func (p *parser) parseTrello(data []byte) error {
+	var board trelloBoard
+	if err := json.Unmarshal(data, &board); err != nil {
+		return fmt.Errorf("invalid Trello JSON: %w", err)
+	}
+	if board.Cards == nil {
+		return errors.New("file must contain Trello cards")
+	}
+
+	lists := make(map[string]string, len(board.Lists))
+	closedLists := make(map[string]bool)
+	for _, list := range board.Lists {
+		lists[list.ID] = list.Name
+		closedLists[list.ID] = list.Closed
+	}
+	for _, action := range board.Actions {
+		if action.Type == trelloCommentAction {
+			p.unmappedFields[trelloCommentsField] = struct{}{}
+			break
+		}
+	}
+
+	for i, element := range board.Cards {
+		raw := rawItem{row: i + 1}
+
+		var card trelloCard
+		if err := json.Unmarshal(element, &card); err != nil {
+			p.report.Total++
+			p.rowError(raw.row, "", "record is not a Trello card")
+			continue
+		}
+		if card.Closed || closedLists[card.IDList] {
+			p.report.Total++
+			p.report.Skipped++
+			continue
+		}
+		p.collectDropped(element, trelloDroppedFields)
+
+		raw.title = card.Name
+		raw.description = card.Desc
+		raw.deadline = card.Due
+		raw.startAt = card.Start
+		raw.status = lists[card.IDList]
+		if card.DueComplete {
+			raw.status = taskmodels.StatusCompleted
+		}
+		for _, label := range card.Labels {
+			name := label.Name
+			if name == "" {
+				name = label.Color
+			}
+			raw.labels = append(raw.labels, name)
+		}
+
+		if err := p.add(raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseExport разбирает JSON-выгрузку проекта. Участники и сам проект не импортируются
+func (p *parser) parseExport(data []byte) error {
+	var export exportdto.ExportDTO
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("invalid export JSON: %w", err)
+	}
+	if export.Format != exportmodels.Kind {
+		return fmt.Errorf("file is not a %s file", exportmodels.Kind)
+	}
+	if export.Version < 1 || export.Version > exportmodels.Version {
+		return fmt.Errorf("unsupported export version %d", export.Version)
+	}
+
+	row := 0
+	for _, task := range export.Tasks {
+		row++
+		raw := rawItem{
+			row:         row,
+			kind:        models.KindTask,
+			title:       task.Title,
+			description: task.Description,
+			status:      task.Status,
+			importance:  strconv.Itoa(task.Importance),
+			allDay:      strconv.FormatBool(task.AllDay),
+		}
+		if task.Deadline != nil {
+			raw.deadline = task.Deadline.Format(time.RFC3339Nano)
+		}
+		if task.StartAt != nil {
+			raw.startAt = task.StartAt.Format(time.RFC3339Nano)
+		}
+		if task.EstimateMinutes != nil {
+			raw.estimate = strconv.Itoa(*task.EstimateMinutes)
+		}
+		if err := p.add(raw); err != nil {
+			return err
+		}
+	}
+	for _, note := range export.Notes {
+		row++
+		raw := rawItem{
+			row:         row,
+			kind:        models.KindNote,
+			title:       note.Name,
+			description: note.Description,
+			format:      note.Format,
+		}
+		if err := p.add(raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add проверяет запись и добавляет ее к импорту. Ошибка возвращается,
+// только если в файле больше записей, чем разрешено
+func (p *parser) add(raw rawItem) error {
+	p.report.Total++
+
+	var kind string
+	switch normalize(raw.kind) {
+	case "", models.KindTask:
+		kind = models.KindTask
+	case models.KindNote:
+		kind = models.KindNote
+	case exportmodels.RecordProject, exportmodels.RecordMember:
+		p.report.Skipped++
+		return nil
+	default:
+		p.rowError(raw.row, models.FieldType, "type must be task or note")
+		return nil
+	}
+
+	if len(p.items) >= p.maxItems {
+		return fmt.Errorf("file contains more than %d records", p.maxItems)
+	}
+
+	item := models.Item{
+		Row:         raw.row,
+		Kind:        kind,
+		Title:       strings.TrimSpace(raw.title),
+		Description: raw.description,
+	}
+	valid := true
+	fail := func(field, message string) {
+		p.rowError(raw.row, field, message)
+		valid = false
+	}
+
+	if item.Title == "" {
+		fail(models.FieldTitle, "title is required")
+	} else if len(item.Title) < minTitleLength || len(item.Title) > maxTitleLength {
+		fail(models.FieldTitle, fmt.Sprintf("title must be between %d and %d characters", minTitleLength, maxTitleLength))
+	}
+
+	if kind == models.KindNote {
+		if len(item.Description) > maxNoteDescription {
+			fail(models.FieldDescription, fmt.Sprintf("description must be at most %d characters", maxNoteDescription))
+		}
+		item.Format = normalize(raw.format)
+		if item.Format == "" {
+			item.Format = defaultNoteFormat
+		}
+		if item.Format != "plain" && item.Format != "markdown" {
+			fail(models.FieldFormat, "format must be plain or markdown")
+		}
+		if valid {
+			p.items = append(p.items, item)
+			p.report.Notes++
+		}
+		return nil
+	}
+
+	item.Status = p.resolveStatus(raw.status)
+	item.Importance = defaultImportance
+	if importance, ok := p.resolveLabels(raw.labels); ok {
+		item.Importance = importance
+	} else if value := strings.TrimSpace(raw.importance); value != "" {
+		importance, err := strconv.Atoi(value)
+		if err != nil || importance < 1 || importance > maxImportance {
+			fail(models.FieldImportance, fmt.Sprintf("importance must be between 1 and %d", maxImportance))
+		}
+		item.Importance = importance
+	}
+
+	var deadlineAllDay, startAllDay bool
+	if value := strings.TrimSpace(raw.deadline); value != "" {
+		deadline, allDay, err := parseDate(value)
+		if err != nil {
+			fail(models.FieldDeadline, err.Error())
+		}
+		item.Deadline, deadlineAllDay = deadline, allDay
+	}
+	if value := strings.TrimSpace(raw.startAt); value != "" {
+		startAt, allDay, err := parseDate(value)
+		if err != nil {
+			fail(models.FieldStartAt, err.Error())
+		} else {
+			item.StartAt = &startAt
+		}
+		startAllDay = allDay
+	}
+	// Без явного признака задача считается задачей на весь день,
+	// если ее даты заданы без времени
+	item.AllDay = deadlineAllDay || (item.Deadline.IsZero() && startAllDay)
+	if value := strings.TrimSpace(raw.allDay); value != "" {
+		allDay, err := strconv.ParseBool(value)
+		if err != nil {
+			fail(models.FieldAllDay, "all_day must be true or false")
+		}
+		item.AllDay = allDay
+	}
+	if item.StartAt != nil && !item.Deadline.IsZero() {
+		start, end := *item.StartAt, item.Deadline
+		if item.AllDay {
+			start, end = start.Truncate(24*time.Hour), end.Truncate(24*time.Hour)
+		}
+		if start.After(end) {
+			fail(models.FieldStartAt, "start_at must be at or before deadline")
+		}
+	}
+
+	if value := strings.TrimSpace(raw.estimate); value != "" {
+		estimate, err := strconv.Atoi(value)
+		if err != nil || estimate < 0 || estimate > maxEstimateMinutes {
+			fail(models.FieldEstimateMinutes, fmt.Sprintf("estimate_minutes must be between 0 and %d", maxEstimateMinutes))
+		} else {
+			item.EstimateMinutes = &estimate
+		}
+	}
+
+	if valid {
+		p.items = append(p.items, item)
+		p.report.Tasks++
+	}
+	return nil
+}
+
+// resolveStatus сопоставляет статус источника со статусом задачи. Несопоставленный
+// статус попадает в отчет, а задача получает статус waiting
+func (p *parser) resolveStatus(value string) string {
+	key := normalize(value)
+	if key == "" {
+		return taskmodels.StatusWaiting
+	}
+	if status, ok := p.statuses[key]; ok {
+		return status
+	}
+	switch key {
+	case taskmodels.StatusWaiting, taskmodels.StatusInProgress, taskmodels.StatusCompleted:
+		return key
+	}
+	p.unmappedStatuses[strings.TrimSpace(value)] = struct{}{}
+	return taskmodels.StatusWaiting
+}
+
+// resolveLabels возвращает наибольшую важность среди сопоставленных меток
+func (p *parser) resolveLabels(labels []string) (int, bool) {
+	importance, found := 0, false
+	for _, label := range labels {
+		key := normalize(label)
+		if key == "" {
+			continue
+		}
+		value, ok := p.labels[key]
+		if !ok {
+			p.unmappedLabels[strings.TrimSpace(label)] = struct{}{}
+			continue
+		}
+		importance, found = max(importance, value), true
+	}
+	return importance, found
+}
+
+// collectDropped отмечает в отчете заполненные поля записи, которые не импортируются
+func (p *parser) collectDropped(element json.RawMessage, fields []string) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(element, &values); err != nil {
+		return
+	}
+	for _, field := range fields {
+		switch strings.TrimSpace(string(values[field])) {
+		case "", "null", `""`, "[]", "{}", "0", "false":
+			continue
+		}
+		p.unmappedFields[field] = struct{}{}
+	}
+}
+
+func (p *parser) rowError(row int, field, message string) {
+	p.report.ErrorCount++
+	if len(p.report.Errors) < models.MaxReportErrors {
+		p.report.Errors = append(p.report.Errors, models.RowError{Row: row, Field: field, Message: message})
+	}
+}
+
+func (p *parser) finish() *models.Report {
+	report := p.report
+	if report.Errors == nil {
+		report.Errors = []models.RowError{}
+	}
+	report.UnmappedFields = sortedKeys(p.unmappedFields)
+	report.UnmappedStatuses = sortedKeys(p.unmappedStatuses)
+	report.UnmappedLabels = sortedKeys(p.unmappedLabels)
+	return &report
+}
+
+// parseDate разбирает дату в формате RFC 3339 или YYYY-MM-DD. Дата без времени
+// означает весь день и хранится как полночь UTC
+func parseDate(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t.UTC(), false, nil
+	}
+	if t, err := time.Parse("2006-01-02T15:04:05", value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, errors.New("date must be in RFC 3339 or YYYY-MM-DD format")
+}
+
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
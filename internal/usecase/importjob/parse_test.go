@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	models "github.com/lzimin05/course-todo/internal/models/importjob"
+)
+
+func TestParseFile_CSV(t *testing.T) {
+	data := "Title,Status,Due,Tags,Owner\n" +
+		"Купить молоко,done,2026-05-01,\"home,urgent\",me\n" +
+		"\"Подготовить\nотчет\",in_progress,2026-05-02T10:00:00Z,,me\n" +
+		"x,waiting,,,me\n" +
+		"Позвонить,waiting,завтра,,me\n"
+	mapping := models.Mapping{
+		Columns:  map[string]string{"Owner": ""},
+		Statuses: map[string]string{"Done": "completed"},
+		Labels:   map[string]int{"urgent": 3},
+	}
+
+	items, report, err := parseFile(models.SourceCSV, []byte(data), mapping, 100)
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, 2, items[0].Row)
+	assert.Equal(t, "Купить молоко", items[0].Title)
+	assert.Equal(t, "completed", items[0].Status)
+	assert.Equal(t, 3, items[0].Importance)
+	assert.True(t, items[0].AllDay)
+	assert.Equal(t, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), items[0].Deadline)
+
+	assert.Equal(t, 3, items[1].Row)
+	assert.Equal(t, "in_progress", items[1].Status)
+	assert.Equal(t, 1, items[1].Importance)
+	assert.False(t, items[1].AllDay)
+
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 2, report.Tasks)
+	assert.Equal(t, 2, report.ErrorCount)
+	assert.Equal(t, []models.RowError{
+		{Row: 5, Field: "title", Message: "title must be between 2 and 100 characters"},
+		{Row: 6, Field: "deadline", Message: "date must be in RFC 3339 or YYYY-MM-DD format"},
+	}, report.Errors)
+	assert.Equal(t, []string{"home"}, report.UnmappedLabels)
+	assert.Empty(t, report.UnmappedFields)
+	assert.Empty(t, report.UnmappedStatuses)
+}
+
+func TestParseFile_CSVExport(t *testing.T) {
+	data := "record_type,id,name,description,status,importance,deadline,start_at,all_day,estimate_minutes,format,author_id\r\n" +
+		"project,1,Запуск,,,,,,,,,\r\n" +
+		"task,2,Макет,,waiting,2,2026-05-01T00:00:00Z,2026-04-30T00:00:00Z,true,90,,\r\n" +
+		"note,3,Идеи,# Идеи,,,,,,,markdown,\r\n"
+
+	items, report, err := parseFile(models.SourceCSV, []byte(data), models.Mapping{}, 100)
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, models.KindTask, items[0].Kind)
+	assert.True(t, items[0].AllDay)
+	require.NotNil(t, items[0].EstimateMinutes)
+	assert.Equal(t, 90, *items[0].EstimateMinutes)
+	assert.Equal(t, models.KindNote, items[1].Kind)
+	assert.Equal(t, "markdown", items[1].Format)
+
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, []string{"author_id", "id"}, report.UnmappedFields)
+}
+
+func TestParseFile_Todoist(t *testing.T) {
+	data := `{"items": [
+		{"content": "Оплатить счет", "priority": 4, "labels": ["finance"], "due": {"date": "2026-06-01"}, "checked": false, "section_id": "42"},
+		{"content": "Сдать отчет", "priority": 1, "is_completed": true, "due": {"date": "2026-06-02", "datetime": "2026-06-02T09:00:00Z"},
+		 "duration": {"amount": 2, "unit": "day"}, "parent_id": null}
+	]}`
+
+	items, report, err := parseFile(models.SourceTodoist, []byte(data), models.Mapping{}, 100)
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, 3, items[0].Importance)
+	assert.Equal(t, "waiting", items[0].Status)
+	assert.True(t, items[0].AllDay)
+
+	assert.Equal(t, 1, items[1].Importance)
+	assert.Equal(t, "completed", items[1].Status)
+	assert.Equal(t, time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC), items[1].Deadline)
+	require.NotNil(t, items[1].EstimateMinutes)
+	assert.Equal(t, 2*24*60, *items[1].EstimateMinutes)
+
+	assert.Equal(t, []string{"section_id"}, report.UnmappedFields)
+	assert.Equal(t, []string{"finance"}, report.UnmappedLabels)
+}
+
+func TestParseFile_Trello(t *testing.T) {
+	data := `{
+		"lists": [{"id": "l1", "name": "Backlog"}, {"id": "l2", "name": "Doing"}, {"id": "l3", "name": "Old", "closed": true}],
+		"cards": [
+			{"name": "Макет", "desc": "Главная", "idList": "l2", "due": "2026-07-01T12:00:00.000Z", "labels": [{"name": "", "color": "red"}], "idMembers": ["u1"]},
+			{"name": "Идея", "idList": "l1", "due": null, "dueComplete": false, "labels": [], "idMembers": []},
+			{"name": "Готово", "idList": "l1", "dueComplete": true},
+			{"name": "Архив", "idList": "l1", "closed": true},
+			{"name": "Старый список", "idList": "l3"}
+		],
+		"actions": [{"type": "commentCard"}]
+	}`
+	mapping := models.Mapping{
+		Statuses: map[string]string{"doing": "in_progress"},
+		Labels:   map[string]int{"red": 3},
+	}
+
+	items, report, err := parseFile(models.SourceTrello, []byte(data), mapping, 100)
+
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, "in_progress", items[0].Status)
+	assert.Equal(t, 3, items[0].Importance)
+	assert.Equal(t, "waiting", items[1].Status)
+	assert.Equal(t, "completed", items[2].Status)
+
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 2, report.Skipped)
+	assert.Equal(t, []string{"Backlog"}, report.UnmappedStatuses)
+	assert.Equal(t, []string{"comments", "idMembers"}, report.UnmappedFields)
+}
+
+func TestParseFile_Export(t *testing.T) {
+	data := `{"format": "course-todo-export", "version": 1,
+		"tasks": [{"title": "Макет", "status": "completed", "importance": 2, "deadline": "2026-05-01T00:00:00Z", "all_day": true}],
+		"notes": [{"name": "Идеи", "description": "текст", "format": "plain"}]}`
+
+	items, report, err := parseFile(models.SourceExport, []byte(data), models.Mapping{}, 100)
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "completed", items[0].Status)
+	assert.True(t, items[0].AllDay)
+	assert.Equal(t, models.KindNote, items[1].Kind)
+	assert.Equal(t, 1, report.Tasks)
+	assert.Equal(t, 1, report.Notes)
+
+	_, _, err = parseFile(models.SourceExport, []byte(`{"format": "course-todo-export", "version": 2}`), models.Mapping{}, 100)
+	assert.EqualError(t, err, "unsupported export version 2")
+}
+
+func TestParseFile_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		data        string
+		expectedErr string
+	}{
+		{name: "empty CSV", source: models.SourceCSV, data: "", expectedErr: "file is empty"},
+		{name: "too many records", source: models.SourceCSV, data: "title\nОдин\nДва\nТри\n", expectedErr: "file contains more than 2 records"},
+		{name: "broken json", source: models.SourceTodoist, data: "{", expectedErr: "invalid Todoist JSON: unexpected end of JSON input"},
+		{name: "not a board", source: models.SourceTrello, data: `{"name": "board"}`, expectedErr: "file must contain Trello cards"},
+		{name: "foreign json", source: models.SourceExport, data: `{}`, expectedErr: "file is not a course-todo-export file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseFile(tt.source, []byte(tt.data), models.Mapping{}, 2)
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
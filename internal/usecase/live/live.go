@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/config"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	models "github.com/lzimin05/course-todo/internal/models/live"
+	outboxmodels "github.com/lzimin05/course-todo/internal/models/outbox"
+	projectmodels "github.com/lzimin05/course-todo/internal/models/project"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const listenRetryDelay = time.Second
+
+// ErrSlowSubscriber - подписчик не успевал забирать события и был отключен.
+// Клиент переподключается с последним полученным ID и догоняет пропущенное
+var ErrSlowSubscriber = errors.New("subscriber is too slow")
+
+//go:generate mockgen -source=live.go -destination=../mocks/live_mocks.go -package=mocks LiveBroker,LiveOutboxRepository,LiveProjectRepository
+type LiveBroker interface {
+	Publish(ctx context.Context, msg outboxmodels.Message) error
+	Listen(ctx context.Context, handler func(outboxmodels.Message)) error
+}
+
+type LiveOutboxRepository interface {
+	GetPublishedAfter(ctx context.Context, afterSeq int64, projectIDs []uuid.UUID, limit int) ([]outboxmodels.Message, error)
+}
+
+type LiveProjectRepository interface {
+	GetUserProjects(ctx context.Context, userID uuid.UUID) ([]*projectmodels.Project, error)
+}
+
+// LiveHub доставляет события задач, заметок и участников подключенным клиентам.
+// Ретранслятор outbox публикует события в Redis, каждый экземпляр сервиса
+// слушает канал и раздает события своим подписчикам
+type LiveHub struct {
+	broker      LiveBroker
+	outboxRepo  LiveOutboxRepository
+	projectRepo LiveProjectRepository
+	cfg         *config.LiveConfig
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+// subscriber - одно соединение. Набор проектов фиксируется при подключении
+// и меняется по событиям member.added и member.removed самого пользователя
+type subscriber struct {
+	userID   uuid.UUID
+	projects map[uuid.UUID]struct{}
+	events   chan outboxmodels.Message
+	dropped  chan struct{}
+	dropOnce sync.Once
+}
+
+func New(broker LiveBroker, outboxRepo LiveOutboxRepository, projectRepo LiveProjectRepository, cfg *config.LiveConfig) *LiveHub {
+	return &LiveHub{
+		broker:      broker,
+		outboxRepo:  outboxRepo,
+		projectRepo: projectRepo,
+		cfg:         cfg,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// HandleMessage - наблюдатель ретранслятора outbox: передает опубликованное
+// событие всем экземплярам
+func (h *LiveHub) HandleMessage(ctx context.Context, msg outboxmodels.Message) error {
+	if !eventmodels.IsValidType(msg.Type) {
+		return nil
+	}
+	return h.broker.Publish(ctx, msg)
+}
+
+// Run слушает канал Redis и раздает события подписчикам этого экземпляра.
+// После ошибки подписка восстанавливается, завершается при отмене контекста
+func (h *LiveHub) Run(ctx context.Context) {
+	logger := logctx.GetLogger(ctx).WithField("op", "LiveHub.Run")
+
+	for {
+		err := h.broker.Listen(ctx, h.dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.WithError(err).Error("live channel subscription failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+// Stream подписывает текущего пользователя на изменения его проектов и пишет
+// события в w, пока не отменен контекст. afterSeq > 0 - номер последнего
+// полученного события: сначала отправляются пропущенные после него события.
+// Ошибка до w.Open означает, что подписка не создана
+func (h *LiveHub) Stream(ctx context.Context, afterSeq int64, w models.Writer) error {
+	const op = "LiveHub.Stream"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return err
+	}
+
+	projects, err := h.projectRepo.GetUserProjects(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get user projects")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	sub := &subscriber{
+		userID:   userID,
+		projects: make(map[uuid.UUID]struct{}, len(projects)),
+		events:   make(chan outboxmodels.Message, h.cfg.BufferSize),
+		dropped:  make(chan struct{}),
+	}
+	projectIDs := make([]uuid.UUID, 0, len(projects))
+	for _, p := range projects {
+		sub.projects[p.ID] = struct{}{}
+		projectIDs = append(projectIDs, p.ID)
+	}
+
+	// Подписываемся до чтения пропущенного, чтобы не потерять события между
+	// запросом и подпиской. Повторы на стыке отбрасываются по ID
+	h.add(sub)
+	defer h.remove(sub)
+
+	var missed []outboxmodels.Message
+	reset := false
+	if afterSeq > 0 && len(projectIDs) > 0 {
+		missed, err = h.outboxRepo.GetPublishedAfter(ctx, afterSeq, projectIDs, h.cfg.ReplayLimit+1)
+		if err != nil {
+			logger.WithError(err).Error("failed to get missed events")
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if len(missed) > h.cfg.ReplayLimit {
+			missed, reset = nil, true
+		}
+	}
+
+	if err := w.Open(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if reset {
+		if err := w.WriteReset(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	sent := make(map[uuid.UUID]struct{}, len(missed))
+	for _, msg := range missed {
+		if err := w.WriteEvent(msg); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		sent[msg.ID] = struct{}{}
+	}
+
+	heartbeat := time.NewTicker(h.cfg.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.dropped:
+			logger.Warn("live subscriber dropped, buffer is full")
+			return ErrSlowSubscriber
+		case <-heartbeat.C:
+			if err := w.WriteHeartbeat(); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		case msg := <-sub.events:
+			if _, ok := sent[msg.ID]; ok {
+				continue
+			}
+			if err := w.WriteEvent(msg); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+}
+
+func (h *LiveHub) add(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribers[sub] = struct{}{}
+}
+
+func (h *LiveHub) remove(sub *subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, sub)
+}
+
+// dispatch передает событие подписчикам проекта. Подписчик с заполненным
+// буфером отключается, чтобы не задерживать остальных
+func (h *LiveHub) dispatch(msg outboxmodels.Message) {
+	var member eventmodels.MemberData
+	if msg.Type == eventmodels.TypeMemberAdded || msg.Type == eventmodels.TypeMemberRemoved {
+		var event struct {
+			Data eventmodels.MemberData `json:"data"`
+		}
+		if err := json.Unmarshal(msg.Payload, &event); err == nil {
+			member = event.Data
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		if msg.Type == eventmodels.TypeMemberAdded && member.UserID == sub.userID {
+			sub.projects[msg.ProjectID] = struct{}{}
+		}
+		if _, ok := sub.projects[msg.ProjectID]; !ok {
+			continue
+		}
+
+		select {
+		case sub.events <- msg:
+		default:
+			sub.dropOnce.Do(func() { close(sub.dropped) })
+		}
+
+		if msg.Type == eventmodels.TypeMemberRemoved && member.UserID == sub.userID {
+			delete(sub.projects, msg.ProjectID)
+		}
+	}
+}
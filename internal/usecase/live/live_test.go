@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	outboxmodels "github.com/lzimin05/course-todo/internal/models/outbox"
+	projectmodels "github.com/lzimin05/course-todo/internal/models/project"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+// recordingWriter запоминает отправленное и отменяет подписку после stopAfter событий
+type recordingWriter struct {
+	onOpen    func()
+	stopAfter int
+	cancel    context.CancelFunc
+
+	opened bool
+	resets int
+	events []outboxmodels.Message
+}
+
+func (w *recordingWriter) Open() error {
+	w.opened = true
+	if w.onOpen != nil {
+		w.onOpen()
+	}
+	return nil
+}
+
+func (w *recordingWriter) WriteEvent(msg outboxmodels.Message) error {
+	w.events = append(w.events, msg)
+	if len(w.events) >= w.stopAfter {
+		w.cancel()
+	}
+	return nil
+}
+
+func (w *recordingWriter) WriteReset() error {
+	w.resets++
+	return nil
+}
+
+func (w *recordingWriter) WriteHeartbeat() error {
+	return nil
+}
+
+func newTestConfig() *config.LiveConfig {
+	return &config.LiveConfig{
+		Heartbeat:   time.Minute,
+		BufferSize:  8,
+		ReplayLimit: 2,
+	}
+}
+
+func message(seq int64, eventType string, projectID uuid.UUID, data any) outboxmodels.Message {
+	event := eventmodels.New(eventType, projectID, uuid.New(), data)
+	payload, _ := json.Marshal(event)
+	return outboxmodels.Message{ID: event.ID, PublishedSeq: seq, Type: eventType, ProjectID: projectID, Payload: payload}
+}
+
+func TestLiveHub_Stream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOutbox := mocks.NewMockLiveOutboxRepository(ctrl)
+	mockProjects := mocks.NewMockLiveProjectRepository(ctrl)
+	hub := New(nil, mockOutbox, mockProjects, newTestConfig())
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	otherProjectID := uuid.New()
+	baseCtx := context.WithValue(logctx.WithLogger(context.Background(), logctx.NewLogger()), domains.UserIDKey{}, userID.String())
+
+	t.Run("replays missed events and skips duplicates", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(baseCtx)
+		defer cancel()
+
+		missed := message(11, eventmodels.TypeTaskCreated, projectID, nil)
+		live := message(12, eventmodels.TypeTaskUpdated, projectID, nil)
+
+		mockProjects.EXPECT().GetUserProjects(gomock.Any(), userID).
+			Return([]*projectmodels.Project{{ID: projectID}}, nil)
+		mockOutbox.EXPECT().GetPublishedAfter(gomock.Any(), int64(10), []uuid.UUID{projectID}, 3).
+			Return([]outboxmodels.Message{missed}, nil)
+
+		w := &recordingWriter{stopAfter: 2, cancel: cancel, onOpen: func() {
+			hub.dispatch(missed)
+			hub.dispatch(message(13, eventmodels.TypeNoteCreated, otherProjectID, nil))
+			hub.dispatch(live)
+		}}
+
+		err := hub.Stream(ctx, 10, w)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []outboxmodels.Message{missed, live}, w.events)
+		assert.Empty(t, hub.subscribers)
+	})
+
+	t.Run("too many missed events", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(baseCtx)
+		defer cancel()
+
+		live := message(20, eventmodels.TypeTaskDeleted, projectID, nil)
+
+		mockProjects.EXPECT().GetUserProjects(gomock.Any(), userID).
+			Return([]*projectmodels.Project{{ID: projectID}}, nil)
+		mockOutbox.EXPECT().GetPublishedAfter(gomock.Any(), int64(1), gomock.Any(), 3).
+			Return([]outboxmodels.Message{
+				message(2, eventmodels.TypeTaskCreated, projectID, nil),
+				message(3, eventmodels.TypeTaskCreated, projectID, nil),
+				message(4, eventmodels.TypeTaskCreated, projectID, nil),
+			}, nil)
+
+		w := &recordingWriter{stopAfter: 1, cancel: cancel, onOpen: func() { hub.dispatch(live) }}
+
+		err := hub.Stream(ctx, 1, w)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, w.resets)
+		assert.Equal(t, []outboxmodels.Message{live}, w.events)
+	})
+
+	t.Run("follows membership changes", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(baseCtx)
+		defer cancel()
+
+		added := message(30, eventmodels.TypeMemberAdded, otherProjectID,
+			eventmodels.MemberData{ProjectID: otherProjectID, UserID: userID})
+		created := message(31, eventmodels.TypeTaskCreated, otherProjectID, nil)
+		removed := message(32, eventmodels.TypeMemberRemoved, otherProjectID,
+			eventmodels.MemberData{ProjectID: otherProjectID, UserID: userID})
+		afterRemoval := message(33, eventmodels.TypeTaskUpdated, otherProjectID, nil)
+		own := message(34, eventmodels.TypeTaskUpdated, projectID, nil)
+
+		mockProjects.EXPECT().GetUserProjects(gomock.Any(), userID).
+			Return([]*projectmodels.Project{{ID: projectID}}, nil)
+
+		w := &recordingWriter{stopAfter: 4, cancel: cancel, onOpen: func() {
+			for _, msg := range []outboxmodels.Message{added, created, removed, afterRemoval, own} {
+				hub.dispatch(msg)
+			}
+		}}
+
+		err := hub.Stream(ctx, 0, w)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []outboxmodels.Message{added, created, removed, own}, w.events)
+	})
+
+	t.Run("slow subscriber is dropped", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(baseCtx)
+		defer cancel()
+
+		slowHub := New(nil, mockOutbox, mockProjects, &config.LiveConfig{Heartbeat: time.Minute, BufferSize: 1, ReplayLimit: 2})
+		mockProjects.EXPECT().GetUserProjects(gomock.Any(), userID).
+			Return([]*projectmodels.Project{{ID: projectID}}, nil)
+
+		w := &recordingWriter{stopAfter: 10, cancel: cancel, onOpen: func() {
+			slowHub.dispatch(message(40, eventmodels.TypeTaskCreated, projectID, nil))
+			slowHub.dispatch(message(41, eventmodels.TypeTaskCreated, projectID, nil))
+		}}
+
+		err := slowHub.Stream(ctx, 0, w)
+
+		assert.ErrorIs(t, err, ErrSlowSubscriber)
+	})
+
+	t.Run("projects error", func(t *testing.T) {
+		mockProjects.EXPECT().GetUserProjects(gomock.Any(), userID).Return(nil, errors.New("db error"))
+
+		w := &recordingWriter{}
+		err := hub.Stream(baseCtx, 0, w)
+
+		assert.Error(t, err)
+		assert.False(t, w.opened)
+	})
+}
+
+func TestLiveHub_HandleMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBroker := mocks.NewMockLiveBroker(ctrl)
+	hub := New(mockBroker, nil, nil, newTestConfig())
+	ctx := context.Background()
+
+	msg := message(1, eventmodels.TypeNoteUpdated, uuid.New(), nil)
+	mockBroker.EXPECT().Publish(gomock.Any(), msg).Return(nil)
+
+	assert.NoError(t, hub.HandleMessage(ctx, msg))
+	assert.NoError(t, hub.HandleMessage(ctx, outboxmodels.Message{Type: "project.archived"}))
+}
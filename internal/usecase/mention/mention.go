@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/mention"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/mention"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+//go:generate mockgen -source=mention.go -destination=../mocks/mention_mocks.go -package=mocks MentionRepository
+type MentionRepository interface {
+	ResolveMembers(ctx context.Context, projectID uuid.UUID, logins []string) ([]models.MentionedUser, error)
+	GetSourceMentions(ctx context.Context, sourceType string, sourceID uuid.UUID) ([]models.MentionedUser, error)
+	GetUserMentions(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.Mention, error)
+}
+
+// MentionUsecase разбирает упоминания @login в описаниях задач и текстах
+// заметок. Упомянутый участник проекта получает уведомление mentioned
+type MentionUsecase struct {
+	repo MentionRepository
+	cfg  *config.MentionConfig
+}
+
+func New(repo MentionRepository, cfg *config.MentionConfig) *MentionUsecase {
+	return &MentionUsecase{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// CheckMentions проверяет упоминания в тексте перед сохранением. При
+// MENTION_NON_MEMBERS=reject возвращает ErrMentionNotMember со списком логинов,
+// не найденных среди участников проекта
+func (uc *MentionUsecase) CheckMentions(ctx context.Context, projectID uuid.UUID, text string) error {
+	const op = "MentionUsecase.CheckMentions"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	if uc.cfg.NonMembers != config.MentionNonMembersReject {
+		return nil
+	}
+
+	logins := helpers.ParseMentions(text)
+	if len(logins) == 0 {
+		return nil
+	}
+
+	members, err := uc.repo.ResolveMembers(ctx, projectID, logins)
+	if err != nil {
+		logger.WithError(err).Error("failed to resolve mentions")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	found := make(map[string]struct{}, len(members))
+	for _, m := range members {
+		found[m.Login] = struct{}{}
+	}
+	var missing []string
+	for _, login := range logins {
+		if _, ok := found[login]; !ok {
+			missing = append(missing, "@"+login)
+		}
+	}
+	if len(missing) > 0 {
+		logger.Warn("mentioned users are not project members")
+		return fmt.Errorf("%w: %s", errs.ErrMentionNotMember, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// GetSourceMentions возвращает ID упомянутых в источнике пользователей по логину в нижнем регистре
+func (uc *MentionUsecase) GetSourceMentions(ctx context.Context, sourceType string, sourceID uuid.UUID) (map[string]uuid.UUID, error) {
+	const op = "MentionUsecase.GetSourceMentions"
+
+	users, err := uc.repo.GetSourceMentions(ctx, sourceType, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make(map[string]uuid.UUID, len(users))
+	for _, u := range users {
+		result[u.Login] = u.ID
+	}
+	return result, nil
+}
+
+// GetMyMentions возвращает упоминания текущего пользователя, новые первыми
+func (uc *MentionUsecase) GetMyMentions(ctx context.Context, limit, offset int) ([]dto.MentionDTO, error) {
+	const op = "MentionUsecase.GetMyMentions"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return nil, err
+	}
+
+	mentions, err := uc.repo.GetUserMentions(ctx, userID, limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("failed to get mentions")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]dto.MentionDTO, 0, len(mentions))
+	for _, m := range mentions {
+		result = append(result, dto.MentionDTO{
+			SourceType:  m.SourceType,
+			SourceID:    m.SourceID,
+			Title:       m.Title,
+			ProjectID:   m.ProjectID,
+			ProjectName: m.ProjectName,
+			AuthorID:    m.AuthorID,
+			CreatedAt:   m.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/mention"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestMentionUsecase_CheckMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMentionRepository(ctrl)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+	projectID := uuid.New()
+
+	t.Run("ignore mode skips check", func(t *testing.T) {
+		uc := New(mockRepo, &config.MentionConfig{NonMembers: config.MentionNonMembersIgnore})
+
+		assert.NoError(t, uc.CheckMentions(ctx, projectID, "cc @stranger"))
+	})
+
+	uc := New(mockRepo, &config.MentionConfig{NonMembers: config.MentionNonMembersReject})
+
+	t.Run("text without mentions", func(t *testing.T) {
+		assert.NoError(t, uc.CheckMentions(ctx, projectID, "mail me at anna@example.com"))
+	})
+
+	t.Run("all mentioned users are members", func(t *testing.T) {
+		mockRepo.EXPECT().ResolveMembers(gomock.Any(), projectID, []string{"anna"}).
+			Return([]models.MentionedUser{{ID: uuid.New(), Login: "anna"}}, nil)
+
+		assert.NoError(t, uc.CheckMentions(ctx, projectID, "cc @Anna"))
+	})
+
+	t.Run("non-members rejected", func(t *testing.T) {
+		mockRepo.EXPECT().ResolveMembers(gomock.Any(), projectID, []string{"anna", "bob", "eve"}).
+			Return([]models.MentionedUser{{ID: uuid.New(), Login: "anna"}}, nil)
+
+		err := uc.CheckMentions(ctx, projectID, "@anna @bob @eve")
+		assert.ErrorIs(t, err, errs.ErrMentionNotMember)
+		assert.Contains(t, err.Error(), "@bob, @eve")
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.EXPECT().ResolveMembers(gomock.Any(), projectID, []string{"anna"}).
+			Return(nil, errors.New("db error"))
+
+		assert.Error(t, uc.CheckMentions(ctx, projectID, "@anna"))
+	})
+}
+
+func TestMentionUsecase_GetSourceMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMentionRepository(ctrl)
+	uc := New(mockRepo, &config.MentionConfig{})
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	noteID := uuid.New()
+	annaID := uuid.New()
+
+	mockRepo.EXPECT().GetSourceMentions(gomock.Any(), "note", noteID).
+		Return([]models.MentionedUser{{ID: annaID, Login: "anna"}}, nil)
+
+	mentions, err := uc.GetSourceMentions(ctx, "note", noteID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]uuid.UUID{"anna": annaID}, mentions)
+}
+
+func TestMentionUsecase_GetMyMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockMentionRepository(ctrl)
+	uc := New(mockRepo, &config.MentionConfig{})
+
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	t.Run("success", func(t *testing.T) {
+		sourceID := uuid.New()
+		mockRepo.EXPECT().GetUserMentions(gomock.Any(), userID, 20, 0).
+			Return([]models.Mention{{SourceType: "task", SourceID: sourceID, Title: "Release", UserID: userID, CreatedAt: time.Now()}}, nil)
+
+		mentions, err := uc.GetMyMentions(ctx, 20, 0)
+		assert.NoError(t, err)
+		assert.Len(t, mentions, 1)
+		assert.Equal(t, sourceID, mentions[0].SourceID)
+		assert.Equal(t, "Release", mentions[0].Title)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserMentions(gomock.Any(), userID, 20, 0).Return(nil, errors.New("db error"))
+
+		_, err := uc.GetMyMentions(ctx, 20, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("no user in context", func(t *testing.T) {
+		_, err := uc.GetMyMentions(logctx.WithLogger(context.Background(), logctx.NewLogger()), 20, 0)
+		assert.Error(t, err)
+	})
+}
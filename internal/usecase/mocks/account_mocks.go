@@ -0,0 +1,286 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: account.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/account"
+	models0 "github.com/lzimin05/course-todo/internal/models/user"
+)
+
+// MockAccountRepository is a mock of AccountRepository interface.
+type MockAccountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountRepositoryMockRecorder
+}
+
+// MockAccountRepositoryMockRecorder is the mock recorder for MockAccountRepository.
+type MockAccountRepositoryMockRecorder struct {
+	mock *MockAccountRepository
+}
+
+// NewMockAccountRepository creates a new mock instance.
+func NewMockAccountRepository(ctrl *gomock.Controller) *MockAccountRepository {
+	mock := &MockAccountRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountRepository) EXPECT() *MockAccountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccountRepository) DeleteAccount(ctx context.Context, deletion models.Deletion, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, deletion, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccountRepositoryMockRecorder) DeleteAccount(ctx, deletion, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccountRepository)(nil).DeleteAccount), ctx, deletion, now)
+}
+
+// GetProfile mocks base method.
+func (m *MockAccountRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*models.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, userID)
+	ret0, _ := ret[0].(*models.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockAccountRepositoryMockRecorder) GetProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockAccountRepository)(nil).GetProfile), ctx, userID)
+}
+
+// GetProjects mocks base method.
+func (m *MockAccountRepository) GetProjects(ctx context.Context, userID uuid.UUID) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjects", ctx, userID)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjects indicates an expected call of GetProjects.
+func (mr *MockAccountRepositoryMockRecorder) GetProjects(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjects", reflect.TypeOf((*MockAccountRepository)(nil).GetProjects), ctx, userID)
+}
+
+// GetTimeEntries mocks base method.
+func (m *MockAccountRepository) GetTimeEntries(ctx context.Context, userID uuid.UUID) ([]models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeEntries", ctx, userID)
+	ret0, _ := ret[0].([]models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeEntries indicates an expected call of GetTimeEntries.
+func (mr *MockAccountRepositoryMockRecorder) GetTimeEntries(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeEntries", reflect.TypeOf((*MockAccountRepository)(nil).GetTimeEntries), ctx, userID)
+}
+
+// MockAccountUserRepository is a mock of AccountUserRepository interface.
+type MockAccountUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountUserRepositoryMockRecorder
+}
+
+// MockAccountUserRepositoryMockRecorder is the mock recorder for MockAccountUserRepository.
+type MockAccountUserRepositoryMockRecorder struct {
+	mock *MockAccountUserRepository
+}
+
+// NewMockAccountUserRepository creates a new mock instance.
+func NewMockAccountUserRepository(ctrl *gomock.Controller) *MockAccountUserRepository {
+	mock := &MockAccountUserRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountUserRepository) EXPECT() *MockAccountUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockAccountUserRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (*models0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(*models0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockAccountUserRepositoryMockRecorder) GetUserByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockAccountUserRepository)(nil).GetUserByID), ctx, userID)
+}
+
+// MockAccountProjectRepository is a mock of AccountProjectRepository interface.
+type MockAccountProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountProjectRepositoryMockRecorder
+}
+
+// MockAccountProjectRepositoryMockRecorder is the mock recorder for MockAccountProjectRepository.
+type MockAccountProjectRepositoryMockRecorder struct {
+	mock *MockAccountProjectRepository
+}
+
+// NewMockAccountProjectRepository creates a new mock instance.
+func NewMockAccountProjectRepository(ctrl *gomock.Controller) *MockAccountProjectRepository {
+	mock := &MockAccountProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockAccountProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountProjectRepository) EXPECT() *MockAccountProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckProjectAccess mocks base method.
+func (m *MockAccountProjectRepository) CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckProjectAccess", ctx, projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckProjectAccess indicates an expected call of CheckProjectAccess.
+func (mr *MockAccountProjectRepositoryMockRecorder) CheckProjectAccess(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProjectAccess", reflect.TypeOf((*MockAccountProjectRepository)(nil).CheckProjectAccess), ctx, projectID, userID)
+}
+
+// MockAccountProjectExporter is a mock of AccountProjectExporter interface.
+type MockAccountProjectExporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountProjectExporterMockRecorder
+}
+
+// MockAccountProjectExporterMockRecorder is the mock recorder for MockAccountProjectExporter.
+type MockAccountProjectExporterMockRecorder struct {
+	mock *MockAccountProjectExporter
+}
+
+// NewMockAccountProjectExporter creates a new mock instance.
+func NewMockAccountProjectExporter(ctrl *gomock.Controller) *MockAccountProjectExporter {
+	mock := &MockAccountProjectExporter{ctrl: ctrl}
+	mock.recorder = &MockAccountProjectExporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountProjectExporter) EXPECT() *MockAccountProjectExporterMockRecorder {
+	return m.recorder
+}
+
+// ExportProject mocks base method.
+func (m *MockAccountProjectExporter) ExportProject(ctx context.Context, projectID uuid.UUID, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProject", ctx, projectID, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProject indicates an expected call of ExportProject.
+func (mr *MockAccountProjectExporterMockRecorder) ExportProject(ctx, projectID, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProject", reflect.TypeOf((*MockAccountProjectExporter)(nil).ExportProject), ctx, projectID, format, w)
+}
+
+// MockAccountSessionRevoker is a mock of AccountSessionRevoker interface.
+type MockAccountSessionRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountSessionRevokerMockRecorder
+}
+
+// MockAccountSessionRevokerMockRecorder is the mock recorder for MockAccountSessionRevoker.
+type MockAccountSessionRevokerMockRecorder struct {
+	mock *MockAccountSessionRevoker
+}
+
+// NewMockAccountSessionRevoker creates a new mock instance.
+func NewMockAccountSessionRevoker(ctrl *gomock.Controller) *MockAccountSessionRevoker {
+	mock := &MockAccountSessionRevoker{ctrl: ctrl}
+	mock.recorder = &MockAccountSessionRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountSessionRevoker) EXPECT() *MockAccountSessionRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAccountSessionRevoker) RevokeSessions(ctx context.Context, userID string, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", ctx, userID, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAccountSessionRevokerMockRecorder) RevokeSessions(ctx, userID, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAccountSessionRevoker)(nil).RevokeSessions), ctx, userID, before)
+}
+
+// MockAccountAttachmentCleaner is a mock of AccountAttachmentCleaner interface.
+type MockAccountAttachmentCleaner struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountAttachmentCleanerMockRecorder
+}
+
+// MockAccountAttachmentCleanerMockRecorder is the mock recorder for MockAccountAttachmentCleaner.
+type MockAccountAttachmentCleanerMockRecorder struct {
+	mock *MockAccountAttachmentCleaner
+}
+
+// NewMockAccountAttachmentCleaner creates a new mock instance.
+func NewMockAccountAttachmentCleaner(ctrl *gomock.Controller) *MockAccountAttachmentCleaner {
+	mock := &MockAccountAttachmentCleaner{ctrl: ctrl}
+	mock.recorder = &MockAccountAttachmentCleanerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountAttachmentCleaner) EXPECT() *MockAccountAttachmentCleanerMockRecorder {
+	return m.recorder
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockAccountAttachmentCleaner) PurgeDeleted(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockAccountAttachmentCleanerMockRecorder) PurgeDeleted(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockAccountAttachmentCleaner)(nil).PurgeDeleted), ctx)
+}
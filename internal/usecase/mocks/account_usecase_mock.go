@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: account.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/account"
+)
+
+// MockAccountUsecase is a mock of AccountUsecase interface.
+type MockAccountUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountUsecaseMockRecorder
+}
+
+// MockAccountUsecaseMockRecorder is the mock recorder for MockAccountUsecase.
+type MockAccountUsecaseMockRecorder struct {
+	mock *MockAccountUsecase
+}
+
+// NewMockAccountUsecase creates a new mock instance.
+func NewMockAccountUsecase(ctrl *gomock.Controller) *MockAccountUsecase {
+	mock := &MockAccountUsecase{ctrl: ctrl}
+	mock.recorder = &MockAccountUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountUsecase) EXPECT() *MockAccountUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteAccount mocks base method.
+func (m *MockAccountUsecase) DeleteAccount(ctx context.Context, req dto.DeleteAccountDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockAccountUsecaseMockRecorder) DeleteAccount(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockAccountUsecase)(nil).DeleteAccount), ctx, req)
+}
+
+// ExportAccount mocks base method.
+func (m *MockAccountUsecase) ExportAccount(ctx context.Context, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccount", ctx, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportAccount indicates an expected call of ExportAccount.
+func (mr *MockAccountUsecaseMockRecorder) ExportAccount(ctx, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccount", reflect.TypeOf((*MockAccountUsecase)(nil).ExportAccount), ctx, w)
+}
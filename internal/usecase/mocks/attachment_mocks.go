@@ -0,0 +1,275 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attachment.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/attachment"
+)
+
+// MockAttachmentRepository is a mock of AttachmentRepository interface.
+type MockAttachmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepositoryMockRecorder
+}
+
+// MockAttachmentRepositoryMockRecorder is the mock recorder for MockAttachmentRepository.
+type MockAttachmentRepositoryMockRecorder struct {
+	mock *MockAttachmentRepository
+}
+
+// NewMockAttachmentRepository creates a new mock instance.
+func NewMockAttachmentRepository(ctrl *gomock.Controller) *MockAttachmentRepository {
+	mock := &MockAttachmentRepository{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepository) EXPECT() *MockAttachmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAttachment mocks base method.
+func (m *MockAttachmentRepository) CreateAttachment(ctx context.Context, attachment *models.Attachment, quota int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", ctx, attachment, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockAttachmentRepositoryMockRecorder) CreateAttachment(ctx, attachment, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockAttachmentRepository)(nil).CreateAttachment), ctx, attachment, quota)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentRepository) DeleteAttachment(ctx context.Context, attachmentID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, attachmentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentRepositoryMockRecorder) DeleteAttachment(ctx, attachmentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentRepository)(nil).DeleteAttachment), ctx, attachmentID, userID)
+}
+
+// GetAttachmentByID mocks base method.
+func (m *MockAttachmentRepository) GetAttachmentByID(ctx context.Context, attachmentID, userID uuid.UUID) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentByID", ctx, attachmentID, userID)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentByID indicates an expected call of GetAttachmentByID.
+func (mr *MockAttachmentRepositoryMockRecorder) GetAttachmentByID(ctx, attachmentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentByID", reflect.TypeOf((*MockAttachmentRepository)(nil).GetAttachmentByID), ctx, attachmentID, userID)
+}
+
+// GetAttachmentsByParent mocks base method.
+func (m *MockAttachmentRepository) GetAttachmentsByParent(ctx context.Context, parentType string, parentID, userID uuid.UUID) ([]models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsByParent", ctx, parentType, parentID, userID)
+	ret0, _ := ret[0].([]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentsByParent indicates an expected call of GetAttachmentsByParent.
+func (mr *MockAttachmentRepositoryMockRecorder) GetAttachmentsByParent(ctx, parentType, parentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsByParent", reflect.TypeOf((*MockAttachmentRepository)(nil).GetAttachmentsByParent), ctx, parentType, parentID, userID)
+}
+
+// GetDeletedBlobs mocks base method.
+func (m *MockAttachmentRepository) GetDeletedBlobs(ctx context.Context, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedBlobs", ctx, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedBlobs indicates an expected call of GetDeletedBlobs.
+func (mr *MockAttachmentRepositoryMockRecorder) GetDeletedBlobs(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedBlobs", reflect.TypeOf((*MockAttachmentRepository)(nil).GetDeletedBlobs), ctx, limit)
+}
+
+// GetParentProject mocks base method.
+func (m *MockAttachmentRepository) GetParentProject(ctx context.Context, parentType string, parentID, userID uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParentProject", ctx, parentType, parentID, userID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParentProject indicates an expected call of GetParentProject.
+func (mr *MockAttachmentRepositoryMockRecorder) GetParentProject(ctx, parentType, parentID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParentProject", reflect.TypeOf((*MockAttachmentRepository)(nil).GetParentProject), ctx, parentType, parentID, userID)
+}
+
+// GetProjectUsage mocks base method.
+func (m *MockAttachmentRepository) GetProjectUsage(ctx context.Context, projectID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectUsage", ctx, projectID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectUsage indicates an expected call of GetProjectUsage.
+func (mr *MockAttachmentRepositoryMockRecorder) GetProjectUsage(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectUsage", reflect.TypeOf((*MockAttachmentRepository)(nil).GetProjectUsage), ctx, projectID)
+}
+
+// RemoveDeletedBlobs mocks base method.
+func (m *MockAttachmentRepository) RemoveDeletedBlobs(ctx context.Context, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDeletedBlobs", ctx, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveDeletedBlobs indicates an expected call of RemoveDeletedBlobs.
+func (mr *MockAttachmentRepositoryMockRecorder) RemoveDeletedBlobs(ctx, keys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDeletedBlobs", reflect.TypeOf((*MockAttachmentRepository)(nil).RemoveDeletedBlobs), ctx, keys)
+}
+
+// MockAttachmentProjectRepository is a mock of AttachmentProjectRepository interface.
+type MockAttachmentProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentProjectRepositoryMockRecorder
+}
+
+// MockAttachmentProjectRepositoryMockRecorder is the mock recorder for MockAttachmentProjectRepository.
+type MockAttachmentProjectRepositoryMockRecorder struct {
+	mock *MockAttachmentProjectRepository
+}
+
+// NewMockAttachmentProjectRepository creates a new mock instance.
+func NewMockAttachmentProjectRepository(ctrl *gomock.Controller) *MockAttachmentProjectRepository {
+	mock := &MockAttachmentProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockAttachmentProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentProjectRepository) EXPECT() *MockAttachmentProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckProjectAccess mocks base method.
+func (m *MockAttachmentProjectRepository) CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckProjectAccess", ctx, projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckProjectAccess indicates an expected call of CheckProjectAccess.
+func (mr *MockAttachmentProjectRepositoryMockRecorder) CheckProjectAccess(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProjectAccess", reflect.TypeOf((*MockAttachmentProjectRepository)(nil).CheckProjectAccess), ctx, projectID, userID)
+}
+
+// MockBlobStorage is a mock of BlobStorage interface.
+type MockBlobStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStorageMockRecorder
+}
+
+// MockBlobStorageMockRecorder is the mock recorder for MockBlobStorage.
+type MockBlobStorageMockRecorder struct {
+	mock *MockBlobStorage
+}
+
+// NewMockBlobStorage creates a new mock instance.
+func NewMockBlobStorage(ctrl *gomock.Controller) *MockBlobStorage {
+	mock := &MockBlobStorage{ctrl: ctrl}
+	mock.recorder = &MockBlobStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStorage) EXPECT() *MockBlobStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlobStorage) Delete(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobStorageMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobStorage)(nil).Delete), ctx, key)
+}
+
+// Get mocks base method.
+func (m *MockBlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBlobStorageMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBlobStorage)(nil).Get), ctx, key)
+}
+
+// Put mocks base method.
+func (m *MockBlobStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, r, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobStorageMockRecorder) Put(ctx, key, r, contentType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobStorage)(nil).Put), ctx, key, r, contentType)
+}
+
+// SignedURL mocks base method.
+func (m *MockBlobStorage) SignedURL(ctx context.Context, key, fileName, contentType string, ttl time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignedURL", ctx, key, fileName, contentType, ttl)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignedURL indicates an expected call of SignedURL.
+func (mr *MockBlobStorageMockRecorder) SignedURL(ctx, key, fileName, contentType, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignedURL", reflect.TypeOf((*MockBlobStorage)(nil).SignedURL), ctx, key, fileName, contentType, ttl)
+}
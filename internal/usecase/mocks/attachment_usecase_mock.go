@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attachment.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	url "net/url"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/attachment"
+)
+
+// MockIAttachmentUsecase is a mock of IAttachmentUsecase interface.
+type MockIAttachmentUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAttachmentUsecaseMockRecorder
+}
+
+// MockIAttachmentUsecaseMockRecorder is the mock recorder for MockIAttachmentUsecase.
+type MockIAttachmentUsecaseMockRecorder struct {
+	mock *MockIAttachmentUsecase
+}
+
+// NewMockIAttachmentUsecase creates a new mock instance.
+func NewMockIAttachmentUsecase(ctrl *gomock.Controller) *MockIAttachmentUsecase {
+	mock := &MockIAttachmentUsecase{ctrl: ctrl}
+	mock.recorder = &MockIAttachmentUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAttachmentUsecase) EXPECT() *MockIAttachmentUsecaseMockRecorder {
+	return m.recorder
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockIAttachmentUsecase) DeleteAttachment(ctx context.Context, attachmentID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", ctx, attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockIAttachmentUsecaseMockRecorder) DeleteAttachment(ctx, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockIAttachmentUsecase)(nil).DeleteAttachment), ctx, attachmentID)
+}
+
+// GetAttachments mocks base method.
+func (m *MockIAttachmentUsecase) GetAttachments(ctx context.Context, parentType string, parentID uuid.UUID) ([]*dto.AttachmentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", ctx, parentType, parentID)
+	ret0, _ := ret[0].([]*dto.AttachmentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockIAttachmentUsecaseMockRecorder) GetAttachments(ctx, parentType, parentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockIAttachmentUsecase)(nil).GetAttachments), ctx, parentType, parentID)
+}
+
+// GetDownloadURL mocks base method.
+func (m *MockIAttachmentUsecase) GetDownloadURL(ctx context.Context, attachmentID uuid.UUID) (*dto.AttachmentURLDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDownloadURL", ctx, attachmentID)
+	ret0, _ := ret[0].(*dto.AttachmentURLDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDownloadURL indicates an expected call of GetDownloadURL.
+func (mr *MockIAttachmentUsecaseMockRecorder) GetDownloadURL(ctx, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDownloadURL", reflect.TypeOf((*MockIAttachmentUsecase)(nil).GetDownloadURL), ctx, attachmentID)
+}
+
+// GetProjectUsage mocks base method.
+func (m *MockIAttachmentUsecase) GetProjectUsage(ctx context.Context, projectID uuid.UUID) (*dto.AttachmentUsageDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectUsage", ctx, projectID)
+	ret0, _ := ret[0].(*dto.AttachmentUsageDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectUsage indicates an expected call of GetProjectUsage.
+func (mr *MockIAttachmentUsecaseMockRecorder) GetProjectUsage(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectUsage", reflect.TypeOf((*MockIAttachmentUsecase)(nil).GetProjectUsage), ctx, projectID)
+}
+
+// Upload mocks base method.
+func (m *MockIAttachmentUsecase) Upload(ctx context.Context, parentType string, parentID uuid.UUID, fileName string, content io.Reader) (*dto.AttachmentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, parentType, parentID, fileName, content)
+	ret0, _ := ret[0].(*dto.AttachmentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockIAttachmentUsecaseMockRecorder) Upload(ctx, parentType, parentID, fileName, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockIAttachmentUsecase)(nil).Upload), ctx, parentType, parentID, fileName, content)
+}
+
+// MockSignedFileStorage is a mock of SignedFileStorage interface.
+type MockSignedFileStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignedFileStorageMockRecorder
+}
+
+// MockSignedFileStorageMockRecorder is the mock recorder for MockSignedFileStorage.
+type MockSignedFileStorageMockRecorder struct {
+	mock *MockSignedFileStorage
+}
+
+// NewMockSignedFileStorage creates a new mock instance.
+func NewMockSignedFileStorage(ctrl *gomock.Controller) *MockSignedFileStorage {
+	mock := &MockSignedFileStorage{ctrl: ctrl}
+	mock.recorder = &MockSignedFileStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignedFileStorage) EXPECT() *MockSignedFileStorageMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockSignedFileStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockSignedFileStorageMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockSignedFileStorage)(nil).Get), ctx, key)
+}
+
+// VerifySignedURL mocks base method.
+func (m *MockSignedFileStorage) VerifySignedURL(key string, query url.Values) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySignedURL", key, query)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VerifySignedURL indicates an expected call of VerifySignedURL.
+func (mr *MockSignedFileStorageMockRecorder) VerifySignedURL(key, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySignedURL", reflect.TypeOf((*MockSignedFileStorage)(nil).VerifySignedURL), key, query)
+}
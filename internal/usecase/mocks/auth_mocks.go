@@ -7,8 +7,10 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 	models "github.com/lzimin05/course-todo/internal/models/project"
 	models0 "github.com/lzimin05/course-todo/internal/models/user"
 	jwt "github.com/lzimin05/course-todo/internal/transport/jwt"
@@ -90,6 +92,20 @@ func (m *MockAuthRepository) EXPECT() *MockAuthRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateAppPassword mocks base method.
+func (m *MockAuthRepository) CreateAppPassword(ctx context.Context, password *models0.AppPassword, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAppPassword", ctx, password, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAppPassword indicates an expected call of CreateAppPassword.
+func (mr *MockAuthRepositoryMockRecorder) CreateAppPassword(ctx, password, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAppPassword", reflect.TypeOf((*MockAuthRepository)(nil).CreateAppPassword), ctx, password, passwordHash)
+}
+
 // CreateUser mocks base method.
 func (m *MockAuthRepository) CreateUser(ctx context.Context, login, username, email string, passwordHash []byte) (*models0.User, error) {
 	m.ctrl.T.Helper()
@@ -105,6 +121,35 @@ func (mr *MockAuthRepositoryMockRecorder) CreateUser(ctx, login, username, email
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthRepository)(nil).CreateUser), ctx, login, username, email, passwordHash)
 }
 
+// DeleteAppPassword mocks base method.
+func (m *MockAuthRepository) DeleteAppPassword(ctx context.Context, id, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAppPassword", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAppPassword indicates an expected call of DeleteAppPassword.
+func (mr *MockAuthRepositoryMockRecorder) DeleteAppPassword(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAppPassword", reflect.TypeOf((*MockAuthRepository)(nil).DeleteAppPassword), ctx, id, userID)
+}
+
+// GetAppPasswords mocks base method.
+func (m *MockAuthRepository) GetAppPasswords(ctx context.Context, userID uuid.UUID) ([]models0.AppPassword, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppPasswords", ctx, userID)
+	ret0, _ := ret[0].([]models0.AppPassword)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppPasswords indicates an expected call of GetAppPasswords.
+func (mr *MockAuthRepositoryMockRecorder) GetAppPasswords(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppPasswords", reflect.TypeOf((*MockAuthRepository)(nil).GetAppPasswords), ctx, userID)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockAuthRepository) GetUserByEmail(ctx context.Context, email string) (*models0.User, error) {
 	m.ctrl.T.Helper()
@@ -135,6 +180,21 @@ func (mr *MockAuthRepositoryMockRecorder) GetUserByEmailOrLogin(ctx, emailOrLogi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmailOrLogin", reflect.TypeOf((*MockAuthRepository)(nil).GetUserByEmailOrLogin), ctx, emailOrLogin)
 }
 
+// UseAppPassword mocks base method.
+func (m *MockAuthRepository) UseAppPassword(ctx context.Context, loginOrEmail, passwordHash string, usedAt time.Time) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseAppPassword", ctx, loginOrEmail, passwordHash, usedAt)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseAppPassword indicates an expected call of UseAppPassword.
+func (mr *MockAuthRepositoryMockRecorder) UseAppPassword(ctx, loginOrEmail, passwordHash, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseAppPassword", reflect.TypeOf((*MockAuthRepository)(nil).UseAppPassword), ctx, loginOrEmail, passwordHash, usedAt)
+}
+
 // MockIAuthRedisRepository is a mock of IAuthRedisRepository interface.
 type MockIAuthRedisRepository struct {
 	ctrl     *gomock.Controller
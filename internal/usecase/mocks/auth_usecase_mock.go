@@ -9,6 +9,8 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/auth"
 )
 
 // MockAuthUsecase is a mock of AuthUsecase interface.
@@ -49,6 +51,50 @@ func (mr *MockAuthUsecaseMockRecorder) Authenticate(ctx, login_or_email, passwor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAuthUsecase)(nil).Authenticate), ctx, login_or_email, password)
 }
 
+// CreateAppPassword mocks base method.
+func (m *MockAuthUsecase) CreateAppPassword(ctx context.Context, name string) (*dto.AppPasswordDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAppPassword", ctx, name)
+	ret0, _ := ret[0].(*dto.AppPasswordDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAppPassword indicates an expected call of CreateAppPassword.
+func (mr *MockAuthUsecaseMockRecorder) CreateAppPassword(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAppPassword", reflect.TypeOf((*MockAuthUsecase)(nil).CreateAppPassword), ctx, name)
+}
+
+// DeleteAppPassword mocks base method.
+func (m *MockAuthUsecase) DeleteAppPassword(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAppPassword", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAppPassword indicates an expected call of DeleteAppPassword.
+func (mr *MockAuthUsecaseMockRecorder) DeleteAppPassword(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAppPassword", reflect.TypeOf((*MockAuthUsecase)(nil).DeleteAppPassword), ctx, id)
+}
+
+// GetAppPasswords mocks base method.
+func (m *MockAuthUsecase) GetAppPasswords(ctx context.Context) ([]dto.AppPasswordDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAppPasswords", ctx)
+	ret0, _ := ret[0].([]dto.AppPasswordDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAppPasswords indicates an expected call of GetAppPasswords.
+func (mr *MockAuthUsecaseMockRecorder) GetAppPasswords(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppPasswords", reflect.TypeOf((*MockAuthUsecase)(nil).GetAppPasswords), ctx)
+}
+
 // Logout mocks base method.
 func (m *MockAuthUsecase) Logout(ctx context.Context, token string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,252 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: caldav.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/caldav"
+	models0 "github.com/lzimin05/course-todo/internal/models/task"
+	models1 "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+)
+
+// MockCalDAVRepository is a mock of CalDAVRepository interface.
+type MockCalDAVRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalDAVRepositoryMockRecorder
+}
+
+// MockCalDAVRepositoryMockRecorder is the mock recorder for MockCalDAVRepository.
+type MockCalDAVRepositoryMockRecorder struct {
+	mock *MockCalDAVRepository
+}
+
+// NewMockCalDAVRepository creates a new mock instance.
+func NewMockCalDAVRepository(ctrl *gomock.Controller) *MockCalDAVRepository {
+	mock := &MockCalDAVRepository{ctrl: ctrl}
+	mock.recorder = &MockCalDAVRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalDAVRepository) EXPECT() *MockCalDAVRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetChanges mocks base method.
+func (m *MockCalDAVRepository) GetChanges(ctx context.Context, projectID, userID uuid.UUID, since int64) ([]models.Object, []string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", ctx, projectID, userID, since)
+	ret0, _ := ret[0].([]models.Object)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockCalDAVRepositoryMockRecorder) GetChanges(ctx, projectID, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockCalDAVRepository)(nil).GetChanges), ctx, projectID, userID, since)
+}
+
+// GetCollection mocks base method.
+func (m *MockCalDAVRepository) GetCollection(ctx context.Context, projectID, userID uuid.UUID) (*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollection", ctx, projectID, userID)
+	ret0, _ := ret[0].(*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollection indicates an expected call of GetCollection.
+func (mr *MockCalDAVRepositoryMockRecorder) GetCollection(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollection", reflect.TypeOf((*MockCalDAVRepository)(nil).GetCollection), ctx, projectID, userID)
+}
+
+// GetCollections mocks base method.
+func (m *MockCalDAVRepository) GetCollections(ctx context.Context, userID uuid.UUID) ([]models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", ctx, userID)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockCalDAVRepositoryMockRecorder) GetCollections(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockCalDAVRepository)(nil).GetCollections), ctx, userID)
+}
+
+// GetObjectByName mocks base method.
+func (m *MockCalDAVRepository) GetObjectByName(ctx context.Context, projectID, userID uuid.UUID, name string) (*models.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectByName", ctx, projectID, userID, name)
+	ret0, _ := ret[0].(*models.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectByName indicates an expected call of GetObjectByName.
+func (mr *MockCalDAVRepositoryMockRecorder) GetObjectByName(ctx, projectID, userID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectByName", reflect.TypeOf((*MockCalDAVRepository)(nil).GetObjectByName), ctx, projectID, userID, name)
+}
+
+// GetObjects mocks base method.
+func (m *MockCalDAVRepository) GetObjects(ctx context.Context, projectID, userID uuid.UUID) ([]models.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjects", ctx, projectID, userID)
+	ret0, _ := ret[0].([]models.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjects indicates an expected call of GetObjects.
+func (mr *MockCalDAVRepositoryMockRecorder) GetObjects(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjects", reflect.TypeOf((*MockCalDAVRepository)(nil).GetObjects), ctx, projectID, userID)
+}
+
+// GetObjectsByNames mocks base method.
+func (m *MockCalDAVRepository) GetObjectsByNames(ctx context.Context, projectID, userID uuid.UUID, names []string) ([]models.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectsByNames", ctx, projectID, userID, names)
+	ret0, _ := ret[0].([]models.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectsByNames indicates an expected call of GetObjectsByNames.
+func (mr *MockCalDAVRepositoryMockRecorder) GetObjectsByNames(ctx, projectID, userID, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectsByNames", reflect.TypeOf((*MockCalDAVRepository)(nil).GetObjectsByNames), ctx, projectID, userID, names)
+}
+
+// MockCalDAVTaskUsecase is a mock of CalDAVTaskUsecase interface.
+type MockCalDAVTaskUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalDAVTaskUsecaseMockRecorder
+}
+
+// MockCalDAVTaskUsecaseMockRecorder is the mock recorder for MockCalDAVTaskUsecase.
+type MockCalDAVTaskUsecaseMockRecorder struct {
+	mock *MockCalDAVTaskUsecase
+}
+
+// NewMockCalDAVTaskUsecase creates a new mock instance.
+func NewMockCalDAVTaskUsecase(ctrl *gomock.Controller) *MockCalDAVTaskUsecase {
+	mock := &MockCalDAVTaskUsecase{ctrl: ctrl}
+	mock.recorder = &MockCalDAVTaskUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalDAVTaskUsecase) EXPECT() *MockCalDAVTaskUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateCalDAVTask mocks base method.
+func (m *MockCalDAVTaskUsecase) CreateCalDAVTask(ctx context.Context, req *dto.PostTaskDTO, origin models0.CalDAVOrigin) (*dto.CreateTaskDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCalDAVTask", ctx, req, origin)
+	ret0, _ := ret[0].(*dto.CreateTaskDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCalDAVTask indicates an expected call of CreateCalDAVTask.
+func (mr *MockCalDAVTaskUsecaseMockRecorder) CreateCalDAVTask(ctx, req, origin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCalDAVTask", reflect.TypeOf((*MockCalDAVTaskUsecase)(nil).CreateCalDAVTask), ctx, req, origin)
+}
+
+// DeleteTask mocks base method.
+func (m *MockCalDAVTaskUsecase) DeleteTask(ctx context.Context, taskID, userID uuid.UUID, expectedVersions []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, taskID, userID, expectedVersions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockCalDAVTaskUsecaseMockRecorder) DeleteTask(ctx, taskID, userID, expectedVersions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockCalDAVTaskUsecase)(nil).DeleteTask), ctx, taskID, userID, expectedVersions)
+}
+
+// UpdateTask mocks base method.
+func (m *MockCalDAVTaskUsecase) UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, estimateMinutes *int, startAt *time.Time, allDay bool, taskID, userID uuid.UUID, expectedVersions []int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, expectedVersions)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockCalDAVTaskUsecaseMockRecorder) UpdateTask(ctx, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, expectedVersions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockCalDAVTaskUsecase)(nil).UpdateTask), ctx, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, expectedVersions)
+}
+
+// UpdateTaskStatus mocks base method.
+func (m *MockCalDAVTaskUsecase) UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID, expectedVersions []int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, status, taskID, userID, expectedVersions)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
+func (mr *MockCalDAVTaskUsecaseMockRecorder) UpdateTaskStatus(ctx, status, taskID, userID, expectedVersions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockCalDAVTaskUsecase)(nil).UpdateTaskStatus), ctx, status, taskID, userID, expectedVersions)
+}
+
+// MockCalDAVUserRepository is a mock of CalDAVUserRepository interface.
+type MockCalDAVUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalDAVUserRepositoryMockRecorder
+}
+
+// MockCalDAVUserRepositoryMockRecorder is the mock recorder for MockCalDAVUserRepository.
+type MockCalDAVUserRepositoryMockRecorder struct {
+	mock *MockCalDAVUserRepository
+}
+
+// NewMockCalDAVUserRepository creates a new mock instance.
+func NewMockCalDAVUserRepository(ctrl *gomock.Controller) *MockCalDAVUserRepository {
+	mock := &MockCalDAVUserRepository{ctrl: ctrl}
+	mock.recorder = &MockCalDAVUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalDAVUserRepository) EXPECT() *MockCalDAVUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockCalDAVUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*models1.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models1.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockCalDAVUserRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockCalDAVUserRepository)(nil).GetUserByID), ctx, id)
+}
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: caldav.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/caldav"
+)
+
+// MockCalDAVUsecase is a mock of CalDAVUsecase interface.
+type MockCalDAVUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalDAVUsecaseMockRecorder
+}
+
+// MockCalDAVUsecaseMockRecorder is the mock recorder for MockCalDAVUsecase.
+type MockCalDAVUsecaseMockRecorder struct {
+	mock *MockCalDAVUsecase
+}
+
+// NewMockCalDAVUsecase creates a new mock instance.
+func NewMockCalDAVUsecase(ctrl *gomock.Controller) *MockCalDAVUsecase {
+	mock := &MockCalDAVUsecase{ctrl: ctrl}
+	mock.recorder = &MockCalDAVUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalDAVUsecase) EXPECT() *MockCalDAVUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CalendarData mocks base method.
+func (m *MockCalDAVUsecase) CalendarData(o models.Object) []byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CalendarData", o)
+	ret0, _ := ret[0].([]byte)
+	return ret0
+}
+
+// CalendarData indicates an expected call of CalendarData.
+func (mr *MockCalDAVUsecaseMockRecorder) CalendarData(o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalendarData", reflect.TypeOf((*MockCalDAVUsecase)(nil).CalendarData), o)
+}
+
+// DeleteObject mocks base method.
+func (m *MockCalDAVUsecase) DeleteObject(ctx context.Context, projectID uuid.UUID, name string, cond models.Preconditions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteObject", ctx, projectID, name, cond)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteObject indicates an expected call of DeleteObject.
+func (mr *MockCalDAVUsecaseMockRecorder) DeleteObject(ctx, projectID, name, cond interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObject", reflect.TypeOf((*MockCalDAVUsecase)(nil).DeleteObject), ctx, projectID, name, cond)
+}
+
+// GetChanges mocks base method.
+func (m *MockCalDAVUsecase) GetChanges(ctx context.Context, projectID uuid.UUID, syncToken string) (*models.Changes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", ctx, projectID, syncToken)
+	ret0, _ := ret[0].(*models.Changes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockCalDAVUsecaseMockRecorder) GetChanges(ctx, projectID, syncToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockCalDAVUsecase)(nil).GetChanges), ctx, projectID, syncToken)
+}
+
+// GetCollection mocks base method.
+func (m *MockCalDAVUsecase) GetCollection(ctx context.Context, projectID uuid.UUID) (*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollection", ctx, projectID)
+	ret0, _ := ret[0].(*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollection indicates an expected call of GetCollection.
+func (mr *MockCalDAVUsecaseMockRecorder) GetCollection(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollection", reflect.TypeOf((*MockCalDAVUsecase)(nil).GetCollection), ctx, projectID)
+}
+
+// GetCollections mocks base method.
+func (m *MockCalDAVUsecase) GetCollections(ctx context.Context) ([]models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", ctx)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockCalDAVUsecaseMockRecorder) GetCollections(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockCalDAVUsecase)(nil).GetCollections), ctx)
+}
+
+// GetObject mocks base method.
+func (m *MockCalDAVUsecase) GetObject(ctx context.Context, projectID uuid.UUID, name string) (*models.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObject", ctx, projectID, name)
+	ret0, _ := ret[0].(*models.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObject indicates an expected call of GetObject.
+func (mr *MockCalDAVUsecaseMockRecorder) GetObject(ctx, projectID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObject", reflect.TypeOf((*MockCalDAVUsecase)(nil).GetObject), ctx, projectID, name)
+}
+
+// GetObjects mocks base method.
+func (m *MockCalDAVUsecase) GetObjects(ctx context.Context, projectID uuid.UUID) ([]models.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjects", ctx, projectID)
+	ret0, _ := ret[0].([]models.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjects indicates an expected call of GetObjects.
+func (mr *MockCalDAVUsecaseMockRecorder) GetObjects(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjects", reflect.TypeOf((*MockCalDAVUsecase)(nil).GetObjects), ctx, projectID)
+}
+
+// GetObjectsByNames mocks base method.
+func (m *MockCalDAVUsecase) GetObjectsByNames(ctx context.Context, projectID uuid.UUID, names []string) ([]models.Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObjectsByNames", ctx, projectID, names)
+	ret0, _ := ret[0].([]models.Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObjectsByNames indicates an expected call of GetObjectsByNames.
+func (mr *MockCalDAVUsecaseMockRecorder) GetObjectsByNames(ctx, projectID, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObjectsByNames", reflect.TypeOf((*MockCalDAVUsecase)(nil).GetObjectsByNames), ctx, projectID, names)
+}
+
+// GetPrincipal mocks base method.
+func (m *MockCalDAVUsecase) GetPrincipal(ctx context.Context) (*models.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrincipal", ctx)
+	ret0, _ := ret[0].(*models.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrincipal indicates an expected call of GetPrincipal.
+func (mr *MockCalDAVUsecaseMockRecorder) GetPrincipal(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrincipal", reflect.TypeOf((*MockCalDAVUsecase)(nil).GetPrincipal), ctx)
+}
+
+// PutObject mocks base method.
+func (m *MockCalDAVUsecase) PutObject(ctx context.Context, projectID uuid.UUID, name string, data []byte, cond models.Preconditions) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutObject", ctx, projectID, name, data, cond)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutObject indicates an expected call of PutObject.
+func (mr *MockCalDAVUsecaseMockRecorder) PutObject(ctx, projectID, name, data, cond interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutObject", reflect.TypeOf((*MockCalDAVUsecase)(nil).PutObject), ctx, projectID, name, data, cond)
+}
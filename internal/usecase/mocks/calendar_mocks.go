@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: calendar.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/calendar"
+	models0 "github.com/lzimin05/course-todo/internal/models/user"
+)
+
+// MockCalendarRepository is a mock of CalendarRepository interface.
+type MockCalendarRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarRepositoryMockRecorder
+}
+
+// MockCalendarRepositoryMockRecorder is the mock recorder for MockCalendarRepository.
+type MockCalendarRepositoryMockRecorder struct {
+	mock *MockCalendarRepository
+}
+
+// NewMockCalendarRepository creates a new mock instance.
+func NewMockCalendarRepository(ctrl *gomock.Controller) *MockCalendarRepository {
+	mock := &MockCalendarRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarRepository) EXPECT() *MockCalendarRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteFeed mocks base method.
+func (m *MockCalendarRepository) DeleteFeed(ctx context.Context, feedID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFeed", ctx, feedID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFeed indicates an expected call of DeleteFeed.
+func (mr *MockCalendarRepositoryMockRecorder) DeleteFeed(ctx, feedID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeed", reflect.TypeOf((*MockCalendarRepository)(nil).DeleteFeed), ctx, feedID, userID)
+}
+
+// GetCalendarEntries mocks base method.
+func (m *MockCalendarRepository) GetCalendarEntries(ctx context.Context, userID uuid.UUID, from, to, fromDate, toDate time.Time) ([]models.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarEntries", ctx, userID, from, to, fromDate, toDate)
+	ret0, _ := ret[0].([]models.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarEntries indicates an expected call of GetCalendarEntries.
+func (mr *MockCalendarRepositoryMockRecorder) GetCalendarEntries(ctx, userID, from, to, fromDate, toDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarEntries", reflect.TypeOf((*MockCalendarRepository)(nil).GetCalendarEntries), ctx, userID, from, to, fromDate, toDate)
+}
+
+// GetFeedByTokenHash mocks base method.
+func (m *MockCalendarRepository) GetFeedByTokenHash(ctx context.Context, tokenHash string) (*models.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*models.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedByTokenHash indicates an expected call of GetFeedByTokenHash.
+func (mr *MockCalendarRepositoryMockRecorder) GetFeedByTokenHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedByTokenHash", reflect.TypeOf((*MockCalendarRepository)(nil).GetFeedByTokenHash), ctx, tokenHash)
+}
+
+// GetFeedTasks mocks base method.
+func (m *MockCalendarRepository) GetFeedTasks(ctx context.Context, userID uuid.UUID, projectID *uuid.UUID) ([]models.FeedTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedTasks", ctx, userID, projectID)
+	ret0, _ := ret[0].([]models.FeedTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedTasks indicates an expected call of GetFeedTasks.
+func (mr *MockCalendarRepositoryMockRecorder) GetFeedTasks(ctx, userID, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedTasks", reflect.TypeOf((*MockCalendarRepository)(nil).GetFeedTasks), ctx, userID, projectID)
+}
+
+// GetFeeds mocks base method.
+func (m *MockCalendarRepository) GetFeeds(ctx context.Context, userID uuid.UUID) ([]models.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeds", ctx, userID)
+	ret0, _ := ret[0].([]models.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeds indicates an expected call of GetFeeds.
+func (mr *MockCalendarRepositoryMockRecorder) GetFeeds(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeds", reflect.TypeOf((*MockCalendarRepository)(nil).GetFeeds), ctx, userID)
+}
+
+// ReplaceFeed mocks base method.
+func (m *MockCalendarRepository) ReplaceFeed(ctx context.Context, feed *models.Feed, tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceFeed", ctx, feed, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceFeed indicates an expected call of ReplaceFeed.
+func (mr *MockCalendarRepositoryMockRecorder) ReplaceFeed(ctx, feed, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceFeed", reflect.TypeOf((*MockCalendarRepository)(nil).ReplaceFeed), ctx, feed, tokenHash)
+}
+
+// MockCalendarProjectRepository is a mock of CalendarProjectRepository interface.
+type MockCalendarProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarProjectRepositoryMockRecorder
+}
+
+// MockCalendarProjectRepositoryMockRecorder is the mock recorder for MockCalendarProjectRepository.
+type MockCalendarProjectRepositoryMockRecorder struct {
+	mock *MockCalendarProjectRepository
+}
+
+// NewMockCalendarProjectRepository creates a new mock instance.
+func NewMockCalendarProjectRepository(ctrl *gomock.Controller) *MockCalendarProjectRepository {
+	mock := &MockCalendarProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarProjectRepository) EXPECT() *MockCalendarProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckProjectAccess mocks base method.
+func (m *MockCalendarProjectRepository) CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckProjectAccess", ctx, projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckProjectAccess indicates an expected call of CheckProjectAccess.
+func (mr *MockCalendarProjectRepositoryMockRecorder) CheckProjectAccess(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProjectAccess", reflect.TypeOf((*MockCalendarProjectRepository)(nil).CheckProjectAccess), ctx, projectID, userID)
+}
+
+// MockCalendarUserRepository is a mock of CalendarUserRepository interface.
+type MockCalendarUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarUserRepositoryMockRecorder
+}
+
+// MockCalendarUserRepositoryMockRecorder is the mock recorder for MockCalendarUserRepository.
+type MockCalendarUserRepositoryMockRecorder struct {
+	mock *MockCalendarUserRepository
+}
+
+// NewMockCalendarUserRepository creates a new mock instance.
+func NewMockCalendarUserRepository(ctrl *gomock.Controller) *MockCalendarUserRepository {
+	mock := &MockCalendarUserRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarUserRepository) EXPECT() *MockCalendarUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockCalendarUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*models0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockCalendarUserRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockCalendarUserRepository)(nil).GetUserByID), ctx, id)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: calendar.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/calendar"
+)
+
+// MockCalendarUsecase is a mock of CalendarUsecase interface.
+type MockCalendarUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarUsecaseMockRecorder
+}
+
+// MockCalendarUsecaseMockRecorder is the mock recorder for MockCalendarUsecase.
+type MockCalendarUsecaseMockRecorder struct {
+	mock *MockCalendarUsecase
+}
+
+// NewMockCalendarUsecase creates a new mock instance.
+func NewMockCalendarUsecase(ctrl *gomock.Controller) *MockCalendarUsecase {
+	mock := &MockCalendarUsecase{ctrl: ctrl}
+	mock.recorder = &MockCalendarUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarUsecase) EXPECT() *MockCalendarUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateFeed mocks base method.
+func (m *MockCalendarUsecase) CreateFeed(ctx context.Context, projectID *uuid.UUID) (*dto.CalendarFeedDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeed", ctx, projectID)
+	ret0, _ := ret[0].(*dto.CalendarFeedDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeed indicates an expected call of CreateFeed.
+func (mr *MockCalendarUsecaseMockRecorder) CreateFeed(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeed", reflect.TypeOf((*MockCalendarUsecase)(nil).CreateFeed), ctx, projectID)
+}
+
+// DeleteFeed mocks base method.
+func (m *MockCalendarUsecase) DeleteFeed(ctx context.Context, feedID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFeed", ctx, feedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFeed indicates an expected call of DeleteFeed.
+func (mr *MockCalendarUsecaseMockRecorder) DeleteFeed(ctx, feedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeed", reflect.TypeOf((*MockCalendarUsecase)(nil).DeleteFeed), ctx, feedID)
+}
+
+// GetCalendar mocks base method.
+func (m *MockCalendarUsecase) GetCalendar(ctx context.Context, from, to time.Time) (*dto.CalendarDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendar", ctx, from, to)
+	ret0, _ := ret[0].(*dto.CalendarDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendar indicates an expected call of GetCalendar.
+func (mr *MockCalendarUsecaseMockRecorder) GetCalendar(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendar", reflect.TypeOf((*MockCalendarUsecase)(nil).GetCalendar), ctx, from, to)
+}
+
+// GetFeedICS mocks base method.
+func (m *MockCalendarUsecase) GetFeedICS(ctx context.Context, token, kind string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedICS", ctx, token, kind)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedICS indicates an expected call of GetFeedICS.
+func (mr *MockCalendarUsecaseMockRecorder) GetFeedICS(ctx, token, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedICS", reflect.TypeOf((*MockCalendarUsecase)(nil).GetFeedICS), ctx, token, kind)
+}
+
+// GetFeeds mocks base method.
+func (m *MockCalendarUsecase) GetFeeds(ctx context.Context) ([]dto.CalendarFeedDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeds", ctx)
+	ret0, _ := ret[0].([]dto.CalendarFeedDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeds indicates an expected call of GetFeeds.
+func (mr *MockCalendarUsecaseMockRecorder) GetFeeds(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeds", reflect.TypeOf((*MockCalendarUsecase)(nil).GetFeeds), ctx)
+}
@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: export.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/export"
+)
+
+// MockExportRepository is a mock of ExportRepository interface.
+type MockExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportRepositoryMockRecorder
+}
+
+// MockExportRepositoryMockRecorder is the mock recorder for MockExportRepository.
+type MockExportRepositoryMockRecorder struct {
+	mock *MockExportRepository
+}
+
+// NewMockExportRepository creates a new mock instance.
+func NewMockExportRepository(ctrl *gomock.Controller) *MockExportRepository {
+	mock := &MockExportRepository{ctrl: ctrl}
+	mock.recorder = &MockExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportRepository) EXPECT() *MockExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ExportProject mocks base method.
+func (m *MockExportRepository) ExportProject(ctx context.Context, projectID uuid.UUID, w models.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProject", ctx, projectID, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProject indicates an expected call of ExportProject.
+func (mr *MockExportRepositoryMockRecorder) ExportProject(ctx, projectID, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProject", reflect.TypeOf((*MockExportRepository)(nil).ExportProject), ctx, projectID, w)
+}
+
+// MockExportProjectRepository is a mock of ExportProjectRepository interface.
+type MockExportProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportProjectRepositoryMockRecorder
+}
+
+// MockExportProjectRepositoryMockRecorder is the mock recorder for MockExportProjectRepository.
+type MockExportProjectRepositoryMockRecorder struct {
+	mock *MockExportProjectRepository
+}
+
+// NewMockExportProjectRepository creates a new mock instance.
+func NewMockExportProjectRepository(ctrl *gomock.Controller) *MockExportProjectRepository {
+	mock := &MockExportProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockExportProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportProjectRepository) EXPECT() *MockExportProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckProjectAccess mocks base method.
+func (m *MockExportProjectRepository) CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckProjectAccess", ctx, projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckProjectAccess indicates an expected call of CheckProjectAccess.
+func (mr *MockExportProjectRepositoryMockRecorder) CheckProjectAccess(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProjectAccess", reflect.TypeOf((*MockExportProjectRepository)(nil).CheckProjectAccess), ctx, projectID, userID)
+}
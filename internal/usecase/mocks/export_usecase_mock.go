@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: export.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockExportUsecase is a mock of ExportUsecase interface.
+type MockExportUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportUsecaseMockRecorder
+}
+
+// MockExportUsecaseMockRecorder is the mock recorder for MockExportUsecase.
+type MockExportUsecaseMockRecorder struct {
+	mock *MockExportUsecase
+}
+
+// NewMockExportUsecase creates a new mock instance.
+func NewMockExportUsecase(ctrl *gomock.Controller) *MockExportUsecase {
+	mock := &MockExportUsecase{ctrl: ctrl}
+	mock.recorder = &MockExportUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportUsecase) EXPECT() *MockExportUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ExportProject mocks base method.
+func (m *MockExportUsecase) ExportProject(ctx context.Context, projectID uuid.UUID, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProject", ctx, projectID, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProject indicates an expected call of ExportProject.
+func (mr *MockExportUsecaseMockRecorder) ExportProject(ctx, projectID, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProject", reflect.TypeOf((*MockExportUsecase)(nil).ExportProject), ctx, projectID, format, w)
+}
@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: filter.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/filter"
+	models0 "github.com/lzimin05/course-todo/internal/models/task"
+	models1 "github.com/lzimin05/course-todo/internal/models/user"
+)
+
+// MockFilterRepository is a mock of FilterRepository interface.
+type MockFilterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilterRepositoryMockRecorder
+}
+
+// MockFilterRepositoryMockRecorder is the mock recorder for MockFilterRepository.
+type MockFilterRepositoryMockRecorder struct {
+	mock *MockFilterRepository
+}
+
+// NewMockFilterRepository creates a new mock instance.
+func NewMockFilterRepository(ctrl *gomock.Controller) *MockFilterRepository {
+	mock := &MockFilterRepository{ctrl: ctrl}
+	mock.recorder = &MockFilterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilterRepository) EXPECT() *MockFilterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateFilter mocks base method.
+func (m *MockFilterRepository) CreateFilter(ctx context.Context, filter *models.SavedFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFilter", ctx, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFilter indicates an expected call of CreateFilter.
+func (mr *MockFilterRepositoryMockRecorder) CreateFilter(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilter", reflect.TypeOf((*MockFilterRepository)(nil).CreateFilter), ctx, filter)
+}
+
+// DeleteFilter mocks base method.
+func (m *MockFilterRepository) DeleteFilter(ctx context.Context, filterID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilter", ctx, filterID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilter indicates an expected call of DeleteFilter.
+func (mr *MockFilterRepositoryMockRecorder) DeleteFilter(ctx, filterID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilter", reflect.TypeOf((*MockFilterRepository)(nil).DeleteFilter), ctx, filterID, userID)
+}
+
+// GetFilterByID mocks base method.
+func (m *MockFilterRepository) GetFilterByID(ctx context.Context, filterID, userID uuid.UUID) (*models.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilterByID", ctx, filterID, userID)
+	ret0, _ := ret[0].(*models.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilterByID indicates an expected call of GetFilterByID.
+func (mr *MockFilterRepositoryMockRecorder) GetFilterByID(ctx, filterID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilterByID", reflect.TypeOf((*MockFilterRepository)(nil).GetFilterByID), ctx, filterID, userID)
+}
+
+// GetFiltersByUserID mocks base method.
+func (m *MockFilterRepository) GetFiltersByUserID(ctx context.Context, userID uuid.UUID) ([]*models.SavedFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFiltersByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*models.SavedFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFiltersByUserID indicates an expected call of GetFiltersByUserID.
+func (mr *MockFilterRepositoryMockRecorder) GetFiltersByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiltersByUserID", reflect.TypeOf((*MockFilterRepository)(nil).GetFiltersByUserID), ctx, userID)
+}
+
+// GetTasksByQuery mocks base method.
+func (m *MockFilterRepository) GetTasksByQuery(ctx context.Context, query models.TaskQuery) ([]*models0.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByQuery", ctx, query)
+	ret0, _ := ret[0].([]*models0.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByQuery indicates an expected call of GetTasksByQuery.
+func (mr *MockFilterRepositoryMockRecorder) GetTasksByQuery(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByQuery", reflect.TypeOf((*MockFilterRepository)(nil).GetTasksByQuery), ctx, query)
+}
+
+// UpdateFilter mocks base method.
+func (m *MockFilterRepository) UpdateFilter(ctx context.Context, filter *models.SavedFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilter", ctx, filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFilter indicates an expected call of UpdateFilter.
+func (mr *MockFilterRepositoryMockRecorder) UpdateFilter(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilter", reflect.TypeOf((*MockFilterRepository)(nil).UpdateFilter), ctx, filter)
+}
+
+// MockFilterProjectRepository is a mock of FilterProjectRepository interface.
+type MockFilterProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilterProjectRepositoryMockRecorder
+}
+
+// MockFilterProjectRepositoryMockRecorder is the mock recorder for MockFilterProjectRepository.
+type MockFilterProjectRepositoryMockRecorder struct {
+	mock *MockFilterProjectRepository
+}
+
+// NewMockFilterProjectRepository creates a new mock instance.
+func NewMockFilterProjectRepository(ctrl *gomock.Controller) *MockFilterProjectRepository {
+	mock := &MockFilterProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockFilterProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilterProjectRepository) EXPECT() *MockFilterProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckProjectAccess mocks base method.
+func (m *MockFilterProjectRepository) CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckProjectAccess", ctx, projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckProjectAccess indicates an expected call of CheckProjectAccess.
+func (mr *MockFilterProjectRepositoryMockRecorder) CheckProjectAccess(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProjectAccess", reflect.TypeOf((*MockFilterProjectRepository)(nil).CheckProjectAccess), ctx, projectID, userID)
+}
+
+// MockFilterUserRepository is a mock of FilterUserRepository interface.
+type MockFilterUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilterUserRepositoryMockRecorder
+}
+
+// MockFilterUserRepositoryMockRecorder is the mock recorder for MockFilterUserRepository.
+type MockFilterUserRepositoryMockRecorder struct {
+	mock *MockFilterUserRepository
+}
+
+// NewMockFilterUserRepository creates a new mock instance.
+func NewMockFilterUserRepository(ctrl *gomock.Controller) *MockFilterUserRepository {
+	mock := &MockFilterUserRepository{ctrl: ctrl}
+	mock.recorder = &MockFilterUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilterUserRepository) EXPECT() *MockFilterUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockFilterUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*models1.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models1.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockFilterUserRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockFilterUserRepository)(nil).GetUserByID), ctx, id)
+}
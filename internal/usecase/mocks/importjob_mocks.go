@@ -0,0 +1,206 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: importjob.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/importjob"
+)
+
+// MockImportRepository is a mock of ImportRepository interface.
+type MockImportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepositoryMockRecorder
+}
+
+// MockImportRepositoryMockRecorder is the mock recorder for MockImportRepository.
+type MockImportRepositoryMockRecorder struct {
+	mock *MockImportRepository
+}
+
+// NewMockImportRepository creates a new mock instance.
+func NewMockImportRepository(ctrl *gomock.Controller) *MockImportRepository {
+	mock := &MockImportRepository{ctrl: ctrl}
+	mock.recorder = &MockImportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepository) EXPECT() *MockImportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimJob mocks base method.
+func (m *MockImportRepository) ClaimJob(ctx context.Context, now, staleBefore time.Time) (*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJob", ctx, now, staleBefore)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJob indicates an expected call of ClaimJob.
+func (mr *MockImportRepositoryMockRecorder) ClaimJob(ctx, now, staleBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJob", reflect.TypeOf((*MockImportRepository)(nil).ClaimJob), ctx, now, staleBefore)
+}
+
+// CommitImport mocks base method.
+func (m *MockImportRepository) CommitImport(ctx context.Context, job *models.Job, items []models.Item, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitImport", ctx, job, items, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitImport indicates an expected call of CommitImport.
+func (mr *MockImportRepositoryMockRecorder) CommitImport(ctx, job, items, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitImport", reflect.TypeOf((*MockImportRepository)(nil).CommitImport), ctx, job, items, now)
+}
+
+// CreateJob mocks base method.
+func (m *MockImportRepository) CreateJob(ctx context.Context, job *models.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockImportRepositoryMockRecorder) CreateJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockImportRepository)(nil).CreateJob), ctx, job)
+}
+
+// DeleteFinished mocks base method.
+func (m *MockImportRepository) DeleteFinished(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFinished", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFinished indicates an expected call of DeleteFinished.
+func (mr *MockImportRepositoryMockRecorder) DeleteFinished(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinished", reflect.TypeOf((*MockImportRepository)(nil).DeleteFinished), ctx, before)
+}
+
+// FailJob mocks base method.
+func (m *MockImportRepository) FailJob(ctx context.Context, jobID uuid.UUID, message string, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailJob", ctx, jobID, message, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailJob indicates an expected call of FailJob.
+func (mr *MockImportRepositoryMockRecorder) FailJob(ctx, jobID, message, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailJob", reflect.TypeOf((*MockImportRepository)(nil).FailJob), ctx, jobID, message, now)
+}
+
+// GetJob mocks base method.
+func (m *MockImportRepository) GetJob(ctx context.Context, projectID, jobID uuid.UUID) (*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, projectID, jobID)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockImportRepositoryMockRecorder) GetJob(ctx, projectID, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockImportRepository)(nil).GetJob), ctx, projectID, jobID)
+}
+
+// GetJobs mocks base method.
+func (m *MockImportRepository) GetJobs(ctx context.Context, projectID uuid.UUID, limit int) ([]*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobs", ctx, projectID, limit)
+	ret0, _ := ret[0].([]*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobs indicates an expected call of GetJobs.
+func (mr *MockImportRepositoryMockRecorder) GetJobs(ctx, projectID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobs", reflect.TypeOf((*MockImportRepository)(nil).GetJobs), ctx, projectID, limit)
+}
+
+// RequestCommit mocks base method.
+func (m *MockImportRepository) RequestCommit(ctx context.Context, projectID, jobID uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestCommit", ctx, projectID, jobID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestCommit indicates an expected call of RequestCommit.
+func (mr *MockImportRepositoryMockRecorder) RequestCommit(ctx, projectID, jobID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCommit", reflect.TypeOf((*MockImportRepository)(nil).RequestCommit), ctx, projectID, jobID, now)
+}
+
+// SaveValidation mocks base method.
+func (m *MockImportRepository) SaveValidation(ctx context.Context, jobID uuid.UUID, report *models.Report, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveValidation", ctx, jobID, report, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveValidation indicates an expected call of SaveValidation.
+func (mr *MockImportRepositoryMockRecorder) SaveValidation(ctx, jobID, report, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveValidation", reflect.TypeOf((*MockImportRepository)(nil).SaveValidation), ctx, jobID, report, now)
+}
+
+// MockImportProjectRepository is a mock of ImportProjectRepository interface.
+type MockImportProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportProjectRepositoryMockRecorder
+}
+
+// MockImportProjectRepositoryMockRecorder is the mock recorder for MockImportProjectRepository.
+type MockImportProjectRepositoryMockRecorder struct {
+	mock *MockImportProjectRepository
+}
+
+// NewMockImportProjectRepository creates a new mock instance.
+func NewMockImportProjectRepository(ctrl *gomock.Controller) *MockImportProjectRepository {
+	mock := &MockImportProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockImportProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportProjectRepository) EXPECT() *MockImportProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckProjectAccess mocks base method.
+func (m *MockImportProjectRepository) CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckProjectAccess", ctx, projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckProjectAccess indicates an expected call of CheckProjectAccess.
+func (mr *MockImportProjectRepositoryMockRecorder) CheckProjectAccess(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProjectAccess", reflect.TypeOf((*MockImportProjectRepository)(nil).CheckProjectAccess), ctx, projectID, userID)
+}
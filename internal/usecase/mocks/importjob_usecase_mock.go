@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: importjob.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/importjob"
+)
+
+// MockImportUsecase is a mock of ImportUsecase interface.
+type MockImportUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportUsecaseMockRecorder
+}
+
+// MockImportUsecaseMockRecorder is the mock recorder for MockImportUsecase.
+type MockImportUsecaseMockRecorder struct {
+	mock *MockImportUsecase
+}
+
+// NewMockImportUsecase creates a new mock instance.
+func NewMockImportUsecase(ctrl *gomock.Controller) *MockImportUsecase {
+	mock := &MockImportUsecase{ctrl: ctrl}
+	mock.recorder = &MockImportUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportUsecase) EXPECT() *MockImportUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CommitImport mocks base method.
+func (m *MockImportUsecase) CommitImport(ctx context.Context, projectID, jobID uuid.UUID) (*dto.ImportJobDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitImport", ctx, projectID, jobID)
+	ret0, _ := ret[0].(*dto.ImportJobDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitImport indicates an expected call of CommitImport.
+func (mr *MockImportUsecaseMockRecorder) CommitImport(ctx, projectID, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitImport", reflect.TypeOf((*MockImportUsecase)(nil).CommitImport), ctx, projectID, jobID)
+}
+
+// CreateImport mocks base method.
+func (m *MockImportUsecase) CreateImport(ctx context.Context, projectID uuid.UUID, source, fileName string, mapping dto.ImportMappingDTO, content io.Reader) (*dto.ImportJobDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImport", ctx, projectID, source, fileName, mapping, content)
+	ret0, _ := ret[0].(*dto.ImportJobDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImport indicates an expected call of CreateImport.
+func (mr *MockImportUsecaseMockRecorder) CreateImport(ctx, projectID, source, fileName, mapping, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImport", reflect.TypeOf((*MockImportUsecase)(nil).CreateImport), ctx, projectID, source, fileName, mapping, content)
+}
+
+// GetImport mocks base method.
+func (m *MockImportUsecase) GetImport(ctx context.Context, projectID, jobID uuid.UUID) (*dto.ImportJobDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImport", ctx, projectID, jobID)
+	ret0, _ := ret[0].(*dto.ImportJobDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImport indicates an expected call of GetImport.
+func (mr *MockImportUsecaseMockRecorder) GetImport(ctx, projectID, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImport", reflect.TypeOf((*MockImportUsecase)(nil).GetImport), ctx, projectID, jobID)
+}
+
+// GetImports mocks base method.
+func (m *MockImportUsecase) GetImports(ctx context.Context, projectID uuid.UUID) ([]dto.ImportJobDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImports", ctx, projectID)
+	ret0, _ := ret[0].([]dto.ImportJobDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImports indicates an expected call of GetImports.
+func (mr *MockImportUsecaseMockRecorder) GetImports(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImports", reflect.TypeOf((*MockImportUsecase)(nil).GetImports), ctx, projectID)
+}
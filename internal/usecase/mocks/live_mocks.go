@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: live.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/outbox"
+	models0 "github.com/lzimin05/course-todo/internal/models/project"
+)
+
+// MockLiveBroker is a mock of LiveBroker interface.
+type MockLiveBroker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLiveBrokerMockRecorder
+}
+
+// MockLiveBrokerMockRecorder is the mock recorder for MockLiveBroker.
+type MockLiveBrokerMockRecorder struct {
+	mock *MockLiveBroker
+}
+
+// NewMockLiveBroker creates a new mock instance.
+func NewMockLiveBroker(ctrl *gomock.Controller) *MockLiveBroker {
+	mock := &MockLiveBroker{ctrl: ctrl}
+	mock.recorder = &MockLiveBrokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLiveBroker) EXPECT() *MockLiveBrokerMockRecorder {
+	return m.recorder
+}
+
+// Listen mocks base method.
+func (m *MockLiveBroker) Listen(ctx context.Context, handler func(models.Message)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockLiveBrokerMockRecorder) Listen(ctx, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockLiveBroker)(nil).Listen), ctx, handler)
+}
+
+// Publish mocks base method.
+func (m *MockLiveBroker) Publish(ctx context.Context, msg models.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockLiveBrokerMockRecorder) Publish(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockLiveBroker)(nil).Publish), ctx, msg)
+}
+
+// MockLiveOutboxRepository is a mock of LiveOutboxRepository interface.
+type MockLiveOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLiveOutboxRepositoryMockRecorder
+}
+
+// MockLiveOutboxRepositoryMockRecorder is the mock recorder for MockLiveOutboxRepository.
+type MockLiveOutboxRepositoryMockRecorder struct {
+	mock *MockLiveOutboxRepository
+}
+
+// NewMockLiveOutboxRepository creates a new mock instance.
+func NewMockLiveOutboxRepository(ctrl *gomock.Controller) *MockLiveOutboxRepository {
+	mock := &MockLiveOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockLiveOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLiveOutboxRepository) EXPECT() *MockLiveOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPublishedAfter mocks base method.
+func (m *MockLiveOutboxRepository) GetPublishedAfter(ctx context.Context, afterSeq int64, projectIDs []uuid.UUID, limit int) ([]models.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublishedAfter", ctx, afterSeq, projectIDs, limit)
+	ret0, _ := ret[0].([]models.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublishedAfter indicates an expected call of GetPublishedAfter.
+func (mr *MockLiveOutboxRepositoryMockRecorder) GetPublishedAfter(ctx, afterSeq, projectIDs, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublishedAfter", reflect.TypeOf((*MockLiveOutboxRepository)(nil).GetPublishedAfter), ctx, afterSeq, projectIDs, limit)
+}
+
+// MockLiveProjectRepository is a mock of LiveProjectRepository interface.
+type MockLiveProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLiveProjectRepositoryMockRecorder
+}
+
+// MockLiveProjectRepositoryMockRecorder is the mock recorder for MockLiveProjectRepository.
+type MockLiveProjectRepositoryMockRecorder struct {
+	mock *MockLiveProjectRepository
+}
+
+// NewMockLiveProjectRepository creates a new mock instance.
+func NewMockLiveProjectRepository(ctrl *gomock.Controller) *MockLiveProjectRepository {
+	mock := &MockLiveProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockLiveProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLiveProjectRepository) EXPECT() *MockLiveProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserProjects mocks base method.
+func (m *MockLiveProjectRepository) GetUserProjects(ctx context.Context, userID uuid.UUID) ([]*models0.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProjects", ctx, userID)
+	ret0, _ := ret[0].([]*models0.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProjects indicates an expected call of GetUserProjects.
+func (mr *MockLiveProjectRepositoryMockRecorder) GetUserProjects(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProjects", reflect.TypeOf((*MockLiveProjectRepository)(nil).GetUserProjects), ctx, userID)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: live.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/lzimin05/course-todo/internal/models/live"
+)
+
+// MockLiveUsecase is a mock of LiveUsecase interface.
+type MockLiveUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLiveUsecaseMockRecorder
+}
+
+// MockLiveUsecaseMockRecorder is the mock recorder for MockLiveUsecase.
+type MockLiveUsecaseMockRecorder struct {
+	mock *MockLiveUsecase
+}
+
+// NewMockLiveUsecase creates a new mock instance.
+func NewMockLiveUsecase(ctrl *gomock.Controller) *MockLiveUsecase {
+	mock := &MockLiveUsecase{ctrl: ctrl}
+	mock.recorder = &MockLiveUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLiveUsecase) EXPECT() *MockLiveUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Stream mocks base method.
+func (m *MockLiveUsecase) Stream(ctx context.Context, afterSeq int64, w models.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", ctx, afterSeq, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockLiveUsecaseMockRecorder) Stream(ctx, afterSeq, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockLiveUsecase)(nil).Stream), ctx, afterSeq, w)
+}
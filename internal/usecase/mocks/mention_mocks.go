@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mention.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/mention"
+)
+
+// MockMentionRepository is a mock of MentionRepository interface.
+type MockMentionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionRepositoryMockRecorder
+}
+
+// MockMentionRepositoryMockRecorder is the mock recorder for MockMentionRepository.
+type MockMentionRepositoryMockRecorder struct {
+	mock *MockMentionRepository
+}
+
+// NewMockMentionRepository creates a new mock instance.
+func NewMockMentionRepository(ctrl *gomock.Controller) *MockMentionRepository {
+	mock := &MockMentionRepository{ctrl: ctrl}
+	mock.recorder = &MockMentionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionRepository) EXPECT() *MockMentionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSourceMentions mocks base method.
+func (m *MockMentionRepository) GetSourceMentions(ctx context.Context, sourceType string, sourceID uuid.UUID) ([]models.MentionedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSourceMentions", ctx, sourceType, sourceID)
+	ret0, _ := ret[0].([]models.MentionedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSourceMentions indicates an expected call of GetSourceMentions.
+func (mr *MockMentionRepositoryMockRecorder) GetSourceMentions(ctx, sourceType, sourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSourceMentions", reflect.TypeOf((*MockMentionRepository)(nil).GetSourceMentions), ctx, sourceType, sourceID)
+}
+
+// GetUserMentions mocks base method.
+func (m *MockMentionRepository) GetUserMentions(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMentions", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]models.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMentions indicates an expected call of GetUserMentions.
+func (mr *MockMentionRepositoryMockRecorder) GetUserMentions(ctx, userID, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMentions", reflect.TypeOf((*MockMentionRepository)(nil).GetUserMentions), ctx, userID, limit, offset)
+}
+
+// ResolveMembers mocks base method.
+func (m *MockMentionRepository) ResolveMembers(ctx context.Context, projectID uuid.UUID, logins []string) ([]models.MentionedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveMembers", ctx, projectID, logins)
+	ret0, _ := ret[0].([]models.MentionedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveMembers indicates an expected call of ResolveMembers.
+func (mr *MockMentionRepositoryMockRecorder) ResolveMembers(ctx, projectID, logins interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveMembers", reflect.TypeOf((*MockMentionRepository)(nil).ResolveMembers), ctx, projectID, logins)
+}
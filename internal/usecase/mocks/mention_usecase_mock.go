@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mention.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/mention"
+)
+
+// MockMentionUsecase is a mock of MentionUsecase interface.
+type MockMentionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionUsecaseMockRecorder
+}
+
+// MockMentionUsecaseMockRecorder is the mock recorder for MockMentionUsecase.
+type MockMentionUsecaseMockRecorder struct {
+	mock *MockMentionUsecase
+}
+
+// NewMockMentionUsecase creates a new mock instance.
+func NewMockMentionUsecase(ctrl *gomock.Controller) *MockMentionUsecase {
+	mock := &MockMentionUsecase{ctrl: ctrl}
+	mock.recorder = &MockMentionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionUsecase) EXPECT() *MockMentionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetMyMentions mocks base method.
+func (m *MockMentionUsecase) GetMyMentions(ctx context.Context, limit, offset int) ([]dto.MentionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyMentions", ctx, limit, offset)
+	ret0, _ := ret[0].([]dto.MentionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyMentions indicates an expected call of GetMyMentions.
+func (mr *MockMentionUsecaseMockRecorder) GetMyMentions(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyMentions", reflect.TypeOf((*MockMentionUsecase)(nil).GetMyMentions), ctx, limit, offset)
+}
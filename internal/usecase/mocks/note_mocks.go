@@ -10,7 +10,8 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
-	models "github.com/lzimin05/course-todo/internal/models/note"
+	models "github.com/lzimin05/course-todo/internal/models/link"
+	models0 "github.com/lzimin05/course-todo/internal/models/note"
 )
 
 // MockINoteRepository is a mock of INoteRepository interface.
@@ -36,40 +37,68 @@ func (m *MockINoteRepository) EXPECT() *MockINoteRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateFolder mocks base method.
+func (m *MockINoteRepository) CreateFolder(ctx context.Context, folder *models0.NoteFolder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", ctx, folder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockINoteRepositoryMockRecorder) CreateFolder(ctx, folder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockINoteRepository)(nil).CreateFolder), ctx, folder)
+}
+
 // CreateNote mocks base method.
-func (m *MockINoteRepository) CreateNote(ctx context.Context, projectID, userID uuid.UUID, name, description string) (uuid.UUID, error) {
+func (m *MockINoteRepository) CreateNote(ctx context.Context, projectID, userID uuid.UUID, name, description, format string, refs models.References, mentions []string) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNote", ctx, projectID, userID, name, description)
+	ret := m.ctrl.Call(m, "CreateNote", ctx, projectID, userID, name, description, format, refs, mentions)
 	ret0, _ := ret[0].(uuid.UUID)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateNote indicates an expected call of CreateNote.
-func (mr *MockINoteRepositoryMockRecorder) CreateNote(ctx, projectID, userID, name, description interface{}) *gomock.Call {
+func (mr *MockINoteRepositoryMockRecorder) CreateNote(ctx, projectID, userID, name, description, format, refs, mentions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockINoteRepository)(nil).CreateNote), ctx, projectID, userID, name, description)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockINoteRepository)(nil).CreateNote), ctx, projectID, userID, name, description, format, refs, mentions)
+}
+
+// DeleteFolder mocks base method.
+func (m *MockINoteRepository) DeleteFolder(ctx context.Context, folderID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", ctx, folderID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockINoteRepositoryMockRecorder) DeleteFolder(ctx, folderID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockINoteRepository)(nil).DeleteFolder), ctx, folderID, userID)
 }
 
 // DeleteNote mocks base method.
-func (m *MockINoteRepository) DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error {
+func (m *MockINoteRepository) DeleteNote(ctx context.Context, userID, noteID uuid.UUID, expectedVersions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteNote", ctx, userID, noteID)
+	ret := m.ctrl.Call(m, "DeleteNote", ctx, userID, noteID, expectedVersions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteNote indicates an expected call of DeleteNote.
-func (mr *MockINoteRepositoryMockRecorder) DeleteNote(ctx, userID, noteID interface{}) *gomock.Call {
+func (mr *MockINoteRepositoryMockRecorder) DeleteNote(ctx, userID, noteID, expectedVersions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockINoteRepository)(nil).DeleteNote), ctx, userID, noteID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockINoteRepository)(nil).DeleteNote), ctx, userID, noteID, expectedVersions)
 }
 
 // GetAllNotes mocks base method.
-func (m *MockINoteRepository) GetAllNotes(ctx context.Context, userID uuid.UUID) ([]models.Note, error) {
+func (m *MockINoteRepository) GetAllNotes(ctx context.Context, userID uuid.UUID) ([]models0.Note, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAllNotes", ctx, userID)
-	ret0, _ := ret[0].([]models.Note)
+	ret0, _ := ret[0].([]models0.Note)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -80,11 +109,71 @@ func (mr *MockINoteRepositoryMockRecorder) GetAllNotes(ctx, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllNotes", reflect.TypeOf((*MockINoteRepository)(nil).GetAllNotes), ctx, userID)
 }
 
+// GetFoldersByProject mocks base method.
+func (m *MockINoteRepository) GetFoldersByProject(ctx context.Context, projectID, userID uuid.UUID) ([]models0.NoteFolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoldersByProject", ctx, projectID, userID)
+	ret0, _ := ret[0].([]models0.NoteFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFoldersByProject indicates an expected call of GetFoldersByProject.
+func (mr *MockINoteRepositoryMockRecorder) GetFoldersByProject(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoldersByProject", reflect.TypeOf((*MockINoteRepository)(nil).GetFoldersByProject), ctx, projectID, userID)
+}
+
+// GetNoteByID mocks base method.
+func (m *MockINoteRepository) GetNoteByID(ctx context.Context, noteID, userID uuid.UUID) (*models0.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteByID", ctx, noteID, userID)
+	ret0, _ := ret[0].(*models0.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNoteByID indicates an expected call of GetNoteByID.
+func (mr *MockINoteRepositoryMockRecorder) GetNoteByID(ctx, noteID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteByID", reflect.TypeOf((*MockINoteRepository)(nil).GetNoteByID), ctx, noteID, userID)
+}
+
+// GetNoteRevision mocks base method.
+func (m *MockINoteRepository) GetNoteRevision(ctx context.Context, noteID uuid.UUID, revision int, userID uuid.UUID) (*models0.NoteRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteRevision", ctx, noteID, revision, userID)
+	ret0, _ := ret[0].(*models0.NoteRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNoteRevision indicates an expected call of GetNoteRevision.
+func (mr *MockINoteRepositoryMockRecorder) GetNoteRevision(ctx, noteID, revision, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteRevision", reflect.TypeOf((*MockINoteRepository)(nil).GetNoteRevision), ctx, noteID, revision, userID)
+}
+
+// GetNoteRevisions mocks base method.
+func (m *MockINoteRepository) GetNoteRevisions(ctx context.Context, noteID, userID uuid.UUID) ([]models0.NoteRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteRevisions", ctx, noteID, userID)
+	ret0, _ := ret[0].([]models0.NoteRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNoteRevisions indicates an expected call of GetNoteRevisions.
+func (mr *MockINoteRepositoryMockRecorder) GetNoteRevisions(ctx, noteID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteRevisions", reflect.TypeOf((*MockINoteRepository)(nil).GetNoteRevisions), ctx, noteID, userID)
+}
+
 // GetNotesByProject mocks base method.
-func (m *MockINoteRepository) GetNotesByProject(ctx context.Context, projectID, userID uuid.UUID) ([]models.Note, error) {
+func (m *MockINoteRepository) GetNotesByProject(ctx context.Context, projectID, userID uuid.UUID) ([]models0.Note, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetNotesByProject", ctx, projectID, userID)
-	ret0, _ := ret[0].([]models.Note)
+	ret0, _ := ret[0].([]models0.Note)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -95,18 +184,77 @@ func (mr *MockINoteRepositoryMockRecorder) GetNotesByProject(ctx, projectID, use
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesByProject", reflect.TypeOf((*MockINoteRepository)(nil).GetNotesByProject), ctx, projectID, userID)
 }
 
-// UpdateNote mocks base method.
-func (m *MockINoteRepository) UpdateNote(ctx context.Context, userID, noteID, projectID uuid.UUID, name, description string) error {
+// MoveNote mocks base method.
+func (m *MockINoteRepository) MoveNote(ctx context.Context, noteID, userID uuid.UUID, folderID *uuid.UUID, position int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNote", ctx, noteID, userID, folderID, position)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveNote indicates an expected call of MoveNote.
+func (mr *MockINoteRepositoryMockRecorder) MoveNote(ctx, noteID, userID, folderID, position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNote", reflect.TypeOf((*MockINoteRepository)(nil).MoveNote), ctx, noteID, userID, folderID, position)
+}
+
+// RestoreNoteRevision mocks base method.
+func (m *MockINoteRepository) RestoreNoteRevision(ctx context.Context, noteID uuid.UUID, revision int, userID uuid.UUID, refs models.References, mentions []string) (*models0.NoteRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNoteRevision", ctx, noteID, revision, userID, refs, mentions)
+	ret0, _ := ret[0].(*models0.NoteRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreNoteRevision indicates an expected call of RestoreNoteRevision.
+func (mr *MockINoteRepositoryMockRecorder) RestoreNoteRevision(ctx, noteID, revision, userID, refs, mentions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNoteRevision", reflect.TypeOf((*MockINoteRepository)(nil).RestoreNoteRevision), ctx, noteID, revision, userID, refs, mentions)
+}
+
+// SetNoteFlags mocks base method.
+func (m *MockINoteRepository) SetNoteFlags(ctx context.Context, noteID, userID uuid.UUID, pinned, favourite *bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNoteFlags", ctx, noteID, userID, pinned, favourite)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNoteFlags indicates an expected call of SetNoteFlags.
+func (mr *MockINoteRepositoryMockRecorder) SetNoteFlags(ctx, noteID, userID, pinned, favourite interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNoteFlags", reflect.TypeOf((*MockINoteRepository)(nil).SetNoteFlags), ctx, noteID, userID, pinned, favourite)
+}
+
+// UpdateFolder mocks base method.
+func (m *MockINoteRepository) UpdateFolder(ctx context.Context, folderID, userID uuid.UUID, name string, parentID *uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateNote", ctx, userID, noteID, projectID, name, description)
+	ret := m.ctrl.Call(m, "UpdateFolder", ctx, folderID, userID, name, parentID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// UpdateFolder indicates an expected call of UpdateFolder.
+func (mr *MockINoteRepositoryMockRecorder) UpdateFolder(ctx, folderID, userID, name, parentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFolder", reflect.TypeOf((*MockINoteRepository)(nil).UpdateFolder), ctx, folderID, userID, name, parentID)
+}
+
+// UpdateNote mocks base method.
+func (m *MockINoteRepository) UpdateNote(ctx context.Context, userID, noteID, projectID uuid.UUID, name, description, format string, expectedVersions []int, refs models.References, mentions []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNote", ctx, userID, noteID, projectID, name, description, format, expectedVersions, refs, mentions)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateNote indicates an expected call of UpdateNote.
-func (mr *MockINoteRepositoryMockRecorder) UpdateNote(ctx, userID, noteID, projectID, name, description interface{}) *gomock.Call {
+func (mr *MockINoteRepositoryMockRecorder) UpdateNote(ctx, userID, noteID, projectID, name, description, format, expectedVersions, refs, mentions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockINoteRepository)(nil).UpdateNote), ctx, userID, noteID, projectID, name, description)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockINoteRepository)(nil).UpdateNote), ctx, userID, noteID, projectID, name, description, format, expectedVersions, refs, mentions)
 }
 
 // MockNoteProjectRepository is a mock of NoteProjectRepository interface.
@@ -146,3 +294,197 @@ func (mr *MockNoteProjectRepositoryMockRecorder) CheckProjectAccess(ctx, project
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProjectAccess", reflect.TypeOf((*MockNoteProjectRepository)(nil).CheckProjectAccess), ctx, projectID, userID)
 }
+
+// MockNoteHTMLCache is a mock of NoteHTMLCache interface.
+type MockNoteHTMLCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoteHTMLCacheMockRecorder
+}
+
+// MockNoteHTMLCacheMockRecorder is the mock recorder for MockNoteHTMLCache.
+type MockNoteHTMLCacheMockRecorder struct {
+	mock *MockNoteHTMLCache
+}
+
+// NewMockNoteHTMLCache creates a new mock instance.
+func NewMockNoteHTMLCache(ctrl *gomock.Controller) *MockNoteHTMLCache {
+	mock := &MockNoteHTMLCache{ctrl: ctrl}
+	mock.recorder = &MockNoteHTMLCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNoteHTMLCache) EXPECT() *MockNoteHTMLCacheMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockNoteHTMLCache) Get(ctx context.Context, noteID uuid.UUID, stamp string) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, noteID, stamp)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockNoteHTMLCacheMockRecorder) Get(ctx, noteID, stamp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNoteHTMLCache)(nil).Get), ctx, noteID, stamp)
+}
+
+// Invalidate mocks base method.
+func (m *MockNoteHTMLCache) Invalidate(ctx context.Context, noteID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invalidate", ctx, noteID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockNoteHTMLCacheMockRecorder) Invalidate(ctx, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockNoteHTMLCache)(nil).Invalidate), ctx, noteID)
+}
+
+// Set mocks base method.
+func (m *MockNoteHTMLCache) Set(ctx context.Context, noteID uuid.UUID, stamp, html string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, noteID, stamp, html)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockNoteHTMLCacheMockRecorder) Set(ctx, noteID, stamp, html interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockNoteHTMLCache)(nil).Set), ctx, noteID, stamp, html)
+}
+
+// MockNoteLinkRepository is a mock of NoteLinkRepository interface.
+type MockNoteLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoteLinkRepositoryMockRecorder
+}
+
+// MockNoteLinkRepositoryMockRecorder is the mock recorder for MockNoteLinkRepository.
+type MockNoteLinkRepositoryMockRecorder struct {
+	mock *MockNoteLinkRepository
+}
+
+// NewMockNoteLinkRepository creates a new mock instance.
+func NewMockNoteLinkRepository(ctrl *gomock.Controller) *MockNoteLinkRepository {
+	mock := &MockNoteLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockNoteLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNoteLinkRepository) EXPECT() *MockNoteLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBacklinks mocks base method.
+func (m *MockNoteLinkRepository) GetBacklinks(ctx context.Context, targetType string, targetID, userID uuid.UUID) ([]models.Backlink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacklinks", ctx, targetType, targetID, userID)
+	ret0, _ := ret[0].([]models.Backlink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBacklinks indicates an expected call of GetBacklinks.
+func (mr *MockNoteLinkRepositoryMockRecorder) GetBacklinks(ctx, targetType, targetID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacklinks", reflect.TypeOf((*MockNoteLinkRepository)(nil).GetBacklinks), ctx, targetType, targetID, userID)
+}
+
+// MockNoteAttachmentCleaner is a mock of NoteAttachmentCleaner interface.
+type MockNoteAttachmentCleaner struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoteAttachmentCleanerMockRecorder
+}
+
+// MockNoteAttachmentCleanerMockRecorder is the mock recorder for MockNoteAttachmentCleaner.
+type MockNoteAttachmentCleanerMockRecorder struct {
+	mock *MockNoteAttachmentCleaner
+}
+
+// NewMockNoteAttachmentCleaner creates a new mock instance.
+func NewMockNoteAttachmentCleaner(ctrl *gomock.Controller) *MockNoteAttachmentCleaner {
+	mock := &MockNoteAttachmentCleaner{ctrl: ctrl}
+	mock.recorder = &MockNoteAttachmentCleanerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNoteAttachmentCleaner) EXPECT() *MockNoteAttachmentCleanerMockRecorder {
+	return m.recorder
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockNoteAttachmentCleaner) PurgeDeleted(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockNoteAttachmentCleanerMockRecorder) PurgeDeleted(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockNoteAttachmentCleaner)(nil).PurgeDeleted), ctx)
+}
+
+// MockNoteMentionService is a mock of NoteMentionService interface.
+type MockNoteMentionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoteMentionServiceMockRecorder
+}
+
+// MockNoteMentionServiceMockRecorder is the mock recorder for MockNoteMentionService.
+type MockNoteMentionServiceMockRecorder struct {
+	mock *MockNoteMentionService
+}
+
+// NewMockNoteMentionService creates a new mock instance.
+func NewMockNoteMentionService(ctrl *gomock.Controller) *MockNoteMentionService {
+	mock := &MockNoteMentionService{ctrl: ctrl}
+	mock.recorder = &MockNoteMentionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNoteMentionService) EXPECT() *MockNoteMentionServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckMentions mocks base method.
+func (m *MockNoteMentionService) CheckMentions(ctx context.Context, projectID uuid.UUID, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMentions", ctx, projectID, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckMentions indicates an expected call of CheckMentions.
+func (mr *MockNoteMentionServiceMockRecorder) CheckMentions(ctx, projectID, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMentions", reflect.TypeOf((*MockNoteMentionService)(nil).CheckMentions), ctx, projectID, text)
+}
+
+// GetSourceMentions mocks base method.
+func (m *MockNoteMentionService) GetSourceMentions(ctx context.Context, sourceType string, sourceID uuid.UUID) (map[string]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSourceMentions", ctx, sourceType, sourceID)
+	ret0, _ := ret[0].(map[string]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSourceMentions indicates an expected call of GetSourceMentions.
+func (mr *MockNoteMentionServiceMockRecorder) GetSourceMentions(ctx, sourceType, sourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSourceMentions", reflect.TypeOf((*MockNoteMentionService)(nil).GetSourceMentions), ctx, sourceType, sourceID)
+}
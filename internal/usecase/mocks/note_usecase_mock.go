@@ -10,7 +10,8 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
-	dto "github.com/lzimin05/course-todo/internal/transport/dto/note"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/link"
+	dto0 "github.com/lzimin05/course-todo/internal/transport/dto/note"
 )
 
 // MockINoteUsecase is a mock of INoteUsecase interface.
@@ -36,11 +37,26 @@ func (m *MockINoteUsecase) EXPECT() *MockINoteUsecaseMockRecorder {
 	return m.recorder
 }
 
+// CreateFolder mocks base method.
+func (m *MockINoteUsecase) CreateFolder(ctx context.Context, req dto0.CreateNoteFolderDTO) (*dto0.NoteFolderDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", ctx, req)
+	ret0, _ := ret[0].(*dto0.NoteFolderDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockINoteUsecaseMockRecorder) CreateFolder(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockINoteUsecase)(nil).CreateFolder), ctx, req)
+}
+
 // CreateNote mocks base method.
-func (m *MockINoteUsecase) CreateNote(ctx context.Context, req dto.CreateOrUpdateNote) (*dto.CreateNoteDTO, error) {
+func (m *MockINoteUsecase) CreateNote(ctx context.Context, req dto0.CreateOrUpdateNote) (*dto0.CreateNoteDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateNote", ctx, req)
-	ret0, _ := ret[0].(*dto.CreateNoteDTO)
+	ret0, _ := ret[0].(*dto0.CreateNoteDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -51,25 +67,54 @@ func (mr *MockINoteUsecaseMockRecorder) CreateNote(ctx, req interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockINoteUsecase)(nil).CreateNote), ctx, req)
 }
 
+// DeleteFolder mocks base method.
+func (m *MockINoteUsecase) DeleteFolder(ctx context.Context, folderID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", ctx, folderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockINoteUsecaseMockRecorder) DeleteFolder(ctx, folderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockINoteUsecase)(nil).DeleteFolder), ctx, folderID)
+}
+
 // DeleteNote mocks base method.
-func (m *MockINoteUsecase) DeleteNote(ctx context.Context, noteID uuid.UUID) error {
+func (m *MockINoteUsecase) DeleteNote(ctx context.Context, noteID uuid.UUID, expectedVersions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteNote", ctx, noteID)
+	ret := m.ctrl.Call(m, "DeleteNote", ctx, noteID, expectedVersions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteNote indicates an expected call of DeleteNote.
-func (mr *MockINoteUsecaseMockRecorder) DeleteNote(ctx, noteID interface{}) *gomock.Call {
+func (mr *MockINoteUsecaseMockRecorder) DeleteNote(ctx, noteID, expectedVersions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockINoteUsecase)(nil).DeleteNote), ctx, noteID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockINoteUsecase)(nil).DeleteNote), ctx, noteID, expectedVersions)
+}
+
+// DiffNoteRevisions mocks base method.
+func (m *MockINoteUsecase) DiffNoteRevisions(ctx context.Context, noteID uuid.UUID, from, to int) (*dto0.NoteDiffDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffNoteRevisions", ctx, noteID, from, to)
+	ret0, _ := ret[0].(*dto0.NoteDiffDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffNoteRevisions indicates an expected call of DiffNoteRevisions.
+func (mr *MockINoteUsecaseMockRecorder) DiffNoteRevisions(ctx, noteID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffNoteRevisions", reflect.TypeOf((*MockINoteUsecase)(nil).DiffNoteRevisions), ctx, noteID, from, to)
 }
 
 // GetAllNotes mocks base method.
-func (m *MockINoteUsecase) GetAllNotes(ctx context.Context) ([]*dto.NoteDTO, error) {
+func (m *MockINoteUsecase) GetAllNotes(ctx context.Context) ([]*dto0.NoteDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAllNotes", ctx)
-	ret0, _ := ret[0].([]*dto.NoteDTO)
+	ret0, _ := ret[0].([]*dto0.NoteDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -80,11 +125,86 @@ func (mr *MockINoteUsecaseMockRecorder) GetAllNotes(ctx interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllNotes", reflect.TypeOf((*MockINoteUsecase)(nil).GetAllNotes), ctx)
 }
 
+// GetBacklinks mocks base method.
+func (m *MockINoteUsecase) GetBacklinks(ctx context.Context, noteID uuid.UUID) ([]*dto.BacklinkDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacklinks", ctx, noteID)
+	ret0, _ := ret[0].([]*dto.BacklinkDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBacklinks indicates an expected call of GetBacklinks.
+func (mr *MockINoteUsecaseMockRecorder) GetBacklinks(ctx, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacklinks", reflect.TypeOf((*MockINoteUsecase)(nil).GetBacklinks), ctx, noteID)
+}
+
+// GetNoteByID mocks base method.
+func (m *MockINoteUsecase) GetNoteByID(ctx context.Context, noteID uuid.UUID) (*dto0.NoteDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteByID", ctx, noteID)
+	ret0, _ := ret[0].(*dto0.NoteDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNoteByID indicates an expected call of GetNoteByID.
+func (mr *MockINoteUsecaseMockRecorder) GetNoteByID(ctx, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteByID", reflect.TypeOf((*MockINoteUsecase)(nil).GetNoteByID), ctx, noteID)
+}
+
+// GetNoteRevision mocks base method.
+func (m *MockINoteUsecase) GetNoteRevision(ctx context.Context, noteID uuid.UUID, revision int) (*dto0.NoteRevisionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteRevision", ctx, noteID, revision)
+	ret0, _ := ret[0].(*dto0.NoteRevisionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNoteRevision indicates an expected call of GetNoteRevision.
+func (mr *MockINoteUsecaseMockRecorder) GetNoteRevision(ctx, noteID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteRevision", reflect.TypeOf((*MockINoteUsecase)(nil).GetNoteRevision), ctx, noteID, revision)
+}
+
+// GetNoteRevisions mocks base method.
+func (m *MockINoteUsecase) GetNoteRevisions(ctx context.Context, noteID uuid.UUID) ([]*dto0.NoteRevisionSummaryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteRevisions", ctx, noteID)
+	ret0, _ := ret[0].([]*dto0.NoteRevisionSummaryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNoteRevisions indicates an expected call of GetNoteRevisions.
+func (mr *MockINoteUsecaseMockRecorder) GetNoteRevisions(ctx, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteRevisions", reflect.TypeOf((*MockINoteUsecase)(nil).GetNoteRevisions), ctx, noteID)
+}
+
+// GetNoteTree mocks base method.
+func (m *MockINoteUsecase) GetNoteTree(ctx context.Context, projectID uuid.UUID) (*dto0.NoteTreeDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteTree", ctx, projectID)
+	ret0, _ := ret[0].(*dto0.NoteTreeDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNoteTree indicates an expected call of GetNoteTree.
+func (mr *MockINoteUsecaseMockRecorder) GetNoteTree(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteTree", reflect.TypeOf((*MockINoteUsecase)(nil).GetNoteTree), ctx, projectID)
+}
+
 // GetNotesByProject mocks base method.
-func (m *MockINoteUsecase) GetNotesByProject(ctx context.Context, projectID uuid.UUID) ([]*dto.NoteDTO, error) {
+func (m *MockINoteUsecase) GetNotesByProject(ctx context.Context, projectID uuid.UUID) ([]*dto0.NoteDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetNotesByProject", ctx, projectID)
-	ret0, _ := ret[0].([]*dto.NoteDTO)
+	ret0, _ := ret[0].([]*dto0.NoteDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -95,16 +215,90 @@ func (mr *MockINoteUsecaseMockRecorder) GetNotesByProject(ctx, projectID interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesByProject", reflect.TypeOf((*MockINoteUsecase)(nil).GetNotesByProject), ctx, projectID)
 }
 
-// UpdateNote mocks base method.
-func (m *MockINoteUsecase) UpdateNote(ctx context.Context, noteID uuid.UUID, req dto.CreateOrUpdateNote) error {
+// MoveNote mocks base method.
+func (m *MockINoteUsecase) MoveNote(ctx context.Context, noteID uuid.UUID, req dto0.MoveNoteDTO) (*dto0.MoveNoteDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateNote", ctx, noteID, req)
+	ret := m.ctrl.Call(m, "MoveNote", ctx, noteID, req)
+	ret0, _ := ret[0].(*dto0.MoveNoteDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveNote indicates an expected call of MoveNote.
+func (mr *MockINoteUsecaseMockRecorder) MoveNote(ctx, noteID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNote", reflect.TypeOf((*MockINoteUsecase)(nil).MoveNote), ctx, noteID, req)
+}
+
+// RenderNote mocks base method.
+func (m *MockINoteUsecase) RenderNote(ctx context.Context, noteID uuid.UUID) (*dto0.RenderedNoteDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenderNote", ctx, noteID)
+	ret0, _ := ret[0].(*dto0.RenderedNoteDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenderNote indicates an expected call of RenderNote.
+func (mr *MockINoteUsecaseMockRecorder) RenderNote(ctx, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenderNote", reflect.TypeOf((*MockINoteUsecase)(nil).RenderNote), ctx, noteID)
+}
+
+// RestoreNoteRevision mocks base method.
+func (m *MockINoteUsecase) RestoreNoteRevision(ctx context.Context, noteID uuid.UUID, revision int) (*dto0.NoteRevisionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNoteRevision", ctx, noteID, revision)
+	ret0, _ := ret[0].(*dto0.NoteRevisionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreNoteRevision indicates an expected call of RestoreNoteRevision.
+func (mr *MockINoteUsecaseMockRecorder) RestoreNoteRevision(ctx, noteID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNoteRevision", reflect.TypeOf((*MockINoteUsecase)(nil).RestoreNoteRevision), ctx, noteID, revision)
+}
+
+// SetNoteFlags mocks base method.
+func (m *MockINoteUsecase) SetNoteFlags(ctx context.Context, noteID uuid.UUID, req dto0.NoteFlagsDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNoteFlags", ctx, noteID, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNoteFlags indicates an expected call of SetNoteFlags.
+func (mr *MockINoteUsecaseMockRecorder) SetNoteFlags(ctx, noteID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNoteFlags", reflect.TypeOf((*MockINoteUsecase)(nil).SetNoteFlags), ctx, noteID, req)
+}
+
+// UpdateFolder mocks base method.
+func (m *MockINoteUsecase) UpdateFolder(ctx context.Context, folderID uuid.UUID, req dto0.UpdateNoteFolderDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFolder", ctx, folderID, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// UpdateFolder indicates an expected call of UpdateFolder.
+func (mr *MockINoteUsecaseMockRecorder) UpdateFolder(ctx, folderID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFolder", reflect.TypeOf((*MockINoteUsecase)(nil).UpdateFolder), ctx, folderID, req)
+}
+
+// UpdateNote mocks base method.
+func (m *MockINoteUsecase) UpdateNote(ctx context.Context, noteID uuid.UUID, req dto0.CreateOrUpdateNote, expectedVersions []int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNote", ctx, noteID, req, expectedVersions)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateNote indicates an expected call of UpdateNote.
-func (mr *MockINoteUsecaseMockRecorder) UpdateNote(ctx, noteID, req interface{}) *gomock.Call {
+func (mr *MockINoteUsecaseMockRecorder) UpdateNote(ctx, noteID, req, expectedVersions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockINoteUsecase)(nil).UpdateNote), ctx, noteID, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockINoteUsecase)(nil).UpdateNote), ctx, noteID, req, expectedVersions)
 }
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/notification"
+)
+
+// MockNotificationUsecase is a mock of NotificationUsecase interface.
+type MockNotificationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationUsecaseMockRecorder
+}
+
+// MockNotificationUsecaseMockRecorder is the mock recorder for MockNotificationUsecase.
+type MockNotificationUsecaseMockRecorder struct {
+	mock *MockNotificationUsecase
+}
+
+// NewMockNotificationUsecase creates a new mock instance.
+func NewMockNotificationUsecase(ctrl *gomock.Controller) *MockNotificationUsecase {
+	mock := &MockNotificationUsecase{ctrl: ctrl}
+	mock.recorder = &MockNotificationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationUsecase) EXPECT() *MockNotificationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetNotifications mocks base method.
+func (m *MockNotificationUsecase) GetNotifications(ctx context.Context, unreadOnly bool, limit, offset int) (*dto.NotificationListDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, unreadOnly, limit, offset)
+	ret0, _ := ret[0].(*dto.NotificationListDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockNotificationUsecaseMockRecorder) GetNotifications(ctx, unreadOnly, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockNotificationUsecase)(nil).GetNotifications), ctx, unreadOnly, limit, offset)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotificationUsecase) GetPreferences(ctx context.Context) ([]dto.PreferenceDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx)
+	ret0, _ := ret[0].([]dto.PreferenceDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationUsecaseMockRecorder) GetPreferences(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotificationUsecase)(nil).GetPreferences), ctx)
+}
+
+// GetUnreadCount mocks base method.
+func (m *MockNotificationUsecase) GetUnreadCount(ctx context.Context) (*dto.UnreadCountDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadCount", ctx)
+	ret0, _ := ret[0].(*dto.UnreadCountDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadCount indicates an expected call of GetUnreadCount.
+func (mr *MockNotificationUsecaseMockRecorder) GetUnreadCount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadCount", reflect.TypeOf((*MockNotificationUsecase)(nil).GetUnreadCount), ctx)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotificationUsecase) MarkAllRead(ctx context.Context) (*dto.UnreadCountDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", ctx)
+	ret0, _ := ret[0].(*dto.UnreadCountDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationUsecaseMockRecorder) MarkAllRead(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotificationUsecase)(nil).MarkAllRead), ctx)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationUsecase) MarkRead(ctx context.Context, notificationID uuid.UUID) (*dto.UnreadCountDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, notificationID)
+	ret0, _ := ret[0].(*dto.UnreadCountDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationUsecaseMockRecorder) MarkRead(ctx, notificationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationUsecase)(nil).MarkRead), ctx, notificationID)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockNotificationUsecase) UpdatePreferences(ctx context.Context, req dto.UpdatePreferencesDTO) ([]dto.PreferenceDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", ctx, req)
+	ret0, _ := ret[0].([]dto.PreferenceDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockNotificationUsecaseMockRecorder) UpdatePreferences(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockNotificationUsecase)(nil).UpdatePreferences), ctx, req)
+}
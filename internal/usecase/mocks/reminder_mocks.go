@@ -0,0 +1,242 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reminder.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/mail"
+	models0 "github.com/lzimin05/course-todo/internal/models/notification"
+	models1 "github.com/lzimin05/course-todo/internal/models/reminder"
+)
+
+// MockReminderRepository is a mock of ReminderRepository interface.
+type MockReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderRepositoryMockRecorder
+}
+
+// MockReminderRepositoryMockRecorder is the mock recorder for MockReminderRepository.
+type MockReminderRepositoryMockRecorder struct {
+	mock *MockReminderRepository
+}
+
+// NewMockReminderRepository creates a new mock instance.
+func NewMockReminderRepository(ctrl *gomock.Controller) *MockReminderRepository {
+	mock := &MockReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderRepository) EXPECT() *MockReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetDigestRecipients mocks base method.
+func (m *MockReminderRepository) GetDigestRecipients(ctx context.Context, now time.Time, hour int, until time.Time, limit int) ([]models1.Recipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigestRecipients", ctx, now, hour, until, limit)
+	ret0, _ := ret[0].([]models1.Recipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigestRecipients indicates an expected call of GetDigestRecipients.
+func (mr *MockReminderRepositoryMockRecorder) GetDigestRecipients(ctx, now, hour, until, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigestRecipients", reflect.TypeOf((*MockReminderRepository)(nil).GetDigestRecipients), ctx, now, hour, until, limit)
+}
+
+// GetDigestTasks mocks base method.
+func (m *MockReminderRepository) GetDigestTasks(ctx context.Context, userID uuid.UUID, until time.Time) ([]models1.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigestTasks", ctx, userID, until)
+	ret0, _ := ret[0].([]models1.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigestTasks indicates an expected call of GetDigestTasks.
+func (mr *MockReminderRepositoryMockRecorder) GetDigestTasks(ctx, userID, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigestTasks", reflect.TypeOf((*MockReminderRepository)(nil).GetDigestTasks), ctx, userID, until)
+}
+
+// GetOverdueTasks mocks base method.
+func (m *MockReminderRepository) GetOverdueTasks(ctx context.Context, now time.Time, limit int) ([]models1.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueTasks", ctx, now, limit)
+	ret0, _ := ret[0].([]models1.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdueTasks indicates an expected call of GetOverdueTasks.
+func (mr *MockReminderRepositoryMockRecorder) GetOverdueTasks(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueTasks", reflect.TypeOf((*MockReminderRepository)(nil).GetOverdueTasks), ctx, now, limit)
+}
+
+// GetUpcomingTasks mocks base method.
+func (m *MockReminderRepository) GetUpcomingTasks(ctx context.Context, from, until time.Time) ([]models1.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcomingTasks", ctx, from, until)
+	ret0, _ := ret[0].([]models1.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcomingTasks indicates an expected call of GetUpcomingTasks.
+func (mr *MockReminderRepositoryMockRecorder) GetUpcomingTasks(ctx, from, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcomingTasks", reflect.TypeOf((*MockReminderRepository)(nil).GetUpcomingTasks), ctx, from, until)
+}
+
+// MarkDigestSent mocks base method.
+func (m *MockReminderRepository) MarkDigestSent(ctx context.Context, userID uuid.UUID, localDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDigestSent", ctx, userID, localDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDigestSent indicates an expected call of MarkDigestSent.
+func (mr *MockReminderRepositoryMockRecorder) MarkDigestSent(ctx, userID, localDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDigestSent", reflect.TypeOf((*MockReminderRepository)(nil).MarkDigestSent), ctx, userID, localDate)
+}
+
+// MarkOverdue mocks base method.
+func (m *MockReminderRepository) MarkOverdue(ctx context.Context, taskIDs []uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOverdue", ctx, taskIDs, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOverdue indicates an expected call of MarkOverdue.
+func (mr *MockReminderRepositoryMockRecorder) MarkOverdue(ctx, taskIDs, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOverdue", reflect.TypeOf((*MockReminderRepository)(nil).MarkOverdue), ctx, taskIDs, now)
+}
+
+// MockReminderNotifier is a mock of ReminderNotifier interface.
+type MockReminderNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderNotifierMockRecorder
+}
+
+// MockReminderNotifierMockRecorder is the mock recorder for MockReminderNotifier.
+type MockReminderNotifierMockRecorder struct {
+	mock *MockReminderNotifier
+}
+
+// NewMockReminderNotifier creates a new mock instance.
+func NewMockReminderNotifier(ctrl *gomock.Controller) *MockReminderNotifier {
+	mock := &MockReminderNotifier{ctrl: ctrl}
+	mock.recorder = &MockReminderNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderNotifier) EXPECT() *MockReminderNotifierMockRecorder {
+	return m.recorder
+}
+
+// CreateNotification mocks base method.
+func (m *MockReminderNotifier) CreateNotification(ctx context.Context, n *models0.Notification) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotification", ctx, n)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotification indicates an expected call of CreateNotification.
+func (mr *MockReminderNotifierMockRecorder) CreateNotification(ctx, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotification", reflect.TypeOf((*MockReminderNotifier)(nil).CreateNotification), ctx, n)
+}
+
+// MockReminderLocker is a mock of ReminderLocker interface.
+type MockReminderLocker struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderLockerMockRecorder
+}
+
+// MockReminderLockerMockRecorder is the mock recorder for MockReminderLocker.
+type MockReminderLockerMockRecorder struct {
+	mock *MockReminderLocker
+}
+
+// NewMockReminderLocker creates a new mock instance.
+func NewMockReminderLocker(ctrl *gomock.Controller) *MockReminderLocker {
+	mock := &MockReminderLocker{ctrl: ctrl}
+	mock.recorder = &MockReminderLockerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderLocker) EXPECT() *MockReminderLockerMockRecorder {
+	return m.recorder
+}
+
+// TryLock mocks base method.
+func (m *MockReminderLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (func(context.Context) error, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLock", ctx, name, ttl)
+	ret0, _ := ret[0].(func(context.Context) error)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TryLock indicates an expected call of TryLock.
+func (mr *MockReminderLockerMockRecorder) TryLock(ctx, name, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLock", reflect.TypeOf((*MockReminderLocker)(nil).TryLock), ctx, name, ttl)
+}
+
+// MockMailer is a mock of Mailer interface.
+type MockMailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailerMockRecorder
+}
+
+// MockMailerMockRecorder is the mock recorder for MockMailer.
+type MockMailerMockRecorder struct {
+	mock *MockMailer
+}
+
+// NewMockMailer creates a new mock instance.
+func NewMockMailer(ctrl *gomock.Controller) *MockMailer {
+	mock := &MockMailer{ctrl: ctrl}
+	mock.recorder = &MockMailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailer) EXPECT() *MockMailerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMailer) Send(ctx context.Context, mail models.Mail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, mail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMailerMockRecorder) Send(ctx, mail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailer)(nil).Send), ctx, mail)
+}
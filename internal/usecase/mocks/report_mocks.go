@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/report"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCumulativeFlow mocks base method.
+func (m *MockReportRepository) GetCumulativeFlow(ctx context.Context, projectID uuid.UUID, from, to time.Time, bucket string) ([]models.CFDPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCumulativeFlow", ctx, projectID, from, to, bucket)
+	ret0, _ := ret[0].([]models.CFDPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCumulativeFlow indicates an expected call of GetCumulativeFlow.
+func (mr *MockReportRepositoryMockRecorder) GetCumulativeFlow(ctx, projectID, from, to, bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCumulativeFlow", reflect.TypeOf((*MockReportRepository)(nil).GetCumulativeFlow), ctx, projectID, from, to, bucket)
+}
+
+// MockReportProjectRepository is a mock of ReportProjectRepository interface.
+type MockReportProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportProjectRepositoryMockRecorder
+}
+
+// MockReportProjectRepositoryMockRecorder is the mock recorder for MockReportProjectRepository.
+type MockReportProjectRepositoryMockRecorder struct {
+	mock *MockReportProjectRepository
+}
+
+// NewMockReportProjectRepository creates a new mock instance.
+func NewMockReportProjectRepository(ctrl *gomock.Controller) *MockReportProjectRepository {
+	mock := &MockReportProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockReportProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportProjectRepository) EXPECT() *MockReportProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckProjectAccess mocks base method.
+func (m *MockReportProjectRepository) CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckProjectAccess", ctx, projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckProjectAccess indicates an expected call of CheckProjectAccess.
+func (mr *MockReportProjectRepositoryMockRecorder) CheckProjectAccess(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProjectAccess", reflect.TypeOf((*MockReportProjectRepository)(nil).CheckProjectAccess), ctx, projectID, userID)
+}
@@ -11,7 +11,8 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
-	models "github.com/lzimin05/course-todo/internal/models/task"
+	models "github.com/lzimin05/course-todo/internal/models/link"
+	models0 "github.com/lzimin05/course-todo/internal/models/task"
 )
 
 // MockTaskRepository is a mock of TaskRepository interface.
@@ -37,40 +38,98 @@ func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CreateChecklistItem mocks base method.
+func (m *MockTaskRepository) CreateChecklistItem(ctx context.Context, item *models0.ChecklistItem, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChecklistItem", ctx, item, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateChecklistItem indicates an expected call of CreateChecklistItem.
+func (mr *MockTaskRepositoryMockRecorder) CreateChecklistItem(ctx, item, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChecklistItem", reflect.TypeOf((*MockTaskRepository)(nil).CreateChecklistItem), ctx, item, userID)
+}
+
 // CreateTask mocks base method.
-func (m *MockTaskRepository) CreateTask(ctx context.Context, task *models.Task) (*models.Task, error) {
+func (m *MockTaskRepository) CreateTask(ctx context.Context, task *models0.Task, refs models.References, mentions []string) (*models0.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", ctx, task)
-	ret0, _ := ret[0].(*models.Task)
+	ret := m.ctrl.Call(m, "CreateTask", ctx, task, refs, mentions)
+	ret0, _ := ret[0].(*models0.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateTask indicates an expected call of CreateTask.
-func (mr *MockTaskRepositoryMockRecorder) CreateTask(ctx, task interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) CreateTask(ctx, task, refs, mentions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepository)(nil).CreateTask), ctx, task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepository)(nil).CreateTask), ctx, task, refs, mentions)
+}
+
+// DeleteChecklistItem mocks base method.
+func (m *MockTaskRepository) DeleteChecklistItem(ctx context.Context, itemID, taskID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", ctx, itemID, taskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MockTaskRepositoryMockRecorder) DeleteChecklistItem(ctx, itemID, taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MockTaskRepository)(nil).DeleteChecklistItem), ctx, itemID, taskID, userID)
 }
 
 // DeleteTask mocks base method.
-func (m *MockTaskRepository) DeleteTask(ctx context.Context, taskID, userID uuid.UUID) error {
+func (m *MockTaskRepository) DeleteTask(ctx context.Context, taskID, userID uuid.UUID, expectedVersions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", ctx, taskID, userID)
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, taskID, userID, expectedVersions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskRepositoryMockRecorder) DeleteTask(ctx, taskID, userID interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) DeleteTask(ctx, taskID, userID, expectedVersions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTask), ctx, taskID, userID, expectedVersions)
+}
+
+// GetChecklist mocks base method.
+func (m *MockTaskRepository) GetChecklist(ctx context.Context, taskID, userID uuid.UUID) ([]models0.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklist", ctx, taskID, userID)
+	ret0, _ := ret[0].([]models0.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChecklist indicates an expected call of GetChecklist.
+func (mr *MockTaskRepositoryMockRecorder) GetChecklist(ctx, taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklist", reflect.TypeOf((*MockTaskRepository)(nil).GetChecklist), ctx, taskID, userID)
+}
+
+// GetTaskByID mocks base method.
+func (m *MockTaskRepository) GetTaskByID(ctx context.Context, taskID, userID uuid.UUID) (*models0.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByID", ctx, taskID, userID)
+	ret0, _ := ret[0].(*models0.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByID indicates an expected call of GetTaskByID.
+func (mr *MockTaskRepositoryMockRecorder) GetTaskByID(ctx, taskID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepository)(nil).DeleteTask), ctx, taskID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTaskRepository)(nil).GetTaskByID), ctx, taskID, userID)
 }
 
 // GetTasksByProjectID mocks base method.
-func (m *MockTaskRepository) GetTasksByProjectID(ctx context.Context, projectID, userID uuid.UUID) ([]*models.Task, error) {
+func (m *MockTaskRepository) GetTasksByProjectID(ctx context.Context, projectID, userID uuid.UUID) ([]*models0.Task, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetTasksByProjectID", ctx, projectID, userID)
-	ret0, _ := ret[0].([]*models.Task)
+	ret0, _ := ret[0].([]*models0.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -82,10 +141,10 @@ func (mr *MockTaskRepositoryMockRecorder) GetTasksByProjectID(ctx, projectID, us
 }
 
 // GetTasksByUserID mocks base method.
-func (m *MockTaskRepository) GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Task, error) {
+func (m *MockTaskRepository) GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*models0.Task, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetTasksByUserID", ctx, userID)
-	ret0, _ := ret[0].([]*models.Task)
+	ret0, _ := ret[0].([]*models0.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -96,32 +155,64 @@ func (mr *MockTaskRepositoryMockRecorder) GetTasksByUserID(ctx, userID interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByUserID", reflect.TypeOf((*MockTaskRepository)(nil).GetTasksByUserID), ctx, userID)
 }
 
+// MoveChecklistItem mocks base method.
+func (m *MockTaskRepository) MoveChecklistItem(ctx context.Context, itemID, taskID, userID uuid.UUID, position int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveChecklistItem", ctx, itemID, taskID, userID, position)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveChecklistItem indicates an expected call of MoveChecklistItem.
+func (mr *MockTaskRepositoryMockRecorder) MoveChecklistItem(ctx, itemID, taskID, userID, position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveChecklistItem", reflect.TypeOf((*MockTaskRepository)(nil).MoveChecklistItem), ctx, itemID, taskID, userID, position)
+}
+
+// UpdateChecklistItem mocks base method.
+func (m *MockTaskRepository) UpdateChecklistItem(ctx context.Context, itemID, taskID, userID uuid.UUID, text *string, done *bool, assigneeID *uuid.UUID, clearAssignee bool) (*models0.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklistItem", ctx, itemID, taskID, userID, text, done, assigneeID, clearAssignee)
+	ret0, _ := ret[0].(*models0.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChecklistItem indicates an expected call of UpdateChecklistItem.
+func (mr *MockTaskRepositoryMockRecorder) UpdateChecklistItem(ctx, itemID, taskID, userID, text, done, assigneeID, clearAssignee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklistItem", reflect.TypeOf((*MockTaskRepository)(nil).UpdateChecklistItem), ctx, itemID, taskID, userID, text, done, assigneeID, clearAssignee)
+}
+
 // UpdateTask mocks base method.
-func (m *MockTaskRepository) UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, taskID, userID uuid.UUID) error {
+func (m *MockTaskRepository) UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, estimateMinutes *int, startAt *time.Time, allDay bool, taskID, userID uuid.UUID, expectedVersions []int, refs models.References, mentions []string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", ctx, title, description, importance, deadline, taskID, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, expectedVersions, refs, mentions)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskRepositoryMockRecorder) UpdateTask(ctx, title, description, importance, deadline, taskID, userID interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) UpdateTask(ctx, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, expectedVersions, refs, mentions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTask), ctx, title, description, importance, deadline, taskID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTask), ctx, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, expectedVersions, refs, mentions)
 }
 
 // UpdateTaskStatus mocks base method.
-func (m *MockTaskRepository) UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID) error {
+func (m *MockTaskRepository) UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID, expectedVersions []int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, status, taskID, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, status, taskID, userID, expectedVersions)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
-func (mr *MockTaskRepositoryMockRecorder) UpdateTaskStatus(ctx, status, taskID, userID interface{}) *gomock.Call {
+func (mr *MockTaskRepositoryMockRecorder) UpdateTaskStatus(ctx, status, taskID, userID, expectedVersions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTaskStatus), ctx, status, taskID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockTaskRepository)(nil).UpdateTaskStatus), ctx, status, taskID, userID, expectedVersions)
 }
 
 // MockTaskProjectRepository is a mock of TaskProjectRepository interface.
@@ -161,3 +252,115 @@ func (mr *MockTaskProjectRepositoryMockRecorder) CheckProjectAccess(ctx, project
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProjectAccess", reflect.TypeOf((*MockTaskProjectRepository)(nil).CheckProjectAccess), ctx, projectID, userID)
 }
+
+// MockTaskLinkRepository is a mock of TaskLinkRepository interface.
+type MockTaskLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskLinkRepositoryMockRecorder
+}
+
+// MockTaskLinkRepositoryMockRecorder is the mock recorder for MockTaskLinkRepository.
+type MockTaskLinkRepositoryMockRecorder struct {
+	mock *MockTaskLinkRepository
+}
+
+// NewMockTaskLinkRepository creates a new mock instance.
+func NewMockTaskLinkRepository(ctrl *gomock.Controller) *MockTaskLinkRepository {
+	mock := &MockTaskLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskLinkRepository) EXPECT() *MockTaskLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetBacklinks mocks base method.
+func (m *MockTaskLinkRepository) GetBacklinks(ctx context.Context, targetType string, targetID, userID uuid.UUID) ([]models.Backlink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacklinks", ctx, targetType, targetID, userID)
+	ret0, _ := ret[0].([]models.Backlink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBacklinks indicates an expected call of GetBacklinks.
+func (mr *MockTaskLinkRepositoryMockRecorder) GetBacklinks(ctx, targetType, targetID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacklinks", reflect.TypeOf((*MockTaskLinkRepository)(nil).GetBacklinks), ctx, targetType, targetID, userID)
+}
+
+// MockTaskAttachmentCleaner is a mock of TaskAttachmentCleaner interface.
+type MockTaskAttachmentCleaner struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskAttachmentCleanerMockRecorder
+}
+
+// MockTaskAttachmentCleanerMockRecorder is the mock recorder for MockTaskAttachmentCleaner.
+type MockTaskAttachmentCleanerMockRecorder struct {
+	mock *MockTaskAttachmentCleaner
+}
+
+// NewMockTaskAttachmentCleaner creates a new mock instance.
+func NewMockTaskAttachmentCleaner(ctrl *gomock.Controller) *MockTaskAttachmentCleaner {
+	mock := &MockTaskAttachmentCleaner{ctrl: ctrl}
+	mock.recorder = &MockTaskAttachmentCleanerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskAttachmentCleaner) EXPECT() *MockTaskAttachmentCleanerMockRecorder {
+	return m.recorder
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockTaskAttachmentCleaner) PurgeDeleted(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockTaskAttachmentCleanerMockRecorder) PurgeDeleted(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockTaskAttachmentCleaner)(nil).PurgeDeleted), ctx)
+}
+
+// MockTaskMentionService is a mock of TaskMentionService interface.
+type MockTaskMentionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskMentionServiceMockRecorder
+}
+
+// MockTaskMentionServiceMockRecorder is the mock recorder for MockTaskMentionService.
+type MockTaskMentionServiceMockRecorder struct {
+	mock *MockTaskMentionService
+}
+
+// NewMockTaskMentionService creates a new mock instance.
+func NewMockTaskMentionService(ctrl *gomock.Controller) *MockTaskMentionService {
+	mock := &MockTaskMentionService{ctrl: ctrl}
+	mock.recorder = &MockTaskMentionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskMentionService) EXPECT() *MockTaskMentionServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckMentions mocks base method.
+func (m *MockTaskMentionService) CheckMentions(ctx context.Context, projectID uuid.UUID, text string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMentions", ctx, projectID, text)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckMentions indicates an expected call of CheckMentions.
+func (mr *MockTaskMentionServiceMockRecorder) CheckMentions(ctx, projectID, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMentions", reflect.TypeOf((*MockTaskMentionService)(nil).CheckMentions), ctx, projectID, text)
+}
@@ -11,7 +11,8 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
-	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/link"
+	dto0 "github.com/lzimin05/course-todo/internal/transport/dto/task"
 )
 
 // MockTaskUsecase is a mock of TaskUsecase interface.
@@ -37,11 +38,26 @@ func (m *MockTaskUsecase) EXPECT() *MockTaskUsecaseMockRecorder {
 	return m.recorder
 }
 
+// AddChecklistItem mocks base method.
+func (m *MockTaskUsecase) AddChecklistItem(ctx context.Context, taskID uuid.UUID, req dto0.CreateChecklistItemDTO) (*dto0.ChecklistItemDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", ctx, taskID, req)
+	ret0, _ := ret[0].(*dto0.ChecklistItemDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MockTaskUsecaseMockRecorder) AddChecklistItem(ctx, taskID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MockTaskUsecase)(nil).AddChecklistItem), ctx, taskID, req)
+}
+
 // CreateTask mocks base method.
-func (m *MockTaskUsecase) CreateTask(ctx context.Context, req *dto.PostTaskDTO) (*dto.CreateTaskDTO, error) {
+func (m *MockTaskUsecase) CreateTask(ctx context.Context, req *dto0.PostTaskDTO) (*dto0.CreateTaskDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateTask", ctx, req)
-	ret0, _ := ret[0].(*dto.CreateTaskDTO)
+	ret0, _ := ret[0].(*dto0.CreateTaskDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -52,25 +68,84 @@ func (mr *MockTaskUsecaseMockRecorder) CreateTask(ctx, req interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskUsecase)(nil).CreateTask), ctx, req)
 }
 
+// DeleteChecklistItem mocks base method.
+func (m *MockTaskUsecase) DeleteChecklistItem(ctx context.Context, taskID, itemID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", ctx, taskID, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MockTaskUsecaseMockRecorder) DeleteChecklistItem(ctx, taskID, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MockTaskUsecase)(nil).DeleteChecklistItem), ctx, taskID, itemID)
+}
+
 // DeleteTask mocks base method.
-func (m *MockTaskUsecase) DeleteTask(ctx context.Context, taskID, userID uuid.UUID) error {
+func (m *MockTaskUsecase) DeleteTask(ctx context.Context, taskID, userID uuid.UUID, expectedVersions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", ctx, taskID, userID)
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, taskID, userID, expectedVersions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskUsecaseMockRecorder) DeleteTask(ctx, taskID, userID interface{}) *gomock.Call {
+func (mr *MockTaskUsecaseMockRecorder) DeleteTask(ctx, taskID, userID, expectedVersions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskUsecase)(nil).DeleteTask), ctx, taskID, userID, expectedVersions)
+}
+
+// GetBacklinks mocks base method.
+func (m *MockTaskUsecase) GetBacklinks(ctx context.Context, taskID uuid.UUID) ([]*dto.BacklinkDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacklinks", ctx, taskID)
+	ret0, _ := ret[0].([]*dto.BacklinkDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBacklinks indicates an expected call of GetBacklinks.
+func (mr *MockTaskUsecaseMockRecorder) GetBacklinks(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacklinks", reflect.TypeOf((*MockTaskUsecase)(nil).GetBacklinks), ctx, taskID)
+}
+
+// GetChecklist mocks base method.
+func (m *MockTaskUsecase) GetChecklist(ctx context.Context, taskID uuid.UUID) ([]*dto0.ChecklistItemDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklist", ctx, taskID)
+	ret0, _ := ret[0].([]*dto0.ChecklistItemDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChecklist indicates an expected call of GetChecklist.
+func (mr *MockTaskUsecaseMockRecorder) GetChecklist(ctx, taskID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskUsecase)(nil).DeleteTask), ctx, taskID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklist", reflect.TypeOf((*MockTaskUsecase)(nil).GetChecklist), ctx, taskID)
+}
+
+// GetTaskByID mocks base method.
+func (m *MockTaskUsecase) GetTaskByID(ctx context.Context, taskID uuid.UUID) (*dto0.TaskDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByID", ctx, taskID)
+	ret0, _ := ret[0].(*dto0.TaskDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByID indicates an expected call of GetTaskByID.
+func (mr *MockTaskUsecaseMockRecorder) GetTaskByID(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTaskUsecase)(nil).GetTaskByID), ctx, taskID)
 }
 
 // GetTasksByProjectID mocks base method.
-func (m *MockTaskUsecase) GetTasksByProjectID(ctx context.Context, projectID uuid.UUID) ([]*dto.TaskDTO, error) {
+func (m *MockTaskUsecase) GetTasksByProjectID(ctx context.Context, projectID uuid.UUID) ([]*dto0.TaskDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetTasksByProjectID", ctx, projectID)
-	ret0, _ := ret[0].([]*dto.TaskDTO)
+	ret0, _ := ret[0].([]*dto0.TaskDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -82,10 +157,10 @@ func (mr *MockTaskUsecaseMockRecorder) GetTasksByProjectID(ctx, projectID interf
 }
 
 // GetTasksByUserID mocks base method.
-func (m *MockTaskUsecase) GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*dto.TaskDTO, error) {
+func (m *MockTaskUsecase) GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*dto0.TaskDTO, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetTasksByUserID", ctx, userID)
-	ret0, _ := ret[0].([]*dto.TaskDTO)
+	ret0, _ := ret[0].([]*dto0.TaskDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -96,30 +171,62 @@ func (mr *MockTaskUsecaseMockRecorder) GetTasksByUserID(ctx, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByUserID", reflect.TypeOf((*MockTaskUsecase)(nil).GetTasksByUserID), ctx, userID)
 }
 
+// MoveChecklistItem mocks base method.
+func (m *MockTaskUsecase) MoveChecklistItem(ctx context.Context, taskID, itemID uuid.UUID, req dto0.MoveChecklistItemDTO) (*dto0.MoveChecklistItemDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveChecklistItem", ctx, taskID, itemID, req)
+	ret0, _ := ret[0].(*dto0.MoveChecklistItemDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveChecklistItem indicates an expected call of MoveChecklistItem.
+func (mr *MockTaskUsecaseMockRecorder) MoveChecklistItem(ctx, taskID, itemID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveChecklistItem", reflect.TypeOf((*MockTaskUsecase)(nil).MoveChecklistItem), ctx, taskID, itemID, req)
+}
+
+// UpdateChecklistItem mocks base method.
+func (m *MockTaskUsecase) UpdateChecklistItem(ctx context.Context, taskID, itemID uuid.UUID, req dto0.UpdateChecklistItemDTO) (*dto0.ChecklistItemDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChecklistItem", ctx, taskID, itemID, req)
+	ret0, _ := ret[0].(*dto0.ChecklistItemDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChecklistItem indicates an expected call of UpdateChecklistItem.
+func (mr *MockTaskUsecaseMockRecorder) UpdateChecklistItem(ctx, taskID, itemID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChecklistItem", reflect.TypeOf((*MockTaskUsecase)(nil).UpdateChecklistItem), ctx, taskID, itemID, req)
+}
+
 // UpdateTask mocks base method.
-func (m *MockTaskUsecase) UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, taskID, userID uuid.UUID) error {
+func (m *MockTaskUsecase) UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, estimateMinutes *int, startAt *time.Time, allDay bool, taskID, userID uuid.UUID, expectedVersions []int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", ctx, title, description, importance, deadline, taskID, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, expectedVersions)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskUsecaseMockRecorder) UpdateTask(ctx, title, description, importance, deadline, taskID, userID interface{}) *gomock.Call {
+func (mr *MockTaskUsecaseMockRecorder) UpdateTask(ctx, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, expectedVersions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskUsecase)(nil).UpdateTask), ctx, title, description, importance, deadline, taskID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskUsecase)(nil).UpdateTask), ctx, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, expectedVersions)
 }
 
 // UpdateTaskStatus mocks base method.
-func (m *MockTaskUsecase) UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID) error {
+func (m *MockTaskUsecase) UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID, expectedVersions []int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, status, taskID, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateTaskStatus", ctx, status, taskID, userID, expectedVersions)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdateTaskStatus indicates an expected call of UpdateTaskStatus.
-func (mr *MockTaskUsecaseMockRecorder) UpdateTaskStatus(ctx, status, taskID, userID interface{}) *gomock.Call {
+func (mr *MockTaskUsecaseMockRecorder) UpdateTaskStatus(ctx, status, taskID, userID, expectedVersions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockTaskUsecase)(nil).UpdateTaskStatus), ctx, status, taskID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskStatus", reflect.TypeOf((*MockTaskUsecase)(nil).UpdateTaskStatus), ctx, status, taskID, userID, expectedVersions)
 }
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: taskquery.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/task"
+	models0 "github.com/lzimin05/course-todo/internal/models/user"
+	taskquery "github.com/lzimin05/course-todo/internal/taskquery"
+)
+
+// MockTaskQueryRepository is a mock of TaskQueryRepository interface.
+type MockTaskQueryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskQueryRepositoryMockRecorder
+}
+
+// MockTaskQueryRepositoryMockRecorder is the mock recorder for MockTaskQueryRepository.
+type MockTaskQueryRepositoryMockRecorder struct {
+	mock *MockTaskQueryRepository
+}
+
+// NewMockTaskQueryRepository creates a new mock instance.
+func NewMockTaskQueryRepository(ctrl *gomock.Controller) *MockTaskQueryRepository {
+	mock := &MockTaskQueryRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskQueryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskQueryRepository) EXPECT() *MockTaskQueryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SearchTasks mocks base method.
+func (m *MockTaskQueryRepository) SearchTasks(ctx context.Context, query taskquery.Node, params taskquery.Params) ([]*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTasks", ctx, query, params)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTasks indicates an expected call of SearchTasks.
+func (mr *MockTaskQueryRepositoryMockRecorder) SearchTasks(ctx, query, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTasks", reflect.TypeOf((*MockTaskQueryRepository)(nil).SearchTasks), ctx, query, params)
+}
+
+// MockTaskQueryUserRepository is a mock of TaskQueryUserRepository interface.
+type MockTaskQueryUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskQueryUserRepositoryMockRecorder
+}
+
+// MockTaskQueryUserRepositoryMockRecorder is the mock recorder for MockTaskQueryUserRepository.
+type MockTaskQueryUserRepositoryMockRecorder struct {
+	mock *MockTaskQueryUserRepository
+}
+
+// NewMockTaskQueryUserRepository creates a new mock instance.
+func NewMockTaskQueryUserRepository(ctrl *gomock.Controller) *MockTaskQueryUserRepository {
+	mock := &MockTaskQueryUserRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskQueryUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskQueryUserRepository) EXPECT() *MockTaskQueryUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockTaskQueryUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*models0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockTaskQueryUserRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockTaskQueryUserRepository)(nil).GetUserByID), ctx, id)
+}
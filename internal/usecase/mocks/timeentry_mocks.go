@@ -0,0 +1,218 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: timeentry.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/timeentry"
+	models0 "github.com/lzimin05/course-todo/internal/models/user"
+)
+
+// MockTimeEntryRepository is a mock of TimeEntryRepository interface.
+type MockTimeEntryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntryRepositoryMockRecorder
+}
+
+// MockTimeEntryRepositoryMockRecorder is the mock recorder for MockTimeEntryRepository.
+type MockTimeEntryRepositoryMockRecorder struct {
+	mock *MockTimeEntryRepository
+}
+
+// NewMockTimeEntryRepository creates a new mock instance.
+func NewMockTimeEntryRepository(ctrl *gomock.Controller) *MockTimeEntryRepository {
+	mock := &MockTimeEntryRepository{ctrl: ctrl}
+	mock.recorder = &MockTimeEntryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntryRepository) EXPECT() *MockTimeEntryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTimeEntry mocks base method.
+func (m *MockTimeEntryRepository) CreateTimeEntry(ctx context.Context, entry *models.TimeEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTimeEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTimeEntry indicates an expected call of CreateTimeEntry.
+func (mr *MockTimeEntryRepositoryMockRecorder) CreateTimeEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTimeEntry", reflect.TypeOf((*MockTimeEntryRepository)(nil).CreateTimeEntry), ctx, entry)
+}
+
+// DeleteTimeEntry mocks base method.
+func (m *MockTimeEntryRepository) DeleteTimeEntry(ctx context.Context, entryID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimeEntry", ctx, entryID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTimeEntry indicates an expected call of DeleteTimeEntry.
+func (mr *MockTimeEntryRepositoryMockRecorder) DeleteTimeEntry(ctx, entryID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimeEntry", reflect.TypeOf((*MockTimeEntryRepository)(nil).DeleteTimeEntry), ctx, entryID, userID)
+}
+
+// GetRunningTimer mocks base method.
+func (m *MockTimeEntryRepository) GetRunningTimer(ctx context.Context, userID uuid.UUID) (*models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningTimer", ctx, userID)
+	ret0, _ := ret[0].(*models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningTimer indicates an expected call of GetRunningTimer.
+func (mr *MockTimeEntryRepositoryMockRecorder) GetRunningTimer(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTimer", reflect.TypeOf((*MockTimeEntryRepository)(nil).GetRunningTimer), ctx, userID)
+}
+
+// GetTaskProject mocks base method.
+func (m *MockTimeEntryRepository) GetTaskProject(ctx context.Context, taskID, userID uuid.UUID) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskProject", ctx, taskID, userID)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskProject indicates an expected call of GetTaskProject.
+func (mr *MockTimeEntryRepositoryMockRecorder) GetTaskProject(ctx, taskID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskProject", reflect.TypeOf((*MockTimeEntryRepository)(nil).GetTaskProject), ctx, taskID, userID)
+}
+
+// GetTaskTimeEntries mocks base method.
+func (m *MockTimeEntryRepository) GetTaskTimeEntries(ctx context.Context, taskID uuid.UUID) ([]models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskTimeEntries", ctx, taskID)
+	ret0, _ := ret[0].([]models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskTimeEntries indicates an expected call of GetTaskTimeEntries.
+func (mr *MockTimeEntryRepositoryMockRecorder) GetTaskTimeEntries(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTimeEntries", reflect.TypeOf((*MockTimeEntryRepository)(nil).GetTaskTimeEntries), ctx, taskID)
+}
+
+// GetTimeReport mocks base method.
+func (m *MockTimeEntryRepository) GetTimeReport(ctx context.Context, userID uuid.UUID, projectID *uuid.UUID, from, to time.Time, timezone string) ([]models.ReportRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeReport", ctx, userID, projectID, from, to, timezone)
+	ret0, _ := ret[0].([]models.ReportRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeReport indicates an expected call of GetTimeReport.
+func (mr *MockTimeEntryRepositoryMockRecorder) GetTimeReport(ctx, userID, projectID, from, to, timezone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeReport", reflect.TypeOf((*MockTimeEntryRepository)(nil).GetTimeReport), ctx, userID, projectID, from, to, timezone)
+}
+
+// StopTimer mocks base method.
+func (m *MockTimeEntryRepository) StopTimer(ctx context.Context, userID uuid.UUID, endedAt time.Time) (*models.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", ctx, userID, endedAt)
+	ret0, _ := ret[0].(*models.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockTimeEntryRepositoryMockRecorder) StopTimer(ctx, userID, endedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockTimeEntryRepository)(nil).StopTimer), ctx, userID, endedAt)
+}
+
+// MockTimeEntryProjectRepository is a mock of TimeEntryProjectRepository interface.
+type MockTimeEntryProjectRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntryProjectRepositoryMockRecorder
+}
+
+// MockTimeEntryProjectRepositoryMockRecorder is the mock recorder for MockTimeEntryProjectRepository.
+type MockTimeEntryProjectRepositoryMockRecorder struct {
+	mock *MockTimeEntryProjectRepository
+}
+
+// NewMockTimeEntryProjectRepository creates a new mock instance.
+func NewMockTimeEntryProjectRepository(ctrl *gomock.Controller) *MockTimeEntryProjectRepository {
+	mock := &MockTimeEntryProjectRepository{ctrl: ctrl}
+	mock.recorder = &MockTimeEntryProjectRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntryProjectRepository) EXPECT() *MockTimeEntryProjectRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckProjectAccess mocks base method.
+func (m *MockTimeEntryProjectRepository) CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckProjectAccess", ctx, projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckProjectAccess indicates an expected call of CheckProjectAccess.
+func (mr *MockTimeEntryProjectRepositoryMockRecorder) CheckProjectAccess(ctx, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckProjectAccess", reflect.TypeOf((*MockTimeEntryProjectRepository)(nil).CheckProjectAccess), ctx, projectID, userID)
+}
+
+// MockTimeEntryUserRepository is a mock of TimeEntryUserRepository interface.
+type MockTimeEntryUserRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntryUserRepositoryMockRecorder
+}
+
+// MockTimeEntryUserRepositoryMockRecorder is the mock recorder for MockTimeEntryUserRepository.
+type MockTimeEntryUserRepositoryMockRecorder struct {
+	mock *MockTimeEntryUserRepository
+}
+
+// NewMockTimeEntryUserRepository creates a new mock instance.
+func NewMockTimeEntryUserRepository(ctrl *gomock.Controller) *MockTimeEntryUserRepository {
+	mock := &MockTimeEntryUserRepository{ctrl: ctrl}
+	mock.recorder = &MockTimeEntryUserRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntryUserRepository) EXPECT() *MockTimeEntryUserRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockTimeEntryUserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*models0.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, id)
+	ret0, _ := ret[0].(*models0.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockTimeEntryUserRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockTimeEntryUserRepository)(nil).GetUserByID), ctx, id)
+}
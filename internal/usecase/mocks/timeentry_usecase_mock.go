@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: timeentry.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/timeentry"
+)
+
+// MockTimeEntryUsecase is a mock of TimeEntryUsecase interface.
+type MockTimeEntryUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntryUsecaseMockRecorder
+}
+
+// MockTimeEntryUsecaseMockRecorder is the mock recorder for MockTimeEntryUsecase.
+type MockTimeEntryUsecaseMockRecorder struct {
+	mock *MockTimeEntryUsecase
+}
+
+// NewMockTimeEntryUsecase creates a new mock instance.
+func NewMockTimeEntryUsecase(ctrl *gomock.Controller) *MockTimeEntryUsecase {
+	mock := &MockTimeEntryUsecase{ctrl: ctrl}
+	mock.recorder = &MockTimeEntryUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntryUsecase) EXPECT() *MockTimeEntryUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddTimeEntry mocks base method.
+func (m *MockTimeEntryUsecase) AddTimeEntry(ctx context.Context, taskID uuid.UUID, req dto.CreateTimeEntryDTO) (*dto.TimeEntryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTimeEntry", ctx, taskID, req)
+	ret0, _ := ret[0].(*dto.TimeEntryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTimeEntry indicates an expected call of AddTimeEntry.
+func (mr *MockTimeEntryUsecaseMockRecorder) AddTimeEntry(ctx, taskID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTimeEntry", reflect.TypeOf((*MockTimeEntryUsecase)(nil).AddTimeEntry), ctx, taskID, req)
+}
+
+// DeleteTimeEntry mocks base method.
+func (m *MockTimeEntryUsecase) DeleteTimeEntry(ctx context.Context, entryID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimeEntry", ctx, entryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTimeEntry indicates an expected call of DeleteTimeEntry.
+func (mr *MockTimeEntryUsecaseMockRecorder) DeleteTimeEntry(ctx, entryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimeEntry", reflect.TypeOf((*MockTimeEntryUsecase)(nil).DeleteTimeEntry), ctx, entryID)
+}
+
+// GetRunningTimer mocks base method.
+func (m *MockTimeEntryUsecase) GetRunningTimer(ctx context.Context) (*dto.TimeEntryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningTimer", ctx)
+	ret0, _ := ret[0].(*dto.TimeEntryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningTimer indicates an expected call of GetRunningTimer.
+func (mr *MockTimeEntryUsecaseMockRecorder) GetRunningTimer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTimer", reflect.TypeOf((*MockTimeEntryUsecase)(nil).GetRunningTimer), ctx)
+}
+
+// GetTaskTimeEntries mocks base method.
+func (m *MockTimeEntryUsecase) GetTaskTimeEntries(ctx context.Context, taskID uuid.UUID) (*dto.TaskTimeDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskTimeEntries", ctx, taskID)
+	ret0, _ := ret[0].(*dto.TaskTimeDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskTimeEntries indicates an expected call of GetTaskTimeEntries.
+func (mr *MockTimeEntryUsecaseMockRecorder) GetTaskTimeEntries(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTimeEntries", reflect.TypeOf((*MockTimeEntryUsecase)(nil).GetTaskTimeEntries), ctx, taskID)
+}
+
+// GetTimeReport mocks base method.
+func (m *MockTimeEntryUsecase) GetTimeReport(ctx context.Context, projectID *uuid.UUID, from, to time.Time) (*dto.TimeReportDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeReport", ctx, projectID, from, to)
+	ret0, _ := ret[0].(*dto.TimeReportDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeReport indicates an expected call of GetTimeReport.
+func (mr *MockTimeEntryUsecaseMockRecorder) GetTimeReport(ctx, projectID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeReport", reflect.TypeOf((*MockTimeEntryUsecase)(nil).GetTimeReport), ctx, projectID, from, to)
+}
+
+// StartTimer mocks base method.
+func (m *MockTimeEntryUsecase) StartTimer(ctx context.Context, taskID uuid.UUID, req dto.StartTimerDTO) (*dto.TimeEntryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", ctx, taskID, req)
+	ret0, _ := ret[0].(*dto.TimeEntryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockTimeEntryUsecaseMockRecorder) StartTimer(ctx, taskID, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockTimeEntryUsecase)(nil).StartTimer), ctx, taskID, req)
+}
+
+// StopTimer mocks base method.
+func (m *MockTimeEntryUsecase) StopTimer(ctx context.Context) (*dto.TimeEntryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", ctx)
+	ret0, _ := ret[0].(*dto.TimeEntryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockTimeEntryUsecaseMockRecorder) StopTimer(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockTimeEntryUsecase)(nil).StopTimer), ctx)
+}
@@ -81,6 +81,20 @@ func (mr *MockUserRepositoryMockRecorder) GetUserByLogin(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockUserRepository)(nil).GetUserByLogin), arg0, arg1)
 }
 
+// UpdateTimezone mocks base method.
+func (m *MockUserRepository) UpdateTimezone(arg0 context.Context, arg1 uuid.UUID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTimezone", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTimezone indicates an expected call of UpdateTimezone.
+func (mr *MockUserRepositoryMockRecorder) UpdateTimezone(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimezone", reflect.TypeOf((*MockUserRepository)(nil).UpdateTimezone), arg0, arg1, arg2)
+}
+
 // UpdateUsername mocks base method.
 func (m *MockUserRepository) UpdateUsername(arg0 context.Context, arg1 uuid.UUID, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -80,6 +80,20 @@ func (mr *MockIUserUsecaseMockRecorder) GetUserByLogin(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockIUserUsecase)(nil).GetUserByLogin), arg0, arg1)
 }
 
+// UpdateTimezone mocks base method.
+func (m *MockIUserUsecase) UpdateTimezone(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTimezone", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTimezone indicates an expected call of UpdateTimezone.
+func (mr *MockIUserUsecaseMockRecorder) UpdateTimezone(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimezone", reflect.TypeOf((*MockIUserUsecase)(nil).UpdateTimezone), arg0, arg1)
+}
+
 // UpdateUsername mocks base method.
 func (m *MockIUserUsecase) UpdateUsername(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
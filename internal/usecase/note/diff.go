@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"strings"
+
+	models "github.com/lzimin05/course-todo/internal/models/note"
+)
+
+// splitLines разбивает текст на строки. Пустой текст не содержит строк
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(text, "\n")
+}
+
+// diffLines строит построчный diff алгоритмом Майерса: минимальный набор
+// удалений и вставок, превращающий a в b
+func diffLines(a, b []string) []models.DiffLine {
+	// Общие начало и конец не влияют на результат, а алгоритм на них тратит время
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]models.DiffLine, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		result = append(result, models.DiffLine{Op: models.DiffEqual, OldLine: i + 1, NewLine: i + 1, Text: a[i]})
+	}
+
+	middle := myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	for _, line := range middle {
+		if line.OldLine > 0 {
+			line.OldLine += prefix
+		}
+		if line.NewLine > 0 {
+			line.NewLine += prefix
+		}
+		result = append(result, line)
+	}
+
+	for i := 0; i < suffix; i++ {
+		oldIdx := len(a) - suffix + i
+		newIdx := len(b) - suffix + i
+		result = append(result, models.DiffLine{Op: models.DiffEqual, OldLine: oldIdx + 1, NewLine: newIdx + 1, Text: a[oldIdx]})
+	}
+
+	return result
+}
+
+func myers(a, b []string) []models.DiffLine {
+	n, m := len(a), len(b)
+	maxD := n + m
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+
+	// trace[d] - состояние v перед шагом d, по нему восстанавливается путь
+	var trace [][]int
+	found := false
+	for d := 0; d <= maxD && !found; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	var reversed []models.DiffLine
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, models.DiffLine{Op: models.DiffEqual, OldLine: x, NewLine: y, Text: a[x-1]})
+			x--
+			y--
+		}
+
+		if d > 0 {
+			if x == prevX {
+				reversed = append(reversed, models.DiffLine{Op: models.DiffInsert, NewLine: y, Text: b[y-1]})
+			} else {
+				reversed = append(reversed, models.DiffLine{Op: models.DiffDelete, OldLine: x, Text: a[x-1]})
+			}
+		}
+
+		x, y = prevX, prevY
+	}
+
+	result := make([]models.DiffLine, len(reversed))
+	for i, line := range reversed {
+		result[len(reversed)-1-i] = line
+	}
+	return result
+}
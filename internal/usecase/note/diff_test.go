@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	models "github.com/lzimin05/course-todo/internal/models/note"
+)
+
+// applyDiff восстанавливает обе стороны сравнения из результата diff
+func applyDiff(lines []models.DiffLine) (oldLines, newLines []string) {
+	for _, line := range lines {
+		switch line.Op {
+		case models.DiffEqual:
+			oldLines = append(oldLines, line.Text)
+			newLines = append(newLines, line.Text)
+		case models.DiffDelete:
+			oldLines = append(oldLines, line.Text)
+		case models.DiffInsert:
+			newLines = append(newLines, line.Text)
+		}
+	}
+	return oldLines, newLines
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		expected []models.DiffLine
+	}{
+		{
+			name:     "both empty",
+			old:      "",
+			new:      "",
+			expected: []models.DiffLine{},
+		},
+		{
+			name: "from empty",
+			old:  "",
+			new:  "a\nb",
+			expected: []models.DiffLine{
+				{Op: models.DiffInsert, NewLine: 1, Text: "a"},
+				{Op: models.DiffInsert, NewLine: 2, Text: "b"},
+			},
+		},
+		{
+			name: "to empty",
+			old:  "a",
+			new:  "",
+			expected: []models.DiffLine{
+				{Op: models.DiffDelete, OldLine: 1, Text: "a"},
+			},
+		},
+		{
+			name: "line replaced in the middle",
+			old:  "a\nb\nc",
+			new:  "a\nx\nc",
+			expected: []models.DiffLine{
+				{Op: models.DiffEqual, OldLine: 1, NewLine: 1, Text: "a"},
+				{Op: models.DiffDelete, OldLine: 2, Text: "b"},
+				{Op: models.DiffInsert, NewLine: 2, Text: "x"},
+				{Op: models.DiffEqual, OldLine: 3, NewLine: 3, Text: "c"},
+			},
+		},
+		{
+			name: "crlf is normalized",
+			old:  "a\r\nb",
+			new:  "a\nb\nc",
+			expected: []models.DiffLine{
+				{Op: models.DiffEqual, OldLine: 1, NewLine: 1, Text: "a"},
+				{Op: models.DiffEqual, OldLine: 2, NewLine: 2, Text: "b"},
+				{Op: models.DiffInsert, NewLine: 3, Text: "c"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := diffLines(splitLines(tt.old), splitLines(tt.new))
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestDiffLines_Minimal(t *testing.T) {
+	// Классический пример из статьи Майерса: кратчайший скрипт - 5 правок
+	a := strings.Split("A B C A B B A", " ")
+	b := strings.Split("C B A B A C", " ")
+
+	result := diffLines(a, b)
+
+	edits := 0
+	for _, line := range result {
+		if line.Op != models.DiffEqual {
+			edits++
+		}
+	}
+	assert.Equal(t, 5, edits)
+
+	oldLines, newLines := applyDiff(result)
+	assert.Equal(t, a, oldLines)
+	assert.Equal(t, b, newLines)
+}
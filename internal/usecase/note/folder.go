@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/note"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/note"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+func (u *NoteUsecase) CreateFolder(ctx context.Context, req dto.CreateNoteFolderDTO) (*dto.NoteFolderDTO, error) {
+	const op = "NoteUsecase.CreateFolder"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", req.ProjectID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	hasAccess, err := u.projectRepo.CheckProjectAccess(ctx, req.ProjectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to check project access")
+		return nil, err
+	}
+
+	if !hasAccess {
+		logger.Warn("user doesn't have access to project")
+		return nil, errs.ErrNoAccess
+	}
+
+	folder := &models.NoteFolder{
+		ID:        uuid.New(),
+		ProjectID: req.ProjectID,
+		ParentID:  req.ParentID,
+		Name:      req.Name,
+		CreatedAt: time.Now(),
+	}
+
+	if err := u.repo.CreateFolder(ctx, folder); err != nil {
+		logger.WithError(err).Error("failed to create folder in repository")
+		return nil, err
+	}
+
+	// Путь папки зависит от родителей, поэтому берем его из общего списка
+	folders, err := u.repo.GetFoldersByProject(ctx, req.ProjectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note folders from repository")
+		return nil, err
+	}
+
+	for _, f := range folders {
+		if f.ID == folder.ID {
+			folder.Path = f.Path
+			break
+		}
+	}
+
+	return folderToDTO(folder), nil
+}
+
+func (u *NoteUsecase) UpdateFolder(ctx context.Context, folderID uuid.UUID, req dto.UpdateNoteFolderDTO) error {
+	const op = "NoteUsecase.UpdateFolder"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("folderID", folderID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	if err := u.repo.UpdateFolder(ctx, folderID, userID, req.Name, req.ParentID); err != nil {
+		logger.WithError(err).Error("failed to update folder in repository")
+		return err
+	}
+
+	return nil
+}
+
+func (u *NoteUsecase) DeleteFolder(ctx context.Context, folderID uuid.UUID) error {
+	const op = "NoteUsecase.DeleteFolder"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("folderID", folderID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	if err := u.repo.DeleteFolder(ctx, folderID, userID); err != nil {
+		logger.WithError(err).Error("failed to delete folder from repository")
+		return err
+	}
+
+	return nil
+}
+
+// GetNoteTree собирает дерево папок и заметок проекта из двух запросов
+func (u *NoteUsecase) GetNoteTree(ctx context.Context, projectID uuid.UUID) (*dto.NoteTreeDTO, error) {
+	const op = "NoteUsecase.GetNoteTree"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("projectID", projectID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	hasAccess, err := u.projectRepo.CheckProjectAccess(ctx, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to check project access")
+		return nil, err
+	}
+
+	if !hasAccess {
+		logger.Warn("user doesn't have access to project")
+		return nil, errs.ErrNoAccess
+	}
+
+	folders, err := u.repo.GetFoldersByProject(ctx, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note folders from repository")
+		return nil, err
+	}
+
+	notes, err := u.repo.GetNotesByProject(ctx, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get notes by project from repository")
+		return nil, err
+	}
+
+	tree := &dto.NoteTreeDTO{
+		ProjectID: projectID,
+		Folders:   make([]*dto.NoteFolderNodeDTO, 0),
+		Notes:     make([]*dto.NoteDTO, 0),
+	}
+
+	nodes := make(map[uuid.UUID]*dto.NoteFolderNodeDTO, len(folders))
+	for _, f := range folders {
+		nodes[f.ID] = &dto.NoteFolderNodeDTO{
+			ID:       f.ID,
+			ParentID: f.ParentID,
+			Name:     f.Name,
+			Path:     f.Path,
+			Folders:  make([]*dto.NoteFolderNodeDTO, 0),
+			Notes:    make([]*dto.NoteDTO, 0),
+		}
+	}
+
+	// Папки приходят отсортированными по пути, порядок сохраняется среди соседей
+	for _, f := range folders {
+		node := nodes[f.ID]
+		if f.ParentID != nil {
+			if parent, ok := nodes[*f.ParentID]; ok {
+				parent.Folders = append(parent.Folders, node)
+				continue
+			}
+		}
+		tree.Folders = append(tree.Folders, node)
+	}
+
+	for _, n := range notes {
+		note := noteToDTO(&n)
+		if n.FolderID != nil {
+			if folder, ok := nodes[*n.FolderID]; ok {
+				note.Path = folder.Path
+				folder.Notes = append(folder.Notes, note)
+				continue
+			}
+		}
+		tree.Notes = append(tree.Notes, note)
+	}
+
+	return tree, nil
+}
+
+// MoveNote переносит заметку в папку и ставит на указанную позицию
+func (u *NoteUsecase) MoveNote(ctx context.Context, noteID uuid.UUID, req dto.MoveNoteDTO) (*dto.MoveNoteDTO, error) {
+	const op = "NoteUsecase.MoveNote"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	position, err := u.repo.MoveNote(ctx, noteID, userID, req.FolderID, req.Position)
+	if err != nil {
+		logger.WithError(err).Error("failed to move note in repository")
+		return nil, err
+	}
+
+	return &dto.MoveNoteDTO{
+		FolderID: req.FolderID,
+		Position: position,
+	}, nil
+}
+
+// SetNoteFlags закрепляет заметку или добавляет ее в избранное для текущего пользователя
+func (u *NoteUsecase) SetNoteFlags(ctx context.Context, noteID uuid.UUID, req dto.NoteFlagsDTO) error {
+	const op = "NoteUsecase.SetNoteFlags"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	if err := u.repo.SetNoteFlags(ctx, noteID, userID, req.Pinned, req.Favourite); err != nil {
+		logger.WithError(err).Error("failed to set note flags in repository")
+		return err
+	}
+
+	return nil
+}
+
+func folderToDTO(folder *models.NoteFolder) *dto.NoteFolderDTO {
+	return &dto.NoteFolderDTO{
+		ID:        folder.ID,
+		ProjectID: folder.ProjectID,
+		ParentID:  folder.ParentID,
+		Name:      folder.Name,
+		Path:      folder.Path,
+		CreatedAt: folder.CreatedAt.Truncate(time.Second),
+	}
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/note"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/note"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestNoteUsecase_CreateFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteRepo := mocks.NewMockINoteRepository(ctrl)
+	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	uc := NewNoteUsecase(noteRepo, projectRepo, mocks.NewMockNoteHTMLCache(ctrl), mocks.NewMockNoteLinkRepository(ctrl), mocks.NewMockNoteAttachmentCleaner(ctrl), mocks.NewMockNoteMentionService(ctrl))
+
+	ctx, userID := setupNoteTest()
+	projectID := uuid.New()
+	parentID := uuid.New()
+
+	t.Run("folder path is returned", func(t *testing.T) {
+		var created *models.NoteFolder
+		projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+		noteRepo.EXPECT().CreateFolder(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, folder *models.NoteFolder) error {
+				created = folder
+				return nil
+			})
+		noteRepo.EXPECT().GetFoldersByProject(gomock.Any(), projectID, userID).
+			DoAndReturn(func(_ interface{}, _, _ uuid.UUID) ([]models.NoteFolder, error) {
+				return []models.NoteFolder{
+					{ID: parentID, Name: "Docs", Path: "Docs"},
+					{ID: created.ID, ParentID: &parentID, Name: "API", Path: "Docs/API"},
+				}, nil
+			})
+
+		folder, err := uc.CreateFolder(ctx, dto.CreateNoteFolderDTO{ProjectID: projectID, ParentID: &parentID, Name: "API"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Docs/API", folder.Path)
+		assert.Equal(t, &parentID, folder.ParentID)
+	})
+
+	t.Run("no project access", func(t *testing.T) {
+		projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(false, nil)
+
+		folder, err := uc.CreateFolder(ctx, dto.CreateNoteFolderDTO{ProjectID: projectID, Name: "API"})
+
+		assert.ErrorIs(t, err, errs.ErrNoAccess)
+		assert.Nil(t, folder)
+	})
+}
+
+func TestNoteUsecase_GetNoteTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteRepo := mocks.NewMockINoteRepository(ctrl)
+	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	uc := NewNoteUsecase(noteRepo, projectRepo, mocks.NewMockNoteHTMLCache(ctrl), mocks.NewMockNoteLinkRepository(ctrl), mocks.NewMockNoteAttachmentCleaner(ctrl), mocks.NewMockNoteMentionService(ctrl))
+
+	ctx, userID := setupNoteTest()
+	projectID := uuid.New()
+	docsID := uuid.New()
+	apiID := uuid.New()
+	archiveID := uuid.New()
+
+	folders := []models.NoteFolder{
+		{ID: archiveID, ProjectID: projectID, Name: "Archive", Path: "Archive"},
+		{ID: docsID, ProjectID: projectID, Name: "Docs", Path: "Docs"},
+		{ID: apiID, ProjectID: projectID, ParentID: &docsID, Name: "API", Path: "Docs/API"},
+	}
+	notes := []models.Note{
+		{ID: uuid.New(), ProjectID: projectID, Name: "Pinned root", Pinned: true},
+		{ID: uuid.New(), ProjectID: projectID, Name: "Endpoints", FolderID: &apiID},
+		{ID: uuid.New(), ProjectID: projectID, Name: "Root", Position: 1},
+	}
+
+	t.Run("tree is built from flat lists", func(t *testing.T) {
+		projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+		noteRepo.EXPECT().GetFoldersByProject(gomock.Any(), projectID, userID).Return(folders, nil)
+		noteRepo.EXPECT().GetNotesByProject(gomock.Any(), projectID, userID).Return(notes, nil)
+
+		tree, err := uc.GetNoteTree(ctx, projectID)
+
+		assert.NoError(t, err)
+		assert.Len(t, tree.Folders, 2)
+		assert.Equal(t, "Archive", tree.Folders[0].Name)
+		assert.Empty(t, tree.Folders[0].Notes)
+
+		docs := tree.Folders[1]
+		assert.Len(t, docs.Folders, 1)
+		assert.Equal(t, "Docs/API", docs.Folders[0].Path)
+		assert.Len(t, docs.Folders[0].Notes, 1)
+		assert.Equal(t, "Docs/API", docs.Folders[0].Notes[0].Path)
+
+		assert.Len(t, tree.Notes, 2)
+		assert.Equal(t, "Pinned root", tree.Notes[0].Name)
+		assert.True(t, tree.Notes[0].Pinned)
+	})
+
+	t.Run("folders error", func(t *testing.T) {
+		projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+		noteRepo.EXPECT().GetFoldersByProject(gomock.Any(), projectID, userID).Return(nil, errors.New("db error"))
+
+		tree, err := uc.GetNoteTree(ctx, projectID)
+
+		assert.Error(t, err)
+		assert.Nil(t, tree)
+	})
+}
+
+func TestNoteUsecase_MoveNote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteRepo := mocks.NewMockINoteRepository(ctrl)
+	uc := NewNoteUsecase(noteRepo, mocks.NewMockNoteProjectRepository(ctrl), mocks.NewMockNoteHTMLCache(ctrl), mocks.NewMockNoteLinkRepository(ctrl), mocks.NewMockNoteAttachmentCleaner(ctrl), mocks.NewMockNoteMentionService(ctrl))
+
+	ctx, userID := setupNoteTest()
+	noteID := uuid.New()
+	folderID := uuid.New()
+
+	noteRepo.EXPECT().MoveNote(gomock.Any(), noteID, userID, &folderID, 10).Return(3, nil)
+
+	result, err := uc.MoveNote(ctx, noteID, dto.MoveNoteDTO{FolderID: &folderID, Position: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Position)
+	assert.Equal(t, &folderID, result.FolderID)
+}
+
+func TestNoteUsecase_SetNoteFlags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteRepo := mocks.NewMockINoteRepository(ctrl)
+	uc := NewNoteUsecase(noteRepo, mocks.NewMockNoteProjectRepository(ctrl), mocks.NewMockNoteHTMLCache(ctrl), mocks.NewMockNoteLinkRepository(ctrl), mocks.NewMockNoteAttachmentCleaner(ctrl), mocks.NewMockNoteMentionService(ctrl))
+
+	ctx, userID := setupNoteTest()
+	noteID := uuid.New()
+	favourite := true
+
+	noteRepo.EXPECT().SetNoteFlags(gomock.Any(), noteID, userID, nil, &favourite).Return(errs.ErrNotFound)
+
+	err := uc.SetNoteFlags(ctx, noteID, dto.NoteFlagsDTO{Favourite: &favourite})
+
+	assert.ErrorIs(t, err, errs.ErrNotFound)
+}
@@ -6,34 +6,79 @@ import (
 
 	"github.com/google/uuid"
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
 	models "github.com/lzimin05/course-todo/internal/models/note"
+	linkdto "github.com/lzimin05/course-todo/internal/transport/dto/link"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/note"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
 	"github.com/lzimin05/course-todo/internal/usecase/helpers"
 )
 
-//go:generate mockgen -source=note.go -destination=../mocks/note_mocks.go -package=mocks INoteRepository,NoteProjectRepository
+//go:generate mockgen -source=note.go -destination=../mocks/note_mocks.go -package=mocks INoteRepository,NoteProjectRepository,NoteHTMLCache,NoteLinkRepository,NoteAttachmentCleaner,NoteMentionService
 type INoteRepository interface {
 	GetAllNotes(ctx context.Context, userID uuid.UUID) ([]models.Note, error)
 	GetNotesByProject(ctx context.Context, projectID, userID uuid.UUID) ([]models.Note, error)
-	CreateNote(ctx context.Context, projectID, userID uuid.UUID, name, description string) (uuid.UUID, error)
-	UpdateNote(ctx context.Context, userID, noteID, projectID uuid.UUID, name, description string) error
-	DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error
+	CreateNote(ctx context.Context, projectID, userID uuid.UUID, name, description, format string, refs linkmodels.References, mentions []string) (uuid.UUID, error)
+	GetNoteByID(ctx context.Context, noteID, userID uuid.UUID) (*models.Note, error)
+	UpdateNote(ctx context.Context, userID, noteID, projectID uuid.UUID, name, description, format string, expectedVersions []int, refs linkmodels.References, mentions []string) (int, error)
+	DeleteNote(ctx context.Context, userID, noteID uuid.UUID, expectedVersions []int) error
+	GetNoteRevisions(ctx context.Context, noteID, userID uuid.UUID) ([]models.NoteRevision, error)
+	GetNoteRevision(ctx context.Context, noteID uuid.UUID, revision int, userID uuid.UUID) (*models.NoteRevision, error)
+	RestoreNoteRevision(ctx context.Context, noteID uuid.UUID, revision int, userID uuid.UUID, refs linkmodels.References, mentions []string) (*models.NoteRevision, error)
+	CreateFolder(ctx context.Context, folder *models.NoteFolder) error
+	GetFoldersByProject(ctx context.Context, projectID, userID uuid.UUID) ([]models.NoteFolder, error)
+	UpdateFolder(ctx context.Context, folderID, userID uuid.UUID, name string, parentID *uuid.UUID) error
+	DeleteFolder(ctx context.Context, folderID, userID uuid.UUID) error
+	MoveNote(ctx context.Context, noteID, userID uuid.UUID, folderID *uuid.UUID, position int) (int, error)
+	SetNoteFlags(ctx context.Context, noteID, userID uuid.UUID, pinned, favourite *bool) error
 }
 
 type NoteProjectRepository interface {
 	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
 }
 
+// NoteHTMLCache хранит отрисованный HTML заметок. Запись привязана к отметке
+// исходных данных: версии заметки и набору упоминаний
+type NoteHTMLCache interface {
+	Get(ctx context.Context, noteID uuid.UUID, stamp string) (string, bool, error)
+	Set(ctx context.Context, noteID uuid.UUID, stamp string, html string) error
+	Invalidate(ctx context.Context, noteID uuid.UUID) error
+}
+
+// NoteLinkRepository отдает сущности, ссылающиеся на заметку. Сами ссылки
+// сохраняются репозиторием заметок вместе с текстом
+type NoteLinkRepository interface {
+	GetBacklinks(ctx context.Context, targetType string, targetID, userID uuid.UUID) ([]linkmodels.Backlink, error)
+}
+
+// NoteAttachmentCleaner удаляет из хранилища файлы вложений удаленных заметок
+type NoteAttachmentCleaner interface {
+	PurgeDeleted(ctx context.Context) error
+}
+
+// NoteMentionService разбирает упоминания @login в тексте заметок
+type NoteMentionService interface {
+	CheckMentions(ctx context.Context, projectID uuid.UUID, text string) error
+	GetSourceMentions(ctx context.Context, sourceType string, sourceID uuid.UUID) (map[string]uuid.UUID, error)
+}
+
 type NoteUsecase struct {
 	repo        INoteRepository
 	projectRepo NoteProjectRepository
+	htmlCache   NoteHTMLCache
+	linkRepo    NoteLinkRepository
+	attachments NoteAttachmentCleaner
+	mentions    NoteMentionService
 }
 
-func NewNoteUsecase(repo INoteRepository, projectRepo NoteProjectRepository) *NoteUsecase {
+func NewNoteUsecase(repo INoteRepository, projectRepo NoteProjectRepository, htmlCache NoteHTMLCache, linkRepo NoteLinkRepository, attachments NoteAttachmentCleaner, mentions NoteMentionService) *NoteUsecase {
 	return &NoteUsecase{
 		repo:        repo,
 		projectRepo: projectRepo,
+		htmlCache:   htmlCache,
+		linkRepo:    linkRepo,
+		attachments: attachments,
+		mentions:    mentions,
 	}
 }
 
@@ -55,14 +100,7 @@ func (u *NoteUsecase) GetAllNotes(ctx context.Context) ([]*dto.NoteDTO, error) {
 
 	notesDTO := make([]*dto.NoteDTO, len(notesmodel))
 	for i, notemodel := range notesmodel {
-		notesDTO[i] = &dto.NoteDTO{
-			ID:          notemodel.ID,
-			ProjectID:   notemodel.ProjectID,
-			UserID:      notemodel.UserID,
-			Name:        notemodel.Name,
-			Description: notemodel.Description,
-			CreatedAt:   notemodel.CreatedAt.Truncate(time.Second),
-		}
+		notesDTO[i] = noteToDTO(&notemodel)
 	}
 
 	return notesDTO, nil
@@ -96,21 +134,48 @@ func (u *NoteUsecase) GetNotesByProject(ctx context.Context, projectID uuid.UUID
 		return nil, err
 	}
 
+	folders, err := u.repo.GetFoldersByProject(ctx, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note folders from repository")
+		return nil, err
+	}
+
+	paths := make(map[uuid.UUID]string, len(folders))
+	for _, folder := range folders {
+		paths[folder.ID] = folder.Path
+	}
+
 	notesDTO := make([]*dto.NoteDTO, len(notesmodel))
 	for i, notemodel := range notesmodel {
-		notesDTO[i] = &dto.NoteDTO{
-			ID:          notemodel.ID,
-			ProjectID:   notemodel.ProjectID,
-			UserID:      notemodel.UserID,
-			Name:        notemodel.Name,
-			Description: notemodel.Description,
-			CreatedAt:   notemodel.CreatedAt.Truncate(time.Second),
+		notesDTO[i] = noteToDTO(&notemodel)
+		if notemodel.FolderID != nil {
+			notesDTO[i].Path = paths[*notemodel.FolderID]
 		}
 	}
 
 	return notesDTO, nil
 }
 
+func (u *NoteUsecase) GetNoteByID(ctx context.Context, noteID uuid.UUID) (*dto.NoteDTO, error) {
+	const op = "NoteUsecase.GetNoteByID"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	notemodel, err := u.repo.GetNoteByID(ctx, noteID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note from repository")
+		return nil, err
+	}
+
+	return noteToDTO(notemodel), nil
+}
+
 func (u *NoteUsecase) CreateNote(ctx context.Context, req dto.CreateOrUpdateNote) (*dto.CreateNoteDTO, error) {
 	const op = "NoteUsecase.CreateNote"
 	logger := logctx.GetLogger(ctx).WithField("op", op)
@@ -126,6 +191,11 @@ func (u *NoteUsecase) CreateNote(ctx context.Context, req dto.CreateOrUpdateNote
 		return nil, errs.ErrEmptyNoteName
 	}
 
+	format := req.Format
+	if format == "" {
+		format = models.FormatPlain
+	}
+
 	// Проверяем права доступа к проекту
 	hasAccess, err := u.projectRepo.CheckProjectAccess(ctx, req.ProjectID, userID)
 	if err != nil {
@@ -138,7 +208,12 @@ func (u *NoteUsecase) CreateNote(ctx context.Context, req dto.CreateOrUpdateNote
 		return nil, errs.ErrNoAccess
 	}
 
-	noteID, err := u.repo.CreateNote(ctx, req.ProjectID, userID, req.Name, req.Description)
+	if err := u.mentions.CheckMentions(ctx, req.ProjectID, req.Description); err != nil {
+		logger.WithError(err).Warn("invalid mentions in note")
+		return nil, err
+	}
+
+	noteID, err := u.repo.CreateNote(ctx, req.ProjectID, userID, req.Name, req.Description, format, helpers.ParseReferences(req.Description), helpers.ParseMentions(req.Description))
 	if err != nil {
 		logger.WithError(err).Error("failed to create note in repository")
 		return nil, err
@@ -149,7 +224,7 @@ func (u *NoteUsecase) CreateNote(ctx context.Context, req dto.CreateOrUpdateNote
 	}, nil
 }
 
-func (u *NoteUsecase) UpdateNote(ctx context.Context, noteID uuid.UUID, req dto.CreateOrUpdateNote) error {
+func (u *NoteUsecase) UpdateNote(ctx context.Context, noteID uuid.UUID, req dto.CreateOrUpdateNote, expectedVersions []int) (int, error) {
 	const op = "NoteUsecase.UpdateNote"
 	logger := logctx.GetLogger(ctx).WithField("op", op).
 		WithField("noteID", noteID)
@@ -157,24 +232,33 @@ func (u *NoteUsecase) UpdateNote(ctx context.Context, noteID uuid.UUID, req dto.
 	userID, err := helpers.GetUserIDFromContext(ctx)
 	if err != nil {
 		logger.WithError(err).Error("invalid user ID format")
-		return err
+		return 0, err
 	}
 
 	if req.Name == "" {
 		logger.Warn("empty note name")
-		return errs.ErrEmptyNoteName
+		return 0, errs.ErrEmptyNoteName
+	}
+
+	if err := u.mentions.CheckMentions(ctx, req.ProjectID, req.Description); err != nil {
+		logger.WithError(err).Warn("invalid mentions in note")
+		return 0, err
 	}
 
-	err = u.repo.UpdateNote(ctx, userID, noteID, req.ProjectID, req.Name, req.Description)
+	version, err := u.repo.UpdateNote(ctx, userID, noteID, req.ProjectID, req.Name, req.Description, req.Format, expectedVersions, helpers.ParseReferences(req.Description), helpers.ParseMentions(req.Description))
 	if err != nil {
 		logger.WithError(err).Error("failed to update note in repository")
-		return err
+		return 0, err
 	}
 
-	return nil
+	// Сбрасываем после сохранения упоминаний, иначе параллельная отрисовка
+	// успеет закэшировать HTML без ссылок на пользователей
+	u.invalidateHTML(ctx, noteID)
+
+	return version, nil
 }
 
-func (u *NoteUsecase) DeleteNote(ctx context.Context, noteID uuid.UUID) error {
+func (u *NoteUsecase) DeleteNote(ctx context.Context, noteID uuid.UUID, expectedVersions []int) error {
 	const op = "NoteUsecase.DeleteNote"
 	logger := logctx.GetLogger(ctx).WithField("op", op).
 		WithField("noteID", noteID)
@@ -185,11 +269,281 @@ func (u *NoteUsecase) DeleteNote(ctx context.Context, noteID uuid.UUID) error {
 		return err
 	}
 
-	err = u.repo.DeleteNote(ctx, userID, noteID)
+	err = u.repo.DeleteNote(ctx, userID, noteID, expectedVersions)
 	if err != nil {
 		logger.WithError(err).Error("failed to delete note from repository")
 		return err
 	}
 
+	u.invalidateHTML(ctx, noteID)
+
+	if err := u.attachments.PurgeDeleted(ctx); err != nil {
+		logger.WithError(err).Warn("failed to purge attachment files")
+	}
+
 	return nil
 }
+
+func (u *NoteUsecase) GetNoteRevisions(ctx context.Context, noteID uuid.UUID) ([]*dto.NoteRevisionSummaryDTO, error) {
+	const op = "NoteUsecase.GetNoteRevisions"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	revisions, err := u.repo.GetNoteRevisions(ctx, noteID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note revisions from repository")
+		return nil, err
+	}
+
+	revisionsDTO := make([]*dto.NoteRevisionSummaryDTO, len(revisions))
+	for i, revision := range revisions {
+		revisionsDTO[i] = &dto.NoteRevisionSummaryDTO{
+			Revision:     revision.Revision,
+			Name:         revision.Name,
+			AuthorID:     revision.AuthorID,
+			CreatedAt:    revision.CreatedAt.Truncate(time.Second),
+			RestoredFrom: revision.RestoredFrom,
+		}
+	}
+
+	return revisionsDTO, nil
+}
+
+func (u *NoteUsecase) GetNoteRevision(ctx context.Context, noteID uuid.UUID, revision int) (*dto.NoteRevisionDTO, error) {
+	const op = "NoteUsecase.GetNoteRevision"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID).WithField("revision", revision)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	revisionModel, err := u.repo.GetNoteRevision(ctx, noteID, revision, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note revision from repository")
+		return nil, err
+	}
+
+	return revisionToDTO(revisionModel), nil
+}
+
+func (u *NoteUsecase) DiffNoteRevisions(ctx context.Context, noteID uuid.UUID, from, to int) (*dto.NoteDiffDTO, error) {
+	const op = "NoteUsecase.DiffNoteRevisions"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID).WithField("from", from).WithField("to", to)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	oldRevision, err := u.repo.GetNoteRevision(ctx, noteID, from, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get source revision from repository")
+		return nil, err
+	}
+
+	newRevision, err := u.repo.GetNoteRevision(ctx, noteID, to, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get target revision from repository")
+		return nil, err
+	}
+
+	lines := diffLines(splitLines(oldRevision.Description), splitLines(newRevision.Description))
+
+	diffDTO := &dto.NoteDiffDTO{
+		From:        from,
+		To:          to,
+		NameChanged: oldRevision.Name != newRevision.Name,
+		OldName:     oldRevision.Name,
+		NewName:     newRevision.Name,
+		Lines:       make([]dto.DiffLineDTO, len(lines)),
+	}
+	for i, line := range lines {
+		switch line.Op {
+		case models.DiffInsert:
+			diffDTO.Added++
+		case models.DiffDelete:
+			diffDTO.Removed++
+		}
+		diffDTO.Lines[i] = dto.DiffLineDTO{
+			Op:      line.Op,
+			OldLine: line.OldLine,
+			NewLine: line.NewLine,
+			Text:    line.Text,
+		}
+	}
+
+	return diffDTO, nil
+}
+
+func (u *NoteUsecase) RestoreNoteRevision(ctx context.Context, noteID uuid.UUID, revision int) (*dto.NoteRevisionDTO, error) {
+	const op = "NoteUsecase.RestoreNoteRevision"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID).WithField("revision", revision)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	// Ревизии не меняются, поэтому ссылки и упоминания из ее текста можно разобрать до восстановления
+	old, err := u.repo.GetNoteRevision(ctx, noteID, revision, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note revision from repository")
+		return nil, err
+	}
+
+	restored, err := u.repo.RestoreNoteRevision(ctx, noteID, revision, userID,
+		helpers.ParseReferences(old.Description), helpers.ParseMentions(old.Description))
+	if err != nil {
+		logger.WithError(err).Error("failed to restore note revision in repository")
+		return nil, err
+	}
+
+	u.invalidateHTML(ctx, noteID)
+
+	return revisionToDTO(restored), nil
+}
+
+// RenderNote возвращает описание заметки в виде безопасного HTML.
+// Упоминания участников проекта становятся ссылками на пользователя.
+// Результат кэшируется до следующего изменения заметки
+func (u *NoteUsecase) RenderNote(ctx context.Context, noteID uuid.UUID) (*dto.RenderedNoteDTO, error) {
+	const op = "NoteUsecase.RenderNote"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	notemodel, err := u.repo.GetNoteByID(ctx, noteID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get note from repository")
+		return nil, err
+	}
+
+	rendered := &dto.RenderedNoteDTO{
+		NoteID:  notemodel.ID,
+		Format:  notemodel.Format,
+		Version: notemodel.Version,
+	}
+
+	// Без списка упоминаний заметка отрисовывается с обычным текстом @login.
+	// Список входит в отметку кэша: HTML, отрисованный до сохранения
+	// упоминаний, не будет отдан после него
+	mentions, err := u.mentions.GetSourceMentions(ctx, linkmodels.TypeNote, noteID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get note mentions")
+	}
+	stamp := renderStamp(notemodel.Version, mentions)
+
+	// Кэш только ускоряет ответ, поэтому его ошибки не прерывают запрос
+	html, found, err := u.htmlCache.Get(ctx, noteID, stamp)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get note html from cache")
+	}
+	if found {
+		rendered.HTML = html
+		return rendered, nil
+	}
+
+	rendered.HTML, err = renderNoteHTML(notemodel.Format, notemodel.Description, mentions)
+	if err != nil {
+		logger.WithError(err).Error("failed to render note")
+		return nil, err
+	}
+
+	if err := u.htmlCache.Set(ctx, noteID, stamp, rendered.HTML); err != nil {
+		logger.WithError(err).Warn("failed to save note html to cache")
+	}
+
+	return rendered, nil
+}
+
+func (u *NoteUsecase) invalidateHTML(ctx context.Context, noteID uuid.UUID) {
+	if err := u.htmlCache.Invalidate(ctx, noteID); err != nil {
+		logctx.GetLogger(ctx).WithField("noteID", noteID).
+			WithError(err).Warn("failed to invalidate note html cache")
+	}
+}
+
+// GetBacklinks возвращает задачи и заметки, которые ссылаются на заметку
+// и доступны пользователю
+func (u *NoteUsecase) GetBacklinks(ctx context.Context, noteID uuid.UUID) ([]*linkdto.BacklinkDTO, error) {
+	const op = "NoteUsecase.GetBacklinks"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("noteID", noteID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	if _, err := u.repo.GetNoteByID(ctx, noteID, userID); err != nil {
+		logger.WithError(err).Error("failed to get note from repository")
+		return nil, err
+	}
+
+	backlinks, err := u.linkRepo.GetBacklinks(ctx, linkmodels.TypeNote, noteID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get backlinks from repository")
+		return nil, err
+	}
+
+	backlinksDTO := make([]*linkdto.BacklinkDTO, len(backlinks))
+	for i, backlink := range backlinks {
+		backlinksDTO[i] = &linkdto.BacklinkDTO{
+			Type:      backlink.Type,
+			ID:        backlink.ID,
+			ProjectID: backlink.ProjectID,
+			Title:     backlink.Title,
+		}
+	}
+
+	return backlinksDTO, nil
+}
+
+func noteToDTO(note *models.Note) *dto.NoteDTO {
+	return &dto.NoteDTO{
+		ID:          note.ID,
+		ProjectID:   note.ProjectID,
+		UserID:      note.UserID,
+		Name:        note.Name,
+		Description: note.Description,
+		Format:      note.Format,
+		CreatedAt:   note.CreatedAt.Truncate(time.Second),
+		Version:     note.Version,
+		FolderID:    note.FolderID,
+		Position:    note.Position,
+		Pinned:      note.Pinned,
+		Favourite:   note.Favourite,
+	}
+}
+
+func revisionToDTO(revision *models.NoteRevision) *dto.NoteRevisionDTO {
+	return &dto.NoteRevisionDTO{
+		NoteID:       revision.NoteID,
+		Revision:     revision.Revision,
+		Name:         revision.Name,
+		Description:  revision.Description,
+		Format:       revision.Format,
+		AuthorID:     revision.AuthorID,
+		CreatedAt:    revision.CreatedAt.Truncate(time.Second),
+		RestoredFrom: revision.RestoredFrom,
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/lzimin05/course-todo/internal/models/domains"
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
 	models "github.com/lzimin05/course-todo/internal/models/note"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/note"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
@@ -31,6 +32,8 @@ func TestNoteUsecase_GetAllNotes(t *testing.T) {
 
 	noteRepo := mocks.NewMockINoteRepository(ctrl)
 	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
 
 	notes := []models.Note{
 		{
@@ -77,7 +80,7 @@ func TestNoteUsecase_GetAllNotes(t *testing.T) {
 			ctx, userID := setupNoteTest()
 			tt.setupMocks(userID)
 
-			uc := NewNoteUsecase(noteRepo, projectRepo)
+			uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, mocks.NewMockNoteAttachmentCleaner(ctrl), mocks.NewMockNoteMentionService(ctrl))
 			result, err := uc.GetAllNotes(ctx)
 
 			if tt.expectedErr != nil {
@@ -98,9 +101,12 @@ func TestNoteUsecase_GetNotesByProject(t *testing.T) {
 
 	noteRepo := mocks.NewMockINoteRepository(ctrl)
 	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
 
 	projectID := uuid.New()
 	userID := uuid.New()
+	folderID := uuid.New()
 	notes := []models.Note{
 		{
 			ID:          uuid.New(),
@@ -109,8 +115,12 @@ func TestNoteUsecase_GetNotesByProject(t *testing.T) {
 			ProjectID:   projectID,
 			UserID:      userID,
 			CreatedAt:   time.Now(),
+			FolderID:    &folderID,
 		},
 	}
+	folders := []models.NoteFolder{
+		{ID: folderID, ProjectID: projectID, Name: "API", Path: "Docs/API"},
+	}
 
 	tests := []struct {
 		name        string
@@ -122,6 +132,7 @@ func TestNoteUsecase_GetNotesByProject(t *testing.T) {
 			setupMocks: func() {
 				projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
 				noteRepo.EXPECT().GetNotesByProject(gomock.Any(), projectID, userID).Return(notes, nil)
+				noteRepo.EXPECT().GetFoldersByProject(gomock.Any(), projectID, userID).Return(folders, nil)
 			},
 			expectedErr: nil,
 		},
@@ -147,7 +158,7 @@ func TestNoteUsecase_GetNotesByProject(t *testing.T) {
 			ctx = logctx.WithLogger(ctx, logctx.NewLogger())
 			tt.setupMocks()
 
-			uc := NewNoteUsecase(noteRepo, projectRepo)
+			uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, mocks.NewMockNoteAttachmentCleaner(ctrl), mocks.NewMockNoteMentionService(ctrl))
 			result, err := uc.GetNotesByProject(ctx, projectID)
 
 			if tt.expectedErr != nil {
@@ -155,7 +166,8 @@ func TestNoteUsecase_GetNotesByProject(t *testing.T) {
 				assert.Nil(t, result)
 			} else {
 				assert.NoError(t, err)
-				assert.NotNil(t, result)
+				assert.Len(t, result, 1)
+				assert.Equal(t, "Docs/API", result[0].Path)
 			}
 		})
 	}
@@ -167,6 +179,9 @@ func TestNoteUsecase_CreateNote(t *testing.T) {
 
 	noteRepo := mocks.NewMockINoteRepository(ctrl)
 	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
+	mentions := mocks.NewMockNoteMentionService(ctrl)
 
 	projectID := uuid.New()
 	userID := uuid.New()
@@ -187,10 +202,24 @@ func TestNoteUsecase_CreateNote(t *testing.T) {
 			},
 			setupMocks: func() {
 				projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
-				noteRepo.EXPECT().CreateNote(gomock.Any(), projectID, userID, "New Note", "Note Description").Return(noteID, nil)
+				mentions.EXPECT().CheckMentions(gomock.Any(), projectID, "Note Description").Return(nil)
+				noteRepo.EXPECT().CreateNote(gomock.Any(), projectID, userID, "New Note", "Note Description", models.FormatPlain, linkmodels.References{}, nil).Return(noteID, nil)
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "mention of non-member rejected",
+			req: dto.CreateOrUpdateNote{
+				Name:        "New Note",
+				Description: "cc @stranger",
+				ProjectID:   projectID,
+			},
+			setupMocks: func() {
+				projectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+				mentions.EXPECT().CheckMentions(gomock.Any(), projectID, "cc @stranger").Return(errs.ErrMentionNotMember)
+			},
+			expectedErr: errs.ErrMentionNotMember,
+		},
 		{
 			name: "no project access",
 			req: dto.CreateOrUpdateNote{
@@ -223,7 +252,7 @@ func TestNoteUsecase_CreateNote(t *testing.T) {
 			ctx = logctx.WithLogger(ctx, logctx.NewLogger())
 			tt.setupMocks()
 
-			uc := NewNoteUsecase(noteRepo, projectRepo)
+			uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, mocks.NewMockNoteAttachmentCleaner(ctrl), mentions)
 			result, err := uc.CreateNote(ctx, tt.req)
 
 			if tt.expectedErr != nil {
@@ -244,6 +273,9 @@ func TestNoteUsecase_UpdateNote(t *testing.T) {
 
 	noteRepo := mocks.NewMockINoteRepository(ctrl)
 	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
+	mentions := mocks.NewMockNoteMentionService(ctrl)
 
 	projectID := uuid.New()
 	noteID := uuid.New()
@@ -260,10 +292,16 @@ func TestNoteUsecase_UpdateNote(t *testing.T) {
 			req: dto.CreateOrUpdateNote{
 				Name:        "Updated Note",
 				Description: "Updated Description",
+				Format:      models.FormatMarkdown,
 				ProjectID:   projectID,
 			},
 			setupMocks: func() {
-				noteRepo.EXPECT().UpdateNote(gomock.Any(), userID, noteID, projectID, "Updated Note", "Updated Description").Return(nil)
+				mentions.EXPECT().CheckMentions(gomock.Any(), projectID, "Updated Description").Return(nil)
+				// Кэш сбрасывается только после сохранения заметки с упоминаниями
+				gomock.InOrder(
+					noteRepo.EXPECT().UpdateNote(gomock.Any(), userID, noteID, projectID, "Updated Note", "Updated Description", models.FormatMarkdown, nil, linkmodels.References{}, nil).Return(2, nil),
+					htmlCache.EXPECT().Invalidate(gomock.Any(), noteID).Return(nil),
+				)
 			},
 			expectedErr: nil,
 		},
@@ -287,7 +325,8 @@ func TestNoteUsecase_UpdateNote(t *testing.T) {
 				ProjectID:   projectID,
 			},
 			setupMocks: func() {
-				noteRepo.EXPECT().UpdateNote(gomock.Any(), userID, noteID, projectID, "Updated Note", "Updated Description").Return(errors.New("db error"))
+				mentions.EXPECT().CheckMentions(gomock.Any(), projectID, "Updated Description").Return(nil)
+				noteRepo.EXPECT().UpdateNote(gomock.Any(), userID, noteID, projectID, "Updated Note", "Updated Description", "", nil, gomock.Any(), gomock.Any()).Return(0, errors.New("db error"))
 			},
 			expectedErr: errors.New("db error"),
 		},
@@ -299,8 +338,8 @@ func TestNoteUsecase_UpdateNote(t *testing.T) {
 			ctx = logctx.WithLogger(ctx, logctx.NewLogger())
 			tt.setupMocks()
 
-			uc := NewNoteUsecase(noteRepo, projectRepo)
-			err := uc.UpdateNote(ctx, noteID, tt.req)
+			uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, mocks.NewMockNoteAttachmentCleaner(ctrl), mentions)
+			_, err := uc.UpdateNote(ctx, noteID, tt.req, nil)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -317,6 +356,9 @@ func TestNoteUsecase_DeleteNote(t *testing.T) {
 
 	noteRepo := mocks.NewMockINoteRepository(ctrl)
 	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
+	attachments := mocks.NewMockNoteAttachmentCleaner(ctrl)
 
 	noteID := uuid.New()
 	userID := uuid.New()
@@ -329,14 +371,16 @@ func TestNoteUsecase_DeleteNote(t *testing.T) {
 		{
 			name: "successful deletion",
 			setupMocks: func() {
-				noteRepo.EXPECT().DeleteNote(gomock.Any(), userID, noteID).Return(nil)
+				noteRepo.EXPECT().DeleteNote(gomock.Any(), userID, noteID, nil).Return(nil)
+				htmlCache.EXPECT().Invalidate(gomock.Any(), noteID).Return(errors.New("redis down"))
+				attachments.EXPECT().PurgeDeleted(gomock.Any()).Return(nil)
 			},
 			expectedErr: nil,
 		},
 		{
 			name: "repository error",
 			setupMocks: func() {
-				noteRepo.EXPECT().DeleteNote(gomock.Any(), userID, noteID).Return(errors.New("db error"))
+				noteRepo.EXPECT().DeleteNote(gomock.Any(), userID, noteID, nil).Return(errors.New("db error"))
 			},
 			expectedErr: errors.New("db error"),
 		},
@@ -348,8 +392,8 @@ func TestNoteUsecase_DeleteNote(t *testing.T) {
 			ctx = logctx.WithLogger(ctx, logctx.NewLogger())
 			tt.setupMocks()
 
-			uc := NewNoteUsecase(noteRepo, projectRepo)
-			err := uc.DeleteNote(ctx, noteID)
+			uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, attachments, mocks.NewMockNoteMentionService(ctrl))
+			err := uc.DeleteNote(ctx, noteID, nil)
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -366,10 +410,375 @@ func TestNew_NoteUsecase(t *testing.T) {
 
 	noteRepo := mocks.NewMockINoteRepository(ctrl)
 	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
 
-	uc := NewNoteUsecase(noteRepo, projectRepo)
+	uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, mocks.NewMockNoteAttachmentCleaner(ctrl), mocks.NewMockNoteMentionService(ctrl))
 
 	assert.NotNil(t, uc)
 	assert.Equal(t, noteRepo, uc.repo)
 	assert.Equal(t, projectRepo, uc.projectRepo)
 }
+
+func TestNoteUsecase_DiffNoteRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteRepo := mocks.NewMockINoteRepository(ctrl)
+	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
+
+	noteID := uuid.New()
+	oldRevision := &models.NoteRevision{NoteID: noteID, Revision: 1, Name: "Draft", Description: "a\nb\nc"}
+	newRevision := &models.NoteRevision{NoteID: noteID, Revision: 3, Name: "Final", Description: "a\nc\nd"}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(uuid.UUID)
+		expectedErr error
+	}{
+		{
+			name: "successful diff",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteRevision(gomock.Any(), noteID, 1, userID).Return(oldRevision, nil)
+				noteRepo.EXPECT().GetNoteRevision(gomock.Any(), noteID, 3, userID).Return(newRevision, nil)
+			},
+		},
+		{
+			name: "revision not found",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteRevision(gomock.Any(), noteID, 1, userID).Return(nil, errs.ErrNotFound)
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, userID := setupNoteTest()
+			tt.setupMocks(userID)
+
+			uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, mocks.NewMockNoteAttachmentCleaner(ctrl), mocks.NewMockNoteMentionService(ctrl))
+			result, err := uc.DiffNoteRevisions(ctx, noteID, 1, 3)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, result.NameChanged)
+			assert.Equal(t, "Draft", result.OldName)
+			assert.Equal(t, "Final", result.NewName)
+			assert.Equal(t, 1, result.Added)
+			assert.Equal(t, 1, result.Removed)
+			assert.Equal(t, []dto.DiffLineDTO{
+				{Op: models.DiffEqual, OldLine: 1, NewLine: 1, Text: "a"},
+				{Op: models.DiffDelete, OldLine: 2, Text: "b"},
+				{Op: models.DiffEqual, OldLine: 3, NewLine: 2, Text: "c"},
+				{Op: models.DiffInsert, NewLine: 3, Text: "d"},
+			}, result.Lines)
+		})
+	}
+}
+
+func TestNoteUsecase_RestoreNoteRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteRepo := mocks.NewMockINoteRepository(ctrl)
+	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
+	mentions := mocks.NewMockNoteMentionService(ctrl)
+
+	noteID := uuid.New()
+	restoredFrom := 2
+
+	tests := []struct {
+		name        string
+		setupMocks  func(uuid.UUID)
+		expectedErr error
+	}{
+		{
+			name: "successful restore",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteRevision(gomock.Any(), noteID, 2, userID).Return(&models.NoteRevision{
+					NoteID:      noteID,
+					Revision:    2,
+					Name:        "Note",
+					Description: "text, see [[Plan]], cc @anna",
+				}, nil)
+				noteRepo.EXPECT().RestoreNoteRevision(gomock.Any(), noteID, 2, userID, linkmodels.References{Titles: []string{"Plan"}}, []string{"anna"}).Return(&models.NoteRevision{
+					NoteID:       noteID,
+					Revision:     5,
+					Name:         "Note",
+					Description:  "text, see [[Plan]], cc @anna",
+					AuthorID:     &userID,
+					CreatedAt:    time.Now(),
+					RestoredFrom: &restoredFrom,
+				}, nil)
+				htmlCache.EXPECT().Invalidate(gomock.Any(), noteID).Return(nil)
+			},
+		},
+		{
+			name: "revision not found",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteRevision(gomock.Any(), noteID, 2, userID).Return(nil, errs.ErrNotFound)
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+		{
+			name: "repository error",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteRevision(gomock.Any(), noteID, 2, userID).Return(&models.NoteRevision{NoteID: noteID, Revision: 2}, nil)
+				noteRepo.EXPECT().RestoreNoteRevision(gomock.Any(), noteID, 2, userID, linkmodels.References{}, nil).Return(nil, errors.New("db error"))
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, userID := setupNoteTest()
+			tt.setupMocks(userID)
+
+			uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, mocks.NewMockNoteAttachmentCleaner(ctrl), mentions)
+			result, err := uc.RestoreNoteRevision(ctx, noteID, 2)
+
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, 5, result.Revision)
+			assert.Equal(t, &restoredFrom, result.RestoredFrom)
+		})
+	}
+}
+
+func TestNoteUsecase_GetNoteByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteRepo := mocks.NewMockINoteRepository(ctrl)
+	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
+
+	noteID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMocks  func(uuid.UUID)
+		expectedErr error
+	}{
+		{
+			name: "successful retrieval",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteByID(gomock.Any(), noteID, userID).Return(&models.Note{
+					ID:        noteID,
+					Name:      "Note",
+					CreatedAt: time.Now(),
+					Version:   3,
+				}, nil)
+			},
+		},
+		{
+			name: "note not found",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteByID(gomock.Any(), noteID, userID).Return(nil, errs.ErrNotFound)
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, userID := setupNoteTest()
+			tt.setupMocks(userID)
+
+			uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, mocks.NewMockNoteAttachmentCleaner(ctrl), mocks.NewMockNoteMentionService(ctrl))
+			result, err := uc.GetNoteByID(ctx, noteID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, 3, result.Version)
+		})
+	}
+}
+
+func TestNoteUsecase_RenderNote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteRepo := mocks.NewMockINoteRepository(ctrl)
+	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
+	mentions := mocks.NewMockNoteMentionService(ctrl)
+
+	noteID := uuid.New()
+	note := &models.Note{
+		ID:          noteID,
+		Name:        "Note",
+		Description: "# Title\n\n<script>alert(1)</script>",
+		Format:      models.FormatMarkdown,
+		CreatedAt:   time.Now(),
+		Version:     4,
+	}
+	annaID := uuid.New()
+
+	tests := []struct {
+		name         string
+		setupMocks   func(uuid.UUID)
+		expectedHTML string
+		expectedErr  error
+	}{
+		{
+			name: "rendered and cached",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteByID(gomock.Any(), noteID, userID).Return(note, nil)
+				mentions.EXPECT().GetSourceMentions(gomock.Any(), linkmodels.TypeNote, noteID).Return(nil, nil)
+				htmlCache.EXPECT().Get(gomock.Any(), noteID, renderStamp(4, nil)).Return("", false, nil)
+				htmlCache.EXPECT().Set(gomock.Any(), noteID, renderStamp(4, nil), "<h1>Title</h1>\n\n").Return(nil)
+			},
+			expectedHTML: "<h1>Title</h1>\n\n",
+		},
+		{
+			name: "mentions linked to users",
+			setupMocks: func(userID uuid.UUID) {
+				mentioned := &models.Note{ID: noteID, Description: "ask @anna", Format: models.FormatMarkdown, Version: 4}
+				noteRepo.EXPECT().GetNoteByID(gomock.Any(), noteID, userID).Return(mentioned, nil)
+				annaMentions := map[string]uuid.UUID{"anna": annaID}
+				mentions.EXPECT().GetSourceMentions(gomock.Any(), linkmodels.TypeNote, noteID).Return(annaMentions, nil)
+				// HTML, отрисованный до сохранения упоминаний, лежит под другой отметкой
+				htmlCache.EXPECT().Get(gomock.Any(), noteID, renderStamp(4, annaMentions)).Return("", false, nil)
+				htmlCache.EXPECT().Set(gomock.Any(), noteID, renderStamp(4, annaMentions), gomock.Any()).Return(nil)
+			},
+			expectedHTML: `<p>ask <a class="mention" data-user-id="` + annaID.String() + `" href="#user-` + annaID.String() + `">@anna</a></p>` + "\n",
+		},
+		{
+			name: "served from cache",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteByID(gomock.Any(), noteID, userID).Return(note, nil)
+				mentions.EXPECT().GetSourceMentions(gomock.Any(), linkmodels.TypeNote, noteID).Return(nil, nil)
+				htmlCache.EXPECT().Get(gomock.Any(), noteID, renderStamp(4, nil)).Return("<p>cached</p>", true, nil)
+			},
+			expectedHTML: "<p>cached</p>",
+		},
+		{
+			name: "cache unavailable",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteByID(gomock.Any(), noteID, userID).Return(note, nil)
+				mentions.EXPECT().GetSourceMentions(gomock.Any(), linkmodels.TypeNote, noteID).Return(nil, errors.New("db down"))
+				htmlCache.EXPECT().Get(gomock.Any(), noteID, gomock.Any()).Return("", false, errors.New("redis down"))
+				htmlCache.EXPECT().Set(gomock.Any(), noteID, gomock.Any(), gomock.Any()).Return(errors.New("redis down"))
+			},
+			expectedHTML: "<h1>Title</h1>\n\n",
+		},
+		{
+			name: "note not found",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteByID(gomock.Any(), noteID, userID).Return(nil, errs.ErrNotFound)
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, userID := setupNoteTest()
+			tt.setupMocks(userID)
+
+			uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, mocks.NewMockNoteAttachmentCleaner(ctrl), mentions)
+			result, err := uc.RenderNote(ctx, noteID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedHTML, result.HTML)
+			assert.Equal(t, models.FormatMarkdown, result.Format)
+			assert.Equal(t, 4, result.Version)
+		})
+	}
+}
+
+func TestNoteUsecase_GetBacklinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteRepo := mocks.NewMockINoteRepository(ctrl)
+	projectRepo := mocks.NewMockNoteProjectRepository(ctrl)
+	htmlCache := mocks.NewMockNoteHTMLCache(ctrl)
+	linkRepo := mocks.NewMockNoteLinkRepository(ctrl)
+
+	noteID := uuid.New()
+	taskID := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupMocks  func(uuid.UUID)
+		expectedLen int
+		expectedErr error
+	}{
+		{
+			name: "backlinks from tasks",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteByID(gomock.Any(), noteID, userID).Return(&models.Note{ID: noteID}, nil)
+				linkRepo.EXPECT().GetBacklinks(gomock.Any(), linkmodels.TypeNote, noteID, userID).Return([]linkmodels.Backlink{
+					{Type: linkmodels.TypeTask, ID: taskID, Title: "Fix login"},
+				}, nil)
+			},
+			expectedLen: 1,
+		},
+		{
+			name: "no backlinks",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteByID(gomock.Any(), noteID, userID).Return(&models.Note{ID: noteID}, nil)
+				linkRepo.EXPECT().GetBacklinks(gomock.Any(), linkmodels.TypeNote, noteID, userID).Return([]linkmodels.Backlink{}, nil)
+			},
+			expectedLen: 0,
+		},
+		{
+			name: "note not accessible",
+			setupMocks: func(userID uuid.UUID) {
+				noteRepo.EXPECT().GetNoteByID(gomock.Any(), noteID, userID).Return(nil, errs.ErrNotFound)
+			},
+			expectedErr: errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, userID := setupNoteTest()
+			tt.setupMocks(userID)
+
+			uc := NewNoteUsecase(noteRepo, projectRepo, htmlCache, linkRepo, mocks.NewMockNoteAttachmentCleaner(ctrl), mocks.NewMockNoteMentionService(ctrl))
+			result, err := uc.GetBacklinks(ctx, noteID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Len(t, result, tt.expectedLen)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	xhtml "golang.org/x/net/html"
+
+	models "github.com/lzimin05/course-todo/internal/models/note"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+// markdown - CommonMark с расширениями GFM: таблицы, списки задач,
+// зачеркивание и автоссылки. Сырой HTML в тексте заметки не выводится
+var markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// sanitizer пропускает только безопасную разметку. Поверх UGC-политики
+// разрешены выравнивание ячеек таблиц и отключенные чекбоксы списков задач
+var sanitizer = newSanitizer()
+
+func newSanitizer() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowStyles("text-align").MatchingEnum("left", "right", "center").OnElements("th", "td")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").Matching(regexp.MustCompile(`^$`)).OnElements("input")
+	return p
+}
+
+// renderNoteHTML превращает описание заметки в безопасный HTML.
+// Обычный текст экранируется: абзацы разделяются пустой строкой, переносы сохраняются.
+// Упоминания из mentions (логин -> ID пользователя) становятся ссылками
+func renderNoteHTML(format, source string, mentions map[string]uuid.UUID) (string, error) {
+	var buf bytes.Buffer
+	if format == models.FormatMarkdown {
+		if err := markdown.Convert([]byte(source), &buf); err != nil {
+			return "", err
+		}
+	} else {
+		renderPlain(&buf, source)
+	}
+
+	return linkMentions(sanitizer.Sanitize(buf.String()), mentions), nil
+}
+
+// renderStamp - отметка исходных данных отрисовки: версия заметки и хеш
+// упоминаний, отсортированных по логину
+func renderStamp(version int, mentions map[string]uuid.UUID) string {
+	logins := make([]string, 0, len(mentions))
+	for login := range mentions {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+
+	h := sha256.New()
+	for _, login := range logins {
+		fmt.Fprintf(h, "%s=%s;", login, mentions[login])
+	}
+	return fmt.Sprintf("%d:%x", version, h.Sum(nil)[:8])
+}
+
+// linkMentions оборачивает упоминания в тексте HTML в ссылки на пользователя.
+// Текст внутри ссылок и блоков кода не меняется. Ссылки добавляются после
+// санитайзера, поэтому атрибут data-user-id до клиента доходит
+func linkMentions(rendered string, mentions map[string]uuid.UUID) string {
+	if len(mentions) == 0 {
+		return rendered
+	}
+
+	var b strings.Builder
+	skipDepth := 0
+	z := xhtml.NewTokenizer(strings.NewReader(rendered))
+	for {
+		tt := z.Next()
+		if tt == xhtml.ErrorToken {
+			if z.Err() != io.EOF {
+				return rendered
+			}
+			return b.String()
+		}
+
+		raw := string(z.Raw())
+		switch tt {
+		case xhtml.StartTagToken, xhtml.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "a", "code", "pre":
+				if tt == xhtml.StartTagToken {
+					skipDepth++
+				} else if skipDepth > 0 {
+					skipDepth--
+				}
+			}
+		case xhtml.TextToken:
+			if skipDepth == 0 {
+				raw = helpers.ReplaceMentions(raw, func(login, mention string) (string, bool) {
+					userID, ok := mentions[login]
+					if !ok {
+						return "", false
+					}
+					return fmt.Sprintf(`<a class="mention" data-user-id="%s" href="#user-%s">%s</a>`, userID, userID, mention), true
+				})
+			}
+		}
+		b.WriteString(raw)
+	}
+}
+
+func renderPlain(buf *bytes.Buffer, source string) {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	for _, paragraph := range strings.Split(source, "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		buf.WriteString("<p>")
+		for i, line := range strings.Split(paragraph, "\n") {
+			if i > 0 {
+				buf.WriteString("<br>\n")
+			}
+			buf.WriteString(html.EscapeString(line))
+		}
+		buf.WriteString("</p>\n")
+	}
+}
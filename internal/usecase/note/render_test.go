@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	models "github.com/lzimin05/course-todo/internal/models/note"
+)
+
+func TestRenderNoteHTML_Markdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		contains []string
+	}{
+		{
+			name:     "heading and emphasis",
+			source:   "# Title\n\nSome **bold** and _italic_ text",
+			contains: []string{"<h1>Title</h1>", "<strong>bold</strong>", "<em>italic</em>"},
+		},
+		{
+			name:   "gfm table with alignment",
+			source: "| a | b |\n|:--|--:|\n| 1 | 2 |",
+			contains: []string{
+				"<table>",
+				`<th style="text-align: left">a</th>`,
+				`<td style="text-align: right">2</td>`,
+			},
+		},
+		{
+			name:   "gfm task list",
+			source: "- [x] done\n- [ ] todo",
+			contains: []string{
+				`<li><input checked="" disabled="" type="checkbox"> done</li>`,
+				`<li><input disabled="" type="checkbox"> todo</li>`,
+			},
+		},
+		{
+			name:     "strikethrough and autolink",
+			source:   "~~old~~ see https://example.com",
+			contains: []string{"<del>old</del>", `<a href="https://example.com" rel="nofollow">https://example.com</a>`},
+		},
+		{
+			name:     "fenced code is escaped",
+			source:   "```\n<b>not bold</b>\n```",
+			contains: []string{"<pre><code>&lt;b&gt;not bold&lt;/b&gt;\n</code></pre>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := renderNoteHTML(models.FormatMarkdown, tt.source, nil)
+			require.NoError(t, err)
+			for _, fragment := range tt.contains {
+				assert.Contains(t, html, fragment)
+			}
+		})
+	}
+}
+
+var tagPattern = regexp.MustCompile(`<([a-zA-Z]+)`)
+
+func TestRenderNoteHTML_XSS(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		forbidden []string
+	}{
+		{
+			name:      "script tag",
+			source:    "hello <script>alert(1)</script>",
+			forbidden: []string{"<script", "alert(1)</script>"},
+		},
+		{
+			name:      "script block",
+			source:    "<script>\nalert(1)\n</script>",
+			forbidden: []string{"<script"},
+		},
+		{
+			name:      "event handler attribute",
+			source:    `<img src="x" onerror="alert(1)">`,
+			forbidden: []string{"onerror", "<img"},
+		},
+		{
+			name:      "javascript link",
+			source:    "[click](javascript:alert(1))",
+			forbidden: []string{"javascript:"},
+		},
+		{
+			name:      "encoded javascript link",
+			source:    "[click](&#106;avascript:alert(1))",
+			forbidden: []string{"javascript:", "avascript:"},
+		},
+		{
+			name:      "vbscript link",
+			source:    "[click](vbscript:msgbox(1))",
+			forbidden: []string{"vbscript:"},
+		},
+		{
+			name:      "data uri image",
+			source:    "![x](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)",
+			forbidden: []string{"data:text/html"},
+		},
+		{
+			name:      "iframe",
+			source:    `<iframe src="https://evil.example"></iframe>`,
+			forbidden: []string{"<iframe"},
+		},
+		{
+			name:      "style injection in table cell",
+			source:    "| a |\n|---|\n| <td style=\"background:url(javascript:alert(1))\">x</td> |",
+			forbidden: []string{"background", "javascript:"},
+		},
+		{
+			name:      "svg with onload",
+			source:    `<svg onload="alert(1)"></svg>`,
+			forbidden: []string{"<svg", "onload"},
+		},
+		{
+			name:      "text input",
+			source:    `<input type="text" onfocus="alert(1)" autofocus>`,
+			forbidden: []string{"<input", "onfocus"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := renderNoteHTML(models.FormatMarkdown, tt.source, nil)
+			require.NoError(t, err)
+			lower := strings.ToLower(html)
+			for _, fragment := range tt.forbidden {
+				assert.NotContains(t, lower, fragment)
+			}
+
+			// В обычном тексте любая разметка экранируется целиком
+			plain, err := renderNoteHTML(models.FormatPlain, tt.source, nil)
+			require.NoError(t, err)
+			for _, tag := range tagPattern.FindAllStringSubmatch(plain, -1) {
+				assert.Contains(t, []string{"p", "br"}, tag[1])
+			}
+		})
+	}
+}
+
+func TestRenderNoteHTML_Plain(t *testing.T) {
+	html, err := renderNoteHTML(models.FormatPlain, "# not a heading\nsecond line\n\n<b>& more</b>", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "<p># not a heading<br>\nsecond line</p>\n<p>&lt;b&gt;&amp; more&lt;/b&gt;</p>\n", html)
+}
+
+func TestRenderNoteHTML_Mentions(t *testing.T) {
+	annaID := uuid.New()
+	mentions := map[string]uuid.UUID{"anna": annaID}
+	link := `<a class="mention" data-user-id="` + annaID.String() + `" href="#user-` + annaID.String() + `">@Anna</a>`
+
+	html, err := renderNoteHTML(models.FormatMarkdown, "cc @Anna and @bob, mail anna@example.com\n\n`@anna` [@anna](https://example.com)", mentions)
+	require.NoError(t, err)
+
+	assert.Contains(t, html, "<p>cc "+link+" and @bob, mail <a href=\"mailto:anna@example.com\"")
+	assert.Contains(t, html, "<code>@anna</code>")
+	assert.Contains(t, html, `>@anna</a>`)
+	assert.Equal(t, 1, strings.Count(html, `class="mention"`))
+
+	plain, err := renderNoteHTML(models.FormatPlain, "<b>@Anna</b>", mentions)
+	require.NoError(t, err)
+	assert.Equal(t, "<p>&lt;b&gt;"+link+"&lt;/b&gt;</p>\n", plain)
+}
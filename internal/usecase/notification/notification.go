@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	models "github.com/lzimin05/course-todo/internal/models/notification"
+	outboxmodels "github.com/lzimin05/course-todo/internal/models/outbox"
+	userModels "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/notification"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+//go:generate mockgen -source=notification.go -destination=../mocks/notification_mocks.go -package=mocks NotificationRepository
+type NotificationRepository interface {
+	CreateNotification(ctx context.Context, n *models.Notification) (bool, error)
+	GetTaskAuthor(ctx context.Context, taskID uuid.UUID) (uuid.UUID, error)
+	GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]models.Notification, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, notificationID, userID uuid.UUID, readAt time.Time) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID, readAt time.Time) (int64, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) (map[string]bool, error)
+	SetPreferences(ctx context.Context, userID uuid.UUID, preferences map[string]bool) error
+	DeleteRead(ctx context.Context, before time.Time) (int64, error)
+}
+
+type NotificationUsecase struct {
+	repo NotificationRepository
+	cfg  *config.NotificationConfig
+}
+
+func New(repo NotificationRepository, cfg *config.NotificationConfig) *NotificationUsecase {
+	return &NotificationUsecase{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// event - событие outbox, Data разбирается в зависимости от типа
+type event struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	ProjectID  uuid.UUID       `json:"project_id"`
+	ActorID    uuid.UUID       `json:"actor_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// HandleMessage - обработчик шины событий. Создает уведомления: участнику -
+// о добавлении в проект, автору задачи - о ее завершении, исполнителю - о
+// назначении пункта чек-листа. О собственных действиях пользователь не уведомляется,
+// служебный пользователь удаленных аккаунтов не уведомляется совсем
+func (uc *NotificationUsecase) HandleMessage(ctx context.Context, msg outboxmodels.Message) error {
+	const op = "NotificationUsecase.HandleMessage"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("eventID", msg.ID)
+
+	var e event
+	if err := json.Unmarshal(msg.Payload, &e); err != nil {
+		// Повтор не поможет, событие пропускается
+		logger.WithError(err).Warn("failed to decode event")
+		return nil
+	}
+
+	var recipientID uuid.UUID
+	var notificationType string
+	switch msg.Type {
+	case eventmodels.TypeMemberAdded:
+		var data eventmodels.MemberData
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			logger.WithError(err).Warn("failed to decode member data")
+			return nil
+		}
+		recipientID, notificationType = data.UserID, models.TypeMemberAdded
+	case eventmodels.TypeTaskCompleted:
+		var data eventmodels.TaskData
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			logger.WithError(err).Warn("failed to decode task data")
+			return nil
+		}
+		authorID, err := uc.repo.GetTaskAuthor(ctx, data.ID)
+		if errors.Is(err, errs.ErrTaskNotFound) {
+			return nil
+		}
+		if err != nil {
+			logger.WithError(err).Error("failed to get task author")
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		recipientID, notificationType = authorID, models.TypeTaskCompleted
+	case eventmodels.TypeChecklistAssigned:
+		var data eventmodels.ChecklistData
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			logger.WithError(err).Warn("failed to decode checklist data")
+			return nil
+		}
+		recipientID, notificationType = data.AssigneeID, models.TypeChecklistAssigned
+	case eventmodels.TypeMentionCreated:
+		var data eventmodels.MentionData
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			logger.WithError(err).Warn("failed to decode mention data")
+			return nil
+		}
+		recipientID, notificationType = data.UserID, models.TypeMentioned
+	default:
+		return nil
+	}
+
+	// Служебный пользователь удаленных аккаунтов уведомления не читает
+	if recipientID == uuid.Nil || recipientID == e.ActorID || recipientID == userModels.DeletedUserID {
+		return nil
+	}
+
+	n := &models.Notification{
+		ID:        uuid.New(),
+		UserID:    recipientID,
+		Type:      notificationType,
+		ProjectID: msg.ProjectID,
+		EventID:   msg.ID,
+		Data:      e.Data,
+		CreatedAt: e.OccurredAt,
+	}
+	if e.ActorID != uuid.Nil {
+		actorID := e.ActorID
+		n.ActorID = &actorID
+	}
+
+	if _, err := uc.repo.CreateNotification(ctx, n); err != nil {
+		logger.WithError(err).Error("failed to create notification")
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetNotifications возвращает страницу уведомлений текущего пользователя и число непрочитанных
+func (uc *NotificationUsecase) GetNotifications(ctx context.Context, unreadOnly bool, limit, offset int) (*dto.NotificationListDTO, error) {
+	const op = "NotificationUsecase.GetNotifications"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return nil, err
+	}
+
+	notifications, err := uc.repo.GetNotifications(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("failed to get notifications")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	unread, err := uc.repo.CountUnread(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to count unread notifications")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	items := make([]dto.NotificationDTO, 0, len(notifications))
+	for _, n := range notifications {
+		items = append(items, toNotificationDTO(n))
+	}
+
+	return &dto.NotificationListDTO{Items: items, UnreadCount: unread}, nil
+}
+
+func (uc *NotificationUsecase) GetUnreadCount(ctx context.Context) (*dto.UnreadCountDTO, error) {
+	const op = "NotificationUsecase.GetUnreadCount"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return nil, err
+	}
+
+	unread, err := uc.repo.CountUnread(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to count unread notifications")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &dto.UnreadCountDTO{UnreadCount: unread}, nil
+}
+
+// MarkRead отмечает уведомление прочитанным и возвращает оставшееся число непрочитанных
+func (uc *NotificationUsecase) MarkRead(ctx context.Context, notificationID uuid.UUID) (*dto.UnreadCountDTO, error) {
+	const op = "NotificationUsecase.MarkRead"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("notificationID", notificationID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return nil, err
+	}
+
+	if err := uc.repo.MarkRead(ctx, notificationID, userID, time.Now().UTC()); err != nil {
+		logger.WithError(err).Warn("failed to mark notification read")
+		return nil, err
+	}
+
+	return uc.GetUnreadCount(ctx)
+}
+
+func (uc *NotificationUsecase) MarkAllRead(ctx context.Context) (*dto.UnreadCountDTO, error) {
+	const op = "NotificationUsecase.MarkAllRead"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return nil, err
+	}
+
+	if _, err := uc.repo.MarkAllRead(ctx, userID, time.Now().UTC()); err != nil {
+		logger.WithError(err).Error("failed to mark notifications read")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &dto.UnreadCountDTO{UnreadCount: 0}, nil
+}
+
+// GetPreferences возвращает настройки по всем типам уведомлений,
+// не заданные явно типы включены
+func (uc *NotificationUsecase) GetPreferences(ctx context.Context) ([]dto.PreferenceDTO, error) {
+	const op = "NotificationUsecase.GetPreferences"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return nil, err
+	}
+
+	preferences, err := uc.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get notification preferences")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result := make([]dto.PreferenceDTO, 0, len(models.Types))
+	for _, notificationType := range models.Types {
+		enabled, ok := preferences[notificationType]
+		result = append(result, dto.PreferenceDTO{Type: notificationType, Enabled: !ok || enabled})
+	}
+
+	return result, nil
+}
+
+func (uc *NotificationUsecase) UpdatePreferences(ctx context.Context, req dto.UpdatePreferencesDTO) ([]dto.PreferenceDTO, error) {
+	const op = "NotificationUsecase.UpdatePreferences"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user ID from context")
+		return nil, err
+	}
+
+	if err := uc.repo.SetPreferences(ctx, userID, req); err != nil {
+		logger.WithError(err).Error("failed to set notification preferences")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return uc.GetPreferences(ctx)
+}
+
+// Run раз в CleanupInterval удаляет прочитанные уведомления старше Retention.
+// Завершается при отмене контекста
+func (uc *NotificationUsecase) Run(ctx context.Context) {
+	logger := logctx.GetLogger(ctx).WithField("op", "NotificationUsecase.Run")
+
+	ticker := time.NewTicker(uc.cfg.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := uc.Cleanup(ctx); err != nil {
+			logger.WithError(err).Error("failed to clean up notifications")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Cleanup удаляет прочитанные уведомления старше Retention и возвращает их число
+func (uc *NotificationUsecase) Cleanup(ctx context.Context) (int64, error) {
+	const op = "NotificationUsecase.Cleanup"
+
+	count, err := uc.repo.DeleteRead(ctx, time.Now().UTC().Add(-uc.cfg.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return count, nil
+}
+
+func toNotificationDTO(n models.Notification) dto.NotificationDTO {
+	data := json.RawMessage(n.Data)
+	if len(data) == 0 {
+		data = json.RawMessage(`{}`)
+	}
+	return dto.NotificationDTO{
+		ID:          n.ID,
+		Type:        n.Type,
+		ProjectID:   n.ProjectID,
+		ProjectName: n.ProjectName,
+		ActorID:     n.ActorID,
+		Data:        data,
+		CreatedAt:   n.CreatedAt,
+		ReadAt:      n.ReadAt,
+	}
+}
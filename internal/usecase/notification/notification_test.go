@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	models "github.com/lzimin05/course-todo/internal/models/notification"
+	outboxmodels "github.com/lzimin05/course-todo/internal/models/outbox"
+	userModels "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/notification"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newTestConfig() *config.NotificationConfig {
+	return &config.NotificationConfig{
+		Retention:       24 * time.Hour,
+		CleanupInterval: time.Hour,
+	}
+}
+
+func message(eventType string, projectID, actorID uuid.UUID, data any) outboxmodels.Message {
+	event := eventmodels.New(eventType, projectID, actorID, data)
+	payload, _ := json.Marshal(event)
+	return outboxmodels.Message{ID: event.ID, Type: eventType, ProjectID: projectID, Payload: payload}
+}
+
+func TestNotificationUsecase_HandleMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNotificationRepository(ctrl)
+	uc := New(mockRepo, newTestConfig())
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	actorID := uuid.New()
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	t.Run("member added", func(t *testing.T) {
+		msg := message(eventmodels.TypeMemberAdded, projectID, actorID,
+			eventmodels.MemberData{ProjectID: projectID, UserID: userID})
+
+		mockRepo.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, n *models.Notification) (bool, error) {
+				assert.Equal(t, userID, n.UserID)
+				assert.Equal(t, models.TypeMemberAdded, n.Type)
+				assert.Equal(t, projectID, n.ProjectID)
+				assert.Equal(t, msg.ID, n.EventID)
+				assert.Equal(t, actorID, *n.ActorID)
+				assert.JSONEq(t, `{"project_id":"`+projectID.String()+`","user_id":"`+userID.String()+`"}`, string(n.Data))
+				return true, nil
+			})
+
+		assert.NoError(t, uc.HandleMessage(ctx, msg))
+	})
+
+	t.Run("task completed notifies author", func(t *testing.T) {
+		msg := message(eventmodels.TypeTaskCompleted, projectID, actorID,
+			eventmodels.TaskData{ID: taskID, ProjectID: projectID, Title: "Задача", Status: "completed"})
+
+		mockRepo.EXPECT().GetTaskAuthor(gomock.Any(), taskID).Return(userID, nil)
+		mockRepo.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, n *models.Notification) (bool, error) {
+				assert.Equal(t, userID, n.UserID)
+				assert.Equal(t, models.TypeTaskCompleted, n.Type)
+				return true, nil
+			})
+
+		assert.NoError(t, uc.HandleMessage(ctx, msg))
+	})
+
+	t.Run("author completed own task", func(t *testing.T) {
+		msg := message(eventmodels.TypeTaskCompleted, projectID, userID,
+			eventmodels.TaskData{ID: taskID, ProjectID: projectID})
+
+		mockRepo.EXPECT().GetTaskAuthor(gomock.Any(), taskID).Return(userID, nil)
+
+		assert.NoError(t, uc.HandleMessage(ctx, msg))
+	})
+
+	t.Run("task of deleted account", func(t *testing.T) {
+		msg := message(eventmodels.TypeTaskCompleted, projectID, actorID,
+			eventmodels.TaskData{ID: taskID, ProjectID: projectID})
+
+		mockRepo.EXPECT().GetTaskAuthor(gomock.Any(), taskID).Return(userModels.DeletedUserID, nil)
+
+		assert.NoError(t, uc.HandleMessage(ctx, msg))
+	})
+
+	t.Run("task already deleted", func(t *testing.T) {
+		msg := message(eventmodels.TypeTaskCompleted, projectID, actorID,
+			eventmodels.TaskData{ID: taskID, ProjectID: projectID})
+
+		mockRepo.EXPECT().GetTaskAuthor(gomock.Any(), taskID).Return(uuid.Nil, errs.ErrTaskNotFound)
+
+		assert.NoError(t, uc.HandleMessage(ctx, msg))
+	})
+
+	t.Run("checklist assigned", func(t *testing.T) {
+		msg := message(eventmodels.TypeChecklistAssigned, projectID, actorID,
+			eventmodels.ChecklistData{ID: uuid.New(), TaskID: taskID, ProjectID: projectID, AssigneeID: userID})
+
+		mockRepo.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, n *models.Notification) (bool, error) {
+				assert.Equal(t, userID, n.UserID)
+				assert.Equal(t, models.TypeChecklistAssigned, n.Type)
+				return false, nil
+			})
+
+		assert.NoError(t, uc.HandleMessage(ctx, msg))
+	})
+
+	t.Run("mention notifies mentioned user", func(t *testing.T) {
+		msg := message(eventmodels.TypeMentionCreated, projectID, actorID,
+			eventmodels.MentionData{SourceType: "note", SourceID: uuid.New(), ProjectID: projectID, Title: "Plan", UserID: userID})
+
+		mockRepo.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, n *models.Notification) (bool, error) {
+				assert.Equal(t, userID, n.UserID)
+				assert.Equal(t, models.TypeMentioned, n.Type)
+				return true, nil
+			})
+
+		assert.NoError(t, uc.HandleMessage(ctx, msg))
+	})
+
+	t.Run("repository error is retried", func(t *testing.T) {
+		msg := message(eventmodels.TypeMemberAdded, projectID, actorID,
+			eventmodels.MemberData{ProjectID: projectID, UserID: userID})
+
+		mockRepo.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Return(false, errors.New("db error"))
+
+		assert.Error(t, uc.HandleMessage(ctx, msg))
+	})
+
+	t.Run("other events are ignored", func(t *testing.T) {
+		msg := message(eventmodels.TypeNoteUpdated, projectID, actorID, eventmodels.NoteData{})
+
+		assert.NoError(t, uc.HandleMessage(ctx, msg))
+	})
+}
+
+func TestNotificationUsecase_GetNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNotificationRepository(ctrl)
+	uc := New(mockRepo, newTestConfig())
+
+	userID := uuid.New()
+	ctx := context.WithValue(logctx.WithLogger(context.Background(), logctx.NewLogger()), domains.UserIDKey{}, userID.String())
+
+	notificationID := uuid.New()
+	mockRepo.EXPECT().GetNotifications(gomock.Any(), userID, true, 20, 0).
+		Return([]models.Notification{{ID: notificationID, Type: models.TypeMemberAdded, ProjectName: "Проект"}}, nil)
+	mockRepo.EXPECT().CountUnread(gomock.Any(), userID).Return(3, nil)
+
+	result, err := uc.GetNotifications(ctx, true, 20, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.UnreadCount)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, notificationID, result.Items[0].ID)
+	assert.JSONEq(t, `{}`, string(result.Items[0].Data))
+}
+
+func TestNotificationUsecase_MarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNotificationRepository(ctrl)
+	uc := New(mockRepo, newTestConfig())
+
+	userID := uuid.New()
+	notificationID := uuid.New()
+	ctx := context.WithValue(logctx.WithLogger(context.Background(), logctx.NewLogger()), domains.UserIDKey{}, userID.String())
+
+	t.Run("marked", func(t *testing.T) {
+		mockRepo.EXPECT().MarkRead(gomock.Any(), notificationID, userID, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().CountUnread(gomock.Any(), userID).Return(1, nil)
+
+		result, err := uc.MarkRead(ctx, notificationID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.UnreadCount)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo.EXPECT().MarkRead(gomock.Any(), notificationID, userID, gomock.Any()).
+			Return(errs.NewNotFoundError("notification not found"))
+
+		_, err := uc.MarkRead(ctx, notificationID)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+	})
+}
+
+func TestNotificationUsecase_Preferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockNotificationRepository(ctrl)
+	uc := New(mockRepo, newTestConfig())
+
+	userID := uuid.New()
+	ctx := context.WithValue(logctx.WithLogger(context.Background(), logctx.NewLogger()), domains.UserIDKey{}, userID.String())
+
+	req := dto.UpdatePreferencesDTO{models.TypeTaskCompleted: false}
+	mockRepo.EXPECT().SetPreferences(gomock.Any(), userID, map[string]bool(req)).Return(nil)
+	mockRepo.EXPECT().GetPreferences(gomock.Any(), userID).
+		Return(map[string]bool{models.TypeTaskCompleted: false}, nil)
+
+	preferences, err := uc.UpdatePreferences(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Len(t, preferences, len(models.Types))
+	for _, p := range preferences {
+		assert.Equal(t, p.Type != models.TypeTaskCompleted, p.Enabled, p.Type)
+	}
+}
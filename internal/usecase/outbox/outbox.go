@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/outbox"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const (
+	cleanupInterval = time.Hour
+	maxErrorLength  = 500
+)
+
+//go:generate mockgen -source=outbox.go -destination=../mocks/outbox_mocks.go -package=mocks OutboxRepository,OutboxSink,OutboxObserver
+type OutboxRepository interface {
+	ClaimMessages(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Message, error)
+	MarkPublished(ctx context.Context, messageID uuid.UUID, publishedAt time.Time) (int64, error)
+	MarkFailed(ctx context.Context, messageID uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string) error
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// OutboxSink - получатель событий: шина внутри процесса или поток Redis Streams.
+// Событие может прийти повторно с тем же ID
+type OutboxSink interface {
+	Name() string
+	Publish(ctx context.Context, msg models.Message) error
+}
+
+// OutboxObserver получает событие после отметки о публикации, уже с номером
+// публикации. Ошибка наблюдателя не возвращает событие в очередь: пропущенное
+// он догоняет по таблице outbox
+type OutboxObserver interface {
+	HandleMessage(ctx context.Context, msg models.Message) error
+}
+
+// OutboxRelay переносит события из таблицы outbox во все получатели.
+// Событие отмечается опубликованным, только когда его приняли все получатели,
+// иначе публикуется снова целиком: доставка не реже одного раза
+type OutboxRelay struct {
+	repo      OutboxRepository
+	sinks     []OutboxSink
+	observers []OutboxObserver
+	cfg       *config.OutboxConfig
+}
+
+func New(repo OutboxRepository, sinks []OutboxSink, observers []OutboxObserver, cfg *config.OutboxConfig) *OutboxRelay {
+	return &OutboxRelay{
+		repo:      repo,
+		sinks:     sinks,
+		observers: observers,
+		cfg:       cfg,
+	}
+}
+
+// Run раз в PollInterval публикует созревшие события, раз в час удаляет
+// опубликованные старше Retention. Завершается при отмене контекста
+func (r *OutboxRelay) Run(ctx context.Context) {
+	logger := logctx.GetLogger(ctx).WithField("op", "OutboxRelay.Run")
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		// Полная пачка - в таблице, вероятно, есть еще, забираем без паузы
+		for {
+			count, err := r.RelayDue(ctx)
+			if err != nil {
+				logger.WithError(err).Error("failed to relay outbox messages")
+				break
+			}
+			if count < r.cfg.BatchSize {
+				break
+			}
+		}
+
+		if time.Since(lastCleanup) >= cleanupInterval {
+			if _, err := r.Cleanup(ctx); err != nil {
+				logger.WithError(err).Error("failed to clean up outbox")
+			}
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayDue публикует одну пачку созревших событий в порядке записи
+// и возвращает ее размер
+func (r *OutboxRelay) RelayDue(ctx context.Context) (int, error) {
+	const op = "OutboxRelay.RelayDue"
+
+	now := time.Now().UTC()
+	leaseUntil := now.Add(r.cfg.Lease)
+	messages, err := r.repo.ClaimMessages(ctx, now, leaseUntil, r.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, msg := range messages {
+		// После истечения аренды события может забрать другой экземпляр,
+		// оставшиеся вернутся в работу сами
+		if time.Now().UTC().After(leaseUntil) {
+			break
+		}
+		r.relay(ctx, msg)
+	}
+
+	return len(messages), nil
+}
+
+// Cleanup удаляет опубликованные события старше Retention
+func (r *OutboxRelay) Cleanup(ctx context.Context) (int64, error) {
+	const op = "OutboxRelay.Cleanup"
+
+	count, err := r.repo.DeletePublished(ctx, time.Now().UTC().Add(-r.cfg.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return count, nil
+}
+
+func (r *OutboxRelay) relay(ctx context.Context, msg models.Message) {
+	const op = "OutboxRelay.relay"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("messageID", msg.ID).
+		WithField("eventType", msg.Type)
+
+	var publishErr error
+	for _, sink := range r.sinks {
+		if err := r.publish(ctx, sink, msg); err != nil {
+			publishErr = fmt.Errorf("%s: %w", sink.Name(), err)
+			break
+		}
+	}
+
+	now := time.Now().UTC()
+	if publishErr == nil {
+		publishedSeq, err := r.repo.MarkPublished(ctx, msg.ID, now)
+		if err != nil {
+			logger.WithError(err).Error("failed to mark message published")
+			return
+		}
+
+		msg.PublishedSeq = publishedSeq
+		for _, observer := range r.observers {
+			if err := observer.HandleMessage(ctx, msg); err != nil {
+				logger.WithError(err).Warn("outbox observer failed")
+			}
+		}
+		return
+	}
+
+	attempts := msg.Attempts + 1
+	logger.WithField("attempts", attempts).WithError(publishErr).Warn("failed to publish outbox message")
+	err := r.repo.MarkFailed(ctx, msg.ID, attempts, now.Add(r.backoff(attempts)), helpers.Truncate(publishErr.Error(), maxErrorLength))
+	if err != nil {
+		logger.WithError(err).Error("failed to mark message failed")
+	}
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, sink OutboxSink, msg models.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.PublishTimeout)
+	defer cancel()
+
+	return sink.Publish(ctx, msg)
+}
+
+// backoff - пауза после attempts неудачных попыток: RetryBase * 2^(attempts-1),
+// но не больше RetryMax
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	delay := r.cfg.RetryBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= r.cfg.RetryMax {
+			return r.cfg.RetryMax
+		}
+	}
+	if delay > r.cfg.RetryMax {
+		return r.cfg.RetryMax
+	}
+	return delay
+}
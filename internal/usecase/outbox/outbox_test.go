@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	models "github.com/lzimin05/course-todo/internal/models/outbox"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newTestConfig() *config.OutboxConfig {
+	return &config.OutboxConfig{
+		PollInterval:   time.Second,
+		BatchSize:      10,
+		Lease:          time.Minute,
+		PublishTimeout: time.Second,
+		RetryBase:      time.Second,
+		RetryMax:       time.Minute,
+		Retention:      24 * time.Hour,
+	}
+}
+
+func TestOutboxRelay_Backoff(t *testing.T) {
+	relay := New(nil, nil, nil, newTestConfig())
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 4*time.Second, relay.backoff(3))
+	assert.Equal(t, time.Minute, relay.backoff(7))
+	assert.Equal(t, time.Minute, relay.backoff(100))
+}
+
+func TestOutboxRelay_RelayDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOutboxRepository(ctrl)
+	mockBus := mocks.NewMockOutboxSink(ctrl)
+	mockStream := mocks.NewMockOutboxSink(ctrl)
+	mockBus.EXPECT().Name().Return("bus").AnyTimes()
+	mockStream.EXPECT().Name().Return("redis").AnyTimes()
+	mockLive := mocks.NewMockOutboxObserver(ctrl)
+
+	relay := New(mockRepo, []OutboxSink{mockBus, mockStream}, []OutboxObserver{mockLive}, newTestConfig())
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	t.Run("published to all sinks in order", func(t *testing.T) {
+		first := models.Message{ID: uuid.New(), Seq: 1, Type: "task.created"}
+		second := models.Message{ID: uuid.New(), Seq: 2, Type: "task.completed"}
+
+		// Наблюдатель получает номер публикации, а не номер записи
+		firstPublished, secondPublished := first, second
+		firstPublished.PublishedSeq, secondPublished.PublishedSeq = 8, 7
+
+		mockRepo.EXPECT().ClaimMessages(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+			Return([]models.Message{first, second}, nil)
+		gomock.InOrder(
+			mockBus.EXPECT().Publish(gomock.Any(), first).Return(nil),
+			mockStream.EXPECT().Publish(gomock.Any(), first).Return(nil),
+			mockRepo.EXPECT().MarkPublished(gomock.Any(), first.ID, gomock.Any()).Return(int64(8), nil),
+			mockLive.EXPECT().HandleMessage(gomock.Any(), firstPublished).Return(nil),
+			mockBus.EXPECT().Publish(gomock.Any(), second).Return(nil),
+			mockStream.EXPECT().Publish(gomock.Any(), second).Return(nil),
+			mockRepo.EXPECT().MarkPublished(gomock.Any(), second.ID, gomock.Any()).Return(int64(7), nil),
+			mockLive.EXPECT().HandleMessage(gomock.Any(), secondPublished).Return(errors.New("redis error")),
+		)
+
+		count, err := relay.RelayDue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("sink failure postpones message", func(t *testing.T) {
+		msg := models.Message{ID: uuid.New(), Seq: 3, Type: "note.updated", Attempts: 2}
+
+		mockRepo.EXPECT().ClaimMessages(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+			Return([]models.Message{msg}, nil)
+		mockBus.EXPECT().Publish(gomock.Any(), msg).Return(nil)
+		mockStream.EXPECT().Publish(gomock.Any(), msg).Return(errors.New("connection refused"))
+		mockRepo.EXPECT().MarkFailed(gomock.Any(), msg.ID, 3, gomock.Any(), "redis: connection refused").
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, _ int, next time.Time, _ string) error {
+				assert.WithinDuration(t, time.Now().Add(4*time.Second), next, time.Second)
+				return nil
+			})
+
+		count, err := relay.RelayDue(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("first sink failure skips the rest", func(t *testing.T) {
+		msg := models.Message{ID: uuid.New(), Seq: 4, Type: "member.added"}
+
+		mockRepo.EXPECT().ClaimMessages(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+			Return([]models.Message{msg}, nil)
+		mockBus.EXPECT().Publish(gomock.Any(), msg).Return(errors.New("webhooks: db error"))
+		mockRepo.EXPECT().MarkFailed(gomock.Any(), msg.ID, 1, gomock.Any(), "bus: webhooks: db error").Return(nil)
+
+		_, err := relay.RelayDue(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("claim error", func(t *testing.T) {
+		mockRepo.EXPECT().ClaimMessages(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+			Return(nil, errors.New("db error"))
+
+		count, err := relay.RelayDue(ctx)
+
+		assert.Error(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func TestOutboxRelay_Cleanup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockOutboxRepository(ctrl)
+	relay := New(mockRepo, nil, nil, newTestConfig())
+
+	mockRepo.EXPECT().DeletePublished(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Second)
+			return 5, nil
+		})
+
+	count, err := relay.Cleanup(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+}
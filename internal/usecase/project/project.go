@@ -2,10 +2,12 @@ package usecase
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/lzimin05/course-todo/internal/models/errs"
 	models "github.com/lzimin05/course-todo/internal/models/project"
+	userModels "github.com/lzimin05/course-todo/internal/models/user"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/project"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
 	"github.com/lzimin05/course-todo/internal/usecase/helpers"
@@ -15,20 +17,27 @@ type ProjectRepository interface {
 	CreateProject(ctx context.Context, project *models.Project) error
 	GetProjectByID(ctx context.Context, id uuid.UUID) (*models.Project, error)
 	GetUserProjects(ctx context.Context, userID uuid.UUID) ([]*models.Project, error)
-	AddProjectMember(ctx context.Context, projectID, userID uuid.UUID) error
+	AddProjectMember(ctx context.Context, projectID, userID, actorID uuid.UUID) error
 	GetProjectMembers(ctx context.Context, projectID uuid.UUID) ([]*models.ProjectMember, error)
 	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
-	DeleteProject(ctx context.Context, projectID, ownerID uuid.UUID) error
-	RemoveProjectMember(ctx context.Context, projectID, userID uuid.UUID) error
-	UpdateProject(ctx context.Context, projectID uuid.UUID, name, description string, ownerID uuid.UUID) error
+	DeleteProject(ctx context.Context, projectID, ownerID uuid.UUID, expectedVersions []int) error
+	RemoveProjectMember(ctx context.Context, projectID, userID, actorID uuid.UUID) error
+	UpdateProject(ctx context.Context, projectID uuid.UUID, name, description string, ownerID uuid.UUID, expectedVersions []int) error
+	GetProjectStats(ctx context.Context, projectID uuid.UUID) (*models.ProjectStats, error)
+}
+
+// ProjectAttachmentCleaner удаляет из хранилища файлы вложений удаленных проектов
+type ProjectAttachmentCleaner interface {
+	PurgeDeleted(ctx context.Context) error
 }
 
 type ProjectUsecase struct {
-	repo ProjectRepository
+	repo        ProjectRepository
+	attachments ProjectAttachmentCleaner
 }
 
-func New(repo ProjectRepository) *ProjectUsecase {
-	return &ProjectUsecase{repo: repo}
+func New(repo ProjectRepository, attachments ProjectAttachmentCleaner) *ProjectUsecase {
+	return &ProjectUsecase{repo: repo, attachments: attachments}
 }
 
 func (uc *ProjectUsecase) CreateProject(ctx context.Context, req *dto.PostProjectDTO) (*dto.ProjectDTO, error) {
@@ -60,6 +69,7 @@ func (uc *ProjectUsecase) CreateProject(ctx context.Context, req *dto.PostProjec
 		Description: newProject.Description,
 		OwnerID:     newProject.OwnerID,
 		CreatedAt:   newProject.CreatedAt,
+		Version:     newProject.Version,
 	}, nil
 }
 
@@ -87,6 +97,7 @@ func (uc *ProjectUsecase) GetUserProjects(ctx context.Context) ([]*dto.ProjectDT
 			Description: project.Description,
 			OwnerID:     project.OwnerID,
 			CreatedAt:   project.CreatedAt,
+			Version:     project.Version,
 		}
 	}
 
@@ -127,6 +138,7 @@ func (uc *ProjectUsecase) GetProjectByID(ctx context.Context, projectID uuid.UUI
 		Description: project.Description,
 		OwnerID:     project.OwnerID,
 		CreatedAt:   project.CreatedAt,
+		Version:     project.Version,
 	}, nil
 }
 
@@ -158,7 +170,13 @@ func (uc *ProjectUsecase) AddProjectMember(ctx context.Context, projectID uuid.U
 		return errs.ErrCannotAddSelf
 	}
 
-	err = uc.repo.AddProjectMember(ctx, projectID, req.UserID)
+	// Служебный пользователь удаленных аккаунтов не может участвовать в проектах
+	if req.UserID == userModels.DeletedUserID {
+		logger.Warn("attempt to add deleted user placeholder to project")
+		return errs.NewNotFoundError("user not found")
+	}
+
+	err = uc.repo.AddProjectMember(ctx, projectID, req.UserID, userID)
 	if err != nil {
 		logger.WithError(err).Error("failed to add project member")
 		return err
@@ -211,7 +229,7 @@ func (uc *ProjectUsecase) GetProjectMembers(ctx context.Context, projectID uuid.
 	return memberDTOs, nil
 }
 
-func (uc *ProjectUsecase) DeleteProject(ctx context.Context, projectID uuid.UUID) error {
+func (uc *ProjectUsecase) DeleteProject(ctx context.Context, projectID uuid.UUID, expectedVersions []int) error {
 	const op = "ProjectUseCase.DeleteProject"
 	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
 
@@ -221,12 +239,16 @@ func (uc *ProjectUsecase) DeleteProject(ctx context.Context, projectID uuid.UUID
 		return err
 	}
 
-	err = uc.repo.DeleteProject(ctx, projectID, userID)
+	err = uc.repo.DeleteProject(ctx, projectID, userID, expectedVersions)
 	if err != nil {
 		logger.WithError(err).Error("failed to delete project")
 		return err
 	}
 
+	if err := uc.attachments.PurgeDeleted(ctx); err != nil {
+		logger.WithError(err).Warn("failed to purge attachment files")
+	}
+
 	return nil
 }
 
@@ -252,7 +274,7 @@ func (uc *ProjectUsecase) RemoveProjectMember(ctx context.Context, projectID, me
 		return errs.ErrNotOwner
 	}
 
-	err = uc.repo.RemoveProjectMember(ctx, projectID, memberUserID)
+	err = uc.repo.RemoveProjectMember(ctx, projectID, memberUserID, userID)
 	if err != nil {
 		logger.WithError(err).Error("failed to remove project member")
 		return err
@@ -261,7 +283,7 @@ func (uc *ProjectUsecase) RemoveProjectMember(ctx context.Context, projectID, me
 	return nil
 }
 
-func (uc *ProjectUsecase) UpdateProject(ctx context.Context, projectID uuid.UUID, req *dto.UpdateProjectDTO) (*dto.ProjectDTO, error) {
+func (uc *ProjectUsecase) UpdateProject(ctx context.Context, projectID uuid.UUID, req *dto.UpdateProjectDTO, expectedVersions []int) (*dto.ProjectDTO, error) {
 	const op = "ProjectUseCase.UpdateProject"
 	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
 
@@ -283,7 +305,7 @@ func (uc *ProjectUsecase) UpdateProject(ctx context.Context, projectID uuid.UUID
 		return nil, errs.ErrNotOwner
 	}
 
-	err = uc.repo.UpdateProject(ctx, projectID, req.Name, req.Description, userID)
+	err = uc.repo.UpdateProject(ctx, projectID, req.Name, req.Description, userID, expectedVersions)
 	if err != nil {
 		logger.WithError(err).Error("failed to update project")
 		return nil, err
@@ -301,6 +323,7 @@ func (uc *ProjectUsecase) UpdateProject(ctx context.Context, projectID uuid.UUID
 		Description: updatedProject.Description,
 		OwnerID:     updatedProject.OwnerID,
 		CreatedAt:   updatedProject.CreatedAt,
+		Version:     updatedProject.Version,
 	}, nil
 }
 
@@ -338,7 +361,7 @@ func (uc *ProjectUsecase) LeaveProject(ctx context.Context, projectID uuid.UUID)
 		return errs.ErrNoAccess
 	}
 
-	err = uc.repo.RemoveProjectMember(ctx, projectID, userID)
+	err = uc.repo.RemoveProjectMember(ctx, projectID, userID, userID)
 	if err != nil {
 		logger.WithError(err).Error("failed to leave project")
 		return err
@@ -346,3 +369,69 @@ func (uc *ProjectUsecase) LeaveProject(ctx context.Context, projectID uuid.UUID)
 
 	return nil
 }
+
+func (uc *ProjectUsecase) GetProjectStats(ctx context.Context, projectID uuid.UUID) (*dto.ProjectStatsDTO, error) {
+	const op = "ProjectUseCase.GetProjectStats"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	// Проверяем доступ к проекту
+	hasAccess, err := uc.repo.CheckProjectAccess(ctx, projectID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to check project access")
+		return nil, err
+	}
+
+	if !hasAccess {
+		logger.Warn("user doesn't have access to project")
+		return nil, errs.ErrNoAccess
+	}
+
+	stats, err := uc.repo.GetProjectStats(ctx, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get project stats")
+		return nil, err
+	}
+
+	statsDTO := &dto.ProjectStatsDTO{
+		ProjectID:           stats.ProjectID,
+		ByStatus:            stats.ByStatus,
+		ByImportance:        make(map[string]int, len(stats.ByImportance)),
+		Overdue:             stats.Overdue,
+		CompletedPerDay:     toDateCountDTOs(stats.CompletedPerDay),
+		CompletedPerWeek:    toDateCountDTOs(stats.CompletedPerWeek),
+		AvgCycleTimeSeconds: stats.AvgCycleTimeSeconds,
+		Members:             make([]dto.MemberStatsDTO, len(stats.Members)),
+	}
+
+	for importance, count := range stats.ByImportance {
+		statsDTO.ByImportance[strconv.Itoa(importance)] = count
+	}
+
+	for i, member := range stats.Members {
+		statsDTO.Members[i] = dto.MemberStatsDTO{
+			UserID:    member.UserID,
+			Username:  member.Username,
+			Open:      member.Open,
+			Completed: member.Completed,
+		}
+	}
+
+	return statsDTO, nil
+}
+
+func toDateCountDTOs(counts []models.DateCount) []dto.DateCountDTO {
+	result := make([]dto.DateCountDTO, len(counts))
+	for i, c := range counts {
+		result[i] = dto.DateCountDTO{
+			Date:  c.Date.Format("2006-01-02"),
+			Count: c.Count,
+		}
+	}
+	return result
+}
@@ -0,0 +1,337 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/config"
+	mailmodels "github.com/lzimin05/course-todo/internal/models/mail"
+	notificationmodels "github.com/lzimin05/course-todo/internal/models/notification"
+	models "github.com/lzimin05/course-todo/internal/models/reminder"
+	userModels "github.com/lzimin05/course-todo/internal/models/user"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+)
+
+const (
+	jobReminders = "reminders"
+	jobOverdue   = "overdue"
+	jobDigest    = "digest"
+
+	// digestWindow - сводка перечисляет просроченные задачи и задачи со сроком в ближайшие сутки
+	digestWindow = 24 * time.Hour
+	batchSize    = 100
+)
+
+// Пространство имен для ID событий напоминаний: один и тот же срок задачи дает
+// один и тот же ID, и повторный проход не создает дублей уведомлений
+var reminderNamespace = uuid.MustParse("4f1c9a52-3e0b-4d61-9a57-0c2be2a8f6d3")
+
+//go:generate mockgen -source=reminder.go -destination=../mocks/reminder_mocks.go -package=mocks ReminderRepository,ReminderNotifier,ReminderLocker,Mailer
+type ReminderRepository interface {
+	GetUpcomingTasks(ctx context.Context, from, until time.Time) ([]models.Task, error)
+	GetOverdueTasks(ctx context.Context, now time.Time, limit int) ([]models.Task, error)
+	MarkOverdue(ctx context.Context, taskIDs []uuid.UUID, now time.Time) error
+	GetDigestRecipients(ctx context.Context, now time.Time, hour int, until time.Time, limit int) ([]models.Recipient, error)
+	GetDigestTasks(ctx context.Context, userID uuid.UUID, until time.Time) ([]models.Task, error)
+	MarkDigestSent(ctx context.Context, userID uuid.UUID, localDate time.Time) error
+}
+
+// ReminderNotifier сохраняет уведомление с учетом настроек получателя
+type ReminderNotifier interface {
+	CreateNotification(ctx context.Context, n *notificationmodels.Notification) (bool, error)
+}
+
+// ReminderLocker - распределенная блокировка: задание выполняет только тот
+// экземпляр сервиса, который ее захватил
+type ReminderLocker interface {
+	TryLock(ctx context.Context, name string, ttl time.Duration) (func(context.Context) error, bool, error)
+}
+
+// Mailer отправляет письма. Локально его заменяет заглушка, пишущая письма в лог
+type Mailer interface {
+	Send(ctx context.Context, mail mailmodels.Mail) error
+}
+
+// ReminderScheduler - фоновые задания по срокам задач: напоминания о
+// приближающемся сроке, отметка просроченных задач и ежедневная сводка на почту.
+// Напоминания и просрочки получает автор задачи
+type ReminderScheduler struct {
+	repo     ReminderRepository
+	notifier ReminderNotifier
+	locker   ReminderLocker
+	mailer   Mailer
+	cfg      *config.ReminderConfig
+	offsets  []time.Duration
+}
+
+func New(repo ReminderRepository, notifier ReminderNotifier, locker ReminderLocker, mailer Mailer, cfg *config.ReminderConfig) *ReminderScheduler {
+	offsets := append([]time.Duration(nil), cfg.Offsets...)
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	return &ReminderScheduler{
+		repo:     repo,
+		notifier: notifier,
+		locker:   locker,
+		mailer:   mailer,
+		cfg:      cfg,
+		offsets:  offsets,
+	}
+}
+
+// reminderData - данные уведомлений о сроке задачи
+type reminderData struct {
+	TaskID        uuid.UUID `json:"task_id"`
+	ProjectID     uuid.UUID `json:"project_id"`
+	Title         string    `json:"title"`
+	Deadline      time.Time `json:"deadline"`
+	AllDay        bool      `json:"all_day"`
+	OffsetMinutes int       `json:"offset_minutes,omitempty"`
+}
+
+// Run раз в PollInterval выполняет все задания. Завершается при отмене контекста
+func (s *ReminderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.runJob(ctx, jobReminders, s.SendReminders)
+		s.runJob(ctx, jobOverdue, s.FlagOverdue)
+		s.runJob(ctx, jobDigest, s.SendDigests)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runJob выполняет задание под блокировкой. Если блокировку держит другой
+// экземпляр, задание пропускается до следующего тика
+func (s *ReminderScheduler) runJob(ctx context.Context, name string, job func(context.Context, time.Time) (int, error)) {
+	logger := logctx.GetLogger(ctx).WithField("op", "ReminderScheduler.runJob").WithField("job", name)
+
+	release, ok, err := s.locker.TryLock(ctx, name, s.cfg.LockTTL)
+	if err != nil {
+		logger.WithError(err).Error("failed to acquire job lock")
+		return
+	}
+	if !ok {
+		return
+	}
+	defer func() {
+		if err := release(ctx); err != nil {
+			logger.WithError(err).Warn("failed to release job lock")
+		}
+	}()
+
+	if _, err := job(ctx, time.Now().UTC()); err != nil {
+		logger.WithError(err).Error("scheduled job failed")
+	}
+}
+
+// SendReminders создает уведомления о задачах, до срока которых осталось не
+// больше одного из Offsets. Для задачи выбирается наименьший подходящий
+// интервал, поэтому за каждый интервал приходит не больше одного напоминания
+func (s *ReminderScheduler) SendReminders(ctx context.Context, now time.Time) (int, error) {
+	const op = "ReminderScheduler.SendReminders"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	if len(s.offsets) == 0 {
+		return 0, nil
+	}
+
+	tasks, err := s.repo.GetUpcomingTasks(ctx, now, now.Add(s.offsets[len(s.offsets)-1]))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created := 0
+	for _, t := range tasks {
+		offset, ok := s.offsetFor(t.Deadline.Sub(now))
+		if !ok {
+			continue
+		}
+
+		key := fmt.Sprintf("reminder|%s|%d|%s", t.ID, t.Deadline.Unix(), offset)
+		data := reminderData{
+			TaskID:        t.ID,
+			ProjectID:     t.ProjectID,
+			Title:         t.Title,
+			Deadline:      t.Deadline,
+			AllDay:        t.AllDay,
+			OffsetMinutes: int(offset / time.Minute),
+		}
+		inserted, err := s.notify(ctx, t, notificationmodels.TypeDeadlineApproaching, key, data, now)
+		if err != nil {
+			logger.WithError(err).WithField("taskID", t.ID).Error("failed to create deadline reminder")
+			continue
+		}
+		if inserted {
+			created++
+		}
+	}
+
+	return created, nil
+}
+
+// FlagOverdue отмечает просроченные задачи и уведомляет их авторов. Задача
+// отмечается только после создания уведомления, при сбое она попадет в следующий проход
+func (s *ReminderScheduler) FlagOverdue(ctx context.Context, now time.Time) (int, error) {
+	const op = "ReminderScheduler.FlagOverdue"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	tasks, err := s.repo.GetOverdueTasks(ctx, now, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	flagged := make([]uuid.UUID, 0, len(tasks))
+	for _, t := range tasks {
+		key := fmt.Sprintf("overdue|%s|%d", t.ID, t.Deadline.Unix())
+		data := reminderData{
+			TaskID:    t.ID,
+			ProjectID: t.ProjectID,
+			Title:     t.Title,
+			Deadline:  t.Deadline,
+			AllDay:    t.AllDay,
+		}
+		if _, err := s.notify(ctx, t, notificationmodels.TypeTaskOverdue, key, data, now); err != nil {
+			logger.WithError(err).WithField("taskID", t.ID).Error("failed to create overdue notification")
+			continue
+		}
+		flagged = append(flagged, t.ID)
+	}
+
+	if len(flagged) == 0 {
+		return 0, nil
+	}
+	if err := s.repo.MarkOverdue(ctx, flagged, now); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return len(flagged), nil
+}
+
+// SendDigests отправляет ежедневные сводки пользователям, у которых наступил
+// DigestHour. Сводка отмечается отправленной после успешной отправки письма
+func (s *ReminderScheduler) SendDigests(ctx context.Context, now time.Time) (int, error) {
+	const op = "ReminderScheduler.SendDigests"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	until := now.Add(digestWindow)
+	recipients, err := s.repo.GetDigestRecipients(ctx, now, s.cfg.DigestHour, until, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sent := 0
+	for _, rc := range recipients {
+		userLogger := logger.WithField("userID", rc.UserID)
+
+		tasks, err := s.repo.GetDigestTasks(ctx, rc.UserID, until)
+		if err != nil {
+			userLogger.WithError(err).Error("failed to get digest tasks")
+			continue
+		}
+
+		if len(tasks) > 0 {
+			if err := s.mailer.Send(ctx, buildDigest(rc, tasks, now)); err != nil {
+				userLogger.WithError(err).Error("failed to send digest")
+				continue
+			}
+			sent++
+		}
+
+		if err := s.repo.MarkDigestSent(ctx, rc.UserID, rc.LocalDate); err != nil {
+			userLogger.WithError(err).Error("failed to mark digest sent")
+		}
+	}
+
+	return sent, nil
+}
+
+// offsetFor возвращает наименьший интервал напоминания, не меньший оставшегося времени
+func (s *ReminderScheduler) offsetFor(remaining time.Duration) (time.Duration, bool) {
+	for _, offset := range s.offsets {
+		if remaining <= offset {
+			return offset, true
+		}
+	}
+	return 0, false
+}
+
+// notify создает уведомление автору задачи. Задачи удаленных аккаунтов принадлежат
+// служебному пользователю, и уведомлять по ним некого
+func (s *ReminderScheduler) notify(ctx context.Context, t models.Task, notificationType, key string, data reminderData, now time.Time) (bool, error) {
+	if t.AuthorID == userModels.DeletedUserID {
+		return false, nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return false, err
+	}
+
+	return s.notifier.CreateNotification(ctx, &notificationmodels.Notification{
+		ID:        uuid.New(),
+		UserID:    t.AuthorID,
+		Type:      notificationType,
+		ProjectID: t.ProjectID,
+		EventID:   uuid.NewSHA1(reminderNamespace, []byte(key)),
+		Data:      payload,
+		CreatedAt: now,
+	})
+}
+
+// buildDigest собирает письмо со сводкой: сначала просроченные задачи, затем
+// задачи со сроком в ближайшие сутки. Время показывается в часовом поясе пользователя
+func buildDigest(rc models.Recipient, tasks []models.Task, now time.Time) mailmodels.Mail {
+	loc, err := time.LoadLocation(rc.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	var overdue, due []models.Task
+	for _, t := range tasks {
+		if t.Deadline.After(now) {
+			due = append(due, t)
+		} else {
+			overdue = append(overdue, t)
+		}
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hello, %s!\n", rc.Username)
+	writeSection := func(title string, tasks []models.Task) {
+		if len(tasks) == 0 {
+			return
+		}
+		fmt.Fprintf(&body, "\n%s:\n", title)
+		for _, t := range tasks {
+			fmt.Fprintf(&body, "- %s [%s], due %s\n", t.Title, t.ProjectName, formatDeadline(t, loc))
+		}
+	}
+	writeSection("Overdue", overdue)
+	writeSection("Due in the next 24 hours", due)
+
+	return mailmodels.Mail{
+		To:      rc.Email,
+		Subject: fmt.Sprintf("Daily digest for %s: %d due, %d overdue", rc.LocalDate.Format("2006-01-02"), len(due), len(overdue)),
+		Body:    body.String(),
+	}
+}
+
+// formatDeadline показывает у задачи на весь день только дату: ее срок хранится
+// как конец дня по UTC
+func formatDeadline(t models.Task, loc *time.Location) string {
+	if t.AllDay {
+		return t.Deadline.UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	}
+	return t.Deadline.In(loc).Format("2006-01-02 15:04 MST")
+}
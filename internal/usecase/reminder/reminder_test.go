@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	mailmodels "github.com/lzimin05/course-todo/internal/models/mail"
+	notificationmodels "github.com/lzimin05/course-todo/internal/models/notification"
+	models "github.com/lzimin05/course-todo/internal/models/reminder"
+	userModels "github.com/lzimin05/course-todo/internal/models/user"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newTestConfig() *config.ReminderConfig {
+	return &config.ReminderConfig{
+		Offsets:      []time.Duration{time.Hour, 24 * time.Hour},
+		PollInterval: time.Minute,
+		LockTTL:      time.Minute,
+		DigestHour:   8,
+	}
+}
+
+type testDeps struct {
+	repo     *mocks.MockReminderRepository
+	notifier *mocks.MockReminderNotifier
+	locker   *mocks.MockReminderLocker
+	mailer   *mocks.MockMailer
+}
+
+func newTestScheduler(t *testing.T) (*ReminderScheduler, testDeps) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	deps := testDeps{
+		repo:     mocks.NewMockReminderRepository(ctrl),
+		notifier: mocks.NewMockReminderNotifier(ctrl),
+		locker:   mocks.NewMockReminderLocker(ctrl),
+		mailer:   mocks.NewMockMailer(ctrl),
+	}
+	return New(deps.repo, deps.notifier, deps.locker, deps.mailer, newTestConfig()), deps
+}
+
+func TestReminderScheduler_SendReminders(t *testing.T) {
+	uc, deps := newTestScheduler(t)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	soon := models.Task{ID: uuid.New(), ProjectID: uuid.New(), AuthorID: uuid.New(), Title: "Отчет", Deadline: now.Add(30 * time.Minute)}
+	tomorrow := models.Task{ID: uuid.New(), ProjectID: uuid.New(), AuthorID: uuid.New(), Title: "Релиз", Deadline: now.Add(20 * time.Hour)}
+
+	deps.repo.EXPECT().GetUpcomingTasks(gomock.Any(), now, now.Add(24*time.Hour)).
+		Return([]models.Task{soon, tomorrow}, nil)
+
+	var eventIDs []uuid.UUID
+	deps.notifier.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(ctx context.Context, n *notificationmodels.Notification) (bool, error) {
+			assert.Equal(t, notificationmodels.TypeDeadlineApproaching, n.Type)
+			assert.Nil(t, n.ActorID)
+			eventIDs = append(eventIDs, n.EventID)
+			switch n.UserID {
+			case soon.AuthorID:
+				assert.Contains(t, string(n.Data), `"offset_minutes":60`)
+			case tomorrow.AuthorID:
+				assert.Contains(t, string(n.Data), `"offset_minutes":1440`)
+			default:
+				t.Errorf("unexpected recipient %s", n.UserID)
+			}
+			return true, nil
+		})
+
+	created, err := uc.SendReminders(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, created)
+
+	// Повторный проход дает те же ID событий, дубли отсекает уникальность уведомлений
+	deps.repo.EXPECT().GetUpcomingTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Task{soon}, nil)
+	deps.notifier.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, n *notificationmodels.Notification) (bool, error) {
+			assert.Equal(t, eventIDs[0], n.EventID)
+			return false, nil
+		})
+
+	created, err = uc.SendReminders(ctx, now.Add(time.Minute))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, created)
+}
+
+func TestReminderScheduler_FlagOverdue(t *testing.T) {
+	uc, deps := newTestScheduler(t)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	first := models.Task{ID: uuid.New(), ProjectID: uuid.New(), AuthorID: uuid.New(), Deadline: now.Add(-time.Hour)}
+	second := models.Task{ID: uuid.New(), ProjectID: uuid.New(), AuthorID: uuid.New(), Deadline: now.Add(-time.Minute)}
+	// Задача удаленного аккаунта отмечается без уведомления
+	orphan := models.Task{ID: uuid.New(), ProjectID: uuid.New(), AuthorID: userModels.DeletedUserID, Deadline: now.Add(-time.Hour)}
+
+	deps.repo.EXPECT().GetOverdueTasks(gomock.Any(), now, batchSize).Return([]models.Task{first, second, orphan}, nil)
+	deps.notifier.EXPECT().CreateNotification(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, n *notificationmodels.Notification) (bool, error) {
+			assert.Equal(t, notificationmodels.TypeTaskOverdue, n.Type)
+			if n.UserID == second.AuthorID {
+				return false, errors.New("db error")
+			}
+			return true, nil
+		}).Times(2)
+	// Задача без уведомления не отмечается и будет обработана повторно
+	deps.repo.EXPECT().MarkOverdue(gomock.Any(), []uuid.UUID{first.ID, orphan.ID}, now).Return(nil)
+
+	flagged, err := uc.FlagOverdue(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, flagged)
+}
+
+func TestReminderScheduler_SendDigests(t *testing.T) {
+	uc, deps := newTestScheduler(t)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	now := time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC)
+	localDate := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	withTasks := models.Recipient{UserID: uuid.New(), Email: "anna@example.com", Username: "anna", Timezone: "Europe/Moscow", LocalDate: localDate}
+	failing := models.Recipient{UserID: uuid.New(), Email: "ivan@example.com", Username: "ivan", Timezone: "UTC", LocalDate: localDate}
+	noTasks := models.Recipient{UserID: uuid.New(), Email: "olga@example.com", Username: "olga", Timezone: "UTC", LocalDate: localDate}
+
+	deps.repo.EXPECT().GetDigestRecipients(gomock.Any(), now, 8, now.Add(digestWindow), batchSize).
+		Return([]models.Recipient{withTasks, failing, noTasks}, nil)
+
+	deps.repo.EXPECT().GetDigestTasks(gomock.Any(), withTasks.UserID, now.Add(digestWindow)).Return([]models.Task{
+		{Title: "Отчет", ProjectName: "Работа", Deadline: now.Add(-2 * time.Hour)},
+		{Title: "Релиз", ProjectName: "Работа", Deadline: now.Add(4 * time.Hour)},
+	}, nil)
+	deps.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, mail mailmodels.Mail) error {
+			assert.Equal(t, "anna@example.com", mail.To)
+			assert.Equal(t, "Daily digest for 2026-03-02: 1 due, 1 overdue", mail.Subject)
+			assert.Contains(t, mail.Body, "Overdue:\n- Отчет [Работа], due 2026-03-02 07:00 MSK")
+			assert.Contains(t, mail.Body, "Due in the next 24 hours:\n- Релиз [Работа], due 2026-03-02 13:00 MSK")
+			return nil
+		})
+	deps.repo.EXPECT().MarkDigestSent(gomock.Any(), withTasks.UserID, localDate).Return(nil)
+
+	// Письмо не отправлено - сводка не отмечается и уйдет на следующем проходе
+	deps.repo.EXPECT().GetDigestTasks(gomock.Any(), failing.UserID, gomock.Any()).
+		Return([]models.Task{{Title: "Звонок", Deadline: now.Add(time.Hour)}}, nil)
+	deps.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("smtp error"))
+
+	deps.repo.EXPECT().GetDigestTasks(gomock.Any(), noTasks.UserID, gomock.Any()).Return(nil, nil)
+	deps.repo.EXPECT().MarkDigestSent(gomock.Any(), noTasks.UserID, localDate).Return(nil)
+
+	sent, err := uc.SendDigests(ctx, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+}
+
+func TestReminderScheduler_RunJob(t *testing.T) {
+	uc, deps := newTestScheduler(t)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	t.Run("lock held by another instance", func(t *testing.T) {
+		deps.locker.EXPECT().TryLock(gomock.Any(), jobDigest, time.Minute).Return(nil, false, nil)
+
+		uc.runJob(ctx, jobDigest, func(context.Context, time.Time) (int, error) {
+			t.Error("job must not run without lock")
+			return 0, nil
+		})
+	})
+
+	t.Run("lock acquired and released", func(t *testing.T) {
+		released := false
+		deps.locker.EXPECT().TryLock(gomock.Any(), jobOverdue, time.Minute).
+			Return(func(context.Context) error { released = true; return nil }, true, nil)
+
+		ran := false
+		uc.runJob(ctx, jobOverdue, func(context.Context, time.Time) (int, error) {
+			ran = true
+			return 0, errors.New("db error")
+		})
+
+		assert.True(t, ran)
+		assert.True(t, released)
+	})
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/report"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/report"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const dateLayout = "2006-01-02"
+
+//go:generate mockgen -source=report.go -destination=../mocks/report_mocks.go -package=mocks ReportRepository,ReportProjectRepository
+type ReportRepository interface {
+	GetCumulativeFlow(ctx context.Context, projectID uuid.UUID, from, to time.Time, bucket string) ([]models.CFDPoint, error)
+}
+
+type ReportProjectRepository interface {
+	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
+}
+
+type ReportUsecase struct {
+	repo        ReportRepository
+	projectRepo ReportProjectRepository
+}
+
+func New(repo ReportRepository, projectRepo ReportProjectRepository) *ReportUsecase {
+	return &ReportUsecase{
+		repo:        repo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (uc *ReportUsecase) GetCumulativeFlow(ctx context.Context, projectID uuid.UUID, from, to time.Time, bucket string) (*dto.CFDDTO, error) {
+	const op = "ReportUsecase.GetCumulativeFlow"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	points, err := uc.getPoints(ctx, projectID, from, to, bucket)
+	if err != nil {
+		logger.WithError(err).Error("failed to get cumulative flow")
+		return nil, err
+	}
+
+	result := &dto.CFDDTO{
+		ProjectID: projectID,
+		From:      from.Format(dateLayout),
+		To:        to.Format(dateLayout),
+		Bucket:    bucket,
+		Points:    make([]dto.CFDPointDTO, len(points)),
+	}
+	for i, p := range points {
+		result.Points[i] = dto.CFDPointDTO{
+			Date:       p.Bucket.Format(dateLayout),
+			Waiting:    p.Waiting,
+			InProgress: p.InProgress,
+			Completed:  p.Completed,
+		}
+	}
+
+	return result, nil
+}
+
+func (uc *ReportUsecase) GetBurndown(ctx context.Context, projectID uuid.UUID, from, to time.Time, bucket string) (*dto.BurndownDTO, error) {
+	const op = "ReportUsecase.GetBurndown"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	points, err := uc.getPoints(ctx, projectID, from, to, bucket)
+	if err != nil {
+		logger.WithError(err).Error("failed to get burndown")
+		return nil, err
+	}
+
+	result := &dto.BurndownDTO{
+		ProjectID: projectID,
+		From:      from.Format(dateLayout),
+		To:        to.Format(dateLayout),
+		Bucket:    bucket,
+		Points:    make([]dto.BurndownPointDTO, len(points)),
+	}
+	if len(points) == 0 {
+		return result, nil
+	}
+
+	// Идеальная линия равномерно снижает число открытых задач с начала периода до нуля
+	start := float64(points[0].Waiting + points[0].InProgress)
+	steps := float64(len(points) - 1)
+	for i, p := range points {
+		ideal := 0.0
+		if steps > 0 {
+			ideal = start - start*float64(i)/steps
+		}
+		result.Points[i] = dto.BurndownPointDTO{
+			Date:  p.Bucket.Format(dateLayout),
+			Open:  p.Waiting + p.InProgress,
+			Ideal: ideal,
+		}
+	}
+
+	return result, nil
+}
+
+func (uc *ReportUsecase) getPoints(ctx context.Context, projectID uuid.UUID, from, to time.Time, bucket string) ([]models.CFDPoint, error) {
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Проверяем права доступа к проекту
+	hasAccess, err := uc.projectRepo.CheckProjectAccess(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasAccess {
+		return nil, errs.ErrNoAccess
+	}
+
+	return uc.repo.GetCumulativeFlow(ctx, projectID, from, to, bucket)
+}
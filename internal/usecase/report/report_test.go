@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/report"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestReportUsecase_GetCumulativeFlow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReportRepository(ctrl)
+	mockProjectRepo := mocks.NewMockReportProjectRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	tests := []struct {
+		name          string
+		setupMocks    func()
+		expectedError error
+	}{
+		{
+			name: "successful cumulative flow",
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().
+					CheckProjectAccess(gomock.Any(), projectID, userID).
+					Return(true, nil)
+				mockRepo.EXPECT().
+					GetCumulativeFlow(gomock.Any(), projectID, from, to, models.BucketDay).
+					Return([]models.CFDPoint{{Bucket: from, Waiting: 2, InProgress: 1, Completed: 0}}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "no project access",
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().
+					CheckProjectAccess(gomock.Any(), projectID, userID).
+					Return(false, nil)
+			},
+			expectedError: errs.ErrNoAccess,
+		},
+		{
+			name: "repository error",
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().
+					CheckProjectAccess(gomock.Any(), projectID, userID).
+					Return(true, nil)
+				mockRepo.EXPECT().
+					GetCumulativeFlow(gomock.Any(), projectID, from, to, models.BucketDay).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			result, err := uc.GetCumulativeFlow(ctx, projectID, from, to, models.BucketDay)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Points, 1)
+				assert.Equal(t, "2025-01-01", result.Points[0].Date)
+				assert.Equal(t, 2, result.Points[0].Waiting)
+			}
+		})
+	}
+}
+
+func TestReportUsecase_GetBurndown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockReportRepository(ctrl)
+	mockProjectRepo := mocks.NewMockReportProjectRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	mockProjectRepo.EXPECT().
+		CheckProjectAccess(gomock.Any(), projectID, userID).
+		Return(true, nil)
+	mockRepo.EXPECT().
+		GetCumulativeFlow(gomock.Any(), projectID, from, to, models.BucketDay).
+		Return([]models.CFDPoint{
+			{Bucket: from, Waiting: 3, InProgress: 1},
+			{Bucket: from.AddDate(0, 0, 1), Waiting: 1, InProgress: 1, Completed: 2},
+			{Bucket: to, Completed: 4},
+		}, nil)
+
+	result, err := uc.GetBurndown(ctx, projectID, from, to, models.BucketDay)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Points, 3)
+	assert.Equal(t, 4, result.Points[0].Open)
+	assert.Equal(t, 2, result.Points[1].Open)
+	assert.Equal(t, 0, result.Points[2].Open)
+	assert.Equal(t, 4.0, result.Points[0].Ideal)
+	assert.Equal(t, 2.0, result.Points[1].Ideal)
+	assert.Equal(t, 0.0, result.Points[2].Ideal)
+}
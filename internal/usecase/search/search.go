@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/search"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/search"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+//go:generate mockgen -source=search.go -destination=../mocks/search_mocks.go -package=mocks SearchRepository
+type SearchRepository interface {
+	Search(ctx context.Context, userID uuid.UUID, query string, limit, offset int) ([]models.SearchResult, error)
+}
+
+type SearchUsecase struct {
+	repo SearchRepository
+}
+
+func New(repo SearchRepository) *SearchUsecase {
+	return &SearchUsecase{repo: repo}
+}
+
+func (uc *SearchUsecase) Search(ctx context.Context, query string, limit, offset int) (*dto.SearchResponseDTO, error) {
+	const op = "SearchUsecase.Search"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	results, err := uc.repo.Search(ctx, userID, query, limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("failed to search")
+		return nil, err
+	}
+
+	resultDTOs := make([]dto.SearchResultDTO, len(results))
+	for i, res := range results {
+		resultDTOs[i] = dto.SearchResultDTO{
+			Type:      res.Type,
+			ID:        res.ID,
+			ProjectID: res.ProjectID,
+			Title:     highlight(res.Title),
+			Snippet:   highlight(res.Snippet),
+			Rank:      res.Rank,
+		}
+	}
+
+	return &dto.SearchResponseDTO{
+		Query:   query,
+		Results: resultDTOs,
+	}, nil
+}
+
+var highlightReplacer = strings.NewReplacer(
+	models.HighlightStart, "<mark>",
+	models.HighlightStop, "</mark>",
+)
+
+// highlight экранирует пользовательский текст и заменяет маркеры совпадений на теги <mark>
+func highlight(s string) string {
+	return highlightReplacer.Replace(html.EscapeString(s))
+}
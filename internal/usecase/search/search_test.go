@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	models "github.com/lzimin05/course-todo/internal/models/search"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestSearchUsecase_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSearchRepository(ctrl)
+	uc := New(mockRepo)
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupContext  func() context.Context
+		setupMocks    func()
+		expectedError bool
+	}{
+		{
+			name: "successful search",
+			setupContext: func() context.Context {
+				ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+				return logctx.WithLogger(ctx, logctx.NewLogger())
+			},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					Search(gomock.Any(), userID, "login", 20, 0).
+					Return([]models.SearchResult{{
+						Type:    models.TypeTask,
+						ID:      uuid.New(),
+						Title:   "<script>\x02login\x03</script>",
+						Snippet: "a & \x02login\x03",
+						Rank:    0.5,
+					}}, nil)
+			},
+			expectedError: false,
+		},
+		{
+			name: "repository error",
+			setupContext: func() context.Context {
+				ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+				return logctx.WithLogger(ctx, logctx.NewLogger())
+			},
+			setupMocks: func() {
+				mockRepo.EXPECT().
+					Search(gomock.Any(), userID, "login", 20, 0).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: true,
+		},
+		{
+			name: "no user in context",
+			setupContext: func() context.Context {
+				return logctx.WithLogger(context.Background(), logctx.NewLogger())
+			},
+			setupMocks:    func() {},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			result, err := uc.Search(tt.setupContext(), "login", 20, 0)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Results, 1)
+				assert.Equal(t, "&lt;script&gt;<mark>login</mark>&lt;/script&gt;", result.Results[0].Title)
+				assert.Equal(t, "a &amp; <mark>login</mark>", result.Results[0].Snippet)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/task"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+// GetChecklist возвращает пункты чек-листа задачи по порядку
+func (uc *TaskUsecase) GetChecklist(ctx context.Context, taskID uuid.UUID) ([]*dto.ChecklistItemDTO, error) {
+	const op = "TaskUseCase.GetChecklist"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("TaskID", taskID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	// Для недоступной задачи отвечаем 404, а не пустым списком
+	if _, err := uc.repo.GetTaskByID(ctx, taskID, userID); err != nil {
+		logger.WithError(err).Error("failed to get task")
+		return nil, err
+	}
+
+	items, err := uc.repo.GetChecklist(ctx, taskID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get checklist")
+		return nil, err
+	}
+
+	result := make([]*dto.ChecklistItemDTO, len(items))
+	for i := range items {
+		result[i] = checklistItemToDTO(&items[i])
+	}
+	return result, nil
+}
+
+func (uc *TaskUsecase) AddChecklistItem(ctx context.Context, taskID uuid.UUID, req dto.CreateChecklistItemDTO) (*dto.ChecklistItemDTO, error) {
+	const op = "TaskUseCase.AddChecklistItem"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("TaskID", taskID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	item := &models.ChecklistItem{
+		ID:         uuid.New(),
+		TaskID:     taskID,
+		Text:       req.Text,
+		AssigneeID: req.AssigneeID,
+		Position:   -1,
+		CreatedAt:  time.Now(),
+	}
+	if req.Position != nil {
+		item.Position = *req.Position
+	}
+
+	if err := uc.repo.CreateChecklistItem(ctx, item, userID); err != nil {
+		logger.WithError(err).Error("failed to create checklist item")
+		return nil, err
+	}
+
+	return checklistItemToDTO(item), nil
+}
+
+// UpdateChecklistItem меняет текст, исполнителя или отметку о выполнении пункта
+func (uc *TaskUsecase) UpdateChecklistItem(ctx context.Context, taskID, itemID uuid.UUID, req dto.UpdateChecklistItemDTO) (*dto.ChecklistItemDTO, error) {
+	const op = "TaskUseCase.UpdateChecklistItem"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("TaskID", taskID).
+		WithField("ItemID", itemID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	item, err := uc.repo.UpdateChecklistItem(ctx, itemID, taskID, userID, req.Text, req.Done, req.AssigneeID, req.ClearAssignee)
+	if err != nil {
+		logger.WithError(err).Error("failed to update checklist item")
+		return nil, err
+	}
+
+	return checklistItemToDTO(item), nil
+}
+
+func (uc *TaskUsecase) MoveChecklistItem(ctx context.Context, taskID, itemID uuid.UUID, req dto.MoveChecklistItemDTO) (*dto.MoveChecklistItemDTO, error) {
+	const op = "TaskUseCase.MoveChecklistItem"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("TaskID", taskID).
+		WithField("ItemID", itemID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	position, err := uc.repo.MoveChecklistItem(ctx, itemID, taskID, userID, req.Position)
+	if err != nil {
+		logger.WithError(err).Error("failed to move checklist item")
+		return nil, err
+	}
+
+	return &dto.MoveChecklistItemDTO{Position: position}, nil
+}
+
+func (uc *TaskUsecase) DeleteChecklistItem(ctx context.Context, taskID, itemID uuid.UUID) error {
+	const op = "TaskUseCase.DeleteChecklistItem"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("TaskID", taskID).
+		WithField("ItemID", itemID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	if err := uc.repo.DeleteChecklistItem(ctx, itemID, taskID, userID); err != nil {
+		logger.WithError(err).Error("failed to delete checklist item")
+		return err
+	}
+	return nil
+}
+
+func checklistItemToDTO(item *models.ChecklistItem) *dto.ChecklistItemDTO {
+	return &dto.ChecklistItemDTO{
+		ID:         item.ID,
+		TaskID:     item.TaskID,
+		Text:       item.Text,
+		Done:       item.Done,
+		AssigneeID: item.AssigneeID,
+		Position:   item.Position,
+		CreatedAt:  item.CreatedAt.Truncate(time.Second),
+	}
+}
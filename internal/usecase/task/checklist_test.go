@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/task"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newChecklistContext(userID uuid.UUID) context.Context {
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	return logctx.WithLogger(ctx, logctx.NewLogger())
+}
+
+func TestTaskUsecase_GetChecklist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	uc := New(mockTaskRepo, mocks.NewMockTaskProjectRepository(ctrl), mocks.NewMockTaskLinkRepository(ctrl), mocks.NewMockTaskAttachmentCleaner(ctrl), mocks.NewMockTaskMentionService(ctrl))
+
+	taskID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("items in order", func(t *testing.T) {
+		mockTaskRepo.EXPECT().GetTaskByID(gomock.Any(), taskID, userID).Return(&models.Task{ID: taskID}, nil)
+		mockTaskRepo.EXPECT().GetChecklist(gomock.Any(), taskID, userID).Return([]models.ChecklistItem{
+			{ID: uuid.New(), TaskID: taskID, Text: "Первый", Position: 0},
+			{ID: uuid.New(), TaskID: taskID, Text: "Второй", Done: true, Position: 1},
+		}, nil)
+
+		items, err := uc.GetChecklist(newChecklistContext(userID), taskID)
+
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Equal(t, "Первый", items[0].Text)
+		assert.True(t, items[1].Done)
+	})
+
+	t.Run("task not accessible", func(t *testing.T) {
+		mockTaskRepo.EXPECT().GetTaskByID(gomock.Any(), taskID, userID).Return(nil, errs.ErrTaskNotFound)
+
+		_, err := uc.GetChecklist(newChecklistContext(userID), taskID)
+
+		assert.ErrorIs(t, err, errs.ErrTaskNotFound)
+	})
+}
+
+func TestTaskUsecase_AddChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	uc := New(mockTaskRepo, mocks.NewMockTaskProjectRepository(ctrl), mocks.NewMockTaskLinkRepository(ctrl), mocks.NewMockTaskAttachmentCleaner(ctrl), mocks.NewMockTaskMentionService(ctrl))
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	position := 0
+
+	tests := []struct {
+		name             string
+		req              dto.CreateChecklistItemDTO
+		expectedPosition int
+	}{
+		{
+			name:             "without position goes to the end",
+			req:              dto.CreateChecklistItemDTO{Text: "Шаг"},
+			expectedPosition: -1,
+		},
+		{
+			name:             "explicit position",
+			req:              dto.CreateChecklistItemDTO{Text: "Шаг", Position: &position},
+			expectedPosition: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo.EXPECT().CreateChecklistItem(gomock.Any(), gomock.Any(), userID).
+				DoAndReturn(func(_ context.Context, item *models.ChecklistItem, _ uuid.UUID) error {
+					assert.Equal(t, tt.expectedPosition, item.Position)
+					assert.Equal(t, taskID, item.TaskID)
+					item.Position = 4
+					return nil
+				})
+
+			item, err := uc.AddChecklistItem(newChecklistContext(userID), taskID, tt.req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 4, item.Position)
+			assert.False(t, item.Done)
+			assert.WithinDuration(t, time.Now(), item.CreatedAt, 2*time.Second)
+		})
+	}
+}
+
+func TestTaskUsecase_UpdateChecklistItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	uc := New(mockTaskRepo, mocks.NewMockTaskProjectRepository(ctrl), mocks.NewMockTaskLinkRepository(ctrl), mocks.NewMockTaskAttachmentCleaner(ctrl), mocks.NewMockTaskMentionService(ctrl))
+
+	taskID := uuid.New()
+	itemID := uuid.New()
+	userID := uuid.New()
+	done := true
+
+	mockTaskRepo.EXPECT().UpdateChecklistItem(gomock.Any(), itemID, taskID, userID, nil, &done, nil, false).
+		Return(nil, errs.ErrAssigneeNotMember)
+
+	_, err := uc.UpdateChecklistItem(newChecklistContext(userID), taskID, itemID, dto.UpdateChecklistItemDTO{Done: &done})
+
+	assert.ErrorIs(t, err, errs.ErrAssigneeNotMember)
+}
@@ -6,39 +6,83 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/lzimin05/course-todo/internal/models/errs"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
 	models "github.com/lzimin05/course-todo/internal/models/task"
+	linkdto "github.com/lzimin05/course-todo/internal/transport/dto/link"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
 	"github.com/lzimin05/course-todo/internal/usecase/helpers"
 )
 
-//go:generate mockgen -source=task.go -destination=../mocks/task_mocks.go -package=mocks TaskRepository,TaskProjectRepository
+//go:generate mockgen -source=task.go -destination=../mocks/task_mocks.go -package=mocks TaskRepository,TaskProjectRepository,TaskLinkRepository,TaskAttachmentCleaner,TaskMentionService
 type TaskRepository interface {
-	CreateTask(ctx context.Context, task *models.Task) (*models.Task, error)
+	CreateTask(ctx context.Context, task *models.Task, refs linkmodels.References, mentions []string) (*models.Task, error)
 	GetTasksByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Task, error)
 	GetTasksByProjectID(ctx context.Context, projectID, userID uuid.UUID) ([]*models.Task, error)
-	UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, taskID, userID uuid.UUID) error
-	UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID) error
-	DeleteTask(ctx context.Context, taskID, userID uuid.UUID) error
+	GetTaskByID(ctx context.Context, taskID, userID uuid.UUID) (*models.Task, error)
+	UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, estimateMinutes *int, startAt *time.Time, allDay bool, taskID, userID uuid.UUID, expectedVersions []int, refs linkmodels.References, mentions []string) (int, error)
+	UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID, expectedVersions []int) (int, error)
+	DeleteTask(ctx context.Context, taskID, userID uuid.UUID, expectedVersions []int) error
+	GetChecklist(ctx context.Context, taskID, userID uuid.UUID) ([]models.ChecklistItem, error)
+	CreateChecklistItem(ctx context.Context, item *models.ChecklistItem, userID uuid.UUID) error
+	UpdateChecklistItem(ctx context.Context, itemID, taskID, userID uuid.UUID, text *string, done *bool, assigneeID *uuid.UUID, clearAssignee bool) (*models.ChecklistItem, error)
+	MoveChecklistItem(ctx context.Context, itemID, taskID, userID uuid.UUID, position int) (int, error)
+	DeleteChecklistItem(ctx context.Context, itemID, taskID, userID uuid.UUID) error
 }
 
 type TaskProjectRepository interface {
 	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
 }
 
+// TaskLinkRepository отдает сущности, ссылающиеся на задачу. Сами ссылки
+// сохраняются репозиторием задач вместе с описанием
+type TaskLinkRepository interface {
+	GetBacklinks(ctx context.Context, targetType string, targetID, userID uuid.UUID) ([]linkmodels.Backlink, error)
+}
+
+// TaskAttachmentCleaner удаляет из хранилища файлы вложений удаленных задач
+type TaskAttachmentCleaner interface {
+	PurgeDeleted(ctx context.Context) error
+}
+
+// TaskMentionService проверяет упоминания @login из описаний задач. Сами упоминания
+// сохраняются репозиторием задач вместе с описанием
+type TaskMentionService interface {
+	CheckMentions(ctx context.Context, projectID uuid.UUID, text string) error
+}
+
 type TaskUsecase struct {
 	repo        TaskRepository
 	projectRepo TaskProjectRepository
+	linkRepo    TaskLinkRepository
+	attachments TaskAttachmentCleaner
+	mentions    TaskMentionService
 }
 
-func New(repo TaskRepository, projectRepo TaskProjectRepository) *TaskUsecase {
+func New(repo TaskRepository, projectRepo TaskProjectRepository, linkRepo TaskLinkRepository, attachments TaskAttachmentCleaner, mentions TaskMentionService) *TaskUsecase {
 	return &TaskUsecase{
 		repo:        repo,
 		projectRepo: projectRepo,
+		linkRepo:    linkRepo,
+		attachments: attachments,
+		mentions:    mentions,
 	}
 }
 
 func (uc *TaskUsecase) CreateTask(ctx context.Context, req *dto.PostTaskDTO) (*dto.CreateTaskDTO, error) {
+	return uc.createTask(ctx, req, models.CalDAVOrigin{Status: models.StatusWaiting})
+}
+
+// CreateCalDAVTask создает задачу из VTODO: в отличие от API, клиент задает
+// начальный статус, а имя ресурса и UID сохраняются вместе с задачей
+func (uc *TaskUsecase) CreateCalDAVTask(ctx context.Context, req *dto.PostTaskDTO, origin models.CalDAVOrigin) (*dto.CreateTaskDTO, error) {
+	if origin.Status == "" {
+		origin.Status = models.StatusWaiting
+	}
+	return uc.createTask(ctx, req, origin)
+}
+
+func (uc *TaskUsecase) createTask(ctx context.Context, req *dto.PostTaskDTO, origin models.CalDAVOrigin) (*dto.CreateTaskDTO, error) {
 	const op = "TaskUseCase.CreateTask"
 	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("title", req.Title)
 
@@ -60,6 +104,12 @@ func (uc *TaskUsecase) CreateTask(ctx context.Context, req *dto.PostTaskDTO) (*d
 		return nil, errs.ErrNoAccess
 	}
 
+	if err := uc.mentions.CheckMentions(ctx, req.ProjectID, req.Description); err != nil {
+		logger.WithError(err).Warn("invalid mentions")
+		return nil, err
+	}
+
+	deadline, startAt := normalizeSchedule(req.Deadline, req.StartAt, req.AllDay)
 	newTaskModel := &models.Task{
 		ID:          uuid.New(),
 		ProjectID:   req.ProjectID,
@@ -67,12 +117,18 @@ func (uc *TaskUsecase) CreateTask(ctx context.Context, req *dto.PostTaskDTO) (*d
 		Title:       req.Title,
 		Description: req.Description,
 		Importance:  req.Importance,
-		Deadline:    req.Deadline,
+		Deadline:    deadline,
 		CreatedAt:   time.Now(),
-		Status:      models.StatusWaiting,
+		Status:      origin.Status,
+
+		EstimateMinutes: req.EstimateMinutes,
+		StartAt:         startAt,
+		AllDay:          req.AllDay,
+		CalDAVName:      origin.Name,
+		CalDAVUID:       origin.UID,
 	}
 
-	_, err = uc.repo.CreateTask(ctx, newTaskModel)
+	_, err = uc.repo.CreateTask(ctx, newTaskModel, helpers.ParseReferences(newTaskModel.Description), helpers.ParseMentions(newTaskModel.Description))
 	if err != nil {
 		logger.WithError(err).Error("failed to create task")
 		return nil, err
@@ -104,6 +160,14 @@ func (uc *TaskUsecase) GetTasksByUserID(ctx context.Context, userID uuid.UUID) (
 			Deadline:    taskmodel.Deadline,
 			Status:      taskmodel.Status,
 			CreatedAt:   taskmodel.CreatedAt,
+			CompletedAt: taskmodel.CompletedAt,
+			Version:     taskmodel.Version,
+
+			EstimateMinutes: taskmodel.EstimateMinutes,
+			StartAt:         taskmodel.StartAt,
+			AllDay:          taskmodel.AllDay,
+			ChecklistDone:   taskmodel.ChecklistDone,
+			ChecklistTotal:  taskmodel.ChecklistTotal,
 		}
 	}
 
@@ -150,41 +214,159 @@ func (uc *TaskUsecase) GetTasksByProjectID(ctx context.Context, projectID uuid.U
 			Deadline:    taskmodel.Deadline,
 			Status:      taskmodel.Status,
 			CreatedAt:   taskmodel.CreatedAt,
+			CompletedAt: taskmodel.CompletedAt,
+			Version:     taskmodel.Version,
+
+			EstimateMinutes: taskmodel.EstimateMinutes,
+			StartAt:         taskmodel.StartAt,
+			AllDay:          taskmodel.AllDay,
+			ChecklistDone:   taskmodel.ChecklistDone,
+			ChecklistTotal:  taskmodel.ChecklistTotal,
 		}
 	}
 
 	return TasksDTO, nil
 }
 
-func (uc *TaskUsecase) UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, taskID, userID uuid.UUID) error {
+func (uc *TaskUsecase) GetTaskByID(ctx context.Context, taskID uuid.UUID) (*dto.TaskDTO, error) {
+	const op = "TaskUseCase.GetTaskByID"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("TaskID", taskID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	taskmodel, err := uc.repo.GetTaskByID(ctx, taskID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get task")
+		return nil, err
+	}
+
+	return &dto.TaskDTO{
+		ID:          taskmodel.ID,
+		ProjectID:   taskmodel.ProjectID,
+		UserID:      taskmodel.UserID,
+		Title:       taskmodel.Title,
+		Description: taskmodel.Description,
+		Importance:  taskmodel.Importance,
+		Deadline:    taskmodel.Deadline,
+		Status:      taskmodel.Status,
+		CreatedAt:   taskmodel.CreatedAt,
+		CompletedAt: taskmodel.CompletedAt,
+		Version:     taskmodel.Version,
+
+		EstimateMinutes: taskmodel.EstimateMinutes,
+		StartAt:         taskmodel.StartAt,
+		AllDay:          taskmodel.AllDay,
+		ChecklistDone:   taskmodel.ChecklistDone,
+		ChecklistTotal:  taskmodel.ChecklistTotal,
+	}, nil
+}
+
+func (uc *TaskUsecase) UpdateTask(ctx context.Context, title, description string, importance int, deadline time.Time, estimateMinutes *int, startAt *time.Time, allDay bool, taskID, userID uuid.UUID, expectedVersions []int) (int, error) {
 	const op = "TaskUseCase.UpdateTask"
 	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("TaskID", taskID)
-	err := uc.repo.UpdateTask(ctx, title, description, importance, deadline, taskID, userID)
+	// Проект нужен только для проверки упоминаний, без них задача не читается
+	mentions := helpers.ParseMentions(description)
+	if len(mentions) > 0 {
+		task, err := uc.repo.GetTaskByID(ctx, taskID, userID)
+		if err != nil {
+			logger.WithError(err).Error("failed to get task")
+			return 0, err
+		}
+		if err := uc.mentions.CheckMentions(ctx, task.ProjectID, description); err != nil {
+			logger.WithError(err).Warn("invalid mentions")
+			return 0, err
+		}
+	}
+
+	deadline, startAt = normalizeSchedule(deadline, startAt, allDay)
+	version, err := uc.repo.UpdateTask(ctx, title, description, importance, deadline, estimateMinutes, startAt, allDay, taskID, userID, expectedVersions, helpers.ParseReferences(description), mentions)
 	if err != nil {
 		logger.WithError(err).Error("failed to update task")
-		return err
+		return 0, err
 	}
-	return nil
+	return version, nil
 }
 
-func (uc *TaskUsecase) UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID) error {
+func (uc *TaskUsecase) UpdateTaskStatus(ctx context.Context, status string, taskID, userID uuid.UUID, expectedVersions []int) (int, error) {
 	const op = "TaskUseCase.UpdateTaskStatus"
 	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("TaskID", taskID)
-	err := uc.repo.UpdateTaskStatus(ctx, status, taskID, userID)
+	version, err := uc.repo.UpdateTaskStatus(ctx, status, taskID, userID, expectedVersions)
 	if err != nil {
 		logger.WithError(err).Error("failed to update status for task")
-		return err
+		return 0, err
 	}
-	return nil
+	return version, nil
 }
 
-func (uc *TaskUsecase) DeleteTask(ctx context.Context, taskID, userID uuid.UUID) error {
+func (uc *TaskUsecase) DeleteTask(ctx context.Context, taskID, userID uuid.UUID, expectedVersions []int) error {
 	const op = "TaskUseCase.DeleteTask"
 	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("TaskID", taskID)
-	err := uc.repo.DeleteTask(ctx, taskID, userID)
+	err := uc.repo.DeleteTask(ctx, taskID, userID, expectedVersions)
 	if err != nil {
 		logger.WithError(err).Error("failed to delete task")
 		return err
 	}
+
+	if err := uc.attachments.PurgeDeleted(ctx); err != nil {
+		logger.WithError(err).Warn("failed to purge attachment files")
+	}
 	return nil
 }
+
+// GetBacklinks возвращает задачи и заметки, которые ссылаются на задачу
+// и доступны пользователю
+func (uc *TaskUsecase) GetBacklinks(ctx context.Context, taskID uuid.UUID) ([]*linkdto.BacklinkDTO, error) {
+	const op = "TaskUseCase.GetBacklinks"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("TaskID", taskID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	if _, err := uc.repo.GetTaskByID(ctx, taskID, userID); err != nil {
+		logger.WithError(err).Error("failed to get task")
+		return nil, err
+	}
+
+	backlinks, err := uc.linkRepo.GetBacklinks(ctx, linkmodels.TypeTask, taskID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get backlinks")
+		return nil, err
+	}
+
+	backlinksDTO := make([]*linkdto.BacklinkDTO, len(backlinks))
+	for i, backlink := range backlinks {
+		backlinksDTO[i] = &linkdto.BacklinkDTO{
+			Type:      backlink.Type,
+			ID:        backlink.ID,
+			ProjectID: backlink.ProjectID,
+			Title:     backlink.Title,
+		}
+	}
+
+	return backlinksDTO, nil
+}
+
+// normalizeSchedule приводит даты задачи на весь день к полуночи UTC того дня,
+// который указал клиент, чтобы они не зависели от часового пояса
+func normalizeSchedule(deadline time.Time, startAt *time.Time, allDay bool) (time.Time, *time.Time) {
+	if !allDay {
+		return deadline, startAt
+	}
+
+	if !deadline.IsZero() {
+		deadline = time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	if startAt != nil {
+		date := time.Date(startAt.Year(), startAt.Month(), startAt.Day(), 0, 0, 0, 0, time.UTC)
+		startAt = &date
+	}
+
+	return deadline, startAt
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/lzimin05/course-todo/internal/models/domains"
 	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	linkmodels "github.com/lzimin05/course-todo/internal/models/link"
 	models "github.com/lzimin05/course-todo/internal/models/task"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
@@ -24,7 +26,9 @@ func TestTaskUsecase_CreateTask(t *testing.T) {
 
 	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockTaskProjectRepository(ctrl)
-	uc := New(mockTaskRepo, mockProjectRepo)
+	mockLinkRepo := mocks.NewMockTaskLinkRepository(ctrl)
+	mockMentions := mocks.NewMockTaskMentionService(ctrl)
+	uc := New(mockTaskRepo, mockProjectRepo, mockLinkRepo, mocks.NewMockTaskAttachmentCleaner(ctrl), mockMentions)
 
 	userID := uuid.New()
 	projectID := uuid.New()
@@ -54,12 +58,40 @@ func TestTaskUsecase_CreateTask(t *testing.T) {
 					CheckProjectAccess(gomock.Any(), projectID, userID).
 					Return(true, nil)
 
+				mockMentions.EXPECT().
+					CheckMentions(gomock.Any(), projectID, "Test Description").
+					Return(nil)
+
 				mockTaskRepo.EXPECT().
-					CreateTask(gomock.Any(), gomock.Any()).
+					CreateTask(gomock.Any(), gomock.Any(), linkmodels.References{}, nil).
 					Return(&models.Task{}, nil)
 			},
 			expectedError: nil,
 		},
+		{
+			name: "mention of non-member rejected",
+			request: &dto.PostTaskDTO{
+				ProjectID:   projectID,
+				Title:       "Test Task",
+				Description: "cc @stranger",
+				Importance:  1,
+				Deadline:    time.Now().Add(24 * time.Hour),
+			},
+			setupContext: func() context.Context {
+				ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+				return logctx.WithLogger(ctx, logctx.NewLogger())
+			},
+			setupMocks: func() {
+				mockProjectRepo.EXPECT().
+					CheckProjectAccess(gomock.Any(), projectID, userID).
+					Return(true, nil)
+
+				mockMentions.EXPECT().
+					CheckMentions(gomock.Any(), projectID, "cc @stranger").
+					Return(fmt.Errorf("%w: @stranger", errs.ErrMentionNotMember))
+			},
+			expectedError: fmt.Errorf("%w: @stranger", errs.ErrMentionNotMember),
+		},
 		{
 			name: "no project access",
 			request: &dto.PostTaskDTO{
@@ -118,8 +150,12 @@ func TestTaskUsecase_CreateTask(t *testing.T) {
 					CheckProjectAccess(gomock.Any(), projectID, userID).
 					Return(true, nil)
 
+				mockMentions.EXPECT().
+					CheckMentions(gomock.Any(), projectID, gomock.Any()).
+					Return(nil)
+
 				mockTaskRepo.EXPECT().
-					CreateTask(gomock.Any(), gomock.Any()).
+					CreateTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("database error"))
 			},
 			expectedError: errors.New("database error"),
@@ -143,13 +179,45 @@ func TestTaskUsecase_CreateTask(t *testing.T) {
 	}
 }
 
+func TestTaskUsecase_CreateCalDAVTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjectRepo := mocks.NewMockTaskProjectRepository(ctrl)
+	mockLinkRepo := mocks.NewMockTaskLinkRepository(ctrl)
+	mockMentions := mocks.NewMockTaskMentionService(ctrl)
+	uc := New(mockTaskRepo, mockProjectRepo, mockLinkRepo, mocks.NewMockTaskAttachmentCleaner(ctrl), mockMentions)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+
+	mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+	mockMentions.EXPECT().CheckMentions(gomock.Any(), projectID, "").Return(nil)
+	mockTaskRepo.EXPECT().CreateTask(gomock.Any(), gomock.Any(), linkmodels.References{}, nil).
+		DoAndReturn(func(ctx context.Context, task *models.Task, refs linkmodels.References, mentions []string) (*models.Task, error) {
+			assert.Equal(t, models.StatusCompleted, task.Status)
+			assert.Equal(t, "abc.ics", task.CalDAVName)
+			assert.Equal(t, "abc-uid", task.CalDAVUID)
+			return task, nil
+		})
+
+	_, err := uc.CreateCalDAVTask(ctx, &dto.PostTaskDTO{ProjectID: projectID, Title: "Купить молоко", Importance: 2},
+		models.CalDAVOrigin{Status: models.StatusCompleted, Name: "abc.ics", UID: "abc-uid"})
+
+	assert.NoError(t, err)
+}
+
 func TestTaskUsecase_GetTasksByProjectID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockTaskProjectRepository(ctrl)
-	uc := New(mockTaskRepo, mockProjectRepo)
+	mockLinkRepo := mocks.NewMockTaskLinkRepository(ctrl)
+	uc := New(mockTaskRepo, mockProjectRepo, mockLinkRepo, mocks.NewMockTaskAttachmentCleaner(ctrl), mocks.NewMockTaskMentionService(ctrl))
 
 	userID := uuid.New()
 	projectID := uuid.New()
@@ -231,13 +299,73 @@ func TestTaskUsecase_GetTasksByProjectID(t *testing.T) {
 	}
 }
 
+func TestTaskUsecase_GetTaskByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjectRepo := mocks.NewMockTaskProjectRepository(ctrl)
+	mockLinkRepo := mocks.NewMockTaskLinkRepository(ctrl)
+	uc := New(mockTaskRepo, mockProjectRepo, mockLinkRepo, mocks.NewMockTaskAttachmentCleaner(ctrl), mocks.NewMockTaskMentionService(ctrl))
+
+	userID := uuid.New()
+	taskID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func()
+		expectedError error
+	}{
+		{
+			name: "successful retrieval",
+			setupMocks: func() {
+				mockTaskRepo.EXPECT().
+					GetTaskByID(gomock.Any(), taskID, userID).
+					Return(&models.Task{ID: taskID, Title: "Task", Status: models.StatusWaiting, Version: 5}, nil)
+			},
+		},
+		{
+			name: "task not found",
+			setupMocks: func() {
+				mockTaskRepo.EXPECT().
+					GetTaskByID(gomock.Any(), taskID, userID).
+					Return(nil, errs.ErrTaskNotFound)
+			},
+			expectedError: errs.ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+			ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+			result, err := uc.GetTaskByID(ctx, taskID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, taskID, result.ID)
+				assert.Equal(t, 5, result.Version)
+			}
+		})
+	}
+}
+
 func TestTaskUsecase_UpdateTask(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockTaskProjectRepository(ctrl)
-	uc := New(mockTaskRepo, mockProjectRepo)
+	mockLinkRepo := mocks.NewMockTaskLinkRepository(ctrl)
+	mockMentions := mocks.NewMockTaskMentionService(ctrl)
+	uc := New(mockTaskRepo, mockProjectRepo, mockLinkRepo, mocks.NewMockTaskAttachmentCleaner(ctrl), mockMentions)
+
+	projectID := uuid.New()
 
 	tests := []struct {
 		name          string
@@ -253,18 +381,38 @@ func TestTaskUsecase_UpdateTask(t *testing.T) {
 		{
 			name:        "successful task update",
 			title:       "Updated Task",
-			description: "Updated Description",
+			description: "Updated Description, see [[Release plan]]",
 			importance:  2,
 			deadline:    time.Now().Add(48 * time.Hour),
 			taskID:      uuid.New(),
 			userID:      uuid.New(),
 			setupMocks: func() {
 				mockTaskRepo.EXPECT().
-					UpdateTask(gomock.Any(), "Updated Task", "Updated Description", 2, gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(nil)
+					UpdateTask(gomock.Any(), "Updated Task", "Updated Description, see [[Release plan]]", 2, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+						linkmodels.References{Titles: []string{"Release plan"}}, nil).
+					Return(2, nil)
 			},
 			expectedError: nil,
 		},
+		{
+			name:        "mentions are checked against task project",
+			title:       "Updated Task",
+			description: "Updated Description, cc @anna",
+			importance:  2,
+			deadline:    time.Now().Add(48 * time.Hour),
+			taskID:      uuid.New(),
+			userID:      uuid.New(),
+			setupMocks: func() {
+				mockTaskRepo.EXPECT().
+					GetTaskByID(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&models.Task{ProjectID: projectID}, nil)
+
+				mockMentions.EXPECT().
+					CheckMentions(gomock.Any(), projectID, "Updated Description, cc @anna").
+					Return(fmt.Errorf("%w: @anna", errs.ErrMentionNotMember))
+			},
+			expectedError: fmt.Errorf("%w: @anna", errs.ErrMentionNotMember),
+		},
 		{
 			name:        "repository error",
 			title:       "Updated Task",
@@ -275,8 +423,8 @@ func TestTaskUsecase_UpdateTask(t *testing.T) {
 			userID:      uuid.New(),
 			setupMocks: func() {
 				mockTaskRepo.EXPECT().
-					UpdateTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(errors.New("database error"))
+					UpdateTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(0, errors.New("database error"))
 			},
 			expectedError: errors.New("database error"),
 		},
@@ -287,7 +435,7 @@ func TestTaskUsecase_UpdateTask(t *testing.T) {
 			tt.setupMocks()
 
 			ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
-			err := uc.UpdateTask(ctx, tt.title, tt.description, tt.importance, tt.deadline, tt.taskID, tt.userID)
+			_, err := uc.UpdateTask(ctx, tt.title, tt.description, tt.importance, tt.deadline, nil, nil, false, tt.taskID, tt.userID, nil)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -305,7 +453,8 @@ func TestTaskUsecase_GetTasksByUserID(t *testing.T) {
 
 	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockTaskProjectRepository(ctrl)
-	uc := New(mockTaskRepo, mockProjectRepo)
+	mockLinkRepo := mocks.NewMockTaskLinkRepository(ctrl)
+	uc := New(mockTaskRepo, mockProjectRepo, mockLinkRepo, mocks.NewMockTaskAttachmentCleaner(ctrl), mocks.NewMockTaskMentionService(ctrl))
 
 	tests := []struct {
 		name          string
@@ -420,7 +569,8 @@ func TestTaskUsecase_UpdateTaskStatus(t *testing.T) {
 
 	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockTaskProjectRepository(ctrl)
-	uc := New(mockTaskRepo, mockProjectRepo)
+	mockLinkRepo := mocks.NewMockTaskLinkRepository(ctrl)
+	uc := New(mockTaskRepo, mockProjectRepo, mockLinkRepo, mocks.NewMockTaskAttachmentCleaner(ctrl), mocks.NewMockTaskMentionService(ctrl))
 
 	tests := []struct {
 		name          string
@@ -437,8 +587,8 @@ func TestTaskUsecase_UpdateTaskStatus(t *testing.T) {
 			userID: uuid.New(),
 			setupMocks: func() {
 				mockTaskRepo.EXPECT().
-					UpdateTaskStatus(gomock.Any(), models.StatusCompleted, gomock.Any(), gomock.Any()).
-					Return(nil)
+					UpdateTaskStatus(gomock.Any(), models.StatusCompleted, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(2, nil)
 			},
 			expectedError: nil,
 		},
@@ -449,8 +599,8 @@ func TestTaskUsecase_UpdateTaskStatus(t *testing.T) {
 			userID: uuid.New(),
 			setupMocks: func() {
 				mockTaskRepo.EXPECT().
-					UpdateTaskStatus(gomock.Any(), models.StatusCompleted, gomock.Any(), gomock.Any()).
-					Return(errors.New("database error"))
+					UpdateTaskStatus(gomock.Any(), models.StatusCompleted, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(0, errors.New("database error"))
 			},
 			expectedError: errors.New("database error"),
 		},
@@ -461,8 +611,8 @@ func TestTaskUsecase_UpdateTaskStatus(t *testing.T) {
 			userID: uuid.New(),
 			setupMocks: func() {
 				mockTaskRepo.EXPECT().
-					UpdateTaskStatus(gomock.Any(), "invalid_status", gomock.Any(), gomock.Any()).
-					Return(nil)
+					UpdateTaskStatus(gomock.Any(), "invalid_status", gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(2, nil)
 			},
 			expectedError: nil, // Пока нет валидации в usecase
 		},
@@ -473,7 +623,7 @@ func TestTaskUsecase_UpdateTaskStatus(t *testing.T) {
 			tt.setupMocks()
 
 			ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
-			err := uc.UpdateTaskStatus(ctx, tt.status, tt.taskID, tt.userID)
+			_, err := uc.UpdateTaskStatus(ctx, tt.status, tt.taskID, tt.userID, nil)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -491,7 +641,9 @@ func TestTaskUsecase_DeleteTask(t *testing.T) {
 
 	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockTaskProjectRepository(ctrl)
-	uc := New(mockTaskRepo, mockProjectRepo)
+	mockLinkRepo := mocks.NewMockTaskLinkRepository(ctrl)
+	mockAttachments := mocks.NewMockTaskAttachmentCleaner(ctrl)
+	uc := New(mockTaskRepo, mockProjectRepo, mockLinkRepo, mockAttachments, mocks.NewMockTaskMentionService(ctrl))
 
 	tests := []struct {
 		name          string
@@ -506,8 +658,9 @@ func TestTaskUsecase_DeleteTask(t *testing.T) {
 			userID: uuid.New(),
 			setupMocks: func() {
 				mockTaskRepo.EXPECT().
-					DeleteTask(gomock.Any(), gomock.Any(), gomock.Any()).
+					DeleteTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
+				mockAttachments.EXPECT().PurgeDeleted(gomock.Any()).Return(errors.New("storage down"))
 			},
 			expectedError: nil,
 		},
@@ -517,7 +670,7 @@ func TestTaskUsecase_DeleteTask(t *testing.T) {
 			userID: uuid.New(),
 			setupMocks: func() {
 				mockTaskRepo.EXPECT().
-					DeleteTask(gomock.Any(), gomock.Any(), gomock.Any()).
+					DeleteTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errs.ErrTaskNotFound)
 			},
 			expectedError: errs.ErrTaskNotFound,
@@ -528,7 +681,7 @@ func TestTaskUsecase_DeleteTask(t *testing.T) {
 			userID: uuid.New(),
 			setupMocks: func() {
 				mockTaskRepo.EXPECT().
-					DeleteTask(gomock.Any(), gomock.Any(), gomock.Any()).
+					DeleteTask(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("database error"))
 			},
 			expectedError: errors.New("database error"),
@@ -540,7 +693,7 @@ func TestTaskUsecase_DeleteTask(t *testing.T) {
 			tt.setupMocks()
 
 			ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
-			err := uc.DeleteTask(ctx, tt.taskID, tt.userID)
+			err := uc.DeleteTask(ctx, tt.taskID, tt.userID, nil)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -558,10 +711,107 @@ func TestNew_TaskUsecase(t *testing.T) {
 
 	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
 	mockProjectRepo := mocks.NewMockTaskProjectRepository(ctrl)
+	mockLinkRepo := mocks.NewMockTaskLinkRepository(ctrl)
 
-	uc := New(mockTaskRepo, mockProjectRepo)
+	uc := New(mockTaskRepo, mockProjectRepo, mockLinkRepo, mocks.NewMockTaskAttachmentCleaner(ctrl), mocks.NewMockTaskMentionService(ctrl))
 
 	assert.NotNil(t, uc)
 	assert.Equal(t, mockTaskRepo, uc.repo)
 	assert.Equal(t, mockProjectRepo, uc.projectRepo)
+	assert.Equal(t, mockLinkRepo, uc.linkRepo)
+}
+
+func TestTaskUsecase_GetBacklinks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	mockProjectRepo := mocks.NewMockTaskProjectRepository(ctrl)
+	mockLinkRepo := mocks.NewMockTaskLinkRepository(ctrl)
+	uc := New(mockTaskRepo, mockProjectRepo, mockLinkRepo, mocks.NewMockTaskAttachmentCleaner(ctrl), mocks.NewMockTaskMentionService(ctrl))
+
+	userID := uuid.New()
+	taskID := uuid.New()
+	noteID := uuid.New()
+	projectID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func()
+		expectedLen   int
+		expectedError error
+	}{
+		{
+			name: "backlinks from notes",
+			setupMocks: func() {
+				mockTaskRepo.EXPECT().
+					GetTaskByID(gomock.Any(), taskID, userID).
+					Return(&models.Task{ID: taskID}, nil)
+
+				mockLinkRepo.EXPECT().
+					GetBacklinks(gomock.Any(), linkmodels.TypeTask, taskID, userID).
+					Return([]linkmodels.Backlink{
+						{Type: linkmodels.TypeNote, ID: noteID, ProjectID: projectID, Title: "Meeting notes"},
+					}, nil)
+			},
+			expectedLen: 1,
+		},
+		{
+			name: "task not accessible",
+			setupMocks: func() {
+				mockTaskRepo.EXPECT().
+					GetTaskByID(gomock.Any(), taskID, userID).
+					Return(nil, errs.ErrTaskNotFound)
+			},
+			expectedError: errs.ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+			ctx = logctx.WithLogger(ctx, logctx.NewLogger())
+			tt.setupMocks()
+
+			result, err := uc.GetBacklinks(ctx, taskID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, result, tt.expectedLen)
+			assert.Equal(t, noteID, result[0].ID)
+			assert.Equal(t, linkmodels.TypeNote, result[0].Type)
+		})
+	}
+}
+
+func TestNormalizeSchedule(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	deadline := time.Date(2025, 3, 10, 1, 30, 0, 0, msk)
+	startAt := time.Date(2025, 3, 8, 23, 0, 0, 0, msk)
+
+	t.Run("timed task is unchanged", func(t *testing.T) {
+		gotDeadline, gotStart := normalizeSchedule(deadline, &startAt, false)
+
+		assert.Equal(t, deadline, gotDeadline)
+		assert.Equal(t, &startAt, gotStart)
+	})
+
+	t.Run("all-day task keeps the client's dates", func(t *testing.T) {
+		gotDeadline, gotStart := normalizeSchedule(deadline, &startAt, true)
+
+		assert.Equal(t, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), gotDeadline)
+		assert.Equal(t, time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC), *gotStart)
+	})
+
+	t.Run("all-day task without deadline", func(t *testing.T) {
+		gotDeadline, gotStart := normalizeSchedule(time.Time{}, nil, true)
+
+		assert.True(t, gotDeadline.IsZero())
+		assert.Nil(t, gotStart)
+	})
 }
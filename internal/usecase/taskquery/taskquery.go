@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/lzimin05/course-todo/internal/models/task"
+	usermodels "github.com/lzimin05/course-todo/internal/models/user"
+	"github.com/lzimin05/course-todo/internal/taskquery"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/task"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+//go:generate mockgen -source=taskquery.go -destination=../mocks/taskquery_mocks.go -package=mocks TaskQueryRepository,TaskQueryUserRepository
+type TaskQueryRepository interface {
+	SearchTasks(ctx context.Context, query taskquery.Node, params taskquery.Params) ([]*models.Task, error)
+}
+
+type TaskQueryUserRepository interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*usermodels.User, error)
+}
+
+type TaskQueryUsecase struct {
+	repo     TaskQueryRepository
+	userRepo TaskQueryUserRepository
+}
+
+func New(repo TaskQueryRepository, userRepo TaskQueryUserRepository) *TaskQueryUsecase {
+	return &TaskQueryUsecase{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// SearchTasks разбирает запрос и выполняет его по задачам из проектов пользователя.
+// Ошибки разбора возвращаются как *taskquery.Error с позицией
+func (uc *TaskQueryUsecase) SearchTasks(ctx context.Context, query string, limit, offset int) ([]*dto.TaskDTO, error) {
+	const op = "TaskQueryUsecase.SearchTasks"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	node, err := taskquery.Parse(query)
+	if err != nil {
+		logger.WithError(err).Warn("failed to parse query")
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get user")
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil || user.Timezone == "" {
+		logger.WithField("timezone", user.Timezone).Warn("unknown user timezone, falling back to UTC")
+		loc = time.UTC
+	}
+
+	tasks, err := uc.repo.SearchTasks(ctx, node, taskquery.Params{
+		UserID:   userID,
+		Now:      time.Now(),
+		Location: loc,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to search tasks")
+		return nil, err
+	}
+
+	taskDTOs := make([]*dto.TaskDTO, len(tasks))
+	for i, task := range tasks {
+		taskDTOs[i] = &dto.TaskDTO{
+			ID:          task.ID,
+			ProjectID:   task.ProjectID,
+			UserID:      task.UserID,
+			Title:       task.Title,
+			Description: task.Description,
+			Importance:  task.Importance,
+			Deadline:    task.Deadline,
+			Status:      task.Status,
+			CreatedAt:   task.CreatedAt,
+			CompletedAt: task.CompletedAt,
+			Version:     task.Version,
+
+			EstimateMinutes: task.EstimateMinutes,
+			StartAt:         task.StartAt,
+			AllDay:          task.AllDay,
+			ChecklistDone:   task.ChecklistDone,
+			ChecklistTotal:  task.ChecklistTotal,
+		}
+	}
+
+	return taskDTOs, nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	models "github.com/lzimin05/course-todo/internal/models/task"
+	usermodels "github.com/lzimin05/course-todo/internal/models/user"
+	"github.com/lzimin05/course-todo/internal/taskquery"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestTaskQueryUsecase_SearchTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTaskQueryRepository(ctrl)
+	mockUserRepo := mocks.NewMockTaskQueryUserRepository(ctrl)
+	uc := New(mockRepo, mockUserRepo)
+
+	userID := uuid.New()
+	ctx := logctx.WithLogger(context.WithValue(context.Background(), domains.UserIDKey{}, userID.String()), logctx.NewLogger())
+
+	t.Run("successful search", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).
+			Return(&usermodels.User{ID: userID, Timezone: "Europe/Moscow"}, nil)
+		mockRepo.EXPECT().SearchTasks(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, node taskquery.Node, params taskquery.Params) ([]*models.Task, error) {
+				assert.Equal(t, `(status:"waiting" AND due<"+3d")`, node.String())
+				assert.Equal(t, userID, params.UserID)
+				assert.Equal(t, "Europe/Moscow", params.Location.String())
+				assert.Equal(t, 20, params.Limit)
+				return []*models.Task{{ID: uuid.New(), Title: "Задача", Status: "waiting"}}, nil
+			})
+
+		tasks, err := uc.SearchTasks(ctx, "status:waiting due<+3d", 20, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, "Задача", tasks[0].Title)
+	})
+
+	t.Run("parse error keeps position", func(t *testing.T) {
+		tasks, err := uc.SearchTasks(ctx, "status:waiting -label:blocked", 20, 0)
+
+		var queryErr *taskquery.Error
+		assert.True(t, errors.As(err, &queryErr))
+		assert.Equal(t, 16, queryErr.Position)
+		assert.Nil(t, tasks)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).
+			Return(&usermodels.User{ID: userID, Timezone: usermodels.DefaultTimezone}, nil)
+		mockRepo.EXPECT().SearchTasks(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("database error"))
+
+		tasks, err := uc.SearchTasks(ctx, "bug", 20, 0)
+
+		assert.Error(t, err)
+		assert.Nil(t, tasks)
+	})
+}
@@ -0,0 +1,316 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/timeentry"
+	usermodels "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/timeentry"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const dateLayout = "2006-01-02"
+
+//go:generate mockgen -source=timeentry.go -destination=../mocks/timeentry_mocks.go -package=mocks TimeEntryRepository,TimeEntryProjectRepository,TimeEntryUserRepository
+type TimeEntryRepository interface {
+	GetTaskProject(ctx context.Context, taskID, userID uuid.UUID) (uuid.UUID, error)
+	CreateTimeEntry(ctx context.Context, entry *models.TimeEntry) error
+	StopTimer(ctx context.Context, userID uuid.UUID, endedAt time.Time) (*models.TimeEntry, error)
+	GetRunningTimer(ctx context.Context, userID uuid.UUID) (*models.TimeEntry, error)
+	GetTaskTimeEntries(ctx context.Context, taskID uuid.UUID) ([]models.TimeEntry, error)
+	DeleteTimeEntry(ctx context.Context, entryID, userID uuid.UUID) error
+	GetTimeReport(ctx context.Context, userID uuid.UUID, projectID *uuid.UUID, from, to time.Time, timezone string) ([]models.ReportRow, error)
+}
+
+type TimeEntryProjectRepository interface {
+	CheckProjectAccess(ctx context.Context, projectID, userID uuid.UUID) (bool, error)
+}
+
+type TimeEntryUserRepository interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*usermodels.User, error)
+}
+
+type TimeEntryUsecase struct {
+	repo        TimeEntryRepository
+	projectRepo TimeEntryProjectRepository
+	userRepo    TimeEntryUserRepository
+}
+
+func New(repo TimeEntryRepository, projectRepo TimeEntryProjectRepository, userRepo TimeEntryUserRepository) *TimeEntryUsecase {
+	return &TimeEntryUsecase{
+		repo:        repo,
+		projectRepo: projectRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// StartTimer запускает таймер по задаче. Если у пользователя уже запущен
+// таймер, возвращается errs.ErrTimerRunning
+func (uc *TimeEntryUsecase) StartTimer(ctx context.Context, taskID uuid.UUID, req dto.StartTimerDTO) (*dto.TimeEntryDTO, error) {
+	const op = "TimeEntryUsecase.StartTimer"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("taskID", taskID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	if _, err := uc.repo.GetTaskProject(ctx, taskID, userID); err != nil {
+		logger.WithError(err).Warn("failed to get task")
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	entry := &models.TimeEntry{
+		ID:        uuid.New(),
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: now,
+		Note:      req.Note,
+		CreatedAt: now,
+	}
+
+	if err := uc.repo.CreateTimeEntry(ctx, entry); err != nil {
+		logger.WithError(err).Warn("failed to start timer")
+		return nil, err
+	}
+
+	return timeEntryToDTO(entry, now), nil
+}
+
+func (uc *TimeEntryUsecase) StopTimer(ctx context.Context) (*dto.TimeEntryDTO, error) {
+	const op = "TimeEntryUsecase.StopTimer"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	entry, err := uc.repo.StopTimer(ctx, userID, now)
+	if err != nil {
+		logger.WithError(err).Warn("failed to stop timer")
+		return nil, err
+	}
+
+	return timeEntryToDTO(entry, now), nil
+}
+
+func (uc *TimeEntryUsecase) GetRunningTimer(ctx context.Context) (*dto.TimeEntryDTO, error) {
+	const op = "TimeEntryUsecase.GetRunningTimer"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	entry, err := uc.repo.GetRunningTimer(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get running timer")
+		return nil, err
+	}
+
+	return timeEntryToDTO(entry, time.Now().UTC()), nil
+}
+
+// AddTimeEntry сохраняет уже завершенную запись. Конец считается по ended_at
+// или по началу и duration_minutes
+func (uc *TimeEntryUsecase) AddTimeEntry(ctx context.Context, taskID uuid.UUID, req dto.CreateTimeEntryDTO) (*dto.TimeEntryDTO, error) {
+	const op = "TimeEntryUsecase.AddTimeEntry"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("taskID", taskID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	if _, err := uc.repo.GetTaskProject(ctx, taskID, userID); err != nil {
+		logger.WithError(err).Warn("failed to get task")
+		return nil, err
+	}
+
+	startedAt := req.StartedAt.UTC()
+	var endedAt time.Time
+	if req.EndedAt != nil {
+		endedAt = req.EndedAt.UTC()
+	} else {
+		endedAt = startedAt.Add(time.Duration(*req.DurationMinutes) * time.Minute)
+	}
+
+	now := time.Now().UTC()
+	entry := &models.TimeEntry{
+		ID:        uuid.New(),
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: startedAt,
+		EndedAt:   &endedAt,
+		Note:      req.Note,
+		CreatedAt: now,
+	}
+
+	if err := uc.repo.CreateTimeEntry(ctx, entry); err != nil {
+		logger.WithError(err).Error("failed to create time entry")
+		return nil, err
+	}
+
+	return timeEntryToDTO(entry, now), nil
+}
+
+func (uc *TimeEntryUsecase) GetTaskTimeEntries(ctx context.Context, taskID uuid.UUID) (*dto.TaskTimeDTO, error) {
+	const op = "TimeEntryUsecase.GetTaskTimeEntries"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("taskID", taskID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	if _, err := uc.repo.GetTaskProject(ctx, taskID, userID); err != nil {
+		logger.WithError(err).Warn("failed to get task")
+		return nil, err
+	}
+
+	entries, err := uc.repo.GetTaskTimeEntries(ctx, taskID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get time entries")
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	result := &dto.TaskTimeDTO{
+		TaskID:  taskID,
+		Entries: make([]dto.TimeEntryDTO, len(entries)),
+	}
+	for i := range entries {
+		result.Entries[i] = *timeEntryToDTO(&entries[i], now)
+		// Запущенные таймеры в итог не входят, как и в отчете
+		if entries[i].EndedAt != nil {
+			result.LoggedSeconds += result.Entries[i].DurationSeconds
+		}
+	}
+
+	return result, nil
+}
+
+func (uc *TimeEntryUsecase) DeleteTimeEntry(ctx context.Context, entryID uuid.UUID) error {
+	const op = "TimeEntryUsecase.DeleteTimeEntry"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("entryID", entryID)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	if err := uc.repo.DeleteTimeEntry(ctx, entryID, userID); err != nil {
+		logger.WithError(err).Warn("failed to delete time entry")
+		return err
+	}
+
+	return nil
+}
+
+// GetTimeReport суммирует время по проектам, участникам и дням за период
+// [from, to] включительно. Дни считаются в часовом поясе пользователя.
+// Без projectID в отчет попадают все проекты пользователя
+func (uc *TimeEntryUsecase) GetTimeReport(ctx context.Context, projectID *uuid.UUID, from, to time.Time) (*dto.TimeReportDTO, error) {
+	const op = "TimeEntryUsecase.GetTimeReport"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return nil, err
+	}
+
+	if projectID != nil {
+		hasAccess, err := uc.projectRepo.CheckProjectAccess(ctx, *projectID, userID)
+		if err != nil {
+			logger.WithError(err).Error("failed to check project access")
+			return nil, err
+		}
+		if !hasAccess {
+			logger.Warn("user doesn't have access to project")
+			return nil, errs.ErrNoAccess
+		}
+	}
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get user")
+		return nil, err
+	}
+
+	timezone := user.Timezone
+	loc, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		logger.WithField("timezone", timezone).Warn("unknown user timezone, falling back to UTC")
+		timezone = usermodels.DefaultTimezone
+		loc = time.UTC
+	}
+
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+
+	rows, err := uc.repo.GetTimeReport(ctx, userID, projectID, start.UTC(), end.UTC(), timezone)
+	if err != nil {
+		logger.WithError(err).Error("failed to get time report")
+		return nil, err
+	}
+
+	result := &dto.TimeReportDTO{
+		From:     from.Format(dateLayout),
+		To:       to.Format(dateLayout),
+		Timezone: timezone,
+		Rows:     make([]dto.TimeReportRowDTO, len(rows)),
+	}
+	for i, row := range rows {
+		result.Rows[i] = dto.TimeReportRowDTO{
+			ProjectID:   row.ProjectID,
+			ProjectName: row.ProjectName,
+			UserID:      row.UserID,
+			Login:       row.Login,
+			Date:        row.Day.Format(dateLayout),
+			Seconds:     row.Seconds,
+			Hours:       secondsToHours(row.Seconds),
+		}
+		result.TotalSeconds += row.Seconds
+	}
+
+	return result, nil
+}
+
+func timeEntryToDTO(entry *models.TimeEntry, now time.Time) *dto.TimeEntryDTO {
+	end := now
+	if entry.EndedAt != nil {
+		end = *entry.EndedAt
+	}
+
+	return &dto.TimeEntryDTO{
+		ID:              entry.ID,
+		TaskID:          entry.TaskID,
+		UserID:          entry.UserID,
+		StartedAt:       entry.StartedAt,
+		EndedAt:         entry.EndedAt,
+		DurationSeconds: int64(end.Sub(entry.StartedAt) / time.Second),
+		Note:            entry.Note,
+		CreatedAt:       entry.CreatedAt,
+	}
+}
+
+// secondsToHours округляет до сотых часа
+func secondsToHours(seconds int64) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	errs "github.com/lzimin05/course-todo/internal/models/errs"
+	models "github.com/lzimin05/course-todo/internal/models/timeentry"
+	usermodels "github.com/lzimin05/course-todo/internal/models/user"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/timeentry"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newTimeEntryContext(userID uuid.UUID) context.Context {
+	ctx := context.WithValue(context.Background(), domains.UserIDKey{}, userID.String())
+	return logctx.WithLogger(ctx, logctx.NewLogger())
+}
+
+func TestTimeEntryUsecase_StartTimer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTimeEntryRepository(ctrl)
+	uc := New(mockRepo, mocks.NewMockTimeEntryProjectRepository(ctrl), mocks.NewMockTimeEntryUserRepository(ctrl))
+
+	taskID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("started", func(t *testing.T) {
+		mockRepo.EXPECT().GetTaskProject(gomock.Any(), taskID, userID).Return(uuid.New(), nil)
+		mockRepo.EXPECT().CreateTimeEntry(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, entry *models.TimeEntry) error {
+				assert.Nil(t, entry.EndedAt)
+				assert.Equal(t, userID, entry.UserID)
+				assert.Equal(t, "Ревью", entry.Note)
+				return nil
+			})
+
+		entry, err := uc.StartTimer(newTimeEntryContext(userID), taskID, dto.StartTimerDTO{Note: "Ревью"})
+
+		assert.NoError(t, err)
+		assert.Nil(t, entry.EndedAt)
+		assert.Equal(t, int64(0), entry.DurationSeconds)
+	})
+
+	t.Run("already running", func(t *testing.T) {
+		mockRepo.EXPECT().GetTaskProject(gomock.Any(), taskID, userID).Return(uuid.New(), nil)
+		mockRepo.EXPECT().CreateTimeEntry(gomock.Any(), gomock.Any()).Return(errs.ErrTimerRunning)
+
+		_, err := uc.StartTimer(newTimeEntryContext(userID), taskID, dto.StartTimerDTO{})
+
+		assert.ErrorIs(t, err, errs.ErrTimerRunning)
+	})
+
+	t.Run("task not accessible", func(t *testing.T) {
+		mockRepo.EXPECT().GetTaskProject(gomock.Any(), taskID, userID).Return(uuid.Nil, errs.ErrTaskNotFound)
+
+		_, err := uc.StartTimer(newTimeEntryContext(userID), taskID, dto.StartTimerDTO{})
+
+		assert.ErrorIs(t, err, errs.ErrTaskNotFound)
+	})
+}
+
+func TestTimeEntryUsecase_AddTimeEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTimeEntryRepository(ctrl)
+	uc := New(mockRepo, mocks.NewMockTimeEntryProjectRepository(ctrl), mocks.NewMockTimeEntryUserRepository(ctrl))
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	startedAt := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	minutes := 90
+
+	mockRepo.EXPECT().GetTaskProject(gomock.Any(), taskID, userID).Return(uuid.New(), nil)
+	mockRepo.EXPECT().CreateTimeEntry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry *models.TimeEntry) error {
+			assert.Equal(t, startedAt.Add(90*time.Minute), *entry.EndedAt)
+			return nil
+		})
+
+	entry, err := uc.AddTimeEntry(newTimeEntryContext(userID), taskID, dto.CreateTimeEntryDTO{
+		StartedAt:       startedAt,
+		DurationMinutes: &minutes,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5400), entry.DurationSeconds)
+}
+
+func TestTimeEntryUsecase_GetTaskTimeEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTimeEntryRepository(ctrl)
+	uc := New(mockRepo, mocks.NewMockTimeEntryProjectRepository(ctrl), mocks.NewMockTimeEntryUserRepository(ctrl))
+
+	taskID := uuid.New()
+	userID := uuid.New()
+	startedAt := time.Now().UTC().Add(-3 * time.Hour)
+	endedAt := startedAt.Add(time.Hour)
+
+	mockRepo.EXPECT().GetTaskProject(gomock.Any(), taskID, userID).Return(uuid.New(), nil)
+	mockRepo.EXPECT().GetTaskTimeEntries(gomock.Any(), taskID).Return([]models.TimeEntry{
+		{ID: uuid.New(), TaskID: taskID, UserID: userID, StartedAt: time.Now().UTC().Add(-time.Minute)},
+		{ID: uuid.New(), TaskID: taskID, UserID: userID, StartedAt: startedAt, EndedAt: &endedAt},
+	}, nil)
+
+	result, err := uc.GetTaskTimeEntries(newTimeEntryContext(userID), taskID)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Entries, 2)
+	// Запущенный таймер в сумму не входит
+	assert.Equal(t, int64(3600), result.LoggedSeconds)
+	assert.Greater(t, result.Entries[0].DurationSeconds, int64(0))
+}
+
+func TestTimeEntryUsecase_GetTimeReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockTimeEntryRepository(ctrl)
+	mockProjectRepo := mocks.NewMockTimeEntryProjectRepository(ctrl)
+	mockUserRepo := mocks.NewMockTimeEntryUserRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo, mockUserRepo)
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)
+
+	t.Run("days in user timezone", func(t *testing.T) {
+		mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(true, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&usermodels.User{ID: userID, Timezone: "Europe/Moscow"}, nil)
+		// Москва UTC+3: период начинается 28 февраля в 21:00 UTC и заканчивается 7 марта в 21:00 UTC
+		mockRepo.EXPECT().GetTimeReport(gomock.Any(), userID, &projectID,
+			time.Date(2025, 2, 28, 21, 0, 0, 0, time.UTC),
+			time.Date(2025, 3, 7, 21, 0, 0, 0, time.UTC),
+			"Europe/Moscow",
+		).Return([]models.ReportRow{
+			{ProjectID: projectID, ProjectName: "Backend", UserID: userID, Login: "ivan", Day: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), Seconds: 5400},
+			{ProjectID: projectID, ProjectName: "Backend", UserID: userID, Login: "ivan", Day: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), Seconds: 1000},
+		}, nil)
+
+		report, err := uc.GetTimeReport(newTimeEntryContext(userID), &projectID, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-03-01", report.From)
+		assert.Equal(t, "2025-03-07", report.To)
+		assert.Equal(t, int64(6400), report.TotalSeconds)
+		assert.Equal(t, "2025-03-03", report.Rows[0].Date)
+		assert.Equal(t, 1.5, report.Rows[0].Hours)
+		assert.Equal(t, 0.28, report.Rows[1].Hours)
+	})
+
+	t.Run("no access to project", func(t *testing.T) {
+		mockProjectRepo.EXPECT().CheckProjectAccess(gomock.Any(), projectID, userID).Return(false, nil)
+
+		_, err := uc.GetTimeReport(newTimeEntryContext(userID), &projectID, from, to)
+
+		assert.ErrorIs(t, err, errs.ErrNoAccess)
+	})
+
+	t.Run("unknown timezone falls back to UTC", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(&usermodels.User{ID: userID, Timezone: "Mars/Olympus"}, nil)
+		mockRepo.EXPECT().GetTimeReport(gomock.Any(), userID, nil, from, to.AddDate(0, 0, 1), "UTC").Return(nil, nil)
+
+		report, err := uc.GetTimeReport(newTimeEntryContext(userID), nil, from, to)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "UTC", report.Timezone)
+		assert.Empty(t, report.Rows)
+	})
+}
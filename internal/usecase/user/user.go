@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/internal/models/errs"
 	models "github.com/lzimin05/course-todo/internal/models/user"
 	dto "github.com/lzimin05/course-todo/internal/transport/dto/user"
 	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
@@ -16,6 +17,7 @@ type UserRepository interface {
 	GetUserByEmail(context.Context, string) (*models.User, error)
 	GetUserByLogin(context.Context, string) (*models.User, error)
 	UpdateUsername(context.Context, uuid.UUID, string) error
+	UpdateTimezone(context.Context, uuid.UUID, string) error
 }
 
 type UserUsecase struct {
@@ -49,6 +51,7 @@ func (u *UserUsecase) GetMe(ctx context.Context) (*dto.UserDTO, error) {
 		Login:    user.Login,
 		Email:    user.Email,
 		Username: user.Username,
+		Timezone: user.Timezone,
 	}
 
 	return userDTO, nil
@@ -63,12 +66,17 @@ func (u *UserUsecase) GetUserByEmail(ctx context.Context, email string) (*dto.Us
 		logger.WithError(err).Error("get user by email from repository")
 		return nil, err
 	}
+	if user.ID == models.DeletedUserID {
+		logger.Warn("deleted user placeholder is not searchable")
+		return nil, errs.NewNotFoundError("user not found")
+	}
 
 	userDTO := &dto.UserDTO{
 		ID:       user.ID,
 		Login:    user.Login,
 		Email:    user.Email,
 		Username: user.Username,
+		Timezone: user.Timezone,
 	}
 
 	return userDTO, nil
@@ -83,12 +91,17 @@ func (u *UserUsecase) GetUserByLogin(ctx context.Context, login string) (*dto.Us
 		logger.WithError(err).Error("get user by login from repository")
 		return nil, err
 	}
+	if user.ID == models.DeletedUserID {
+		logger.Warn("deleted user placeholder is not searchable")
+		return nil, errs.NewNotFoundError("user not found")
+	}
 
 	userDTO := &dto.UserDTO{
 		ID:       user.ID,
 		Login:    user.Login,
 		Email:    user.Email,
 		Username: user.Username,
+		Timezone: user.Timezone,
 	}
 
 	return userDTO, nil
@@ -113,3 +126,23 @@ func (u *UserUsecase) UpdateUsername(ctx context.Context, username string) error
 	logger.Info("username updated successfully")
 	return nil
 }
+
+func (u *UserUsecase) UpdateTimezone(ctx context.Context, timezone string) error {
+	const op = "UserUsecase.UpdateTimezone"
+	logger := logctx.GetLogger(ctx).WithField("op", op)
+
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		logger.WithError(err).Error("invalid user ID format")
+		return err
+	}
+
+	err = u.repo.UpdateTimezone(ctx, userID, timezone)
+	if err != nil {
+		logger.WithError(err).Error("failed to update timezone in repository")
+		return err
+	}
+
+	logger.Info("timezone updated successfully")
+	return nil
+}
@@ -125,6 +125,17 @@ func TestUserUsecase_GetUserByLogin(t *testing.T) {
 			wantErr:  true,
 			errMsg:   "not found",
 		},
+		{
+			name:  "Deleted user placeholder",
+			login: "deleted-user",
+			mockFunc: func() {
+				user := &models.User{ID: models.DeletedUserID, Login: "deleted-user"}
+				mockUserRepo.EXPECT().GetUserByLogin(gomock.Any(), "deleted-user").Return(user, nil)
+			},
+			expected: nil,
+			wantErr:  true,
+			errMsg:   "not found",
+		},
 	}
 
 	for _, tt := range tests {
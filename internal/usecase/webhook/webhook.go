@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	outboxmodels "github.com/lzimin05/course-todo/internal/models/outbox"
+	projectmodels "github.com/lzimin05/course-todo/internal/models/project"
+	models "github.com/lzimin05/course-todo/internal/models/webhook"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/webhook"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const secretBytes = 32
+
+//go:generate mockgen -source=webhook.go -destination=../mocks/webhook_mocks.go -package=mocks WebhookRepository,WebhookProjectRepository,WebhookSender
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetWebhooks(ctx context.Context, projectID uuid.UUID) ([]models.Webhook, error)
+	GetWebhookByID(ctx context.Context, webhookID, projectID uuid.UUID) (*models.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *models.Webhook) error
+	DeleteWebhook(ctx context.Context, webhookID, projectID uuid.UUID) error
+	EnqueueDeliveries(ctx context.Context, projectID, eventID uuid.UUID, eventType string, payload []byte, now time.Time) (int64, error)
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, status string, limit int) ([]models.Delivery, error)
+	Redeliver(ctx context.Context, deliveryID, webhookID uuid.UUID, now time.Time) (*models.Delivery, error)
+	ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Job, error)
+	SaveAttempt(ctx context.Context, delivery *models.Delivery) error
+}
+
+type WebhookProjectRepository interface {
+	GetProjectByID(ctx context.Context, id uuid.UUID) (*projectmodels.Project, error)
+}
+
+// WebhookSender отправляет доставку получателю и возвращает код ответа.
+// Код 0 - ответ не получен
+type WebhookSender interface {
+	Send(ctx context.Context, job models.Job) (int, error)
+}
+
+type WebhookUsecase struct {
+	repo        WebhookRepository
+	projectRepo WebhookProjectRepository
+	sender      WebhookSender
+	cfg         *config.WebhookConfig
+}
+
+func New(repo WebhookRepository, projectRepo WebhookProjectRepository, sender WebhookSender, cfg *config.WebhookConfig) *WebhookUsecase {
+	return &WebhookUsecase{
+		repo:        repo,
+		projectRepo: projectRepo,
+		sender:      sender,
+		cfg:         cfg,
+	}
+}
+
+// CreateWebhook регистрирует вебхук. Секрет для проверки подписи генерируется
+// сервером и показывается только в ответе на создание
+func (uc *WebhookUsecase) CreateWebhook(ctx context.Context, projectID uuid.UUID, req *dto.PostWebhookDTO) (*dto.WebhookDTO, error) {
+	const op = "WebhookUsecase.CreateWebhook"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	userID, err := uc.checkOwner(ctx, projectID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to check project owner")
+		return nil, err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		logger.WithError(err).Error("failed to generate webhook secret")
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now().UTC()
+	webhook := &models.Webhook{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		CreatedBy: userID,
+		URL:       req.URL,
+		Secret:    secret,
+		Events:    req.Events,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.repo.CreateWebhook(ctx, webhook); err != nil {
+		logger.WithError(err).Error("failed to create webhook")
+		return nil, err
+	}
+
+	result := toWebhookDTO(webhook)
+	result.Secret = secret
+	return result, nil
+}
+
+func (uc *WebhookUsecase) GetWebhooks(ctx context.Context, projectID uuid.UUID) ([]*dto.WebhookDTO, error) {
+	const op = "WebhookUsecase.GetWebhooks"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("projectID", projectID)
+
+	if _, err := uc.checkOwner(ctx, projectID); err != nil {
+		logger.WithError(err).Warn("failed to check project owner")
+		return nil, err
+	}
+
+	webhooks, err := uc.repo.GetWebhooks(ctx, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get webhooks")
+		return nil, err
+	}
+
+	result := make([]*dto.WebhookDTO, len(webhooks))
+	for i := range webhooks {
+		result[i] = toWebhookDTO(&webhooks[i])
+	}
+	return result, nil
+}
+
+func (uc *WebhookUsecase) UpdateWebhook(ctx context.Context, projectID, webhookID uuid.UUID, req *dto.UpdateWebhookDTO) (*dto.WebhookDTO, error) {
+	const op = "WebhookUsecase.UpdateWebhook"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("webhookID", webhookID)
+
+	if _, err := uc.checkOwner(ctx, projectID); err != nil {
+		logger.WithError(err).Warn("failed to check project owner")
+		return nil, err
+	}
+
+	webhook, err := uc.repo.GetWebhookByID(ctx, webhookID, projectID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get webhook")
+		return nil, err
+	}
+
+	if req.URL != nil {
+		webhook.URL = *req.URL
+	}
+	if req.Events != nil {
+		webhook.Events = req.Events
+	}
+	if req.IsActive != nil {
+		webhook.IsActive = *req.IsActive
+	}
+	webhook.UpdatedAt = time.Now().UTC()
+
+	if err := uc.repo.UpdateWebhook(ctx, webhook); err != nil {
+		logger.WithError(err).Error("failed to update webhook")
+		return nil, err
+	}
+
+	return toWebhookDTO(webhook), nil
+}
+
+func (uc *WebhookUsecase) DeleteWebhook(ctx context.Context, projectID, webhookID uuid.UUID) error {
+	const op = "WebhookUsecase.DeleteWebhook"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("webhookID", webhookID)
+
+	if _, err := uc.checkOwner(ctx, projectID); err != nil {
+		logger.WithError(err).Warn("failed to check project owner")
+		return err
+	}
+
+	if err := uc.repo.DeleteWebhook(ctx, webhookID, projectID); err != nil {
+		logger.WithError(err).Error("failed to delete webhook")
+		return err
+	}
+
+	return nil
+}
+
+// GetDeliveries возвращает журнал доставок вебхука, новые сначала
+func (uc *WebhookUsecase) GetDeliveries(ctx context.Context, projectID, webhookID uuid.UUID, status string, limit int) ([]*dto.DeliveryDTO, error) {
+	const op = "WebhookUsecase.GetDeliveries"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("webhookID", webhookID)
+
+	if _, err := uc.checkOwner(ctx, projectID); err != nil {
+		logger.WithError(err).Warn("failed to check project owner")
+		return nil, err
+	}
+
+	if _, err := uc.repo.GetWebhookByID(ctx, webhookID, projectID); err != nil {
+		logger.WithError(err).Error("failed to get webhook")
+		return nil, err
+	}
+
+	deliveries, err := uc.repo.GetDeliveries(ctx, webhookID, status, limit)
+	if err != nil {
+		logger.WithError(err).Error("failed to get deliveries")
+		return nil, err
+	}
+
+	result := make([]*dto.DeliveryDTO, len(deliveries))
+	for i := range deliveries {
+		result[i] = toDeliveryDTO(&deliveries[i])
+	}
+	return result, nil
+}
+
+// Redeliver ставит событие из журнала в очередь повторно. Создается новая
+// доставка с тем же ID события, старая остается в журнале как есть
+func (uc *WebhookUsecase) Redeliver(ctx context.Context, projectID, webhookID, deliveryID uuid.UUID) (*dto.DeliveryDTO, error) {
+	const op = "WebhookUsecase.Redeliver"
+	logger := logctx.GetLogger(ctx).WithField("op", op).WithField("deliveryID", deliveryID)
+
+	if _, err := uc.checkOwner(ctx, projectID); err != nil {
+		logger.WithError(err).Warn("failed to check project owner")
+		return nil, err
+	}
+
+	if _, err := uc.repo.GetWebhookByID(ctx, webhookID, projectID); err != nil {
+		logger.WithError(err).Error("failed to get webhook")
+		return nil, err
+	}
+
+	delivery, err := uc.repo.Redeliver(ctx, deliveryID, webhookID, time.Now().UTC())
+	if err != nil {
+		logger.WithError(err).Error("failed to redeliver")
+		return nil, err
+	}
+
+	return toDeliveryDTO(delivery), nil
+}
+
+// HandleMessage ставит событие из outbox в очередь доставок всех подписанных
+// вебхуков проекта. Подписчик шины событий: ошибка вернет событие на повторную
+// публикацию, а повтор уже поставленного события ничего не добавит
+func (uc *WebhookUsecase) HandleMessage(ctx context.Context, msg outboxmodels.Message) error {
+	const op = "WebhookUsecase.HandleMessage"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("eventType", msg.Type).
+		WithField("eventID", msg.ID)
+
+	if !eventmodels.IsValidType(msg.Type) {
+		logger.Debug("event type is not available for webhooks")
+		return nil
+	}
+
+	count, err := uc.repo.EnqueueDeliveries(ctx, msg.ProjectID, msg.ID, msg.Type, msg.Payload, time.Now().UTC())
+	if err != nil {
+		logger.WithError(err).Error("failed to enqueue deliveries")
+		return err
+	}
+
+	logger.WithField("deliveries", count).Debug("event enqueued")
+	return nil
+}
+
+func (uc *WebhookUsecase) checkOwner(ctx context.Context, projectID uuid.UUID) (uuid.UUID, error) {
+	userID, err := helpers.GetUserIDFromContext(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	project, err := uc.projectRepo.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if project.OwnerID != userID {
+		return uuid.Nil, errs.ErrNotOwner
+	}
+
+	return userID, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func toWebhookDTO(webhook *models.Webhook) *dto.WebhookDTO {
+	return &dto.WebhookDTO{
+		ID:        webhook.ID,
+		ProjectID: webhook.ProjectID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		IsActive:  webhook.IsActive,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+func toDeliveryDTO(delivery *models.Delivery) *dto.DeliveryDTO {
+	return &dto.DeliveryDTO{
+		ID:            delivery.ID,
+		WebhookID:     delivery.WebhookID,
+		EventID:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Status:        delivery.Status,
+		Attempts:      delivery.Attempts,
+		ResponseCode:  delivery.ResponseCode,
+		LastError:     delivery.LastError,
+		NextAttemptAt: delivery.NextAttemptAt,
+		LastAttemptAt: delivery.LastAttemptAt,
+		DeliveredAt:   delivery.DeliveredAt,
+		CreatedAt:     delivery.CreatedAt,
+		Payload:       delivery.Payload,
+	}
+}
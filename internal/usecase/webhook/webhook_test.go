@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/config"
+	"github.com/lzimin05/course-todo/internal/models/domains"
+	"github.com/lzimin05/course-todo/internal/models/errs"
+	eventmodels "github.com/lzimin05/course-todo/internal/models/event"
+	outboxmodels "github.com/lzimin05/course-todo/internal/models/outbox"
+	projectmodels "github.com/lzimin05/course-todo/internal/models/project"
+	models "github.com/lzimin05/course-todo/internal/models/webhook"
+	dto "github.com/lzimin05/course-todo/internal/transport/dto/webhook"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func newTestConfig() *config.WebhookConfig {
+	return &config.WebhookConfig{
+		MaxAttempts:  3,
+		RetryBase:    time.Second,
+		RetryMax:     10 * time.Second,
+		Timeout:      time.Second,
+		PollInterval: time.Second,
+		BatchSize:    10,
+	}
+}
+
+func TestWebhookUsecase_CreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockProjectRepo := mocks.NewMockWebhookProjectRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo, mocks.NewMockWebhookSender(ctrl), newTestConfig())
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	ctx := logctx.WithLogger(context.WithValue(context.Background(), domains.UserIDKey{}, userID.String()), logctx.NewLogger())
+
+	req := &dto.PostWebhookDTO{URL: "https://example.com/hook", Events: []string{eventmodels.TypeTaskCreated}}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mockProjectRepo.EXPECT().GetProjectByID(gomock.Any(), projectID).
+			Return(&projectmodels.Project{ID: projectID, OwnerID: userID}, nil)
+		mockRepo.EXPECT().CreateWebhook(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, webhook *models.Webhook) error {
+				assert.Equal(t, projectID, webhook.ProjectID)
+				assert.Equal(t, userID, webhook.CreatedBy)
+				assert.True(t, webhook.IsActive)
+				assert.Len(t, webhook.Secret, 2*secretBytes)
+				return nil
+			})
+
+		result, err := uc.CreateWebhook(ctx, projectID, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, req.URL, result.URL)
+		assert.NotEmpty(t, result.Secret)
+	})
+
+	t.Run("not owner", func(t *testing.T) {
+		mockProjectRepo.EXPECT().GetProjectByID(gomock.Any(), projectID).
+			Return(&projectmodels.Project{ID: projectID, OwnerID: uuid.New()}, nil)
+
+		result, err := uc.CreateWebhook(ctx, projectID, req)
+
+		assert.ErrorIs(t, err, errs.ErrNotOwner)
+		assert.Nil(t, result)
+	})
+}
+
+func TestWebhookUsecase_UpdateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockProjectRepo := mocks.NewMockWebhookProjectRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo, mocks.NewMockWebhookSender(ctrl), newTestConfig())
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	webhookID := uuid.New()
+	ctx := logctx.WithLogger(context.WithValue(context.Background(), domains.UserIDKey{}, userID.String()), logctx.NewLogger())
+
+	inactive := false
+	mockProjectRepo.EXPECT().GetProjectByID(gomock.Any(), projectID).
+		Return(&projectmodels.Project{ID: projectID, OwnerID: userID}, nil)
+	mockRepo.EXPECT().GetWebhookByID(gomock.Any(), webhookID, projectID).
+		Return(&models.Webhook{ID: webhookID, ProjectID: projectID, URL: "https://example.com/old",
+			Secret: "secret", Events: []string{eventmodels.TypeTaskCreated}, IsActive: true}, nil)
+	mockRepo.EXPECT().UpdateWebhook(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, webhook *models.Webhook) error {
+			assert.Equal(t, "https://example.com/old", webhook.URL)
+			assert.False(t, webhook.IsActive)
+			return nil
+		})
+
+	result, err := uc.UpdateWebhook(ctx, projectID, webhookID, &dto.UpdateWebhookDTO{IsActive: &inactive})
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsActive)
+	assert.Empty(t, result.Secret)
+}
+
+func TestWebhookUsecase_Redeliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockProjectRepo := mocks.NewMockWebhookProjectRepository(ctrl)
+	uc := New(mockRepo, mockProjectRepo, mocks.NewMockWebhookSender(ctrl), newTestConfig())
+
+	userID := uuid.New()
+	projectID := uuid.New()
+	webhookID := uuid.New()
+	deliveryID := uuid.New()
+	ctx := logctx.WithLogger(context.WithValue(context.Background(), domains.UserIDKey{}, userID.String()), logctx.NewLogger())
+
+	t.Run("successful redelivery", func(t *testing.T) {
+		mockProjectRepo.EXPECT().GetProjectByID(gomock.Any(), projectID).
+			Return(&projectmodels.Project{ID: projectID, OwnerID: userID}, nil)
+		mockRepo.EXPECT().GetWebhookByID(gomock.Any(), webhookID, projectID).Return(&models.Webhook{ID: webhookID}, nil)
+		mockRepo.EXPECT().Redeliver(gomock.Any(), deliveryID, webhookID, gomock.Any()).
+			Return(&models.Delivery{ID: uuid.New(), WebhookID: webhookID, Status: models.DeliveryStatusPending}, nil)
+
+		result, err := uc.Redeliver(ctx, projectID, webhookID, deliveryID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.DeliveryStatusPending, result.Status)
+	})
+
+	t.Run("webhook of another project", func(t *testing.T) {
+		mockProjectRepo.EXPECT().GetProjectByID(gomock.Any(), projectID).
+			Return(&projectmodels.Project{ID: projectID, OwnerID: userID}, nil)
+		mockRepo.EXPECT().GetWebhookByID(gomock.Any(), webhookID, projectID).
+			Return(nil, errs.NewNotFoundError("webhook not found"))
+
+		result, err := uc.Redeliver(ctx, projectID, webhookID, deliveryID)
+
+		assert.ErrorIs(t, err, errs.ErrNotFound)
+		assert.Nil(t, result)
+	})
+}
+
+func TestWebhookUsecase_HandleMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	uc := New(mockRepo, mocks.NewMockWebhookProjectRepository(ctrl), mocks.NewMockWebhookSender(ctrl), newTestConfig())
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	projectID := uuid.New()
+	event := eventmodels.New(eventmodels.TypeMemberAdded, projectID, uuid.New(),
+		eventmodels.MemberData{ProjectID: projectID, UserID: uuid.New()})
+	payload, err := json.Marshal(event)
+	assert.NoError(t, err)
+	msg := outboxmodels.Message{ID: event.ID, Type: event.Type, ProjectID: projectID, Payload: payload}
+
+	t.Run("enqueued with outbox payload", func(t *testing.T) {
+		mockRepo.EXPECT().EnqueueDeliveries(gomock.Any(), projectID, event.ID, eventmodels.TypeMemberAdded, payload, gomock.Any()).
+			Return(int64(1), nil)
+
+		assert.NoError(t, uc.HandleMessage(ctx, msg))
+	})
+
+	t.Run("enqueue error is returned for retry", func(t *testing.T) {
+		mockRepo.EXPECT().EnqueueDeliveries(gomock.Any(), projectID, event.ID, eventmodels.TypeMemberAdded, payload, gomock.Any()).
+			Return(int64(0), errors.New("db error"))
+
+		assert.Error(t, uc.HandleMessage(ctx, msg))
+	})
+
+	t.Run("unknown type is skipped", func(t *testing.T) {
+		assert.NoError(t, uc.HandleMessage(ctx, outboxmodels.Message{ID: uuid.New(), Type: "project.archived", ProjectID: projectID}))
+	})
+}
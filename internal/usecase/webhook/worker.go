@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	models "github.com/lzimin05/course-todo/internal/models/webhook"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/helpers"
+)
+
+const (
+	// Запас аренды сверх таймаута запроса: доставку, зависшую у упавшего
+	// воркера, подхватит другой после истечения аренды
+	claimLeaseMargin = time.Minute
+
+	maxErrorLength = 500
+)
+
+// Run раз в PollInterval забирает созревшие доставки и отправляет их.
+// Завершается при отмене контекста
+func (uc *WebhookUsecase) Run(ctx context.Context) {
+	logger := logctx.GetLogger(ctx).WithField("op", "WebhookUsecase.Run")
+
+	ticker := time.NewTicker(uc.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Полная пачка - в очереди, вероятно, есть еще, забираем без паузы
+		for {
+			count, err := uc.DeliverDue(ctx)
+			if err != nil {
+				logger.WithError(err).Error("failed to deliver webhooks")
+				break
+			}
+			if count < uc.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue отправляет одну пачку созревших доставок и возвращает ее размер
+func (uc *WebhookUsecase) DeliverDue(ctx context.Context) (int, error) {
+	const op = "WebhookUsecase.DeliverDue"
+
+	now := time.Now().UTC()
+	jobs, err := uc.repo.ClaimDeliveries(ctx, now, now.Add(uc.cfg.Timeout+claimLeaseMargin), uc.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(job models.Job) {
+			defer wg.Done()
+			uc.deliver(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+
+	return len(jobs), nil
+}
+
+func (uc *WebhookUsecase) deliver(ctx context.Context, job models.Job) {
+	const op = "WebhookUsecase.deliver"
+	logger := logctx.GetLogger(ctx).WithField("op", op).
+		WithField("deliveryID", job.ID).
+		WithField("webhookID", job.WebhookID)
+
+	code, err := uc.sender.Send(ctx, job)
+
+	delivery := job.Delivery
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseCode = nil
+	if code != 0 {
+		delivery.ResponseCode = &code
+	}
+
+	switch {
+	case err == nil && code >= 200 && code < 300:
+		delivery.Status = models.DeliveryStatusDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	default:
+		if err != nil {
+			delivery.LastError = helpers.Truncate(err.Error(), maxErrorLength)
+		} else {
+			delivery.LastError = fmt.Sprintf("unexpected response status %d", code)
+		}
+		if delivery.Attempts >= uc.cfg.MaxAttempts {
+			delivery.Status = models.DeliveryStatusFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(uc.backoff(delivery.Attempts))
+		}
+		logger.WithField("attempts", delivery.Attempts).Warn("webhook delivery failed: ", delivery.LastError)
+	}
+
+	if err := uc.repo.SaveAttempt(ctx, &delivery); err != nil {
+		logger.WithError(err).Error("failed to save delivery attempt")
+	}
+}
+
+// backoff - пауза после attempts неудачных попыток: RetryBase * 2^(attempts-1),
+// но не больше RetryMax
+func (uc *WebhookUsecase) backoff(attempts int) time.Duration {
+	delay := uc.cfg.RetryBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= uc.cfg.RetryMax {
+			return uc.cfg.RetryMax
+		}
+	}
+	if delay > uc.cfg.RetryMax {
+		return uc.cfg.RetryMax
+	}
+	return delay
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lzimin05/course-todo/internal/infrastructure/webhook"
+	models "github.com/lzimin05/course-todo/internal/models/webhook"
+	"github.com/lzimin05/course-todo/internal/transport/middleware/logctx"
+	"github.com/lzimin05/course-todo/internal/usecase/mocks"
+)
+
+func TestWebhookUsecase_Backoff(t *testing.T) {
+	uc := New(nil, nil, nil, newTestConfig())
+
+	assert.Equal(t, time.Second, uc.backoff(1))
+	assert.Equal(t, 2*time.Second, uc.backoff(2))
+	assert.Equal(t, 8*time.Second, uc.backoff(4))
+	assert.Equal(t, 10*time.Second, uc.backoff(5))
+	assert.Equal(t, 10*time.Second, uc.backoff(100))
+}
+
+func TestWebhookUsecase_DeliverDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	mockSender := mocks.NewMockWebhookSender(ctrl)
+	uc := New(mockRepo, mocks.NewMockWebhookProjectRepository(ctrl), mockSender, newTestConfig())
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	tests := []struct {
+		name      string
+		attempts  int
+		code      int
+		sendErr   error
+		checkSave func(t *testing.T, d *models.Delivery)
+	}{
+		{
+			name: "delivered",
+			code: http.StatusOK,
+			checkSave: func(t *testing.T, d *models.Delivery) {
+				assert.Equal(t, models.DeliveryStatusDelivered, d.Status)
+				assert.Equal(t, 1, d.Attempts)
+				assert.NotNil(t, d.DeliveredAt)
+				assert.Equal(t, http.StatusOK, *d.ResponseCode)
+			},
+		},
+		{
+			name: "server error is retried",
+			code: http.StatusBadGateway,
+			checkSave: func(t *testing.T, d *models.Delivery) {
+				assert.Equal(t, models.DeliveryStatusPending, d.Status)
+				assert.Equal(t, "unexpected response status 502", d.LastError)
+				assert.WithinDuration(t, time.Now().Add(time.Second), d.NextAttemptAt, 500*time.Millisecond)
+			},
+		},
+		{
+			name:     "network error on last attempt",
+			attempts: 2,
+			sendErr:  errors.New("connection refused"),
+			checkSave: func(t *testing.T, d *models.Delivery) {
+				assert.Equal(t, models.DeliveryStatusFailed, d.Status)
+				assert.Equal(t, 3, d.Attempts)
+				assert.Nil(t, d.ResponseCode)
+				assert.Equal(t, "connection refused", d.LastError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := models.Job{
+				Delivery: models.Delivery{ID: uuid.New(), Status: models.DeliveryStatusPending, Attempts: tt.attempts},
+				URL:      "https://example.com/hook",
+			}
+			mockRepo.EXPECT().ClaimDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]models.Job{job}, nil)
+			mockSender.EXPECT().Send(gomock.Any(), job).Return(tt.code, tt.sendErr)
+			mockRepo.EXPECT().SaveAttempt(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, d *models.Delivery) error {
+					tt.checkSave(t, d)
+					return nil
+				})
+
+			count, err := uc.DeliverDue(ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count)
+		})
+	}
+}
+
+// Доставка до локального получателя: первая попытка получает 500,
+// повторная проходит, подпись сверяется на стороне получателя
+func TestWebhookUsecase_DeliverToReceiver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var calls int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(models.HeaderTimestamp), 10, 64)
+		if !models.Verify("secret", timestamp, body, r.Header.Get(models.HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	// Получатель слушает loopback
+	cfg := newTestConfig()
+	cfg.AllowPrivateNetworks = true
+	mockRepo := mocks.NewMockWebhookRepository(ctrl)
+	uc := New(mockRepo, mocks.NewMockWebhookProjectRepository(ctrl), webhook.NewHTTPSender(cfg), cfg)
+	ctx := logctx.WithLogger(context.Background(), logctx.NewLogger())
+
+	job := models.Job{
+		Delivery: models.Delivery{ID: uuid.New(), EventType: "task.created", Payload: []byte(`{"id":"1"}`),
+			Status: models.DeliveryStatusPending},
+		URL:    receiver.URL,
+		Secret: "secret",
+	}
+
+	var saved models.Delivery
+	mockRepo.EXPECT().ClaimDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, time.Time, time.Time, int) ([]models.Job, error) {
+			return []models.Job{job}, nil
+		}).Times(2)
+	mockRepo.EXPECT().SaveAttempt(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *models.Delivery) error {
+			saved = *d
+			job.Delivery = *d
+			return nil
+		}).Times(2)
+
+	_, err := uc.DeliverDue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DeliveryStatusPending, saved.Status)
+	assert.Equal(t, http.StatusInternalServerError, *saved.ResponseCode)
+
+	_, err = uc.DeliverDue(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DeliveryStatusDelivered, saved.Status)
+	assert.Equal(t, 2, saved.Attempts)
+	assert.Empty(t, saved.LastError)
+}